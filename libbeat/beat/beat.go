@@ -63,6 +63,7 @@ type Beat struct {
 	InSetupCmd bool // this is set to true when the `setup` command is called
 
 	OverwritePipelinesCallback OverwritePipelinesCallback // ingest pipeline loader callback
+	DashboardsFilter           DashboardsFilter           // selects the dashboards loaded by the setup command
 	// XXX: remove Config from public interface.
 	//      It's currently used by filebeat modules to setup the Ingest Node
 	//      pipeline and ML jobs.
@@ -123,3 +124,8 @@ type BeatConfig struct {
 // OverwritePipelinesCallback can be used by the Beat to register Ingest pipeline loader
 // for the enabled modules.
 type OverwritePipelinesCallback func(*config.C) error
+
+// DashboardsFilter can be used by the Beat to select the Kibana dashboards
+// loaded by the setup command by their title. The dashboards that are not
+// selected are removed from Kibana.
+type DashboardsFilter func(title string) bool
@@ -1054,7 +1054,7 @@ func (b *Beat) loadDashboards(ctx context.Context, force bool) error {
 		}
 
 		err = dashboards.ImportDashboards(ctx, b.Info, paths.Resolve(paths.Home, ""),
-			kibanaConfig, b.Config.Dashboards, nil, pattern)
+			kibanaConfig, b.Config.Dashboards, nil, pattern, b.DashboardsFilter)
 		if err != nil {
 			return fmt.Errorf("error importing Kibana dashboards: %w", err)
 		}
@@ -17,7 +17,11 @@
 
 package dashboards
 
-import "time"
+import (
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+)
 
 // Config represents the config values for dashboards
 type Config struct {
@@ -33,6 +37,10 @@ type Config struct {
 	AlwaysKibana       bool              `config:"always_kibana"`
 	Retry              *Retry            `config:"retry"`
 	StringReplacements map[string]string `config:"string_replacements"`
+
+	// Filter selects the dashboards to import by their title, the others are
+	// removed from Kibana. All the dashboards are imported when it is nil.
+	Filter beat.DashboardsFilter `config:",ignore"`
 }
 
 // Retry handles query retries
@@ -29,13 +29,15 @@ import (
 	"github.com/elastic/elastic-agent-libs/version"
 )
 
-// ImportDashboards tries to import the kibana dashboards.
+// ImportDashboards tries to import the kibana dashboards. If filter is not nil,
+// only the dashboards it selects are imported, and the others are removed.
 func ImportDashboards(
 	ctx context.Context,
 	beatInfo beat.Info, homePath string,
 	kibanaConfig, dashboardsConfig *config.C,
 	msgOutputter MessageOutputter,
 	pattern mapstr.M,
+	filter beat.DashboardsFilter,
 ) error {
 	if dashboardsConfig == nil || !dashboardsConfig.Enabled() {
 		return nil
@@ -49,6 +51,7 @@ func ImportDashboards(
 	if err != nil {
 		return err
 	}
+	dashConfig.Filter = filter
 
 	if !kibanaConfig.Enabled() {
 		return errors.New("kibana configuration missing for loading dashboards")
@@ -19,6 +19,7 @@ package dashboards
 
 import (
 	"archive/zip"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -123,18 +124,57 @@ func (imp Importer) ImportDir(dirType string, dir string) error {
 	if len(files) == 0 {
 		return fmt.Errorf("The directory %s is empty, nothing to import", dir)
 	}
+	var stale []string
 	for _, file := range files {
+		if dirType == "dashboard" && imp.cfg.Filter != nil {
+			selected, err := imp.selectDashboard(file)
+			if err != nil {
+				errors = append(errors, fmt.Sprintf("  error loading %s: %s", file, err))
+				continue
+			}
+			if !selected {
+				stale = append(stale, file)
+				continue
+			}
+		}
 		err = imp.ImportFile(dirType, file)
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("  error loading %s: %s", file, err))
 		}
 	}
+	// Stale dashboards are removed after the selected ones are imported, so
+	// the assets they share with them are kept.
+	for _, file := range stale {
+		imp.loader.statusMsg("Remove dashboard %s", file)
+		err = imp.loader.RemoveDashboard(file)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("  error removing %s: %s", file, err))
+		}
+	}
 	if len(errors) > 0 {
 		return fmt.Errorf("Failed to load directory %s:\n%s", dir, strings.Join(errors, "\n"))
 	}
 	return nil
 }
 
+// selectDashboard reads the title of a dashboard file and returns whether the
+// filter of the configuration selects it.
+func (imp Importer) selectDashboard(file string) (bool, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+	var dashboard struct {
+		Attributes struct {
+			Title string `json:"title"`
+		} `json:"attributes"`
+	}
+	if err := json.Unmarshal(content, &dashboard); err != nil {
+		return false, fmt.Errorf("failed to parse dashboard: %w", err)
+	}
+	return imp.cfg.Filter(dashboard.Attributes.Title), nil
+}
+
 func (imp Importer) unzip(archive, target string) error {
 	imp.loader.statusMsg("Unzip archive %s", target)
 
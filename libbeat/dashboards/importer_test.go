@@ -0,0 +1,113 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package dashboards
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestImportKibanaDirWithFilter(t *testing.T) {
+	dir := t.TempDir()
+	writeAsset := func(assetType, id, title string, references ...string) {
+		var refs []string
+		for _, ref := range references {
+			refs = append(refs, fmt.Sprintf(`{"id": %q, "name": "panel", "type": "visualization"}`, ref))
+		}
+		refs = append(refs, `{"id": "metricbeat-*", "name": "index", "type": "index-pattern"}`)
+		path := filepath.Join(dir, "7", assetType, id+".json")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf(
+			`{"id": %q, "type": %q, "attributes": {"title": %q}, "references": [%s]}`,
+			id, assetType, title, strings.Join(refs, ", "))), 0o600))
+	}
+	writeAsset("visualization", "shared", "Shared")
+	writeAsset("visualization", "nginx-vis", "Nginx")
+	writeAsset("visualization", "redis-vis", "Redis")
+	writeAsset("dashboard", "nginx", "[Metricbeat Nginx] Overview", "nginx-vis", "shared")
+	writeAsset("dashboard", "redis", "[Metricbeat Redis] Overview", "redis-vis", "shared")
+
+	var mu sync.Mutex
+	var imported, removed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/api/status":
+			fmt.Fprint(w, `{"version": {"number": "8.15.0"}}`)
+		case req.Method == http.MethodPost && req.URL.Path == importAPI:
+			file, _, err := req.FormFile("file")
+			require.NoError(t, err)
+			content, err := io.ReadAll(file)
+			require.NoError(t, err)
+			for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+				var asset struct {
+					ID string `json:"id"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(line), &asset))
+				imported = append(imported, asset.ID)
+			}
+			fmt.Fprint(w, `{"success": true}`)
+		case req.Method == http.MethodDelete && strings.HasPrefix(req.URL.Path, savedObjectsAPI+"/"):
+			removed = append(removed, strings.TrimPrefix(req.URL.Path, savedObjectsAPI+"/"))
+			if strings.HasSuffix(req.URL.Path, "/redis-vis") {
+				// Assets that don't exist are ignored.
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"statusCode": 404, "error": "Not Found"}`)
+				return
+			}
+			fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &Config{
+		Retry: &Retry{},
+		Filter: func(title string) bool {
+			return strings.HasPrefix(title, "[Metricbeat Nginx]")
+		},
+	}
+	loader, err := NewKibanaLoader(context.Background(), config.MustNewConfigFrom(map[string]interface{}{
+		"host": server.URL,
+	}), cfg, "localhost", nil, "metricbeat")
+	require.NoError(t, err)
+	importer, err := NewImporter(loader.version, cfg, *loader, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, importer.ImportKibanaDir(dir))
+	assert.ElementsMatch(t, []string{"nginx-vis", "shared", "nginx"}, imported)
+	assert.Equal(t, []string{"visualization/redis-vis", "dashboard/redis"}, removed)
+}
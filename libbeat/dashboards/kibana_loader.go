@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"net/url"
 	"path/filepath"
 	"time"
@@ -44,6 +45,9 @@ var (
 	// the base path of the saved objects API
 	// On serverless, you must add an x-elastic-internal-header to reach this API
 	importAPI = "/api/saved_objects/_import"
+
+	// the base path of the saved objects API, to remove saved objects
+	savedObjectsAPI = "/api/saved_objects"
 )
 
 // KibanaLoader loads Kibana files
@@ -55,7 +59,8 @@ type KibanaLoader struct {
 	msgOutputter  MessageOutputter
 	defaultLogger *logp.Logger
 
-	loadedAssets map[string]bool
+	loadedAssets  map[string]bool
+	removedAssets map[string]bool
 }
 
 // NewKibanaLoader creates a new loader to load Kibana files
@@ -77,6 +82,7 @@ func NewKibanaLoader(ctx context.Context, cfg *config.C, dashboardsConfig *Confi
 		msgOutputter:  msgOutputter,
 		defaultLogger: logp.NewLogger("dashboards"),
 		loadedAssets:  make(map[string]bool, 0),
+		removedAssets: make(map[string]bool, 0),
 	}
 
 	version := client.GetVersion()
@@ -227,6 +233,68 @@ func (loader KibanaLoader) addReferences(path string, dashboard []byte) (string,
 	return result, nil
 }
 
+// RemoveDashboard removes the dashboard of the file from Kibana, with the
+// assets it references that have not been imported. Assets that are not found
+// in Kibana are ignored.
+func (loader KibanaLoader) RemoveDashboard(file string) error {
+	if loader.version.LessThan(minimumRequiredVersionSavedObjects) {
+		return fmt.Errorf("Kibana version must be at least " + minimumRequiredVersionSavedObjects.String())
+	}
+
+	loader.statusMsg("Removing dashboard from %s", file)
+
+	return loader.removeAsset(file)
+}
+
+func (loader KibanaLoader) removeAsset(path string) error {
+	if loader.loadedAssets[path] || loader.removedAssets[path] {
+		return nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("fail to read asset from file %s: %w", path, err)
+	}
+	content = loader.formatDashboardAssets(content)
+
+	var asset struct {
+		ID         string               `json:"id"`
+		Type       string               `json:"type"`
+		References []dashboardReference `json:"references"`
+	}
+	if err := json.Unmarshal(content, &asset); err != nil {
+		return fmt.Errorf("failed to parse asset %s: %w", path, err)
+	}
+
+	base := filepath.Dir(path)
+	for _, ref := range asset.References {
+		if ref.Type == "index-pattern" {
+			continue
+		}
+		if err := loader.removeAsset(filepath.Join(base, "..", ref.Type, ref.ID+".json")); err != nil {
+			return err
+		}
+	}
+
+	headers := http.Header{}
+	if serverless, _ := loader.client.KibanaIsServerless(); serverless {
+		headers.Add("x-elastic-internal-origin", "beats")
+	}
+	statusCode, response, err := loader.client.Connection.Request(http.MethodDelete,
+		savedObjectsAPI+"/"+url.PathEscape(asset.Type)+"/"+url.PathEscape(asset.ID), nil, headers, nil)
+	if statusCode != http.StatusNotFound {
+		if err != nil {
+			return fmt.Errorf("returned %d to remove %s %s: %w. Response: %s", statusCode, asset.Type, asset.ID, err, response)
+		}
+		if statusCode >= 300 {
+			return fmt.Errorf("returned %d to remove %s %s. Response: %s", statusCode, asset.Type, asset.ID, response)
+		}
+	}
+
+	loader.removedAssets[path] = true
+	return nil
+}
+
 func (loader KibanaLoader) formatDashboardAssets(content []byte) []byte {
 	content = ReplaceIndexInDashboardObject(loader.config.Index, content)
 	content = EncodeJSONObjects(content)
@@ -0,0 +1,280 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package prometheus serves the internal metrics of a Beat in the Prometheus
+// text exposition format.
+package prometheus
+
+import (
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/monitoring/report"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// keyLabel is the label added to the series whose name and labels collide
+// with the ones of other series, with the key of their metric.
+const keyLabel = "key"
+
+// Option configures the handler.
+type Option func(*handler)
+
+// WithLabels converts the metrics whose keys match the pattern to metrics
+// with labels. The pattern is a dot separated list of the elements of the
+// keys, and elements in braces are the names of the labels whose values are
+// the elements at their position. For example, with the pattern
+// "metricbeat.{module}.{metricset}", the metric with key
+// "metricbeat.system.cpu.success" is reported as
+// metricbeat_success_total{module="system",metricset="cpu"}. Patterns are
+// tried in the order they are given, the first one that matches is used.
+func WithLabels(pattern string) Option {
+	return func(h *handler) {
+		h.patterns = append(h.patterns, strings.Split(pattern, "."))
+	}
+}
+
+type handler struct {
+	registry *monitoring.Registry
+	patterns [][]string
+	logger   *logp.Logger
+}
+
+// NewHandler returns an HTTP handler that serves the metrics of the given
+// registry in the Prometheus text exposition format. Metric names are the
+// keys of the metrics, without the elements converted to labels, with every
+// character not allowed by Prometheus, like dots, replaced by underscores.
+// Gauges are reported as such, other metrics are reported as counters with
+// the _total suffix. String metrics are not reported. Series whose names and
+// labels collide once converted are all reported, with their key as label.
+func NewHandler(registry *monitoring.Registry, opts ...Option) http.Handler {
+	h := &handler{registry: registry, logger: logp.NewLogger("prometheus")}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var b strings.Builder
+	var family string
+	for _, s := range h.collect() {
+		if s.name != family {
+			b.WriteString("# TYPE " + s.name + " " + s.typ + "\n")
+			family = s.name
+		}
+		b.WriteString(s.name + formatLabels(s.labels) + " " + s.value + "\n")
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	_, _ = io.WriteString(w, b.String())
+}
+
+type label struct {
+	name  string
+	value string
+}
+
+type sample struct {
+	key    string
+	name   string
+	labels []label
+	typ    string
+	value  string
+}
+
+// collect returns the samples of the metrics of the registry, sorted by name
+// and labels.
+func (h *handler) collect() []sample {
+	v := &visitor{handler: h}
+	h.registry.Visit(monitoring.Full, v)
+	samples := v.samples
+
+	sort.Slice(samples, func(i, j int) bool {
+		if samples[i].name != samples[j].name {
+			return samples[i].name < samples[j].name
+		}
+		if li, lj := formatLabels(samples[i].labels), formatLabels(samples[j].labels); li != lj {
+			return li < lj
+		}
+		return samples[i].key < samples[j].key
+	})
+
+	// Different keys can have the same name and labels once converted,
+	// Prometheus rejects duplicated series so they are disambiguated with
+	// their key.
+	for i := 0; i < len(samples); {
+		j := i + 1
+		for j < len(samples) && samples[j].name == samples[i].name &&
+			formatLabels(samples[j].labels) == formatLabels(samples[i].labels) {
+			j++
+		}
+		if j-i > 1 {
+			keys := make([]string, 0, j-i)
+			for k := i; k < j; k++ {
+				keys = append(keys, samples[k].key)
+				samples[k].labels = append(samples[k].labels, label{name: keyLabel, value: samples[k].key})
+			}
+			h.logger.Warnf("Metrics %v have the same Prometheus series %s%s, they are reported with the %q label",
+				keys, samples[i].name, formatLabels(samples[i].labels[:len(samples[i].labels)-1]), keyLabel)
+		}
+		i = j
+	}
+	return samples
+}
+
+// sample returns the sample of the metric with the key made of the given
+// elements.
+func (h *handler) sample(elements []string, value string, gauge bool) sample {
+	key := strings.Join(elements, ".")
+	nameElements, labels := h.applyPatterns(elements)
+
+	s := sample{
+		key:    key,
+		name:   metricName(strings.Join(nameElements, ".")),
+		labels: labels,
+		typ:    "gauge",
+		value:  value,
+	}
+	if !gauge && !report.IsGauge(key) {
+		s.typ = "counter"
+		if !strings.HasSuffix(s.name, "_total") {
+			s.name += "_total"
+		}
+	}
+	return s
+}
+
+// applyPatterns returns the elements of the key that are part of the name of
+// the metric, and its labels, from the first pattern that matches the key.
+func (h *handler) applyPatterns(elements []string) ([]string, []label) {
+	for _, pattern := range h.patterns {
+		// The name must have at least one element after the pattern.
+		if len(elements) <= len(pattern) {
+			continue
+		}
+		var name []string
+		var labels []label
+		matched := true
+		for i, p := range pattern {
+			if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+				labels = append(labels, label{name: p[1 : len(p)-1], value: elements[i]})
+				continue
+			}
+			if p != elements[i] {
+				matched = false
+				break
+			}
+			name = append(name, p)
+		}
+		if matched {
+			return append(name, elements[len(pattern):]...), labels
+		}
+	}
+	return elements, nil
+}
+
+// visitor collects the samples of a registry, keeping the elements of the
+// keys, as they can contain dots.
+type visitor struct {
+	*handler
+	elements []string
+	samples  []sample
+}
+
+func (v *visitor) OnRegistryStart() {}
+
+func (v *visitor) OnRegistryFinished() {
+	if len(v.elements) > 0 {
+		v.elements = v.elements[:len(v.elements)-1]
+	}
+}
+
+func (v *visitor) OnKey(key string) {
+	v.elements = append(v.elements, key)
+}
+
+func (v *visitor) add(value string, gauge bool) {
+	v.samples = append(v.samples, v.sample(v.elements, value, gauge))
+	v.elements = v.elements[:len(v.elements)-1]
+}
+
+func (v *visitor) OnString(string) { v.elements = v.elements[:len(v.elements)-1] }
+
+func (v *visitor) OnStringSlice([]string) { v.elements = v.elements[:len(v.elements)-1] }
+
+func (v *visitor) OnBool(b bool) {
+	value := "0"
+	if b {
+		value = "1"
+	}
+	v.add(value, true)
+}
+
+func (v *visitor) OnInt(i int64) { v.add(strconv.FormatInt(i, 10), false) }
+
+func (v *visitor) OnFloat(f float64) { v.add(strconv.FormatFloat(f, 'g', -1, 64), false) }
+
+// metricName converts a metric key to a valid Prometheus metric name.
+func metricName(key string) string {
+	var b strings.Builder
+	b.Grow(len(key) + 1)
+	for i, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', r == ':':
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatLabels formats the labels of a series, as {name="value",...}.
+func formatLabels(labels []label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, l := range labels {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(l.name + `="` + labelValueReplacer.Replace(l.value) + `"`)
+	}
+	b.WriteByte('}')
+	return b.String()
+}
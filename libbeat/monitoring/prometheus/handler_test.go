@@ -0,0 +1,108 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestMetricName(t *testing.T) {
+	tests := map[string]string{
+		"libbeat.output.events.acked":       "libbeat_output_events_acked",
+		"metricbeat.system.cpu.success":     "metricbeat_system_cpu_success",
+		"metricbeat.http.json.hosts.a:80.x": "metricbeat_http_json_hosts_a:80_x",
+		"system.load.1":                     "system_load_1",
+		"1m":                                "_1m",
+		"beat.info.ephemeral-id":            "beat_info_ephemeral_id",
+	}
+	for key, expected := range tests {
+		assert.Equal(t, expected, metricName(key), key)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	reg := monitoring.NewRegistry()
+	monitoring.NewInt(reg, "metricbeat.system.cpu.success").Set(3)
+	monitoring.NewInt(reg, "metricbeat.system.cpu.events_gauge").Set(2)
+	monitoring.NewUint(reg, "metricbeat.system.cpu.output_blocked_total").Set(1)
+	monitoring.NewInt(reg, "metricbeat.system.memory.success").Set(4)
+	monitoring.NewFunc(reg, "metricbeat.http.json.hosts", func(_ monitoring.Mode, V monitoring.Visitor) {
+		V.OnRegistryStart()
+		defer V.OnRegistryFinished()
+		V.OnKey("a.example.com:80")
+		V.OnRegistryStart()
+		monitoring.ReportInt(V, "success", 5)
+		V.OnRegistryFinished()
+	})
+	monitoring.NewFloat(reg, "system.load.1").Set(0.5)
+	monitoring.NewBool(reg, "beat.up").Set(true)
+	monitoring.NewString(reg, "beat.info.version").Set("8.15.0")
+
+	// Dots and dashes are both replaced, both series are reported with
+	// their key.
+	monitoring.NewInt(reg, "a.b").Set(1)
+	monitoring.NewInt(reg, "a-b").Set(2)
+
+	server := httptest.NewServer(NewHandler(reg,
+		WithLabels("metricbeat.{module}.{metricset}.hosts.{host}"),
+		WithLabels("metricbeat.{module}.{metricset}"),
+	))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, contentType, resp.Header.Get("Content-Type"))
+	assert.Equal(t, `# TYPE a_b_total counter
+a_b_total{key="a-b"} 2
+a_b_total{key="a.b"} 1
+# TYPE beat_up gauge
+beat_up 1
+# TYPE metricbeat_events_gauge gauge
+metricbeat_events_gauge{module="system",metricset="cpu"} 2
+# TYPE metricbeat_hosts_success_total counter
+metricbeat_hosts_success_total{module="http",metricset="json",host="a.example.com:80"} 5
+# TYPE metricbeat_output_blocked_total counter
+metricbeat_output_blocked_total{module="system",metricset="cpu"} 1
+# TYPE metricbeat_success_total counter
+metricbeat_success_total{module="system",metricset="cpu"} 3
+metricbeat_success_total{module="system",metricset="memory"} 4
+# TYPE system_load_1 gauge
+system_load_1 0.5
+`, string(body))
+}
+
+func TestFormatLabels(t *testing.T) {
+	assert.Equal(t, "", formatLabels(nil))
+	assert.Equal(t, `{host="a\"b\\c\nd",module="m"}`, formatLabels([]label{
+		{name: "host", value: "a\"b\\c\nd"},
+		{name: "module", value: "m"},
+	}))
+}
@@ -0,0 +1,37 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsGauge(t *testing.T) {
+	assert.True(t, IsGauge("libbeat.pipeline.clients"))
+	assert.True(t, IsGauge("beat.memstats.rss"))
+	assert.True(t, IsGauge("metricbeat.system.cpu.fetch_duration.histogram.p99"))
+	assert.True(t, IsGauge("example.queue_gauge"))
+	assert.False(t, IsGauge("libbeat.output.events.acked"))
+
+	assert.False(t, IsGauge("example.queue.in_flight"))
+	defer func(suffixes []string) { gaugeSuffixes = suffixes }(gaugeSuffixes)
+	RegisterGaugeSuffix(".in_flight")
+	assert.True(t, IsGauge("example.queue.in_flight"))
+}
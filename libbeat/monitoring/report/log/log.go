@@ -18,7 +18,6 @@
 package log
 
 import (
-	"strings"
 	"sync"
 	"time"
 
@@ -30,58 +29,11 @@ import (
 	"github.com/elastic/elastic-agent-libs/monitoring"
 )
 
-// List of metrics that are gauges. This is used to identify metrics that should
-// not be reported as deltas. Instead we log the raw value if there was any
-// observable change during the interval.
-//
-// TODO: Replace this with a proper solution that uses the metric type from
-// where it is defined. See: https://github.com/elastic/beats/issues/5433
-var gauges = map[string]bool{
-	"libbeat.output.events.active":         true,
-	"libbeat.pipeline.events.active":       true,
-	"libbeat.pipeline.clients":             true,
-	"libbeat.pipeline.queue.max_events":    true,
-	"libbeat.pipeline.queue.max_bytes":     true,
-	"libbeat.pipeline.queue.filled.events": true,
-	"libbeat.pipeline.queue.filled.bytes":  true,
-	"libbeat.pipeline.queue.filled.pct":    true,
-	"libbeat.config.module.running":        true,
-	"registrar.states.current":             true,
-	"filebeat.events.active":               true,
-	"filebeat.harvester.running":           true,
-	"filebeat.harvester.open_files":        true,
-	"beat.memstats.memory_total":           true,
-	"beat.memstats.memory_alloc":           true,
-	"beat.memstats.rss":                    true,
-	"beat.memstats.gc_next":                true,
-	"beat.info.uptime.ms":                  true,
-	"beat.cgroup.memory.mem.usage.bytes":   true,
-	"beat.cpu.user.ticks":                  true,
-	"beat.cpu.system.ticks":                true,
-	"beat.cpu.total.value":                 true,
-	"beat.cpu.total.ticks":                 true,
-	"beat.handles.open":                    true,
-	"beat.handles.limit.hard":              true,
-	"beat.handles.limit.soft":              true,
-	"beat.runtime.goroutines":              true,
-	"system.load.1":                        true,
-	"system.load.5":                        true,
-	"system.load.15":                       true,
-	"system.load.norm.1":                   true,
-	"system.load.norm.5":                   true,
-	"system.load.norm.15":                  true,
-}
-
 // isGauge returns true when the given metric key name represents a gauge value.
-// Any metric name suffixed in '_gauge' or containing '.histogram.' is
-// treated as a gauge. Other metrics can specifically be marked as gauges
-// through the list maintained in this package.
+// Gauges are not reported as deltas. Instead we log the raw value if there was
+// any observable change during the interval.
 func isGauge(key string) bool {
-	if strings.HasSuffix(key, "_gauge") || strings.Contains(key, ".histogram.") {
-		return true
-	}
-	_, found := gauges[key]
-	return found
+	return report.IsGauge(key)
 }
 
 // TODO: Change this when gauges are refactored, too.
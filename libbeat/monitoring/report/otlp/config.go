@@ -0,0 +1,43 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+type config struct {
+	// Endpoint is the base URL of the OTLP/HTTP receiver, metrics are sent
+	// to its /v1/metrics path.
+	Endpoint   string                           `config:"endpoint" validate:"required"`
+	Headers    map[string]string                `config:"headers"`
+	Period     time.Duration                    `config:"period" validate:"positive"`
+	Namespaces []string                         `config:"namespaces"`
+	Transport  httpcommon.HTTPTransportSettings `config:",inline"`
+}
+
+func defaultConfig() config {
+	return config{
+		Endpoint:   "http://localhost:4318",
+		Period:     30 * time.Second,
+		Namespaces: []string{"stats"},
+		Transport:  httpcommon.DefaultHTTPTransportSettings(),
+	}
+}
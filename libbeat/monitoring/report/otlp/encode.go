@@ -0,0 +1,186 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/monitoring/report"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// The types below are the subset of the JSON encoding of the OTLP metrics
+// protocol used by the reporter. See
+// https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/metrics/v1/metrics.proto
+
+const aggregationTemporalityCumulative = 2
+
+type exportRequest struct {
+	ResourceMetrics []resourceMetrics `json:"resourceMetrics"`
+}
+
+type resourceMetrics struct {
+	Resource     resource       `json:"resource"`
+	ScopeMetrics []scopeMetrics `json:"scopeMetrics"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type scopeMetrics struct {
+	Scope   scope    `json:"scope"`
+	Metrics []metric `json:"metrics"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type metric struct {
+	Name  string `json:"name"`
+	Sum   *sum   `json:"sum,omitempty"`
+	Gauge *gauge `json:"gauge,omitempty"`
+}
+
+type sum struct {
+	DataPoints             []dataPoint `json:"dataPoints"`
+	AggregationTemporality int         `json:"aggregationTemporality"`
+	IsMonotonic            bool        `json:"isMonotonic"`
+}
+
+type gauge struct {
+	DataPoints []dataPoint `json:"dataPoints"`
+}
+
+type dataPoint struct {
+	StartTimeUnixNano string   `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string   `json:"timeUnixNano"`
+	AsInt             *string  `json:"asInt,omitempty"` // 64-bit integers are encoded as strings.
+	AsDouble          *float64 `json:"asDouble,omitempty"`
+}
+
+// makeResource returns the resource identifying the beat that reports the
+// metrics.
+func makeResource(info beat.Info) resource {
+	attrs := []keyValue{
+		{Key: "service.name", Value: anyValue{StringValue: info.Beat}},
+		{Key: "service.version", Value: anyValue{StringValue: info.Version}},
+		{Key: "service.instance.id", Value: anyValue{StringValue: info.ID.String()}},
+	}
+	if info.Hostname != "" {
+		attrs = append(attrs, keyValue{Key: "host.name", Value: anyValue{StringValue: info.Hostname}})
+	}
+	return resource{Attributes: attrs}
+}
+
+// startTimes keeps the start times of the cumulative metrics of a namespace
+// between exports. A metric is restarted when it is reset with
+// report.MarkReset, when its value decreases, or when it appears after the
+// first export, as when its registry is replaced on reload.
+type startTimes struct {
+	initial time.Time // Start time of the metrics of the first export.
+	last    time.Time // Time of the previous export, zero before the first one.
+	series  map[string]seriesStart
+	seen    map[string]seriesStart // Series of the current export.
+}
+
+type seriesStart struct {
+	start time.Time
+	value float64
+}
+
+func newStartTimes(initial time.Time) *startTimes {
+	return &startTimes{initial: initial, series: map[string]seriesStart{}}
+}
+
+// get returns the start time of the metric with the given name and value.
+func (s *startTimes) get(name string, value float64) time.Time {
+	if s.seen == nil {
+		s.seen = map[string]seriesStart{}
+	}
+
+	prev, found := s.series[name]
+	start := prev.start
+	switch {
+	case !found && s.last.IsZero():
+		start = s.initial
+	case !found, value < prev.value:
+		start = s.last
+	}
+	if reset := report.LastReset(name); reset.After(start) {
+		start = reset
+	}
+	s.seen[name] = seriesStart{start: start, value: value}
+	return start
+}
+
+// finish completes an export done at now. Metrics that were not part of it
+// are forgotten, so they are restarted if they appear again.
+func (s *startTimes) finish(now time.Time) {
+	s.series, s.seen = s.seen, nil
+	if s.series == nil {
+		s.series = map[string]seriesStart{}
+	}
+	s.last = now
+}
+
+// makeScopeMetrics converts the numeric metrics of a snapshot of the given
+// namespace. Gauges are reported as gauges, other metrics as cumulative sums
+// since their start time.
+func makeScopeMetrics(namespace string, snapshot monitoring.FlatSnapshot, starts *startTimes, now time.Time) scopeMetrics {
+	defer starts.finish(now)
+	nowNano := strconv.FormatInt(now.UnixNano(), 10)
+
+	metrics := make([]metric, 0, len(snapshot.Ints)+len(snapshot.Floats))
+	add := func(name string, value float64, point dataPoint) {
+		if report.IsGauge(name) {
+			metrics = append(metrics, metric{Name: name, Gauge: &gauge{DataPoints: []dataPoint{point}}})
+			return
+		}
+		point.StartTimeUnixNano = strconv.FormatInt(starts.get(name, value).UnixNano(), 10)
+		metrics = append(metrics, metric{Name: name, Sum: &sum{
+			DataPoints:             []dataPoint{point},
+			AggregationTemporality: aggregationTemporalityCumulative,
+			IsMonotonic:            true,
+		}})
+	}
+	for name, value := range snapshot.Ints {
+		asInt := strconv.FormatInt(value, 10)
+		add(name, float64(value), dataPoint{TimeUnixNano: nowNano, AsInt: &asInt})
+	}
+	for name, value := range snapshot.Floats {
+		asDouble := value
+		add(name, value, dataPoint{TimeUnixNano: nowNano, AsDouble: &asDouble})
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+
+	return scopeMetrics{Scope: scope{Name: namespace}, Metrics: metrics}
+}
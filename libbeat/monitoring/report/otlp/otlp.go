@@ -0,0 +1,165 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package otlp implements a reporter that periodically exports the internal
+// metrics of a beat to an OpenTelemetry collector with the OTLP/HTTP protocol.
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/monitoring/report"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+type reporter struct {
+	config
+	wg         sync.WaitGroup
+	done       chan struct{}
+	registries map[string]*monitoring.Registry
+
+	client   *http.Client
+	url      string
+	resource resource
+	starts   map[string]*startTimes // Start times of the cumulative metrics of each namespace.
+
+	logger *logp.Logger
+}
+
+func init() {
+	report.RegisterReporterFactory("otlp", makeReporter)
+}
+
+// makeReporter returns a new Reporter that periodically exports metrics to an
+// OTLP/HTTP endpoint. If cfg is nil defaults will be used.
+func makeReporter(beat beat.Info, _ report.Settings, cfg *conf.C) (report.Reporter, error) {
+	config := defaultConfig()
+	if cfg != nil {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	logger := logp.NewLogger("monitoring.otlp")
+	client, err := config.Transport.Client(
+		httpcommon.WithLogger(logger),
+		httpcommon.WithHeaderRoundTripper(config.Headers),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP client: %w", err)
+	}
+
+	r := &reporter{
+		config:     config,
+		done:       make(chan struct{}),
+		registries: map[string]*monitoring.Registry{},
+		client:     client,
+		url:        strings.TrimSuffix(config.Endpoint, "/") + "/v1/metrics",
+		resource:   makeResource(beat),
+		starts:     map[string]*startTimes{},
+		logger:     logger,
+	}
+
+	start := time.Now()
+	for _, ns := range r.config.Namespaces {
+		r.registries[ns] = monitoring.GetNamespace(ns).GetRegistry()
+		r.starts[ns] = newStartTimes(start)
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.exportLoop()
+	}()
+	return r, nil
+}
+
+func (r *reporter) Stop() {
+	close(r.done)
+	r.wg.Wait()
+}
+
+func (r *reporter) exportLoop() {
+	r.logger.Infof("Starting OTLP metrics export to %v every %v", r.url, r.Period)
+	defer r.logger.Infof("Stopping OTLP metrics export.")
+
+	ticker := time.NewTicker(r.Period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.done:
+			// Export the final values before stopping.
+			r.export()
+			return
+		case <-ticker.C:
+		}
+
+		r.export()
+	}
+}
+
+func (r *reporter) export() {
+	if err := r.send(r.makeRequest(time.Now())); err != nil {
+		r.logger.Warnf("Failed to export metrics: %v", err)
+	}
+}
+
+func (r *reporter) makeRequest(now time.Time) exportRequest {
+	rm := resourceMetrics{Resource: r.resource}
+	for name, reg := range r.registries {
+		snapshot := monitoring.CollectFlatSnapshot(reg, monitoring.Full, false)
+		rm.ScopeMetrics = append(rm.ScopeMetrics, makeScopeMetrics(name, snapshot, r.starts[name], now))
+	}
+	return exportRequest{ResourceMetrics: []resourceMetrics{rm}}
+}
+
+func (r *reporter) send(request exportRequest) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to encode metrics: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.Period)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %v from %v", resp.StatusCode, r.url)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/monitoring/report"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestMakeScopeMetrics(t *testing.T) {
+	start := time.Unix(100, 0)
+	now := time.Unix(200, 0)
+	snapshot := monitoring.FlatSnapshot{
+		Ints: map[string]int64{
+			"metricbeat.redis.info.success": 10,
+			"libbeat.pipeline.clients":      2,
+		},
+		Floats: map[string]float64{
+			"system.load.1": 1.5,
+		},
+	}
+
+	sm := makeScopeMetrics("stats", snapshot, newStartTimes(start), now)
+	assert.Equal(t, "stats", sm.Scope.Name)
+	require.Len(t, sm.Metrics, 3)
+
+	clients := sm.Metrics[0]
+	assert.Equal(t, "libbeat.pipeline.clients", clients.Name)
+	require.NotNil(t, clients.Gauge)
+	assert.Equal(t, "2", *clients.Gauge.DataPoints[0].AsInt)
+
+	success := sm.Metrics[1]
+	assert.Equal(t, "metricbeat.redis.info.success", success.Name)
+	require.NotNil(t, success.Sum)
+	assert.True(t, success.Sum.IsMonotonic)
+	assert.Equal(t, aggregationTemporalityCumulative, success.Sum.AggregationTemporality)
+	assert.Equal(t, "10", *success.Sum.DataPoints[0].AsInt)
+	assert.Equal(t, "100000000000", success.Sum.DataPoints[0].StartTimeUnixNano)
+	assert.Equal(t, "200000000000", success.Sum.DataPoints[0].TimeUnixNano)
+
+	load := sm.Metrics[2]
+	assert.Equal(t, "system.load.1", load.Name)
+	require.NotNil(t, load.Gauge)
+	assert.Equal(t, 1.5, *load.Gauge.DataPoints[0].AsDouble)
+}
+
+func TestMakeScopeMetricsStartTimes(t *testing.T) {
+	starts := newStartTimes(time.Unix(100, 0))
+	export := func(now int64, ints map[string]int64) map[string]string {
+		sm := makeScopeMetrics("stats", monitoring.FlatSnapshot{Ints: ints}, starts, time.Unix(now, 0))
+		result := map[string]string{}
+		for _, m := range sm.Metrics {
+			result[m.Name] = m.Sum.DataPoints[0].StartTimeUnixNano
+		}
+		return result
+	}
+
+	assert.Equal(t, map[string]string{
+		"otlp_test.a.success": "100000000000",
+	}, export(200, map[string]int64{"otlp_test.a.success": 10}))
+
+	// Metrics that decrease or appear later start after the previous export.
+	assert.Equal(t, map[string]string{
+		"otlp_test.a.success": "200000000000",
+		"otlp_test.b.success": "200000000000",
+	}, export(300, map[string]int64{"otlp_test.a.success": 5, "otlp_test.b.success": 1}))
+
+	// Metrics reset with report.MarkReset start at the reset, even if they
+	// didn't decrease.
+	report.MarkReset("otlp_test.a", time.Unix(350, 0))
+	assert.Equal(t, map[string]string{
+		"otlp_test.a.success": "350000000000",
+		"otlp_test.b.success": "200000000000",
+	}, export(400, map[string]int64{"otlp_test.a.success": 20, "otlp_test.b.success": 2}))
+
+	// Metrics that disappear are restarted when they appear again.
+	export(500, map[string]int64{"otlp_test.a.success": 20})
+	assert.Equal(t, map[string]string{
+		"otlp_test.a.success": "350000000000",
+		"otlp_test.b.success": "500000000000",
+	}, export(600, map[string]int64{"otlp_test.a.success": 20, "otlp_test.b.success": 2}))
+}
+
+func TestReporter(t *testing.T) {
+	requests := make(chan exportRequest, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/metrics", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+
+		var request exportRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		select {
+		case requests <- request:
+		default:
+		}
+	}))
+	defer server.Close()
+
+	ns := monitoring.GetNamespace("otlp_reporter_test")
+	monitoring.NewInt(ns.GetRegistry(), "metricbeat.fake.status.events").Set(3)
+
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
+		"endpoint":   server.URL,
+		"period":     "10ms",
+		"namespaces": []string{"otlp_reporter_test"},
+		"headers":    map[string]string{"Authorization": "secret"},
+	})
+	r, err := makeReporter(beat.Info{Beat: "metricbeat", Version: "9.9.9"}, report.Settings{}, cfg)
+	require.NoError(t, err)
+	defer r.Stop()
+
+	select {
+	case request := <-requests:
+		require.Len(t, request.ResourceMetrics, 1)
+		rm := request.ResourceMetrics[0]
+		assert.Contains(t, rm.Resource.Attributes, keyValue{Key: "service.name", Value: anyValue{StringValue: "metricbeat"}})
+		require.Len(t, rm.ScopeMetrics, 1)
+		require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+		m := rm.ScopeMetrics[0].Metrics[0]
+		assert.Equal(t, "metricbeat.fake.status.events", m.Name)
+		require.NotNil(t, m.Sum)
+		assert.Equal(t, "3", *m.Sum.DataPoints[0].AsInt)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no metrics were exported")
+	}
+}
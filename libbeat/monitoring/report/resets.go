@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package report
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	resetsMu sync.Mutex
+	resets   = map[string]time.Time{}
+)
+
+// MarkReset records that the cumulative metrics whose keys start with prefix
+// were reset to zero, or created, at the given time. Reporters of cumulative
+// metrics use it to restart the metrics at that time instead of reporting a
+// decrease.
+func MarkReset(prefix string, t time.Time) {
+	resetsMu.Lock()
+	defer resetsMu.Unlock()
+	resets[prefix] = t
+}
+
+// LastReset returns the last time the metric with the given key was reset
+// according to MarkReset, or the zero time if it wasn't.
+func LastReset(key string) time.Time {
+	resetsMu.Lock()
+	defer resetsMu.Unlock()
+
+	var last time.Time
+	for prefix, t := range resets {
+		if (key == prefix || strings.HasPrefix(key, prefix+".")) && t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
@@ -0,0 +1,35 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLastReset(t *testing.T) {
+	MarkReset("resets_test.module.metricset", time.Unix(100, 0))
+	MarkReset("resets_test.module.metricset.hosts.localhost:6379", time.Unix(200, 0))
+
+	assert.Equal(t, time.Unix(100, 0), LastReset("resets_test.module.metricset.success"))
+	assert.Equal(t, time.Unix(200, 0), LastReset("resets_test.module.metricset.hosts.localhost:6379.success"))
+	assert.True(t, LastReset("resets_test.module.metricset_other.success").IsZero())
+	assert.True(t, LastReset("resets_test.module").IsZero())
+}
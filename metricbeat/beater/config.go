@@ -21,6 +21,8 @@ import (
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/autodiscover"
+	"github.com/elastic/beats/v7/metricbeat/mb/plugin"
+	"github.com/elastic/beats/v7/metricbeat/tracing"
 	conf "github.com/elastic/elastic-agent-libs/config"
 )
 
@@ -31,8 +33,38 @@ type Config struct {
 	ConfigModules *conf.C              `config:"config.modules"`
 	MaxStartDelay time.Duration        `config:"max_start_delay"` // Upper bound on the random startup delay for metricsets (use 0 to disable startup delay).
 	Autodiscover  *autodiscover.Config `config:"autodiscover"`
+
+	// MaxConcurrentFetches is an upper bound on the number of metricset fetches
+	// running at the same time across all modules (use 0 for no limit).
+	MaxConcurrentFetches int `config:"max_concurrent_fetches" validate:"min=0"`
+
+	// StatsResetToken enables the endpoint of the HTTP monitoring server that
+	// resets the stats of the metricsets. Requests must send it as bearer
+	// token.
+	StatsResetToken string `config:"stats_reset_token"`
+
+	// DebugPage enables the HTML page of the HTTP monitoring server with the
+	// health of the metricsets.
+	DebugPage DebugPageConfig `config:"debug_page"`
+
+	// Plugins configures the plugins that serve external metricsets, they
+	// are started and registered on startup.
+	Plugins plugin.Config `config:"plugins"`
+
+	// Tracing configures the export of the traces of metricset fetches to an
+	// OTLP endpoint.
+	Tracing tracing.Config `config:"tracing"`
+}
+
+// DebugPageConfig configures the HTML page with the health of the
+// metricsets.
+type DebugPageConfig struct {
+	Enabled bool          `config:"enabled"`
+	Refresh time.Duration `config:"refresh" validate:"min=1s"`
 }
 
 var defaultConfig = Config{
 	MaxStartDelay: 10 * time.Second,
+	DebugPage:     DebugPageConfig{Refresh: 5 * time.Second},
+	Tracing:       tracing.DefaultConfig(),
 }
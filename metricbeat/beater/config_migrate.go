@@ -0,0 +1,388 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package beater
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v3"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+// MigrateConfig rewrites the deprecated settings and the removed metricsets of
+// the modules of metricbeat.modules in the config file, and of the files of
+// metricbeat.config.modules, to their current syntax, following the
+// migrations of the registry. The changes and the diff of each file are
+// written to w. The files are only rewritten if dryRun is not set.
+func MigrateConfig(w io.Writer, cfgFile string, rawConfig *conf.C, registry *mb.Register, dryRun bool) error {
+	var mbConfig struct {
+		ConfigModules *conf.C `config:"metricbeat.config.modules"`
+	}
+	if err := rawConfig.Unpack(&mbConfig); err != nil {
+		return fmt.Errorf("error reading metricbeat config: %w", err)
+	}
+
+	changed := false
+	migrate := func(file, path string) error {
+		diff, err := migrateConfigFile(w, file, path, registry, dryRun)
+		if err != nil {
+			return fmt.Errorf("error migrating file '%s': %w", file, err)
+		}
+		changed = changed || diff
+		return nil
+	}
+	if err := migrate(cfgFile, "metricbeat.modules"); err != nil {
+		return err
+	}
+	if mbConfig.ConfigModules.Enabled() {
+		files, _, err := configModulesFiles(mbConfig.ConfigModules)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			if err := migrate(file, ""); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !changed {
+		fmt.Fprintln(w, "No deprecated settings found.")
+	}
+	return nil
+}
+
+// migrateConfigFile migrates the modules of a file, listed in the setting with
+// the given path, or at the root of the file if path is empty. It returns
+// true if the file is changed. Only the lines of the modules that are changed
+// are rewritten, so the rest of the file is kept as it is.
+func migrateConfigFile(w io.Writer, file, path string, registry *mb.Register, dryRun bool) (bool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return false, err
+	}
+	if len(doc.Content) == 0 {
+		return false, nil
+	}
+
+	list := doc.Content[0]
+	if path != "" {
+		parent, i := findSetting(list, path)
+		if parent == nil {
+			return false, nil
+		}
+		list = parent.Content[i+1]
+	}
+	// Lists in flow style can't be rewritten line by line.
+	if list.Kind != yaml.SequenceNode || list.Style&yaml.FlowStyle != 0 {
+		return false, nil
+	}
+
+	lines := strings.SplitAfter(string(data), "\n")
+	var changes []string
+	// Modules are rewritten from the last one, so the lines of the previous
+	// ones don't move.
+	for i := len(list.Content) - 1; i >= 0; i-- {
+		module := list.Content[i]
+		first, last, indent, ok := moduleLines(lines, module)
+		if !ok {
+			continue
+		}
+		moduleChanges := migrateModule(module, registry)
+		if len(moduleChanges) == 0 {
+			continue
+		}
+		for j := len(moduleChanges) - 1; j >= 0; j-- {
+			changes = append(changes, fmt.Sprintf("config #%d: %s", i+1, moduleChanges[j]))
+		}
+
+		rewritten, err := encodeModule(module, indent)
+		if err != nil {
+			return false, err
+		}
+		lines = slices.Replace(lines, first, last+1, rewritten...)
+	}
+	if len(changes) == 0 {
+		return false, nil
+	}
+	slices.Reverse(changes)
+	migrated := strings.Join(lines, "")
+
+	for _, change := range changes {
+		fmt.Fprintf(w, "# %s: %s\n", file, change)
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        diffLines(string(data)),
+		B:        diffLines(migrated),
+		FromFile: file,
+		ToFile:   file,
+		Context:  3,
+	})
+	if err != nil {
+		return false, err
+	}
+	fmt.Fprint(w, diff)
+
+	if dryRun {
+		return true, nil
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(file, []byte(migrated), info.Mode().Perm())
+}
+
+// moduleLines returns the indexes of the first and last lines of the
+// configuration of a module in a list, and the indentation of its dash. The
+// configuration spans the lines indented deeper than the dash, and the
+// comments and blank lines between them. It returns false if the dash is not
+// in the line of the first setting.
+func moduleLines(lines []string, module *yaml.Node) (first, last, indent int, ok bool) {
+	first = module.Line - 1
+	if first < 0 || first >= len(lines) {
+		return 0, 0, 0, false
+	}
+	trimmed := strings.TrimLeft(lines[first], " ")
+	if !strings.HasPrefix(trimmed, "-") {
+		return 0, 0, 0, false
+	}
+	indent = len(lines[first]) - len(trimmed)
+
+	last = first
+	for i := first + 1; i < len(lines); i++ {
+		content := strings.TrimLeft(lines[i], " ")
+		if strings.TrimSpace(content) == "" {
+			continue
+		}
+		if len(lines[i])-len(content) <= indent {
+			break
+		}
+		last = i
+	}
+	return first, last, indent, true
+}
+
+// encodeModule encodes the configuration of a module as an item of a list,
+// indented with the given number of spaces.
+func encodeModule(module *yaml.Node, indent int) ([]string, error) {
+	// The comments before the dash are not part of the rewritten lines.
+	item := *module
+	item.HeadComment = ""
+	if len(item.Content) > 0 {
+		key := *item.Content[0]
+		key.HeadComment = ""
+		item.Content = append([]*yaml.Node{&key}, item.Content[1:]...)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{&item}}); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	lines := strings.SplitAfter(buf.String(), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = strings.Repeat(" ", indent) + line
+		}
+	}
+	return lines, nil
+}
+
+// diffLines splits a file in lines ending with a newline, as expected by
+// difflib.
+func diffLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	lines := strings.SplitAfter(s, "\n")
+	return lines[:len(lines)-1]
+}
+
+// migrateModule applies the migrations of the registry to the configuration
+// of a module, and returns the description of the changes.
+func migrateModule(module *yaml.Node, registry *mb.Register) []string {
+	if module.Kind != yaml.MappingNode {
+		return nil
+	}
+	name := mappingValue(module, "module")
+	if name == nil || name.Kind != yaml.ScalarNode {
+		return nil
+	}
+	migrations := registry.Migrations(name.Value)
+	if len(migrations) == 0 {
+		return nil
+	}
+
+	var metricSets []string
+	list := mappingValue(module, "metricsets")
+	if list == nil || list.Decode(&metricSets) != nil {
+		metricSets, _ = registry.DefaultMetricSets(name.Value)
+	}
+
+	var changes []string
+	for _, m := range migrations {
+		if m.Setting != "" || list == nil {
+			continue
+		}
+		for i := 0; i < len(list.Content); i++ {
+			if list.Content[i].Value != m.MetricSet {
+				continue
+			}
+			if m.Replacement != "" && !slices.Contains(metricSets, m.Replacement) {
+				list.Content[i].Value = m.Replacement
+				metricSets = append(metricSets, m.Replacement)
+			} else {
+				list.Content = append(list.Content[:i], list.Content[i+1:]...)
+				i--
+			}
+			changes = append(changes, m.String())
+		}
+		metricSets = slices.DeleteFunc(metricSets, func(name string) bool { return name == m.MetricSet })
+	}
+
+	// Deprecated settings can be shared by several metricsets, so they are
+	// removed once all the migrations are applied.
+	var deprecated []string
+	inserted := map[string]int{} // Replacements added after each deprecated setting.
+	for _, m := range migrations {
+		if m.Setting == "" || (m.MetricSet != "" && !slices.Contains(metricSets, m.MetricSet)) {
+			continue
+		}
+		parent, i := findSetting(module, m.Setting)
+		if parent == nil {
+			continue
+		}
+		if !slices.Contains(deprecated, m.Setting) {
+			deprecated = append(deprecated, m.Setting)
+		}
+		changes = append(changes, m.String())
+		if m.Replacement == "" {
+			continue
+		}
+
+		var value, current interface{}
+		if err := parent.Content[i+1].Decode(&value); err != nil {
+			continue
+		}
+		replacementParent, j := findSetting(module, m.Replacement)
+		if replacementParent != nil {
+			if err := replacementParent.Content[j+1].Decode(&current); err != nil {
+				continue
+			}
+		}
+		if m.Convert != nil {
+			var ok bool
+			if value, ok = m.Convert(value, current); !ok {
+				continue
+			}
+		}
+
+		var node yaml.Node
+		if err := node.Encode(value); err != nil {
+			continue
+		}
+		if node.Kind == yaml.SequenceNode {
+			node.Style = yaml.FlowStyle
+		}
+		switch {
+		case replacementParent != nil:
+			replacementParent.Content[j+1] = &node
+		case parent == module:
+			// The replacement is added where the deprecated setting was,
+			// with its comment.
+			key := &yaml.Node{Kind: yaml.ScalarNode, Value: m.Replacement, HeadComment: module.Content[i].HeadComment}
+			module.Content[i].HeadComment = ""
+			module.Content = slices.Insert(module.Content, i+2+2*inserted[m.Setting], key, &node)
+			inserted[m.Setting]++
+		default:
+			key := &yaml.Node{Kind: yaml.ScalarNode, Value: m.Replacement}
+			module.Content = append(module.Content, key, &node)
+		}
+	}
+	for _, setting := range deprecated {
+		removeSetting(module, setting)
+	}
+	return changes
+}
+
+// findSetting returns the mapping that contains the key of the setting with
+// the given dotted path, and the index of the key, or nil if it is not found.
+// Keys can be dotted, like ssl.verification_mode.
+func findSetting(node *yaml.Node, path string) (*yaml.Node, int) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, -1
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if key == path {
+			return node, i
+		}
+		if rest, found := strings.CutPrefix(path, key+"."); found {
+			if parent, j := findSetting(node.Content[i+1], rest); parent != nil {
+				return parent, j
+			}
+		}
+	}
+	return nil, -1
+}
+
+// removeSetting removes the setting with the given dotted path, and the
+// mappings left empty by its removal. It returns false if it is not found.
+func removeSetting(node *yaml.Node, path string) bool {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if key == path {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return true
+		}
+		if rest, found := strings.CutPrefix(path, key+"."); found && removeSetting(node.Content[i+1], rest) {
+			if len(node.Content[i+1].Content) == 0 {
+				node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			}
+			return true
+		}
+	}
+	return false
+}
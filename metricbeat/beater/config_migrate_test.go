@@ -0,0 +1,122 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package beater
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestMigrateConfig(t *testing.T) {
+	registry := mb.NewRegister()
+	factory := func(base mb.BaseMetricSet) (mb.MetricSet, error) { return nil, nil }
+	for _, ms := range []string{"status", "stats"} {
+		require.NoError(t, registry.AddMetricSet("test", ms, factory))
+	}
+	require.NoError(t, registry.AddMigration(mb.Migration{
+		Module: "test", MetricSet: "status", Setting: "status_path", Replacement: "status.path", Since: "8.0.0",
+	}))
+	require.NoError(t, registry.AddMigration(mb.Migration{
+		Module: "test", MetricSet: "stats", Setting: "extended", Replacement: "stats.metrics", Since: "8.1.0",
+		Convert: func(value, current interface{}) (interface{}, bool) {
+			if enabled, _ := value.(bool); !enabled {
+				return nil, false
+			}
+			return []interface{}{"basic", "extended"}, true
+		},
+	}))
+	require.NoError(t, registry.AddMigration(mb.Migration{
+		Module: "test", MetricSet: "info", Replacement: "status", Since: "8.2.0",
+	}))
+	require.NoError(t, registry.AddMigration(mb.Migration{
+		Module: "test", Setting: "legacy.enabled", Since: "8.3.0",
+	}))
+	assert.Error(t, registry.AddMigration(mb.Migration{Module: "test", MetricSet: "info"}))
+
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "metricbeat.yml")
+	moduleFile := filepath.Join(dir, "modules.d", "test.yml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(moduleFile), 0o755))
+	require.NoError(t, os.WriteFile(cfgFile, []byte(`metricbeat.config.modules:
+  path: ${path.config}/modules.d/*.yml
+
+# Modules
+metricbeat.modules:
+  - module: test
+    metricsets: ["info", "stats"]
+    # Collect the extended stats.
+    extended: true
+    legacy:
+      enabled: true
+  - module: other
+    extended: true
+`), 0o600))
+	require.NoError(t, os.WriteFile(moduleFile, []byte(`- module: test
+  metricsets: ["status"]
+  status_path: /server-status
+`), 0o600))
+
+	rawConfig := conf.MustNewConfigFrom(map[string]interface{}{
+		"metricbeat.config.modules.path": filepath.Join(dir, "modules.d", "*.yml"),
+	})
+
+	var out bytes.Buffer
+	require.NoError(t, MigrateConfig(&out, cfgFile, rawConfig, registry, true))
+	assert.Contains(t, out.String(), "# "+cfgFile+": config #1: metricset 'test/info' was removed in 8.2.0, use 'status' instead\n")
+	assert.Contains(t, out.String(), "# "+moduleFile+": config #1: setting 'status_path' of module 'test' was deprecated in 8.0.0, use 'status.path' instead\n")
+	assert.Contains(t, out.String(), "-  status_path: /server-status\n+  status.path: /server-status\n")
+
+	// Files are not changed in dry run mode.
+	data, err := os.ReadFile(moduleFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "status_path")
+
+	out.Reset()
+	require.NoError(t, MigrateConfig(&out, cfgFile, rawConfig, registry, false))
+	data, err = os.ReadFile(cfgFile)
+	require.NoError(t, err)
+	assert.Equal(t, `metricbeat.config.modules:
+  path: ${path.config}/modules.d/*.yml
+
+# Modules
+metricbeat.modules:
+  - module: test
+    metricsets: ["status", "stats"]
+    # Collect the extended stats.
+    stats.metrics: [basic, extended]
+  - module: other
+    extended: true
+`, string(data))
+	data, err = os.ReadFile(moduleFile)
+	require.NoError(t, err)
+	assert.Equal(t, "- module: test\n  metricsets: [\"status\"]\n  status.path: /server-status\n", string(data))
+
+	out.Reset()
+	require.NoError(t, MigrateConfig(&out, cfgFile, rawConfig, registry, false))
+	assert.Equal(t, "No deprecated settings found.\n", out.String())
+}
@@ -0,0 +1,205 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package beater
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/elastic/beats/v7/libbeat/cfgfile"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+// strictConfigModules is set by the --strict flag of the test config command.
+var strictConfigModules = flag.Bool("strict", false, "Also report the unknown settings in metricbeat.config.modules files, with the line they are defined in")
+
+// settingsChecker is implemented by runner factories that can report the
+// settings of a configuration that are not used.
+type settingsChecker interface {
+	UnusedSettings(config *conf.C) ([]string, error)
+}
+
+// checkConfigModules checks the module configurations in the files of
+// metricbeat.config.modules. Unlike cfgfile.Reloader.Check, it reports the
+// errors of all the configurations, with the file and position they are
+// defined in. Nothing is checked if reload is enabled, as errors may be fixed
+// before the files are reloaded, unless strict is set. In strict mode, the
+// settings not used by the factory are also reported if it implements
+// settingsChecker, and the errors include the line of the configuration or
+// setting.
+func checkConfigModules(cfg *conf.C, factory cfgfile.RunnerFactory, strict bool) error {
+	files, reload, err := configModulesFiles(cfg)
+	if err != nil {
+		return err
+	}
+	if reload && !strict {
+		return nil
+	}
+
+	var errs []error
+	for _, file := range files {
+		configs, err := cfgfile.LoadList(file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("loading configs: %w", err))
+			continue
+		}
+
+		if strict {
+			errs = append(errs, checkConfigsStrict(file, configs, factory)...)
+			continue
+		}
+		for i, c := range configs {
+			if !c.Enabled() {
+				continue
+			}
+			if err := factory.CheckConfig(c); err != nil {
+				errs = append(errs, fmt.Errorf("invalid config #%d in file '%s': %w", i+1, file, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// configModulesFiles returns the files matched by the path of the
+// metricbeat.config.modules configuration, and whether they are reloaded.
+func configModulesFiles(cfg *conf.C) (files []string, reload bool, err error) {
+	dynamicConfig := cfgfile.DefaultDynamicConfig
+	if err := cfg.Unpack(&dynamicConfig); err != nil {
+		return nil, false, fmt.Errorf("error reading metricbeat.config.modules: %w", err)
+	}
+
+	path := dynamicConfig.Path
+	if !filepath.IsAbs(path) {
+		path = paths.Resolve(paths.Config, path)
+	}
+	files, _, err = cfgfile.NewGlobWatcher(path).Scan()
+	if err != nil {
+		return nil, false, fmt.Errorf("fetching config files: %w", err)
+	}
+	return files, dynamicConfig.Reload.Enabled, nil
+}
+
+// checkConfigsStrict checks the configurations of a file in strict mode.
+func checkConfigsStrict(file string, configs []*conf.C, factory cfgfile.RunnerFactory) []error {
+	lines, err := loadConfigLines(file)
+	if err != nil {
+		return []error{fmt.Errorf("parsing config file '%s': %w", file, err)}
+	}
+	checker, _ := factory.(settingsChecker)
+
+	var errs []error
+	for i, c := range configs {
+		if !c.Enabled() {
+			continue
+		}
+		if err := factory.CheckConfig(c); err != nil {
+			errs = append(errs, fmt.Errorf("invalid config #%d in file '%s' at line %d: %w", i+1, file, lines.line(i, ""), err))
+			continue
+		}
+		if checker == nil {
+			continue
+		}
+		unused, err := checker.UnusedSettings(c)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid config #%d in file '%s' at line %d: %w", i+1, file, lines.line(i, ""), err))
+			continue
+		}
+		for _, setting := range unused {
+			errs = append(errs, fmt.Errorf("invalid config #%d in file '%s': unknown setting '%s' at line %d", i+1, file, setting, lines.line(i, setting)))
+		}
+	}
+	return errs
+}
+
+// configLines finds the lines of the configurations of a file, and of their
+// settings.
+type configLines struct {
+	list *yaml.Node // Sequence of the configurations, nil if not found.
+}
+
+func loadConfigLines(file string) (*configLines, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	lines := &configLines{}
+	if len(doc.Content) > 0 && doc.Content[0].Kind == yaml.SequenceNode {
+		lines.list = doc.Content[0]
+	}
+	return lines, nil
+}
+
+// line returns the line of the setting with the given dotted path in the
+// configuration with index i, or of the configuration if path is empty. If the
+// setting is not found, as when it is defined by a variable, the line of its
+// closest parent is returned. Zero is returned if the configuration is not
+// found.
+func (l *configLines) line(i int, path string) int {
+	if l.list == nil || i >= len(l.list.Content) {
+		return 0
+	}
+	node := l.list.Content[i]
+	var keys []string
+	if path != "" {
+		keys = strings.Split(path, ".")
+	}
+	line := node.Line
+	for len(keys) > 0 {
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			// Keys can be dotted, like ssl.verification_mode.
+			for j := 0; j+1 < len(node.Content) && next == nil; j += 2 {
+				key := node.Content[j]
+				n := len(strings.Split(key.Value, "."))
+				switch {
+				case n <= len(keys) && strings.Join(keys[:n], ".") == key.Value:
+					line = key.Line
+					next = node.Content[j+1]
+					keys = keys[n:]
+				case n > len(keys) && strings.HasPrefix(key.Value, strings.Join(keys, ".")+"."):
+					// The setting is a parent of the dotted key.
+					return key.Line
+				}
+			}
+		case yaml.SequenceNode:
+			if idx, err := strconv.Atoi(keys[0]); err == nil && idx < len(node.Content) {
+				next = node.Content[idx]
+				line = next.Line
+				keys = keys[1:]
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return line
+}
@@ -0,0 +1,130 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package beater
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/cfgfile"
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestCheckConfigModules(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	writeFile("a.yml", "- module: valid\n- module: invalid\n- module: invalid\n  enabled: false\n")
+	writeFile("b.yml", "- module: valid\n- module: invalid\n")
+
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
+		"path": filepath.Join(dir, "*.yml"),
+	})
+	err := checkConfigModules(cfg, checkFactory{}, false)
+	require.Error(t, err)
+	assert.Equal(t, "invalid config #2 in file '"+filepath.Join(dir, "a.yml")+"': invalid module\n"+
+		"invalid config #2 in file '"+filepath.Join(dir, "b.yml")+"': invalid module", err.Error())
+
+	// Errors are not reported when configurations are reloaded.
+	cfg = conf.MustNewConfigFrom(map[string]interface{}{
+		"path":           filepath.Join(dir, "*.yml"),
+		"reload.enabled": true,
+	})
+	assert.NoError(t, checkConfigModules(cfg, checkFactory{}, false))
+}
+
+// checkFactory is a runner factory whose CheckConfig fails for the "invalid"
+// module.
+type checkFactory struct{}
+
+func (checkFactory) Create(beat.PipelineConnector, *conf.C) (cfgfile.Runner, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (checkFactory) CheckConfig(c *conf.C) error {
+	var config struct {
+		Module string `config:"module"`
+	}
+	if err := c.Unpack(&config); err != nil {
+		return err
+	}
+	if config.Module == "invalid" {
+		return errors.New("invalid module")
+	}
+	return nil
+}
+
+func TestCheckConfigModulesStrict(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.yml")
+	require.NoError(t, os.WriteFile(file, []byte(`- module: valid
+  usrname: elastic
+- module: invalid
+- module: valid
+  ssl.verfication_mode: none
+  process.include_top_n.by_cpu: 5
+  servers:
+    - name: a
+    - nmae: b
+`), 0o600))
+
+	// Configurations are checked even if they are reloaded.
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
+		"path":           filepath.Join(dir, "*.yml"),
+		"reload.enabled": true,
+	})
+	err := checkConfigModules(cfg, strictCheckFactory{}, true)
+	require.Error(t, err)
+	assert.Equal(t, "invalid config #1 in file '"+file+"': unknown setting 'usrname' at line 2\n"+
+		"invalid config #2 in file '"+file+"' at line 3: invalid module\n"+
+		"invalid config #3 in file '"+file+"': unknown setting 'process' at line 6\n"+
+		"invalid config #3 in file '"+file+"': unknown setting 'servers.1.nmae' at line 9\n"+
+		"invalid config #3 in file '"+file+"': unknown setting 'ssl.verfication_mode' at line 5", err.Error())
+}
+
+// strictCheckFactory is a checkFactory that reports the settings that are not
+// module, name or ssl.verification_mode as unused, including nested ones.
+type strictCheckFactory struct {
+	checkFactory
+}
+
+func (strictCheckFactory) UnusedSettings(c *conf.C) ([]string, error) {
+	var unused []string
+	for _, key := range c.FlattenedKeys() {
+		// The keys of the configurations of a list start with their index.
+		_, key, _ = strings.Cut(key, ".")
+		switch {
+		case key == "module", key == "ssl.verification_mode", strings.HasSuffix(key, ".name"):
+		case strings.HasPrefix(key, "process."):
+			unused = append(unused, "process")
+		default:
+			unused = append(unused, key)
+		}
+	}
+	return unused, nil
+}
@@ -0,0 +1,84 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package beater
+
+import (
+	"flag"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// setupModules is set by the --modules flag of the setup command.
+var setupModules = flag.String("modules", "", "List of modules whose dashboards are loaded (comma separated), the dashboards of the other modules are removed")
+
+// dashboardTitle matches the titles of the dashboards of the modules, like
+// "[Metricbeat Nginx] Overview ECS", and captures the name of the module.
+var dashboardTitle = regexp.MustCompile(`^\[Metricbeat ([^\]]+)\]`)
+
+// dashboardsFilter returns a filter that selects the dashboards of the given
+// modules, by the name of the module in their title. The modules must be
+// registered. Dashboards that don't belong to a module are always selected.
+func dashboardsFilter(registry *mb.Register, modules string) (beat.DashboardsFilter, error) {
+	registered := map[string]bool{}
+	for _, module := range registry.Modules() {
+		registered[normalizeModuleName(module)] = true
+	}
+
+	selected := map[string]bool{}
+	for _, module := range strings.Split(modules, ",") {
+		module = strings.TrimSpace(module)
+		if module == "" {
+			continue
+		}
+		name := normalizeModuleName(module)
+		if !registered[name] {
+			return nil, fmt.Errorf("unknown module '%s' in --modules", module)
+		}
+		selected[name] = true
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no modules in --modules")
+	}
+
+	return func(title string) bool {
+		m := dashboardTitle.FindStringSubmatch(title)
+		if m == nil {
+			return true
+		}
+		return selected[normalizeModuleName(m[1])]
+	}, nil
+}
+
+// normalizeModuleName returns the name of a module in lower case and without
+// separators, so the names in the titles of the dashboards, like "AWSFargate"
+// or "PHP-FPM", match the names of the registry.
+func normalizeModuleName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		}
+		return -1
+	}, name)
+}
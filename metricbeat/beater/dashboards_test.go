@@ -0,0 +1,51 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package beater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+func TestDashboardsFilter(t *testing.T) {
+	registry := mb.NewRegister()
+	factory := func(base mb.BaseMetricSet) (mb.MetricSet, error) { return nil, nil }
+	for _, module := range []string{"nginx", "redis", "awsfargate", "php_fpm"} {
+		require.NoError(t, registry.AddMetricSet(module, "status", factory))
+	}
+
+	filter, err := dashboardsFilter(registry, "nginx, awsfargate,php_fpm")
+	require.NoError(t, err)
+	assert.True(t, filter("[Metricbeat Nginx] Overview ECS"))
+	assert.True(t, filter("[Metricbeat AWSFargate] Fargate Overview"))
+	assert.True(t, filter("[Metricbeat PHP-FPM] Overview"))
+	assert.False(t, filter("[Metricbeat Redis] Overview ECS"))
+	assert.True(t, filter("Custom dashboard"))
+
+	_, err = dashboardsFilter(registry, "nginx,unknown")
+	assert.EqualError(t, err, "unknown module 'unknown' in --modules")
+
+	_, err = dashboardsFilter(registry, " , ")
+	assert.Error(t, err)
+}
@@ -0,0 +1,200 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package embed runs Metricbeat modules in other Go programs, without the
+// Beat runtime: there is no publisher pipeline, output, or configuration file.
+// The program registers the modules it uses, adds the configurations of the
+// modules to a Collector, and consumes the events they report from a single
+// channel.
+//
+// The modules of Metricbeat are registered in mb.Registry by importing the
+// packages of the modules and of their metricsets, like
+// github.com/elastic/beats/v7/metricbeat/module/system and
+// github.com/elastic/beats/v7/metricbeat/module/system/cpu, or
+// github.com/elastic/beats/v7/metricbeat/include for all of them. Other
+// modules can be registered in the registry of the Collector with
+// mb.Register.MustAddMetricSet.
+//
+// The processors of the module configurations are applied to the events. The
+// other settings handled by the publisher pipeline, like fields, tags and
+// index, are ignored.
+package embed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/module"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// Collector runs the modules added to it, and sends the events they report to
+// a single channel.
+type Collector struct {
+	logger   *logp.Logger
+	registry *mb.Register
+	options  []module.Option
+
+	mu      sync.Mutex
+	modules []*collectorModule
+	started bool
+}
+
+// collectorModule is a module of a Collector, with the processors of its
+// configuration.
+type collectorModule struct {
+	wrapper    *module.Wrapper
+	processors *processors.Processors
+}
+
+// Option configures a Collector.
+type Option func(c *Collector)
+
+// WithRegistry sets the registry of the modules of the Collector, instead of
+// mb.Registry.
+func WithRegistry(registry *mb.Register) Option {
+	return func(c *Collector) {
+		c.registry = registry
+	}
+}
+
+// WithModuleOptions sets the options used to create the modules, instead of
+// module.WithMetricSetInfo and module.WithServiceName, that add the same
+// fields as Metricbeat to the events.
+func WithModuleOptions(options ...module.Option) Option {
+	return func(c *Collector) {
+		c.options = options
+	}
+}
+
+// NewCollector returns a new Collector, with no modules.
+func NewCollector(options ...Option) *Collector {
+	c := &Collector{
+		logger:   logp.NewLogger("embed"),
+		registry: mb.Registry,
+		options:  []module.Option{module.WithMetricSetInfo(), module.WithServiceName()},
+	}
+	for _, opt := range options {
+		opt(c)
+	}
+	return c
+}
+
+// Registry returns the registry of the modules of the Collector.
+func (c *Collector) Registry() *mb.Register {
+	return c.registry
+}
+
+// NewWrapper creates the Wrapper of a module configuration with the registry
+// and the options of the Collector, without adding it to the Collector. It
+// can be used to run a module separately, or to fetch it once.
+func (c *Collector) NewWrapper(config *conf.C) (*module.Wrapper, error) {
+	return module.NewWrapper(config, c.registry, c.options...)
+}
+
+// Add creates the Wrapper of a module configuration and adds it to the
+// Collector. Modules can't be added once the Collector is started.
+func (c *Collector) Add(config *conf.C) (*module.Wrapper, error) {
+	var procConfig struct {
+		Processors processors.PluginConfig `config:"processors"`
+	}
+	if err := config.Unpack(&procConfig); err != nil {
+		return nil, err
+	}
+	procs, err := processors.New(procConfig.Processors)
+	if err != nil {
+		return nil, fmt.Errorf("error creating processors: %w", err)
+	}
+
+	wrapper, err := c.NewWrapper(config)
+	if err != nil {
+		_ = processors.Close(procs)
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.started {
+		_ = processors.Close(procs)
+		return nil, errors.New("modules can't be added to a started collector")
+	}
+	c.modules = append(c.modules, &collectorModule{wrapper: wrapper, processors: procs})
+	return wrapper, nil
+}
+
+// Start starts the modules of the Collector, and returns the channel of their
+// events, that must be drained. The modules run until ctx is done or Stop is
+// called, then the channel is closed. Start can be called only once.
+func (c *Collector) Start(ctx context.Context) (<-chan beat.Event, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.started {
+		return nil, errors.New("collector already started")
+	}
+	c.started = true
+
+	out := make(chan beat.Event)
+	var wg sync.WaitGroup
+	for _, m := range c.modules {
+		events := m.wrapper.StartWithContext(ctx)
+		wg.Add(1)
+		go func(m *collectorModule) {
+			defer wg.Done()
+			defer processors.Close(m.processors) //nolint:errcheck // Errors closing processors are not relevant when stopping.
+			for event := range events {
+				processed, err := m.processors.Run(&event)
+				if err != nil {
+					c.logger.Errorf("Dropping event of %s: %v", m.wrapper, err)
+					continue
+				}
+				if processed == nil {
+					// The event was dropped by the processors.
+					continue
+				}
+				out <- *processed
+			}
+		}(m)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out, nil
+}
+
+// Stop stops the modules of the Collector. The fetches in progress are given
+// until ctx is done to finish and report their events, then they are aborted.
+// The channel of the events is closed once all the modules have stopped.
+func (c *Collector) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	modules := c.modules
+	c.mu.Unlock()
+
+	var errs []error
+	for _, m := range modules {
+		if err := m.wrapper.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("error stopping %s: %w", m.wrapper, err))
+		}
+	}
+	return errors.Join(errs...)
+}
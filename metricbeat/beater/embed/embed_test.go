@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package embed_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/elastic/beats/v7/libbeat/processors/actions"
+	"github.com/elastic/beats/v7/metricbeat/beater/embed"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type counterMetricSet struct {
+	mb.BaseMetricSet
+}
+
+func (m *counterMetricSet) Fetch(r mb.ReporterV2) error {
+	r.Event(mb.Event{MetricSetFields: mapstr.M{"host": m.Host()}})
+	return nil
+}
+
+func TestCollector(t *testing.T) {
+	collector := embed.NewCollector(embed.WithRegistry(mb.NewRegister()))
+	require.NoError(t, collector.Registry().AddMetricSet("embedded", "counter", func(base mb.BaseMetricSet) (mb.MetricSet, error) {
+		return &counterMetricSet{BaseMetricSet: base}, nil
+	}))
+
+	_, err := collector.Add(conf.MustNewConfigFrom(map[string]interface{}{
+		"module":     "embedded",
+		"metricsets": []string{"counter"},
+		"hosts":      []string{"a", "b"},
+		"period":     "1h",
+		"processors": []map[string]interface{}{
+			{"drop_event.when.equals.embedded.counter.host": "b"},
+			{"add_fields": map[string]interface{}{"target": "", "fields": map[string]interface{}{"env": "test"}}},
+		},
+	}))
+	require.NoError(t, err)
+	_, err = collector.Add(conf.MustNewConfigFrom(map[string]interface{}{
+		"module":     "embedded",
+		"metricsets": []string{"unknown"},
+	}))
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	events, err := collector.Start(ctx)
+	require.NoError(t, err)
+	_, err = collector.Start(ctx)
+	assert.Error(t, err)
+	_, err = collector.Add(conf.MustNewConfigFrom(map[string]interface{}{"module": "embedded"}))
+	assert.Error(t, err)
+
+	event := <-events
+	host, _ := event.Fields.GetValue("embedded.counter.host")
+	assert.Equal(t, "a", host)
+	env, _ := event.Fields.GetValue("env")
+	assert.Equal(t, "test", env)
+	dataset, _ := event.Fields.GetValue("event.dataset")
+	assert.Equal(t, "embedded.counter", dataset)
+
+	require.NoError(t, collector.Stop(ctx))
+	for event := range events {
+		// The event of the other host is dropped by the processors.
+		host, _ := event.Fields.GetValue("embedded.counter.host")
+		assert.NotEqual(t, "b", host)
+	}
+}
@@ -18,8 +18,12 @@
 package beater
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
 	"github.com/elastic/beats/v7/libbeat/autodiscover"
 	"github.com/elastic/beats/v7/libbeat/beat"
@@ -27,10 +31,14 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common/reload"
 	"github.com/elastic/beats/v7/libbeat/management"
 	"github.com/elastic/beats/v7/libbeat/monitoring/inputmon"
+	"github.com/elastic/beats/v7/libbeat/monitoring/prometheus"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/metricbeat/mb/module"
+	"github.com/elastic/beats/v7/metricbeat/mb/plugin"
+	"github.com/elastic/beats/v7/metricbeat/tracing"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
 	"github.com/elastic/elastic-agent-libs/paths"
 
 	// include all metricbeat specific builders
@@ -43,6 +51,10 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/processor/add_kubernetes_metadata"
 )
 
+// tracingShutdownTimeout is the maximum time to export the pending traces on
+// shutdown.
+const tracingShutdownTimeout = 5 * time.Second
+
 // Metricbeat implements the Beater interface for metricbeat.
 type Metricbeat struct {
 	done         chan struct{}    // Channel used to initiate shutdown.
@@ -51,6 +63,9 @@ type Metricbeat struct {
 	config       Config
 	registry     *mb.Register
 	autodiscover *autodiscover.Autodiscover
+	plugins      *plugin.Plugins // Plugins serving external metricsets.
+
+	tracerProvider *sdktrace.TracerProvider // Exports the traces of fetches, if enabled.
 
 	// Options
 	moduleOptions []module.Option
@@ -138,7 +153,7 @@ func DefaultTestModulesCreator() beat.Creator {
 }
 
 // newMetricbeat creates and returns a new Metricbeat instance.
-func newMetricbeat(b *beat.Beat, c *conf.C, registry *mb.Register, options ...Option) (*Metricbeat, error) {
+func newMetricbeat(b *beat.Beat, c *conf.C, registry *mb.Register, options ...Option) (_ *Metricbeat, err error) {
 	config := defaultConfig
 	if err := c.Unpack(&config); err != nil {
 		return nil, fmt.Errorf("error reading configuration file: %w", err)
@@ -158,18 +173,84 @@ func newMetricbeat(b *beat.Beat, c *conf.C, registry *mb.Register, options ...Op
 		applyOption(metricbeat)
 	}
 
-	// List all registered modules and metricsets.
-	logp.Debug("modules", "Available modules and metricsets: %s", registry.String())
+	// Stop the plugins and the tracer provider started below if the instance
+	// cannot be created, Run is the one stopping them otherwise.
+	defer func() {
+		if err != nil {
+			metricbeat.plugins.Close()
+			metricbeat.shutdownTracing()
+		}
+	}()
+
+	// The fetch limiter is shared by all modules, including the ones started
+	// dynamically, so that the limit applies to the whole beat.
+	if limiter := module.NewFetchLimiter(config.MaxConcurrentFetches); limiter != nil {
+		metricbeat.moduleOptions = append(metricbeat.moduleOptions, module.WithFetchLimiter(limiter))
+	}
+
+	// References to the keystore in module configurations are resolved when
+	// the modules are created, including the ones started dynamically.
+	if b.Keystore != nil {
+		registry.SetKeystore(b.Keystore)
+	}
 
 	if b.InSetupCmd {
+		if *setupModules != "" {
+			filter, err := dashboardsFilter(registry, *setupModules)
+			if err != nil {
+				return nil, err
+			}
+			b.DashboardsFilter = filter
+		}
 		// Return without instantiating the metricsets.
 		return metricbeat, nil
 	}
 
+	if config.Tracing.Enabled {
+		provider, err := tracing.NewTracerProvider(b.Info, config.Tracing)
+		if err != nil {
+			return nil, err
+		}
+		metricbeat.tracerProvider = provider
+		metricbeat.moduleOptions = append(metricbeat.moduleOptions, module.WithTracer(provider.Tracer("github.com/elastic/beats/v7/metricbeat")))
+	}
+
+	if config.Plugins.Path != "" {
+		pluginsConfig := config.Plugins
+		pluginsConfig.Path = paths.Resolve(paths.Home, pluginsConfig.Path)
+		plugins, err := plugin.Load(registry, pluginsConfig)
+		if err != nil {
+			return nil, err
+		}
+		metricbeat.plugins = plugins
+	}
+
+	// List all registered modules and metricsets.
+	logp.Debug("modules", "Available modules and metricsets: %s", registry.String())
+
 	if b.API != nil {
 		if err := inputmon.AttachHandler(b.API.Router()); err != nil {
 			return nil, fmt.Errorf("failed attach inputs api to monitoring endpoint server: %w", err)
 		}
+		if err := b.API.AttachHandler("/metricsets", module.HealthHandler()); err != nil {
+			return nil, fmt.Errorf("failed attach metricsets api to monitoring endpoint server: %w", err)
+		}
+		if config.StatsResetToken != "" {
+			if err := b.API.AttachHandler("/metricsets/reset", module.StatsResetHandler(config.StatsResetToken)); err != nil {
+				return nil, fmt.Errorf("failed attach metricsets stats reset api to monitoring endpoint server: %w", err)
+			}
+		}
+		if config.DebugPage.Enabled {
+			if err := b.API.AttachHandler("/debug/metricsets", module.HealthPageHandler(config.DebugPage.Refresh)); err != nil {
+				return nil, fmt.Errorf("failed attach metricsets debug page to monitoring endpoint server: %w", err)
+			}
+		}
+		if err := b.API.AttachHandler("/metrics", prometheus.NewHandler(monitoring.Default,
+			prometheus.WithLabels("metricbeat.{module}.{metricset}.hosts.{host}"),
+			prometheus.WithLabels("metricbeat.{module}.{metricset}"),
+		)); err != nil {
+			return nil, fmt.Errorf("failed attach prometheus metrics to monitoring endpoint server: %w", err)
+		}
 	}
 
 	if b.Manager != nil {
@@ -182,6 +263,15 @@ func newMetricbeat(b *beat.Beat, c *conf.C, registry *mb.Register, options ...Op
 				}
 				return data
 			})
+		b.Manager.RegisterDiagnosticHook("metricsets", "Health, redacted configuration, stats, recent errors and goroutines of the running metricsets.",
+			"metricsets.json", "application/json", func() []byte {
+				data, err := module.DiagnosticsJSON()
+				if err != nil {
+					logp.L().Warnw("Failed to collect metricsets diagnostics for Agent diagnostics.", "error", err)
+					return []byte(err.Error())
+				}
+				return data
+			})
 	}
 
 	moduleOptions := append(
@@ -203,6 +293,15 @@ func newMetricbeat(b *beat.Beat, c *conf.C, registry *mb.Register, options ...Op
 		metricbeat.runners = append(metricbeat.runners, runner)
 	}
 
+	// The configurations in metricbeat.config.modules are checked here and not
+	// when the reloader is started in Run, so their errors are also reported
+	// by the test config command.
+	if config.ConfigModules.Enabled() {
+		if err := checkConfigModules(config.ConfigModules, factory, *strictConfigModules); err != nil {
+			return nil, err
+		}
+	}
+
 	if len(metricbeat.runners) == 0 && !dynamicCfgEnabled {
 		return nil, mb.ErrAllModulesDisabled
 	}
@@ -230,6 +329,9 @@ func newMetricbeat(b *beat.Beat, c *conf.C, registry *mb.Register, options ...Op
 // that a single unresponsive host cannot inadvertently block other hosts
 // within the same Module and MetricSet from collection.
 func (bt *Metricbeat) Run(b *beat.Beat) error {
+	defer bt.shutdownTracing()
+	defer bt.plugins.Close()
+
 	var wg sync.WaitGroup
 
 	// Static modules (metricbeat.runners)
@@ -263,14 +365,10 @@ func (bt *Metricbeat) Run(b *beat.Beat) error {
 	}
 	defer b.Manager.Stop()
 
-	// Dynamic file based modules (metricbeat.config.modules)
+	// Dynamic file based modules (metricbeat.config.modules), already checked
+	// by newMetricbeat.
 	if bt.config.ConfigModules.Enabled() {
 		moduleReloader := cfgfile.NewReloader(b.Publisher, bt.config.ConfigModules)
-
-		if err := moduleReloader.Check(factory); err != nil {
-			return err
-		}
-
 		go moduleReloader.Run(factory)
 		wg.Add(1)
 		go func() {
@@ -306,6 +404,19 @@ func (bt *Metricbeat) Stop() {
 
 }
 
+// shutdownTracing exports the pending traces of fetches, if tracing is
+// enabled.
+func (bt *Metricbeat) shutdownTracing() {
+	if bt.tracerProvider == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	if err := bt.tracerProvider.Shutdown(ctx); err != nil {
+		logp.Warn("Failed to export the pending traces: %v", err)
+	}
+}
+
 // Modules return a list of all configured modules.
 func (bt *Metricbeat) Modules() ([]*module.Wrapper, error) {
 	return module.ConfiguredModules(bt.registry, bt.config.Modules, bt.config.ConfigModules, bt.moduleOptions)
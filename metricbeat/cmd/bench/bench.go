@@ -0,0 +1,191 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package bench implements the command that benchmarks the fetches of a
+// metricset.
+package bench
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/v7/libbeat/cmd/instance"
+	"github.com/elastic/beats/v7/metricbeat/cmd/fetch"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/module"
+	"github.com/elastic/elastic-agent-libs/service"
+)
+
+// GenBenchCmd returns the command that fetches a metricset repeatedly for a
+// duration and reports its performance.
+func GenBenchCmd(name, beatVersion string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Benchmark the fetches of a metricset",
+		Long: `Fetch a metricset repeatedly, without publishing its events, and report the
+number of events per second, the fetch latencies and the memory allocated per
+fetch. The module is configured as in the fetch command. Use -cpuprofile and
+-memprofile to write profiles of the benchmark.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			moduleName, _ := cmd.Flags().GetString("module")
+			metricSetName, _ := cmd.Flags().GetString("metricset")
+			hosts, _ := cmd.Flags().GetStringArray("host")
+			configFile, _ := cmd.Flags().GetString("config")
+			duration, _ := cmd.Flags().GetDuration("duration")
+			if moduleName == "" || metricSetName == "" {
+				fmt.Fprintln(os.Stderr, "The --module and --metricset flags are required")
+				os.Exit(1)
+			}
+
+			if _, err := instance.NewInitializedBeat(instance.Settings{Name: name, Version: beatVersion}); err != nil {
+				fmt.Fprintf(os.Stderr, "Error initializing beat: %s\n", err)
+				os.Exit(1)
+			}
+
+			config, err := fetch.ModuleConfig(moduleName, metricSetName, hosts, configFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading module configuration: %s\n", err)
+				os.Exit(1)
+			}
+			wrapper, err := module.NewWrapper(config, mb.Registry, module.WithMetricSetInfo(), module.WithServiceName())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating metricset: %s\n", err)
+				os.Exit(1)
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+			service.BeforeRun()
+			result, err := run(ctx, wrapper, duration)
+			service.Cleanup()
+			wrapper.Close()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error benchmarking metricset: %s\n", err)
+				os.Exit(1)
+			}
+
+			result.write(os.Stdout)
+			if result.errors > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().String("module", "", "Name of the module")
+	cmd.Flags().String("metricset", "", "Name of the metricset")
+	cmd.Flags().StringArray("host", nil, "Host to fetch the metricset from, can be repeated")
+	cmd.Flags().String("config", "", "File with the settings of the module, as in the modules.d directory")
+	cmd.Flags().Duration("duration", 10*time.Second, "Duration of the benchmark")
+	cmd.Flags().AddGoFlag(flag.CommandLine.Lookup("cpuprofile"))
+	cmd.Flags().AddGoFlag(flag.CommandLine.Lookup("memprofile"))
+	return cmd
+}
+
+// result is the result of a benchmark.
+type result struct {
+	fetches   int
+	events    int
+	errors    int             // Error events.
+	elapsed   time.Duration   // Duration of all the fetches.
+	latencies []time.Duration // Duration of each fetch, sorted.
+	allocs    uint64          // Heap objects allocated.
+	bytes     uint64          // Heap bytes allocated.
+}
+
+// run fetches the MetricSets of the wrapper back to back until the duration
+// has elapsed or ctx is done. Each fetch fetches all the hosts of the module.
+func run(ctx context.Context, wrapper *module.Wrapper, duration time.Duration) (*result, error) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	r := &result{}
+	start := time.Now()
+	for time.Since(start) < duration && ctx.Err() == nil {
+		fetchStart := time.Now()
+		events, err := wrapper.Fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		r.latencies = append(r.latencies, time.Since(fetchStart))
+		r.fetches++
+		for _, event := range events {
+			if ok, _ := event.Fields.HasKey("error.message"); ok {
+				r.errors++
+			} else {
+				r.events++
+			}
+		}
+	}
+	r.elapsed = time.Since(start)
+
+	runtime.ReadMemStats(&after)
+	r.allocs = after.Mallocs - before.Mallocs
+	r.bytes = after.TotalAlloc - before.TotalAlloc
+	sort.Slice(r.latencies, func(i, j int) bool { return r.latencies[i] < r.latencies[j] })
+	return r, nil
+}
+
+// percentile returns the latency below which the given fraction of the fetches
+// are, using the nearest-rank method.
+func (r *result) percentile(p float64) time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+	rank := int(p*float64(len(r.latencies))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(r.latencies) {
+		rank = len(r.latencies) - 1
+	}
+	return r.latencies[rank]
+}
+
+// write writes a report of the result.
+func (r *result) write(w io.Writer) {
+	perSecond := func(n int) float64 {
+		if r.elapsed <= 0 {
+			return 0
+		}
+		return float64(n) / r.elapsed.Seconds()
+	}
+	perFetch := func(n uint64) uint64 {
+		if r.fetches == 0 {
+			return 0
+		}
+		return n / uint64(r.fetches)
+	}
+
+	fmt.Fprintf(w, "Duration:      %v\n", r.elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "Fetches:       %d (%.1f/s)\n", r.fetches, perSecond(r.fetches))
+	fmt.Fprintf(w, "Events:        %d (%.1f/s)\n", r.events, perSecond(r.events))
+	fmt.Fprintf(w, "Errors:        %d\n", r.errors)
+	if len(r.latencies) > 0 {
+		fmt.Fprintf(w, "Fetch latency: p50 %v, p95 %v, max %v\n",
+			r.percentile(0.50), r.percentile(0.95), r.latencies[len(r.latencies)-1])
+	}
+	fmt.Fprintf(w, "Allocations:   %d allocs/fetch, %d B/fetch\n", perFetch(r.allocs), perFetch(r.bytes))
+}
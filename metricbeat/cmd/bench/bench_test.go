@@ -0,0 +1,87 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package bench
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/cmd/fetch"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/module"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type fakeMetricSet struct {
+	mb.BaseMetricSet
+}
+
+// Fetch fails for the host "down", and reports two events for the others.
+func (ms *fakeMetricSet) Fetch(r mb.ReporterV2) error {
+	if ms.Host() == "down" {
+		return errors.New("connection refused")
+	}
+	r.Event(mb.Event{MetricSetFields: mapstr.M{"value": 1}})
+	r.Event(mb.Event{MetricSetFields: mapstr.M{"value": 2}})
+	return nil
+}
+
+func TestRun(t *testing.T) {
+	r := mb.NewRegister()
+	require.NoError(t, r.AddMetricSet("fake", "status", func(base mb.BaseMetricSet) (mb.MetricSet, error) {
+		return &fakeMetricSet{BaseMetricSet: base}, nil
+	}))
+	config, err := fetch.ModuleConfig("fake", "status", []string{"alpha", "down"}, "")
+	require.NoError(t, err)
+	wrapper, err := module.NewWrapper(config, r)
+	require.NoError(t, err)
+	defer wrapper.Close()
+
+	result, err := run(context.Background(), wrapper, 50*time.Millisecond)
+	require.NoError(t, err)
+
+	require.Positive(t, result.fetches)
+	assert.Equal(t, 2*result.fetches, result.events)
+	assert.Equal(t, result.fetches, result.errors)
+	assert.Len(t, result.latencies, result.fetches)
+	assert.GreaterOrEqual(t, result.elapsed, 50*time.Millisecond)
+
+	var buf bytes.Buffer
+	result.write(&buf)
+	assert.Contains(t, buf.String(), "Fetch latency: p50")
+}
+
+func TestPercentile(t *testing.T) {
+	r := &result{}
+	assert.Zero(t, r.percentile(0.5))
+
+	for i := 1; i <= 100; i++ {
+		r.latencies = append(r.latencies, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, 50*time.Millisecond, r.percentile(0.50))
+	assert.Equal(t, 95*time.Millisecond, r.percentile(0.95))
+	assert.Equal(t, 100*time.Millisecond, r.percentile(1))
+}
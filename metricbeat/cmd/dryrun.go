@@ -0,0 +1,137 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	"github.com/elastic/beats/v7/libbeat/publisher/processing"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// dryRun is set by the --dry-run flag of the run command.
+var dryRun dryRunFlag
+
+func init() {
+	flag.CommandLine.Var(&dryRun, "dry-run", "Print the processed events to stdout instead of publishing them, the output is not used")
+}
+
+// dryRunFlag is a boolean flag that also disables the outputs with the -N flag
+// when it is set, so the configured output is never connected.
+type dryRunFlag bool
+
+func (f *dryRunFlag) String() string   { return strconv.FormatBool(bool(*f)) }
+func (f *dryRunFlag) IsBoolFlag() bool { return true }
+
+func (f *dryRunFlag) Set(s string) error {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	*f = dryRunFlag(v)
+	if v {
+		return flag.CommandLine.Set("N", "true")
+	}
+	return nil
+}
+
+// WithDryRun wraps a processing support factory, so in dry-run mode the events
+// are printed to stdout after all the processors are applied, and then dropped.
+func WithDryRun(factory processing.SupportFactory) processing.SupportFactory {
+	return func(info beat.Info, log *logp.Logger, cfg *config.C) (processing.Supporter, error) {
+		support, err := factory(info, log, cfg)
+		if err != nil || !bool(dryRun) {
+			return support, err
+		}
+		return newDryRunSupport(support, info, os.Stdout), nil
+	}
+}
+
+// dryRunSupport is a processing.Supporter whose processors print the events
+// instead of publishing them.
+type dryRunSupport struct {
+	processing.Supporter
+
+	beat    string
+	mu      sync.Mutex // Serializes the events printed by different clients.
+	out     io.Writer
+	encoder codec.Codec
+}
+
+func newDryRunSupport(support processing.Supporter, info beat.Info, out io.Writer) *dryRunSupport {
+	return &dryRunSupport{
+		Supporter: support,
+		beat:      info.Beat,
+		out:       out,
+		encoder:   json.New(info.Version, json.Config{Pretty: true, EscapeHTML: false}),
+	}
+}
+
+// Create creates the processors of a client. Events are never dropped by them,
+// as they are dropped after being printed.
+func (s *dryRunSupport) Create(cfg beat.ProcessingConfig, _ bool) (beat.Processor, error) {
+	p, err := s.Supporter.Create(cfg, false)
+	if err != nil {
+		return nil, err
+	}
+	return &dryRunProcessor{Processor: p, support: s}, nil
+}
+
+// print prints the event, annotated with its dataset.
+func (s *dryRunSupport) print(event *beat.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := s.encoder.Encode(s.beat, event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	dataset, _ := event.Fields.GetValue("event.dataset")
+	if dataset == nil {
+		dataset = "unknown"
+	}
+	_, err = fmt.Fprintf(s.out, "--- dataset: %v ---\n%s\n", dataset, data)
+	return err
+}
+
+type dryRunProcessor struct {
+	beat.Processor
+	support *dryRunSupport
+}
+
+func (p *dryRunProcessor) Run(event *beat.Event) (*beat.Event, error) {
+	event, err := p.Processor.Run(event)
+	if event == nil || err != nil {
+		return event, err
+	}
+	return nil, p.support.print(event)
+}
+
+func (p *dryRunProcessor) Close() error {
+	return processors.Close(p.Processor)
+}
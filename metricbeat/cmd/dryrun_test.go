@@ -0,0 +1,81 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/publisher/processing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestDryRunSupport(t *testing.T) {
+	var out bytes.Buffer
+	inner := &fakeSupport{}
+	support := newDryRunSupport(inner, beat.Info{Beat: "metricbeat", Version: "9.9.9"}, &out)
+
+	p, err := support.Create(beat.ProcessingConfig{}, true)
+	require.NoError(t, err)
+	assert.False(t, inner.drop, "the wrapped processors must not drop the events")
+
+	event, err := p.Run(&beat.Event{
+		Timestamp: time.Now(),
+		Fields:    mapstr.M{"event": mapstr.M{"dataset": "system.cpu"}},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, event, "events must be dropped after being printed")
+	assert.Contains(t, out.String(), "--- dataset: system.cpu ---\n")
+	assert.Contains(t, out.String(), `"processed": true`)
+
+	// Events dropped by the processors are not printed.
+	out.Reset()
+	event, err = p.Run(&beat.Event{Fields: mapstr.M{"drop": true}})
+	require.NoError(t, err)
+	assert.Nil(t, event)
+	assert.Empty(t, out.String())
+}
+
+type fakeSupport struct {
+	processing.Supporter
+	drop bool
+}
+
+func (s *fakeSupport) Create(_ beat.ProcessingConfig, drop bool) (beat.Processor, error) {
+	s.drop = drop
+	return fakeProcessor{}, nil
+}
+
+type fakeProcessor struct{}
+
+func (fakeProcessor) String() string { return "fake" }
+
+func (fakeProcessor) Run(event *beat.Event) (*beat.Event, error) {
+	if drop, _ := event.Fields.GetValue("drop"); drop == true {
+		return nil, nil
+	}
+	event.Fields["processed"] = true
+	return event, nil
+}
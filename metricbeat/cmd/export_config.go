@@ -0,0 +1,65 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/v7/libbeat/cmd"
+	"github.com/elastic/beats/v7/libbeat/cmd/instance"
+	"github.com/elastic/beats/v7/libbeat/common/cli"
+	"github.com/elastic/beats/v7/metricbeat/beater"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// AddExportConfigFlags adds the --resolved flag to the export config command,
+// to export the configuration with its variables resolved, the modules of the
+// metricbeat.config.modules files and the secrets redacted.
+func AddExportConfigFlags(rootCmd *cmd.BeatsRootCmd, settings instance.Settings) {
+	for _, c := range rootCmd.ExportCmd.Commands() {
+		if c.Name() != "config" {
+			continue
+		}
+		c.Flags().Bool("resolved", false, "Resolve the variables and the keystore references, include the modules of metricbeat.config.modules and redact the secrets")
+		run := c.Run
+		c.Run = func(cmd *cobra.Command, args []string) {
+			if resolved, _ := cmd.Flags().GetBool("resolved"); !resolved {
+				run(cmd, args)
+				return
+			}
+			cli.RunWith(func(cmd *cobra.Command, args []string) error {
+				return exportResolvedConfig(settings)
+			})(cmd, args)
+		}
+	}
+}
+
+func exportResolvedConfig(settings instance.Settings) error {
+	b, err := instance.NewInitializedBeat(settings)
+	if err != nil {
+		return fmt.Errorf("error initializing beat: %w", err)
+	}
+	// The keystore of the registry is set by the beater, which is not created
+	// to export the configuration.
+	store := b.Keystore()
+	mb.Registry.SetKeystore(store)
+	return beater.WriteResolvedConfig(os.Stdout, b.RawConfig, mb.Registry, store)
+}
@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/v7/libbeat/cmd/instance"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+// GenExportModuleReferenceCmd initializes a command that exports the
+// reference configuration of modules, generated from their config structs.
+func GenExportModuleReferenceCmd(name, version string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "module-reference [MODULE...]",
+		Short: "Export the reference configuration of modules",
+		Long: `Export the reference configuration of the given modules, or of all the modules,
+generated from the config structs of the modules and their metricsets, with
+the default values, types and descriptions of their settings.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			_, err := instance.NewInitializedBeat(instance.Settings{Name: name, Version: version})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error initializing beat: %s\n", err)
+				os.Exit(1)
+			}
+			mb.Registry.SetSecondarySource(mb.NewLightModulesSource(paths.Resolve(paths.Home, "module")))
+
+			if err := writeModuleReferences(os.Stdout, mb.Registry, args); err != nil {
+				fmt.Fprintf(os.Stderr, "Error exporting module reference: %s\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+}
+
+// writeModuleReferences writes the reference configuration of the modules, or
+// of all the modules of the registry if none is given, under a header with
+// the name of each module.
+func writeModuleReferences(w io.Writer, registry *mb.Register, modules []string) error {
+	if len(modules) == 0 {
+		// Modules without metricsets, like the ones only available in other
+		// platforms, are skipped.
+		for _, module := range registry.Modules() {
+			if len(registry.MetricSets(module)) > 0 {
+				modules = append(modules, module)
+			}
+		}
+		sort.Strings(modules)
+	}
+	for i, module := range modules {
+		reference, err := mb.ModuleReference(registry, module)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, moduleReferenceHeader(module))
+		if _, err := w.Write(reference); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moduleReferenceHeader returns a header line for the module, like the ones of
+// the reference configuration file, with the title centered between dashes.
+func moduleReferenceHeader(module string) string {
+	const lineLen = 80
+	title := module
+	if title != "" {
+		title = strings.ToUpper(title[:1]) + title[1:]
+	}
+	numDashes := (lineLen - len("#") - len(" Module ") - len(title) - 1) / 2
+	if numDashes < 0 {
+		numDashes = 0
+	}
+	dashes := strings.Repeat("-", numDashes)
+	return fmt.Sprintf("#%s %s Module %s", dashes, title, dashes)
+}
@@ -0,0 +1,194 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package fetch implements the command that fetches a metricset once and
+// prints its events.
+package fetch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/cmd/instance"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/module"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// GenFetchCmd returns the command that fetches a metricset once and prints its
+// events as JSON.
+func GenFetchCmd(name, beatVersion string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fetch <module> <metricset>",
+		Short: "Fetch a metricset once and print its events",
+		Long: `Fetch a metricset once and print its events as a JSON array, without publishing
+them. The module is configured with the settings of the file passed with
+--config, if any, and with the hosts passed with --host. The command exits with
+a non-zero status if the metricset can't be created or if it reports an error.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			hosts, _ := cmd.Flags().GetStringArray("host")
+			configFile, _ := cmd.Flags().GetString("config")
+
+			if _, err := instance.NewInitializedBeat(instance.Settings{Name: name, Version: beatVersion}); err != nil {
+				fmt.Fprintf(os.Stderr, "Error initializing beat: %s\n", err)
+				os.Exit(1)
+			}
+
+			config, err := ModuleConfig(args[0], args[1], hosts, configFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading module configuration: %s\n", err)
+				os.Exit(1)
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+			events, err := Fetch(ctx, config, mb.Registry)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error fetching metricset: %s\n", err)
+				os.Exit(1)
+			}
+
+			failed, err := writeEvents(os.Stdout, events)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing events: %s\n", err)
+				os.Exit(1)
+			}
+			if failed {
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().StringArray("host", nil, "Host to fetch the metricset from, can be repeated")
+	cmd.Flags().String("config", "", "File with the settings of the module, as in the modules.d directory")
+	return cmd
+}
+
+// Fetch creates the MetricSets of the given module configuration and fetches
+// them once. There is one MetricSet per host, unless the MetricSet fetches
+// all the hosts at once. The events of all of them are returned, including
+// the error events.
+func Fetch(ctx context.Context, config *conf.C, r *mb.Register) ([]beat.Event, error) {
+	aModule, metricSets, err := mb.NewModule(config, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []beat.Event
+	for _, metricSet := range metricSets {
+		wrapper, err := module.NewWrapperForMetricSet(aModule, metricSet, module.WithMetricSetInfo(), module.WithServiceName())
+		if err != nil {
+			return nil, err
+		}
+		fetched, err := wrapper.FetchOnce(ctx)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, fetched...)
+	}
+	return events, nil
+}
+
+// ModuleConfig returns the configuration of the metricset of the given
+// module, with the settings of the file, if any, and the given hosts. The file
+// can contain the settings of a module, or a list of modules as the files in
+// the modules.d directory, then the first configuration of the module is used.
+func ModuleConfig(moduleName, metricSet string, hosts []string, file string) (*conf.C, error) {
+	config := conf.NewConfig()
+	if file != "" {
+		var err error
+		config, err = loadModuleConfig(moduleName, file)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := config.Remove("metricsets", -1); err != nil {
+		return nil, err
+	}
+	settings := mapstr.M{
+		"module":     moduleName,
+		"metricsets": []string{metricSet},
+	}
+	if len(hosts) > 0 {
+		if _, err := config.Remove("hosts", -1); err != nil {
+			return nil, err
+		}
+		settings["hosts"] = hosts
+	}
+	if err := config.Merge(settings); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// loadModuleConfig loads the settings of the module from the file.
+func loadModuleConfig(moduleName, file string) (*conf.C, error) {
+	config, err := common.LoadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if !config.IsArray() {
+		return config, nil
+	}
+
+	var configs []*conf.C
+	if err := config.Unpack(&configs); err != nil {
+		return nil, err
+	}
+	for _, config := range configs {
+		if name, _ := config.String("module", -1); name == moduleName {
+			return config, nil
+		}
+	}
+	return nil, fmt.Errorf("no configuration of module '%s' found in '%s'", moduleName, file)
+}
+
+// writeEvents writes the events as an indented JSON array, and returns true if
+// any of them is an error event.
+func writeEvents(w io.Writer, events []beat.Event) (bool, error) {
+	failed := false
+	docs := make([]mapstr.M, 0, len(events))
+	for _, event := range events {
+		doc := event.Fields.Clone()
+		doc.Put("@timestamp", common.Time(event.Timestamp))
+		if len(event.Meta) > 0 {
+			doc.Put("@metadata", event.Meta)
+		}
+		if ok, _ := doc.HasKey("error.message"); ok {
+			failed = true
+		}
+		docs = append(docs, doc)
+	}
+
+	data, err := json.MarshalIndent(docs, "", "  ")
+	if err != nil {
+		return failed, err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return failed, err
+}
@@ -0,0 +1,131 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type fakeMetricSet struct {
+	mb.BaseMetricSet
+}
+
+// Fetch fails for the host "down", and reports an event with the host for the
+// others.
+func (ms *fakeMetricSet) Fetch(r mb.ReporterV2) error {
+	if ms.Host() == "down" {
+		return errors.New("connection refused")
+	}
+	r.Event(mb.Event{MetricSetFields: mapstr.M{"host": ms.Host()}})
+	return nil
+}
+
+func newTestRegistry(t *testing.T) *mb.Register {
+	r := mb.NewRegister()
+	require.NoError(t, r.AddMetricSet("fake", "status", func(base mb.BaseMetricSet) (mb.MetricSet, error) {
+		return &fakeMetricSet{BaseMetricSet: base}, nil
+	}))
+	return r
+}
+
+func TestFetch(t *testing.T) {
+	config, err := ModuleConfig("fake", "status", []string{"alpha", "down"}, "")
+	require.NoError(t, err)
+
+	events, err := Fetch(context.Background(), config, newTestRegistry(t))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	var buf bytes.Buffer
+	failed, err := writeEvents(&buf, events)
+	require.NoError(t, err)
+	assert.True(t, failed)
+
+	var docs []mapstr.M
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &docs))
+	require.Len(t, docs, 2)
+	for _, doc := range docs {
+		assert.Contains(t, doc, "@timestamp")
+		name, _ := doc.GetValue("metricset.name")
+		assert.Equal(t, "status", name)
+	}
+	host, _ := docs[0].GetValue("fake.status.host")
+	assert.Equal(t, "alpha", host)
+	message, _ := docs[1].GetValue("error.message")
+	assert.Equal(t, "connection refused", message)
+}
+
+func TestModuleConfig(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "fake.yml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+- module: other
+  period: 5s
+- module: fake
+  metricsets: [other]
+  hosts: [alpha]
+  period: 30s
+`), 0o600))
+
+	t.Run("file", func(t *testing.T) {
+		config, err := ModuleConfig("fake", "status", nil, file)
+		require.NoError(t, err)
+
+		var settings struct {
+			Module     string   `config:"module"`
+			MetricSets []string `config:"metricsets"`
+			Hosts      []string `config:"hosts"`
+			Period     string   `config:"period"`
+		}
+		require.NoError(t, config.Unpack(&settings))
+		assert.Equal(t, "fake", settings.Module)
+		assert.Equal(t, []string{"status"}, settings.MetricSets)
+		assert.Equal(t, []string{"alpha"}, settings.Hosts)
+		assert.Equal(t, "30s", settings.Period)
+	})
+
+	t.Run("hosts override the file", func(t *testing.T) {
+		config, err := ModuleConfig("fake", "status", []string{"beta", "gamma"}, file)
+		require.NoError(t, err)
+
+		var settings struct {
+			Hosts []string `config:"hosts"`
+		}
+		require.NoError(t, config.Unpack(&settings))
+		assert.Equal(t, []string{"beta", "gamma"}, settings.Hosts)
+	})
+
+	t.Run("module not in file", func(t *testing.T) {
+		_, err := ModuleConfig("missing", "status", nil, file)
+		assert.ErrorContains(t, err, "no configuration of module 'missing'")
+	})
+}
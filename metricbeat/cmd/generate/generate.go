@@ -0,0 +1,292 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package generate implements the commands that generate the skeleton of new
+// modules and metricsets.
+package generate
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/modfile"
+
+	"github.com/elastic/beats/v7/metricbeat/scripts/module"
+)
+
+// validName matches the valid names of modules and metricsets, that are also
+// used as Go package names.
+var validName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// GenGenerateCmd returns the generate command, with the metricset subcommand.
+func GenGenerateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate the skeleton of new modules and metricsets",
+	}
+	cmd.AddCommand(genMetricsetCmd())
+	return cmd
+}
+
+func genMetricsetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metricset",
+		Short: "Generate a new metricset, and its module if it doesn't exist",
+		Long: `Generate the files of a new metricset, and of its module if it doesn't exist,
+in the module directory of the beat, and add them to the include list of the
+beat. Run 'mage update' afterwards to update the generated files.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			moduleName, _ := cmd.Flags().GetString("module")
+			metricSetName, _ := cmd.Flags().GetString("metricset")
+			path, _ := cmd.Flags().GetString("path")
+
+			if err := Metricset(os.Stdout, path, moduleName, metricSetName); err != nil {
+				fmt.Fprintf(os.Stderr, "Error generating metricset: %s\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().String("module", "", "Name of the module")
+	cmd.Flags().String("metricset", "", "Name of the metricset")
+	cmd.Flags().String("path", ".", "Directory of the beat, that contains the module and include directories")
+	return cmd
+}
+
+// Metricset generates the files of a new metricset under the module directory
+// of the beat in path, and the files of its module if it doesn't exist yet.
+// The packages of the module and metricset are added to the include list of
+// the beat, so they are registered. Progress is reported to out.
+func Metricset(out io.Writer, path, moduleName, metricSetName string) error {
+	for kind, name := range map[string]string{"module": moduleName, "metricset": metricSetName} {
+		if !validName.MatchString(name) {
+			return fmt.Errorf("invalid %s name '%s', only [a-z0-9_] characters are allowed, starting with a letter", kind, name)
+		}
+	}
+
+	modulePath := filepath.Join(path, "module", moduleName)
+	metricSetPath := filepath.Join(modulePath, metricSetName)
+	if _, err := os.Stat(metricSetPath); err == nil {
+		return fmt.Errorf("metricset %s already exists in %s", metricSetName, metricSetPath)
+	}
+
+	r := strings.NewReplacer("{module}", moduleName, "{metricset}", metricSetName)
+
+	var packages []string
+	if _, err := os.Stat(modulePath); errors.Is(err, fs.ErrNotExist) {
+		files := map[string]string{
+			"doc.go.tmpl":   "doc.go",
+			"config.yml":    filepath.Join("_meta", "config.yml"),
+			"docs.asciidoc": filepath.Join("_meta", "docs.asciidoc"),
+			"fields.yml":    filepath.Join("_meta", "fields.yml"),
+		}
+		if err := writeTemplates(modulePath, "", files, r); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Module %s created in %s.\n", moduleName, modulePath)
+		packages = append(packages, modulePath)
+	} else if err != nil {
+		return err
+	}
+
+	files := map[string]string{
+		"metricset.go.tmpl":      metricSetName + ".go",
+		"config.go.tmpl":         "config.go",
+		"metricset_test.go.tmpl": metricSetName + "_test.go",
+		"data.json":              filepath.Join("_meta", "data.json"),
+		"docs.asciidoc":          filepath.Join("_meta", "docs.asciidoc"),
+		"fields.yml":             filepath.Join("_meta", "fields.yml"),
+	}
+	if err := writeTemplates(metricSetPath, "metricset", files, r); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Metricset %s created in %s.\n", metricSetName, metricSetPath)
+	packages = append(packages, metricSetPath)
+
+	listPath, err := addToIncludeList(path, packages)
+	if err != nil {
+		return err
+	}
+	if listPath == "" {
+		fmt.Fprintln(out, "No include list found, run 'mage update' to register the metricset.")
+	} else {
+		fmt.Fprintf(out, "Metricset %s added to %s.\n", metricSetName, listPath)
+	}
+	fmt.Fprintln(out, "Run 'mage update' to update the generated fields and documentation.")
+	return nil
+}
+
+// writeTemplates writes the given templates of the dir directory of the module
+// templates to the files, relative to path, they are mapped to.
+func writeTemplates(path, dir string, files map[string]string, r *strings.Replacer) error {
+	for name, file := range files {
+		content, err := module.Templates.ReadFile(filepath.ToSlash(filepath.Join(dir, name)))
+		if err != nil {
+			return err
+		}
+		data := []byte(r.Replace(string(content)))
+		if strings.HasSuffix(file, ".go") {
+			if data, err = format.Source(data); err != nil {
+				return fmt.Errorf("formatting %s: %w", file, err)
+			}
+		}
+
+		file = filepath.Join(path, file)
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(file, data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// includeLists are the files, relative to the beat directory, that can list
+// the packages of the modules and metricsets, in order of preference.
+var includeLists = []string{
+	filepath.Join("include", "list_common.go"),
+	filepath.Join("include", "list.go"),
+}
+
+// addToIncludeList adds imports of the given package directories to the
+// include list of the beat in path, keeping the imports sorted as in the
+// generated lists. It returns the path of the list, or an empty string if the
+// beat doesn't have one.
+func addToIncludeList(path string, packages []string) (string, error) {
+	var listPath string
+	for _, name := range includeLists {
+		if _, err := os.Stat(filepath.Join(path, name)); err == nil {
+			listPath = filepath.Join(path, name)
+			break
+		}
+	}
+	if listPath == "" {
+		return "", nil
+	}
+
+	var imports []string
+	for _, pkg := range packages {
+		importPath, err := goImportPath(pkg)
+		if err != nil {
+			return "", err
+		}
+		imports = append(imports, importPath)
+	}
+
+	content, err := os.ReadFile(listPath)
+	if err != nil {
+		return "", err
+	}
+	content, err = addImports(content, imports)
+	if err != nil {
+		return "", fmt.Errorf("updating %s: %w", listPath, err)
+	}
+	return listPath, os.WriteFile(listPath, content, 0o644)
+}
+
+// addImports adds blank imports of the given packages to the first block of
+// blank imports of the Go source.
+func addImports(src []byte, imports []string) ([]byte, error) {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(src))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	start, end := -1, -1
+	for i, line := range lines {
+		isImport := strings.HasPrefix(strings.TrimSpace(line), `_ "`)
+		if isImport && start < 0 {
+			start = i
+		}
+		if !isImport && start >= 0 {
+			end = i
+			break
+		}
+	}
+	if start < 0 || end < 0 {
+		return nil, errors.New("no blank imports found")
+	}
+
+	block := append([]string{}, lines[start:end]...)
+	indent := block[0][:strings.Index(block[0], `_ "`)]
+	for _, importPath := range imports {
+		line := indent + `_ "` + importPath + `"`
+		found := false
+		for _, existing := range block {
+			found = found || existing == line
+		}
+		if !found {
+			block = append(block, line)
+		}
+	}
+	sort.Slice(block, func(i, j int) bool { return importOf(block[i]) < importOf(block[j]) })
+
+	result := append(append(append([]string{}, lines[:start]...), block...), lines[end:]...)
+	return format.Source([]byte(strings.Join(result, "\n") + "\n"))
+}
+
+func importOf(line string) string {
+	return strings.Trim(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "_")), `"`)
+}
+
+// goImportPath returns the import path of the package in dir, based on the
+// path of the nearest go.mod file.
+func goImportPath(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for root := dir; ; {
+		content, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			modulePath := modfile.ModulePath(content)
+			if modulePath == "" {
+				return "", fmt.Errorf("no module path found in %s", filepath.Join(root, "go.mod"))
+			}
+			rel, err := filepath.Rel(root, dir)
+			if err != nil {
+				return "", err
+			}
+			return modulePath + "/" + filepath.ToSlash(rel), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+
+		parent := filepath.Dir(root)
+		if parent == root {
+			return "", fmt.Errorf("no go.mod found for %s", dir)
+		}
+		root = parent
+	}
+}
@@ -0,0 +1,115 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package generate
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const includeList = `package include
+
+import (
+	// Import packages that perform 'func init()'.
+	_ "example.com/beat/module/alpha"
+	_ "example.com/beat/module/alpha/status"
+	_ "example.com/beat/module/zulu"
+)
+`
+
+func TestMetricset(t *testing.T) {
+	path := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(path, "go.mod"), []byte("module example.com/beat\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(path, "include"), 0o755))
+	listPath := filepath.Join(path, "include", "list.go")
+	require.NoError(t, os.WriteFile(listPath, []byte(includeList), 0o644))
+
+	var out bytes.Buffer
+	require.NoError(t, Metricset(&out, path, "foo", "bar"))
+	assert.Contains(t, out.String(), "Module foo created")
+	assert.Contains(t, out.String(), "Metricset bar added to "+listPath)
+
+	for _, file := range []string{
+		"doc.go",
+		"_meta/config.yml",
+		"_meta/docs.asciidoc",
+		"_meta/fields.yml",
+		"bar/bar.go",
+		"bar/bar_test.go",
+		"bar/config.go",
+		"bar/_meta/data.json",
+		"bar/_meta/docs.asciidoc",
+		"bar/_meta/fields.yml",
+	} {
+		content, err := os.ReadFile(filepath.Join(path, "module", "foo", file))
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), "{module}", file)
+		assert.NotContains(t, string(content), "{metricset}", file)
+		if strings.HasSuffix(file, ".go") {
+			_, err := parser.ParseFile(token.NewFileSet(), file, content, 0)
+			assert.NoError(t, err, file)
+		}
+	}
+
+	barGo, err := os.ReadFile(filepath.Join(path, "module", "foo", "bar", "bar.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(barGo), `mb.Registry.MustAddMetricSet("foo", "bar", New)`)
+
+	// The module is reused by other metricsets.
+	out.Reset()
+	require.NoError(t, Metricset(&out, path, "foo", "baz"))
+	assert.NotContains(t, out.String(), "Module foo created")
+
+	list, err := os.ReadFile(listPath)
+	require.NoError(t, err)
+	assert.Equal(t, `package include
+
+import (
+	// Import packages that perform 'func init()'.
+	_ "example.com/beat/module/alpha"
+	_ "example.com/beat/module/alpha/status"
+	_ "example.com/beat/module/foo"
+	_ "example.com/beat/module/foo/bar"
+	_ "example.com/beat/module/foo/baz"
+	_ "example.com/beat/module/zulu"
+)
+`, string(list))
+
+	assert.ErrorContains(t, Metricset(&out, path, "foo", "bar"), "already exists")
+}
+
+func TestMetricsetInvalidName(t *testing.T) {
+	path := t.TempDir()
+	var out bytes.Buffer
+	assert.ErrorContains(t, Metricset(&out, path, "Foo", "bar"), "invalid module name 'Foo'")
+	assert.ErrorContains(t, Metricset(&out, path, "foo", "bar-baz"), "invalid metricset name 'bar-baz'")
+
+	entries, err := os.ReadDir(path)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,62 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/v7/libbeat/cfgfile"
+	"github.com/elastic/beats/v7/libbeat/cmd/instance"
+	"github.com/elastic/beats/v7/libbeat/common/cli"
+	"github.com/elastic/beats/v7/metricbeat/beater"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// GenMigrateCmd initializes a command to migrate the configuration to the
+// current version.
+func GenMigrateCmd(settings instance.Settings) *cobra.Command {
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the configuration to the current version",
+	}
+	migrateCmd.AddCommand(genMigrateConfigCmd(settings))
+	return migrateCmd
+}
+
+func genMigrateConfigCmd(settings instance.Settings) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Rewrite the deprecated settings of the modules",
+		Long: `Rewrite the deprecated settings and the removed metricsets of the modules of
+the configuration file and of the files of metricbeat.config.modules to their
+current syntax, and print the diff of the files.`,
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			b, err := instance.NewInitializedBeat(settings)
+			if err != nil {
+				return fmt.Errorf("error initializing beat: %w", err)
+			}
+			return beater.MigrateConfig(os.Stdout, cfgfile.GetDefaultCfgfile(), b.RawConfig, mb.Registry, dryRun)
+		}),
+	}
+	cmd.Flags().Bool("dry-run", false, "Print the diff without rewriting the files")
+	return cmd
+}
@@ -18,12 +18,19 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/spf13/cobra"
+
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/cfgfile"
 	"github.com/elastic/beats/v7/libbeat/cmd"
+	"github.com/elastic/beats/v7/libbeat/cmd/instance"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/paths"
 )
 
 // BuildModulesManager adds support for modules management to a beat
@@ -45,3 +52,75 @@ func BuildModulesManager(beat *beat.Beat) (cmd.ModulesManager, error) {
 	}
 	return modulesManager, nil
 }
+
+// GenDescribeModulesCmd initializes a command that describes the modules and
+// metricsets supported by the beat, including its light modules. With the
+// --json flag the description is printed as JSON, to be used by tooling.
+func GenDescribeModulesCmd(name, version string) *cobra.Command {
+	describeCmd := cobra.Command{
+		Use:   "describe [MODULE...]",
+		Short: "Describe the available modules and their metricsets",
+		Run: func(cmd *cobra.Command, args []string) {
+			asJSON, _ := cmd.Flags().GetBool("json")
+
+			_, err := instance.NewInitializedBeat(instance.Settings{Name: name, Version: version})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error initializing beat: %s\n", err)
+				os.Exit(1)
+			}
+			mb.Registry.SetSecondarySource(mb.NewLightModulesSource(paths.Resolve(paths.Home, "module")))
+
+			modules := filterModules(mb.Registry.Describe(), args)
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(modules); err != nil {
+					fmt.Fprintf(os.Stderr, "Error encoding modules: %s\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			for _, module := range modules {
+				fmt.Println(module.Name + describeFlags(module.Light, false))
+				for _, ms := range module.MetricSets {
+					fmt.Println("  " + ms.Name + describeFlags(ms.Light, ms.Default))
+				}
+			}
+		},
+	}
+	describeCmd.Flags().Bool("json", false, "Print the description as JSON")
+	return &describeCmd
+}
+
+// filterModules returns the modules with the given names, or all of them if
+// no names are given.
+func filterModules(modules []mb.ModuleDescription, names []string) []mb.ModuleDescription {
+	if len(names) == 0 {
+		return modules
+	}
+	var filtered []mb.ModuleDescription
+	for _, module := range modules {
+		for _, name := range names {
+			if strings.EqualFold(module.Name, name) {
+				filtered = append(filtered, module)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+func describeFlags(light, isDefault bool) string {
+	var flags []string
+	if light {
+		flags = append(flags, "light")
+	}
+	if isDefault {
+		flags = append(flags, "default")
+	}
+	if len(flags) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(flags, ", ") + ")"
+}
@@ -29,6 +29,9 @@ import (
 	"github.com/elastic/beats/v7/libbeat/ecs"
 	"github.com/elastic/beats/v7/libbeat/publisher/processing"
 	"github.com/elastic/beats/v7/metricbeat/beater"
+	"github.com/elastic/beats/v7/metricbeat/cmd/bench"
+	"github.com/elastic/beats/v7/metricbeat/cmd/fetch"
+	"github.com/elastic/beats/v7/metricbeat/cmd/generate"
 	"github.com/elastic/beats/v7/metricbeat/cmd/test"
 	"github.com/elastic/beats/v7/metricbeat/include"
 	"github.com/elastic/beats/v7/metricbeat/mb/module"
@@ -56,11 +59,12 @@ var withECSVersion = processing.WithFields(mapstr.M{
 func MetricbeatSettings() instance.Settings {
 	var runFlags = pflag.NewFlagSet(Name, pflag.ExitOnError)
 	runFlags.AddGoFlag(flag.CommandLine.Lookup("system.hostfs"))
+	runFlags.AddGoFlag(flag.CommandLine.Lookup("dry-run"))
 	return instance.Settings{
 		RunFlags:      runFlags,
 		Name:          Name,
 		HasDashboards: true,
-		Processing:    processing.MakeDefaultSupport(true, nil, withECSVersion, processing.WithHost, processing.WithAgentMeta()),
+		Processing:    WithDryRun(processing.MakeDefaultSupport(true, nil, withECSVersion, processing.WithHost, processing.WithAgentMeta())),
 		Initialize: []func(){
 			include.InitializeModule,
 			module.RegisterMonitoringModules,
@@ -71,11 +75,35 @@ func MetricbeatSettings() instance.Settings {
 // Initialize initializes the entrypoint commands for metricbeat
 func Initialize(settings instance.Settings) *cmd.BeatsRootCmd {
 	rootCmd := cmd.GenRootCmdWithSettings(beater.DefaultCreator(), settings)
-	rootCmd.AddCommand(cmd.GenModulesCmd(Name, "", BuildModulesManager))
+	modulesCmd := cmd.GenModulesCmd(Name, "", BuildModulesManager)
+	modulesCmd.AddCommand(GenDescribeModulesCmd(Name, ""))
+	rootCmd.AddCommand(modulesCmd)
 	rootCmd.TestCmd.AddCommand(test.GenTestModulesCmd(Name, "", beater.DefaultTestModulesCreator()))
+	rootCmd.AddCommand(generate.GenGenerateCmd())
+	rootCmd.AddCommand(fetch.GenFetchCmd(Name, ""))
+	rootCmd.AddCommand(bench.GenBenchCmd(Name, ""))
+	rootCmd.ExportCmd.AddCommand(GenExportModuleReferenceCmd(Name, ""))
+	rootCmd.AddCommand(GenMigrateCmd(settings))
+	AddTestConfigFlags(rootCmd)
+	AddSetupFlags(rootCmd)
+	AddExportConfigFlags(rootCmd, settings)
 	return rootCmd
 }
 
+// AddTestConfigFlags adds the flags of Metricbeat to the test config command.
+func AddTestConfigFlags(rootCmd *cmd.BeatsRootCmd) {
+	for _, c := range rootCmd.TestCmd.Commands() {
+		if c.Name() == "config" {
+			c.Flags().AddGoFlag(flag.CommandLine.Lookup("strict"))
+		}
+	}
+}
+
+// AddSetupFlags adds the flags of Metricbeat to the setup command.
+func AddSetupFlags(rootCmd *cmd.BeatsRootCmd) {
+	rootCmd.SetupCmd.Flags().AddGoFlag(flag.CommandLine.Lookup("modules"))
+}
+
 func init() {
 	RootCmd = Initialize(MetricbeatSettings())
 }
@@ -18,8 +18,12 @@
 package test
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"runtime"
+	"sync"
 
 	"github.com/spf13/cobra"
 
@@ -30,8 +34,11 @@ import (
 	"github.com/elastic/elastic-agent-libs/testing"
 )
 
+// GenTestModulesCmd returns the command that tests the configured modules. The
+// MetricSets are tested concurrently, and the results are reported in order as
+// text or, with --format json, as a JSON report that can be used in CI.
 func GenTestModulesCmd(name, beatVersion string, create beat.Creator) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "modules [module] [metricset]",
 		Short: "Test modules settings",
 		Run: func(cmd *cobra.Command, args []string) {
@@ -44,6 +51,13 @@ func GenTestModulesCmd(name, beatVersion string, create beat.Creator) *cobra.Com
 				filter_metricset = args[1]
 			}
 
+			format, _ := cmd.Flags().GetString("format")
+			if format != "text" && format != "json" {
+				fmt.Fprintf(os.Stderr, "Unknown format '%s', expected 'text' or 'json'\n", format)
+				os.Exit(1)
+			}
+			parallel, _ := cmd.Flags().GetInt("parallel")
+
 			b, err := instance.NewInitializedBeat(instance.Settings{Name: name, Version: beatVersion})
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error initializing beat: %s\n", err)
@@ -64,22 +78,95 @@ func GenTestModulesCmd(name, beatVersion string, create beat.Creator) *cobra.Com
 				os.Exit(1)
 			}
 
-			driver := testing.NewConsoleDriver(os.Stdout)
+			var tests []metricSetTest
 			for _, module := range modules {
 				if filter_module != "" && module.Name() != filter_module {
 					continue
 				}
-				driver.Run(module.Name(), func(driver testing.Driver) {
-					for _, set := range module.MetricSets() {
-						if filter_metricset != "" && set.Name() != filter_metricset {
-							continue
-						}
-						set.Test(driver)
+				for _, set := range module.MetricSets() {
+					if filter_metricset != "" && set.Name() != filter_metricset {
+						continue
+					}
+					tests = append(tests, metricSetTest{module: module.Name(), metricSet: set.Name(), test: set.Test})
+				}
+			}
+			results := runTests(tests, parallel)
+
+			if format == "json" {
+				passed := writeJSON(os.Stdout, results)
+				if !passed {
+					os.Exit(1)
+				}
+				return
+			}
+
+			driver := testing.NewConsoleDriver(os.Stdout)
+			for i := 0; i < len(results); {
+				module := results[i].Module
+				driver.Run(module, func(driver testing.Driver) {
+					for ; i < len(results) && results[i].Module == module; i++ {
+						results[i].output.replay(driver)
 					}
 				})
 			}
 		},
 	}
+	cmd.Flags().String("format", "text", "Format of the results, 'text' or 'json'")
+	cmd.Flags().Int("parallel", runtime.NumCPU(), "Maximum number of metricsets tested at the same time")
+	return cmd
+}
+
+// metricSetTest is the test of a MetricSet.
+type metricSetTest struct {
+	module, metricSet string
+	test              func(testing.Driver)
+}
+
+// runTests runs the tests with up to parallel workers, and returns their
+// results in the same order as the tests.
+func runTests(tests []metricSetTest, parallel int) []*result {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	results := make([]*result, len(tests))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < parallel && w < len(tests); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = runTest(tests[i].module, tests[i].metricSet, tests[i].test)
+			}
+		}()
+	}
+	for i := range tests {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// writeJSON writes the results as a JSON report, and returns whether all the
+// tests passed.
+func writeJSON(w io.Writer, results []*result) bool {
+	report := struct {
+		Passed     bool      `json:"passed"`
+		MetricSets []*result `json:"metricsets"`
+	}{Passed: true, MetricSets: results}
+	for _, r := range results {
+		report.Passed = report.Passed && r.Passed
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing results: %s\n", err)
+		return false
+	}
+	return report.Passed
 }
 
 type publisher struct {
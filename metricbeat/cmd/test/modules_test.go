@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	libtesting "github.com/elastic/elastic-agent-libs/testing"
+)
+
+func TestRunTests(t *testing.T) {
+	pass := func(delay time.Duration) func(libtesting.Driver) {
+		return func(d libtesting.Driver) {
+			d.Run("pass", func(d libtesting.Driver) {
+				time.Sleep(delay)
+				d.Result(`{"metricset":{"name":"pass"}}`)
+			})
+		}
+	}
+	fail := func(d libtesting.Driver) {
+		d.Run("fail", func(d libtesting.Driver) {
+			d.Warn("setting", "deprecated")
+			d.Run("host localhost:1", func(d libtesting.Driver) {
+				d.Run("connection", func(d libtesting.Driver) {
+					d.Error("dial up", errors.New("connection refused"))
+				})
+			})
+			d.Fatal("error", errors.New("connection refused"))
+			d.Info("unreachable", "not recorded")
+		})
+	}
+
+	results := runTests([]metricSetTest{
+		{module: "a", metricSet: "pass", test: pass(50 * time.Millisecond)},
+		{module: "a", metricSet: "fail", test: fail},
+		{module: "b", metricSet: "pass", test: pass(0)},
+	}, 3)
+
+	// Results are in the order of the tests, even if they finish in another.
+	require.Len(t, results, 3)
+	assert.Equal(t, "a", results[0].Module)
+	assert.True(t, results[0].Passed)
+	assert.JSONEq(t, `{"metricset":{"name":"pass"}}`, string(results[0].Event))
+	assert.GreaterOrEqual(t, results[0].DurationMS, float64(50))
+
+	assert.False(t, results[1].Passed)
+	assert.Equal(t, []string{
+		"host localhost:1: connection: dial up: connection refused",
+		"error: connection refused",
+	}, results[1].Errors)
+	assert.Equal(t, []string{"setting: deprecated"}, results[1].Warnings)
+	assert.Nil(t, results[1].Event)
+
+	// The output of the tests is replayed in a console.
+	var out bytes.Buffer
+	results[1].output.replay(libtesting.NewConsoleDriverWithKiller(&out, func() {}))
+	assert.Contains(t, out.String(), "fail...")
+	assert.Contains(t, out.String(), "connection refused")
+	assert.NotContains(t, out.String(), "unreachable")
+
+	var report bytes.Buffer
+	assert.False(t, writeJSON(&report, results))
+	var decoded struct {
+		Passed     bool `json:"passed"`
+		MetricSets []struct {
+			Module    string `json:"module"`
+			MetricSet string `json:"metricset"`
+			Passed    bool   `json:"passed"`
+		} `json:"metricsets"`
+	}
+	require.NoError(t, json.Unmarshal(report.Bytes(), &decoded))
+	assert.False(t, decoded.Passed)
+	require.Len(t, decoded.MetricSets, 3)
+	assert.Equal(t, "fail", decoded.MetricSets[1].MetricSet)
+	assert.False(t, decoded.MetricSets[1].Passed)
+
+	report.Reset()
+	assert.True(t, writeJSON(&report, results[2:]))
+}
@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/testing"
+)
+
+// result is the result of the test of a MetricSet.
+type result struct {
+	Module     string          `json:"module"`
+	MetricSet  string          `json:"metricset"`
+	Passed     bool            `json:"passed"`
+	DurationMS float64         `json:"duration_ms"`
+	Errors     []string        `json:"errors,omitempty"`
+	Warnings   []string        `json:"warnings,omitempty"`
+	Event      json.RawMessage `json:"event,omitempty"`
+
+	output *recorder // Output of the test, to replay it in a console.
+}
+
+// runTest runs a test with a recorder, and returns its result.
+func runTest(module, metricSet string, test func(testing.Driver)) *result {
+	r := &result{Module: module, MetricSet: metricSet}
+	r.output = &recorder{result: r}
+
+	start := time.Now()
+	done := make(chan struct{})
+	go func() {
+		// Fatal errors stop this goroutine.
+		defer close(done)
+		test(r.output)
+	}()
+	<-done
+	r.DurationMS = float64(time.Since(start)) / float64(time.Millisecond)
+	r.Passed = len(r.Errors) == 0
+	return r
+}
+
+// recorder is a testing.Driver that records the output of a test, so tests
+// can run concurrently and their output is replayed in order afterwards.
+type recorder struct {
+	result *result
+	ops    []func(testing.Driver)
+
+	depth  int    // Number of runs this recorder is nested in.
+	prefix string // Prefix of the errors and warnings of the results.
+}
+
+// replay reproduces the recorded output in the given driver.
+func (r *recorder) replay(d testing.Driver) {
+	for _, op := range r.ops {
+		op(d)
+	}
+}
+
+func (r *recorder) Run(name string, f func(testing.Driver)) {
+	// The run is recorded before f is called, so its output is kept if f is
+	// stopped by a fatal error.
+	child := &recorder{result: r.result, depth: r.depth + 1, prefix: r.prefix}
+	// The first run is the one of the MetricSet, errors of the nested runs,
+	// like the ones of each host, are prefixed with their names.
+	if r.depth > 0 {
+		child.prefix += name + ": "
+	}
+	r.ops = append(r.ops, func(d testing.Driver) { d.Run(name, child.replay) })
+	f(child)
+}
+
+func (r *recorder) Info(field, value string) {
+	r.ops = append(r.ops, func(d testing.Driver) { d.Info(field, value) })
+}
+
+func (r *recorder) Warn(field, reason string) {
+	r.result.Warnings = append(r.result.Warnings, fmt.Sprintf("%s%s: %s", r.prefix, field, reason))
+	r.ops = append(r.ops, func(d testing.Driver) { d.Warn(field, reason) })
+}
+
+func (r *recorder) Error(field string, err error) {
+	if err != nil {
+		r.result.Errors = append(r.result.Errors, fmt.Sprintf("%s%s: %s", r.prefix, field, err))
+	}
+	r.ops = append(r.ops, func(d testing.Driver) { d.Error(field, err) })
+}
+
+// Fatal records the error, and stops the test if it is not nil. It is not
+// fatal when replayed, so the output of other tests is not lost.
+func (r *recorder) Fatal(field string, err error) {
+	r.Error(field, err)
+	if err != nil {
+		runtime.Goexit()
+	}
+}
+
+func (r *recorder) Result(data string) {
+	if json.Valid([]byte(data)) {
+		r.result.Event = json.RawMessage(data)
+	}
+	r.ops = append(r.ops, func(d testing.Driver) { d.Result(data) })
+}
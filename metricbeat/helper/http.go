@@ -25,6 +25,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 
 	"github.com/elastic/beats/v7/libbeat/version"
 	"github.com/elastic/beats/v7/metricbeat/helper/dialer"
@@ -45,6 +46,7 @@ type HTTP struct {
 	uri      string
 	method   string
 	body     []byte
+	failover *mb.Failover // Fails over between the endpoints of the host, if it has fallbacks.
 }
 
 // NewHTTP creates new http helper
@@ -95,33 +97,56 @@ func NewHTTPFromConfig(config Config, hostData mb.HostData) (*HTTP, error) {
 		return nil, err
 	}
 
-	return &HTTP{
+	h := &HTTP{
 		hostData: hostData,
 		client:   client,
 		headers:  headers,
 		method:   "GET",
 		uri:      hostData.SanitizedURI,
 		body:     nil,
-	}, nil
+	}
+	if len(hostData.Fallbacks) > 0 {
+		h.failover = mb.NewFailover(hostData)
+	}
+	return h, nil
 }
 
 // FetchResponse fetches a response for the http metricset.
 // It's important that resp.Body has to be closed if this method is used. Before using this method
 // check if one of the other Fetch* methods could be used as they ensure that the Body is properly closed.
+// If the host has fallback endpoints, the request is sent to the endpoint that last worked, and to the
+// next ones when it can't be made. Responses with error status codes don't cause a failover.
 func (h *HTTP) FetchResponse() (*http.Response, error) {
+	if h.failover == nil {
+		return h.fetchResponse(h.hostData, h.uri)
+	}
+
+	var resp *http.Response
+	err := h.failover.Do(func(endpoint mb.HostData) error {
+		uri, err := endpointURI(h.uri, endpoint)
+		if err != nil {
+			return err
+		}
+		resp, err = h.fetchResponse(endpoint, uri)
+		return err
+	})
+	return resp, err
+}
+
+func (h *HTTP) fetchResponse(hostData mb.HostData, uri string) (*http.Response, error) {
 	// Create a fresh reader every time
 	var reader io.Reader
 	if h.body != nil {
 		reader = bytes.NewReader(h.body)
 	}
 
-	req, err := http.NewRequest(h.method, h.uri, reader)
+	req, err := http.NewRequest(h.method, uri, reader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 	req.Header = h.headers
-	if h.hostData.User != "" || h.hostData.Password != "" {
-		req.SetBasicAuth(h.hostData.User, h.hostData.Password)
+	if hostData.User != "" || hostData.Password != "" {
+		req.SetBasicAuth(hostData.User, hostData.Password)
 	}
 
 	resp, err := h.client.Do(req)
@@ -132,6 +157,22 @@ func (h *HTTP) FetchResponse() (*http.Response, error) {
 	return resp, nil
 }
 
+// endpointURI returns the URI with the scheme and host of the endpoint, so
+// the paths and queries set with SetURI are kept when failing over.
+func endpointURI(uri string, endpoint mb.HostData) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URI: %w", err)
+	}
+	e, err := url.Parse(endpoint.SanitizedURI)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse endpoint URI: %w", err)
+	}
+	u.Scheme = e.Scheme
+	u.Host = e.Host
+	return u.String(), nil
+}
+
 // SetHeader sets HTTP headers to use in requests
 func (h *HTTP) SetHeader(key, value string) {
 	h.headers.Set(key, value)
@@ -176,7 +217,11 @@ func (h *HTTP) FetchContent() ([]byte, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP error %d in %s: %s", resp.StatusCode, h.name, resp.Status)
+		err := fmt.Errorf("HTTP error %d in %s: %s", resp.StatusCode, h.name, resp.Status)
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			err = mb.WithErrorCategory(err, mb.ErrorCategoryAuth)
+		}
+		return nil, err
 	}
 
 	return ioutil.ReadAll(resp.Body)
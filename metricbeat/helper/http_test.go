@@ -147,6 +147,10 @@ func TestAuthentication(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusUnauthorized, response.StatusCode, "response status code")
 
+	_, err = h.FetchContent()
+	assert.Error(t, err)
+	assert.Equal(t, mb.ErrorCategoryAuth, mb.ErrorCategoryOf(err))
+
 	// Authorized
 	hostData = mb.HostData{
 		URI:          ts.URL,
@@ -163,6 +167,43 @@ func TestAuthentication(t *testing.T) {
 	assert.Equal(t, http.StatusOK, response.StatusCode, "response status code")
 }
 
+func TestFailover(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ := r.BasicAuth()
+		requests = append(requests, user+" "+r.URL.RequestURI())
+		fmt.Fprint(w, "ok")
+	}))
+	defer ts.Close()
+
+	// Address of a server that is down.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	down := "http://" + l.Addr().String()
+	l.Close()
+
+	hostData, err := parse.ParseURLWithFallbacks(down+"|http://fallback:secret@"+ts.Listener.Addr().String(), "http", "", "", "/stats", "")
+	require.NoError(t, err)
+
+	h, err := NewHTTPFromConfig(defaultConfig(), hostData)
+	require.NoError(t, err)
+	h.SetURI(h.GetURI() + "?pretty")
+
+	content, err := h.FetchContent()
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(content))
+
+	// The endpoint that worked is used first from then on.
+	_, err = h.FetchContent()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fallback /stats?pretty", "fallback /stats?pretty"}, requests)
+
+	ts.Close()
+	_, err = h.FetchContent()
+	assert.ErrorContains(t, err, down+"/stats: error making http request")
+	assert.ErrorContains(t, err, "http://"+ts.Listener.Addr().String()+"/stats: error making http request")
+}
+
 func TestSetHeader(t *testing.T) {
 	cfg := defaultConfig()
 	cfg.Headers = map[string]string{
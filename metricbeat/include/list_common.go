@@ -66,6 +66,8 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/pending_tasks"
 	_ "github.com/elastic/beats/v7/metricbeat/module/elasticsearch/shard"
 	_ "github.com/elastic/beats/v7/metricbeat/module/envoyproxy"
+	_ "github.com/elastic/beats/v7/metricbeat/module/envoyproxy/clusters"
+	_ "github.com/elastic/beats/v7/metricbeat/module/envoyproxy/listeners"
 	_ "github.com/elastic/beats/v7/metricbeat/module/envoyproxy/server"
 	_ "github.com/elastic/beats/v7/metricbeat/module/etcd"
 	_ "github.com/elastic/beats/v7/metricbeat/module/etcd/leader"
@@ -79,7 +81,9 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/graphite/server"
 	_ "github.com/elastic/beats/v7/metricbeat/module/haproxy"
 	_ "github.com/elastic/beats/v7/metricbeat/module/haproxy/info"
+	_ "github.com/elastic/beats/v7/metricbeat/module/haproxy/server"
 	_ "github.com/elastic/beats/v7/metricbeat/module/haproxy/stat"
+	_ "github.com/elastic/beats/v7/metricbeat/module/haproxy/sticktable"
 	_ "github.com/elastic/beats/v7/metricbeat/module/http"
 	_ "github.com/elastic/beats/v7/metricbeat/module/http/json"
 	_ "github.com/elastic/beats/v7/metricbeat/module/http/server"
@@ -87,6 +91,7 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/jolokia/jmx"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kafka"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kafka/consumergroup"
+	_ "github.com/elastic/beats/v7/metricbeat/module/kafka/consumergroup_lag"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kafka/partition"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kibana"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kibana/cluster_actions"
@@ -114,6 +119,7 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/memcached/stats"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/collstats"
+	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/currentop"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/dbstats"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/metrics"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/replstatus"
@@ -122,17 +128,25 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/munin/node"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mysql"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mysql/galera_status"
+	_ "github.com/elastic/beats/v7/metricbeat/module/mysql/innodb"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mysql/query"
+	_ "github.com/elastic/beats/v7/metricbeat/module/mysql/replication"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mysql/status"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats/connection"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats/connections"
+	_ "github.com/elastic/beats/v7/metricbeat/module/nats/jetstream"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats/route"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats/routes"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats/stats"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats/subscriptions"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nginx"
+	_ "github.com/elastic/beats/v7/metricbeat/module/nginx/nginx"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nginx/stubstatus"
+	_ "github.com/elastic/beats/v7/metricbeat/module/nomad"
+	_ "github.com/elastic/beats/v7/metricbeat/module/nomad/agent"
+	_ "github.com/elastic/beats/v7/metricbeat/module/nomad/allocations"
+	_ "github.com/elastic/beats/v7/metricbeat/module/nomad/jobs"
 	_ "github.com/elastic/beats/v7/metricbeat/module/openmetrics"
 	_ "github.com/elastic/beats/v7/metricbeat/module/openmetrics/collector"
 	_ "github.com/elastic/beats/v7/metricbeat/module/php_fpm"
@@ -142,6 +156,7 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/activity"
 	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/bgwriter"
 	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/database"
+	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/replication"
 	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/statement"
 	_ "github.com/elastic/beats/v7/metricbeat/module/prometheus"
 	_ "github.com/elastic/beats/v7/metricbeat/module/prometheus/collector"
@@ -152,8 +167,10 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/rabbitmq/exchange"
 	_ "github.com/elastic/beats/v7/metricbeat/module/rabbitmq/node"
 	_ "github.com/elastic/beats/v7/metricbeat/module/rabbitmq/queue"
+	_ "github.com/elastic/beats/v7/metricbeat/module/rabbitmq/quorum"
 	_ "github.com/elastic/beats/v7/metricbeat/module/rabbitmq/shovel"
 	_ "github.com/elastic/beats/v7/metricbeat/module/redis"
+	_ "github.com/elastic/beats/v7/metricbeat/module/redis/cluster"
 	_ "github.com/elastic/beats/v7/metricbeat/module/redis/info"
 	_ "github.com/elastic/beats/v7/metricbeat/module/redis/key"
 	_ "github.com/elastic/beats/v7/metricbeat/module/redis/keyspace"
@@ -177,7 +194,10 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/uptime"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/users"
 	_ "github.com/elastic/beats/v7/metricbeat/module/traefik"
+	_ "github.com/elastic/beats/v7/metricbeat/module/traefik/certificates"
 	_ "github.com/elastic/beats/v7/metricbeat/module/traefik/health"
+	_ "github.com/elastic/beats/v7/metricbeat/module/traefik/routers"
+	_ "github.com/elastic/beats/v7/metricbeat/module/traefik/services"
 	_ "github.com/elastic/beats/v7/metricbeat/module/uwsgi"
 	_ "github.com/elastic/beats/v7/metricbeat/module/uwsgi/status"
 	_ "github.com/elastic/beats/v7/metricbeat/module/vsphere"
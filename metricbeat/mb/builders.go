@@ -25,6 +25,8 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/joeshaw/multierror"
 
+	"github.com/elastic/go-ucfg"
+
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/monitoring"
@@ -52,6 +54,11 @@ func NewModule(config *conf.C, r *Register) (Module, []MetricSet, error) {
 		return nil, nil, ErrModuleDisabled
 	}
 
+	config, err := r.resolveKeystoreReferences(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	bm, err := newBaseModuleFromConfig(config)
 	if err != nil {
 		return nil, nil, err
@@ -62,7 +69,7 @@ func NewModule(config *conf.C, r *Register) (Module, []MetricSet, error) {
 		return nil, nil, err
 	}
 
-	metricsets, err := initMetricSets(r, module)
+	metricsets, err := initMetricSets(r, module, module.Config().Hosts, true)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -70,12 +77,24 @@ func NewModule(config *conf.C, r *Register) (Module, []MetricSet, error) {
 	return module, metricsets, nil
 }
 
+// NewMetricSetsForHosts builds the MetricSets of an existing Module for the
+// given hosts, instead of the hosts in the module's configuration. It can be
+// used to add hosts to a running module. MetricSets registered with
+// WithMultipleHosts are not created, as they don't have an instance per host.
+func NewMetricSetsForHosts(r *Register, m Module, hosts []string) ([]MetricSet, error) {
+	if err := mustNotContainDuplicates(hosts); err != nil {
+		return nil, fmt.Errorf("invalid hosts for module '%s': %w", m.Name(), err)
+	}
+	return initMetricSets(r, m, hosts, false)
+}
+
 // newBaseModuleFromConfig creates a new BaseModule from config. The returned
 // BaseModule's name will always be lower case.
 func newBaseModuleFromConfig(rawConfig *conf.C) (BaseModule, error) {
 	baseModule := BaseModule{
 		config:    DefaultModuleConfig(),
 		rawConfig: rawConfig,
+		cache:     NewModuleCache(),
 	}
 	err := rawConfig.Unpack(&baseModule.config)
 	if err != nil {
@@ -106,13 +125,13 @@ func createModule(r *Register, bm BaseModule) (Module, error) {
 	return f(bm)
 }
 
-func initMetricSets(r *Register, m Module) ([]MetricSet, error) {
+func initMetricSets(r *Register, m Module, hosts []string, withMultipleHosts bool) ([]MetricSet, error) {
 	var (
 		errs       multierror.Errors
 		metricsets []MetricSet
 	)
 
-	bms, err := newBaseMetricSets(r, m)
+	bms, err := newBaseMetricSets(r, m, hosts, withMultipleHosts)
 	if err != nil {
 		return nil, err
 	}
@@ -124,10 +143,28 @@ func initMetricSets(r *Register, m Module) ([]MetricSet, error) {
 			continue
 		}
 
+		if err := checkCapabilities(bm.Module().Name(), registration); err != nil {
+			if bm.Module().Config().SkipUnsupported {
+				bm.Logger().Warnf("Skipping unsupported metricset: %v", err)
+				continue
+			}
+			errs = append(errs, err)
+			continue
+		}
+
 		bm.registration = registration
 		bm.hostData = HostData{URI: bm.host}
-		if registration.HostParser != nil {
-			bm.hostData, err = registration.HostParser(bm.Module(), bm.host)
+		hostParser := registration.HostParser
+		if !registration.parsesHost && !registration.MultipleHosts {
+			hostParser, err = r.hostParserFor(bm.Module(), bm.Name(), hostParser)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("host parsing failed for %v-%v: %w",
+					bm.Module().Name(), bm.Name(), err))
+				continue
+			}
+		}
+		if hostParser != nil && !registration.MultipleHosts {
+			bm.hostData, err = hostParser(bm.Module(), bm.host)
 			if err != nil {
 				errs = append(errs, fmt.Errorf("host parsing failed for %v-%v: %w",
 					bm.Module().Name(), bm.Name(), err))
@@ -135,19 +172,37 @@ func initMetricSets(r *Register, m Module) ([]MetricSet, error) {
 			}
 			bm.host = bm.hostData.Host
 		}
+		r.wrapTransport(&bm.hostData)
 
 		metricSet, err := registration.Factory(bm)
-		if err == nil {
-			err = mustHaveModule(metricSet, bm)
-			if err == nil {
-				err = mustImplementFetcher(metricSet)
+		if err != nil {
+			var ucfgErr ucfg.Error
+			if errors.As(err, &ucfgErr) {
+				err = newMetricSetConfigError(bm.Module().Name(), bm.Name(), err)
 			}
+			errs = append(errs, err)
+			continue
+		}
+
+		err = mustHaveModule(metricSet, bm)
+		if err == nil {
+			err = mustImplementFetcher(metricSet)
 		}
 		if err != nil {
 			errs = append(errs, err)
 			continue
 		}
 
+		if v, ok := metricSet.(Validator); ok {
+			if err := v.Validate(); err != nil {
+				errs = append(errs, newMetricSetConfigError(bm.Module().Name(), bm.Name(), err))
+				if closer, ok := metricSet.(Closer); ok {
+					_ = closer.Close()
+				}
+				continue
+			}
+		}
+
 		metricsets = append(metricsets, metricSet)
 	}
 
@@ -155,12 +210,13 @@ func initMetricSets(r *Register, m Module) ([]MetricSet, error) {
 }
 
 // newBaseMetricSets creates a new BaseMetricSet for all MetricSets defined
-// in the module's config. An error is returned if no MetricSets are specified
-// in the module's config and no default MetricSet is defined.
-func newBaseMetricSets(r *Register, m Module) ([]BaseMetricSet, error) {
-	hosts := []string{""}
-	if l := m.Config().Hosts; len(l) > 0 {
-		hosts = l
+// in the module's config and each of the given hosts. A single BaseMetricSet
+// is created for MetricSets registered with WithMultipleHosts, or none if
+// withMultipleHosts is false. An error is returned if no MetricSets are
+// specified in the module's config and no default MetricSet is defined.
+func newBaseMetricSets(r *Register, m Module, hosts []string, withMultipleHosts bool) ([]BaseMetricSet, error) {
+	if len(hosts) == 0 {
+		hosts = []string{""}
 	}
 
 	metricSetNames := m.Config().MetricSets
@@ -175,7 +231,15 @@ func newBaseMetricSets(r *Register, m Module) ([]BaseMetricSet, error) {
 	var metricsets []BaseMetricSet
 	for _, name := range metricSetNames {
 		name = strings.ToLower(name)
-		for _, host := range hosts {
+		msHosts := hosts
+		if registration, err := r.metricSetRegistration(m.Name(), name); err == nil && registration.MultipleHosts {
+			if !withMultipleHosts {
+				continue
+			}
+			// A single instance fetches all the hosts.
+			msHosts = []string{""}
+		}
+		for _, host := range msHosts {
 			id, err := uuid.NewV4()
 			if err != nil {
 				return nil, fmt.Errorf("failed to generate ID for metricset: %w", err)
@@ -198,17 +262,18 @@ func newBaseMetricSets(r *Register, m Module) ([]BaseMetricSet, error) {
 				monitoring.NewString(metrics, "id").Set(msID)
 			}
 
-			logger := logp.NewLogger(m.Name() + "." + name)
+			logger := m.Config().Logging.Logger(logp.NewLogger(m.Name() + "." + name))
 			if m.Config().ID != "" {
 				logger = logger.With("id", m.Config().ID)
 			}
 			metricsets = append(metricsets, BaseMetricSet{
-				id:      msID,
-				name:    name,
-				module:  m,
-				host:    host,
-				metrics: metrics,
-				logger:  logger,
+				id:             msID,
+				name:           name,
+				module:         m,
+				host:           host,
+				configuredHost: host,
+				metrics:        metrics,
+				logger:         logger,
 			})
 		}
 	}
@@ -250,6 +315,14 @@ func mustImplementFetcher(ms MetricSet) error {
 		ifcs = append(ifcs, "ReportingMetricSetV2WithContext")
 	}
 
+	if _, ok := ms.(ReportingMetricSetV2Batch); ok {
+		ifcs = append(ifcs, "ReportingMetricSetV2Batch")
+	}
+
+	if _, ok := ms.(ReportingMetricSetV2Stream); ok {
+		ifcs = append(ifcs, "ReportingMetricSetV2Stream")
+	}
+
 	if _, ok := ms.(PushMetricSetV2); ok {
 		ifcs = append(ifcs, "PushMetricSetV2")
 	}
@@ -258,12 +331,16 @@ func mustImplementFetcher(ms MetricSet) error {
 		ifcs = append(ifcs, "PushMetricSetV2WithContext")
 	}
 
+	if _, ok := ms.(PushMetricSetV3); ok {
+		ifcs = append(ifcs, "PushMetricSetV3")
+	}
+
 	switch len(ifcs) {
 	case 0:
 		return fmt.Errorf("MetricSet '%s/%s' does not implement an event "+
 			"producing interface ("+
-			"ReportingMetricSet, ReportingMetricSetV2, ReportingMetricSetV2Error, ReportingMetricSetV2WithContext"+
-			"PushMetricSet, PushMetricSetV2, or PushMetricSetV2WithContext)",
+			"ReportingMetricSet, ReportingMetricSetV2, ReportingMetricSetV2Error, ReportingMetricSetV2WithContext, ReportingMetricSetV2Batch, ReportingMetricSetV2Stream, "+
+			"PushMetricSet, PushMetricSetV2, PushMetricSetV2WithContext, or PushMetricSetV3)",
 			ms.Module().Name(), ms.Name())
 	case 1:
 		return nil
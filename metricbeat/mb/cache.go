@@ -0,0 +1,173 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"sync"
+	"time"
+)
+
+// ModuleCache is a cache shared by the MetricSets of a module instance, for
+// data that several of them need but that doesn't change on every fetch, such
+// as the UUID of a cluster. Each entry expires after its own TTL. All the
+// methods can be called on a nil ModuleCache, that doesn't keep any entry.
+// A ModuleCache is safe for concurrent use.
+type ModuleCache struct {
+	mu      sync.Mutex
+	entries map[string]moduleCacheEntry
+	loading map[string]chan struct{}
+	now     func() time.Time
+}
+
+type moduleCacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// NewModuleCache returns an empty ModuleCache.
+func NewModuleCache() *ModuleCache {
+	return &ModuleCache{
+		entries: map[string]moduleCacheEntry{},
+		loading: map[string]chan struct{}{},
+		now:     time.Now,
+	}
+}
+
+// ModuleCacheOf returns the cache of the module. It returns nil if the module
+// doesn't embed a BaseModule.
+func ModuleCacheOf(m Module) *ModuleCache {
+	if m, ok := m.(interface{ Cache() *ModuleCache }); ok {
+		return m.Cache()
+	}
+	return nil
+}
+
+// Get returns the value of the key, and whether it was found and not expired.
+func (c *ModuleCache) Get(key string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.get(key)
+}
+
+// Put sets the value of the key, that expires after the ttl.
+func (c *ModuleCache) Put(key string, value interface{}, ttl time.Duration) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.put(key, value, ttl)
+}
+
+// GetOrLoad returns the value of the key. If it is not found or it is
+// expired, it is loaded with load and kept for the ttl. Concurrent calls for
+// the same key wait for the value loaded by the first one. Errors of load are
+// returned and not cached.
+func (c *ModuleCache) GetOrLoad(key string, ttl time.Duration, load func() (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return load()
+	}
+
+	for {
+		c.mu.Lock()
+		if value, found := c.get(key); found {
+			c.mu.Unlock()
+			return value, nil
+		}
+		if wait, loading := c.loading[key]; loading {
+			c.mu.Unlock()
+			<-wait
+			continue
+		}
+		done := make(chan struct{})
+		c.loading[key] = done
+		c.mu.Unlock()
+
+		value, err := load()
+
+		c.mu.Lock()
+		delete(c.loading, key)
+		if err == nil {
+			c.put(key, value, ttl)
+		}
+		c.mu.Unlock()
+		close(done)
+		return value, err
+	}
+}
+
+// Delete removes the key.
+func (c *ModuleCache) Delete(key string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// Clear removes all the keys.
+func (c *ModuleCache) Clear() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]moduleCacheEntry{}
+}
+
+// Len returns the number of keys, including the expired ones that were not
+// removed yet.
+func (c *ModuleCache) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+func (c *ModuleCache) get(key string) (interface{}, bool) {
+	entry, found := c.entries[key]
+	if !found {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *ModuleCache) put(key string, value interface{}, ttl time.Duration) {
+	now := c.now()
+	for k, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = moduleCacheEntry{value: value, expires: now.Add(ttl)}
+}
@@ -0,0 +1,118 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleCache(t *testing.T) {
+	now := time.Now()
+	c := NewModuleCache()
+	c.now = func() time.Time { return now }
+
+	c.Put("uuid", "abc", time.Minute)
+	v, found := c.Get("uuid")
+	assert.True(t, found)
+	assert.Equal(t, "abc", v)
+
+	now = now.Add(time.Minute)
+	_, found = c.Get("uuid")
+	assert.False(t, found, "entry should expire after its ttl")
+	assert.Equal(t, 0, c.Len())
+
+	loads := 0
+	load := func() (interface{}, error) {
+		loads++
+		return loads, nil
+	}
+	v, err := c.GetOrLoad("loaded", time.Minute, load)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	v, err = c.GetOrLoad("loaded", time.Minute, load)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v, "value should be loaded once until it expires")
+
+	_, err = c.GetOrLoad("failed", time.Minute, func() (interface{}, error) {
+		return nil, errors.New("unavailable")
+	})
+	assert.EqualError(t, err, "unavailable")
+	_, found = c.Get("failed")
+	assert.False(t, found, "errors should not be cached")
+
+	c.Clear()
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestModuleCacheConcurrentLoads(t *testing.T) {
+	c := NewModuleCache()
+
+	var (
+		mu    sync.Mutex
+		loads int
+		wg    sync.WaitGroup
+	)
+	release := make(chan struct{})
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.GetOrLoad("key", time.Minute, func() (interface{}, error) {
+				mu.Lock()
+				loads++
+				mu.Unlock()
+				<-release
+				return "value", nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "value", v)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, 1, loads)
+}
+
+func TestModuleCacheOf(t *testing.T) {
+	r := newTestRegistry(t)
+	module, metricSets, err := NewModule(newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{metricSetName},
+		"hosts":      []string{"host1", "host2"},
+	}), r)
+	require.NoError(t, err)
+	require.Len(t, metricSets, 2)
+
+	cache := ModuleCacheOf(module)
+	require.NotNil(t, cache)
+	assert.Same(t, cache, ModuleCacheOf(metricSets[0].Module()))
+	assert.Same(t, cache, ModuleCacheOf(metricSets[1].Module()))
+
+	var nilCache *ModuleCache
+	nilCache.Put("key", "value", time.Minute)
+	_, found := nilCache.Get("key")
+	assert.False(t, found)
+}
@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+)
+
+// Capability is a requirement of a MetricSet on the environment Metricbeat
+// runs in, declared when the MetricSet is registered with WithCapabilities.
+type Capability string
+
+// Capabilities that MetricSets can require.
+const (
+	CapabilityRoot     Capability = "root"      // Running as root, or as an elevated administrator on Windows.
+	CapabilityCgroupV2 Capability = "cgroup_v2" // The unified cgroup v2 hierarchy mounted at /sys/fs/cgroup.
+	CapabilityWindows  Capability = "windows"   // Running on Windows.
+	CapabilityNetwork  Capability = "network"   // A network interface other than the loopback one is up.
+)
+
+// cgroupV2Controllers is the file that only exists in the root of a cgroup v2
+// hierarchy.
+const cgroupV2Controllers = "/sys/fs/cgroup/cgroup.controllers"
+
+// capabilityChecks contains the checks of the capabilities, that return an
+// error describing why the environment doesn't satisfy it.
+var capabilityChecks = map[Capability]func() error{
+	CapabilityRoot: checkRoot,
+	CapabilityCgroupV2: func() error {
+		if runtime.GOOS != "linux" {
+			return fmt.Errorf("cgroup v2 is not available on %s", runtime.GOOS)
+		}
+		if _, err := os.Stat(cgroupV2Controllers); err != nil {
+			return fmt.Errorf("cgroup v2 is not mounted at /sys/fs/cgroup: %w", err)
+		}
+		return nil
+	},
+	CapabilityWindows: func() error {
+		if runtime.GOOS != "windows" {
+			return fmt.Errorf("it only runs on windows, not on %s", runtime.GOOS)
+		}
+		return nil
+	},
+	CapabilityNetwork: func() error {
+		interfaces, err := net.Interfaces()
+		if err != nil {
+			return fmt.Errorf("failed to list network interfaces: %w", err)
+		}
+		for _, i := range interfaces {
+			if i.Flags&net.FlagUp != 0 && i.Flags&net.FlagLoopback == 0 {
+				return nil
+			}
+		}
+		return errors.New("no network interface is up")
+	},
+}
+
+// WithCapabilities specifies the capabilities that the environment must have
+// for the MetricSet to run. The MetricSet is not created when one of them is
+// missing, its creation fails with an UnsupportedMetricSetError, or it is
+// skipped if skip_unsupported is set in the module configuration.
+func WithCapabilities(capabilities ...Capability) MetricSetOption {
+	return func(r *MetricSetRegistration) {
+		r.Capabilities = append(r.Capabilities, capabilities...)
+	}
+}
+
+// UnsupportedMetricSetError is the error returned when a MetricSet cannot be
+// created because the environment doesn't have one of the capabilities it
+// requires.
+type UnsupportedMetricSetError struct {
+	Module     string     // Name of the module.
+	MetricSet  string     // Name of the MetricSet.
+	Capability Capability // Missing capability.
+	Err        error      // Reason why the capability is missing.
+}
+
+func (e *UnsupportedMetricSetError) Error() string {
+	return fmt.Sprintf("metricset '%s/%s' requires the '%s' capability: %v", e.Module, e.MetricSet, e.Capability, e.Err)
+}
+
+func (e *UnsupportedMetricSetError) Unwrap() error { return e.Err }
+
+// checkCapabilities returns an UnsupportedMetricSetError for the first
+// capability of the registration that the environment doesn't have.
+func checkCapabilities(module string, registration MetricSetRegistration) error {
+	for _, capability := range registration.Capabilities {
+		if err := capabilityChecks[capability](); err != nil {
+			return &UnsupportedMetricSetError{
+				Module:     module,
+				MetricSet:  registration.Name,
+				Capability: capability,
+				Err:        err,
+			}
+		}
+	}
+	return nil
+}
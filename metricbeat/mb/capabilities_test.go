@@ -0,0 +1,43 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package mb
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapabilityWindows(t *testing.T) {
+	err := capabilityChecks[CapabilityWindows]()
+	if runtime.GOOS == "windows" {
+		assert.NoError(t, err)
+	} else {
+		assert.EqualError(t, err, "it only runs on windows, not on "+runtime.GOOS)
+	}
+}
+
+func TestCapabilityCgroupV2(t *testing.T) {
+	if runtime.GOOS == "linux" {
+		t.Skip("the result depends on the cgroup hierarchy of the host")
+	}
+	assert.EqualError(t, capabilityChecks[CapabilityCgroupV2](), "cgroup v2 is not available on "+runtime.GOOS)
+}
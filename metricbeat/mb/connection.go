@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"context"
+	"sync"
+)
+
+// LazyConnection is a connection of a MetricSet to its target that is
+// established by the first fetch that uses it, instead of when the MetricSet
+// is created. MetricSets should not connect to their target in their factory,
+// as an error there prevents the whole module from being created when the
+// target is down at startup. With a LazyConnection the error is reported as an
+// error of the fetch, and the connection is tried again on the next one. A
+// LazyConnection is safe for concurrent use.
+type LazyConnection[T any] struct {
+	connect func(ctx context.Context) (T, error)
+	close   func(T) error
+
+	mu        sync.Mutex
+	conn      T
+	connected bool
+}
+
+// NewLazyConnection returns a LazyConnection that uses connect to establish
+// the connection, and close to close it. close can be nil if the connection
+// doesn't need to be closed.
+func NewLazyConnection[T any](connect func(ctx context.Context) (T, error), close func(T) error) *LazyConnection[T] {
+	return &LazyConnection[T]{connect: connect, close: close}
+}
+
+// Get returns the connection, establishing it if it is not established yet.
+// The error of the connection is returned otherwise, it should be returned as
+// the error of the fetch.
+func (c *LazyConnection[T]) Get(ctx context.Context) (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.connected {
+		return c.conn, nil
+	}
+
+	conn, err := c.connect(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	c.conn, c.connected = conn, true
+	return conn, nil
+}
+
+// Reset closes the connection, if it is established, so the next call to Get
+// establishes a new one. It should be called when a fetch fails because the
+// connection is broken.
+func (c *LazyConnection[T]) Reset() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.connected {
+		return nil
+	}
+
+	conn := c.conn
+	var zero T
+	c.conn, c.connected = zero, false
+	if c.close == nil {
+		return nil
+	}
+	return c.close(conn)
+}
+
+// Close closes the connection if it is established. It should be called by
+// the Close method of the MetricSet.
+func (c *LazyConnection[T]) Close() error {
+	return c.Reset()
+}
@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazyConnection(t *testing.T) {
+	var (
+		connects int
+		closed   []int
+		down     = true
+	)
+	conn := NewLazyConnection(
+		func(context.Context) (int, error) {
+			if down {
+				return 0, errors.New("connection refused")
+			}
+			connects++
+			return connects, nil
+		},
+		func(c int) error {
+			closed = append(closed, c)
+			return nil
+		},
+	)
+
+	// The target is down, the error is returned on every call.
+	_, err := conn.Get(context.Background())
+	assert.EqualError(t, err, "connection refused")
+	_, err = conn.Get(context.Background())
+	assert.EqualError(t, err, "connection refused")
+
+	// The connection is established once and reused.
+	down = false
+	c, err := conn.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, c)
+	c, err = conn.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, c)
+
+	// After a reset a new connection is established.
+	require.NoError(t, conn.Reset())
+	assert.Equal(t, []int{1}, closed)
+	c, err = conn.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, c)
+
+	require.NoError(t, conn.Close())
+	require.NoError(t, conn.Close())
+	assert.Equal(t, []int{1, 2}, closed)
+}
@@ -0,0 +1,63 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"context"
+	"time"
+)
+
+// ReporterContext returns the context of the MetricSet that owns the
+// reporter, so MetricSets implementing the deprecated PushMetricSetV2
+// interface can use APIs that take a context. The reporters created by the
+// module wrapper carry the context of the MetricSet, with its values and
+// deadline. For other reporters the returned context is only cancelled when
+// the done channel of the reporter is closed.
+func ReporterContext(r PushReporterV2) context.Context {
+	if c, ok := r.(interface{ Context() context.Context }); ok {
+		return c.Context()
+	}
+	return DoneContext(r.Done())
+}
+
+// DoneContext returns a context that is cancelled when the done channel is
+// closed. It adapts code that still uses done channels to APIs that take a
+// context. Unlike context.WithCancel, it doesn't start a goroutine to watch
+// the channel.
+func DoneContext(done <-chan struct{}) context.Context {
+	return doneContext{done: done}
+}
+
+// doneContext is a context without values or deadline, whose Done channel is
+// the given done channel.
+type doneContext struct {
+	done <-chan struct{}
+}
+
+func (doneContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (c doneContext) Done() <-chan struct{}     { return c.done }
+func (doneContext) Value(any) any               { return nil }
+
+func (c doneContext) Err() error {
+	select {
+	case <-c.done:
+		return context.Canceled
+	default:
+		return nil
+	}
+}
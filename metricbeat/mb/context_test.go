@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package mb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type doneReporter struct {
+	ReporterV2
+	done chan struct{}
+}
+
+func (r doneReporter) Done() <-chan struct{} { return r.done }
+
+type contextReporter struct {
+	doneReporter
+	ctx context.Context
+}
+
+func (r contextReporter) Context() context.Context { return r.ctx }
+
+func TestDoneContext(t *testing.T) {
+	done := make(chan struct{})
+	ctx := DoneContext(done)
+	assert.NoError(t, ctx.Err())
+
+	child, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	close(done)
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	<-child.Done()
+	assert.ErrorIs(t, child.Err(), context.Canceled)
+}
+
+func TestReporterContext(t *testing.T) {
+	t.Run("done channel", func(t *testing.T) {
+		r := doneReporter{done: make(chan struct{})}
+		ctx := ReporterContext(r)
+		assert.NoError(t, ctx.Err())
+		close(r.done)
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+
+	t.Run("reporter with context", func(t *testing.T) {
+		type key struct{}
+		ctx := context.WithValue(context.Background(), key{}, "value")
+		r := contextReporter{ctx: ctx}
+		assert.Equal(t, "value", ReporterContext(r).Value(key{}))
+	})
+}
@@ -0,0 +1,179 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+
+	"github.com/elastic/go-ucfg"
+)
+
+// ErrorCategory is the category of an error reported by a MetricSet. It is
+// added to error events in the error.type field.
+type ErrorCategory string
+
+// Error categories.
+const (
+	ErrorCategoryDNS               ErrorCategory = "dns"                // The host name could not be resolved.
+	ErrorCategoryConnectionRefused ErrorCategory = "connection_refused" // Nothing listens on the address of the host.
+	ErrorCategoryTLS               ErrorCategory = "tls"                // The TLS handshake or certificate verification failed.
+	ErrorCategoryAuth              ErrorCategory = "auth"               // The credentials were rejected.
+	ErrorCategoryTimeout           ErrorCategory = "timeout"            // The host didn't respond in time.
+	ErrorCategoryParse             ErrorCategory = "parse"              // The response could not be parsed.
+)
+
+type categorizedError struct {
+	category ErrorCategory
+	err      error
+}
+
+func (e *categorizedError) Error() string { return e.err.Error() }
+func (e *categorizedError) Unwrap() error { return e.err }
+
+// WithErrorCategory wraps the error so it has the given category. It is meant
+// for errors whose category can't be guessed from their type, such as
+// authentication errors. It returns nil if err is nil.
+func WithErrorCategory(err error, category ErrorCategory) error {
+	if err == nil {
+		return nil
+	}
+	return &categorizedError{category: category, err: err}
+}
+
+// ErrorCategoryOf returns the category of the error. It is the category the
+// error was wrapped with by WithErrorCategory, or the one of an Error in its
+// chain, if any, otherwise it is guessed
+// from the types of the errors in its chain. An empty category is returned if
+// it is unknown.
+func ErrorCategoryOf(err error) ErrorCategory {
+	if err == nil {
+		return ""
+	}
+
+	var categorized *categorizedError
+	if errors.As(err, &categorized) {
+		return categorized.category
+	}
+	var mbErr *Error
+	if errors.As(err, &mbErr) && mbErr.Category != "" {
+		return mbErr.Category
+	}
+
+	var (
+		dnsErr           *net.DNSError
+		unknownAuthority x509.UnknownAuthorityError
+		invalidCert      x509.CertificateInvalidError
+		hostnameErr      x509.HostnameError
+		verificationErr  *tls.CertificateVerificationError
+		recordHeaderErr  tls.RecordHeaderError
+		alertErr         tls.AlertError
+		jsonSyntaxErr    *json.SyntaxError
+		jsonUnmarshalErr *json.UnmarshalTypeError
+		xmlSyntaxErr     *xml.SyntaxError
+		numErr           *strconv.NumError
+		netErr           net.Error
+	)
+	switch {
+	case errors.As(err, &dnsErr):
+		return ErrorCategoryDNS
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return ErrorCategoryConnectionRefused
+	case errors.As(err, &unknownAuthority), errors.As(err, &invalidCert), errors.As(err, &hostnameErr),
+		errors.As(err, &verificationErr), errors.As(err, &recordHeaderErr), errors.As(err, &alertErr):
+		return ErrorCategoryTLS
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorCategoryTimeout
+	case errors.As(err, &jsonSyntaxErr), errors.As(err, &jsonUnmarshalErr), errors.As(err, &xmlSyntaxErr),
+		errors.As(err, &numErr):
+		return ErrorCategoryParse
+	}
+	return ""
+}
+
+// Error is an error reported by a MetricSet with a code that identifies the
+// failure, so events of the same failure mode can be found and alerted on
+// across modules. The code is added to error events in the error.code field,
+// and the category, if set, in the error.type field.
+type Error struct {
+	Code      string        // Code of the failure, like "cluster_unavailable".
+	Message   string        // Description of the error.
+	Retryable bool          // Whether the fetch can be retried.
+	Category  ErrorCategory // Category of the error, guessed from Err if not set.
+	Err       error         // Underlying error, if any.
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Err == nil:
+		return e.Message
+	case e.Message == "":
+		return e.Err.Error()
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// ErrorCodeOf returns the code of the first Error in the chain of the error,
+// or an empty string if there is none.
+func ErrorCodeOf(err error) string {
+	var mbErr *Error
+	if errors.As(err, &mbErr) {
+		return mbErr.Code
+	}
+	return ""
+}
+
+// MetricSetConfigError is the error returned when a MetricSet cannot be
+// created because its configuration is invalid.
+type MetricSetConfigError struct {
+	Module    string // Name of the module.
+	MetricSet string // Name of the MetricSet.
+	Key       string // Path of the invalid setting in the module configuration, if known.
+	Err       error
+}
+
+func newMetricSetConfigError(module, metricSet string, err error) *MetricSetConfigError {
+	e := &MetricSetConfigError{Module: module, MetricSet: metricSet, Err: err}
+
+	var ucfgErr ucfg.Error
+	if errors.As(err, &ucfgErr) {
+		e.Key = ucfgErr.Path()
+	}
+	return e
+}
+
+func (e *MetricSetConfigError) Error() string {
+	if e.Key != "" {
+		return fmt.Sprintf("invalid configuration of metricset '%s/%s' in '%s': %v", e.Module, e.MetricSet, e.Key, e.Err)
+	}
+	return fmt.Sprintf("invalid configuration of metricset '%s/%s': %v", e.Module, e.MetricSet, e.Err)
+}
+
+func (e *MetricSetConfigError) Unwrap() error { return e.Err }
@@ -0,0 +1,102 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package mb
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCategoryOf(t *testing.T) {
+	var syntaxErr *json.SyntaxError
+	jsonErr := json.Unmarshal([]byte("{"), &map[string]interface{}{})
+	assert.ErrorAs(t, jsonErr, &syntaxErr)
+
+	_, numErr := strconv.Atoi("ten")
+
+	tests := map[string]struct {
+		err      error
+		expected ErrorCategory
+	}{
+		"nil":     {nil, ""},
+		"unknown": {errors.New("something failed"), ""},
+		"dns": {
+			&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "example.invalid"}},
+			ErrorCategoryDNS,
+		},
+		"connection refused": {
+			fmt.Errorf("fetch failed: %w", &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}),
+			ErrorCategoryConnectionRefused,
+		},
+		"tls":              {fmt.Errorf("fetch failed: %w", x509.UnknownAuthorityError{}), ErrorCategoryTLS},
+		"context deadline": {fmt.Errorf("fetch failed: %w", context.DeadlineExceeded), ErrorCategoryTimeout},
+		"i/o timeout":      {&net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}, ErrorCategoryTimeout},
+		"json":             {fmt.Errorf("decoding response: %w", jsonErr), ErrorCategoryParse},
+		"number":           {numErr, ErrorCategoryParse},
+		"explicit": {
+			fmt.Errorf("fetch failed: %w", WithErrorCategory(errors.New("HTTP error 401"), ErrorCategoryAuth)),
+			ErrorCategoryAuth,
+		},
+		"explicit overrides guessed": {
+			WithErrorCategory(context.DeadlineExceeded, ErrorCategoryParse),
+			ErrorCategoryParse,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ErrorCategoryOf(tc.err))
+		})
+	}
+}
+
+func TestWithErrorCategory(t *testing.T) {
+	assert.NoError(t, WithErrorCategory(nil, ErrorCategoryAuth))
+
+	cause := errors.New("HTTP error 403")
+	err := WithErrorCategory(cause, ErrorCategoryAuth)
+	assert.Equal(t, cause.Error(), err.Error())
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestError(t *testing.T) {
+	cause := fmt.Errorf("request failed: %w", context.DeadlineExceeded)
+	err := fmt.Errorf("fetch failed: %w", &Error{Code: "cluster_unavailable", Message: "cluster is not available", Err: cause})
+
+	assert.Equal(t, "fetch failed: cluster is not available: request failed: context deadline exceeded", err.Error())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "cluster_unavailable", ErrorCodeOf(err))
+	assert.Equal(t, ErrorCategoryTimeout, ErrorCategoryOf(err))
+
+	err = &Error{Code: "invalid_credentials", Err: cause, Category: ErrorCategoryAuth}
+	assert.Equal(t, cause.Error(), err.Error())
+	assert.Equal(t, ErrorCategoryAuth, ErrorCategoryOf(err))
+
+	assert.Empty(t, ErrorCodeOf(cause))
+}
@@ -18,11 +18,14 @@
 package mb
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/beat/events"
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/processors/add_data_stream"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
@@ -31,6 +34,26 @@ import (
 // beat.Event. An example is AddMetricSetInfo.
 type EventModifier func(module, metricset string, event *Event)
 
+// EventModifierV2 is an EventModifier that can fail or drop the Event. The
+// Event is not published if drop is true. A returned error doesn't prevent the
+// Event from being published, it is reported in the stats of the MetricSet
+// that generated it.
+type EventModifierV2 func(module, metricset string, event *Event) (drop bool, err error)
+
+// EventModifierV2Of adapts an EventModifier to an EventModifierV2 that never
+// fails or drops the Event.
+func EventModifierV2Of(modifier EventModifier) EventModifierV2 {
+	return func(module, metricset string, event *Event) (bool, error) {
+		modifier(module, metricset, event)
+		return false, nil
+	}
+}
+
+// timestampNanosLayout is the layout of the metricset.timestamp field. Unlike
+// @timestamp, whose precision is set for the whole Beat, it always has nine
+// fractional digits, so it can be mapped as date_nanos.
+const timestampNanosLayout = "2006-01-02T15:04:05.000000000Z"
+
 // Event contains the data generated by a MetricSet.
 type Event struct {
 	RootFields      mapstr.M // Fields that will be added to the root of the event.
@@ -48,6 +71,42 @@ type Event struct {
 	Period    time.Duration // Period that is set to retrieve the events
 
 	DisableTimeSeries bool // true if the event doesn't contain timeseries data
+	TimestampNanos    bool // true if the Timestamp is added with nanosecond precision in metricset.timestamp
+
+	DataStream *DataStream // Data stream of the event. If set overwrites the data stream of the MetricSet.
+}
+
+// DataStream identifies the data stream an Event is routed to, so a MetricSet
+// can send some of its events to a different data stream than the others.
+// Only Dataset is required. When Type or Namespace are empty, the events are
+// routed by the publisher, which only replaces the dataset in the data stream
+// of the input when running under Elastic Agent. The Index of the Event takes
+// precedence over its DataStream.
+type DataStream struct {
+	Type      string
+	Dataset   string
+	Namespace string
+}
+
+// Modify applies the modifiers to the Event in the order they are given. It
+// stops as soon as a modifier drops the Event, and returns true in that case.
+// The errors of the modifiers applied are joined in the returned error.
+func (e *Event) Modify(module, metricSet string, modifiers ...EventModifierV2) (bool, error) {
+	if e.RootFields == nil {
+		e.RootFields = mapstr.M{}
+	}
+
+	var errs []error
+	for _, modify := range modifiers {
+		drop, err := modify(module, metricSet, e)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if drop {
+			return true, errors.Join(errs...)
+		}
+	}
+	return false, errors.Join(errs...)
 }
 
 // BeatEvent returns a new beat.Event containing the data this Event. It does
@@ -97,6 +156,24 @@ func (e *Event) BeatEvent(module, metricSet string, modifiers ...EventModifier)
 		b.Meta = mapstr.M{"index": e.Index}
 	}
 
+	if ds := e.DataStream; ds != nil && ds.Dataset != "" {
+		if b.Meta == nil {
+			b.Meta = mapstr.M{}
+		}
+		b.Meta[add_data_stream.FieldMetaCustomDataset] = ds.Dataset
+		b.Fields.Put("event.dataset", ds.Dataset)
+		b.Fields.Put("data_stream.dataset", ds.Dataset)
+		if ds.Type != "" && ds.Namespace != "" {
+			b.Meta[events.FieldMetaRawIndex] = ds.Type + "-" + ds.Dataset + "-" + ds.Namespace
+			b.Fields.Put("data_stream.type", ds.Type)
+			b.Fields.Put("data_stream.namespace", ds.Namespace)
+		}
+	}
+
+	if e.TimestampNanos && !e.Timestamp.IsZero() {
+		b.Fields.Put("metricset.timestamp", e.Timestamp.UTC().Format(timestampNanosLayout))
+	}
+
 	if e.ID != "" {
 		b.SetID(e.ID)
 	}
@@ -105,6 +182,12 @@ func (e *Event) BeatEvent(module, metricSet string, modifiers ...EventModifier)
 		b.Fields["error"] = mapstr.M{
 			"message": e.Error.Error(),
 		}
+		if category := ErrorCategoryOf(e.Error); category != "" {
+			b.Fields.Put("error.type", string(category))
+		}
+		if code := ErrorCodeOf(e.Error); code != "" {
+			b.Fields.Put("error.code", code)
+		}
 	}
 
 	return b
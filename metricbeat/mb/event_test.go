@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/beats/v7/libbeat/beat/events"
 	"github.com/elastic/elastic-agent-libs/mapstr"
@@ -169,6 +170,84 @@ func TestEventConversionToBeatEvent(t *testing.T) {
 			t.Fatal(err)
 		}
 		assert.Equal(t, msg, errorMessage)
+
+		_, err = e.Fields.GetValue("error.type")
+		assert.ErrorIs(t, err, mapstr.ErrKeyNotFound)
+	})
+
+	t.Run("error type", func(t *testing.T) {
+		e := (&Event{
+			Error: WithErrorCategory(errors.New("401 Unauthorized"), ErrorCategoryAuth),
+		}).BeatEvent(module, metricSet)
+
+		errorType, err := e.Fields.GetValue("error.type")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "auth", errorType)
+	})
+
+	t.Run("error code", func(t *testing.T) {
+		event := TransformMapStrToEvent(module, mapstr.M{}, &Error{
+			Code:     "cluster_unavailable",
+			Message:  "cluster is not available",
+			Category: ErrorCategoryTimeout,
+		})
+		e := event.BeatEvent(module, metricSet)
+
+		errorFields, err := e.Fields.GetValue("error")
+		require.NoError(t, err)
+		assert.Equal(t, mapstr.M{
+			"message": "cluster is not available",
+			"code":    "cluster_unavailable",
+			"type":    "timeout",
+		}, errorFields)
+	})
+
+	t.Run("timestamp nanos", func(t *testing.T) {
+		ts := time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.FixedZone("CEST", 2*60*60))
+		e := (&Event{
+			Timestamp:      ts,
+			TimestampNanos: true,
+		}).BeatEvent(module, metricSet, AddMetricSetInfo)
+
+		assert.Equal(t, ts, e.Timestamp)
+		nanos, err := e.Fields.GetValue("metricset.timestamp")
+		require.NoError(t, err)
+		assert.Equal(t, "2024-05-06T05:08:09.123456789Z", nanos)
+		name, err := e.Fields.GetValue("metricset.name")
+		require.NoError(t, err)
+		assert.Equal(t, metricSet, name)
+
+		e = (&Event{Timestamp: ts}).BeatEvent(module, metricSet)
+		_, err = e.Fields.GetValue("metricset.timestamp")
+		assert.ErrorIs(t, err, mapstr.ErrKeyNotFound)
+	})
+
+	t.Run("with data stream", func(t *testing.T) {
+		e := (&Event{
+			DataStream: &DataStream{Type: "logs", Dataset: "docker.audit", Namespace: "prod"},
+		}).BeatEvent(module, metricSet, AddMetricSetInfo)
+
+		assert.Equal(t, "logs-docker.audit-prod", e.Meta[events.FieldMetaRawIndex])
+		assert.Equal(t, "docker.audit", e.Meta["dataset"])
+		dataset, err := e.Fields.GetValue("event.dataset")
+		require.NoError(t, err)
+		assert.Equal(t, "docker.audit", dataset)
+		dataStream, err := e.Fields.GetValue("data_stream")
+		require.NoError(t, err)
+		assert.Equal(t, mapstr.M{"type": "logs", "dataset": "docker.audit", "namespace": "prod"}, dataStream)
+	})
+
+	t.Run("with data stream dataset only", func(t *testing.T) {
+		e := (&Event{
+			DataStream: &DataStream{Dataset: "docker.audit"},
+		}).BeatEvent(module, metricSet)
+
+		assert.Equal(t, mapstr.M{"dataset": "docker.audit"}, e.Meta)
+		dataStream, err := e.Fields.GetValue("data_stream")
+		require.NoError(t, err)
+		assert.Equal(t, mapstr.M{"dataset": "docker.audit"}, dataStream)
 	})
 }
 
@@ -218,6 +297,42 @@ func TestAddMetricSetInfo(t *testing.T) {
 	})
 }
 
+func TestEventModify(t *testing.T) {
+	var applied []string
+	modifier := func(name string, drop bool, err error) EventModifierV2 {
+		return func(module, metricset string, event *Event) (bool, error) {
+			applied = append(applied, name)
+			return drop, err
+		}
+	}
+
+	t.Run("errors are joined", func(t *testing.T) {
+		applied = nil
+		e := Event{}
+		drop, err := e.Modify(moduleName, metricSetName,
+			modifier("a", false, errors.New("a failed")),
+			EventModifierV2Of(AddMetricSetInfo),
+			modifier("b", false, errors.New("b failed")),
+		)
+		assert.False(t, drop)
+		assert.EqualError(t, err, "a failed\nb failed")
+		assert.Equal(t, []string{"a", "b"}, applied)
+		assert.Equal(t, metricSetName, e.RootFields["metricset"].(mapstr.M)["name"])
+	})
+
+	t.Run("drop stops the modifiers", func(t *testing.T) {
+		applied = nil
+		e := Event{}
+		drop, err := e.Modify(moduleName, metricSetName,
+			modifier("a", true, nil),
+			modifier("b", false, errors.New("b failed")),
+		)
+		assert.True(t, drop)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a"}, applied)
+	})
+}
+
 func TestTransformMapStrToEvent(t *testing.T) {
 	var (
 		timestamp  = time.Now()
@@ -0,0 +1,79 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Failover selects the endpoint used by the fetches of a MetricSet among the
+// endpoints of its HostData, for services that expose several equivalent
+// endpoints. Fetches use the last endpoint that succeeded, and fail over to
+// the other ones when it fails. A Failover is safe for concurrent use.
+type Failover struct {
+	mu        sync.Mutex
+	endpoints []HostData
+	current   int
+}
+
+// NewFailover returns a Failover between the endpoint of the HostData and its
+// fallbacks. The endpoint of the HostData is used first.
+func NewFailover(host HostData) *Failover {
+	return &Failover{endpoints: host.Endpoints()}
+}
+
+// Current returns the endpoint in use.
+func (f *Failover) Current() HostData {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.endpoints[f.current]
+}
+
+// Do calls fn with the endpoint in use. If fn fails, it is called with the
+// next endpoints in order until it succeeds, and the endpoint it succeeded
+// with is used from then on. If fn fails with all the endpoints, their errors,
+// prefixed with the sanitized URI of the endpoint when there are fallbacks, are
+// joined in the returned error.
+func (f *Failover) Do(fn func(HostData) error) error {
+	if len(f.endpoints) == 1 {
+		return fn(f.endpoints[0])
+	}
+
+	f.mu.Lock()
+	start := f.current
+	f.mu.Unlock()
+
+	var errs []error
+	for i := range f.endpoints {
+		index := (start + i) % len(f.endpoints)
+		endpoint := f.endpoints[index]
+		err := fn(endpoint)
+		if err == nil {
+			if index != start {
+				f.mu.Lock()
+				f.current = index
+				f.mu.Unlock()
+			}
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", endpoint.SanitizedURI, err))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package mb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailover(t *testing.T) {
+	host := HostData{
+		URI:          "http://a",
+		SanitizedURI: "http://a",
+		Fallbacks: []HostData{
+			{URI: "http://b", SanitizedURI: "http://b"},
+			{URI: "http://c", SanitizedURI: "http://c"},
+		},
+	}
+	f := NewFailover(host)
+	assert.Equal(t, "http://a", f.Current().URI)
+	assert.Empty(t, f.Current().Fallbacks)
+
+	down := map[string]bool{"http://a": true, "http://b": true}
+	var tried []string
+	fetch := func(h HostData) error {
+		tried = append(tried, h.URI)
+		if down[h.URI] {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	// Fails over to the first endpoint that works, and keeps using it.
+	assert.NoError(t, f.Do(fetch))
+	assert.Equal(t, []string{"http://a", "http://b", "http://c"}, tried)
+	assert.Equal(t, "http://c", f.Current().URI)
+
+	tried = nil
+	down["http://c"] = true
+	down["http://a"] = false
+	assert.NoError(t, f.Do(fetch))
+	assert.Equal(t, []string{"http://c", "http://a"}, tried)
+
+	down["http://a"] = true
+	err := f.Do(fetch)
+	assert.EqualError(t, err, "http://a: connection refused\nhttp://b: connection refused\nhttp://c: connection refused")
+}
+
+func TestFailoverWithoutFallbacks(t *testing.T) {
+	f := NewFailover(HostData{URI: "http://a", SanitizedURI: "http://a"})
+	err := f.Do(func(HostData) error { return errors.New("connection refused") })
+	assert.EqualError(t, err, "connection refused")
+}
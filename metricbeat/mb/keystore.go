@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/go-ucfg"
+	"github.com/elastic/go-ucfg/parse"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/keystore"
+)
+
+// keystorePrefix is the prefix of the variables that reference keys of the
+// keystore in module configurations, as in ${keystore.my_password}.
+const keystorePrefix = "keystore."
+
+// SetKeystore sets the keystore used to resolve the ${keystore.<key>}
+// references in module configurations. The references are resolved every time
+// a module is created, so modules created on configuration reloads use the
+// current values of the keystore.
+func (r *Register) SetKeystore(store keystore.Keystore) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.keystore = store
+}
+
+// ResolveKeystoreReferences resolves the ${keystore.<key>} references of a
+// module configuration as they are resolved when the module is created, see
+// resolveKeystoreReferences.
+func (r *Register) ResolveKeystoreReferences(config *conf.C) (*conf.C, error) {
+	return r.resolveKeystoreReferences(config)
+}
+
+// resolveKeystoreReferences returns a copy of the configuration where the
+// ${keystore.<key>} references are replaced by the values of the keys in the
+// keystore of the Register, in any field, including the nested ones. Other
+// variables are kept to be resolved when the configuration is unpacked. The
+// original configuration is returned if there are no references.
+func (r *Register) resolveKeystoreReferences(config *conf.C) (*conf.C, error) {
+	r.lock.RLock()
+	store := r.keystore
+	r.lock.RUnlock()
+
+	if store == nil {
+		return config, nil
+	}
+
+	// Resolvers are tried in reverse order, so ResolveNOOP, that keeps the
+	// variable, is only used when the variable is not a keystore reference.
+	resolved := false
+	var errs []error
+	retrieve := keystore.ResolverWrap(store)
+	resolver := func(name string) (string, parse.Config, error) {
+		key, found := strings.CutPrefix(name, keystorePrefix)
+		if !found {
+			return "", parse.NoopConfig, ucfg.ErrMissing
+		}
+
+		value, cfg, err := retrieve(key)
+		if errors.Is(err, ucfg.ErrMissing) {
+			err = fmt.Errorf("key '%s' not found in the keystore", key)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			return "", cfg, err
+		}
+		resolved = true
+		return value, cfg, nil
+	}
+
+	var fields map[string]interface{}
+	err := (*ucfg.Config)(config).Unpack(&fields, ucfg.PathSep("."), ucfg.ResolveNOOP, ucfg.Resolve(resolver))
+	if err == nil {
+		err = errors.Join(errs...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve keystore references: %w", err)
+	}
+	if !resolved {
+		return config, nil
+	}
+
+	return conf.NewConfigFrom(fields)
+}
@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/keystore"
+)
+
+type mapKeystore map[string]string
+
+func (k mapKeystore) Retrieve(key string) (*keystore.SecureString, error) {
+	v, found := k[key]
+	if !found {
+		return nil, keystore.ErrKeyDoesntExists
+	}
+	return keystore.NewSecureString([]byte(v)), nil
+}
+
+func (k mapKeystore) GetConfig() (*conf.C, error) { return conf.NewConfig(), nil }
+
+func (k mapKeystore) IsPersisted() bool { return true }
+
+func TestKeystoreReferences(t *testing.T) {
+	t.Setenv("TEST_KEYSTORE_USER", "admin")
+
+	const config = `
+module: ` + moduleName + `
+metricsets: [` + metricSetName + `]
+hosts: ["localhost"]
+username: ${TEST_KEYSTORE_USER}
+password: ${keystore.password}
+query:
+  token: ${keystore.token}
+headers:
+  Authorization: Bearer ${keystore.token}
+`
+
+	type moduleSettings struct {
+		Username string            `config:"username"`
+		Password string            `config:"password"`
+		Headers  map[string]string `config:"headers"`
+	}
+
+	store := mapKeystore{"password": "secret", "token": "abc"}
+	r := newTestRegistry(t)
+	r.SetKeystore(store)
+
+	newModule := func(t *testing.T) Module {
+		c, err := conf.NewConfigWithYAML([]byte(config), "test")
+		require.NoError(t, err)
+		m, _, err := NewModule(c, r)
+		require.NoError(t, err)
+		return m
+	}
+
+	t.Run("nested fields", func(t *testing.T) {
+		m := newModule(t)
+
+		var settings moduleSettings
+		require.NoError(t, m.UnpackConfig(&settings))
+		assert.Equal(t, "admin", settings.Username)
+		assert.Equal(t, "secret", settings.Password)
+		assert.Equal(t, map[string]string{"Authorization": "Bearer abc"}, settings.Headers)
+		assert.Equal(t, QueryParams{"token": "abc"}, m.Config().Query)
+	})
+
+	t.Run("resolved again on new modules", func(t *testing.T) {
+		store["token"] = "def"
+		m := newModule(t)
+
+		var settings moduleSettings
+		require.NoError(t, m.UnpackConfig(&settings))
+		assert.Equal(t, map[string]string{"Authorization": "Bearer def"}, settings.Headers)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		delete(store, "password")
+		c, err := conf.NewConfigWithYAML([]byte(config), "test")
+		require.NoError(t, err)
+
+		_, _, err = NewModule(c, r)
+		assert.ErrorContains(t, err, "key 'password' not found in the keystore")
+	})
+}
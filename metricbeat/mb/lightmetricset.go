@@ -19,6 +19,9 @@ package mb
 
 import (
 	"fmt"
+	"runtime"
+	"strings"
+	"text/template"
 
 	"github.com/elastic/beats/v7/libbeat/processors"
 	conf "github.com/elastic/elastic-agent-libs/config"
@@ -33,8 +36,34 @@ type LightMetricSet struct {
 		Module    string      `config:"module" validate:"required"`
 		MetricSet string      `config:"metricset" validate:"required"`
 		Defaults  interface{} `config:"defaults"`
+
+		// HostParser is the name of a registered HostParser, used instead
+		// of the one of the input metricset.
+		HostParser string `config:"host_parser"`
+
+		// Scheme is added to the hosts that don't include one, Path and
+		// Query are the defaults of the path and query settings.
+		Scheme string                 `config:"scheme"`
+		Path   string                 `config:"path"`
+		Query  map[string]interface{} `config:"query"`
 	} `config:"input" validate:"required"`
 	Processors processors.PluginConfig `config:"processors"`
+
+	// Dataset is the dataset of the events of the metricset, instead of
+	// <module>.<metricset>.
+	Dataset string `config:"dataset"`
+
+	// Vars are the variables that can be used in templates in the input of
+	// the manifest, their values can be set with var.<name> settings in the
+	// module configuration.
+	Vars []LightMetricSetVar `config:"var"`
+}
+
+// LightMetricSetVar is a variable declared in a light metricset manifest.
+type LightMetricSetVar struct {
+	Name    string                 `config:"name" validate:"required"`
+	Default interface{}            `config:"default"`
+	OS      map[string]interface{} `config:"os"` // Defaults for specific operating systems.
 }
 
 // Registration obtains a metric set registration for this light metric set, this registration
@@ -51,11 +80,22 @@ func (m *LightMetricSet) Registration(r *Register) (MetricSetRegistration, error
 
 	originalFactory := registration.Factory
 	registration.IsDefault = m.Default
+	if m.Dataset != "" {
+		registration.Namespace = m.Dataset
+	}
 
 	// Disable the host parser, we will call it as part of the factory so the original
 	// host in the base module is not modified.
 	originalHostParser := registration.HostParser
+	if m.Input.HostParser != "" {
+		originalHostParser, err = r.HostParser(m.Input.HostParser)
+		if err != nil {
+			return registration, fmt.Errorf("invalid host parser for light metricset '%s/%s': %w", m.Module, m.Name, err)
+		}
+	}
 	registration.HostParser = nil
+	registration.HostParserName = ""
+	registration.parsesHost = true
 
 	// Light modules factory has to override defaults and reproduce builder
 	// functionality with the resulting configuration, it does:
@@ -67,7 +107,11 @@ func (m *LightMetricSet) Registration(r *Register) (MetricSetRegistration, error
 	registration.Factory = func(base BaseMetricSet) (MetricSet, error) {
 		// Override default config on base module and metricset
 		base.name = m.Name
-		baseModule, err := m.baseModule(base.module)
+		input, err := m.renderInput(base.module)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render the input of light metricset '%s/%s': %w", m.Module, m.Name, err)
+		}
+		baseModule, err := m.baseModule(base.module, input)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create base module for light module '%s', using base module '%s': %w", m.Module, base.module.Name(), err)
 		}
@@ -84,9 +128,18 @@ func (m *LightMetricSet) Registration(r *Register) (MetricSetRegistration, error
 			base.module = module
 		}
 
-		// Run the host parser if there was anyone defined
-		if originalHostParser != nil {
-			base.hostData, err = originalHostParser(base.module, base.host)
+		if input.Scheme != "" {
+			base.host = withDefaultScheme(base.host, input.Scheme)
+		}
+
+		// Run the host parser if there was anyone defined, or one was
+		// set in the configuration
+		hostParser, err := r.hostParserFor(base.module, m.Name, originalHostParser)
+		if err != nil {
+			return nil, fmt.Errorf("host parser failed on light metricset factory for '%s/%s': %w", m.Module, m.Name, err)
+		}
+		if hostParser != nil {
+			base.hostData, err = hostParser(base.module, base.host)
 			if err != nil {
 				return nil, fmt.Errorf("host parser failed on light metricset factory for '%s/%s': %w", m.Module, m.Name, err)
 			}
@@ -101,15 +154,27 @@ func (m *LightMetricSet) Registration(r *Register) (MetricSetRegistration, error
 
 // baseModule does the configuration overrides in the base module configuration
 // taking into account the light metric set default configurations
-func (m *LightMetricSet) baseModule(from Module) (*BaseModule, error) {
-	// Initialize config using input defaults as raw config
-	rawConfig, err := conf.NewConfigFrom(m.Input.Defaults)
-	if err != nil {
-		return nil, fmt.Errorf("invalid input defaults: %w", err)
+func (m *LightMetricSet) baseModule(from Module, input lightMetricSetInput) (*BaseModule, error) {
+	// Initialize config using input path, query and defaults as raw config
+	rawConfig := conf.NewConfig()
+	if input.Path != "" {
+		if err := rawConfig.SetString("path", -1, input.Path); err != nil {
+			return nil, fmt.Errorf("invalid input path: %w", err)
+		}
+	}
+	if len(input.Query) > 0 {
+		if err := rawConfig.Merge(map[string]interface{}{"query": input.Query}); err != nil {
+			return nil, fmt.Errorf("invalid input query: %w", err)
+		}
+	}
+	if input.Defaults != nil {
+		if err := rawConfig.Merge(input.Defaults); err != nil {
+			return nil, fmt.Errorf("invalid input defaults: %w", err)
+		}
 	}
 
 	// Copy values from user configuration
-	if err = from.UnpackConfig(rawConfig); err != nil {
+	if err := from.UnpackConfig(rawConfig); err != nil {
 		return nil, fmt.Errorf("failed to copy values from user configuration: %w", err)
 	}
 
@@ -119,6 +184,141 @@ func (m *LightMetricSet) baseModule(from Module) (*BaseModule, error) {
 		return nil, fmt.Errorf("failed to create base module: %w", err)
 	}
 	baseModule.name = m.Module
+	if cache := ModuleCacheOf(from); cache != nil {
+		baseModule.cache = cache
+	}
 
 	return &baseModule, nil
 }
+
+// lightMetricSetInput are the settings of the input of a light metricset
+// that can use variables.
+type lightMetricSetInput struct {
+	Scheme   string
+	Path     string
+	Query    map[string]interface{}
+	Defaults interface{}
+}
+
+// renderInput returns the settings of the input with their templates
+// executed with the variables of the metricset.
+func (m *LightMetricSet) renderInput(from Module) (lightMetricSetInput, error) {
+	input := lightMetricSetInput{
+		Scheme:   m.Input.Scheme,
+		Path:     m.Input.Path,
+		Query:    m.Input.Query,
+		Defaults: m.Input.Defaults,
+	}
+	if len(m.Vars) == 0 {
+		return input, nil
+	}
+
+	vars, err := m.vars(from)
+	if err != nil {
+		return input, err
+	}
+
+	if input.Scheme, err = applyTemplate(vars, input.Scheme); err != nil {
+		return input, fmt.Errorf("invalid scheme: %w", err)
+	}
+	if input.Path, err = applyTemplate(vars, input.Path); err != nil {
+		return input, fmt.Errorf("invalid path: %w", err)
+	}
+	query, err := renderTemplates(vars, input.Query)
+	if err != nil {
+		return input, fmt.Errorf("invalid query: %w", err)
+	}
+	input.Query, _ = query.(map[string]interface{})
+	if input.Defaults, err = renderTemplates(vars, input.Defaults); err != nil {
+		return input, fmt.Errorf("invalid defaults: %w", err)
+	}
+	return input, nil
+}
+
+// vars returns the values of the variables of the metricset. They are the
+// ones in the var settings of the module configuration, or their defaults.
+// Defaults can use the variables declared before them.
+func (m *LightMetricSet) vars(from Module) (map[string]interface{}, error) {
+	config := struct {
+		Vars map[string]interface{} `config:"var"`
+	}{}
+	if err := from.UnpackConfig(&config); err != nil {
+		return nil, fmt.Errorf("invalid variables: %w", err)
+	}
+
+	vars := map[string]interface{}{}
+	for _, v := range m.Vars {
+		value, found := config.Vars[v.Name]
+		if !found {
+			value = v.Default
+			if osValue, found := v.OS[runtime.GOOS]; found {
+				value = osValue
+			}
+			var err error
+			value, err = renderTemplates(vars, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid default of variable '%s': %w", v.Name, err)
+			}
+		}
+		vars[v.Name] = value
+	}
+	return vars, nil
+}
+
+// renderTemplates executes the templates in the strings of the value, that
+// can be nested in maps and lists.
+func renderTemplates(vars map[string]interface{}, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return applyTemplate(vars, v)
+	case map[string]interface{}:
+		rendered := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			var err error
+			if rendered[key], err = renderTemplates(vars, value); err != nil {
+				return nil, err
+			}
+		}
+		return rendered, nil
+	case []interface{}:
+		rendered := make([]interface{}, len(v))
+		for i, value := range v {
+			var err error
+			if rendered[i], err = renderTemplates(vars, value); err != nil {
+				return nil, err
+			}
+		}
+		return rendered, nil
+	}
+	return value, nil
+}
+
+// applyTemplate executes the string as a Go template with the variables.
+func applyTemplate(vars map[string]interface{}, s string) (string, error) {
+	if !strings.Contains(s, "{{") {
+		return s, nil
+	}
+
+	tpl, err := template.New("input").Option("missingkey=error").Parse(s)
+	if err != nil {
+		return "", err
+	}
+	var buf strings.Builder
+	if err := tpl.Execute(&buf, vars); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// withDefaultScheme adds the scheme to the URLs of the host that don't include
+// one, including its fallback URLs.
+func withDefaultScheme(host, scheme string) string {
+	urls := strings.Split(host, "|")
+	for i, u := range urls {
+		u = strings.TrimSpace(u)
+		if u != "" && !strings.Contains(u, "://") {
+			urls[i] = scheme + "://" + u
+		}
+	}
+	return strings.Join(urls, "|")
+}
@@ -379,6 +379,246 @@ func TestLightMetricSet_VerifyHostDataURI_NonParsableHost(t *testing.T) {
 	assert.Equal(t, postgresParsed, metricSets[0].HostData().URI)
 }
 
+func TestLightMetricSet_NamedHostParser(t *testing.T) {
+	const sampleHttpsEndpoint = "https://ceph-restful:8003"
+
+	r := NewRegister()
+	r.MustAddMetricSet("http", "json", newMetricSetWithOption)
+	r.MustAddHostParser("test", func(module Module, host string) (HostData, error) {
+		return HostData{Host: "test", URI: host}, nil
+	})
+	r.MustAddHostParser("other", func(module Module, host string) (HostData, error) {
+		return HostData{Host: "other", URI: host}, nil
+	})
+	r.SetSecondarySource(NewLightModulesSource("testdata/lightmodules"))
+
+	t.Run("from manifest", func(t *testing.T) {
+		config, err := conf.NewConfigFrom(mapstr.M{
+			"module":     "httpextended",
+			"metricsets": []string{"named"},
+			"hosts":      []string{sampleHttpsEndpoint},
+		})
+		require.NoError(t, err)
+
+		_, metricSets, err := NewModule(config, r)
+		require.NoError(t, err)
+		require.Len(t, metricSets, 1)
+
+		assert.Equal(t, "test", metricSets[0].Host())
+		assert.Equal(t, sampleHttpsEndpoint, metricSets[0].HostData().URI)
+	})
+
+	t.Run("from config", func(t *testing.T) {
+		config, err := conf.NewConfigFrom(mapstr.M{
+			"module":      "httpextended",
+			"metricsets":  []string{"named"},
+			"hosts":       []string{sampleHttpsEndpoint},
+			"host_parser": "other",
+		})
+		require.NoError(t, err)
+
+		_, metricSets, err := NewModule(config, r)
+		require.NoError(t, err)
+		require.Len(t, metricSets, 1)
+
+		assert.Equal(t, "other", metricSets[0].Host())
+		assert.Equal(t, sampleHttpsEndpoint, metricSets[0].HostData().URI)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		config, err := conf.NewConfigFrom(mapstr.M{
+			"module":      "httpextended",
+			"metricsets":  []string{"extends"},
+			"hosts":       []string{sampleHttpsEndpoint},
+			"host_parser": "unknown",
+		})
+		require.NoError(t, err)
+
+		_, _, err = NewModule(config, r)
+		assert.ErrorContains(t, err, "host parser 'unknown' not found")
+	})
+}
+
+func TestLightMetricSet_URLDefaults(t *testing.T) {
+	r := NewRegister()
+	r.MustAddMetricSet("http", "json", newMetricSetWithOption,
+		WithHostParser(func(module Module, host string) (HostData, error) {
+			config := struct {
+				Path  string      `config:"path"`
+				Query QueryParams `config:"query"`
+			}{}
+			if err := module.UnpackConfig(&config); err != nil {
+				return HostData{}, err
+			}
+			u, err := url.Parse(host)
+			if err != nil {
+				return HostData{}, err
+			}
+			u.Path = config.Path
+			u.RawQuery = config.Query.String()
+			return HostData{Host: u.Host, URI: u.String()}, nil
+		}))
+	r.SetSecondarySource(NewLightModulesSource("testdata/lightmodules"))
+
+	cases := map[string]struct {
+		config      mapstr.M
+		expectedURI string
+	}{
+		"manifest defaults": {
+			config:      mapstr.M{"hosts": []string{"metrics:9443"}},
+			expectedURI: "https://metrics:9443/custom/metrics?format=json",
+		},
+		"host with scheme": {
+			config:      mapstr.M{"hosts": []string{"http://metrics:9443"}},
+			expectedURI: "http://metrics:9443/custom/metrics?format=json",
+		},
+		"user settings": {
+			config: mapstr.M{
+				"hosts": []string{"metrics:9443"},
+				"path":  "/other",
+				"query": mapstr.M{"format": "text"},
+			},
+			expectedURI: "https://metrics:9443/other?format=text",
+		},
+	}
+
+	for title, c := range cases {
+		t.Run(title, func(t *testing.T) {
+			c.config.Update(mapstr.M{
+				"module":     "httpextended",
+				"metricsets": []string{"custom"},
+			})
+			config, err := conf.NewConfigFrom(c.config)
+			require.NoError(t, err)
+
+			_, metricSets, err := NewModule(config, r)
+			require.NoError(t, err)
+			require.Len(t, metricSets, 1)
+
+			assert.Equal(t, "metrics:9443", metricSets[0].Host())
+			assert.Equal(t, c.expectedURI, metricSets[0].HostData().URI)
+		})
+	}
+}
+
+func TestLightMetricSet_Vars(t *testing.T) {
+	r := NewRegister()
+	r.MustAddMetricSet("http", "json", newMetricSetWithOption,
+		WithHostParser(func(module Module, host string) (HostData, error) {
+			config := struct {
+				Path  string      `config:"path"`
+				Query QueryParams `config:"query"`
+			}{}
+			if err := module.UnpackConfig(&config); err != nil {
+				return HostData{}, err
+			}
+			u, err := url.Parse(host)
+			if err != nil {
+				return HostData{}, err
+			}
+			u.Path = config.Path
+			u.RawQuery = config.Query.String()
+			return HostData{Host: u.Host, URI: u.String()}, nil
+		}))
+	r.SetSecondarySource(NewLightModulesSource("testdata/lightmodules"))
+
+	cases := map[string]struct {
+		config         mapstr.M
+		expectedURI    string
+		expectedOption string
+	}{
+		"defaults": {
+			config:         mapstr.M{"hosts": []string{"metrics:9443"}},
+			expectedURI:    "https://metrics:9443/json/metrics?format=json",
+			expectedOption: "localhost:9443/json",
+		},
+		"variables": {
+			config: mapstr.M{
+				"hosts":      []string{"metrics:9443"},
+				"var.port":   8080,
+				"var.format": "text",
+			},
+			expectedURI:    "https://metrics:9443/text/metrics?format=text",
+			expectedOption: "localhost:8080/text",
+		},
+		"user settings": {
+			config: mapstr.M{
+				"hosts":      []string{"http://metrics:80"},
+				"option":     "user",
+				"var.format": "text",
+			},
+			expectedURI:    "http://metrics:80/text/metrics?format=text",
+			expectedOption: "user",
+		},
+	}
+
+	for title, c := range cases {
+		t.Run(title, func(t *testing.T) {
+			c.config.Update(mapstr.M{
+				"module":     "httpextended",
+				"metricsets": []string{"templated"},
+			})
+			config, err := conf.NewConfigFrom(c.config)
+			require.NoError(t, err)
+
+			_, metricSets, err := NewModule(config, r)
+			require.NoError(t, err)
+			require.Len(t, metricSets, 1)
+
+			assert.Equal(t, c.expectedURI, metricSets[0].HostData().URI)
+			assert.Equal(t, c.expectedOption, metricSets[0].(*metricSetWithOption).Option)
+		})
+	}
+}
+
+func TestRenderTemplates(t *testing.T) {
+	vars := map[string]interface{}{"port": 9443}
+
+	rendered, err := renderTemplates(vars, map[string]interface{}{
+		"hosts":   []interface{}{"localhost:{{.port}}"},
+		"timeout": 10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"hosts":   []interface{}{"localhost:9443"},
+		"timeout": 10,
+	}, rendered)
+
+	_, err = renderTemplates(vars, []interface{}{"{{.undeclared}}"})
+	assert.ErrorContains(t, err, "map has no entry for key \"undeclared\"")
+}
+
+func TestWithDefaultScheme(t *testing.T) {
+	assert.Equal(t, "https://node1:9443", withDefaultScheme("node1:9443", "https"))
+	assert.Equal(t, "https://node1:9443|http://node2:80", withDefaultScheme("node1:9443|http://node2:80", "https"))
+}
+
+func TestLightMetricSet_SharedModuleCache(t *testing.T) {
+	r := NewRegister()
+	r.MustAddMetricSet("http", "json", newMetricSetWithOption)
+	r.MustAddHostParser("test", func(module Module, host string) (HostData, error) {
+		return HostData{Host: host, URI: host}, nil
+	})
+	r.SetSecondarySource(NewLightModulesSource("testdata/lightmodules"))
+
+	config, err := conf.NewConfigFrom(mapstr.M{
+		"module":     "httpextended",
+		"metricsets": []string{"extends", "named"},
+		"hosts":      []string{"https://ceph-restful:8003"},
+	})
+	require.NoError(t, err)
+
+	module, metricSets, err := NewModule(config, r)
+	require.NoError(t, err)
+	require.Len(t, metricSets, 2)
+
+	cache := ModuleCacheOf(module)
+	require.NotNil(t, cache)
+	for _, ms := range metricSets {
+		assert.Same(t, cache, ModuleCacheOf(ms.Module()))
+	}
+}
+
 func TestNewModulesCallModuleFactory(t *testing.T) {
 	logp.TestingSetup()
 
@@ -426,6 +666,20 @@ func TestProcessorsForMetricSet_ProcessorsRead(t *testing.T) {
 	require.Len(t, procs.List, 1)
 }
 
+func TestLightMetricSet_Dataset(t *testing.T) {
+	r := NewRegister()
+	r.MustAddMetricSet("foo", "bar", newMetricSetWithOption)
+	r.SetSecondarySource(NewLightModulesSource("testdata/lightmodules"))
+
+	registration, err := r.metricSetRegistration("unpack", "withprocessors")
+	require.NoError(t, err)
+	assert.Equal(t, "unpack.custom", registration.Namespace)
+
+	registration, err = r.metricSetRegistration("service", "metricset")
+	require.NoError(t, err)
+	assert.Empty(t, registration.Namespace)
+}
+
 func TestProcessorsForMetricSet_ListModules(t *testing.T) {
 	source := NewLightModulesSource("testdata/lightmodules")
 	modules, err := source.Modules()
@@ -23,10 +23,19 @@ package mb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gorhill/cronexpr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/elastic/beats/v7/libbeat/common/match"
+	"github.com/elastic/beats/v7/libbeat/processors"
 	"github.com/elastic/beats/v7/metricbeat/helper/dialer"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -76,6 +85,8 @@ type BaseModule struct {
 	name      string
 	config    ModuleConfig
 	rawConfig *conf.C
+	cache     *ModuleCache
+	recorder  *settingsRecorder // Records the unpacked settings, only set by UnusedSettings.
 }
 
 func (m *BaseModule) String() string {
@@ -90,11 +101,17 @@ func (m *BaseModule) Name() string { return m.name }
 // Config returns the ModuleConfig used to create the Module.
 func (m *BaseModule) Config() ModuleConfig { return m.config }
 
+// Cache returns the cache shared by the MetricSets of the Module.
+func (m *BaseModule) Cache() *ModuleCache { return m.cache }
+
 // UnpackConfig unpacks the raw module config to the given object.
 func (m *BaseModule) UnpackConfig(to interface{}) error {
+	m.recorder.record(to)
 	return m.rawConfig.Unpack(to)
 }
 
+func (m *BaseModule) settingsRecorder() *settingsRecorder { return m.recorder }
+
 // WithConfig re-configures the module with the given raw configuration and returns a
 // copy of the module.
 // Intended to be called from module factories. Note that if metricsets are specified
@@ -120,6 +137,8 @@ func (m *BaseModule) WithConfig(config conf.C) (*BaseModule, error) {
 	newBM := &BaseModule{
 		name:      m.name,
 		rawConfig: &config,
+		cache:     m.cache,
+		recorder:  m.recorder,
 	}
 
 	if err := config.Unpack(&newBM.config); err != nil {
@@ -152,6 +171,38 @@ type Closer interface {
 	Close() error
 }
 
+// Pauser is an optional interface that push MetricSets can implement to be
+// notified when their module is paused and resumed. While paused, they should
+// stop reporting events without releasing their resources.
+type Pauser interface {
+	Pause()
+	Resume()
+}
+
+// Lifecycle is an optional interface that a MetricSet can implement to be
+// notified when the framework starts and stops running it. It can be used to
+// establish long-lived connections or subscriptions before the first fetch,
+// and to flush any state after the last one.
+type Lifecycle interface {
+	// OnStart is called before the MetricSet is fetched or run for the first
+	// time. The context is cancelled when the MetricSet is stopped. If an
+	// error is returned it is reported, but the MetricSet keeps running.
+	OnStart(ctx context.Context) error
+
+	// OnStop is called after the last fetch or run of the MetricSet has
+	// finished, before Close is called.
+	OnStop() error
+}
+
+// Validator is an optional interface that a MetricSet can implement to
+// validate its configuration when it is created. The errors returned by
+// Validate are reported in a MetricSetConfigError. Errors of the go-ucfg
+// package, such as the ones returned by unpacking the configuration, include
+// the path of the invalid setting.
+type Validator interface {
+	Validate() error
+}
+
 // Reporter is used by a MetricSet to report events, errors, or errors with
 // metadata. The methods return false if and only if publishing failed because
 // the MetricSet is being closed.
@@ -190,7 +241,7 @@ type PushReporter interface {
 // subscription and it should block until the MetricSet is ready to stop or
 // the PushReporter's done channel is closed.
 //
-// Deprecated: Use PushMetricSetV2.
+// Deprecated: Use PushMetricSetV3.
 type PushMetricSet interface {
 	MetricSet
 	Run(r PushReporter)
@@ -213,6 +264,9 @@ type PushReporterV2 interface {
 
 	// Done returns a channel that's closed when work done on behalf of this
 	// reporter should be canceled.
+	//
+	// Deprecated: Implement PushMetricSetV3 and use the context passed to Run,
+	// or use ReporterContext to obtain a context from the reporter.
 	Done() <-chan struct{}
 }
 
@@ -237,10 +291,32 @@ type ReportingMetricSetV2WithContext interface {
 	Fetch(ctx context.Context, r ReporterV2) error
 }
 
+// ReportingMetricSetV2Batch is a MetricSet that returns all the events of a
+// fetch at once, instead of reporting them one by one. Fetch is called
+// periodically to collect events. The returned events are published even if
+// an error is returned too.
+type ReportingMetricSetV2Batch interface {
+	MetricSet
+	Fetch(ctx context.Context) ([]Event, error)
+}
+
+// ReportingMetricSetV2Stream is a MetricSet that emits the events of a fetch
+// as they are collected, so they don't need to be kept in memory. Fetch is
+// called periodically to collect events. emit returns false when no more
+// events can be published, in which case Fetch should return immediately. The
+// returned error is reported as the outcome of the fetch, as in
+// ReportingMetricSetV2Error.
+type ReportingMetricSetV2Stream interface {
+	MetricSet
+	Fetch(ctx context.Context, emit func(Event) bool) error
+}
+
 // PushMetricSetV2 is a MetricSet that pushes events (rather than pulling them
 // periodically via a Fetch callback). Run is invoked to start the event
 // subscription and it should block until the MetricSet is ready to stop or
 // the PushReporterV2's done channel is closed.
+//
+// Deprecated: Use PushMetricSetV3, or PushMetricSetV2WithContext.
 type PushMetricSetV2 interface {
 	MetricSet
 	Run(r PushReporterV2)
@@ -255,6 +331,17 @@ type PushMetricSetV2WithContext interface {
 	Run(ctx context.Context, r ReporterV2)
 }
 
+// PushMetricSetV3 is a MetricSet that pushes events (rather than pulling them
+// periodically via a Fetch callback). Run is invoked to start the event
+// subscription and it should block until the MetricSet is ready to stop or
+// the context is closed. If Run returns an error, or panics, before the
+// context is closed, the error is reported and Run is invoked again after a
+// backoff delay.
+type PushMetricSetV3 interface {
+	MetricSet
+	Run(ctx context.Context, r ReporterV2) error
+}
+
 // HostData contains values parsed from the 'host' configuration. Other
 // configuration data like protocols, usernames, and passwords may also be
 // used to construct this HostData data. HostData also contains information when combined scheme are
@@ -270,12 +357,24 @@ type HostData struct {
 	Host     string // The host and possibly port.
 	User     string // Username
 	Password string // Password
+
+	// Fallbacks are endpoints equivalent to this one, that can be used when
+	// it fails. See Failover.
+	Fallbacks []HostData
 }
 
 func (h HostData) String() string {
 	return fmt.Sprintf(`{SanitizedURI:"%v", Host:"%v"}`, h.SanitizedURI, h.Host)
 }
 
+// Endpoints returns the endpoint of the HostData, without its fallbacks,
+// followed by its fallbacks.
+func (h HostData) Endpoints() []HostData {
+	primary := h
+	primary.Fallbacks = nil
+	return append([]HostData{primary}, h.Fallbacks...)
+}
+
 func (h HostData) GoString() string { return h.String() }
 
 // BaseMetricSet implements the MetricSet interface.
@@ -284,14 +383,15 @@ func (h HostData) GoString() string { return h.String() }
 // MetricSet interface requirements, leaving only the Fetch() method to be
 // implemented to have a complete MetricSet implementation.
 type BaseMetricSet struct {
-	id           string
-	name         string
-	module       Module
-	host         string
-	hostData     HostData
-	registration MetricSetRegistration
-	metrics      *monitoring.Registry
-	logger       *logp.Logger
+	id             string
+	name           string
+	module         Module
+	host           string
+	configuredHost string
+	hostData       HostData
+	registration   MetricSetRegistration
+	metrics        *monitoring.Registry
+	logger         *logp.Logger
 }
 
 func (b *BaseMetricSet) String() string {
@@ -343,6 +443,12 @@ func (b *BaseMetricSet) Host() string {
 	return b.host
 }
 
+// ConfiguredHost returns the host as it is in the module configuration, before
+// being parsed. It can contain credentials and fallback URLs.
+func (b *BaseMetricSet) ConfiguredHost() string {
+	return b.configuredHost
+}
+
 // HostData returns the parsed host data.
 func (b *BaseMetricSet) HostData() HostData {
 	return b.hostData
@@ -363,7 +469,7 @@ func (b *BaseMetricSet) Registration() MetricSetRegistration {
 // the raw namespace to the event.
 type ModuleConfig struct {
 	ID          string        `config:"id"` // Optional ID (not guaranteed to be unique).
-	Hosts       []string      `config:"hosts"`
+	Hosts       []string      `config:"hosts" doc:"Hosts to fetch the metrics from."`
 	Period      time.Duration `config:"period"     validate:"positive"`
 	Timeout     time.Duration `config:"timeout"    validate:"positive"`
 	Module      string        `config:"module"     validate:"required"`
@@ -372,6 +478,78 @@ type ModuleConfig struct {
 	Raw         bool          `config:"raw"`
 	Query       QueryParams   `config:"query"`
 	ServiceName string        `config:"service.name"`
+	Jitter      Jitter        `config:"jitter"`
+	AlignPeriod bool          `config:"align_period"`
+	WarmupFetch bool          `config:"warmup_fetch"`
+	Overlap     OverlapPolicy `config:"overlap_policy"`
+
+	// FetchTimeout cancels the context of the fetches of context-aware
+	// MetricSets that take longer, if set. Unlike Timeout, it doesn't default
+	// to the period, fetches are not cancelled unless it is configured.
+	FetchTimeout time.Duration `config:"fetch_timeout" validate:"min=0"`
+
+	// HostsParallelism is the maximum number of hosts fetched concurrently by
+	// MetricSets implementing HostFetcher.
+	HostsParallelism int `config:"hosts_parallelism" validate:"min=0"`
+
+	// CircuitBreaker suspends the fetches of MetricSets that fail repeatedly.
+	CircuitBreaker CircuitBreakerConfig `config:"circuit_breaker"`
+
+	// Retry configures retries of failed fetches.
+	Retry RetryConfig `config:"retry"`
+
+	// MaxConsecutiveFailures is the number of consecutive failed fetches
+	// after which a MetricSet is stopped for good. Zero disables it.
+	MaxConsecutiveFailures int `config:"max_consecutive_failures" validate:"min=0"`
+
+	// SampleRate publishes the events of only one of every SampleRate
+	// successful fetches. Errors are always published. Zero or one publishes
+	// all the events.
+	SampleRate int `config:"sample_rate" validate:"min=0"`
+
+	// SlowFetchThreshold is the duration after which fetches are reported as
+	// slow. Zero disables it.
+	SlowFetchThreshold time.Duration `config:"slow_fetch_threshold" validate:"min=0"`
+
+	// Logging overrides the logging settings of the beat for the module.
+	Logging LoggingConfig `config:"logging"`
+
+	// Health configures the state of the MetricSets reported by the health
+	// API.
+	Health HealthConfig `config:"health"`
+
+	// Watchdog detects fetches that don't return.
+	Watchdog WatchdogConfig `config:"watchdog"`
+
+	// LifecycleEvents enables the publication of events with the
+	// metricbeat.state dataset when the MetricSets start, stop or change
+	// their health state.
+	LifecycleEvents bool `config:"lifecycle_events"`
+
+	// RestartBackoff configures the delay before restarting the
+	// PushMetricSetV3 whose Run fails.
+	RestartBackoff BackoffConfig `config:"restart_backoff"`
+
+	// FetchOnStart controls if periodic MetricSets fetch as soon as they are
+	// started, or wait for the first period. It defaults to true when unset.
+	FetchOnStart *bool `config:"fetch_on_start"`
+
+	// HostParser is the name of the registered HostParser used to parse the
+	// hosts, instead of the one of each MetricSet.
+	HostParser string `config:"host_parser"`
+
+	// SkipUnsupported skips the MetricSets that require capabilities that the
+	// environment doesn't have, instead of failing to create the module.
+	SkipUnsupported bool `config:"skip_unsupported"`
+
+	// TimestampNanos adds the timestamp of the events with nanosecond
+	// precision in the metricset.timestamp field, for MetricSets sampled more
+	// than once per millisecond or whose events must be ordered precisely.
+	TimestampNanos bool `config:"timestamp_nanos"`
+
+	// MetricSetOverrides contains settings overridden for individual
+	// MetricSets, keyed by MetricSet name.
+	MetricSetOverrides map[string]MetricSetOverride `config:"metricset_overrides"`
 }
 
 func (c ModuleConfig) String() string {
@@ -383,6 +561,107 @@ func (c ModuleConfig) String() string {
 
 func (c ModuleConfig) GoString() string { return c.String() }
 
+// MetricSetPeriod returns the period used to fetch the given MetricSet. It is
+// the MetricSet's overridden period if one is configured, otherwise the module
+// period.
+func (c ModuleConfig) MetricSetPeriod(name string) time.Duration {
+	for msName, o := range c.MetricSetOverrides {
+		if strings.EqualFold(msName, name) && o.Period > 0 {
+			return o.Period
+		}
+	}
+	return c.Period
+}
+
+// FetchesOnStart returns true if periodic MetricSets must fetch as soon as they
+// are started.
+func (c ModuleConfig) FetchesOnStart() bool {
+	return c.FetchOnStart == nil || *c.FetchOnStart
+}
+
+// MetricSetSchedule returns the cron schedule used to fetch the given
+// MetricSet, or nil if the MetricSet is fetched periodically.
+func (c ModuleConfig) MetricSetSchedule(name string) *Schedule {
+	for msName, o := range c.MetricSetOverrides {
+		if strings.EqualFold(msName, name) && o.Schedule != nil {
+			return o.Schedule
+		}
+	}
+	return nil
+}
+
+// MetricSetRetry returns the retry configuration of the given MetricSet. It is
+// the MetricSet's overridden configuration if one is configured, otherwise the
+// module configuration.
+func (c ModuleConfig) MetricSetRetry(name string) RetryConfig {
+	for msName, o := range c.MetricSetOverrides {
+		if strings.EqualFold(msName, name) && o.Retry != nil {
+			return *o.Retry
+		}
+	}
+	return c.Retry
+}
+
+// MetricSetMaxStartDelay returns the overridden upper bound of the random
+// startup delay of the given MetricSet, and whether it is overridden.
+func (c ModuleConfig) MetricSetMaxStartDelay(name string) (time.Duration, bool) {
+	for msName, o := range c.MetricSetOverrides {
+		if strings.EqualFold(msName, name) && o.MaxStartDelay != nil {
+			return *o.MaxStartDelay, true
+		}
+	}
+	return 0, false
+}
+
+// MetricSetProcessors returns the configuration of the processors applied only
+// to the events of the given MetricSet, before they are published.
+func (c ModuleConfig) MetricSetProcessors(name string) processors.PluginConfig {
+	for msName, o := range c.MetricSetOverrides {
+		if strings.EqualFold(msName, name) && len(o.Processors) > 0 {
+			return o.Processors
+		}
+	}
+	return nil
+}
+
+// MetricSetHostParser returns the name of the registered HostParser used to
+// parse the hosts of the given MetricSet, or an empty string if the MetricSet
+// uses its own HostParser.
+func (c ModuleConfig) MetricSetHostParser(name string) string {
+	for msName, o := range c.MetricSetOverrides {
+		if strings.EqualFold(msName, name) && o.HostParser != "" {
+			return o.HostParser
+		}
+	}
+	return c.HostParser
+}
+
+// MetricSetTimestampNanos returns whether the events of the given MetricSet
+// include their timestamp with nanosecond precision.
+func (c ModuleConfig) MetricSetTimestampNanos(name string) bool {
+	for msName, o := range c.MetricSetOverrides {
+		if strings.EqualFold(msName, name) && o.TimestampNanos != nil {
+			return *o.TimestampNanos
+		}
+	}
+	return c.TimestampNanos
+}
+
+// MetricSetOverride contains the module settings that can be overridden for a
+// single MetricSet.
+type MetricSetOverride struct {
+	Period         time.Duration  `config:"period"          validate:"positive"`
+	Schedule       *Schedule      `config:"schedule"`
+	Retry          *RetryConfig   `config:"retry"`
+	MaxStartDelay  *time.Duration `config:"max_start_delay" validate:"min=0"`
+	HostParser     string         `config:"host_parser"`
+	TimestampNanos *bool          `config:"timestamp_nanos"`
+
+	// Processors are applied to the events of the MetricSet, before the
+	// processors of the module.
+	Processors processors.PluginConfig `config:"processors"`
+}
+
 // QueryParams is a convenient map[string]interface{} wrapper to implement the String interface which returns the
 // values in common query params format (key=value&key2=value2) which is the way that the url package expects this
 // params (without the initial '?')
@@ -411,6 +690,247 @@ func (q QueryParams) String() (s string) {
 	return u.Encode()
 }
 
+// Jitter is the maximum random delay added to each periodic fetch. It is
+// configured either as a duration (e.g. "5s") or as a percentage of the period
+// (e.g. "10%").
+type Jitter string
+
+// Unpack sets and validates the jitter from its configured value.
+func (j *Jitter) Unpack(s string) error {
+	if err := Jitter(s).Validate(); err != nil {
+		return err
+	}
+	*j = Jitter(s)
+	return nil
+}
+
+// Validate validates that the jitter is a valid duration or percentage.
+func (j Jitter) Validate() error {
+	_, err := j.Max(time.Second)
+	return err
+}
+
+// Max returns the maximum delay for the given period.
+func (j Jitter) Max(period time.Duration) (time.Duration, error) {
+	v := strings.TrimSpace(string(j))
+	if v == "" {
+		return 0, nil
+	}
+
+	if pct, found := strings.CutSuffix(v, "%"); found {
+		f, err := strconv.ParseFloat(strings.TrimSpace(pct), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid jitter percentage '%s': %w", j, err)
+		}
+		if f < 0 || f > 100 {
+			return 0, fmt.Errorf("jitter percentage '%s' must be between 0%% and 100%%", j)
+		}
+		return time.Duration(float64(period) * f / 100), nil
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid jitter '%s': %w", j, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("jitter '%s' cannot be negative", j)
+	}
+	return d, nil
+}
+
+// OverlapPolicy defines what is done with the periodic fetches that are due
+// while the previous fetch of the same MetricSet is still running.
+type OverlapPolicy string
+
+const (
+	// OverlapQueue runs a single due fetch as soon as the previous one
+	// finishes. Other due fetches are skipped. This is the default policy.
+	OverlapQueue OverlapPolicy = "queue"
+
+	// OverlapSkip skips all the fetches that were due while the previous one
+	// was running.
+	OverlapSkip OverlapPolicy = "skip"
+
+	// OverlapCancelPrevious cancels the running fetch when the next one is due.
+	// Only MetricSets that receive a context can be cancelled.
+	OverlapCancelPrevious OverlapPolicy = "cancel_previous"
+)
+
+// Unpack sets and validates the overlap policy from its configured value.
+func (p *OverlapPolicy) Unpack(s string) error {
+	if err := OverlapPolicy(s).Validate(); err != nil {
+		return err
+	}
+	*p = OverlapPolicy(s)
+	return nil
+}
+
+// Validate validates that the overlap policy is a known policy.
+func (p OverlapPolicy) Validate() error {
+	switch p {
+	case "", OverlapQueue, OverlapSkip, OverlapCancelPrevious:
+		return nil
+	default:
+		return fmt.Errorf("invalid overlap policy '%s', expected one of '%s', '%s' or '%s'",
+			p, OverlapQueue, OverlapSkip, OverlapCancelPrevious)
+	}
+}
+
+// CircuitBreakerConfig configures the circuit breaker of periodic MetricSets.
+// After Failures consecutive failed fetches the circuit is opened and no
+// fetches are done for the Cooldown duration. Then a single probe fetch is
+// done, if it succeeds the circuit is closed again, if it fails it stays open
+// for another Cooldown. The circuit breaker is disabled when Failures is 0.
+type CircuitBreakerConfig struct {
+	Failures int           `config:"failures" validate:"min=0"`
+	Cooldown time.Duration `config:"cooldown" validate:"min=0"`
+}
+
+// Validate validates that a cool-down is set when the circuit breaker is
+// enabled.
+func (c CircuitBreakerConfig) Validate() error {
+	if c.Failures > 0 && c.Cooldown <= 0 {
+		return errors.New("circuit_breaker.cooldown must be set when circuit_breaker.failures is set")
+	}
+	return nil
+}
+
+// RetryConfig configures retries of failed fetches. A fetch is attempted up to
+// Attempts times, waiting Delay between attempts, until it doesn't report
+// errors. The events and errors reported by failed attempts are discarded,
+// except for the last one. If Errors is not empty, a fetch is only retried if
+// all its errors match any of these patterns.
+type RetryConfig struct {
+	Attempts int             `config:"attempts" validate:"min=0"`
+	Delay    time.Duration   `config:"delay"    validate:"min=0"`
+	Errors   []match.Matcher `config:"errors"`
+}
+
+// Retryable returns true if a fetch that failed with the given error can be
+// retried. Errors of type Error are only retried if they are Retryable.
+func (c RetryConfig) Retryable(err error) bool {
+	var mbErr *Error
+	if errors.As(err, &mbErr) && !mbErr.Retryable {
+		return false
+	}
+	if len(c.Errors) == 0 {
+		return true
+	}
+	for _, m := range c.Errors {
+		if m.MatchString(err.Error()) {
+			return true
+		}
+	}
+	return false
+}
+
+// Schedule is a cron expression used to fetch a MetricSet at specific times
+// instead of at a fixed period.
+type Schedule struct {
+	expr *cronexpr.Expression
+	raw  string
+}
+
+// ParseSchedule parses a cron expression.
+func ParseSchedule(in string) (*Schedule, error) {
+	expr, err := cronexpr.Parse(in)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule '%s': %w", in, err)
+	}
+	return &Schedule{expr: expr, raw: in}, nil
+}
+
+// Unpack parses the schedule from its configuration string.
+func (s *Schedule) Unpack(str string) error {
+	tmp, err := ParseSchedule(str)
+	if err != nil {
+		return err
+	}
+	*s = *tmp
+	return nil
+}
+
+// Next returns the next time matching the schedule after t. It returns the
+// zero time if the schedule doesn't match any future time.
+func (s *Schedule) Next(t time.Time) time.Time {
+	return s.expr.Next(t)
+}
+
+// String returns the cron expression of the schedule.
+func (s *Schedule) String() string {
+	return s.raw
+}
+
+// HealthConfig configures when the state of a MetricSet changes. A running
+// MetricSet is degraded after FailureThreshold consecutive failed fetches, and
+// a degraded MetricSet is running again after SuccessThreshold consecutive
+// successful ones. Zero or one change the state after every fetch.
+type HealthConfig struct {
+	FailureThreshold int `config:"failure_threshold" validate:"min=0"`
+	SuccessThreshold int `config:"success_threshold" validate:"min=0"`
+}
+
+// WatchdogConfig configures the detection of stuck fetches. A fetch is stuck
+// when it didn't return after Periods periods. Stuck fetches are logged with
+// the stacks of the goroutines of the MetricSet, and the MetricSet is marked
+// as failed. If Restart is true, the MetricSet is also replaced by a new
+// instance. The watchdog is disabled when Periods is 0.
+type WatchdogConfig struct {
+	Periods int  `config:"periods" validate:"min=0"`
+	Restart bool `config:"restart"`
+}
+
+// BackoffConfig configures an exponential backoff. The delay starts at Init
+// and doubles after each attempt up to Max. Zero values use the defaults of
+// the user of the configuration.
+type BackoffConfig struct {
+	Init time.Duration `config:"init" validate:"min=0"`
+	Max  time.Duration `config:"max"  validate:"min=0"`
+}
+
+// LoggingConfig contains the logging settings of a module.
+type LoggingConfig struct {
+	// Level is the minimum level of the logs of the module, instead of the
+	// level of the beat. Debug logs are then written regardless of the debug
+	// selectors.
+	Level *logp.Level `config:"level"`
+}
+
+// Logger returns the given logger with the settings applied, or the same
+// logger if there are no settings.
+func (c LoggingConfig) Logger(logger *logp.Logger) *logp.Logger {
+	if c.Level == nil {
+		return logger
+	}
+	level := c.Level.ZapLevel()
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return levelCore{Core: core, level: level}
+	}))
+}
+
+// levelCore is a zapcore.Core that writes the entries enabled by its own
+// level to the core it wraps, even if the level of the wrapped core doesn't
+// enable them.
+type levelCore struct {
+	zapcore.Core
+	level zapcore.Level
+}
+
+func (c levelCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level)
+}
+
+func (c levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return levelCore{Core: c.Core.With(fields), level: c.level}
+}
+
+func (c levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
 // defaultModuleConfig contains the default values for ModuleConfig instances.
 var defaultModuleConfig = ModuleConfig{
 	Enabled: true,
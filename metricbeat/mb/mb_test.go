@@ -20,10 +20,12 @@
 package mb
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/joeshaw/multierror"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -99,6 +101,216 @@ func TestModuleConfig(t *testing.T) {
 			},
 			err: "negative value accessing 'period'",
 		},
+		{
+			name: "metricset period override",
+			in: map[string]interface{}{
+				"module":     "example",
+				"metricsets": []string{"test"},
+				"metricset_overrides": map[string]interface{}{
+					"test": map[string]interface{}{"period": "5m"},
+				},
+			},
+			out: ModuleConfig{
+				Module:     "example",
+				MetricSets: []string{"test"},
+				Enabled:    true,
+				Period:     time.Second * 10,
+				MetricSetOverrides: map[string]MetricSetOverride{
+					"test": {Period: 5 * time.Minute},
+				},
+			},
+		},
+		{
+			name: "negative metricset period override",
+			in: map[string]interface{}{
+				"module":     "example",
+				"metricsets": []string{"test"},
+				"metricset_overrides": map[string]interface{}{
+					"test": map[string]interface{}{"period": -1},
+				},
+			},
+			err: "negative value accessing 'metricset_overrides.test.period'",
+		},
+		{
+			name: "jitter as percentage of period",
+			in: map[string]interface{}{
+				"module":     "example",
+				"metricsets": []string{"test"},
+				"jitter":     "10%",
+			},
+			out: ModuleConfig{
+				Module:     "example",
+				MetricSets: []string{"test"},
+				Enabled:    true,
+				Period:     time.Second * 10,
+				Jitter:     "10%",
+			},
+		},
+		{
+			name: "jitter as duration",
+			in: map[string]interface{}{
+				"module":     "example",
+				"metricsets": []string{"test"},
+				"jitter":     "2s",
+			},
+			out: ModuleConfig{
+				Module:     "example",
+				MetricSets: []string{"test"},
+				Enabled:    true,
+				Period:     time.Second * 10,
+				Jitter:     "2s",
+			},
+		},
+		{
+			name: "invalid jitter percentage",
+			in: map[string]interface{}{
+				"module":     "example",
+				"metricsets": []string{"test"},
+				"jitter":     "150%",
+			},
+			err: "must be between 0% and 100%",
+		},
+		{
+			name: "negative metricset max_start_delay",
+			in: map[string]interface{}{
+				"module":     "example",
+				"metricsets": []string{"test"},
+				"metricset_overrides": map[string]interface{}{
+					"test": map[string]interface{}{"max_start_delay": "-1s"},
+				},
+			},
+			err: "accessing 'metricset_overrides.test.max_start_delay'",
+		},
+		{
+			name: "overlap policy",
+			in: map[string]interface{}{
+				"module":         "example",
+				"metricsets":     []string{"test"},
+				"overlap_policy": "skip",
+			},
+			out: ModuleConfig{
+				Module:     "example",
+				MetricSets: []string{"test"},
+				Enabled:    true,
+				Period:     time.Second * 10,
+				Overlap:    OverlapSkip,
+			},
+		},
+		{
+			name: "invalid overlap policy",
+			in: map[string]interface{}{
+				"module":         "example",
+				"metricsets":     []string{"test"},
+				"overlap_policy": "wait",
+			},
+			err: "invalid overlap policy 'wait'",
+		},
+		{
+			name: "circuit breaker",
+			in: map[string]interface{}{
+				"module":     "example",
+				"metricsets": []string{"test"},
+				"circuit_breaker": map[string]interface{}{
+					"failures": 5,
+					"cooldown": "10m",
+				},
+			},
+			out: ModuleConfig{
+				Module:     "example",
+				MetricSets: []string{"test"},
+				Enabled:    true,
+				Period:     time.Second * 10,
+				CircuitBreaker: CircuitBreakerConfig{
+					Failures: 5,
+					Cooldown: 10 * time.Minute,
+				},
+			},
+		},
+		{
+			name: "circuit breaker without cooldown",
+			in: map[string]interface{}{
+				"module":     "example",
+				"metricsets": []string{"test"},
+				"circuit_breaker": map[string]interface{}{
+					"failures": 5,
+				},
+			},
+			err: "circuit_breaker.cooldown must be set",
+		},
+		{
+			name: "negative max consecutive failures",
+			in: map[string]interface{}{
+				"module":                   "example",
+				"metricsets":               []string{"test"},
+				"max_consecutive_failures": -1,
+			},
+			err: "accessing 'max_consecutive_failures'",
+		},
+		{
+			name: "negative sample rate",
+			in: map[string]interface{}{
+				"module":      "example",
+				"metricsets":  []string{"test"},
+				"sample_rate": -1,
+			},
+			err: "accessing 'sample_rate'",
+		},
+		{
+			name: "negative slow fetch threshold",
+			in: map[string]interface{}{
+				"module":               "example",
+				"metricsets":           []string{"test"},
+				"slow_fetch_threshold": "-1s",
+			},
+			err: "accessing 'slow_fetch_threshold'",
+		},
+		{
+			name: "invalid logging level",
+			in: map[string]interface{}{
+				"module":        "example",
+				"metricsets":    []string{"test"},
+				"logging.level": "verbose",
+			},
+			err: "invalid level 'verbose'",
+		},
+		{
+			name: "negative health failure threshold",
+			in: map[string]interface{}{
+				"module":                   "example",
+				"metricsets":               []string{"test"},
+				"health.failure_threshold": -1,
+			},
+			err: "accessing 'health.failure_threshold'",
+		},
+		{
+			name: "negative watchdog periods",
+			in: map[string]interface{}{
+				"module":           "example",
+				"metricsets":       []string{"test"},
+				"watchdog.periods": -1,
+			},
+			err: "accessing 'watchdog.periods'",
+		},
+		{
+			name: "negative restart backoff",
+			in: map[string]interface{}{
+				"module":               "example",
+				"metricsets":           []string{"test"},
+				"restart_backoff.init": "-1s",
+			},
+			err: "accessing 'restart_backoff.init'",
+		},
+		{
+			name: "invalid metricset schedule",
+			in: map[string]interface{}{
+				"module":     "example",
+				"metricsets": []string{"test"},
+				"metricset_overrides": map[string]interface{}{
+					"test": map[string]interface{}{"schedule": "not a cron"},
+				},
+			},
+			err: "invalid schedule 'not a cron'",
+		},
 		{
 			name: "negative timeout",
 			in: map[string]interface{}{
@@ -158,6 +370,7 @@ func TestModuleConfigDefaults(t *testing.T) {
 	assert.Equal(t, true, mc.Enabled)
 	assert.Equal(t, time.Second*10, mc.Period)
 	assert.Equal(t, time.Second*0, mc.Timeout)
+	assert.Equal(t, true, mc.FetchesOnStart())
 	assert.Empty(t, mc.Hosts)
 }
 
@@ -178,6 +391,24 @@ func TestNewModulesDuplicateHosts(t *testing.T) {
 
 // TestNewModulesWithDefaultMetricSet verifies that the default MetricSet is
 // instantiated when no metricsets are specified in the config.
+// TestNewModulesWithMultipleHosts verifies that a single instance is created
+// for MetricSets registered with WithMultipleHosts.
+func TestNewModulesWithMultipleHosts(t *testing.T) {
+	r := newTestRegistry(t, WithMultipleHosts())
+
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{metricSetName},
+		"hosts":      []string{"a", "b", "c"},
+	})
+
+	_, metricSets, err := NewModule(c, r)
+	require.NoError(t, err)
+	if assert.Len(t, metricSets, 1) {
+		assert.Empty(t, metricSets[0].Host())
+	}
+}
+
 func TestNewModulesWithDefaultMetricSet(t *testing.T) {
 	r := newTestRegistry(t, DefaultMetricSet())
 
@@ -238,6 +469,36 @@ func TestNewModulesHostParser(t *testing.T) {
 		assert.Equal(t, host, ms.Host())
 		assert.Equal(t, HostData{URI: uri, Host: host}, ms.HostData())
 	})
+
+	r.MustAddHostParser("named", func(m Module, rawHost string) (HostData, error) {
+		return HostData{URI: rawHost, Host: "named"}, nil
+	})
+
+	t.Run("HostParser from config", func(t *testing.T) {
+		ms := newTestMetricSet(t, r, map[string]interface{}{
+			"module":      moduleName,
+			"metricsets":  []string{name},
+			"hosts":       []string{uri},
+			"host_parser": "named",
+		})
+
+		assert.Equal(t, "named", ms.Host())
+		assert.Equal(t, HostData{URI: uri, Host: "named"}, ms.HostData())
+	})
+
+	t.Run("HostParser from MetricSet overrides", func(t *testing.T) {
+		ms := newTestMetricSet(t, r, map[string]interface{}{
+			"module":     moduleName,
+			"metricsets": []string{metricSetName},
+			"hosts":      []string{uri},
+			"metricset_overrides": map[string]interface{}{
+				metricSetName: map[string]interface{}{"host_parser": "named"},
+			},
+		})
+
+		assert.Equal(t, "named", ms.Host())
+		assert.Equal(t, HostData{URI: uri, Host: "named"}, ms.HostData())
+	})
 }
 
 func TestNewModulesMetricSetTypes(t *testing.T) {
@@ -299,6 +560,124 @@ func TestNewModulesMetricSetTypes(t *testing.T) {
 	})
 }
 
+type testValidatingMetricSet struct {
+	BaseMetricSet
+	config struct {
+		Limit int `config:"limit"`
+	}
+}
+
+func (m *testValidatingMetricSet) Fetch(reporter ReporterV2) {}
+
+func (m *testValidatingMetricSet) Validate() error {
+	if m.config.Limit > 10 {
+		return fmt.Errorf("limit %d is greater than 10", m.config.Limit)
+	}
+	return nil
+}
+
+func TestNewModulesValidator(t *testing.T) {
+	r := newTestRegistry(t)
+	r.MustAddMetricSet(moduleName, "validating", func(base BaseMetricSet) (MetricSet, error) {
+		ms := &testValidatingMetricSet{BaseMetricSet: base}
+		if err := base.Module().UnpackConfig(&ms.config); err != nil {
+			return nil, err
+		}
+		return ms, nil
+	})
+
+	newModule := func(config map[string]interface{}) error {
+		_, _, err := NewModule(newConfig(t, config), r)
+		var errs *multierror.MultiError
+		if errors.As(err, &errs) {
+			require.Len(t, errs.Errors, 1)
+			return errs.Errors[0]
+		}
+		return err
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		err := newModule(map[string]interface{}{
+			"module":     moduleName,
+			"metricsets": []string{"validating", metricSetName},
+			"limit":      5,
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		err := newModule(map[string]interface{}{
+			"module":     moduleName,
+			"metricsets": []string{"validating", metricSetName},
+			"limit":      20,
+		})
+
+		var configErr *MetricSetConfigError
+		require.ErrorAs(t, err, &configErr)
+		assert.Equal(t, moduleName, configErr.Module)
+		assert.Equal(t, "validating", configErr.MetricSet)
+		assert.Empty(t, configErr.Key)
+		assert.ErrorContains(t, err, "invalid configuration of metricset 'mymodule/validating': limit 20 is greater than 10")
+	})
+
+	t.Run("unpack error", func(t *testing.T) {
+		err := newModule(map[string]interface{}{
+			"module":     moduleName,
+			"metricsets": []string{"validating"},
+			"limit":      "many",
+		})
+
+		var configErr *MetricSetConfigError
+		require.ErrorAs(t, err, &configErr)
+		assert.Equal(t, "validating", configErr.MetricSet)
+		assert.Equal(t, "limit", configErr.Key)
+	})
+}
+
+func TestNewModulesCapabilities(t *testing.T) {
+	checks := capabilityChecks
+	t.Cleanup(func() { capabilityChecks = checks })
+	capabilityChecks = map[Capability]func() error{
+		CapabilityRoot:    func() error { return errors.New("running as user 1000 instead of root") },
+		CapabilityNetwork: func() error { return nil },
+	}
+
+	factory := func(base BaseMetricSet) (MetricSet, error) {
+		return &testMetricSet{base}, nil
+	}
+	r := newTestRegistry(t)
+	r.MustAddMetricSet(moduleName, "privileged", factory, WithCapabilities(CapabilityNetwork, CapabilityRoot))
+	r.MustAddMetricSet(moduleName, "networked", factory, WithCapabilities(CapabilityNetwork))
+
+	t.Run("fail", func(t *testing.T) {
+		_, _, err := NewModule(newConfig(t, map[string]interface{}{
+			"module":     moduleName,
+			"metricsets": []string{"privileged", "networked"},
+		}), r)
+		var errs *multierror.MultiError
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs.Errors, 1)
+
+		var unsupportedErr *UnsupportedMetricSetError
+		require.ErrorAs(t, errs.Errors[0], &unsupportedErr)
+		assert.Equal(t, "privileged", unsupportedErr.MetricSet)
+		assert.Equal(t, CapabilityRoot, unsupportedErr.Capability)
+		assert.ErrorContains(t, err, "metricset 'mymodule/privileged' requires the 'root' capability: running as user 1000 instead of root")
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		_, metricSets, err := NewModule(newConfig(t, map[string]interface{}{
+			"module":           moduleName,
+			"metricsets":       []string{"privileged", "networked"},
+			"skip_unsupported": true,
+		}), r)
+		require.NoError(t, err)
+		if assert.Len(t, metricSets, 1) {
+			assert.Equal(t, "networked", metricSets[0].Name())
+		}
+	})
+}
+
 // TestNewBaseModuleFromModuleConfigStruct tests the creation a new BaseModule.
 func TestNewBaseModuleFromModuleConfigStruct(t *testing.T) {
 	moduleConf := DefaultModuleConfig()
@@ -356,6 +735,114 @@ func newConfig(t testing.TB, moduleConfig interface{}) *conf.C {
 	return config
 }
 
+func TestJitterMax(t *testing.T) {
+	period := 10 * time.Second
+	tests := []struct {
+		jitter Jitter
+		max    time.Duration
+		err    string
+	}{
+		{jitter: "", max: 0},
+		{jitter: "3s", max: 3 * time.Second},
+		{jitter: "25%", max: 2500 * time.Millisecond},
+		{jitter: "-1s", err: "cannot be negative"},
+		{jitter: "abc%", err: "invalid jitter percentage"},
+		{jitter: "abc", err: "invalid jitter"},
+	}
+
+	for _, test := range tests {
+		max, err := test.jitter.Max(period)
+		if test.err != "" {
+			assert.ErrorContains(t, err, test.err, "jitter %q", test.jitter)
+			continue
+		}
+		assert.NoError(t, err, "jitter %q", test.jitter)
+		assert.Equal(t, test.max, max, "jitter %q", test.jitter)
+	}
+}
+
+func TestModuleConfigMetricSetSchedule(t *testing.T) {
+	c, err := conf.NewConfigFrom(map[string]interface{}{
+		"module":     "example",
+		"metricsets": []string{"status", "inventory"},
+		"metricset_overrides": map[string]interface{}{
+			"inventory": map[string]interface{}{"schedule": "0 */6 * * *"},
+		},
+	})
+	require.NoError(t, err)
+
+	mc := DefaultModuleConfig()
+	require.NoError(t, c.Unpack(&mc))
+
+	assert.Nil(t, mc.MetricSetSchedule("status"))
+
+	schedule := mc.MetricSetSchedule("inventory")
+	require.NotNil(t, schedule)
+	assert.Equal(t, "0 */6 * * *", schedule.String())
+
+	now := time.Date(2024, 1, 1, 7, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), schedule.Next(now))
+}
+
+func TestModuleConfigMetricSetRetry(t *testing.T) {
+	c, err := conf.NewConfigFrom(map[string]interface{}{
+		"module":     "example",
+		"metricsets": []string{"status", "slowlog"},
+		"retry": map[string]interface{}{
+			"attempts": 2,
+		},
+		"metricset_overrides": map[string]interface{}{
+			"slowlog": map[string]interface{}{
+				"retry": map[string]interface{}{
+					"attempts": 3,
+					"delay":    "1s",
+					"errors":   []string{"connection refused", "timeout"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	mc := DefaultModuleConfig()
+	require.NoError(t, c.Unpack(&mc))
+
+	status := mc.MetricSetRetry("status")
+	assert.Equal(t, 2, status.Attempts)
+	assert.True(t, status.Retryable(errors.New("any error")))
+	assert.True(t, status.Retryable(&Error{Code: "unavailable", Retryable: true}))
+	assert.False(t, status.Retryable(fmt.Errorf("fetch failed: %w", &Error{Code: "invalid_query"})))
+
+	slowlog := mc.MetricSetRetry("slowlog")
+	assert.Equal(t, 3, slowlog.Attempts)
+	assert.Equal(t, time.Second, slowlog.Delay)
+	assert.True(t, slowlog.Retryable(errors.New("dial tcp: connection refused")))
+	assert.False(t, slowlog.Retryable(errors.New("invalid credentials")))
+}
+
+func TestModuleConfigMetricSetTimestampNanos(t *testing.T) {
+	c, err := conf.NewConfigFrom(map[string]interface{}{
+		"module":          "example",
+		"metricsets":      []string{"status", "slowlog", "info"},
+		"timestamp_nanos": true,
+		"metricset_overrides": map[string]interface{}{
+			"slowlog": map[string]interface{}{
+				"timestamp_nanos": false,
+			},
+			"info": map[string]interface{}{
+				"period": "1m",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	mc := DefaultModuleConfig()
+	require.NoError(t, c.Unpack(&mc))
+
+	assert.True(t, mc.MetricSetTimestampNanos("status"))
+	assert.False(t, mc.MetricSetTimestampNanos("slowlog"))
+	assert.True(t, mc.MetricSetTimestampNanos("info"))
+}
+
 func TestModuleConfigQueryParams(t *testing.T) {
 	qp := QueryParams{
 		"stringKey": "value",
@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Migration describes a deprecated setting or a removed MetricSet of a
+// module, and how the configurations that use it are rewritten to the current
+// syntax by the migrate config command.
+type Migration struct {
+	Module string
+	// MetricSet is the removed MetricSet if Setting is empty. Otherwise it is
+	// the MetricSet the deprecated setting applies to, or empty if it applies
+	// to all the MetricSets of the module.
+	MetricSet string
+	// Setting is the dotted path of the deprecated setting, empty if the
+	// MetricSet is removed.
+	Setting string
+	// Replacement is the setting or the MetricSet that replaces the
+	// deprecated one, empty if it is removed without replacement.
+	Replacement string
+	// Convert returns the value of the replacement setting, given the value of
+	// the deprecated setting and the current value of the replacement, nil if
+	// it is not set. The replacement is not changed if it returns false. If
+	// Convert is nil, the value is moved to the replacement.
+	Convert func(value, current interface{}) (interface{}, bool)
+	// Since is the version the setting or the MetricSet was deprecated in.
+	Since string
+}
+
+// String returns the description of the migration.
+func (m Migration) String() string {
+	var s string
+	if m.Setting == "" {
+		s = fmt.Sprintf("metricset '%s/%s' was removed in %s", m.Module, m.MetricSet, m.Since)
+	} else {
+		s = fmt.Sprintf("setting '%s' of module '%s' was deprecated in %s", m.Setting, m.Module, m.Since)
+	}
+	if m.Replacement != "" {
+		s += fmt.Sprintf(", use '%s' instead", m.Replacement)
+	}
+	return s
+}
+
+// AddMigration registers the migration of a deprecated setting or a removed
+// MetricSet. An error is returned if the module or the deprecated setting or
+// MetricSet are empty, or if a migration has already been registered for
+// them.
+func (r *Register) AddMigration(m Migration) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if m.Module == "" {
+		return fmt.Errorf("module name is required")
+	}
+	if m.Setting == "" && m.MetricSet == "" {
+		return fmt.Errorf("migration of module '%s' requires a setting or a metricset", m.Module)
+	}
+
+	m.Module = strings.ToLower(m.Module)
+	m.MetricSet = strings.ToLower(m.MetricSet)
+	for _, existing := range r.migrations[m.Module] {
+		if existing.MetricSet == m.MetricSet && existing.Setting == m.Setting {
+			return fmt.Errorf("migration of '%s/%s' setting '%s' is already registered", m.Module, m.MetricSet, m.Setting)
+		}
+	}
+
+	r.migrations[m.Module] = append(r.migrations[m.Module], m)
+	r.log.Debugf("Migration registered: %s", m)
+	return nil
+}
+
+// MustAddMigration registers the migration of a deprecated setting or a
+// removed MetricSet. It panics if AddMigration fails.
+func (r *Register) MustAddMigration(m Migration) {
+	if err := r.AddMigration(m); err != nil {
+		panic(err)
+	}
+}
+
+// Migrations returns the migrations registered for a module, in the order they
+// were registered.
+func (r *Register) Migrations(module string) []Migration {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	migrations := r.migrations[strings.ToLower(module)]
+	return append([]Migration(nil), migrations...)
+}
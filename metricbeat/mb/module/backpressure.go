@@ -0,0 +1,76 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"time"
+
+	"github.com/rcrowley/go-metrics"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+	"github.com/elastic/elastic-agent-libs/monitoring/adapter"
+)
+
+// backpressure keeps track of the time a MetricSet is blocked writing events
+// to the output channel of its module, which happens when the publishing
+// pipeline doesn't keep up.
+type backpressure struct {
+	blocked     *monitoring.Uint // Total events whose write to the output blocked.
+	blockedTime *monitoring.Uint // Total time blocked writing to the output, in nanoseconds.
+	sample      metrics.Sample   // Time blocked by each blocked write, in nanoseconds.
+}
+
+// newBackpressure registers the backpressure metrics in the given registry.
+// It returns nil if the registry is nil, which disables the metrics.
+func newBackpressure(reg *monitoring.Registry) *backpressure {
+	if reg == nil {
+		return nil
+	}
+	b := &backpressure{
+		blocked:     monitoring.NewUint(reg, "output_blocked_total"),
+		blockedTime: monitoring.NewUint(reg, "output_blocked_time_ns_total"),
+		sample:      metrics.NewUniformSample(1024),
+	}
+	_ = adapter.NewGoMetrics(reg, "output_blocked_time", adapter.Accept).
+		Register("histogram", metrics.NewHistogram(b.sample))
+	return b
+}
+
+// write writes the event to out like writeEvent, recording the time spent
+// waiting if out is full.
+func (b *backpressure) write(done <-chan struct{}, out chan<- beat.Event, event beat.Event) bool {
+	if b == nil {
+		return writeEvent(done, out, event)
+	}
+
+	select {
+	case out <- event:
+		return true
+	default:
+	}
+
+	start := time.Now()
+	ok := writeEvent(done, out, event)
+	elapsed := time.Since(start)
+
+	b.blocked.Inc()
+	b.blockedTime.Add(uint64(elapsed))
+	b.sample.Update(int64(elapsed))
+	return ok
+}
@@ -0,0 +1,62 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package module
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestBackpressure(t *testing.T) {
+	b := newBackpressure(monitoring.NewRegistry())
+	done := make(chan struct{})
+	out := make(chan beat.Event, 1)
+
+	// There is room in the channel, so the write doesn't block.
+	assert.True(t, b.write(done, out, beat.Event{}))
+	assert.Equal(t, uint64(0), b.blocked.Get())
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		<-out
+	}()
+	assert.True(t, b.write(done, out, beat.Event{}))
+	assert.Equal(t, uint64(1), b.blocked.Get())
+	assert.Greater(t, b.blockedTime.Get(), uint64(0))
+	assert.Equal(t, int64(1), b.sample.Count())
+
+	// The channel is full, so the write blocks until done is closed.
+	close(done)
+	assert.False(t, b.write(done, out, beat.Event{}))
+	assert.Equal(t, uint64(2), b.blocked.Get())
+}
+
+func TestBackpressureDisabled(t *testing.T) {
+	b := newBackpressure(nil)
+	assert.Nil(t, b)
+
+	out := make(chan beat.Event, 1)
+	assert.True(t, b.write(make(chan struct{}), out, beat.Event{}))
+}
@@ -0,0 +1,84 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// circuitBreaker suspends the fetches of a MetricSet after a number of
+// consecutive failures. A nil circuitBreaker never suspends fetches.
+//
+// It is not safe for concurrent use, it is only used by the goroutine fetching
+// the MetricSet.
+type circuitBreaker struct {
+	maxFailures int
+	cooldown    time.Duration
+
+	failures  int       // Number of consecutive failed fetches.
+	open      bool      // Set to true while fetches are suspended.
+	openUntil time.Time // End of the current cool-down, when the circuit is open.
+}
+
+// newCircuitBreaker creates a circuitBreaker from its configuration. It
+// returns nil if the circuit breaker is disabled.
+func newCircuitBreaker(config mb.CircuitBreakerConfig) *circuitBreaker {
+	if config.Failures <= 0 {
+		return nil
+	}
+	return &circuitBreaker{
+		maxFailures: config.Failures,
+		cooldown:    config.Cooldown,
+	}
+}
+
+// allow returns true if a fetch can be done at the given time. Once the
+// cool-down has elapsed, the circuit is half-open and a fetch is allowed to
+// probe if the MetricSet has recovered.
+func (b *circuitBreaker) allow(now time.Time) bool {
+	return b == nil || !b.open || !now.Before(b.openUntil)
+}
+
+// record records the result of a fetch done at the given time. It returns
+// true if the circuit has just been opened, so callers can report it once.
+// Failed probes keep the circuit open for another cool-down without being
+// reported again.
+func (b *circuitBreaker) record(failed bool, now time.Time) bool {
+	if b == nil {
+		return false
+	}
+	if !failed {
+		b.failures = 0
+		b.open = false
+		return false
+	}
+
+	b.failures++
+	if b.open {
+		b.openUntil = now.Add(b.cooldown)
+		return false
+	}
+	if b.failures < b.maxFailures {
+		return false
+	}
+	b.open = true
+	b.openUntil = now.Add(b.cooldown)
+	return true
+}
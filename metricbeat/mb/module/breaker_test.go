@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package module
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := newCircuitBreaker(mb.CircuitBreakerConfig{Failures: 2, Cooldown: time.Minute})
+	now := time.Now()
+
+	assert.True(t, b.allow(now))
+	assert.False(t, b.record(true, now))
+	assert.True(t, b.allow(now))
+
+	// Second consecutive failure opens the circuit.
+	assert.True(t, b.record(true, now))
+	assert.False(t, b.allow(now.Add(30*time.Second)))
+
+	// Half-open after the cool-down, a failed probe doesn't report again.
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow(now))
+	assert.False(t, b.record(true, now))
+	assert.False(t, b.allow(now.Add(30*time.Second)))
+
+	// A successful probe closes the circuit.
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow(now))
+	assert.False(t, b.record(false, now))
+	assert.True(t, b.allow(now))
+	assert.False(t, b.record(true, now))
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	b := newCircuitBreaker(mb.CircuitBreakerConfig{})
+	assert.Nil(t, b)
+
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		assert.False(t, b.record(true, now))
+		assert.True(t, b.allow(now))
+	}
+}
@@ -0,0 +1,207 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/testing"
+	"github.com/elastic/elastic-agent-libs/transport"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+// defaultPorts are the ports of the hosts with these schemes and without port.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// hostTarget is the endpoint of a host whose connectivity is tested.
+type hostTarget struct {
+	scheme  string
+	address string // host:port of TCP hosts.
+	socket  string // Path of the unix socket of the host, if any.
+
+	uri            string // URI of HTTP hosts, with its credentials.
+	user, password string
+}
+
+// newHostTarget returns the endpoint of a host. URIs with schemes are parsed
+// as URLs, others are expected to be host:port addresses.
+func newHostTarget(hostData mb.HostData) (hostTarget, error) {
+	target := hostTarget{user: hostData.User, password: hostData.Password}
+	uri := hostData.URI
+	if uri == "" {
+		uri = hostData.Host
+	}
+
+	if !strings.Contains(uri, "://") {
+		if _, _, err := net.SplitHostPort(hostData.Host); err == nil {
+			target.address = hostData.Host
+			return target, nil
+		}
+		if _, _, err := net.SplitHostPort(uri); err != nil {
+			return target, fmt.Errorf("unknown address of host '%s'", hostData.SanitizedURI)
+		}
+		target.address = uri
+		return target, nil
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return target, err
+	}
+	target.scheme = u.Scheme
+	switch u.Scheme {
+	case "unix", "http+unix":
+		target.socket = u.Path
+		return target, nil
+	case "http", "https":
+		target.uri = uri
+	}
+	target.address = u.Host
+	if u.Port() == "" {
+		port, found := defaultPorts[u.Scheme]
+		if !found {
+			return target, fmt.Errorf("unknown port of host '%s'", hostData.SanitizedURI)
+		}
+		target.address = net.JoinHostPort(u.Hostname(), port)
+	}
+	return target, nil
+}
+
+// testHosts tests the connectivity with the hosts of the MetricSet, layer by
+// layer: the DNS lookup and the connection, the TLS handshake if the host uses
+// TLS, and the credentials of HTTP hosts. Each host is tested separately, so a
+// failed layer doesn't stop the tests of the other hosts or the fetch.
+func (msw *metricSetWrapper) testHosts(d testing.Driver) {
+	var hosts []mb.HostData
+	if msw.Registration().MultipleHosts {
+		for _, host := range msw.module.Config().Hosts {
+			hosts = append(hosts, mb.HostData{URI: host, SanitizedURI: host, Host: host})
+		}
+	} else if hostData := msw.HostData(); hostData.URI != "" || hostData.Host != "" {
+		hosts = append(hosts, hostData)
+	}
+	if len(hosts) == 0 {
+		return
+	}
+
+	var tlsSettings struct {
+		TLS *tlscommon.Config `config:"ssl"`
+	}
+	if err := msw.module.UnpackConfig(&tlsSettings); err != nil {
+		d.Error("ssl", err)
+		return
+	}
+
+	timeout := msw.module.Config().Timeout
+	for _, hostData := range hosts {
+		name := hostData.SanitizedURI
+		if name == "" {
+			name = hostData.Host
+		}
+		// Fatal errors stop the goroutine of the host.
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			d.Run("host "+name, func(d testing.Driver) {
+				testHost(d, hostData, tlsSettings.TLS, timeout)
+			})
+		}()
+		<-done
+	}
+}
+
+func testHost(d testing.Driver, hostData mb.HostData, tlsSettings *tlscommon.Config, timeout time.Duration) {
+	target, err := newHostTarget(hostData)
+	if err != nil {
+		d.Warn("connection", err.Error())
+		return
+	}
+
+	if target.socket != "" {
+		d.Run("connection", func(d testing.Driver) {
+			conn, err := transport.TestUnixDialer(d, timeout, target.socket).Dial("unix", target.socket)
+			d.Fatal("dial up", err)
+			conn.Close()
+		})
+		return
+	}
+
+	d.Run("connection", func(d testing.Driver) {
+		conn, err := transport.TestNetDialer(d, timeout).Dial("tcp", target.address)
+		d.Fatal("dial up", err)
+		conn.Close()
+	})
+
+	useTLS := target.scheme == "https" || tlsSettings.IsEnabled()
+	var tlsConfig *tlscommon.TLSConfig
+	if !useTLS {
+		d.Warn("TLS", "secure connection disabled")
+	} else {
+		d.Run("TLS", func(d testing.Driver) {
+			tlsConfig, err = tlscommon.LoadTLSConfig(tlsSettings)
+			d.Fatal("load tls config", err)
+			tlsDialer := transport.TestTLSDialer(d, transport.NetDialer(timeout), tlsConfig, timeout)
+			conn, err := tlsDialer.Dial("tcp", target.address)
+			d.Fatal("dial up", err)
+			conn.Close()
+		})
+	}
+
+	switch {
+	case target.uri == "":
+		d.Info("auth", "checked by the fetch")
+	case target.user == "" && target.password == "":
+		d.Info("auth", "no credentials")
+	default:
+		d.Fatal("auth", testHTTPAuth(target, tlsConfig, timeout))
+	}
+}
+
+// testHTTPAuth sends a request with the credentials of the target, and fails
+// if the server rejects them.
+func testHTTPAuth(target hostTarget, tlsConfig *tlscommon.TLSConfig, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.uri, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(target.user, target.password)
+
+	host, _, _ := net.SplitHostPort(target.address)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig.BuildModuleClientConfig(host)}}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("credentials rejected by the server: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package module
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	libtesting "github.com/elastic/elastic-agent-libs/testing"
+)
+
+// connectivityDriver records the errors and warnings of a test, with the
+// names of the runs they are reported in.
+type connectivityDriver struct {
+	prefix   string
+	errors   *[]string
+	warnings *[]string
+}
+
+func newConnectivityDriver() *connectivityDriver {
+	return &connectivityDriver{errors: &[]string{}, warnings: &[]string{}}
+}
+
+func (d *connectivityDriver) Run(name string, f func(libtesting.Driver)) {
+	child := *d
+	child.prefix += name + ": "
+	f(&child)
+}
+
+func (d *connectivityDriver) Info(field, value string) {}
+
+func (d *connectivityDriver) Warn(field, reason string) {
+	*d.warnings = append(*d.warnings, d.prefix+field+": "+reason)
+}
+
+func (d *connectivityDriver) Error(field string, err error) {
+	if err != nil {
+		*d.errors = append(*d.errors, fmt.Sprintf("%s%s: %s", d.prefix, field, err))
+	}
+}
+
+func (d *connectivityDriver) Fatal(field string, err error) {
+	d.Error(field, err)
+	if err != nil {
+		runtime.Goexit()
+	}
+}
+
+func (d *connectivityDriver) Result(data string) {}
+
+// runTestHost tests a host in its own goroutine, as fatal errors stop it.
+func runTestHost(d libtesting.Driver, hostData mb.HostData) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		testHost(d, hostData, nil, time.Second)
+	}()
+	<-done
+}
+
+func TestTestHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, _ := r.BasicAuth(); user != "elastic" || password != "changeme" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("valid credentials", func(t *testing.T) {
+		d := newConnectivityDriver()
+		runTestHost(d, mb.HostData{URI: server.URL, User: "elastic", Password: "changeme"})
+		assert.Empty(t, *d.errors)
+		assert.Equal(t, []string{"TLS: secure connection disabled"}, *d.warnings)
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		d := newConnectivityDriver()
+		runTestHost(d, mb.HostData{URI: server.URL, User: "elastic", Password: "wrong"})
+		require.Len(t, *d.errors, 1)
+		assert.Equal(t, "auth: credentials rejected by the server: 401 Unauthorized", (*d.errors)[0])
+	})
+
+	t.Run("connection refused", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		listener.Close()
+
+		d := newConnectivityDriver()
+		runTestHost(d, mb.HostData{URI: "tcp://" + address, Host: address})
+		require.Len(t, *d.errors, 1)
+		assert.True(t, strings.HasPrefix((*d.errors)[0], "connection: dial up: "), (*d.errors)[0])
+	})
+
+	t.Run("TLS handshake", func(t *testing.T) {
+		// The plain HTTP server doesn't accept TLS handshakes.
+		d := newConnectivityDriver()
+		runTestHost(d, mb.HostData{URI: strings.Replace(server.URL, "http://", "https://", 1)})
+		require.Len(t, *d.errors, 1)
+		assert.True(t, strings.HasPrefix((*d.errors)[0], "TLS: handshake: "), (*d.errors)[0])
+	})
+}
+
+func TestNewHostTarget(t *testing.T) {
+	cases := []struct {
+		hostData mb.HostData
+		expected hostTarget
+		err      bool
+	}{
+		{
+			hostData: mb.HostData{URI: "localhost:6379", Host: "localhost:6379"},
+			expected: hostTarget{address: "localhost:6379"},
+		},
+		{
+			hostData: mb.HostData{URI: "http://localhost/server-status", Host: "localhost"},
+			expected: hostTarget{scheme: "http", address: "localhost:80", uri: "http://localhost/server-status"},
+		},
+		{
+			hostData: mb.HostData{URI: "https://[::1]"},
+			expected: hostTarget{scheme: "https", address: "[::1]:443", uri: "https://[::1]"},
+		},
+		{
+			hostData: mb.HostData{URI: "unix:///var/run/docker.sock"},
+			expected: hostTarget{scheme: "unix", socket: "/var/run/docker.sock"},
+		},
+		{
+			hostData: mb.HostData{URI: "mongodb://localhost"},
+			err:      true,
+		},
+		{
+			hostData: mb.HostData{URI: "localhost"},
+			err:      true,
+		},
+	}
+	for _, c := range cases {
+		target, err := newHostTarget(c.hostData)
+		if c.err {
+			assert.Error(t, err, c.hostData.URI)
+			continue
+		}
+		require.NoError(t, err, c.hostData.URI)
+		assert.Equal(t, c.expected, target, c.hostData.URI)
+	}
+}
@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// secretKeys are the keys of configuration values that are redacted in the
+// diagnostics, in addition to the ones masked in the logs.
+var secretKeys = []string{"api_key", "token", "secret"}
+
+// metricSetDiagnostics is the state of a running MetricSet included in the
+// diagnostics.
+type metricSetDiagnostics struct {
+	MetricSetHealth
+	RecentErrors []HealthError          `json:"recent_errors,omitempty"`
+	Config       map[string]interface{} `json:"config,omitempty"`
+	Stats        map[string]interface{} `json:"stats,omitempty"`
+	Metrics      map[string]interface{} `json:"metrics,omitempty"`
+	Goroutines   string                 `json:"goroutines,omitempty"`
+}
+
+// DiagnosticsJSON returns the diagnostics of all the running MetricSets as
+// JSON, to be included in diagnostics bundles. For each MetricSet it contains
+// its health and recent errors, the configuration of its module with secrets
+// redacted, its stats and metrics, and the stacks of its goroutines.
+func DiagnosticsJSON() ([]byte, error) {
+	// A single dump is taken for all the MetricSets.
+	dump := goroutineDump()
+
+	healthLock.Lock()
+	list := make([]metricSetDiagnostics, 0, len(healths))
+	for msw := range healths {
+		list = append(list, msw.diagnostics(dump))
+	}
+	healthLock.Unlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		return healthLess(list[i].MetricSetHealth, list[j].MetricSetHealth)
+	})
+	return json.MarshalIndent(list, "", "  ")
+}
+
+func (msw *metricSetWrapper) diagnostics(dump string) metricSetDiagnostics {
+	d := metricSetDiagnostics{
+		MetricSetHealth: msw.healthSnapshot(),
+		Goroutines:      labeledStacks(dump, metricSetLabel, msw.ID()),
+	}
+
+	msw.health.mu.Lock()
+	d.RecentErrors = append([]HealthError(nil), msw.health.recentErrors...)
+	msw.health.mu.Unlock()
+
+	var config map[string]interface{}
+	if err := msw.module.UnpackConfig(&config); err == nil {
+		conf.ApplyLoggingMask(config)
+		redactSecrets(config)
+		d.Config = config
+	} else {
+		msw.logger.Warnf("Failed to unpack the configuration of %s for diagnostics: %v", msw, err)
+	}
+
+	if reg := monitoring.Default.GetRegistry("metricbeat." + msw.module.Name() + "." + msw.Name()); reg != nil {
+		d.Stats = monitoring.CollectStructSnapshot(reg, monitoring.Full, false)
+	}
+	if reg := msw.Metrics(); reg != nil {
+		d.Metrics = monitoring.CollectStructSnapshot(reg, monitoring.Full, false)
+	}
+	return d
+}
+
+// redactSecrets masks the values of the secretKeys in the configuration,
+// recursively.
+func redactSecrets(config interface{}) {
+	switch c := config.(type) {
+	case map[string]interface{}:
+		for k, v := range c {
+			if isSecretKey(k) {
+				c[k] = "xxxxx"
+				continue
+			}
+			redactSecrets(v)
+		}
+	case []interface{}:
+		for _, v := range c {
+			redactSecrets(v)
+		}
+	}
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range secretKeys {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package module_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb/module"
+)
+
+func TestDiagnosticsJSON(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{flakyFetcherName},
+		"hosts":      []string{"alpha"},
+		"period":     "50ms",
+		"username":   "elastic",
+		"password":   "changeme",
+		"api_key":    "id:key",
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+	id := m.MetricSets()[0].ID()
+
+	output := m.Start(make(chan struct{}))
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, m.Stop(ctx))
+	}()
+
+	// The first fetch fails.
+	<-output
+	require.Eventually(t, func() bool {
+		h, found := findHealth(id)
+		return found && h.State == module.HealthDegraded
+	}, 5*time.Second, 5*time.Millisecond)
+
+	data, err := module.DiagnosticsJSON()
+	require.NoError(t, err)
+
+	var diagnostics []struct {
+		ID           string                 `json:"id"`
+		State        string                 `json:"state"`
+		RecentErrors []module.HealthError   `json:"recent_errors"`
+		Config       map[string]interface{} `json:"config"`
+		Stats        map[string]interface{} `json:"stats"`
+		Goroutines   string                 `json:"goroutines"`
+	}
+	require.NoError(t, json.Unmarshal(data, &diagnostics))
+
+	var found bool
+	for _, d := range diagnostics {
+		if d.ID != id {
+			continue
+		}
+		found = true
+
+		assert.Equal(t, module.HealthDegraded, d.State)
+		require.Len(t, d.RecentErrors, 1)
+		assert.Equal(t, "connection refused", d.RecentErrors[0].Message)
+
+		assert.Equal(t, "elastic", d.Config["username"])
+		assert.Equal(t, "xxxxx", d.Config["password"])
+		assert.Equal(t, "xxxxx", d.Config["api_key"])
+
+		assert.Contains(t, d.Stats, "failures")
+		assert.Contains(t, d.Goroutines, id)
+	}
+	assert.True(t, found, "diagnostics of metricset %s not found", id)
+}
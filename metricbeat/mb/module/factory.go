@@ -96,3 +96,10 @@ func (r *Factory) CheckConfig(config *conf.C) error {
 
 	return nil
 }
+
+// UnusedSettings returns the settings of the module configuration that are not
+// read by the module, its metricsets, or the connectors of its runners. See
+// mb.UnusedSettings.
+func (r *Factory) UnusedSettings(config *conf.C) ([]string, error) {
+	return mb.UnusedSettings(config, r.registry, &connectorConfig{})
+}
@@ -0,0 +1,317 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// Health states of a running MetricSet.
+const (
+	HealthStarting = "starting" // No fetch has succeeded yet, nor failed enough times to be degraded.
+	HealthRunning  = "running"  // Fetches, or the events of push MetricSets, succeed.
+	HealthDegraded = "degraded" // Fetches, or the events of push MetricSets, fail.
+	HealthFailed   = "failed"   // A fetch did not return, see the watchdog settings.
+)
+
+var (
+	healthLock sync.Mutex
+	healths    = map[*metricSetWrapper]struct{}{}
+)
+
+// MetricSetHealth is the health of a running MetricSet, as returned by Health.
+type MetricSetHealth struct {
+	ID              string     `json:"id"`
+	Module          string     `json:"module"`
+	MetricSet       string     `json:"metricset"`
+	Host            string     `json:"host,omitempty"`
+	State           string     `json:"state"`
+	Period          string     `json:"period,omitempty"`
+	LastError       string     `json:"last_error,omitempty"`
+	LastErrorType   string     `json:"last_error_type,omitempty"`
+	LastErrorTime   *time.Time `json:"last_error_time,omitempty"`
+	LastSuccessTime *time.Time `json:"last_success_time,omitempty"`
+	NextFetchTime   *time.Time `json:"next_fetch_time,omitempty"`
+
+	// RecentFetchDurations are the durations of the last fetches, oldest
+	// first.
+	RecentFetchDurations []time.Duration `json:"recent_fetch_durations_ns,omitempty"`
+}
+
+// maxRecentErrors is the number of recent errors kept for each MetricSet.
+const maxRecentErrors = 10
+
+// maxRecentFetchDurations is the number of recent fetch durations kept for
+// each MetricSet.
+const maxRecentFetchDurations = 60
+
+// HealthError is an error reported by a MetricSet.
+type HealthError struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+	Type    string    `json:"type,omitempty"`
+}
+
+// health keeps track of the state of a MetricSet. The state only changes
+// after a number of consecutive failures or successes, so MetricSets that fail
+// intermittently don't flap between running and degraded.
+type health struct {
+	mu   sync.Mutex
+	push bool // Set to true if the state is given by the events instead of the fetches.
+
+	failureThreshold int // Consecutive failures after which the MetricSet is degraded.
+	successThreshold int // Consecutive successes after which a degraded MetricSet is running.
+	failures         int // Current number of consecutive failures.
+	successes        int // Current number of consecutive successes.
+
+	state           string
+	lastError       string
+	lastErrorType   mb.ErrorCategory
+	lastErrorTime   time.Time
+	lastSuccessTime time.Time
+	recentErrors    []HealthError   // Last errors, oldest first.
+	fetchDurations  []time.Duration // Durations of the last fetches, oldest first.
+	scheduler       Scheduler       // Scheduler of the running fetches, nil if there is none.
+
+	changed func(from, to string) // Called when the state changes, if set.
+}
+
+func newHealth(config mb.HealthConfig, push bool) *health {
+	return &health{
+		push:             push,
+		failureThreshold: config.FailureThreshold,
+		successThreshold: config.SuccessThreshold,
+		state:            HealthStarting,
+	}
+}
+
+// event records an event reported by the MetricSet.
+func (h *health) event(err error, now time.Time) {
+	h.update(func() {
+		if err != nil {
+			h.setError(err, now)
+		} else {
+			h.lastSuccessTime = now
+		}
+		if h.push {
+			h.record(err != nil)
+		}
+	})
+}
+
+// fail marks the MetricSet as failed with the given error, until the outcome
+// of a fetch is recorded.
+func (h *health) fail(err error, now time.Time) {
+	h.update(func() {
+		h.state = HealthFailed
+		h.setError(err, now)
+	})
+}
+
+// setError records the last error. It must be called with h.mu held.
+func (h *health) setError(err error, now time.Time) {
+	h.lastError = err.Error()
+	h.lastErrorType = mb.ErrorCategoryOf(err)
+	h.lastErrorTime = now
+
+	if len(h.recentErrors) == maxRecentErrors {
+		h.recentErrors = append(h.recentErrors[:0], h.recentErrors[1:]...)
+	}
+	h.recentErrors = append(h.recentErrors, HealthError{
+		Time:    now,
+		Message: h.lastError,
+		Type:    string(h.lastErrorType),
+	})
+}
+
+// fetchDuration records the duration of a complete fetch.
+func (h *health) fetchDuration(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.fetchDurations) == maxRecentFetchDurations {
+		h.fetchDurations = append(h.fetchDurations[:0], h.fetchDurations[1:]...)
+	}
+	h.fetchDurations = append(h.fetchDurations, d)
+}
+
+// scheduled sets the Scheduler of the running fetches, or nil when they stop.
+func (h *health) scheduled(s Scheduler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.scheduler = s
+}
+
+// fetched records the outcome of a complete fetch.
+func (h *health) fetched(failed bool) {
+	h.update(func() {
+		h.record(failed)
+	})
+}
+
+// update calls f with h.mu held, and then h.changed without it if f changed
+// the state, so it can block publishing events.
+func (h *health) update(f func()) {
+	h.mu.Lock()
+	from := h.state
+	f()
+	to := h.state
+	h.mu.Unlock()
+
+	if from != to && h.changed != nil {
+		h.changed(from, to)
+	}
+}
+
+// record counts a failure or a success, and changes the state when enough of
+// them are consecutive. It must be called with h.mu held.
+func (h *health) record(failed bool) {
+	if failed {
+		h.successes = 0
+		h.failures++
+		if h.state == HealthFailed || h.failures >= h.failureThreshold {
+			h.state = HealthDegraded
+		}
+		return
+	}
+
+	h.failures = 0
+	h.successes++
+	if h.state == HealthStarting || h.state == HealthFailed || h.successes >= h.successThreshold {
+		h.state = HealthRunning
+	}
+}
+
+func registerHealth(msw *metricSetWrapper) {
+	healthLock.Lock()
+	defer healthLock.Unlock()
+	healths[msw] = struct{}{}
+}
+
+func unregisterHealth(msw *metricSetWrapper) {
+	healthLock.Lock()
+	defer healthLock.Unlock()
+	delete(healths, msw)
+}
+
+// Health returns the health of all the running MetricSets, sorted by module,
+// MetricSet and host.
+func Health() []MetricSetHealth {
+	healthLock.Lock()
+	list := make([]MetricSetHealth, 0, len(healths))
+	for msw := range healths {
+		list = append(list, msw.healthSnapshot())
+	}
+	healthLock.Unlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		return healthLess(list[i], list[j])
+	})
+	return list
+}
+
+// healthLess orders the health of MetricSets by module, MetricSet and host.
+func healthLess(a, b MetricSetHealth) bool {
+	if a.Module != b.Module {
+		return a.Module < b.Module
+	}
+	if a.MetricSet != b.MetricSet {
+		return a.MetricSet < b.MetricSet
+	}
+	if a.Host != b.Host {
+		return a.Host < b.Host
+	}
+	return a.ID < b.ID
+}
+
+func (msw *metricSetWrapper) healthSnapshot() MetricSetHealth {
+	h := msw.health
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	snapshot := MetricSetHealth{
+		ID:            msw.ID(),
+		Module:        msw.module.Name(),
+		MetricSet:     msw.Name(),
+		Host:          msw.HostData().SanitizedURI,
+		State:         h.state,
+		LastError:     h.lastError,
+		LastErrorType: string(h.lastErrorType),
+	}
+	if snapshot.Host == "" {
+		snapshot.Host = msw.Host()
+	}
+	if msw.isPeriodic() && msw.schedule == nil && msw.module.schedulerFactory == nil {
+		snapshot.Period = msw.period.String()
+	}
+	if !h.lastErrorTime.IsZero() {
+		t := h.lastErrorTime
+		snapshot.LastErrorTime = &t
+	}
+	if !h.lastSuccessTime.IsZero() {
+		t := h.lastSuccessTime
+		snapshot.LastSuccessTime = &t
+	}
+	if s, ok := h.scheduler.(nextFetcher); ok && !msw.module.paused.Load() {
+		if t := s.NextFetch(time.Now()); !t.IsZero() {
+			snapshot.NextFetchTime = &t
+		}
+	}
+	if len(h.fetchDurations) > 0 {
+		snapshot.RecentFetchDurations = append([]time.Duration(nil), h.fetchDurations...)
+	}
+	return snapshot
+}
+
+// HealthHandler returns an HTTP handler that serves the health of the running
+// MetricSets as JSON. The optional module query parameter filters them by
+// module, and pretty indents the output.
+func HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		list := Health()
+		if module := req.URL.Query().Get("module"); module != "" {
+			filtered := list[:0]
+			for _, h := range list {
+				if strings.EqualFold(h.Module, module) {
+					filtered = append(filtered, h)
+				}
+			}
+			list = filtered
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		if _, pretty := req.URL.Query()["pretty"]; pretty {
+			enc.SetIndent("", "  ")
+		}
+		_ = enc.Encode(list)
+	})
+}
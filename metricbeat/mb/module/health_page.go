@@ -0,0 +1,174 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Size of the fetch duration sparklines, in pixels.
+const (
+	sparklineWidth  = 120
+	sparklineHeight = 20
+)
+
+var healthPageTemplate = template.Must(template.New("metricsets").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="{{.Refresh}}">
+<title>Metricsets</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; }
+table { border-collapse: collapse; }
+th, td { border-bottom: 1px solid #ddd; padding: 4px 8px; text-align: left; vertical-align: top; }
+.starting { color: #666; }
+.running { color: #080; }
+.degraded { color: #b60; }
+.failed { color: #c00; font-weight: bold; }
+.error { max-width: 40em; word-break: break-word; }
+polyline { fill: none; stroke: #36c; stroke-width: 1; }
+</style>
+</head>
+<body>
+<h1>Metricsets</h1>
+<p>{{len .MetricSets}} metricsets running at {{.Now.Format "2006-01-02T15:04:05Z07:00"}}, refreshed every {{.Refresh}}s.</p>
+<table>
+<tr><th>Module</th><th>Metricset</th><th>Host</th><th>State</th><th>Last error</th><th>Next fetch</th><th>Fetch duration</th></tr>
+{{- range .MetricSets}}
+<tr>
+<td>{{.Module}}</td>
+<td>{{.MetricSet}}</td>
+<td>{{.Host}}</td>
+<td class="{{.State}}">{{.State}}</td>
+<td class="error">{{if .LastError}}{{.LastError}}{{if .LastErrorType}} ({{.LastErrorType}}){{end}}<br><small>{{.LastErrorAgo}} ago</small>{{end}}</td>
+<td>{{.NextFetch}}</td>
+<td>{{if .Points}}<svg width="{{.Width}}" height="{{.Height}}"><polyline points="{{.Points}}"/></svg><br><small>last {{.LastDuration}}, max {{.MaxDuration}}</small>{{end}}</td>
+</tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+// healthPageRow is a row of the table of the health page.
+type healthPageRow struct {
+	MetricSetHealth
+	LastErrorAgo string
+	NextFetch    string
+	Points       string // Points of the sparkline of the fetch durations.
+	Width        int
+	Height       int
+	LastDuration time.Duration
+	MaxDuration  time.Duration
+}
+
+// HealthPageHandler returns an HTTP handler that serves an HTML page with the
+// health of the running MetricSets, as returned by Health. For each MetricSet
+// it shows its state, last error, next scheduled fetch and a sparkline of its
+// last fetch durations. The page refreshes itself every refresh, and the
+// optional module query parameter filters the MetricSets by module.
+func HealthPageHandler(refresh time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		now := time.Now()
+		module := req.URL.Query().Get("module")
+		var rows []healthPageRow
+		for _, h := range Health() {
+			if module != "" && !strings.EqualFold(h.Module, module) {
+				continue
+			}
+			rows = append(rows, newHealthPageRow(h, now))
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		err := healthPageTemplate.Execute(w, struct {
+			Now        time.Time
+			Refresh    int
+			MetricSets []healthPageRow
+		}{
+			Now:        now,
+			Refresh:    int(refresh.Seconds()),
+			MetricSets: rows,
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+func newHealthPageRow(h MetricSetHealth, now time.Time) healthPageRow {
+	row := healthPageRow{MetricSetHealth: h}
+	if h.LastErrorTime != nil {
+		row.LastErrorAgo = now.Sub(*h.LastErrorTime).Round(time.Second).String()
+	}
+	switch {
+	case h.NextFetchTime == nil:
+		row.NextFetch = "-"
+	case h.NextFetchTime.After(now):
+		row.NextFetch = fmt.Sprintf("in %v", h.NextFetchTime.Sub(now).Round(time.Millisecond))
+	default:
+		row.NextFetch = "due"
+	}
+	if n := len(h.RecentFetchDurations); n > 0 {
+		row.Points = sparkline(h.RecentFetchDurations, sparklineWidth, sparklineHeight)
+		row.Width = sparklineWidth
+		row.Height = sparklineHeight
+		row.LastDuration = h.RecentFetchDurations[n-1].Round(time.Microsecond)
+		for _, d := range h.RecentFetchDurations {
+			if d > row.MaxDuration {
+				row.MaxDuration = d
+			}
+		}
+		row.MaxDuration = row.MaxDuration.Round(time.Microsecond)
+	}
+	return row
+}
+
+// sparkline returns the points of an SVG polyline of the given size that plots
+// the durations, scaled to the longest one. The points of all the possible
+// durations are spread over the width, so sparklines of MetricSets that
+// started at different times can be compared.
+func sparkline(durations []time.Duration, width, height int) string {
+	var longest time.Duration
+	for _, d := range durations {
+		if d > longest {
+			longest = d
+		}
+	}
+	step := float64(width) / float64(maxRecentFetchDurations-1)
+	offset := maxRecentFetchDurations - len(durations)
+
+	points := make([]string, 0, len(durations))
+	for i, d := range durations {
+		y := float64(height)
+		if longest > 0 {
+			y -= float64(height) * float64(d) / float64(longest)
+		}
+		points = append(points, fmt.Sprintf("%.1f,%.1f", float64(offset+i)*step, y))
+	}
+	return strings.Join(points, " ")
+}
@@ -0,0 +1,228 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb/module"
+)
+
+func findHealth(id string) (module.MetricSetHealth, bool) {
+	for _, h := range module.Health() {
+		if h.ID == id {
+			return h, true
+		}
+	}
+	return module.MetricSetHealth{}, false
+}
+
+func TestHealth(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{flakyFetcherName},
+		"hosts":      []string{"alpha"},
+		"period":     "50ms",
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+	id := m.MetricSets()[0].ID()
+
+	output := m.Start(make(chan struct{}))
+
+	// The first fetch fails.
+	<-output
+	require.Eventually(t, func() bool {
+		h, found := findHealth(id)
+		return found && h.State == module.HealthDegraded
+	}, 5*time.Second, 5*time.Millisecond)
+
+	h, _ := findHealth(id)
+	assert.Equal(t, moduleName, h.Module)
+	assert.Equal(t, m.MetricSets()[0].Name(), h.MetricSet)
+	assert.Equal(t, "alpha", h.Host)
+	assert.Equal(t, "50ms", h.Period)
+	assert.Equal(t, "connection refused", h.LastError)
+	assert.NotNil(t, h.LastErrorTime)
+	assert.Nil(t, h.LastSuccessTime)
+
+	// The second one succeeds.
+	<-output
+	require.Eventually(t, func() bool {
+		h, _ := findHealth(id)
+		return h.State == module.HealthRunning
+	}, 5*time.Second, 5*time.Millisecond)
+
+	h, _ = findHealth(id)
+	assert.Equal(t, "connection refused", h.LastError)
+	assert.NotNil(t, h.LastSuccessTime)
+	assert.GreaterOrEqual(t, len(h.RecentFetchDurations), 2)
+	if assert.NotNil(t, h.NextFetchTime) {
+		assert.WithinDuration(t, time.Now(), *h.NextFetchTime, 50*time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, m.Stop(ctx))
+
+	_, found := findHealth(id)
+	assert.False(t, found, "stopped metricsets are not reported")
+}
+
+func TestHealthFailureThreshold(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":                   moduleName,
+		"metricsets":               []string{flakyFetcherName},
+		"hosts":                    []string{"alpha"},
+		"period":                   "10ms",
+		"health.failure_threshold": 2,
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+	id := m.MetricSets()[0].ID()
+
+	output := m.Start(make(chan struct{}))
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, m.Stop(ctx))
+	}()
+
+	// Every other fetch fails, which is never enough to be degraded.
+	for i := 0; i < 6; i++ {
+		<-output
+		h, _ := findHealth(id)
+		assert.NotEqual(t, module.HealthDegraded, h.State)
+	}
+	h, _ := findHealth(id)
+	assert.Equal(t, module.HealthRunning, h.State)
+	assert.Equal(t, "connection refused", h.LastError)
+}
+
+func TestHealthHandler(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{reportingFetcherName},
+		"hosts":      []string{"alpha"},
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+	id := m.MetricSets()[0].ID()
+
+	output := m.Start(make(chan struct{}))
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, m.Stop(ctx))
+	}()
+	<-output
+
+	server := httptest.NewServer(module.HealthHandler())
+	defer server.Close()
+
+	get := func(query string) []module.MetricSetHealth {
+		t.Helper()
+		resp, err := http.Get(server.URL + query)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json; charset=utf-8", resp.Header.Get("Content-Type"))
+
+		var list []module.MetricSetHealth
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+		return list
+	}
+
+	contains := func(list []module.MetricSetHealth) bool {
+		for _, h := range list {
+			if h.ID == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	assert.True(t, contains(get("/")))
+	assert.True(t, contains(get("/?pretty&module="+moduleName)))
+	assert.False(t, contains(get("/?module=other")))
+
+	resp, err := http.Post(server.URL, "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestHealthPageHandler(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{flakyFetcherName},
+		"hosts":      []string{"<alpha>"},
+		"period":     "50ms",
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	output := m.Start(make(chan struct{}))
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, m.Stop(ctx))
+	}()
+	<-output
+	<-output
+
+	server := httptest.NewServer(module.HealthPageHandler(5 * time.Second))
+	defer server.Close()
+
+	get := func(query string) string {
+		t.Helper()
+		resp, err := http.Get(server.URL + query)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/html; charset=utf-8", resp.Header.Get("Content-Type"))
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	page := get("/")
+	assert.Contains(t, page, `<meta http-equiv="refresh" content="5">`)
+	assert.Contains(t, page, "&lt;alpha&gt;", "values are escaped")
+	assert.Contains(t, page, "connection refused")
+	assert.Contains(t, page, "<polyline points=")
+	assert.NotContains(t, get("/?module=other"), "&lt;alpha&gt;")
+
+	resp, err := http.Post(server.URL, "text/plain", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
@@ -0,0 +1,97 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"context"
+	"time"
+)
+
+// FetchLimiter bounds the number of MetricSet fetches that run at the same
+// time. A single FetchLimiter can be shared by multiple Wrappers to bound the
+// concurrency of all of them. A nil FetchLimiter doesn't limit fetches.
+type FetchLimiter struct {
+	slots chan struct{}
+}
+
+// NewFetchLimiter creates a FetchLimiter that allows up to max concurrent
+// fetches. It returns nil if max is not positive, meaning that fetches are not
+// limited.
+func NewFetchLimiter(max int) *FetchLimiter {
+	if max <= 0 {
+		return nil
+	}
+	return &FetchLimiter{slots: make(chan struct{}, max)}
+}
+
+// acquire blocks until a fetch can be started. It returns false if ctx is
+// done while waiting.
+func (l *FetchLimiter) acquire(ctx context.Context) bool {
+	if l == nil {
+		return true
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case l.slots <- struct{}{}:
+		return true
+	}
+}
+
+// release marks a fetch started with acquire as finished.
+func (l *FetchLimiter) release() {
+	if l == nil {
+		return
+	}
+	<-l.slots
+}
+
+// fetchSlot is a slot of a FetchLimiter held by a fetch.
+type fetchSlot struct {
+	limiter *FetchLimiter
+	held    bool
+}
+
+// acquireSlot is like acquire, but it returns the acquired slot so it can be
+// given up while the fetch waits.
+func (l *FetchLimiter) acquireSlot(ctx context.Context) (*fetchSlot, bool) {
+	if !l.acquire(ctx) {
+		return nil, false
+	}
+	return &fetchSlot{limiter: l, held: true}, true
+}
+
+// wait releases the slot during delay, so other fetches can run meanwhile, and
+// acquires it again. It returns false if ctx is done before the slot is
+// acquired again.
+func (s *fetchSlot) wait(ctx context.Context, delay time.Duration) bool {
+	s.release()
+	if !sleep(ctx, delay) {
+		return false
+	}
+	s.held = s.limiter.acquire(ctx)
+	return s.held
+}
+
+// release releases the slot if it is held.
+func (s *fetchSlot) release() {
+	if s.held {
+		s.limiter.release()
+		s.held = false
+	}
+}
@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package module
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchLimiter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	l := NewFetchLimiter(1)
+	assert.True(t, l.acquire(ctx))
+
+	// The only slot is in use, so acquiring blocks until ctx is done.
+	cancel()
+	assert.False(t, l.acquire(ctx))
+
+	l.release()
+	assert.True(t, l.acquire(context.Background()))
+}
+
+func TestFetchLimiterUnlimited(t *testing.T) {
+	l := NewFetchLimiter(0)
+	assert.Nil(t, l)
+
+	for i := 0; i < 10; i++ {
+		assert.True(t, l.acquire(context.Background()))
+	}
+	l.release()
+}
+
+func TestFetchSlotWait(t *testing.T) {
+	l := NewFetchLimiter(1)
+	slot, ok := l.acquireSlot(context.Background())
+	assert.True(t, ok)
+
+	// The slot is available to other fetches while waiting.
+	acquired := make(chan struct{})
+	go func() {
+		assert.True(t, l.acquire(context.Background()))
+		close(acquired)
+		time.Sleep(10 * time.Millisecond)
+		l.release()
+	}()
+	assert.True(t, slot.wait(context.Background(), 50*time.Millisecond))
+	select {
+	case <-acquired:
+	default:
+		t.Fatal("slot was not released while waiting")
+	}
+
+	// The slot is not held if ctx is done while waiting.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, slot.wait(ctx, time.Hour))
+	slot.release()
+	assert.True(t, l.acquire(context.Background()))
+}
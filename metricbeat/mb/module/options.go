@@ -20,6 +20,8 @@ package module
 import (
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
@@ -36,10 +38,67 @@ func WithMaxStartDelay(delay time.Duration) Option {
 	}
 }
 
+// WithoutPushStartDelay disables the random startup delay for push MetricSets,
+// which are usually sensitive to latency. It can still be overridden per
+// MetricSet with the max_start_delay setting of metricset_overrides.
+func WithoutPushStartDelay() Option {
+	return func(w *Wrapper) {
+		w.noPushStartDelay = true
+	}
+}
+
+// WithOutputBufferSize sets the size of the buffer of the channel returned by
+// Start. A larger buffer prevents MetricSets from blocking while the consumer
+// of the events is slow. The default size is 1.
+func WithOutputBufferSize(size int) Option {
+	return func(w *Wrapper) {
+		w.outputBufferSize = size
+	}
+}
+
+// WithFetchLimiter bounds the number of concurrent fetches of the MetricSets in
+// the module with the given FetchLimiter. The same FetchLimiter can be used
+// with multiple modules to bound their fetches together. By default fetches are
+// not limited.
+func WithFetchLimiter(limiter *FetchLimiter) Option {
+	return func(w *Wrapper) {
+		w.fetchLimiter = limiter
+	}
+}
+
+// WithScheduler sets the SchedulerFactory used to create the Schedulers of the
+// MetricSets in the module, to fetch them on something other than a fixed
+// period or a cron schedule. MetricSets for which the factory returns nil keep
+// their default scheduling.
+func WithScheduler(factory SchedulerFactory) Option {
+	return func(w *Wrapper) {
+		w.schedulerFactory = factory
+	}
+}
+
+// WithTracer sets the OpenTelemetry tracer used to trace the fetches of the
+// MetricSets in the module. Each fetch is recorded as a span, and the context
+// passed to MetricSets that support it carries the span. By default fetches
+// are not traced.
+func WithTracer(tracer trace.Tracer) Option {
+	return func(w *Wrapper) {
+		w.tracer = tracer
+	}
+}
+
 // WithEventModifier attaches an EventModifier that will be executed for each
 // event generated by the MetricSets of the module. Multiple EventModifiers can
 // be added and they will be executed in the order in which they were added.
 func WithEventModifier(modifier mb.EventModifier) Option {
+	return WithEventModifierV2(mb.EventModifierV2Of(modifier))
+}
+
+// WithEventModifierV2 attaches an EventModifierV2 that will be executed for
+// each event generated by the MetricSets of the module, in the same order as
+// the modifiers added with WithEventModifier. Events dropped by the modifier
+// are not published, and its errors are counted in the stats of the
+// MetricSets.
+func WithEventModifierV2(modifier mb.EventModifierV2) Option {
 	return func(w *Wrapper) {
 		w.eventModifiers = append(w.eventModifiers, modifier)
 	}
@@ -83,6 +142,6 @@ func WithServiceName() Option {
 			}
 			event.RootFields.Put("service.name", serviceName)
 		}
-		w.eventModifiers = append(w.eventModifiers, modifier)
+		w.eventModifiers = append(w.eventModifiers, mb.EventModifierV2Of(modifier))
 	}
 }
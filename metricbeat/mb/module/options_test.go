@@ -18,11 +18,16 @@
 package module
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/elastic/beats/v7/metricbeat/mb"
+	conf "github.com/elastic/elastic-agent-libs/config"
 )
 
 func TestWithMaxStartDelay(t *testing.T) {
@@ -31,6 +36,74 @@ func TestWithMaxStartDelay(t *testing.T) {
 	assert.EqualValues(t, 1, w.maxStartDelay)
 }
 
+func TestWithoutPushStartDelay(t *testing.T) {
+	w := &Wrapper{}
+	WithoutPushStartDelay()(w)
+	assert.True(t, w.noPushStartDelay)
+}
+
+type pushV3MetricSet struct{ mb.BaseMetricSet }
+
+func (*pushV3MetricSet) Run(ctx context.Context, _ mb.ReporterV2) error {
+	<-ctx.Done()
+	return nil
+}
+
+type fetcherMetricSet struct{ mb.BaseMetricSet }
+
+func (*fetcherMetricSet) Fetch(mb.ReporterV2) error { return nil }
+
+func TestWithoutPushStartDelayPushMetricSetV3(t *testing.T) {
+	r := mb.NewRegister()
+	require.NoError(t, r.AddMetricSet("test", "push", func(base mb.BaseMetricSet) (mb.MetricSet, error) {
+		var ms mb.PushMetricSetV3 = &pushV3MetricSet{BaseMetricSet: base}
+		return ms, nil
+	}))
+	require.NoError(t, r.AddMetricSet("test", "fetch", func(base mb.BaseMetricSet) (mb.MetricSet, error) {
+		return &fetcherMetricSet{BaseMetricSet: base}, nil
+	}))
+	c, err := conf.NewConfigFrom(map[string]interface{}{
+		"module":     "test",
+		"metricsets": []string{"push", "fetch"},
+	})
+	require.NoError(t, err)
+
+	w, err := NewWrapper(c, r, WithMaxStartDelay(time.Minute), WithoutPushStartDelay())
+	require.NoError(t, err)
+
+	delays := map[string]time.Duration{}
+	for _, msw := range w.metricSets {
+		delays[msw.Name()] = w.metricSetMaxStartDelay(msw.MetricSet)
+	}
+	assert.Equal(t, map[string]time.Duration{"push": 0, "fetch": time.Minute}, delays)
+}
+
+func TestWithOutputBufferSize(t *testing.T) {
+	w := &Wrapper{}
+	WithOutputBufferSize(10)(w)
+	assert.Equal(t, 10, w.outputBufferSize)
+}
+
+func TestWithFetchLimiter(t *testing.T) {
+	l := NewFetchLimiter(1)
+	w := &Wrapper{}
+	WithFetchLimiter(l)(w)
+	assert.Same(t, l, w.fetchLimiter)
+}
+
+func TestWithScheduler(t *testing.T) {
+	w := &Wrapper{}
+	WithScheduler(func(mb.MetricSet, time.Duration) Scheduler { return nil })(w)
+	assert.NotNil(t, w.schedulerFactory)
+}
+
+func TestWithTracer(t *testing.T) {
+	tracer := noop.NewTracerProvider().Tracer("test")
+	w := &Wrapper{}
+	WithTracer(tracer)(w)
+	assert.Equal(t, tracer, w.tracer)
+}
+
 func TestWithMetricSetInfo(t *testing.T) {
 	w := &Wrapper{}
 	WithMetricSetInfo()(w)
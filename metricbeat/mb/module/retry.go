@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"context"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// bufferedReporter is a reporter that keeps the events reported by a fetch
+// attempt in memory, so they can be discarded if the attempt is retried.
+type bufferedReporter struct {
+	parent reporter
+	module string
+	events []mb.Event
+	errs   []error // Errors reported by the attempt.
+}
+
+func newBufferedReporter(parent reporter, module string) *bufferedReporter {
+	return &bufferedReporter{parent: parent, module: module}
+}
+
+func (r *bufferedReporter) Context() context.Context { return r.parent.Context() }
+func (r *bufferedReporter) StartFetchTimer()         { r.parent.StartFetchTimer() }
+func (r *bufferedReporter) FetchFailed() bool        { return len(r.errs) > 0 }
+func (r *bufferedReporter) FetchError() error {
+	if len(r.errs) == 0 {
+		return nil
+	}
+	return r.errs[len(r.errs)-1]
+}
+func (r *bufferedReporter) V1() mb.PushReporter { //nolint:staticcheck // PushReporter is deprecated but not removed
+	return reporterV1{v2: r.V2(), module: r.module}
+}
+func (r *bufferedReporter) V2() mb.PushReporterV2 { return bufferedReporterV2{r} }
+
+func (r *bufferedReporter) Events(events []mb.Event) bool {
+	for _, event := range events {
+		r.V2().Event(event)
+	}
+	return true
+}
+
+// retryable returns true if the attempt failed and all the errors it reported
+// can be retried according to config.
+func (r *bufferedReporter) retryable(config mb.RetryConfig) bool {
+	for _, err := range r.errs {
+		if !config.Retryable(err) {
+			return false
+		}
+	}
+	return r.FetchFailed()
+}
+
+// flush publishes the buffered events through the parent reporter.
+func (r *bufferedReporter) flush() {
+	r.parent.Events(r.events)
+}
+
+type bufferedReporterV2 struct {
+	*bufferedReporter
+}
+
+func (r bufferedReporterV2) Done() <-chan struct{} { return r.parent.Context().Done() }
+func (r bufferedReporterV2) Error(err error) bool  { return r.Event(mb.Event{Error: err}) }
+func (r bufferedReporterV2) Event(event mb.Event) bool {
+	if event.Error != nil {
+		r.errs = append(r.errs, event.Error)
+	}
+	r.events = append(r.events, event)
+	return true
+}
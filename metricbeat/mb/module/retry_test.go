@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package module
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/common/match"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+func TestBufferedReporterRetryable(t *testing.T) {
+	config := mb.RetryConfig{
+		Errors: []match.Matcher{match.MustCompile("connection refused")},
+	}
+
+	cases := map[string]struct {
+		errs      []error
+		retryable bool
+	}{
+		"no errors": {
+			retryable: false,
+		},
+		"retryable error": {
+			errs:      []error{errors.New("connection refused")},
+			retryable: true,
+		},
+		"all errors retryable": {
+			errs:      []error{errors.New("connection refused"), errors.New("dial: connection refused")},
+			retryable: true,
+		},
+		"later error not retryable": {
+			errs:      []error{errors.New("connection refused"), errors.New("unexpected status 401")},
+			retryable: false,
+		},
+		"mb.Error not retryable": {
+			errs:      []error{&mb.Error{Err: errors.New("connection refused")}},
+			retryable: false,
+		},
+	}
+
+	for title, c := range cases {
+		t.Run(title, func(t *testing.T) {
+			r := newBufferedReporter(&eventReporter{ctx: context.Background()}, "test")
+			for _, err := range c.errs {
+				r.V2().Error(err)
+			}
+			assert.Equal(t, c.retryable, r.retryable(config))
+		})
+	}
+}
@@ -18,6 +18,7 @@
 package module
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"sync"
@@ -60,15 +61,18 @@ type Runner interface {
 // the Module will be published to a new publisher.Client generated from the
 // pubClientFactory.
 func NewRunner(client beat.Client, mod *Wrapper) cfgfile.Runner {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &runner{
-		done:   make(chan struct{}),
+		ctx:    ctx,
+		cancel: cancel,
 		mod:    mod,
 		client: client,
 	}
 }
 
 type runner struct {
-	done      chan struct{}
+	ctx       context.Context
+	cancel    context.CancelFunc
 	wg        sync.WaitGroup
 	startOnce sync.Once
 	stopOnce  sync.Once
@@ -78,7 +82,7 @@ type runner struct {
 
 func (mr *runner) Start() {
 	mr.startOnce.Do(func() {
-		output := mr.mod.Start(mr.done)
+		output := mr.mod.StartWithContext(mr.ctx)
 		mr.wg.Add(1)
 		moduleList.Add(mr.mod.Name())
 		go func() {
@@ -90,7 +94,7 @@ func (mr *runner) Start() {
 
 func (mr *runner) Stop() {
 	mr.stopOnce.Do(func() {
-		close(mr.done)
+		mr.cancel()
 		mr.client.Close()
 		mr.wg.Wait()
 		moduleList.Remove(mr.mod.Name())
@@ -121,5 +125,5 @@ func (mr *runner) Diagnostics() []diagnostics.DiagnosticSetup {
 }
 
 func (mr *runner) String() string {
-	return fmt.Sprintf("%s [metricsets=%d]", mr.mod.Name(), len(mr.mod.metricSets))
+	return fmt.Sprintf("%s [metricsets=%d]", mr.mod.Name(), len(mr.mod.MetricSets()))
 }
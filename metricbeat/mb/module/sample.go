@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"context"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// sampler selects the successful fetches of a MetricSet whose events are
+// published, one of every rate. A nil sampler publishes all the fetches.
+//
+// It is not safe for concurrent use, it is only used by the goroutine fetching
+// the MetricSet.
+type sampler struct {
+	rate      int
+	successes int // Number of successful fetches since the last published one.
+}
+
+// newSampler creates a sampler for the given rate. It returns nil if all the
+// fetches are published.
+func newSampler(rate int) *sampler {
+	if rate <= 1 {
+		return nil
+	}
+	return &sampler{rate: rate}
+}
+
+// publish returns true if the events of the next fetch must be published.
+func (s *sampler) publish() bool {
+	return s == nil || s.successes == 0
+}
+
+// record records the result of a fetch. Failed fetches don't count, so the
+// next successful fetch after a failure is published if it was due.
+func (s *sampler) record(failed bool) {
+	if s == nil || failed {
+		return
+	}
+	s.successes = (s.successes + 1) % s.rate
+}
+
+// sampledOutReporter is a reporter for fetches whose events are not
+// published. Errors are still published through the parent reporter.
+type sampledOutReporter struct {
+	parent reporter
+	module string
+}
+
+func (r *sampledOutReporter) Context() context.Context { return r.parent.Context() }
+func (r *sampledOutReporter) StartFetchTimer()         { r.parent.StartFetchTimer() }
+func (r *sampledOutReporter) FetchFailed() bool        { return r.parent.FetchFailed() }
+func (r *sampledOutReporter) FetchError() error        { return r.parent.FetchError() }
+func (r *sampledOutReporter) V1() mb.PushReporter { //nolint:staticcheck // PushReporter is deprecated but not removed
+	return reporterV1{v2: r.V2(), module: r.module}
+}
+func (r *sampledOutReporter) V2() mb.PushReporterV2 { return sampledOutReporterV2{r} }
+func (r *sampledOutReporter) Events(events []mb.Event) bool {
+	for _, event := range events {
+		if !r.V2().Event(event) {
+			return false
+		}
+	}
+	return true
+}
+
+type sampledOutReporterV2 struct {
+	*sampledOutReporter
+}
+
+func (r sampledOutReporterV2) Done() <-chan struct{} { return r.parent.Context().Done() }
+func (r sampledOutReporterV2) Error(err error) bool  { return r.Event(mb.Event{Error: err}) }
+func (r sampledOutReporterV2) Event(event mb.Event) bool {
+	if event.Error != nil {
+		return r.parent.V2().Event(event)
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package module
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampler(t *testing.T) {
+	s := newSampler(3)
+
+	var published []bool
+	for _, failed := range []bool{false, false, false, true, false, true, false, false} {
+		published = append(published, s.publish())
+		s.record(failed)
+	}
+
+	// Failed fetches are not counted, so after the failed fourth fetch the
+	// fifth one is published instead.
+	assert.Equal(t, []bool{true, false, false, true, true, false, false, false}, published)
+}
+
+func TestSamplerDisabled(t *testing.T) {
+	for _, rate := range []int{0, 1} {
+		s := newSampler(rate)
+		assert.Nil(t, s)
+		for i := 0; i < 5; i++ {
+			assert.True(t, s.publish())
+			s.record(false)
+		}
+	}
+}
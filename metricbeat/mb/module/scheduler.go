@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"context"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// Scheduler decides when the fetches of a MetricSet happen.
+type Scheduler interface {
+	// Next returns the channel that receives the time of each fetch when it is
+	// due. The channel is closed when there are no more fetches. Like the
+	// channel of a time.Ticker, it should keep at most one pending time, so
+	// fetches that are due while the previous one is running are not piled
+	// up.
+	Next() <-chan time.Time
+
+	// Done stops the Scheduler. It is called once, when the MetricSet stops.
+	Done()
+}
+
+// nextFetcher is implemented by the Schedulers that know when the next fetch
+// is due, so it is reported in the health of the MetricSet. Schedulers
+// returned by a SchedulerFactory can implement it too.
+type nextFetcher interface {
+	// NextFetch returns the time of the next fetch after now, or zero if
+	// there are no more fetches.
+	NextFetch(now time.Time) time.Time
+}
+
+// SchedulerFactory creates the Scheduler of a MetricSet, period is the
+// period configured for it. It can return nil to use the default scheduling
+// of the MetricSet.
+type SchedulerFactory func(metricSet mb.MetricSet, period time.Duration) Scheduler
+
+// tickerScheduler schedules fetches at fixed intervals.
+type tickerScheduler struct {
+	ticker *time.Ticker
+	start  time.Time
+	period time.Duration
+}
+
+func newTickerScheduler(period time.Duration) *tickerScheduler {
+	return &tickerScheduler{ticker: time.NewTicker(period), start: time.Now(), period: period}
+}
+
+func (s *tickerScheduler) Next() <-chan time.Time { return s.ticker.C }
+func (s *tickerScheduler) Done()                  { s.ticker.Stop() }
+
+// NextFetch returns the time of the first tick after now.
+func (s *tickerScheduler) NextFetch(now time.Time) time.Time {
+	return s.start.Add((now.Sub(s.start)/s.period + 1) * s.period)
+}
+
+// cronScheduler schedules fetches each time a cron schedule matches.
+type cronScheduler struct {
+	schedule *mb.Schedule
+	c        chan time.Time
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+func newCronScheduler(schedule *mb.Schedule) *cronScheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &cronScheduler{
+		schedule: schedule,
+		c:        make(chan time.Time),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	go s.run()
+	return s
+}
+
+func (s *cronScheduler) Next() <-chan time.Time { return s.c }
+func (s *cronScheduler) Done()                  { s.cancel() }
+
+// NextFetch returns the time of the first match of the schedule after now.
+func (s *cronScheduler) NextFetch(now time.Time) time.Time {
+	return s.schedule.Next(now)
+}
+
+func (s *cronScheduler) run() {
+	defer close(s.c)
+	for {
+		next := s.schedule.Next(time.Now())
+		if next.IsZero() {
+			return
+		}
+		if !sleep(s.ctx, time.Until(next)) {
+			return
+		}
+		select {
+		case <-s.ctx.Done():
+			return
+		case s.c <- next:
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// estimateSize returns an estimate of the size in bytes of the JSON encoding
+// of the given value. It is meant to be cheap rather than exact: it doesn't
+// account for escaping and formats uncommon types with fmt.
+func estimateSize(v interface{}) int {
+	switch v := v.(type) {
+	case nil:
+		return len("null")
+	case mapstr.M:
+		return estimateMapSize(v)
+	case map[string]interface{}:
+		return estimateMapSize(v)
+	case []interface{}:
+		size := 2 // Brackets.
+		for _, elem := range v {
+			size += estimateSize(elem) + 1 // Comma.
+		}
+		return size
+	case []string:
+		size := 2 // Brackets.
+		for _, elem := range v {
+			size += len(elem) + 3 // Quotes and comma.
+		}
+		return size
+	case string:
+		return len(v) + 2 // Quotes.
+	case bool:
+		if v {
+			return len("true")
+		}
+		return len("false")
+	case int:
+		return len(strconv.FormatInt(int64(v), 10))
+	case int64:
+		return len(strconv.FormatInt(v, 10))
+	case uint64:
+		return len(strconv.FormatUint(v, 10))
+	case float64:
+		return len(strconv.FormatFloat(v, 'g', -1, 64))
+	case time.Time:
+		return len(`"2006-01-02T15:04:05.000Z"`)
+	default:
+		return len(fmt.Sprint(v))
+	}
+}
+
+func estimateMapSize(m map[string]interface{}) int {
+	size := 2 // Braces.
+	for key, value := range m {
+		size += len(key) + 4 // Quotes, colon and comma.
+		size += estimateSize(value)
+	}
+	return size
+}
@@ -0,0 +1,55 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package module
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestEstimateSize(t *testing.T) {
+	fields := mapstr.M{
+		"metricset": mapstr.M{
+			"name":   "status",
+			"period": 10000,
+		},
+		"service": map[string]interface{}{
+			"address": "127.0.0.1:6379",
+			"up":      true,
+		},
+		"tags":  []string{"a", "b"},
+		"load":  1.5,
+		"value": nil,
+		"list":  []interface{}{int64(1), "two"},
+	}
+
+	encoded, err := json.Marshal(fields)
+	assert.NoError(t, err)
+
+	// The estimate counts a comma after the last member of each object and
+	// array.
+	size := estimateSize(fields)
+	assert.GreaterOrEqual(t, size, len(encoded))
+	assert.InDelta(t, len(encoded), size, 10)
+}
@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Module and MetricSet names of the lifecycle events, published with the
+// metricbeat.state dataset.
+const (
+	stateModule    = "metricbeat"
+	stateMetricSet = "state"
+)
+
+// Actions of the lifecycle events.
+const (
+	stateStarted = "started" // The MetricSet started.
+	stateStopped = "stopped" // The MetricSet stopped.
+	stateChanged = "changed" // The health state of the MetricSet changed.
+)
+
+// publishState publishes a lifecycle event of the MetricSet with the given
+// action, its current health and the previous state, if any. The event is
+// dropped if the module can no longer publish.
+func (msw *metricSetWrapper) publishState(action, previous string) {
+	health := msw.healthSnapshot()
+
+	fields := mapstr.M{
+		"id":        health.ID,
+		"module":    health.Module,
+		"metricset": health.MetricSet,
+		"state":     health.State,
+	}
+	if previous != "" {
+		fields["previous_state"] = previous
+	}
+
+	event := mb.Event{
+		Timestamp:       time.Now().UTC(),
+		Service:         health.Module,
+		Host:            health.Host,
+		RootFields:      mapstr.M{"event": mapstr.M{"action": action}},
+		MetricSetFields: fields,
+	}
+	if health.State == HealthDegraded || health.State == HealthFailed {
+		event.RootFields["error"] = mapstr.M{
+			"message": health.LastError,
+		}
+		if health.LastErrorType != "" {
+			event.RootFields.Put("error.type", health.LastErrorType)
+		}
+	}
+
+	beatEvent := event.BeatEvent(stateModule, stateMetricSet, mb.AddMetricSetInfo)
+	if !writeEvent(msw.module.abortCtx.Done(), msw.module.out, beatEvent) {
+		msw.logger.Debugf("Dropped %s event of %s", action, msw)
+	}
+}
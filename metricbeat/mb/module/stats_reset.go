@@ -0,0 +1,123 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/monitoring/report"
+)
+
+// ResetStats resets the cumulative counters of the stats of the MetricSets
+// of the given module and MetricSet, or of all the MetricSets of the module
+// if metricSet is empty, or of all the MetricSets if both are empty. The
+// current values, like the consecutive failures and the last error, are kept.
+// It returns the keys of the reset stats, sorted.
+func ResetStats(module, metricSet string) []string {
+	prefix := "metricbeat."
+	if module != "" {
+		prefix += strings.ToLower(module) + "."
+		if metricSet != "" {
+			prefix += strings.ToLower(metricSet)
+		}
+	}
+
+	fetchesLock.Lock()
+	defer fetchesLock.Unlock()
+
+	var keys []string
+	for key, s := range fetches {
+		matches := strings.HasPrefix(key, prefix)
+		if metricSet != "" {
+			matches = key == prefix
+		}
+		if !matches {
+			continue
+		}
+		s.reset()
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// reset sets the cumulative counters of the stats, and of their hosts, to 0.
+func (s *stats) reset() {
+	report.MarkReset(s.key, time.Now())
+	s.success.Set(0)
+	s.failures.Set(0)
+	s.events.Set(0)
+	s.timeouts.Set(0)
+	s.skipped.Set(0)
+	s.slow.Set(0)
+	s.stuck.Set(0)
+	s.restarts.Set(0)
+	s.publishedBytes.Set(0)
+	s.dropped.Set(0)
+	s.modifierErrors.Set(0)
+	s.modifierDropped.Set(0)
+	s.processorErrors.Set(0)
+	s.processorDropped.Set(0)
+
+	s.hostsMu.Lock()
+	defer s.hostsMu.Unlock()
+	for _, hs := range s.hosts {
+		hs.success.Set(0)
+		hs.failures.Set(0)
+		hs.events.Set(0)
+	}
+}
+
+// StatsResetHandler returns an HTTP handler that resets the stats of the
+// MetricSets with ResetStats. It only accepts POST requests with the given
+// token as bearer token. The optional module and metricset query parameters
+// select the MetricSets whose stats are reset.
+func StatsResetHandler(token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		auth, found := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if token == "" || !found || subtle.ConstantTimeCompare([]byte(auth), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		query := req.URL.Query()
+		module, metricSet := query.Get("module"), query.Get("metricset")
+		if module == "" && metricSet != "" {
+			http.Error(w, "The metricset parameter requires the module parameter", http.StatusBadRequest)
+			return
+		}
+
+		keys := ResetStats(module, metricSet)
+		if keys == nil {
+			keys = []string{}
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_ = json.NewEncoder(w).Encode(map[string][]string{"reset": keys})
+	})
+}
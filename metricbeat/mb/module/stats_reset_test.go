@@ -0,0 +1,110 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package module_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/monitoring/report"
+	"github.com/elastic/beats/v7/metricbeat/mb/module"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestStatsResetHandler(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{reportingFetcherName},
+		"hosts":      []string{"alpha"},
+		"period":     "1h",
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+	name := m.MetricSets()[0].Name()
+
+	output := m.Start(make(chan struct{}))
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, m.Stop(ctx))
+	}()
+	<-output
+
+	key := "metricbeat." + moduleName + "." + name
+	events := func() int64 {
+		snapshot := monitoring.CollectFlatSnapshot(monitoring.Default, monitoring.Full, false)
+		return snapshot.Ints[key+".events"]
+	}
+	require.Eventually(t, func() bool { return events() == 1 }, 5*time.Second, 5*time.Millisecond)
+
+	handler := module.StatsResetHandler("secret")
+	reset := func(method, target, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Creating the stats is recorded as a reset, so reporters of cumulative
+	// metrics know when they started.
+	created := report.LastReset(key + ".events")
+	assert.False(t, created.IsZero())
+
+	rec := reset(http.MethodGet, "/metricsets/reset", "secret")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	rec = reset(http.MethodPost, "/metricsets/reset", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = reset(http.MethodPost, "/metricsets/reset", "wrong")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, int64(1), events())
+
+	rec = reset(http.MethodPost, "/metricsets/reset?metricset="+name, "secret")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = reset(http.MethodPost, "/metricsets/reset?module="+moduleName+"&metricset=other", "secret")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"reset": []}`, rec.Body.String())
+	assert.Equal(t, int64(1), events())
+
+	rec = reset(http.MethodPost, "/metricsets/reset?module="+moduleName+"&metricset="+name, "secret")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"reset": ["`+key+`"]}`, rec.Body.String())
+	assert.Equal(t, int64(0), events())
+	assert.True(t, report.LastReset(key+".events").After(created))
+}
+
+func TestStatsResetHandlerWithoutToken(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/metricsets/reset", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	rec := httptest.NewRecorder()
+	module.StatsResetHandler("").ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,169 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+)
+
+// metricSetLabel is the pprof label set on the goroutines of a MetricSet
+// worker, with the ID of the MetricSet, to find their stacks.
+const metricSetLabel = "metricset_id"
+
+// watch checks every period that the running fetch of the MetricSet, if any,
+// didn't start more than watchdogPeriods periods ago. Stuck fetches are
+// reported with the stacks of the worker, the MetricSet is marked as failed,
+// and replaced by a new instance if configured. It returns when ctx is done.
+func (msw *metricSetWrapper) watch(ctx context.Context) {
+	timeout := time.Duration(msw.watchdog.Periods) * msw.period
+	ticker := time.NewTicker(msw.period)
+	defer ticker.Stop()
+
+	var reported int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		start := msw.fetchStart.Load()
+		if start == 0 || start == reported {
+			continue
+		}
+		elapsed := time.Since(time.Unix(0, start))
+		if elapsed < timeout {
+			continue
+		}
+		// Each stuck fetch is only reported once.
+		reported = start
+
+		msw.stats.stuck.Add(1)
+		msw.health.fail(fmt.Errorf("fetch did not return after %v", elapsed.Round(time.Millisecond)), time.Now())
+		msw.logger.Errorf("Fetch of %s did not return after %v, goroutines of the metricset:\n%s",
+			msw, elapsed.Round(time.Millisecond), goroutineStacks(metricSetLabel, msw.ID()))
+
+		if msw.watchdog.Restart {
+			if err := msw.module.replaceMetricSet(msw); err != nil {
+				msw.logger.Errorf("Failed to restart %s: %v", msw, err)
+			}
+		}
+	}
+}
+
+// replaceMetricSet stops the worker of the given MetricSet and starts a new
+// one with a new instance of the MetricSet. The old worker is abandoned, its
+// stats and resources are only released if its fetch ever returns.
+func (mw *Wrapper) replaceMetricSet(old *metricSetWrapper) error {
+	if mw.registry == nil {
+		return errors.New("only metricsets of modules created from their configuration can be restarted")
+	}
+	if old.Registration().MultipleHosts {
+		return errors.New("metricsets fetching multiple hosts can't be restarted")
+	}
+
+	var uris []string
+	for _, endpoint := range old.HostData().Endpoints() {
+		uris = append(uris, endpoint.URI)
+	}
+	host := strings.Join(uris, parse.FallbackSeparator)
+	metricSets, err := mb.NewMetricSetsForHosts(mw.registry, mw.Module, []string{host})
+	if err != nil {
+		return fmt.Errorf("failed to create metricset: %w", err)
+	}
+	var replacement mb.MetricSet
+	for _, metricSet := range metricSets {
+		if replacement == nil && metricSet.Name() == old.Name() {
+			replacement = metricSet
+			continue
+		}
+		if closer, ok := metricSet.(mb.Closer); ok {
+			_ = closer.Close()
+		}
+	}
+	if replacement == nil {
+		return errors.New("failed to create metricset")
+	}
+	msw, err := mw.newMetricSetWrapper(replacement)
+	if err != nil {
+		return err
+	}
+
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	index := -1
+	for i, current := range mw.metricSets {
+		if current == old {
+			index = i
+		}
+	}
+	if mw.stopping || index < 0 {
+		// Stopped or removed meanwhile.
+		releaseStats(msw.stats, msw.hostStats)
+		return msw.close()
+	}
+
+	old.logger.Infof("Restarting %s", old)
+	old.cancel()
+	if pauser, ok := msw.MetricSet.(mb.Pauser); ok && mw.paused.Load() {
+		pauser.Pause()
+	}
+	mw.metricSets[index] = msw
+	mw.startWorker(msw)
+	return nil
+}
+
+// goroutineStacks returns the stacks of the goroutines with the given pprof
+// label.
+func goroutineStacks(key, value string) string {
+	return labeledStacks(goroutineDump(), key, value)
+}
+
+// goroutineDump returns the stacks of all the goroutines, with their labels.
+func goroutineDump() string {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// labeledStacks returns the stacks of the goroutine dump with the given pprof
+// label.
+func labeledStacks(dump, key, value string) string {
+	// Goroutines with the same stack and labels are grouped, each group is
+	// followed by an empty line.
+	label := fmt.Sprintf("%q:%q", key, value)
+	var stacks []string
+	for _, group := range strings.Split(dump, "\n\n") {
+		if strings.Contains(group, "# labels: ") && strings.Contains(group, label) {
+			stacks = append(stacks, group)
+		}
+	}
+	return strings.Join(stacks, "\n\n")
+}
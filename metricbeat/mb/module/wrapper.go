@@ -19,18 +19,31 @@ package module
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
+	"runtime/pprof"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/rcrowley/go-metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/backoff"
+	"github.com/elastic/beats/v7/libbeat/monitoring/report"
+	"github.com/elastic/beats/v7/libbeat/processors"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 	"github.com/elastic/elastic-agent-libs/monitoring"
+	"github.com/elastic/elastic-agent-libs/monitoring/adapter"
 	"github.com/elastic/elastic-agent-libs/testing"
 )
 
@@ -39,36 +52,125 @@ const (
 	successesKey = "success"
 	failuresKey  = "failures"
 	eventsKey    = "events"
+	timeoutsKey  = "timeouts"
+	skippedKey   = "fetches.skipped"
+	slowKey      = "fetches.slow"
+	stuckKey     = "fetches.stuck"
+	restartsKey  = "restarts"
+
+	modifierErrorsKey   = "modifiers.errors"
+	modifierDroppedKey  = "modifiers.dropped"
+	processorErrorsKey  = "processors.errors"
+	processorDroppedKey = "processors.dropped"
+
+	consecutiveFailuresKey = "consecutive_failures"
+	lastErrorKey           = "last_error"
+	lastErrorTimeKey       = "last_error_time"
+	lastSuccessTimeKey     = "last_success_time"
+	hostsKey               = "hosts"
+	publishedBytesKey      = "published_bytes"
+
+	// droppedKey counts the events dropped at shutdown. It can't be named
+	// events.dropped, like modifiers.dropped and processors.dropped, because
+	// eventsKey is already registered as an Int and can't hold children.
+	droppedKey = "events_dropped"
 )
 
-var (
-	debugf = logp.MakeDebug("module")
+func init() {
+	// The consecutive failures go back to zero, so they are not reported as
+	// deltas or counters.
+	report.RegisterGaugeSuffix("." + consecutiveFailuresKey)
+}
+
+// Default delays before restarting a failed push MetricSet.
+const (
+	defaultRestartBackoffInit = time.Second
+	defaultRestartBackoffMax  = time.Minute
+)
 
+var (
 	fetchesLock = sync.Mutex{}
 	fetches     = map[string]*stats{}
 )
 
+// deprecationWarnings contains the module/metricset names of the MetricSets
+// that already logged the use of a deprecated interface, so it is logged once
+// per MetricSet and not once per host or configuration reload.
+var deprecationWarnings sync.Map
+
 // Wrapper contains the Module and the private data associated with
 // running the Module and its MetricSets.
 //
 // Use NewWrapper or NewWrappers to construct new Wrappers.
 type Wrapper struct {
 	mb.Module
+	registry *mb.Register // Registry used to create the MetricSets, nil if unknown.
+	logger   *logp.Logger
+
+	mu         sync.Mutex
 	metricSets []*metricSetWrapper // List of pointers to its associated MetricSets.
 
 	// Options
-	maxStartDelay  time.Duration
-	eventModifiers []mb.EventModifier
+	maxStartDelay    time.Duration
+	noPushStartDelay bool
+	eventModifiers   []mb.EventModifierV2
+	fetchLimiter     *FetchLimiter
+	outputBufferSize int
+	schedulerFactory SchedulerFactory
+	tracer           trace.Tracer
+
+	// Set by Start, used by Stop and to start workers of added hosts.
+	out      chan beat.Event
+	wg       sync.WaitGroup
+	stopCtx  context.Context
+	abortCtx context.Context
+	stop     context.CancelFunc // Stops scheduling new fetches.
+	abort    context.CancelFunc // Aborts in-flight fetches and the events they publish.
+	stopping bool               // Set to true once no more workers can be started.
+	stopped  chan struct{}      // Closed when all the workers have stopped.
+
+	paused atomic.Bool // Set to true while periodic fetches are suspended.
 }
 
 // metricSetWrapper contains the MetricSet and the private data associated with
 // running the MetricSet. It contains a pointer to the parent Module.
 type metricSetWrapper struct {
 	mb.MetricSet
-	module *Wrapper // Parent Module.
-	stats  *stats   // stats for this MetricSet.
-
-	periodic bool // Set to true if this metricset is a periodic fetcher
+	module    *Wrapper   // Parent Module.
+	stats     *stats     // stats for this MetricSet.
+	hostStats *hostStats // stats for the host of this MetricSet, nil if it has no host.
+
+	maxStartDelay    time.Duration    // Upper bound of the random startup delay.
+	period           time.Duration    // Period between fetches, if the metricset is a periodic fetcher.
+	jitter           time.Duration    // Maximum random delay added to each periodic fetch.
+	timeout          time.Duration    // Maximum duration of a fetch that supports contexts.
+	schedule         *mb.Schedule     // Cron schedule used instead of the period, if configured.
+	align            bool             // Set to true if periodic fetches are aligned to multiples of the period.
+	fetchOnStart     bool             // Set to true if periodic fetchers fetch as soon as they are started.
+	warmup           bool             // Set to true if the events of the first fetch are discarded.
+	overlap          mb.OverlapPolicy // Policy for periodic fetches due while the previous one is running.
+	breaker          *circuitBreaker  // Suspends fetches after consecutive failures, if enabled.
+	retry            mb.RetryConfig   // Retries of failed fetches.
+	timestampNanos   bool             // Set to true if the events include their timestamp with nanosecond precision.
+	maxFailures      int              // Consecutive failed fetches after which the MetricSet is stopped, if positive.
+	failures         int              // Current number of consecutive failed fetches.
+	sampler          *sampler         // Selects the fetches whose events are published, if sampling is enabled.
+	slowThreshold    time.Duration    // Duration after which fetches are reported as slow, if positive.
+	hostsParallelism int              // Maximum number of hosts fetched concurrently by HostFetchers.
+	periodic         bool             // Set to true if this metricset is a periodic fetcher
+
+	processors *processors.Processors // Applied to the events of this MetricSet only, nil if none are configured.
+
+	cancel        context.CancelFunc // Stops this worker only, set when it is started.
+	trigger       chan struct{}      // Requests an immediate out-of-cycle fetch.
+	backpressure  *backpressure      // Time blocked writing to the output.
+	fetchDuration metrics.Sample     // Duration of each fetch, in nanoseconds.
+	health        *health            // State reported by the health API.
+	dropped       atomic.Int64       // Events dropped because the MetricSet was stopped while publishing them.
+	logger        *logp.Logger       // Logger of the module, with the MetricSet and host.
+	fetchStart    atomic.Int64       // Start of the running fetch in Unix nanoseconds, zero if none is running.
+	watchdog      mb.WatchdogConfig  // Detection of stuck fetches.
+	fetchStarted  bool               // Set to true once started by fetchOnce.
 }
 
 // stats bundles common metricset stats.
@@ -78,6 +180,39 @@ type stats struct {
 	success  *monitoring.Int // Total success events.
 	failures *monitoring.Int // Total error events.
 	events   *monitoring.Int // Total events published.
+	timeouts *monitoring.Int // Total fetches that exceeded the timeout.
+	skipped  *monitoring.Int // Total periodic fetches skipped because the previous one was running.
+	slow     *monitoring.Int // Total fetches that exceeded the slow fetch threshold.
+	stuck    *monitoring.Int // Total fetches detected as stuck by the watchdog.
+	restarts *monitoring.Int // Total restarts of push MetricSets that failed.
+
+	publishedBytes *monitoring.Int // Estimated total size of the events published, in bytes.
+	dropped        *monitoring.Int // Total events dropped because the MetricSet was stopped while publishing them.
+
+	modifierErrors   *monitoring.Int // Total events whose event modifiers failed.
+	modifierDropped  *monitoring.Int // Total events dropped by event modifiers.
+	processorErrors  *monitoring.Int // Total events whose MetricSet processors failed.
+	processorDropped *monitoring.Int // Total events dropped by MetricSet processors.
+
+	consecutiveFailures *monitoring.Int // Current number of consecutive failed fetches, reset on success.
+
+	lastError       *monitoring.String    // Message of the last error event.
+	lastErrorTime   *monitoring.Timestamp // Time of the last error event.
+	lastSuccessTime *monitoring.Timestamp // Time of the last success event.
+
+	hostsMu sync.Mutex
+	hosts   map[string]*hostStats // stats of each host, keyed by sanitized host.
+}
+
+// hostStats bundles the stats of the MetricSets of a single host.
+type hostStats struct {
+	host                string               // sanitized host
+	ref                 uint32               // number of metricsets reusing stats instance
+	registry            *monitoring.Registry // registry of the host stats, reported by its stats
+	success             *monitoring.Int      // Total success events.
+	failures            *monitoring.Int      // Total error events.
+	events              *monitoring.Int      // Total events published.
+	consecutiveFailures *monitoring.Int      // Current number of consecutive failed fetches, reset on success.
 }
 
 // NewWrapper creates a new module and its associated metricsets based on the given configuration.
@@ -86,7 +221,12 @@ func NewWrapper(config *conf.C, r *mb.Register, options ...Option) (*Wrapper, er
 	if err != nil {
 		return nil, err
 	}
-	return createWrapper(module, metricSets, options...)
+	wrapper, err := createWrapper(module, metricSets, options...)
+	if err != nil {
+		return nil, err
+	}
+	wrapper.registry = r
+	return wrapper, nil
 }
 
 // NewWrapperForMetricSet creates a wrapper for the selected module and metricset.
@@ -97,6 +237,7 @@ func NewWrapperForMetricSet(module mb.Module, metricSet mb.MetricSet, options ..
 func createWrapper(module mb.Module, metricSets []mb.MetricSet, options ...Option) (*Wrapper, error) {
 	wrapper := &Wrapper{
 		Module:     module,
+		logger:     module.Config().Logging.Logger(logp.NewLogger("module").With("module", module.Name())),
 		metricSets: make([]*metricSetWrapper, len(metricSets)),
 	}
 
@@ -105,15 +246,93 @@ func createWrapper(module mb.Module, metricSets []mb.MetricSet, options ...Optio
 	}
 
 	for i, metricSet := range metricSets {
-		wrapper.metricSets[i] = &metricSetWrapper{
-			MetricSet: metricSet,
-			module:    wrapper,
-			stats:     getMetricSetStats(wrapper.Name(), metricSet.Name()),
+		msw, err := wrapper.newMetricSetWrapper(metricSet)
+		if err != nil {
+			return nil, err
 		}
+		wrapper.metricSets[i] = msw
 	}
 	return wrapper, nil
 }
 
+// metricSetMaxStartDelay returns the upper bound of the random startup delay
+// of the given MetricSet.
+func (mw *Wrapper) metricSetMaxStartDelay(metricSet mb.MetricSet) time.Duration {
+	if delay, found := mw.Config().MetricSetMaxStartDelay(metricSet.Name()); found {
+		return delay
+	}
+	if mw.noPushStartDelay {
+		switch metricSet.(type) {
+		case mb.PushMetricSet, mb.PushMetricSetV2, mb.PushMetricSetV2WithContext, mb.PushMetricSetV3: //nolint:staticcheck // PushMetricSet is deprecated but not removed
+			return 0
+		}
+	}
+	return mw.maxStartDelay
+}
+
+func (mw *Wrapper) newMetricSetWrapper(metricSet mb.MetricSet) (*metricSetWrapper, error) {
+	period := mw.Config().MetricSetPeriod(metricSet.Name())
+	jitter, err := mw.Config().Jitter.Max(period)
+	if err != nil {
+		return nil, err
+	}
+	var procs *processors.Processors
+	if config := mw.Config().MetricSetProcessors(metricSet.Name()); len(config) > 0 {
+		procs, err = processors.New(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create processors of metricset %s: %w", metricSet.Name(), err)
+		}
+	}
+	host := metricSet.HostData().SanitizedURI
+	if host == "" {
+		host = metricSet.Host()
+	}
+	logger := mw.logger.With("metricset", metricSet.Name())
+	if host != "" {
+		logger = logger.With("host", host)
+	}
+	stats := getMetricSetStats(mw.Name(), metricSet.Name())
+	msw := &metricSetWrapper{
+		MetricSet:        metricSet,
+		module:           mw,
+		logger:           logger,
+		stats:            stats,
+		hostStats:        stats.getHostStats(host),
+		maxStartDelay:    mw.metricSetMaxStartDelay(metricSet),
+		period:           period,
+		jitter:           jitter,
+		timeout:          mw.Config().FetchTimeout,
+		schedule:         mw.Config().MetricSetSchedule(metricSet.Name()),
+		align:            mw.Config().AlignPeriod,
+		fetchOnStart:     mw.Config().FetchesOnStart(),
+		warmup:           mw.Config().WarmupFetch,
+		overlap:          mw.Config().Overlap,
+		breaker:          newCircuitBreaker(mw.Config().CircuitBreaker),
+		retry:            mw.Config().MetricSetRetry(metricSet.Name()),
+		timestampNanos:   mw.Config().MetricSetTimestampNanos(metricSet.Name()),
+		maxFailures:      mw.Config().MaxConsecutiveFailures,
+		sampler:          newSampler(mw.Config().SampleRate),
+		slowThreshold:    mw.Config().SlowFetchThreshold,
+		watchdog:         mw.Config().Watchdog,
+		hostsParallelism: mw.Config().HostsParallelism,
+		trigger:          make(chan struct{}, 1),
+		backpressure:     newBackpressure(metricSet.Metrics()),
+		processors:       procs,
+	}
+	msw.health = newHealth(mw.Config().Health, !msw.isPeriodic())
+	if mw.Config().LifecycleEvents {
+		msw.health.changed = func(from, to string) {
+			msw.publishState(stateChanged, from)
+		}
+	}
+	if reg := metricSet.Metrics(); reg != nil {
+		msw.fetchDuration = metrics.NewUniformSample(1024)
+		_ = adapter.NewGoMetrics(reg, "fetch_duration", adapter.Accept).
+			Register("histogram", metrics.NewHistogram(msw.fetchDuration))
+	}
+	return msw, nil
+}
+
 // Wrapper methods
 
 // Start starts the Module's MetricSet workers which are responsible for
@@ -121,128 +340,867 @@ func createWrapper(module mb.Module, metricSets []mb.MetricSet, options ...Optio
 // done channel is closed. When the done channel is closed all MetricSet workers
 // will stop and the returned output channel will be closed.
 //
-// The returned channel is buffered with a length one one. It must drained to
-// prevent blocking the operation of the MetricSets.
+// The returned channel is buffered with a length of one by default, see
+// WithOutputBufferSize. It must drained to prevent blocking the operation of
+// the MetricSets.
 //
 // Start should be called only once in the life of a Wrapper.
+//
+// Deprecated: Use StartWithContext, which also passes the values and deadline
+// of the context to the MetricSets.
 func (mw *Wrapper) Start(done <-chan struct{}) <-chan beat.Event {
-	debugf("Starting %s", mw)
+	ctx, cancel := context.WithCancel(context.Background())
+	out := mw.StartWithContext(ctx)
+	go func() {
+		defer cancel()
+		select {
+		case <-done:
+		case <-mw.stopped:
+		}
+	}()
+	return out
+}
+
+// StartWithContext starts the Module's MetricSet workers like Start, but the
+// workers run until ctx is done instead of until a channel is closed. The
+// contexts passed to the MetricSets are derived from ctx, so they carry its
+// values and deadline.
+//
+// StartWithContext should be called only once in the life of a Wrapper, and
+// not together with Start.
+func (mw *Wrapper) StartWithContext(ctx context.Context) <-chan beat.Event {
+	mw.logger.Debugf("Starting %s", mw)
+
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	bufferSize := mw.outputBufferSize
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+	mw.out = make(chan beat.Event, bufferSize)
 
-	out := make(chan beat.Event, 1)
+	// Stopping also happens when aborting, so ctx being done or the drain
+	// timeout in Stop expiring stop the workers immediately.
+	mw.abortCtx, mw.abort = context.WithCancel(ctx)
+	mw.stopCtx, mw.stop = context.WithCancel(mw.abortCtx)
+	mw.stopped = make(chan struct{})
 
 	// Start one worker per MetricSet + host combination.
-	var wg sync.WaitGroup
-	wg.Add(len(mw.metricSets))
 	for _, msw := range mw.metricSets {
-		go func(msw *metricSetWrapper) {
-			metricsPath := msw.ID()
-			registry := monitoring.GetNamespace("dataset").GetRegistry()
+		mw.startWorker(msw)
+	}
+
+	// Close the output channel when all writers to the channel have stopped.
+	go func() {
+		<-mw.stopCtx.Done()
+		mw.mu.Lock()
+		mw.stopping = true
+		mw.mu.Unlock()
+
+		mw.wg.Wait()
+		close(mw.out)
+		mw.abort()
+		mw.Cache().Clear()
+		close(mw.stopped)
+		mw.logger.Debugf("Stopped %s", mw)
+	}()
+
+	return mw.out
+}
+
+// reportOutput reports the fill level of the output channel, shared by all the
+// MetricSets of the module.
+func (mw *Wrapper) reportOutput(_ monitoring.Mode, V monitoring.Visitor) {
+	V.OnRegistryStart()
+	defer V.OnRegistryFinished()
+
+	monitoring.ReportInt(V, "queued", int64(len(mw.out)))
+	monitoring.ReportInt(V, "capacity", int64(cap(mw.out)))
+}
+
+// startWorker starts the goroutine running the given MetricSet. It must be
+// called with mw.mu held.
+func (mw *Wrapper) startWorker(msw *metricSetWrapper) {
+	var ctx context.Context
+	ctx, msw.cancel = context.WithCancel(mw.stopCtx)
 
-			defer registry.Remove(metricsPath)
-			defer releaseStats(msw.stats)
-			defer wg.Done()
-			defer msw.close()
+	mw.wg.Add(1)
+	go func() {
+		metricsPath := msw.ID()
+		registry := monitoring.GetNamespace("dataset").GetRegistry()
+
+		defer registry.Remove(metricsPath)
+		defer releaseStats(msw.stats, msw.hostStats)
+		defer mw.wg.Done()
+		defer unregisterHealth(msw)
+		defer msw.close()
+		defer msw.cancel()
+
+		registry.Add(metricsPath, msw.Metrics(), monitoring.Full)
+		registerHealth(msw)
+		monitoring.NewString(msw.Metrics(), "starttime").Set(common.Time(time.Now()).String())
+		monitoring.NewFunc(msw.Metrics(), "output", mw.reportOutput)
+
+		if msw.watchdog.Periods > 0 && msw.isPeriodic() {
+			go msw.watch(ctx)
+		}
 
-			registry.Add(metricsPath, msw.Metrics(), monitoring.Full)
-			monitoring.NewString(msw.Metrics(), "starttime").Set(common.Time(time.Now()).String())
+		// The goroutines of the worker are labeled so the watchdog can find
+		// their stacks.
+		labels := pprof.Labels(metricSetLabel, msw.ID())
+		pprof.Do(ctx, labels, func(ctx context.Context) {
+			msw.run(ctx, mw.abortCtx, mw.out)
+		})
+		if dropped := msw.dropped.Load(); dropped > 0 {
+			msw.logger.Warnf("Dropped %d events of metricset %s/%s for host '%s' that were being published when it was stopped",
+				dropped, mw.Name(), msw.Name(), msw.Host())
+		}
+	}()
+}
 
-			msw.run(done, out)
-		}(msw)
+// UpdateHosts updates the hosts of the module. Only the MetricSets of hosts
+// that are added or removed are started or stopped, the MetricSets of hosts
+// that were already configured keep running. MetricSets registered with
+// mb.WithMultipleHosts are not affected, they keep fetching the hosts of the
+// module configuration. It can only be used with Wrappers created by
+// NewWrapper.
+func (mw *Wrapper) UpdateHosts(hosts []string) error {
+	if mw.registry == nil {
+		return errors.New("hosts can only be updated in modules created from their configuration")
+	}
+	if len(hosts) == 0 {
+		hosts = []string{""}
 	}
 
-	// Close the output channel when all writers to the channel have stopped.
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	if mw.stopping {
+		return errors.New("module is stopped")
+	}
+
+	var kept, removed []*metricSetWrapper
+	keptHosts := map[string]bool{}
+	for _, msw := range mw.metricSets {
+		if msw.Registration().MultipleHosts {
+			// Not bound to a single host.
+			kept = append(kept, msw)
+			continue
+		}
+		host, found := msw.matchHost(hosts)
+		if !found {
+			removed = append(removed, msw)
+			continue
+		}
+		kept = append(kept, msw)
+		keptHosts[host] = true
+	}
+
+	var added []string
+	for _, host := range hosts {
+		if !keptHosts[host] {
+			added = append(added, host)
+		}
+	}
+
+	var addedWrappers []*metricSetWrapper
+	if len(added) > 0 {
+		metricSets, err := mb.NewMetricSetsForHosts(mw.registry, mw.Module, added)
+		if err != nil {
+			return fmt.Errorf("failed to create metricsets for new hosts of module '%s': %w", mw.Name(), err)
+		}
+		for _, metricSet := range metricSets {
+			msw, err := mw.newMetricSetWrapper(metricSet)
+			if err != nil {
+				return err
+			}
+			addedWrappers = append(addedWrappers, msw)
+		}
+	}
+
+	started := mw.out != nil
+	for _, msw := range removed {
+		msw.logger.Debugf("Removing %s", msw)
+		if started {
+			msw.cancel()
+		} else {
+			releaseStats(msw.stats, msw.hostStats)
+			if err := msw.close(); err != nil {
+				msw.logger.Debugf("Error closing %s: %v", msw, err)
+			}
+		}
+	}
+	for _, msw := range addedWrappers {
+		msw.logger.Debugf("Adding %s", msw)
+		if pauser, ok := msw.MetricSet.(mb.Pauser); ok && mw.paused.Load() {
+			pauser.Pause()
+		}
+		if started {
+			mw.startWorker(msw)
+		}
+	}
+
+	mw.metricSets = append(kept, addedWrappers...)
+	return nil
+}
+
+// Stop gracefully stops the MetricSet workers started by Start. No new fetches
+// are scheduled, and the fetches in progress are given until ctx is done to
+// finish and write their events to the output channel, which must be drained
+// meanwhile. Stop returns nil once all the workers have stopped and the output
+// channel has been closed. If ctx is done first, the pending fetches are
+// aborted, their events are discarded, and ctx.Err() is returned without
+// waiting for them, so fetches that ignore their context don't block the
+// shutdown. The output channel is then closed when they return.
+func (mw *Wrapper) Stop(ctx context.Context) error {
+	mw.mu.Lock()
+	stop, abort, stopped := mw.stop, mw.abort, mw.stopped
+	mw.mu.Unlock()
+
+	if stopped == nil {
+		// Not started.
+		return nil
+	}
+
+	stop()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		mw.logger.Debugf("Aborting in-flight fetches of %s: %v", mw, ctx.Err())
+		abort()
+		return ctx.Err()
+	}
+}
+
+// TriggerFetch requests an immediate fetch of the MetricSet with the given
+// name, for all its hosts, in addition to the ones periodically scheduled. The
+// fetch is done asynchronously by the MetricSet workers, using the same
+// reporter and stats as the scheduled fetches. Requests done while a fetch is
+// already requested are ignored. An error is returned if the module has no
+// periodic MetricSet with this name.
+func (mw *Wrapper) TriggerFetch(name string) error {
+	found := false
+	for _, msw := range mw.MetricSets() {
+		if !strings.EqualFold(msw.Name(), name) || !msw.isPeriodic() {
+			continue
+		}
+		found = true
+		select {
+		case msw.trigger <- struct{}{}:
+		default:
+			// A fetch is already requested.
+		}
+	}
+	if !found {
+		return fmt.Errorf("module '%s' has no periodic metricset '%s'", mw.Name(), name)
+	}
+	return nil
+}
+
+// Fetch fetches each of the MetricSets of the module once, as a scheduled
+// fetch would do it, and returns the reported events, including the error
+// events. It can be called repeatedly, as when benchmarking the MetricSets,
+// and Close must be called when done. An error is returned, without fetching,
+// if the Wrapper has been started or if any of the MetricSets is not periodic.
+func (mw *Wrapper) Fetch(ctx context.Context) ([]beat.Event, error) {
+	mw.mu.Lock()
+	started := mw.out != nil
+	metricSets := append([]*metricSetWrapper(nil), mw.metricSets...)
+	mw.mu.Unlock()
+	if started {
+		return nil, fmt.Errorf("module '%s' is already started", mw.Name())
+	}
+	for _, msw := range metricSets {
+		if !msw.isPeriodic() {
+			return nil, fmt.Errorf("metricset '%s/%s' pushes its events, it can't be fetched once", mw.Name(), msw.Name())
+		}
+	}
+
+	out := make(chan beat.Event)
+	done := make(chan struct{})
+	var events []beat.Event
 	go func() {
-		wg.Wait()
-		close(out)
-		debugf("Stopped %s", mw)
+		defer close(done)
+		for event := range out {
+			events = append(events, event)
+		}
 	}()
+	for _, msw := range metricSets {
+		msw.fetchOnce(ctx, out)
+	}
+	close(out)
+	<-done
+	return events, nil
+}
 
-	return out
+// Close closes the MetricSets of a Wrapper fetched with Fetch. It must not be
+// used with Wrappers that have been started, they are closed by Stop.
+func (mw *Wrapper) Close() {
+	for _, msw := range mw.MetricSets() {
+		msw.stopOnce()
+	}
+	mw.Cache().Clear()
+}
+
+// FetchOnce fetches each of the MetricSets of the module once with Fetch, and
+// closes them. It is intended for one-shot fetches like the ones of the fetch
+// command, the Wrapper can't be used afterwards.
+func (mw *Wrapper) FetchOnce(ctx context.Context) ([]beat.Event, error) {
+	events, err := mw.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mw.Close()
+	return events, nil
+}
+
+// Pause suspends the periodic fetches of the module's MetricSets, the fetches
+// that are due while paused are skipped. Push MetricSets implementing
+// mb.Pauser are notified. Connections and other resources are kept open, so
+// fetching can be continued with Resume.
+func (mw *Wrapper) Pause() {
+	if mw.paused.Swap(true) {
+		return
+	}
+	mw.logger.Debugf("Pausing %s", mw)
+	for _, msw := range mw.MetricSets() {
+		if pauser, ok := msw.MetricSet.(mb.Pauser); ok {
+			pauser.Pause()
+		}
+	}
+}
+
+// Resume continues fetching the module's MetricSets after a call to Pause.
+func (mw *Wrapper) Resume() {
+	if !mw.paused.Swap(false) {
+		return
+	}
+	mw.logger.Debugf("Resuming %s", mw)
+	for _, msw := range mw.MetricSets() {
+		if pauser, ok := msw.MetricSet.(mb.Pauser); ok {
+			pauser.Resume()
+		}
+	}
 }
 
 // String returns a string representation of Wrapper.
 func (mw *Wrapper) String() string {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
 	return fmt.Sprintf("Wrapper[name=%s, len(metricSetWrappers)=%d]",
 		mw.Name(), len(mw.metricSets))
 }
 
+// Cache returns the cache shared by the MetricSets of the module. It is
+// cleared when the module is stopped.
+func (mw *Wrapper) Cache() *mb.ModuleCache {
+	return mb.ModuleCacheOf(mw.Module)
+}
+
 // MetricSets return the list of metricsets of the module
 func (mw *Wrapper) MetricSets() []*metricSetWrapper {
-	return mw.metricSets
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	return append([]*metricSetWrapper(nil), mw.metricSets...)
 }
 
 // metricSetWrapper methods
 
-func (msw *metricSetWrapper) run(done <-chan struct{}, out chan<- beat.Event) {
-	defer logp.Recover(fmt.Sprintf("recovered from panic while fetching "+
+// isPeriodic returns true if the MetricSet is fetched periodically, instead of
+// pushing its events.
+func (msw *metricSetWrapper) isPeriodic() bool {
+	switch msw.MetricSet.(type) {
+	case mb.ReportingMetricSet, mb.ReportingMetricSetV2, mb.ReportingMetricSetV2Error, mb.ReportingMetricSetV2WithContext, mb.ReportingMetricSetV2Batch, mb.ReportingMetricSetV2Stream: //nolint:staticcheck // ReportingMetricSet is deprecated but not removed
+		return true
+	default:
+		return false
+	}
+}
+
+// matchHost returns the host in hosts that the MetricSet fetches from, if any.
+// Hosts are compared as they are configured, including their fallback URLs, as
+// the MetricSet was created by parsing its configured host.
+func (msw *metricSetWrapper) matchHost(hosts []string) (string, bool) {
+	configured := msw.Host()
+	if ms, ok := msw.MetricSet.(interface{ ConfiguredHost() string }); ok {
+		configured = ms.ConfiguredHost()
+	}
+	for _, host := range hosts {
+		if host == configured {
+			return host, true
+		}
+	}
+	return "", false
+}
+
+// run runs the MetricSet until ctx is done. In-flight fetches can continue
+// publishing to out until abortCtx is done.
+func (msw *metricSetWrapper) run(ctx, abortCtx context.Context, out chan<- beat.Event) {
+	defer msw.logger.Recover(fmt.Sprintf("recovered from panic while fetching "+
 		"'%s/%s' for host '%s'", msw.module.Name(), msw.Name(), msw.Host()))
 
 	// Start each metricset randomly over a period of MaxDelayPeriod.
-	if msw.module.maxStartDelay > 0 {
-		delay := time.Duration(rand.Int63n(int64(msw.module.maxStartDelay)))
-		debugf("%v/%v will start after %v", msw.module.Name(), msw.Name(), delay)
+	if msw.maxStartDelay > 0 {
+		delay := time.Duration(rand.Int63n(int64(msw.maxStartDelay)))
+		msw.logger.Debugf("%v/%v will start after %v", msw.module.Name(), msw.Name(), delay)
 		select {
-		case <-done:
+		case <-ctx.Done():
 			return
 		case <-time.After(delay):
 		}
 	}
 
-	debugf("Starting %s", msw)
-	defer debugf("Stopped %s", msw)
+	msw.logger.Debugf("Starting %s", msw)
+	defer msw.logger.Debugf("Stopped %s", msw)
+
+	if msw.module.Config().LifecycleEvents {
+		msw.publishState(stateStarted, "")
+		defer msw.publishState(stateStopped, "")
+	}
 
 	// Events and errors are reported through this.
 	reporter := &eventReporter{
-		msw:  msw,
-		out:  out,
-		done: done,
+		msw:   msw,
+		out:   out,
+		ctx:   ctx,
+		abort: abortCtx.Done(),
+	}
+
+	if lifecycle, ok := msw.MetricSet.(mb.Lifecycle); ok {
+		if err := lifecycle.OnStart(ctx); err != nil {
+			reporter.V2().Error(fmt.Errorf("failed to start metricset: %w", err))
+			msw.logger.Errorf("Error starting metricset %s.%s: %s", msw.module.Name(), msw.Name(), err)
+		}
+		defer func() {
+			if err := lifecycle.OnStop(); err != nil {
+				msw.logger.Errorf("Error stopping metricset %s.%s: %s", msw.module.Name(), msw.Name(), err)
+			}
+		}()
 	}
 
 	switch ms := msw.MetricSet.(type) {
 	case mb.PushMetricSet: //nolint:staticcheck // PushMetricSet is deprecated but not removed
+		msw.warnDeprecated("mb.PushMetricSet", "mb.PushMetricSetV3")
 		ms.Run(reporter.V1())
 	case mb.PushMetricSetV2:
 		ms.Run(reporter.V2())
 	case mb.PushMetricSetV2WithContext:
-		ms.Run(&channelContext{done}, reporter.V2())
-	case mb.ReportingMetricSet, mb.ReportingMetricSetV2, mb.ReportingMetricSetV2Error, mb.ReportingMetricSetV2WithContext: //nolint:staticcheck // ReportingMetricSet is deprecated but not removed
-		msw.startPeriodicFetching(&channelContext{done}, reporter)
+		ms.Run(ctx, reporter.V2())
+	case mb.PushMetricSetV3:
+		msw.runPush(ctx, ms, reporter)
+	case mb.ReportingMetricSet, mb.ReportingMetricSetV2, mb.ReportingMetricSetV2Error, mb.ReportingMetricSetV2WithContext, mb.ReportingMetricSetV2Batch, mb.ReportingMetricSetV2Stream: //nolint:staticcheck // ReportingMetricSet is deprecated but not removed
+		if scheduler := msw.scheduler(); scheduler != nil {
+			msw.runScheduler(abortCtx, reporter, scheduler)
+		} else {
+			msw.startPeriodicFetching(abortCtx, reporter)
+		}
 	default:
 		// Earlier startup stages prevent this from happening.
-		logp.Err("MetricSet '%s/%s' does not implement an event producing interface",
+		msw.logger.Errorf("MetricSet '%s/%s' does not implement an event producing interface",
 			msw.Module().Name(), msw.Name())
 	}
 }
 
-// startPeriodicFetching performs an immediate fetch for the MetricSet then it
-// begins a continuous timer scheduled loop to fetch data. To stop the loop the
-// done channel should be closed.
+// fetchOnce fetches the MetricSet once, writing the events to out. The
+// MetricSet is started before its first fetch if it implements mb.Lifecycle.
+func (msw *metricSetWrapper) fetchOnce(ctx context.Context, out chan<- beat.Event) {
+	reporter := &eventReporter{
+		msw:   msw,
+		out:   out,
+		ctx:   ctx,
+		abort: ctx.Done(),
+	}
+
+	if lifecycle, ok := msw.MetricSet.(mb.Lifecycle); ok && !msw.fetchStarted {
+		if err := lifecycle.OnStart(ctx); err != nil {
+			reporter.V2().Error(fmt.Errorf("failed to start metricset: %w", err))
+			return
+		}
+		msw.fetchStarted = true
+	}
+
+	msw.fetch(ctx, reporter)
+}
+
+// stopOnce stops and closes the MetricSet after it has been fetched with
+// fetchOnce.
+func (msw *metricSetWrapper) stopOnce() {
+	defer releaseStats(msw.stats, msw.hostStats)
+	if lifecycle, ok := msw.MetricSet.(mb.Lifecycle); ok && msw.fetchStarted {
+		if err := lifecycle.OnStop(); err != nil {
+			msw.logger.Errorf("Error stopping metricset %s.%s: %s", msw.module.Name(), msw.Name(), err)
+		}
+	}
+	if err := msw.close(); err != nil {
+		msw.logger.Debugf("Error closing %s: %v", msw, err)
+	}
+}
+
+// runPush runs the PushMetricSetV3 until ctx is done. When Run fails, the
+// error is reported and Run is invoked again after a backoff delay, which is
+// reset if the failed run lasted longer than the maximum delay.
+func (msw *metricSetWrapper) runPush(ctx context.Context, ms mb.PushMetricSetV3, reporter reporter) {
+	config := msw.module.Config().RestartBackoff
+	if config.Init <= 0 {
+		config.Init = defaultRestartBackoffInit
+	}
+	if config.Max <= 0 {
+		config.Max = defaultRestartBackoffMax
+	}
+	if config.Max < config.Init {
+		config.Max = config.Init
+	}
+	b := backoff.NewExpBackoff(ctx.Done(), config.Init, config.Max)
+
+	for {
+		start := time.Now()
+		err := runPushOnce(ctx, ms, reporter.V2())
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			msw.logger.Debugf("%s returned without error, it won't be restarted", msw)
+			return
+		}
+
+		reporter.V2().Error(err)
+		msw.logger.Errorf("Error running metricset %s.%s, restarting it: %s", msw.module.Name(), msw.Name(), err)
+		msw.stats.restarts.Add(1)
+		if time.Since(start) > config.Max {
+			b.Reset()
+		}
+		if !b.Wait() {
+			return
+		}
+	}
+}
+
+// runPushOnce invokes Run of the PushMetricSetV3, returning panics as errors.
+func runPushOnce(ctx context.Context, ms mb.PushMetricSetV3, r mb.ReporterV2) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return ms.Run(ctx, r)
+}
+
+// startPeriodicFetching performs an immediate fetch for the MetricSet, unless
+// disabled by fetch_on_start, then it begins a continuous timer scheduled loop
+// to fetch data. To stop the loop the done channel should be closed.
 func (msw *metricSetWrapper) startPeriodicFetching(ctx context.Context, reporter reporter) {
 	// Indicate that it has been started as periodic fetcher
 	msw.periodic = true
 
 	// Fetch immediately.
+	switch {
+	case msw.warmup:
+		msw.warmUp(ctx, reporter)
+	case msw.fetchOnStart:
+		msw.fetch(ctx, reporter)
+	}
+
+	if !msw.align {
+		// Start timer for future fetches.
+		msw.runScheduler(ctx, reporter, newTickerScheduler(msw.period))
+		return
+	}
+
+	// Wait until the next multiple of the period, so the following fetches
+	// happen at the same wall clock times on every host.
+	if !sleep(reporter.Context(), alignDelay(time.Now(), msw.period)) {
+		return
+	}
+	// The ticker is started at the aligned time, before fetching, so the
+	// following fetches stay aligned whatever the duration of this one.
+	due := time.Now()
+	scheduler := newTickerScheduler(msw.period)
+	if msw.waitJitter(reporter.Context()) {
+		msw.fetchPeriodic(ctx, reporter, due, scheduler.Next())
+	}
+	msw.runScheduler(ctx, reporter, scheduler)
+}
+
+// fetchPeriodic performs a periodic fetch that was due at the given time, and
+// handles the fetches that become due while it runs according to the overlap
+// policy. Ticks for the following fetches are received from ticks.
+func (msw *metricSetWrapper) fetchPeriodic(ctx context.Context, reporter reporter, due time.Time, ticks <-chan time.Time) {
+	if msw.overlap == mb.OverlapCancelPrevious {
+		// Cancel the fetch when the next one is due, so they never overlap.
+		fetchCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		timer := time.AfterFunc(time.Until(due.Add(msw.period)), cancel)
+		defer timer.Stop()
+		msw.fetch(fetchCtx, reporter)
+		return
+	}
+
 	msw.fetch(ctx, reporter)
 
-	// Start timer for future fetches.
-	t := time.NewTicker(msw.Module().Config().Period)
-	defer t.Stop()
+	missed := int64(time.Since(due) / msw.period)
+	if missed == 0 {
+		return
+	}
+	switch msw.overlap {
+	case mb.OverlapSkip:
+		// Discard the tick buffered by the ticker while fetching.
+		select {
+		case <-ticks:
+		default:
+		}
+		msw.stats.skipped.Add(missed)
+	default:
+		// The ticker keeps a single tick while fetching, the next fetch runs
+		// immediately and the rest are dropped.
+		msw.stats.skipped.Add(missed - 1)
+	}
+	msw.logger.Debugf("%s took longer than its period, %d fetches were due while it was running", msw, missed)
+}
+
+// scheduler returns the Scheduler of a MetricSet that is not fetched
+// periodically, because a SchedulerFactory or a cron schedule is configured
+// for it. It returns nil for periodic MetricSets.
+func (msw *metricSetWrapper) scheduler() Scheduler {
+	if factory := msw.module.schedulerFactory; factory != nil {
+		if scheduler := factory(msw.MetricSet, msw.period); scheduler != nil {
+			return scheduler
+		}
+	}
+	if msw.schedule != nil {
+		return newCronScheduler(msw.schedule)
+	}
+	return nil
+}
+
+// runScheduler fetches the MetricSet each time the scheduler fires, until
+// the context of the reporter is done. Unlike startPeriodicFetching,
+// there is no immediate fetch. Fetches can also be requested out of cycle
+// with TriggerFetch.
+func (msw *metricSetWrapper) runScheduler(ctx context.Context, reporter reporter, scheduler Scheduler) {
+	defer scheduler.Done()
+	msw.health.scheduled(scheduler)
+	defer msw.health.scheduled(nil)
 	for {
 		select {
-		case <-reporter.V2().Done():
+		case <-reporter.Context().Done():
 			return
-		case <-t.C:
+		case due, ok := <-scheduler.Next():
+			if !ok {
+				msw.logger.Debugf("%s has no more scheduled fetches", msw)
+				<-reporter.Context().Done()
+				return
+			}
+			// The select picks any ready case, don't fetch if the MetricSet
+			// was stopped at the same time.
+			if !msw.waitJitter(reporter.Context()) || reporter.Context().Err() != nil {
+				return
+			}
+			if msw.periodic {
+				msw.fetchPeriodic(ctx, reporter, due, scheduler.Next())
+			} else {
+				msw.fetch(ctx, reporter)
+			}
+		case <-msw.trigger:
 			msw.fetch(ctx, reporter)
 		}
 	}
 }
 
+// warmUp fetches the MetricSet discarding the reported events and errors, so
+// MetricSets that compute their values from the previous fetch have a
+// previous sample when the first events are published.
+func (msw *metricSetWrapper) warmUp(ctx context.Context, reporter reporter) {
+	if !msw.module.fetchLimiter.acquire(reporter.Context()) {
+		return
+	}
+	defer msw.module.fetchLimiter.release()
+
+	msw.logger.Debugf("Warming up %s", msw)
+	discarded := newBufferedReporter(reporter, msw.module.Name())
+	msw.fetchMetricSet(ctx, discarded)
+	if discarded.FetchFailed() {
+		msw.logger.Debugf("Warm-up fetch of %s failed: %v", msw, errors.Join(discarded.errs...))
+	}
+}
+
+// waitJitter waits for a random delay bounded by the configured jitter, so
+// that metricsets of many instances don't fetch at exactly the same time. It
+// returns false if ctx is done while waiting.
+func (msw *metricSetWrapper) waitJitter(ctx context.Context) bool {
+	if msw.jitter <= 0 {
+		return true
+	}
+
+	return sleep(ctx, time.Duration(rand.Int63n(int64(msw.jitter))))
+}
+
 // fetch invokes the appropriate Fetch method for the MetricSet and publishes
 // the result using the publisher client. This method will recover from panics
 // and log a stack track if one occurs.
 func (msw *metricSetWrapper) fetch(ctx context.Context, reporter reporter) {
+	if msw.module.paused.Load() {
+		msw.logger.Debugf("Skipping fetch of %s, module is paused", msw)
+		return
+	}
+
+	if !msw.breaker.allow(time.Now()) {
+		msw.logger.Debugf("Skipping fetch of %s, circuit breaker is open", msw)
+		return
+	}
+
+	slot, ok := msw.module.fetchLimiter.acquireSlot(reporter.Context())
+	if !ok {
+		return
+	}
+	defer slot.release()
+
+	ctx, endSpan := msw.startFetchSpan(ctx)
+	defer func() { endSpan(reporter.FetchError()) }()
+
+	start := time.Now()
+	msw.fetchStart.Store(start.UnixNano())
+	defer msw.fetchStart.Store(0)
+
+	if msw.sampler.publish() {
+		msw.fetchWithRetries(ctx, slot, reporter)
+	} else {
+		msw.fetchWithRetries(ctx, slot, &sampledOutReporter{parent: reporter, module: msw.module.Name()})
+	}
+	elapsed := time.Since(start)
+	if msw.fetchDuration != nil {
+		msw.fetchDuration.Update(int64(elapsed))
+	}
+	msw.health.fetchDuration(elapsed)
+	if msw.slowThreshold > 0 && elapsed > msw.slowThreshold {
+		msw.stats.slow.Add(1)
+		msw.logger.Warnw("Slow fetch", "duration", elapsed, "threshold", msw.slowThreshold)
+	}
+	msw.sampler.record(reporter.FetchFailed())
+
+	if msw.breaker.record(reporter.FetchFailed(), time.Now()) {
+		err := fmt.Errorf("circuit breaker opened after %d consecutive failures, fetches are suspended for %v",
+			msw.breaker.failures, msw.breaker.cooldown)
+		reporter.V2().Error(err)
+		msw.logger.Errorf("Error fetching data for metricset %s.%s: %s", msw.module.Name(), msw.Name(), err)
+	}
+
+	msw.recordFailures(reporter)
+}
+
+// startFetchSpan starts a span for a fetch of the MetricSet, if the module has
+// a tracer. The span is added to the returned context, so MetricSets can add
+// child spans to it. The returned function ends the span with the error of
+// the fetch, if any.
+func (msw *metricSetWrapper) startFetchSpan(ctx context.Context) (context.Context, func(err error)) {
+	tracer := msw.module.tracer
+	if tracer == nil {
+		return ctx, func(error) {}
+	}
+
+	attributes := []attribute.KeyValue{
+		attribute.String("metricbeat.module", msw.module.Name()),
+		attribute.String("metricbeat.metricset", msw.Name()),
+	}
+	if host := msw.Host(); host != "" {
+		attributes = append(attributes, attribute.String("metricbeat.host", host))
+	}
+	ctx, span := tracer.Start(ctx, "fetch "+msw.module.Name()+"/"+msw.Name(),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attributes...),
+	)
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else {
+			span.SetStatus(codes.Ok, "")
+		}
+		span.End()
+	}
+}
+
+// recordFailures counts the consecutive failed fetches of the MetricSet,
+// reports them in the stats, and stops the MetricSet for good when they reach
+// max_consecutive_failures.
+func (msw *metricSetWrapper) recordFailures(reporter reporter) {
+	msw.health.fetched(reporter.FetchFailed())
+	if !reporter.FetchFailed() {
+		msw.failures = 0
+		msw.stats.consecutiveFailures.Set(0)
+		if msw.hostStats != nil {
+			msw.hostStats.consecutiveFailures.Set(0)
+		}
+		return
+	}
+
+	msw.failures++
+	msw.stats.consecutiveFailures.Set(int64(msw.failures))
+	if msw.hostStats != nil {
+		msw.hostStats.consecutiveFailures.Set(int64(msw.failures))
+	}
+	if msw.maxFailures <= 0 || msw.failures < msw.maxFailures {
+		return
+	}
+	err := fmt.Errorf("metricset stopped after %d consecutive failures", msw.failures)
+	reporter.V2().Error(err)
+	msw.logger.Errorf("Error fetching data for metricset %s.%s: %s", msw.module.Name(), msw.Name(), err)
+	if msw.cancel != nil {
+		msw.cancel()
+	}
+}
+
+// fetchWithRetries fetches the MetricSet, retrying failed attempts according
+// to the retry configuration. Only the events of the successful attempt, or of
+// the last one, are reported. The fetch slot is released while waiting between
+// attempts.
+func (msw *metricSetWrapper) fetchWithRetries(ctx context.Context, slot *fetchSlot, reporter reporter) {
+	for attempt := 1; attempt < msw.retry.Attempts; attempt++ {
+		buffered := newBufferedReporter(reporter, msw.module.Name())
+		msw.fetchMetricSet(ctx, buffered)
+		if !buffered.retryable(msw.retry) {
+			buffered.flush()
+			return
+		}
+
+		msw.logger.Debugf("Retrying fetch of %s after attempt %d failed: %v", msw, attempt, errors.Join(buffered.errs...))
+		if !slot.wait(reporter.Context(), msw.retry.Delay) {
+			return
+		}
+	}
+
+	msw.fetchMetricSet(ctx, reporter)
+}
+
+// warnDeprecated logs that the MetricSet implements a deprecated interface
+// instead of its replacement, once per MetricSet.
+func (msw *metricSetWrapper) warnDeprecated(iface, replacement string) {
+	key := msw.module.Name() + "/" + msw.Name()
+	if _, logged := deprecationWarnings.LoadOrStore(key, struct{}{}); logged {
+		return
+	}
+	msw.logger.Warnw(
+		fmt.Sprintf("Metricset %s implements the deprecated interface %s, it should be migrated to %s", key, iface, replacement),
+		"deprecated_interface", iface,
+		"replacement", replacement,
+	)
+}
+
+// fetchMetricSet calls the Fetch method of the MetricSet with the reporter
+// matching its interface.
+func (msw *metricSetWrapper) fetchMetricSet(ctx context.Context, reporter reporter) {
+	if fetcher, ok := msw.MetricSet.(mb.HostFetcher); ok && msw.Registration().MultipleHosts {
+		fetchCtx, cancel := msw.fetchContext(ctx)
+		defer cancel()
+		reporter.StartFetchTimer()
+		mb.FetchHosts(fetchCtx, msw.Module().Config().Hosts, msw.hostsParallelism, reporter.V2(), fetcher.FetchHost)
+		return
+	}
+
 	switch fetcher := msw.MetricSet.(type) {
 	case mb.ReportingMetricSet: //nolint:staticcheck // ReportingMetricSet is deprecated but not removed
+		msw.warnDeprecated("mb.ReportingMetricSet", "mb.ReportingMetricSetV2Error")
 		reporter.StartFetchTimer()
 		fetcher.Fetch(reporter.V1())
 	case mb.ReportingMetricSetV2:
@@ -253,23 +1211,71 @@ func (msw *metricSetWrapper) fetch(ctx context.Context, reporter reporter) {
 		err := fetcher.Fetch(reporter.V2())
 		if err != nil {
 			reporter.V2().Error(err)
-			logp.Err("Error fetching data for metricset %s.%s: %s", msw.module.Name(), msw.Name(), err)
+			msw.logger.Errorf("Error fetching data for metricset %s.%s: %s", msw.module.Name(), msw.Name(), err)
 		}
 	case mb.ReportingMetricSetV2WithContext:
+		fetchCtx, cancel := msw.fetchContext(ctx)
+		defer cancel()
+		reporter.StartFetchTimer()
+		err := fetcher.Fetch(fetchCtx, reporter.V2())
+		if errors.Is(fetchCtx.Err(), context.DeadlineExceeded) {
+			msw.stats.timeouts.Add(1)
+		}
+		if err != nil {
+			reporter.V2().Error(err)
+			msw.logger.Errorf("Error fetching data for metricset %s.%s: %s", msw.module.Name(), msw.Name(), err)
+		}
+	case mb.ReportingMetricSetV2Batch:
+		fetchCtx, cancel := msw.fetchContext(ctx)
+		defer cancel()
+		reporter.StartFetchTimer()
+		events, err := fetcher.Fetch(fetchCtx)
+		if errors.Is(fetchCtx.Err(), context.DeadlineExceeded) {
+			msw.stats.timeouts.Add(1)
+		}
+		// The events are published even if the fetch failed, as partial
+		// results.
+		if !reporter.Events(events) {
+			return
+		}
+		if err != nil {
+			reporter.V2().Error(err)
+			msw.logger.Errorf("Error fetching data for metricset %s.%s: %s", msw.module.Name(), msw.Name(), err)
+		}
+	case mb.ReportingMetricSetV2Stream:
+		fetchCtx, cancel := msw.fetchContext(ctx)
+		defer cancel()
 		reporter.StartFetchTimer()
-		err := fetcher.Fetch(ctx, reporter.V2())
+		err := fetcher.Fetch(fetchCtx, reporter.V2().Event)
+		if errors.Is(fetchCtx.Err(), context.DeadlineExceeded) {
+			msw.stats.timeouts.Add(1)
+		}
 		if err != nil {
 			reporter.V2().Error(err)
-			logp.Err("Error fetching data for metricset %s.%s: %s", msw.module.Name(), msw.Name(), err)
+			msw.logger.Errorf("Error fetching data for metricset %s.%s: %s", msw.module.Name(), msw.Name(), err)
 		}
 	default:
 		panic(fmt.Sprintf("unexpected fetcher type for %v", msw))
 	}
 }
 
-// close closes the underlying MetricSet if it implements the mb.Closer
-// interface.
+// fetchContext returns the context passed to a single fetch. It is cancelled
+// when the configured fetch_timeout expires, if any.
+func (msw *metricSetWrapper) fetchContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if msw.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, msw.timeout)
+}
+
+// close closes the processors of the MetricSet, and the underlying MetricSet
+// if it implements the mb.Closer interface.
 func (msw *metricSetWrapper) close() error {
+	if msw.processors != nil {
+		if err := msw.processors.Close(); err != nil {
+			msw.logger.Debugf("Error closing the processors of %s: %v", msw, err)
+		}
+	}
 	if closer, ok := msw.MetricSet.(mb.Closer); ok {
 		return closer.Close()
 	}
@@ -284,14 +1290,26 @@ func (msw *metricSetWrapper) String() string {
 
 func (msw *metricSetWrapper) Test(d testing.Driver) {
 	d.Run(msw.Name(), func(d testing.Driver) {
+		msw.testHosts(d)
+
 		events := make(chan beat.Event, 1)
-		done := receiveOneEvent(d, events, msw.module.maxStartDelay+5*time.Second)
-		msw.run(done, events)
+		done := receiveOneEvent(d, events, msw.maxStartDelay+5*time.Second)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			<-done
+			cancel()
+		}()
+		msw.run(ctx, ctx, events)
 	})
 }
 
 type reporter interface {
+	Context() context.Context
 	StartFetchTimer()
+	FetchFailed() bool
+	FetchError() error
+	Events(events []mb.Event) bool
 	V1() mb.PushReporter //nolint:staticcheck // PushReporter is deprecated but not removed
 	V2() mb.PushReporterV2
 }
@@ -301,35 +1319,37 @@ type reporter interface {
 // with some additional metadata.
 type eventReporter struct {
 	msw   *metricSetWrapper
-	done  <-chan struct{}
+	ctx   context.Context // Done when the MetricSet must stop.
+	abort <-chan struct{} // Closed when events can no longer be written to out.
 	out   chan<- beat.Event
 	start time.Time // Start time of the current fetch (or zero for push sources).
+
+	failed bool  // Set to true if the current fetch reported an error.
+	err    error // Last error reported by the current fetch.
 }
 
+// Context returns the context of the MetricSet, done when it must stop.
+func (r *eventReporter) Context() context.Context { return r.ctx }
+
 // startFetchTimer demarcates the start of a new fetch. The elapsed time of a
 // fetch is computed based on the time of this call.
-func (r *eventReporter) StartFetchTimer() { r.start = time.Now() }
-func (r *eventReporter) V1() mb.PushReporter { //nolint:staticcheck // PushReporter is deprecated but not removed
-	return reporterV1{v2: r.V2(), module: r.msw.module.Name()}
+func (r *eventReporter) StartFetchTimer() {
+	r.start = time.Now()
+	r.failed = false
+	r.err = nil
 }
-func (r *eventReporter) V2() mb.PushReporterV2 { return reporterV2{r} }
 
-// channelContext implements context.Context by wrapping a channel
-type channelContext struct {
-	done <-chan struct{}
-}
+// FetchFailed returns true if an error was reported since the start of the
+// current fetch.
+func (r *eventReporter) FetchFailed() bool { return r.failed }
 
-func (r *channelContext) Deadline() (time.Time, bool) { return time.Time{}, false }
-func (r *channelContext) Done() <-chan struct{}       { return r.done }
-func (r *channelContext) Err() error {
-	select {
-	case <-r.done:
-		return context.Canceled
-	default:
-		return nil
-	}
+// FetchError returns the last error reported since the start of the current
+// fetch, or nil if there is none.
+func (r *eventReporter) FetchError() error { return r.err }
+func (r *eventReporter) V1() mb.PushReporter { //nolint:staticcheck // PushReporter is deprecated but not removed
+	return reporterV1{v2: r.V2(), module: r.msw.module.Name()}
 }
-func (r *channelContext) Value(key interface{}) interface{} { return nil }
+func (r *eventReporter) V2() mb.PushReporterV2 { return reporterV2{r} }
 
 // reporterV1 wraps V2 to provide a v1 interface.
 type reporterV1 struct {
@@ -352,48 +1372,152 @@ type reporterV2 struct {
 	*eventReporter
 }
 
-func (r reporterV2) Done() <-chan struct{} { return r.done }
+func (r reporterV2) Done() <-chan struct{} { return r.ctx.Done() }
 func (r reporterV2) Error(err error) bool  { return r.Event(mb.Event{Error: err}) }
 func (r reporterV2) Event(event mb.Event) bool {
-	if event.Took == 0 && !r.start.IsZero() {
-		event.Took = time.Since(r.start)
+	return r.publish(r.enrichment(), event)
+}
+
+// Events publishes the events of a whole fetch, computing the fields added to
+// them once. It returns false if the events can no longer be published.
+func (r *eventReporter) Events(events []mb.Event) bool {
+	e := r.enrichment()
+	for _, event := range events {
+		if !r.publish(e, event) {
+			return false
+		}
+	}
+	return true
+}
+
+// enrichment contains the fields added to the events that don't set them.
+type enrichment struct {
+	took      time.Duration
+	period    time.Duration // Always set, if not zero.
+	timestamp time.Time
+	host      string
+	namespace string
+}
+
+func (r *eventReporter) enrichment() enrichment {
+	e := enrichment{
+		host:      r.msw.HostData().SanitizedURI,
+		namespace: r.msw.Registration().Namespace,
+	}
+	if !r.start.IsZero() {
+		e.took = time.Since(r.start)
+		e.timestamp = r.start
+	} else {
+		e.timestamp = time.Now().UTC()
 	}
 	if r.msw.periodic {
-		event.Period = r.msw.Module().Config().Period
+		e.period = r.msw.period
 	}
+	return e
+}
 
+// publish adds the enrichment to the event, records it in the stats and
+// health of the MetricSet, applies the event modifiers of the module and the
+// processors of the MetricSet, and writes it to the output.
+func (r *eventReporter) publish(e enrichment, event mb.Event) bool {
+	if event.Took == 0 {
+		event.Took = e.took
+	}
+	if e.period != 0 {
+		event.Period = e.period
+	}
 	if event.Timestamp.IsZero() {
-		if !r.start.IsZero() {
-			event.Timestamp = r.start
-		} else {
-			event.Timestamp = time.Now().UTC()
-		}
+		event.Timestamp = e.timestamp
 	}
-
 	if event.Host == "" {
-		event.Host = r.msw.HostData().SanitizedURI
+		event.Host = e.host
+	}
+	if r.msw.timestampNanos {
+		event.TimestampNanos = true
 	}
 
+	hostStats := r.msw.hostStats
+	r.msw.health.event(event.Error, time.Now())
 	if event.Error == nil {
 		r.msw.stats.success.Add(1)
+		r.msw.stats.lastSuccessTime.Set(time.Now())
+		if hostStats != nil {
+			hostStats.success.Add(1)
+		}
 	} else {
 		r.msw.stats.failures.Add(1)
+		r.msw.stats.lastError.Set(event.Error.Error())
+		r.msw.stats.lastErrorTime.Set(time.Now())
+		if hostStats != nil {
+			hostStats.failures.Add(1)
+		}
+		r.failed = true
+		r.err = event.Error
 	}
 
 	if event.Namespace == "" {
-		event.Namespace = r.msw.Registration().Namespace
+		event.Namespace = e.namespace
+	}
+	drop, err := event.Modify(r.msw.module.Name(), r.msw.MetricSet.Name(), r.msw.module.eventModifiers...)
+	if err != nil {
+		r.msw.stats.modifierErrors.Add(1)
+		r.msw.logger.Debugf("Event modifier of %s failed: %v", r.msw, err)
+	}
+	if drop {
+		r.msw.stats.modifierDropped.Add(1)
+		return true
 	}
-	beatEvent := event.BeatEvent(r.msw.module.Name(), r.msw.MetricSet.Name(), r.msw.module.eventModifiers...)
-	if !writeEvent(r.done, r.out, beatEvent) {
+	beatEvent := event.BeatEvent(r.msw.module.Name(), r.msw.MetricSet.Name())
+	if r.msw.processors != nil {
+		processed, err := r.msw.processors.Run(&beatEvent)
+		if err != nil {
+			r.msw.stats.processorErrors.Add(1)
+			r.msw.logger.Debugf("Processors of %s failed: %v", r.msw, err)
+		}
+		if processed == nil {
+			r.msw.stats.processorDropped.Add(1)
+			return true
+		}
+		beatEvent = *processed
+	}
+	// The size is estimated before publishing, as the event can be modified
+	// once it is written.
+	size := estimateSize(beatEvent.Fields)
+	if !r.msw.backpressure.write(r.abort, r.out, beatEvent) {
+		r.msw.dropped.Add(1)
+		r.msw.stats.dropped.Add(1)
 		return false
 	}
 	r.msw.stats.events.Add(1)
+	r.msw.stats.publishedBytes.Add(int64(size))
+	if hostStats != nil {
+		hostStats.events.Add(1)
+	}
 
 	return true
 }
 
 // other utility functions
 
+// sleep waits for the given duration. It returns false if ctx is done before
+// the duration elapses.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// alignDelay returns the time remaining from now until the next multiple of
+// the period.
+func alignDelay(now time.Time, period time.Duration) time.Duration {
+	return now.Truncate(period).Add(period).Sub(now)
+}
+
 func writeEvent(done <-chan struct{}, out chan<- beat.Event, event beat.Event) bool {
 	select {
 	case <-done:
@@ -414,6 +1538,9 @@ func getMetricSetStats(module, name string) *stats {
 		return s
 	}
 
+	// The stats start from zero, also when they replace the stats of a
+	// previous instance of the MetricSet.
+	report.MarkReset(key, time.Now())
 	reg := monitoring.Default.NewRegistry(key)
 	s := &stats{
 		key:      key,
@@ -421,13 +1548,90 @@ func getMetricSetStats(module, name string) *stats {
 		success:  monitoring.NewInt(reg, successesKey),
 		failures: monitoring.NewInt(reg, failuresKey),
 		events:   monitoring.NewInt(reg, eventsKey),
+		timeouts: monitoring.NewInt(reg, timeoutsKey),
+		skipped:  monitoring.NewInt(reg, skippedKey),
+		slow:     monitoring.NewInt(reg, slowKey),
+		stuck:    monitoring.NewInt(reg, stuckKey),
+		restarts: monitoring.NewInt(reg, restartsKey),
+
+		publishedBytes: monitoring.NewInt(reg, publishedBytesKey),
+		dropped:        monitoring.NewInt(reg, droppedKey),
+
+		modifierErrors:   monitoring.NewInt(reg, modifierErrorsKey),
+		modifierDropped:  monitoring.NewInt(reg, modifierDroppedKey),
+		processorErrors:  monitoring.NewInt(reg, processorErrorsKey),
+		processorDropped: monitoring.NewInt(reg, processorDroppedKey),
+
+		consecutiveFailures: monitoring.NewInt(reg, consecutiveFailuresKey),
+
+		lastError:       monitoring.NewString(reg, lastErrorKey),
+		lastErrorTime:   monitoring.NewTimestamp(reg, lastErrorTimeKey),
+		lastSuccessTime: monitoring.NewTimestamp(reg, lastSuccessTimeKey),
+
+		hosts: map[string]*hostStats{},
 	}
+	monitoring.NewFunc(reg, hostsKey, s.reportHosts)
 
 	fetches[key] = s
 	return s
 }
 
-func releaseStats(s *stats) {
+// getHostStats returns the stats of the given host, shared by the MetricSets
+// of the same module and host. It returns nil if the host is empty, as in
+// MetricSets that don't have a host or that fetch multiple hosts.
+func (s *stats) getHostStats(host string) *hostStats {
+	if host == "" {
+		return nil
+	}
+
+	s.hostsMu.Lock()
+	defer s.hostsMu.Unlock()
+
+	if hs := s.hosts[host]; hs != nil {
+		hs.ref++
+		return hs
+	}
+
+	report.MarkReset(s.key+"."+hostsKey+"."+host, time.Now())
+	reg := monitoring.NewRegistry()
+	hs := &hostStats{
+		host:                host,
+		ref:                 1,
+		registry:            reg,
+		success:             monitoring.NewInt(reg, successesKey),
+		failures:            monitoring.NewInt(reg, failuresKey),
+		events:              monitoring.NewInt(reg, eventsKey),
+		consecutiveFailures: monitoring.NewInt(reg, consecutiveFailuresKey),
+	}
+	s.hosts[host] = hs
+	return hs
+}
+
+// reportHosts reports the stats of each host. Hosts are reported as keys
+// instead of registries, as they usually contain dots.
+func (s *stats) reportHosts(m monitoring.Mode, V monitoring.Visitor) {
+	s.hostsMu.Lock()
+	defer s.hostsMu.Unlock()
+
+	V.OnRegistryStart()
+	defer V.OnRegistryFinished()
+
+	for host, hs := range s.hosts {
+		V.OnKey(host)
+		hs.registry.Visit(m, V)
+	}
+}
+
+func releaseStats(s *stats, hs *hostStats) {
+	if hs != nil {
+		s.hostsMu.Lock()
+		hs.ref--
+		if hs.ref == 0 {
+			delete(s.hosts, hs.host)
+		}
+		s.hostsMu.Unlock()
+	}
+
 	fetchesLock.Lock()
 	defer fetchesLock.Unlock()
 
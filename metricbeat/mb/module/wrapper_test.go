@@ -20,23 +20,48 @@
 package module_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
+	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/monitoring/report"
+	"github.com/elastic/beats/v7/libbeat/processors"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/metricbeat/mb/module"
 	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
 )
 
 const (
 	moduleName           = "fake"
 	reportingFetcherName = "ReportingFetcher"
 	pushMetricSetName    = "PushMetricSet"
+	contextFetcherName   = "ContextFetcher"
+	flakyFetcherName     = "FlakyFetcher"
+	lifecycleFetcherName = "LifecycleFetcher"
+	stuckFetcherName     = "StuckFetcher"
+	batchFetcherName     = "BatchFetcher"
+	streamFetcherName    = "StreamFetcher"
+	pushMetricSetV3Name  = "PushMetricSetV3"
+	fakeProcessorName    = "fake_processor"
 )
 
 // fakeMetricSet
@@ -44,6 +69,14 @@ const (
 func init() {
 	mb.Registry.MustAddMetricSet(moduleName, reportingFetcherName, newFakeReportingFetcher)
 	mb.Registry.MustAddMetricSet(moduleName, pushMetricSetName, newFakePushMetricSet)
+	mb.Registry.MustAddMetricSet(moduleName, contextFetcherName, newFakeContextFetcher)
+	mb.Registry.MustAddMetricSet(moduleName, flakyFetcherName, newFakeFlakyFetcher)
+	mb.Registry.MustAddMetricSet(moduleName, lifecycleFetcherName, newFakeLifecycleFetcher)
+	mb.Registry.MustAddMetricSet(moduleName, stuckFetcherName, newFakeStuckFetcher)
+	mb.Registry.MustAddMetricSet(moduleName, batchFetcherName, newFakeBatchFetcher)
+	mb.Registry.MustAddMetricSet(moduleName, streamFetcherName, newFakeStreamFetcher)
+	mb.Registry.MustAddMetricSet(moduleName, pushMetricSetV3Name, newFakePushMetricSetV3)
+	processors.RegisterPlugin(fakeProcessorName, newFakeProcessor)
 }
 
 // ReportingFetcher
@@ -80,8 +113,193 @@ func newFakePushMetricSet(base mb.BaseMetricSet) (mb.MetricSet, error) {
 	return r, nil
 }
 
+// PushMetricSetV3
+
+type fakePushMetricSetV3 struct {
+	mb.BaseMetricSet
+	runs int
+}
+
+// Run fails the first time, and then publishes an event until it is stopped.
+func (ms *fakePushMetricSetV3) Run(ctx context.Context, r mb.ReporterV2) error {
+	ms.runs++
+	if ms.runs == 1 {
+		return errors.New("connection refused")
+	}
+	r.Event(mb.Event{MetricSetFields: mapstr.M{"runs": ms.runs}})
+	<-ctx.Done()
+	return nil
+}
+
+func newFakePushMetricSetV3(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	var r mb.PushMetricSetV3 = &fakePushMetricSetV3{BaseMetricSet: base}
+	return r, nil
+}
+
+// ContextFetcher
+
+type fakeContextFetcher struct {
+	mb.BaseMetricSet
+}
+
+// Fetch blocks until the context is done, as a hung request would.
+func (ms *fakeContextFetcher) Fetch(ctx context.Context, r mb.ReporterV2) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func newFakeContextFetcher(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	var r mb.ReportingMetricSetV2WithContext = &fakeContextFetcher{BaseMetricSet: base}
+	return r, nil
+}
+
+// FlakyFetcher
+
+type fakeFlakyFetcher struct {
+	mb.BaseMetricSet
+	fetches int
+}
+
+// Fetch fails every other call, starting with the first one.
+func (ms *fakeFlakyFetcher) Fetch(r mb.ReporterV2) error {
+	ms.fetches++
+	if ms.fetches%2 == 1 {
+		return errors.New("connection refused")
+	}
+	r.Event(mb.Event{MetricSetFields: mapstr.M{"metric": 1}})
+	return nil
+}
+
+func newFakeFlakyFetcher(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	var r mb.ReportingMetricSetV2Error = &fakeFlakyFetcher{BaseMetricSet: base}
+	return r, nil
+}
+
+// LifecycleFetcher
+
+type fakeLifecycleFetcher struct {
+	mb.BaseMetricSet
+	started atomic.Bool
+	stopped atomic.Bool
+}
+
+func (ms *fakeLifecycleFetcher) OnStart(ctx context.Context) error {
+	ms.started.Store(true)
+	return nil
+}
+
+func (ms *fakeLifecycleFetcher) OnStop() error {
+	ms.stopped.Store(true)
+	return nil
+}
+
+func (ms *fakeLifecycleFetcher) Fetch(r mb.ReporterV2) {
+	r.Event(mb.Event{MetricSetFields: mapstr.M{"started": ms.started.Load()}})
+}
+
+func newFakeLifecycleFetcher(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	var r mb.ReportingMetricSetV2 = &fakeLifecycleFetcher{BaseMetricSet: base}
+	return r, nil
+}
+
+// StuckFetcher
+
+// releaseStuckFetches is closed to unblock the fetches of StuckFetcher.
+var releaseStuckFetches chan struct{}
+
+type fakeStuckFetcher struct {
+	mb.BaseMetricSet
+	release chan struct{}
+}
+
+// Fetch blocks ignoring its context, as a call without timeout would.
+func (ms *fakeStuckFetcher) Fetch(r mb.ReporterV2) error {
+	<-ms.release
+	return nil
+}
+
+func newFakeStuckFetcher(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	var r mb.ReportingMetricSetV2Error = &fakeStuckFetcher{BaseMetricSet: base, release: releaseStuckFetches}
+	return r, nil
+}
+
+// BatchFetcher
+
+type fakeBatchFetcher struct {
+	mb.BaseMetricSet
+}
+
+// Fetch returns some events and an error, as a partially failed fetch.
+func (ms *fakeBatchFetcher) Fetch(ctx context.Context) ([]mb.Event, error) {
+	return []mb.Event{
+		{MetricSetFields: mapstr.M{"metric": 1}},
+		{MetricSetFields: mapstr.M{"metric": 2}},
+	}, errors.New("connection refused")
+}
+
+func newFakeBatchFetcher(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	var r mb.ReportingMetricSetV2Batch = &fakeBatchFetcher{BaseMetricSet: base}
+	return r, nil
+}
+
+// StreamFetcher
+
+type fakeStreamFetcher struct {
+	mb.BaseMetricSet
+}
+
+// Fetch emits some events and fails, as a partially failed fetch.
+func (ms *fakeStreamFetcher) Fetch(ctx context.Context, emit func(mb.Event) bool) error {
+	for i := 1; i <= 3; i++ {
+		if !emit(mb.Event{MetricSetFields: mapstr.M{"metric": i}}) {
+			return nil
+		}
+	}
+	return errors.New("connection refused")
+}
+
+func newFakeStreamFetcher(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	var r mb.ReportingMetricSetV2Stream = &fakeStreamFetcher{BaseMetricSet: base}
+	return r, nil
+}
+
+// Scheduler
+
+type fakeScheduler struct {
+	c       chan time.Time
+	stopped atomic.Bool
+}
+
+func (s *fakeScheduler) Next() <-chan time.Time { return s.c }
+func (s *fakeScheduler) Done()                  { s.stopped.Store(true) }
+
 // test utilities
 
+// fakeProcessor drops the events whose field has the given value, and adds the
+// scoped field to the others.
+type fakeProcessor struct {
+	Field string `config:"field"`
+	Drop  int    `config:"drop"`
+}
+
+func (p *fakeProcessor) Run(event *beat.Event) (*beat.Event, error) {
+	if value, _ := event.Fields.GetValue(p.Field); value == p.Drop {
+		return nil, nil
+	}
+	event.Fields.Put("fields.scoped", true)
+	return event, nil
+}
+
+func (p *fakeProcessor) String() string { return fakeProcessorName }
+
+func newFakeProcessor(c *conf.C) (beat.Processor, error) {
+	p := &fakeProcessor{}
+	if err := c.Unpack(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
 func newTestRegistry(t testing.TB) *mb.Register {
 	r := mb.NewRegister()
 
@@ -89,6 +307,20 @@ func newTestRegistry(t testing.TB) *mb.Register {
 	require.NoError(t, err)
 	err = r.AddMetricSet(moduleName, pushMetricSetName, newFakePushMetricSet)
 	require.NoError(t, err)
+	err = r.AddMetricSet(moduleName, contextFetcherName, newFakeContextFetcher)
+	require.NoError(t, err)
+	err = r.AddMetricSet(moduleName, flakyFetcherName, newFakeFlakyFetcher)
+	require.NoError(t, err)
+	err = r.AddMetricSet(moduleName, lifecycleFetcherName, newFakeLifecycleFetcher)
+	require.NoError(t, err)
+	err = r.AddMetricSet(moduleName, stuckFetcherName, newFakeStuckFetcher)
+	require.NoError(t, err)
+	err = r.AddMetricSet(moduleName, batchFetcherName, newFakeBatchFetcher)
+	require.NoError(t, err)
+	err = r.AddMetricSet(moduleName, streamFetcherName, newFakeStreamFetcher)
+	require.NoError(t, err)
+	err = r.AddMetricSet(moduleName, pushMetricSetV3Name, newFakePushMetricSetV3)
+	require.NoError(t, err)
 	return r
 }
 
@@ -131,6 +363,219 @@ func TestWrapperOfReportingFetcher(t *testing.T) {
 	}
 }
 
+func TestPerHostStats(t *testing.T) {
+	hosts := []string{"epsilon", "zeta.example.com"}
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{reportingFetcherName},
+		"hosts":      hosts,
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	output := m.Start(done)
+	<-output
+	<-output
+
+	key := "metricbeat." + m.Name() + "." + m.MetricSets()[0].Name() + ".hosts."
+	snapshot := monitoring.CollectFlatSnapshot(monitoring.Default, monitoring.Full, false)
+	for _, host := range hosts {
+		assert.EqualValues(t, 1, snapshot.Ints[key+host+".success"], "host %s", host)
+	}
+}
+
+func TestWrapperOfBatchFetcher(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{batchFetcherName},
+		"hosts":      []string{"alpha"},
+		"period":     "1h",
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t), module.WithMetricSetInfo())
+	require.NoError(t, err)
+
+	output := m.Start(make(chan struct{}))
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, m.Stop(ctx))
+	}()
+
+	events := []beat.Event{<-output, <-output, <-output}
+	name := m.MetricSets()[0].Name()
+	for i, event := range events[:2] {
+		metric, err := event.Fields.GetValue(moduleName + "." + name + ".metric")
+		require.NoError(t, err)
+		assert.Equal(t, i+1, metric)
+		period, _ := event.Fields.GetValue("metricset.period")
+		assert.Equal(t, time.Hour/time.Millisecond, period)
+		assert.Equal(t, events[0].Timestamp, event.Timestamp)
+	}
+
+	// The error is reported after the events.
+	message, err := events[2].Fields.GetValue("error.message")
+	require.NoError(t, err)
+	assert.Equal(t, "connection refused", message)
+}
+
+func TestEventModifierV2(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{batchFetcherName},
+		"hosts":      []string{"alpha"},
+		"period":     "1h",
+	})
+
+	// Drop the first event, and fail on the second one.
+	modifier := func(module, metricset string, event *mb.Event) (bool, error) {
+		switch event.MetricSetFields["metric"] {
+		case 1:
+			return true, nil
+		case 2:
+			return false, errors.New("lookup failed")
+		}
+		return false, nil
+	}
+
+	m, err := module.NewWrapper(c, newTestRegistry(t), module.WithEventModifierV2(modifier))
+	require.NoError(t, err)
+
+	output := m.Start(make(chan struct{}))
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, m.Stop(ctx))
+	}()
+
+	event := <-output
+	metric, err := event.Fields.GetValue(moduleName + "." + m.MetricSets()[0].Name() + ".metric")
+	require.NoError(t, err)
+	assert.Equal(t, 2, metric)
+
+	event = <-output
+	message, err := event.Fields.GetValue("error.message")
+	require.NoError(t, err)
+	assert.Equal(t, "connection refused", message)
+
+	key := "metricbeat." + m.Name() + "." + m.MetricSets()[0].Name() + ".modifiers."
+	snapshot := monitoring.CollectFlatSnapshot(monitoring.Default, monitoring.Full, false)
+	assert.Equal(t, int64(1), snapshot.Ints[key+"dropped"])
+	assert.Equal(t, int64(1), snapshot.Ints[key+"errors"])
+}
+
+func TestMetricSetProcessors(t *testing.T) {
+	batchName := strings.ToLower(batchFetcherName)
+	flakyName := strings.ToLower(flakyFetcherName)
+	metricField := moduleName + "." + batchName + ".metric"
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{batchFetcherName, flakyFetcherName},
+		"hosts":      []string{"alpha"},
+		"period":     "1h",
+		"metricset_overrides": map[string]interface{}{
+			batchFetcherName: map[string]interface{}{
+				"processors": []map[string]interface{}{
+					{fakeProcessorName: map[string]interface{}{"field": metricField, "drop": 1}},
+				},
+			},
+		},
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t), module.WithMetricSetInfo())
+	require.NoError(t, err)
+
+	output := m.Start(make(chan struct{}))
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, m.Stop(ctx))
+	}()
+
+	events := map[string][]beat.Event{}
+	for len(events[batchName]) < 2 || len(events[flakyName]) < 1 {
+		event := <-output
+		name, err := event.Fields.GetValue("metricset.name")
+		require.NoError(t, err)
+		events[name.(string)] = append(events[name.(string)], event)
+	}
+
+	// The first event of BatchFetcher is dropped, and only its events are
+	// processed.
+	metric, err := events[batchName][0].Fields.GetValue(metricField)
+	require.NoError(t, err)
+	assert.Equal(t, 2, metric)
+	for _, event := range events[batchName] {
+		scoped, err := event.Fields.GetValue("fields.scoped")
+		require.NoError(t, err)
+		assert.Equal(t, true, scoped)
+	}
+	_, err = events[flakyName][0].Fields.GetValue("fields.scoped")
+	assert.Error(t, err)
+
+	key := "metricbeat." + moduleName + "." + batchName + ".processors.dropped"
+	snapshot := monitoring.CollectFlatSnapshot(monitoring.Default, monitoring.Full, false)
+	assert.Equal(t, int64(1), snapshot.Ints[key])
+}
+
+func TestMetricSetProcessorsInvalid(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{batchFetcherName},
+		"hosts":      []string{"alpha"},
+		"metricset_overrides": map[string]interface{}{
+			batchFetcherName: map[string]interface{}{
+				"processors": []map[string]interface{}{{"unknown_processor": nil}},
+			},
+		},
+	})
+
+	_, err := module.NewWrapper(c, newTestRegistry(t))
+	assert.ErrorContains(t, err, "failed to create processors of metricset "+strings.ToLower(batchFetcherName))
+}
+
+func TestWrapperOfStreamFetcher(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{streamFetcherName},
+		"hosts":      []string{"alpha"},
+		"period":     "1h",
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+	id := m.MetricSets()[0].ID()
+
+	output := m.Start(make(chan struct{}))
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, m.Stop(ctx))
+	}()
+
+	name := m.MetricSets()[0].Name()
+	for i := 1; i <= 3; i++ {
+		event := <-output
+		metric, err := event.Fields.GetValue(moduleName + "." + name + ".metric")
+		require.NoError(t, err)
+		assert.Equal(t, i, metric)
+	}
+
+	// The returned error is reported as the outcome of the fetch.
+	event := <-output
+	message, err := event.Fields.GetValue("error.message")
+	require.NoError(t, err)
+	assert.Equal(t, "connection refused", message)
+	assert.Eventually(t, func() bool {
+		h, _ := findHealth(id)
+		return h.State == module.HealthDegraded
+	}, 5*time.Second, 5*time.Millisecond)
+}
+
 func TestWrapperOfPushMetricSet(t *testing.T) {
 	hosts := []string{"alpha"}
 	c := newConfig(t, map[string]interface{}{
@@ -160,6 +605,44 @@ func TestWrapperOfPushMetricSet(t *testing.T) {
 	}
 }
 
+func TestWrapperOfPushMetricSetV3(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":               moduleName,
+		"metricsets":           []string{pushMetricSetV3Name},
+		"hosts":                []string{"alpha"},
+		"restart_backoff.init": "10ms",
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+	id := m.MetricSets()[0].ID()
+
+	output := m.Start(make(chan struct{}))
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, m.Stop(ctx))
+	}()
+
+	// The error of the failed run is reported, and it is restarted.
+	event := <-output
+	message, err := event.Fields.GetValue("error.message")
+	require.NoError(t, err)
+	assert.Equal(t, "connection refused", message)
+
+	event = <-output
+	runs, err := event.Fields.GetValue(moduleName + "." + m.MetricSets()[0].Name() + ".runs")
+	require.NoError(t, err)
+	assert.Equal(t, 2, runs)
+
+	key := "metricbeat." + m.Name() + "." + m.MetricSets()[0].Name() + ".restarts"
+	snapshot := monitoring.CollectFlatSnapshot(monitoring.Default, monitoring.Full, false)
+	assert.Equal(t, int64(1), snapshot.Ints[key])
+
+	h, _ := findHealth(id)
+	assert.Equal(t, module.HealthRunning, h.State)
+}
+
 func TestPeriodIsAddedToEvent(t *testing.T) {
 	cases := map[string]struct {
 		metricset string
@@ -202,6 +685,55 @@ func TestPeriodIsAddedToEvent(t *testing.T) {
 	}
 }
 
+func TestPeriodOverriddenPerMetricSet(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{reportingFetcherName},
+		"hosts":      []string{"alpha"},
+		"period":     "10s",
+		"metricset_overrides": map[string]interface{}{
+			reportingFetcherName: map[string]interface{}{"period": "5m"},
+		},
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t), module.WithMetricSetInfo())
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	output := m.Start(done)
+
+	event := <-output
+
+	period, err := event.Fields.GetValue("metricset.period")
+	require.NoError(t, err)
+	assert.EqualValues(t, 5*time.Minute/time.Millisecond, period)
+}
+
+func TestTimestampNanosIsAddedToEvent(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":          moduleName,
+		"metricsets":      []string{reportingFetcherName},
+		"hosts":           []string{"alpha"},
+		"timestamp_nanos": true,
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t), module.WithMetricSetInfo())
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	output := m.Start(done)
+
+	event := <-output
+
+	nanos, err := event.Fields.GetValue("metricset.timestamp")
+	require.NoError(t, err)
+	assert.Equal(t, event.Timestamp.UTC().Format("2006-01-02T15:04:05.000000000Z"), nanos)
+}
+
 func TestNewWrapperForMetricSet(t *testing.T) {
 	hosts := []string{"alpha"}
 	c := newConfig(t, map[string]interface{}{
@@ -231,3 +763,954 @@ func TestNewWrapperForMetricSet(t *testing.T) {
 		assert.Fail(t, "received unexpected event")
 	}
 }
+
+func TestFetchOnce(t *testing.T) {
+	t.Run("periodic", func(t *testing.T) {
+		c := newConfig(t, map[string]interface{}{
+			"module":     moduleName,
+			"metricsets": []string{reportingFetcherName, lifecycleFetcherName},
+			"hosts":      []string{"alpha", "beta"},
+		})
+		m, err := module.NewWrapper(c, newTestRegistry(t))
+		require.NoError(t, err)
+
+		events, err := m.FetchOnce(context.Background())
+		require.NoError(t, err)
+		require.Len(t, events, 4)
+
+		started := 0
+		for _, event := range events {
+			if value, err := event.Fields.GetValue(moduleName + "." + strings.ToLower(lifecycleFetcherName) + ".started"); err == nil {
+				assert.Equal(t, true, value, "OnStart must be called before fetching")
+				started++
+			}
+		}
+		assert.Equal(t, 2, started)
+	})
+
+	t.Run("repeated", func(t *testing.T) {
+		c := newConfig(t, map[string]interface{}{
+			"module":     moduleName,
+			"metricsets": []string{lifecycleFetcherName},
+		})
+		m, err := module.NewWrapper(c, newTestRegistry(t))
+		require.NoError(t, err)
+		ms, ok := m.MetricSets()[0].MetricSet.(*fakeLifecycleFetcher)
+		require.True(t, ok)
+
+		for i := 0; i < 3; i++ {
+			events, err := m.Fetch(context.Background())
+			require.NoError(t, err)
+			assert.Len(t, events, 1)
+		}
+		assert.False(t, ms.stopped.Load(), "the metricset must not be stopped until it is closed")
+
+		m.Close()
+		assert.True(t, ms.stopped.Load())
+	})
+
+	t.Run("push", func(t *testing.T) {
+		c := newConfig(t, map[string]interface{}{
+			"module":     moduleName,
+			"metricsets": []string{pushMetricSetV3Name},
+		})
+		m, err := module.NewWrapper(c, newTestRegistry(t))
+		require.NoError(t, err)
+
+		_, err = m.FetchOnce(context.Background())
+		assert.ErrorContains(t, err, "it can't be fetched once")
+	})
+}
+
+func TestFetchTimeout(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":        moduleName,
+		"metricsets":    []string{contextFetcherName},
+		"hosts":         []string{"alpha"},
+		"fetch_timeout": "10ms",
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	output := m.Start(done)
+
+	select {
+	case event := <-output:
+		msg, err := event.Fields.GetValue("error.message")
+		require.NoError(t, err)
+		assert.Equal(t, context.DeadlineExceeded.Error(), msg)
+		errorType, err := event.Fields.GetValue("error.type")
+		require.NoError(t, err)
+		assert.Equal(t, "timeout", errorType)
+	case <-time.After(5 * time.Second):
+		t.Fatal("fetch was not cancelled after the timeout")
+	}
+
+	// The error is kept in the metricset stats.
+	key := "metricbeat." + m.Name() + "." + m.MetricSets()[0].Name()
+	snapshot := monitoring.CollectFlatSnapshot(monitoring.Default, monitoring.Full, false)
+	assert.Equal(t, context.DeadlineExceeded.Error(), snapshot.Strings[key+".last_error"])
+	assert.NotEmpty(t, snapshot.Strings[key+".last_error_time"])
+	assert.Empty(t, snapshot.Strings[key+".last_success_time"])
+}
+
+func TestSlowFetch(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":               moduleName,
+		"metricsets":           []string{contextFetcherName},
+		"hosts":                []string{"alpha"},
+		"fetch_timeout":        "20ms",
+		"slow_fetch_threshold": "5ms",
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	output := m.Start(done)
+	<-output
+
+	key := "metricbeat." + m.Name() + "." + m.MetricSets()[0].Name() + ".fetches.slow"
+	assert.Eventually(t, func() bool {
+		snapshot := monitoring.CollectFlatSnapshot(monitoring.Default, monitoring.Full, false)
+		return snapshot.Ints[key] >= 1
+	}, 5*time.Second, 5*time.Millisecond)
+}
+
+func TestWatchdog(t *testing.T) {
+	releaseStuckFetches = make(chan struct{})
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{stuckFetcherName},
+		"hosts":      []string{"alpha"},
+		"period":     "10ms",
+		"watchdog": map[string]interface{}{
+			"periods": 2,
+			"restart": true,
+		},
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+	stuckID := m.MetricSets()[0].ID()
+
+	m.Start(make(chan struct{}))
+	defer func() {
+		close(releaseStuckFetches)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, m.Stop(ctx))
+	}()
+
+	// The stuck metricset is replaced by a new instance.
+	assert.Eventually(t, func() bool {
+		return m.MetricSets()[0].ID() != stuckID
+	}, 5*time.Second, 5*time.Millisecond)
+
+	key := "metricbeat." + m.Name() + "." + m.MetricSets()[0].Name() + ".fetches.stuck"
+	snapshot := monitoring.CollectFlatSnapshot(monitoring.Default, monitoring.Full, false)
+	assert.GreaterOrEqual(t, snapshot.Ints[key], int64(1))
+
+	var state string
+	for _, h := range module.Health() {
+		if h.ID == stuckID {
+			state = h.State
+		}
+	}
+	assert.Equal(t, module.HealthFailed, state)
+}
+
+func TestModuleLoggingLevel(t *testing.T) {
+	require.NoError(t, logp.DevelopmentSetup(logp.WithLevel(logp.InfoLevel), logp.ToObserverOutput()))
+
+	run := func(moduleConfig map[string]interface{}) {
+		m, err := module.NewWrapper(newConfig(t, moduleConfig), newTestRegistry(t))
+		require.NoError(t, err)
+
+		output := m.Start(make(chan struct{}))
+		<-output
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, m.Stop(ctx))
+	}
+
+	// Debug logs of this module are written, even if the beat logs at info.
+	run(map[string]interface{}{
+		"module":        moduleName,
+		"metricsets":    []string{reportingFetcherName},
+		"hosts":         []string{"debug-host"},
+		"logging.level": "debug",
+	})
+	run(map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{reportingFetcherName},
+		"hosts":      []string{"info-host"},
+	})
+
+	debugLogs := logp.ObserverLogs().FilterLevelExact(zapcore.DebugLevel)
+	assert.NotZero(t, debugLogs.FilterField(zap.String("host", "debug-host")).Len())
+	assert.Zero(t, debugLogs.FilterField(zap.String("host", "info-host")).Len())
+
+	for _, entry := range debugLogs.FilterField(zap.String("host", "debug-host")).All() {
+		assert.Equal(t, "module", entry.LoggerName)
+		assert.Equal(t, moduleName, entry.ContextMap()["module"])
+	}
+}
+
+func TestDeprecatedInterfaceWarning(t *testing.T) {
+	require.NoError(t, logp.DevelopmentSetup(logp.ToObserverOutput()))
+
+	// The warnings are logged once per process, the module name is unique for
+	// each run of the test.
+	deprecatedModuleName := fmt.Sprintf("deprecated%d", time.Now().UnixNano())
+	r := mb.NewRegister()
+	require.NoError(t, r.AddMetricSet(deprecatedModuleName, reportingFetcherName, newFakeReportingFetcher))
+	require.NoError(t, r.AddMetricSet(deprecatedModuleName, pushMetricSetName, newFakePushMetricSet))
+
+	// The warning is logged once per metricset, even if they have several hosts.
+	m, err := module.NewWrapper(newConfig(t, map[string]interface{}{
+		"module":     deprecatedModuleName,
+		"metricsets": []string{reportingFetcherName, pushMetricSetName},
+		"hosts":      []string{"alpha", "beta"},
+		"period":     "10ms",
+	}), r)
+	require.NoError(t, err)
+
+	output := m.Start(make(chan struct{}))
+	for i := 0; i < 8; i++ {
+		<-output
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, m.Stop(ctx))
+
+	warnings := logp.ObserverLogs().FilterLevelExact(zapcore.WarnLevel).FilterFieldKey("deprecated_interface").All()
+	require.Len(t, warnings, 2)
+	interfaces := map[string]string{}
+	for _, entry := range warnings {
+		fields := entry.ContextMap()
+		assert.Equal(t, deprecatedModuleName, fields["module"])
+		interfaces[fields["metricset"].(string)] = fields["deprecated_interface"].(string)
+	}
+	assert.Equal(t, map[string]string{
+		strings.ToLower(reportingFetcherName): "mb.ReportingMetricSet",
+		strings.ToLower(pushMetricSetName):    "mb.PushMetricSet",
+	}, interfaces)
+}
+
+// slowFirstFetcher records the start time of its fetches, the first one
+// takes a while.
+type slowFirstFetcher struct {
+	mb.BaseMetricSet
+	delay  time.Duration
+	starts chan<- time.Time
+	first  bool
+}
+
+func (ms *slowFirstFetcher) Fetch(r mb.ReporterV2) error {
+	ms.starts <- time.Now()
+	if !ms.first {
+		ms.first = true
+		time.Sleep(ms.delay)
+	}
+	r.Event(mb.Event{MetricSetFields: mapstr.M{"metric": 1}})
+	return nil
+}
+
+func TestAlignPeriodWithSlowFetch(t *testing.T) {
+	const period = 200 * time.Millisecond
+
+	starts := make(chan time.Time, 10)
+	r := mb.NewRegister()
+	require.NoError(t, r.AddMetricSet(moduleName, "slow", func(base mb.BaseMetricSet) (mb.MetricSet, error) {
+		var ms mb.ReportingMetricSetV2Error = &slowFirstFetcher{BaseMetricSet: base, delay: period / 2, starts: starts}
+		return ms, nil
+	}))
+
+	m, err := module.NewWrapper(newConfig(t, map[string]interface{}{
+		"module":         moduleName,
+		"metricsets":     []string{"slow"},
+		"period":         period.String(),
+		"align_period":   true,
+		"fetch_on_start": false,
+	}), r)
+	require.NoError(t, err)
+
+	output := m.Start(make(chan struct{}))
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, m.Stop(ctx))
+	}()
+	go func() {
+		for range output {
+		}
+	}()
+
+	// The fetches after the slow one still start on period boundaries.
+	for i := 0; i < 3; i++ {
+		start := <-starts
+		offset := start.Sub(start.Truncate(period))
+		assert.Less(t, offset, period/4, "fetch %d started %s after a period boundary", i, offset)
+	}
+}
+
+func TestFetchOnStartDisabled(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":         moduleName,
+		"metricsets":     []string{reportingFetcherName},
+		"hosts":          []string{"alpha"},
+		"period":         "1h",
+		"fetch_on_start": false,
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	output := m.Start(done)
+
+	select {
+	case event := <-output:
+		assert.Fail(t, "received unexpected event", "%+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestFetchRetry(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{flakyFetcherName},
+		"hosts":      []string{"alpha"},
+		"retry": map[string]interface{}{
+			"attempts": 2,
+			"errors":   []string{"connection refused"},
+		},
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	output := m.Start(done)
+
+	// The error of the first attempt is discarded.
+	event := <-output
+	hasError, _ := event.Fields.HasKey("error")
+	assert.False(t, hasError, "unexpected error in event %+v", event)
+}
+
+func TestConsecutiveFailuresStats(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":        moduleName,
+		"metricsets":    []string{contextFetcherName},
+		"hosts":         []string{"alpha"},
+		"period":        "10ms",
+		"fetch_timeout": "10ms",
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	output := m.Start(done)
+
+	// The failures of the first fetch are recorded before the second one.
+	<-output
+	<-output
+
+	key := "metricbeat." + m.Name() + "." + m.MetricSets()[0].Name() + ".consecutive_failures"
+	snapshot := monitoring.CollectFlatSnapshot(monitoring.Default, monitoring.Full, false)
+	assert.GreaterOrEqual(t, snapshot.Ints[key], int64(1))
+	assert.True(t, report.IsGauge(key), "consecutive failures must be reported as a gauge")
+}
+
+func TestMaxConsecutiveFailures(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":                   moduleName,
+		"metricsets":               []string{contextFetcherName},
+		"hosts":                    []string{"alpha"},
+		"period":                   "10ms",
+		"fetch_timeout":            "10ms",
+		"max_consecutive_failures": 2,
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	output := m.Start(make(chan struct{}))
+
+	var messages []interface{}
+	for i := 0; i < 3; i++ {
+		event := <-output
+		msg, err := event.Fields.GetValue("error.message")
+		require.NoError(t, err)
+		messages = append(messages, msg)
+	}
+	assert.Equal(t, []interface{}{
+		context.DeadlineExceeded.Error(),
+		context.DeadlineExceeded.Error(),
+		"metricset stopped after 2 consecutive failures",
+	}, messages)
+
+	// The metricset doesn't fetch anymore.
+	select {
+	case event := <-output:
+		t.Fatalf("unexpected event after the metricset was stopped: %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, m.Stop(ctx))
+}
+
+func TestFetchDurationHistogram(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{reportingFetcherName},
+		"hosts":      []string{"alpha"},
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	output := m.Start(make(chan struct{}))
+	<-output
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, m.Stop(ctx))
+
+	metricSets := m.MetricSets()
+	require.Len(t, metricSets, 1)
+	snapshot := monitoring.CollectFlatSnapshot(metricSets[0].Metrics(), monitoring.Full, false)
+	assert.EqualValues(t, 1, snapshot.Ints["fetch_duration.histogram.count"])
+}
+
+func TestFetchTracing(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{reportingFetcherName},
+		"hosts":      []string{"alpha"},
+	})
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	m, err := module.NewWrapper(c, newTestRegistry(t), module.WithTracer(provider.Tracer("test")))
+	require.NoError(t, err)
+
+	output := m.Start(make(chan struct{}))
+	<-output
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, m.Stop(ctx))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "fetch "+m.Name()+"/"+m.MetricSets()[0].Name(), spans[0].Name())
+	assert.Equal(t, trace.SpanKindInternal, spans[0].SpanKind())
+	assert.Equal(t, codes.Ok, spans[0].Status().Code)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("metricbeat.module", m.Name()),
+		attribute.String("metricbeat.metricset", m.MetricSets()[0].Name()),
+		attribute.String("metricbeat.host", "alpha"),
+	}, spans[0].Attributes())
+}
+
+func TestFetchTracingError(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":        moduleName,
+		"metricsets":    []string{contextFetcherName},
+		"hosts":         []string{"alpha"},
+		"fetch_timeout": "10ms",
+	})
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	m, err := module.NewWrapper(c, newTestRegistry(t), module.WithTracer(provider.Tracer("test")))
+	require.NoError(t, err)
+
+	output := m.Start(make(chan struct{}))
+	<-output
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, m.Stop(ctx))
+
+	spans := recorder.Ended()
+	require.NotEmpty(t, spans)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, context.DeadlineExceeded.Error(), spans[0].Status().Description)
+	if assert.Len(t, spans[0].Events(), 1) {
+		assert.Equal(t, "exception", spans[0].Events()[0].Name)
+	}
+}
+
+func TestWrapperStop(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{reportingFetcherName},
+		"hosts":      []string{"alpha"},
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	output := m.Start(make(chan struct{}))
+	<-output
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, m.Stop(ctx))
+
+	_, ok := <-output
+	assert.False(t, ok, "output channel should be closed")
+}
+
+func TestWrapperStopWhileStarting(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{reportingFetcherName},
+		"hosts":      []string{"alpha"},
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	started := make(chan (<-chan beat.Event))
+	go func() {
+		started <- m.StartWithContext(context.Background())
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, m.Stop(ctx))
+
+	// Stop again, in case the module wasn't started yet when stopping it.
+	output := <-started
+	go func() {
+		for range output {
+		}
+	}()
+	assert.NoError(t, m.Stop(ctx))
+}
+
+func TestWrapperStopAbortsInFlightFetches(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{contextFetcherName},
+		"hosts":      []string{"alpha"},
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	output := m.Start(make(chan struct{}))
+	go func() {
+		for range output {
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, m.Stop(ctx), context.DeadlineExceeded)
+}
+
+func TestWrapperStopCountsDroppedEvents(t *testing.T) {
+	newWrapper := func() *module.Wrapper {
+		c := newConfig(t, map[string]interface{}{
+			"module":     moduleName,
+			"metricsets": []string{reportingFetcherName},
+			"hosts":      []string{"alpha"},
+			"period":     "10ms",
+		})
+		m, err := module.NewWrapper(c, newTestRegistry(t))
+		require.NoError(t, err)
+		return m
+	}
+
+	// The stats are removed when no metricset uses them, this one keeps them
+	// after the other one is stopped.
+	keeper := newWrapper()
+	output := keeper.Start(make(chan struct{}))
+	go func() {
+		for range output {
+		}
+	}()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, keeper.Stop(ctx))
+	}()
+
+	// The output of this one is never read, so it is blocked publishing
+	// events when it is stopped.
+	m := newWrapper()
+	m.Start(make(chan struct{}))
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, m.Stop(ctx), context.DeadlineExceeded)
+
+	// Stop doesn't wait for the aborted writers, they count the dropped
+	// events when they return.
+	key := "metricbeat." + m.Name() + "." + m.MetricSets()[0].Name() + ".events_dropped"
+	assert.Eventually(t, func() bool {
+		snapshot := monitoring.CollectFlatSnapshot(monitoring.Default, monitoring.Full, false)
+		return snapshot.Ints[key] >= 1
+	}, 5*time.Second, 5*time.Millisecond)
+}
+
+func TestWrapperStopWithStuckFetch(t *testing.T) {
+	releaseStuckFetches = make(chan struct{})
+	defer close(releaseStuckFetches)
+
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{stuckFetcherName},
+		"hosts":      []string{"alpha"},
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	output := m.Start(make(chan struct{}))
+	go func() {
+		for range output {
+		}
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	// The fetch ignores its context, Stop returns anyway when ctx is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	stopped := make(chan error, 1)
+	go func() { stopped <- m.Stop(ctx) }()
+
+	select {
+	case err := <-stopped:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop did not return after its context was done")
+	}
+}
+
+func TestMetricSetLifecycle(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{lifecycleFetcherName},
+		"hosts":      []string{"alpha"},
+	})
+
+	aModule, metricSets, err := mb.NewModule(c, newTestRegistry(t))
+	require.NoError(t, err)
+	ms, ok := metricSets[0].(*fakeLifecycleFetcher)
+	require.True(t, ok)
+
+	m, err := module.NewWrapperForMetricSet(aModule, ms)
+	require.NoError(t, err)
+
+	output := m.Start(make(chan struct{}))
+
+	event := <-output
+	started, err := event.Fields.GetValue(moduleName + "." + ms.Name() + ".started")
+	require.NoError(t, err)
+	assert.Equal(t, true, started, "OnStart must be called before the first fetch")
+	assert.False(t, ms.stopped.Load())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, m.Stop(ctx))
+	assert.True(t, ms.stopped.Load(), "OnStop must be called after the last fetch")
+}
+
+func TestLifecycleEvents(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":           moduleName,
+		"metricsets":       []string{flakyFetcherName},
+		"hosts":            []string{"alpha"},
+		"period":           "10ms",
+		"lifecycle_events": true,
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	var states []beat.Event
+	collected := make(chan struct{})
+	output := m.Start(make(chan struct{}))
+	go func() {
+		defer close(collected)
+		for event := range output {
+			if dataset, _ := event.Fields.GetValue("event.dataset"); dataset == "metricbeat.state" {
+				states = append(states, event)
+			}
+		}
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, m.Stop(ctx))
+	<-collected
+
+	require.GreaterOrEqual(t, len(states), 4)
+	actionAndState := func(event beat.Event) []interface{} {
+		action, _ := event.Fields.GetValue("event.action")
+		state, _ := event.Fields.GetValue("metricbeat.state.state")
+		return []interface{}{action, state}
+	}
+	assert.Equal(t, []interface{}{"started", module.HealthStarting}, actionAndState(states[0]))
+	assert.Equal(t, []interface{}{"changed", module.HealthDegraded}, actionAndState(states[1]))
+	assert.Equal(t, []interface{}{"changed", module.HealthRunning}, actionAndState(states[2]))
+	assert.Equal(t, "stopped", actionAndState(states[len(states)-1])[0])
+
+	degraded := states[1].Fields
+	assert.Equal(t, mapstr.M{
+		"id":             m.MetricSets()[0].ID(),
+		"module":         moduleName,
+		"metricset":      strings.ToLower(flakyFetcherName),
+		"state":          module.HealthDegraded,
+		"previous_state": module.HealthStarting,
+	}, degraded["metricbeat"].(mapstr.M)["state"])
+	message, _ := degraded.GetValue("error.message")
+	assert.Equal(t, "connection refused", message)
+	host, _ := degraded.GetValue("service.address")
+	assert.Equal(t, "alpha", host)
+}
+
+func TestWrapperUpdateHosts(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{reportingFetcherName},
+		"hosts":      []string{"alpha", "beta"},
+		"period":     "1h",
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	output := m.Start(done)
+	<-output
+	<-output
+
+	hosts := func() map[string]interface{} {
+		h := map[string]interface{}{}
+		for _, msw := range m.MetricSets() {
+			h[msw.Host()] = msw
+		}
+		return h
+	}
+	before := hosts()
+
+	require.NoError(t, m.UpdateHosts([]string{"beta", "gamma"}))
+
+	after := hosts()
+	assert.Len(t, after, 2)
+	assert.Contains(t, after, "gamma")
+	assert.NotContains(t, after, "alpha")
+	assert.Same(t, before["beta"], after["beta"], "metricset of unchanged host should be kept")
+
+	// The metricset of the new host is started.
+	select {
+	case <-output:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received from the added host")
+	}
+}
+
+func TestWrapperUpdateHostsWithHostParser(t *testing.T) {
+	r := newTestRegistry(t)
+	r.MustAddHostParser("first", func(_ mb.Module, host string) (mb.HostData, error) {
+		primary, _, _ := strings.Cut(host, "|")
+		return mb.HostData{URI: primary, SanitizedURI: primary, Host: strings.ToUpper(primary)}, nil
+	})
+
+	c := newConfig(t, map[string]interface{}{
+		"module":      moduleName,
+		"metricsets":  []string{reportingFetcherName},
+		"hosts":       []string{"alpha|beta", "gamma"},
+		"host_parser": "first",
+		"period":      "1h",
+	})
+
+	m, err := module.NewWrapper(c, r)
+	require.NoError(t, err)
+
+	hosts := func() map[string]interface{} {
+		h := map[string]interface{}{}
+		for _, msw := range m.MetricSets() {
+			h[msw.Host()] = msw
+		}
+		return h
+	}
+	before := hosts()
+	require.Contains(t, before, "ALPHA")
+
+	require.NoError(t, m.UpdateHosts([]string{"alpha|beta", "gamma"}))
+	after := hosts()
+	assert.Same(t, before["ALPHA"], after["ALPHA"], "metricset of unchanged host should be kept")
+	assert.Same(t, before["GAMMA"], after["GAMMA"], "metricset of unchanged host should be kept")
+
+	require.NoError(t, m.UpdateHosts([]string{"alpha|delta", "gamma"}))
+	after = hosts()
+	assert.Len(t, after, 2)
+	assert.NotSame(t, before["ALPHA"], after["ALPHA"], "metricset of host with changed fallbacks should be replaced")
+	assert.Same(t, before["GAMMA"], after["GAMMA"])
+}
+
+func TestWrapperPauseResume(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{reportingFetcherName},
+		"hosts":      []string{"alpha"},
+		"period":     "10ms",
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	m.Pause()
+
+	done := make(chan struct{})
+	defer close(done)
+
+	output := m.Start(done)
+
+	select {
+	case event := <-output:
+		assert.Fail(t, "received unexpected event while paused", "%+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	m.Resume()
+
+	select {
+	case <-output:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received after resuming")
+	}
+}
+
+func TestWrapperTriggerFetch(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{reportingFetcherName},
+		"hosts":      []string{"alpha"},
+		"period":     "1h",
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	output := m.Start(done)
+	<-output
+
+	require.NoError(t, m.TriggerFetch(reportingFetcherName))
+	select {
+	case <-output:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received after triggering a fetch")
+	}
+
+	assert.Error(t, m.TriggerFetch("unknown"))
+}
+
+func TestWarmupFetchIsDiscarded(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":       moduleName,
+		"metricsets":   []string{reportingFetcherName},
+		"hosts":        []string{"alpha"},
+		"period":       "100ms",
+		"warmup_fetch": true,
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	start := time.Now()
+	output := m.Start(done)
+
+	// The first published event comes from the first periodic fetch.
+	<-output
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestWrapperWithScheduler(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{reportingFetcherName},
+		"hosts":      []string{"alpha"},
+	})
+
+	scheduler := &fakeScheduler{c: make(chan time.Time)}
+	m, err := module.NewWrapper(c, newTestRegistry(t), module.WithScheduler(
+		func(mb.MetricSet, time.Duration) module.Scheduler { return scheduler },
+	))
+	require.NoError(t, err)
+
+	output := m.Start(make(chan struct{}))
+
+	// There is no fetch on start, only when the scheduler fires.
+	select {
+	case event := <-output:
+		t.Fatalf("unexpected event before the scheduler fired: %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	scheduler.c <- time.Now()
+	<-output
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, m.Stop(ctx))
+	assert.True(t, scheduler.stopped.Load())
+}
+
+func TestWrapperStartWithContext(t *testing.T) {
+	c := newConfig(t, map[string]interface{}{
+		"module":     moduleName,
+		"metricsets": []string{reportingFetcherName},
+		"hosts":      []string{"alpha"},
+	})
+
+	m, err := module.NewWrapper(c, newTestRegistry(t))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	output := m.StartWithContext(ctx)
+
+	<-output
+	cancel()
+
+	// Validate that the channel is closed after the context is cancelled.
+	for range output {
+	}
+}
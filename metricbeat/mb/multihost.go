@@ -0,0 +1,131 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// HostFetcher is an optional interface for MetricSets that collect the metrics
+// of all the module's hosts in a single instance. When implemented, the
+// framework calls FetchHost for each configured host on every fetch instead of
+// the MetricSet Fetch method, running up to hosts_parallelism of these calls
+// concurrently. The MetricSet must still implement one of the periodic
+// fetcher interfaces.
+type HostFetcher interface {
+	MetricSet
+	FetchHost(ctx context.Context, host string, r ReporterV2) error
+}
+
+// FetchHostFunc fetches the metrics of a single host.
+type FetchHostFunc func(ctx context.Context, host string, r ReporterV2) error
+
+// FetchHosts calls fetch for each of the given hosts, running up to
+// parallelism calls concurrently, and waits for all of them to finish. The
+// events and errors reported for each host are forwarded to r, one at a time,
+// with their host set if it is empty. An error returned by fetch, or a panic,
+// is reported as an error event of its host. Hosts not yet fetched when ctx is
+// done are skipped. The credentials in the hosts are removed from the events
+// and errors.
+func FetchHosts(ctx context.Context, hosts []string, parallelism int, r ReporterV2, fetch FetchHostFunc) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var (
+		mu    sync.Mutex // Serializes the calls to r.
+		wg    sync.WaitGroup
+		slots = make(chan struct{}, parallelism)
+	)
+	for _, host := range hosts {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case slots <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(host string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			hr := hostReporter{host: redactHost(host), mu: &mu, r: r}
+			if err := fetchHost(ctx, host, hr, fetch); err != nil {
+				hr.Error(fmt.Errorf("error fetching host '%s': %w", hr.host, err))
+			}
+		}(host)
+	}
+	wg.Wait()
+}
+
+// fetchHost calls fetch for the host, returning panics as errors, as the
+// fetch runs in its own goroutine, where they would stop the Beat.
+func fetchHost(ctx context.Context, host string, r ReporterV2, fetch FetchHostFunc) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return fetch(ctx, host, r)
+}
+
+// redactHost removes the user information of the URLs in host, including its
+// fallback URLs, as it can contain credentials. Hosts without scheme, like
+// "user:password@host:port", are also redacted.
+func redactHost(host string) string {
+	urls := strings.Split(host, "|")
+	for i, u := range urls {
+		prefix, rest := "", u
+		if i := strings.Index(u, "://"); i >= 0 {
+			prefix, rest = u[:i+3], u[i+3:]
+		}
+		authority := rest
+		if end := strings.IndexAny(rest, "/?#"); end >= 0 {
+			authority = rest[:end]
+		}
+		if at := strings.LastIndex(authority, "@"); at >= 0 {
+			urls[i] = prefix + rest[at+1:]
+		}
+	}
+	return strings.Join(urls, "|")
+}
+
+// hostReporter is a ReporterV2 that forwards the events of a host to a shared
+// reporter.
+type hostReporter struct {
+	host string
+	mu   *sync.Mutex
+	r    ReporterV2
+}
+
+func (r hostReporter) Error(err error) bool { return r.Event(Event{Error: err}) }
+func (r hostReporter) Event(event Event) bool {
+	if event.Host == "" {
+		event.Host = r.host
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.Event(event)
+}
@@ -23,6 +23,19 @@ import (
 	"github.com/elastic/beats/v7/metricbeat/mb"
 )
 
+func init() {
+	// Host parsers that light modules, configurations and metricsets
+	// registered with mb.WithNamedHostParser can reference by name.
+	mb.Registry.MustAddHostParser("passthru", PassThruHostParser)
+	mb.Registry.MustAddHostParser("empty", EmptyHostParser)
+	mb.Registry.MustAddHostParser("tcp", URLHostParserBuilder{DefaultScheme: "tcp"}.Build())
+	mb.Registry.MustAddHostParser("http", URLHostParserBuilder{DefaultScheme: "http"}.Build())
+	mb.Registry.MustAddHostParser("https", URLHostParserBuilder{DefaultScheme: "https"}.Build())
+	mb.Registry.MustAddHostParser("http+unix", URLHostParserBuilder{DefaultScheme: "http+unix"}.Build())
+	mb.Registry.MustAddHostParser("npipe", URLHostParserBuilder{DefaultScheme: "http+npipe"}.Build())
+	mb.Registry.MustAddHostParser("grpc", URLHostParserBuilder{DefaultScheme: "grpc"}.Build())
+}
+
 // PassThruHostParser is a HostParser that sets the HostData URI, SanitizedURI,
 // and Host to the configured 'host' value. This should only be used by
 // MetricSets that do not require host parsing (e.g. host is only addr:port).
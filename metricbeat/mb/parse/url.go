@@ -28,6 +28,10 @@ import (
 	"github.com/elastic/beats/v7/metricbeat/mb"
 )
 
+// FallbackSeparator separates the equivalent URLs of a host, in host strings
+// parsed with ParseURLWithFallbacks.
+const FallbackSeparator = "|"
+
 // URLHostParserBuilder builds a tailored HostParser for used with host strings
 // that are URLs.
 type URLHostParserBuilder struct {
@@ -37,6 +41,11 @@ type URLHostParserBuilder struct {
 	DefaultPassword string
 	DefaultScheme   string
 	QueryParams     string
+
+	// Fallbacks enables host strings with fallback URLs, as described in
+	// ParseURLWithFallbacks. Only enable it for metricsets that fail over
+	// between the endpoints, like the ones using helper.HTTP.
+	Fallbacks bool
 }
 
 // Build returns a new HostParser function whose behavior is influenced by the
@@ -48,6 +57,7 @@ func (b URLHostParserBuilder) Build() mb.HostParser {
 		if err != nil {
 			return mb.HostData{}, err
 		}
+		mb.MarkSettingsRead(module, "query", "username", "password", b.PathConfigKey, "basepath")
 
 		query, ok := conf["query"]
 		if ok {
@@ -104,6 +114,9 @@ func (b URLHostParserBuilder) Build() mb.HostParser {
 		// Combine paths and normalize
 		fullPath := strings.Trim(p.Join(basePath, path), "/")
 
+		if b.Fallbacks {
+			return ParseURLWithFallbacks(host, b.DefaultScheme, user, pass, fullPath, b.QueryParams)
+		}
 		return ParseURL(host, b.DefaultScheme, user, pass, fullPath, b.QueryParams)
 	}
 }
@@ -153,6 +166,27 @@ func ParseURL(rawHost, scheme, user, pass, path, query string) (mb.HostData, err
 	return NewHostDataFromURLWithTransport(transport, u), nil
 }
 
+// ParseURLWithFallbacks is like ParseURL, but the raw 'host' value can contain
+// several equivalent URLs separated by FallbackSeparator, for example
+// "http://es1:9200|http://es2:9200". The first URL is the primary one, the
+// others are parsed with the same defaults into the Fallbacks of the returned
+// HostData.
+func ParseURLWithFallbacks(rawHost, scheme, user, pass, path, query string) (mb.HostData, error) {
+	rawHosts := strings.Split(rawHost, FallbackSeparator)
+	hostData, err := ParseURL(strings.TrimSpace(rawHosts[0]), scheme, user, pass, path, query)
+	if err != nil {
+		return mb.HostData{}, err
+	}
+	for _, raw := range rawHosts[1:] {
+		fallback, err := ParseURL(strings.TrimSpace(raw), scheme, user, pass, path, query)
+		if err != nil {
+			return mb.HostData{}, fmt.Errorf("invalid fallback: %w", err)
+		}
+		hostData.Fallbacks = append(hostData.Fallbacks, fallback)
+	}
+	return hostData, nil
+}
+
 // SetURLUser set the user credentials in the given URL. If the username or
 // password is not set in the URL then the default is used (if provided).
 func SetURLUser(u *url.URL, defaultUser, defaultPass string) {
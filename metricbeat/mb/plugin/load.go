@@ -0,0 +1,266 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	goplugin "github.com/hashicorp/go-plugin"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// describeTimeout is the timeout to get the metricsets of a plugin.
+const describeTimeout = 10 * time.Second
+
+// Config is the configuration of the plugins.
+type Config struct {
+	// Path is the directory of the plugins.
+	Path string `config:"path"`
+
+	// Checksums are the SHA-256 checksums of the plugins, in hex, by file
+	// name. If set, only the plugins listed are loaded, and they are not
+	// started if their checksum doesn't match.
+	Checksums map[string]string `config:"checksums"`
+}
+
+// Validate validates the checksums.
+func (c *Config) Validate() error {
+	for name, sum := range c.Checksums {
+		if _, err := decodeChecksum(sum); err != nil {
+			return fmt.Errorf("invalid checksum of plugin '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Plugins are the plugins started by Load.
+type Plugins struct {
+	clients []*goplugin.Client
+}
+
+// Load starts the plugins in the directory and registers their metricsets in
+// the register, so they can be configured as any other metricset. Plugins
+// are executables that call Serve. Each plugin serves a single module, that
+// must not be already registered. With strict permissions, the directory and
+// the plugins must be owned by the user or root, and only writable by their
+// owner.
+func Load(r *mb.Register, config Config) (*Plugins, error) {
+	logger := logp.NewLogger("plugin")
+	plugins := &Plugins{}
+
+	dir := config.Path
+	if common.IsStrictPerms() {
+		if err := common.OwnerHasExclusiveWritePerms(dir); err != nil {
+			return nil, fmt.Errorf("invalid plugins directory: %w", err)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugins directory: %w", err)
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if !isPlugin(entry) {
+			logger.Debugf("Skipping %s, it is not an executable", path)
+			continue
+		}
+
+		var secure *goplugin.SecureConfig
+		if len(config.Checksums) > 0 {
+			sum, found := config.Checksums[entry.Name()]
+			if !found {
+				logger.Warnf("Skipping %s, it has no checksum configured", path)
+				continue
+			}
+			checksum, err := decodeChecksum(sum)
+			if err != nil {
+				plugins.Close()
+				return nil, fmt.Errorf("invalid checksum of plugin '%s': %w", path, err)
+			}
+			secure = &goplugin.SecureConfig{Checksum: checksum, Hash: sha256.New()}
+		}
+		if common.IsStrictPerms() {
+			if err := common.OwnerHasExclusiveWritePerms(path); err != nil {
+				plugins.Close()
+				return nil, fmt.Errorf("invalid plugin '%s': %w", path, err)
+			}
+		}
+
+		logger.Infof("Loading plugin %s", path)
+		client, err := load(r, path, secure, logger)
+		if err != nil {
+			plugins.Close()
+			return nil, fmt.Errorf("failed to load plugin '%s': %w", path, err)
+		}
+		plugins.clients = append(plugins.clients, client)
+	}
+	return plugins, nil
+}
+
+// Close stops the plugins.
+func (p *Plugins) Close() {
+	if p == nil {
+		return
+	}
+	for _, client := range p.clients {
+		client.Kill()
+	}
+	p.clients = nil
+}
+
+func load(r *mb.Register, path string, secure *goplugin.SecureConfig, logger *logp.Logger) (*goplugin.Client, error) {
+	client := goplugin.NewClient(&goplugin.ClientConfig{
+		HandshakeConfig:  Handshake,
+		Plugins:          goplugin.PluginSet{pluginName: &grpcPlugin{}},
+		Cmd:              exec.Command(path),
+		SecureConfig:     secure,
+		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
+		Logger: hclog.New(&hclog.LoggerOptions{
+			Name:        filepath.Base(path),
+			Output:      logWriter{logger},
+			DisableTime: true,
+		}),
+	})
+
+	svc, err := dispense(client)
+	if err == nil {
+		err = register(r, svc)
+	}
+	if err != nil {
+		client.Kill()
+		return nil, err
+	}
+	return client, nil
+}
+
+func dispense(client *goplugin.Client) (service, error) {
+	protocol, err := client.Client()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := protocol.Dispense(pluginName)
+	if err != nil {
+		return nil, err
+	}
+	svc, ok := raw.(service)
+	if !ok {
+		return nil, errors.New("plugin doesn't serve metricsets")
+	}
+	return svc, nil
+}
+
+// register registers the metricsets served by the plugin in the register. It
+// fails if the module of the plugin is already registered, so plugins cannot
+// add metricsets to other modules. The names are checked before registering
+// any metricset, so none is left registered when it fails.
+func register(r *mb.Register, svc service) error {
+	ctx, cancel := context.WithTimeout(context.Background(), describeTimeout)
+	defer cancel()
+
+	module, err := svc.Describe(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to describe the metricsets: %w", err)
+	}
+	if err := checkNames(r, module); err != nil {
+		return err
+	}
+	for _, ms := range module.MetricSets {
+		var options []mb.MetricSetOption
+		if ms.Default {
+			options = append(options, mb.DefaultMetricSet())
+		}
+		if ms.Namespace != "" {
+			options = append(options, mb.WithNamespace(ms.Namespace))
+		}
+		if err := r.AddMetricSetWithOptions(module.Name, ms.Name, newMetricSetFactory(svc), options...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkNames returns an error if the module or the metricsets served by the
+// plugin cannot be registered.
+func checkNames(r *mb.Register, module mb.ModuleDescription) error {
+	if module.Name == "" {
+		return errors.New("module name is required")
+	}
+	for _, name := range r.Modules() {
+		if strings.EqualFold(name, module.Name) {
+			return fmt.Errorf("module '%s' is already registered", name)
+		}
+	}
+	names := map[string]bool{}
+	for _, ms := range module.MetricSets {
+		name := strings.ToLower(ms.Name)
+		if name == "" {
+			return fmt.Errorf("metricset name of module '%s' is required", module.Name)
+		}
+		if names[name] {
+			return fmt.Errorf("metricset '%s/%s' is served more than once", module.Name, name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+func decodeChecksum(sum string) ([]byte, error) {
+	checksum, err := hex.DecodeString(sum)
+	if err != nil {
+		return nil, err
+	}
+	if len(checksum) != sha256.Size {
+		return nil, fmt.Errorf("expected a SHA-256 checksum of %d bytes, got %d", sha256.Size, len(checksum))
+	}
+	return checksum, nil
+}
+
+func isPlugin(entry os.DirEntry) bool {
+	if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(entry.Name()), ".exe")
+	}
+	info, err := entry.Info()
+	return err == nil && info.Mode().Perm()&0o111 != 0
+}
+
+// logWriter writes the logs of go-plugin and of the plugins to the logger.
+type logWriter struct {
+	logger *logp.Logger
+}
+
+func (w logWriter) Write(p []byte) (int, error) {
+	w.logger.Info(strings.TrimSpace(string(p)))
+	return len(p), nil
+}
@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// metricSet is a metricset served by a plugin. Each instance has its own
+// instance in the plugin, created with the configuration of the module.
+type metricSet struct {
+	mb.BaseMetricSet
+	svc service
+	id  string
+}
+
+func newMetricSetFactory(svc service) mb.MetricSetFactory {
+	return func(base mb.BaseMetricSet) (mb.MetricSet, error) {
+		var config map[string]interface{}
+		if err := base.Module().UnpackConfig(&config); err != nil {
+			return nil, err
+		}
+
+		resp, err := svc.New(context.Background(), newRequest{
+			MetricSet: base.Name(),
+			Host:      base.Host(),
+			Config:    config,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("plugin failed to create metricset '%s/%s': %w", base.Module().Name(), base.Name(), err)
+		}
+		return &metricSet{BaseMetricSet: base, svc: svc, id: resp.ID}, nil
+	}
+}
+
+// Fetch fetches the metricset in the plugin, and reports the events it
+// reported there.
+func (m *metricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
+	resp, err := m.svc.Fetch(ctx, instanceRequest{ID: m.id})
+	if err != nil {
+		return err
+	}
+
+	for _, e := range resp.Events {
+		if e.Error != "" && e.RootFields == nil && e.ModuleFields == nil && e.MetricSetFields == nil {
+			if !reporter.Error(errors.New(e.Error)) {
+				return nil
+			}
+			continue
+		}
+
+		event := mb.Event{
+			Timestamp:       e.Timestamp,
+			RootFields:      e.RootFields,
+			ModuleFields:    e.ModuleFields,
+			MetricSetFields: e.MetricSetFields,
+			Namespace:       e.Namespace,
+			Took:            e.Took,
+			ID:              e.ID,
+		}
+		if e.Error != "" {
+			event.Error = errors.New(e.Error)
+		}
+		if !reporter.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Close closes the instance of the metricset in the plugin.
+func (m *metricSet) Close() error {
+	return m.svc.Close(context.Background(), instanceRequest{ID: m.id})
+}
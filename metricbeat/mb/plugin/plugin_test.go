@@ -0,0 +1,226 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	goplugin "github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type testMetricSet struct {
+	mb.BaseMetricSet
+	Status string `config:"status"`
+	closed *bool
+}
+
+func (m *testMetricSet) Fetch(r mb.ReporterV2) error {
+	if m.Status == "down" {
+		return errors.New("service is down")
+	}
+	r.Event(mb.Event{
+		MetricSetFields: mapstr.M{
+			"host":     m.Host(),
+			"status":   m.Status,
+			"requests": int64(1) << 60,
+			"load":     0.5,
+		},
+	})
+	return nil
+}
+
+func (m *testMetricSet) Close() error {
+	*m.closed = true
+	return nil
+}
+
+func newTestService(t *testing.T, closed *bool) service {
+	r := mb.NewRegister()
+	r.MustAddMetricSet("example", "status", func(base mb.BaseMetricSet) (mb.MetricSet, error) {
+		m := &testMetricSet{BaseMetricSet: base, Status: "up", closed: closed}
+		return m, base.Module().UnpackConfig(m)
+	}, mb.DefaultMetricSet(), mb.WithNamespace("example.service.status"))
+
+	client, _ := goplugin.TestPluginGRPCConn(t, false, goplugin.PluginSet{
+		pluginName: &grpcPlugin{srv: newServer(r, "example")},
+	})
+	t.Cleanup(func() { client.Close() })
+
+	raw, err := client.Dispense(pluginName)
+	require.NoError(t, err)
+	return raw.(service)
+}
+
+func TestPluginMetricSets(t *testing.T) {
+	var closed bool
+	r := mb.NewRegister()
+	require.NoError(t, register(r, newTestService(t, &closed)))
+
+	defaults, err := r.DefaultMetricSets("example")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"status"}, defaults)
+
+	ms := mbtest.NewMetricSetWithRegistry(t, map[string]interface{}{
+		"module":     "example",
+		"metricsets": []string{"status"},
+		"hosts":      []string{"localhost:8080"},
+		"status":     "degraded",
+	}, r)
+	assert.Equal(t, "example.service.status", ms.Registration().Namespace)
+
+	events, errs := mbtest.ReportingFetchV2WithContext(ms.(mb.ReportingMetricSetV2WithContext))
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+	assert.Equal(t, mapstr.M{
+		"host":     "localhost:8080",
+		"status":   "degraded",
+		"requests": int64(1) << 60,
+		"load":     0.5,
+	}, events[0].MetricSetFields)
+
+	require.NoError(t, ms.(mb.Closer).Close())
+	assert.True(t, closed)
+
+	ms = mbtest.NewMetricSetWithRegistry(t, map[string]interface{}{
+		"module":     "example",
+		"metricsets": []string{"status"},
+		"status":     "down",
+	}, r)
+	_, errs = mbtest.ReportingFetchV2WithContext(ms.(mb.ReportingMetricSetV2WithContext))
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "service is down")
+}
+
+func TestPluginModuleAlreadyRegistered(t *testing.T) {
+	var closed bool
+	r := mb.NewRegister()
+	r.MustAddMetricSet("example", "status", func(base mb.BaseMetricSet) (mb.MetricSet, error) {
+		return nil, errors.New("not implemented")
+	})
+
+	err := register(r, newTestService(t, &closed))
+	assert.ErrorContains(t, err, "already registered")
+}
+
+func TestPluginModuleWithOtherMetricSets(t *testing.T) {
+	var closed bool
+	r := mb.NewRegister()
+	r.MustAddMetricSet("example", "other", func(base mb.BaseMetricSet) (mb.MetricSet, error) {
+		return nil, errors.New("not implemented")
+	})
+
+	err := register(r, newTestService(t, &closed))
+	assert.EqualError(t, err, "module 'example' is already registered")
+	assert.Equal(t, []string{"other"}, r.MetricSets("example"))
+}
+
+// describeService is a service that only describes its module.
+type describeService struct {
+	service
+	module mb.ModuleDescription
+}
+
+func (s describeService) Describe(context.Context) (mb.ModuleDescription, error) {
+	return s.module, nil
+}
+
+func TestPluginInvalidMetricSetNames(t *testing.T) {
+	r := mb.NewRegister()
+	err := register(r, describeService{module: mb.ModuleDescription{
+		Name: "example",
+		MetricSets: []mb.MetricSetDescription{
+			{Name: "status"},
+			{Name: "info"},
+			{Name: "Status"},
+		},
+	}})
+	assert.EqualError(t, err, "metricset 'example/status' is served more than once")
+	assert.Empty(t, r.MetricSets("example"))
+
+	err = register(r, describeService{module: mb.ModuleDescription{
+		Name:       "example",
+		MetricSets: []mb.MetricSetDescription{{Name: "status"}, {Name: ""}},
+	}})
+	assert.EqualError(t, err, "metricset name of module 'example' is required")
+	assert.Empty(t, r.MetricSets("example"))
+}
+
+func writePlugin(t *testing.T, dir, name string, perm os.FileMode) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are identified by their exec bit")
+	}
+	content := []byte("#!/bin/sh\nexit 1\n")
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, content, perm))
+	require.NoError(t, os.Chmod(path, perm))
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestLoadStrictPerms(t *testing.T) {
+	dir := t.TempDir()
+	writePlugin(t, dir, "example", 0o777)
+
+	_, err := Load(mb.NewRegister(), Config{Path: dir})
+	assert.ErrorContains(t, err, "invalid plugin")
+	assert.ErrorContains(t, err, "can only be writable by the owner")
+}
+
+func TestLoadChecksums(t *testing.T) {
+	dir := t.TempDir()
+	sum := writePlugin(t, dir, "example", 0o755)
+
+	t.Run("unlisted plugins are skipped", func(t *testing.T) {
+		plugins, err := Load(mb.NewRegister(), Config{Path: dir, Checksums: map[string]string{
+			"other": sum,
+		}})
+		require.NoError(t, err)
+		assert.Empty(t, plugins.clients)
+	})
+
+	t.Run("plugins not matching their checksum are not started", func(t *testing.T) {
+		other := sha256.Sum256([]byte("other"))
+		_, err := Load(mb.NewRegister(), Config{Path: dir, Checksums: map[string]string{
+			"example": hex.EncodeToString(other[:]),
+		}})
+		assert.ErrorIs(t, err, goplugin.ErrChecksumsDoNotMatch)
+	})
+}
+
+func TestConfigValidate(t *testing.T) {
+	config := Config{Checksums: map[string]string{"example": "abcd"}}
+	assert.ErrorContains(t, config.Validate(), "invalid checksum of plugin 'example'")
+
+	sum := sha256.Sum256(nil)
+	config = Config{Checksums: map[string]string{"example": hex.EncodeToString(sum[:])}}
+	assert.NoError(t, config.Validate())
+}
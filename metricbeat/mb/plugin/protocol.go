@@ -0,0 +1,248 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	goplugin "github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Handshake is the handshake shared by Metricbeat and its plugins. Plugins
+// refuse to run when they are not started by Metricbeat.
+var Handshake = goplugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "METRICBEAT_PLUGIN",
+	MagicCookieValue: "metricsets",
+}
+
+// pluginName is the name under which plugins serve their metricsets.
+const pluginName = "metricsets"
+
+// The service of the plugins is described here instead of in a protobuf
+// definition. Its messages are JSON documents in BytesValue messages, so the
+// fields of events don't lose their types as they would in Struct messages.
+const serviceName = "metricbeat.plugin.v1.MetricSets"
+
+// newRequest is the request of New, that creates an instance of a metricset
+// for a host. Its response is the ID of the instance.
+type newRequest struct {
+	MetricSet string                 `json:"metricset"`
+	Host      string                 `json:"host,omitempty"`
+	Config    map[string]interface{} `json:"config"`
+}
+
+type newResponse struct {
+	ID string `json:"id"`
+}
+
+// instanceRequest is the request of Fetch and Close.
+type instanceRequest struct {
+	ID string `json:"id"`
+}
+
+// fetchResponse is the response of Fetch.
+type fetchResponse struct {
+	Events []event `json:"events"`
+}
+
+// event is an mb.Event sent by a plugin.
+type event struct {
+	Timestamp       time.Time     `json:"timestamp"`
+	RootFields      mapstr.M      `json:"root_fields,omitempty"`
+	ModuleFields    mapstr.M      `json:"module_fields,omitempty"`
+	MetricSetFields mapstr.M      `json:"metricset_fields,omitempty"`
+	Namespace       string        `json:"namespace,omitempty"`
+	Took            time.Duration `json:"took,omitempty"`
+	ID              string        `json:"id,omitempty"`
+	Error           string        `json:"error,omitempty"`
+}
+
+// service is implemented by the server of the plugins.
+type service interface {
+	Describe(ctx context.Context) (mb.ModuleDescription, error)
+	New(ctx context.Context, req newRequest) (newResponse, error)
+	Fetch(ctx context.Context, req instanceRequest) (fetchResponse, error)
+	Close(ctx context.Context, req instanceRequest) error
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*service)(nil),
+	Methods: []grpc.MethodDesc{
+		method("Describe", func(s service, ctx context.Context, _ struct{}) (mb.ModuleDescription, error) {
+			return s.Describe(ctx)
+		}),
+		method("New", service.New),
+		method("Fetch", service.Fetch),
+		method("Close", func(s service, ctx context.Context, req instanceRequest) (struct{}, error) {
+			return struct{}{}, s.Close(ctx, req)
+		}),
+	},
+}
+
+// method returns the description of a method of the service, that decodes
+// its request and encodes its response as JSON.
+func method[Req, Resp any](name string, call func(service, context.Context, Req) (Resp, error)) grpc.MethodDesc {
+	handle := func(ctx context.Context, srv interface{}, in *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error) {
+		var req Req
+		if err := decode(in.GetValue(), &req); err != nil {
+			return nil, err
+		}
+		resp, err := call(srv.(service), ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return encode(resp)
+	}
+
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			in := new(wrapperspb.BytesValue)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			if interceptor == nil {
+				return handle(ctx, srv, in)
+			}
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/" + name}
+			return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return handle(ctx, srv, req.(*wrapperspb.BytesValue))
+			})
+		},
+	}
+}
+
+// serviceClient calls the service of a plugin.
+type serviceClient struct {
+	conn *grpc.ClientConn
+}
+
+func (c *serviceClient) Describe(ctx context.Context) (info mb.ModuleDescription, err error) {
+	err = c.invoke(ctx, "Describe", struct{}{}, &info)
+	return info, err
+}
+
+func (c *serviceClient) New(ctx context.Context, req newRequest) (resp newResponse, err error) {
+	err = c.invoke(ctx, "New", req, &resp)
+	return resp, err
+}
+
+func (c *serviceClient) Fetch(ctx context.Context, req instanceRequest) (resp fetchResponse, err error) {
+	err = c.invoke(ctx, "Fetch", req, &resp)
+	return resp, err
+}
+
+func (c *serviceClient) Close(ctx context.Context, req instanceRequest) error {
+	return c.invoke(ctx, "Close", req, &struct{}{})
+}
+
+func (c *serviceClient) invoke(ctx context.Context, name string, req, resp interface{}) error {
+	in, err := encode(req)
+	if err != nil {
+		return err
+	}
+	out := new(wrapperspb.BytesValue)
+	if err := c.conn.Invoke(ctx, "/"+serviceName+"/"+name, in, out); err != nil {
+		return err
+	}
+	return decode(out.GetValue(), resp)
+}
+
+// grpcPlugin is the go-plugin plugin of the metricsets, it serves srv in
+// plugins.
+type grpcPlugin struct {
+	goplugin.NetRPCUnsupportedPlugin
+
+	srv service
+}
+
+func (p *grpcPlugin) GRPCServer(_ *goplugin.GRPCBroker, s *grpc.Server) error {
+	s.RegisterService(&serviceDesc, p.srv)
+	return nil
+}
+
+func (p *grpcPlugin) GRPCClient(_ context.Context, _ *goplugin.GRPCBroker, conn *grpc.ClientConn) (interface{}, error) {
+	return &serviceClient{conn: conn}, nil
+}
+
+func encode(v interface{}) (*wrapperspb.BytesValue, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return wrapperspb.Bytes(data), nil
+}
+
+// decode decodes JSON data, keeping integer numbers as int64.
+func decode(data []byte, v interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case *fetchResponse:
+		for _, e := range v.Events {
+			convertNumbers(e.RootFields)
+			convertNumbers(e.ModuleFields)
+			convertNumbers(e.MetricSetFields)
+		}
+	case *newRequest:
+		convertNumbers(v.Config)
+	}
+	return nil
+}
+
+// convertNumbers replaces the json.Number values decoded in the map by int64
+// or float64 values.
+func convertNumbers(m map[string]interface{}) {
+	for k, v := range m {
+		m[k] = convertNumber(v)
+	}
+}
+
+func convertNumber(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		convertNumbers(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = convertNumber(v[i])
+		}
+	}
+	return v
+}
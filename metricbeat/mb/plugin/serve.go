@@ -0,0 +1,197 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	goplugin "github.com/hashicorp/go-plugin"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+// Serve serves the metricsets of the module registered in the register, it
+// is called from the main function of plugins, and doesn't return until
+// Metricbeat stops the plugin. The metricsets are created and fetched as in
+// Metricbeat, but they must implement ReportingMetricSetV2,
+// ReportingMetricSetV2Error or ReportingMetricSetV2WithContext.
+//
+//	func main() {
+//		r := mb.NewRegister()
+//		r.MustAddMetricSet("example", "status", New, mb.DefaultMetricSet())
+//		plugin.Serve(r, "example")
+//	}
+func Serve(r *mb.Register, module string) {
+	goplugin.Serve(&goplugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins: goplugin.PluginSet{
+			pluginName: &grpcPlugin{srv: newServer(r, module)},
+		},
+		GRPCServer: goplugin.DefaultGRPCServer,
+	})
+}
+
+// server is the service of a plugin. It keeps the instances of the
+// metricsets created by Metricbeat.
+type server struct {
+	register *mb.Register
+	module   string
+
+	mu        sync.Mutex
+	lastID    int
+	instances map[string]mb.MetricSet
+}
+
+func newServer(r *mb.Register, module string) *server {
+	return &server{
+		register:  r,
+		module:    strings.ToLower(module),
+		instances: map[string]mb.MetricSet{},
+	}
+}
+
+func (s *server) Describe(context.Context) (mb.ModuleDescription, error) {
+	for _, module := range s.register.Describe() {
+		if module.Name == s.module && len(module.MetricSets) > 0 {
+			return module, nil
+		}
+	}
+	return mb.ModuleDescription{}, fmt.Errorf("module '%s' has no metricsets", s.module)
+}
+
+func (s *server) New(_ context.Context, req newRequest) (newResponse, error) {
+	config := req.Config
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+	config["module"] = s.module
+	config["metricsets"] = []string{req.MetricSet}
+	delete(config, "hosts")
+	if req.Host != "" {
+		config["hosts"] = []string{req.Host}
+	}
+
+	c, err := conf.NewConfigFrom(config)
+	if err != nil {
+		return newResponse{}, err
+	}
+	_, metricSets, err := mb.NewModule(c, s.register)
+	if err != nil {
+		return newResponse{}, err
+	}
+	if len(metricSets) != 1 {
+		return newResponse{}, fmt.Errorf("expected one instance of metricset '%s', got %d", req.MetricSet, len(metricSets))
+	}
+	ms := metricSets[0]
+	switch ms.(type) {
+	case mb.ReportingMetricSetV2, mb.ReportingMetricSetV2Error, mb.ReportingMetricSetV2WithContext:
+	default:
+		if closer, ok := ms.(mb.Closer); ok {
+			_ = closer.Close()
+		}
+		return newResponse{}, fmt.Errorf("metricset '%s/%s' does not implement a supported fetch interface", s.module, req.MetricSet)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastID++
+	id := strconv.Itoa(s.lastID)
+	s.instances[id] = ms
+	return newResponse{ID: id}, nil
+}
+
+func (s *server) Fetch(ctx context.Context, req instanceRequest) (fetchResponse, error) {
+	ms, err := s.instance(req.ID)
+	if err != nil {
+		return fetchResponse{}, err
+	}
+
+	reporter := &eventsReporter{ctx: ctx}
+	switch ms := ms.(type) {
+	case mb.ReportingMetricSetV2WithContext:
+		err = ms.Fetch(ctx, reporter)
+	case mb.ReportingMetricSetV2Error:
+		err = ms.Fetch(reporter)
+	case mb.ReportingMetricSetV2:
+		ms.Fetch(reporter)
+	}
+	if err != nil {
+		reporter.Error(err)
+	}
+	return fetchResponse{Events: reporter.events}, nil
+}
+
+func (s *server) Close(_ context.Context, req instanceRequest) error {
+	ms, err := s.instance(req.ID)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	delete(s.instances, req.ID)
+	s.mu.Unlock()
+
+	if closer, ok := ms.(mb.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (s *server) instance(id string) (mb.MetricSet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ms, found := s.instances[id]
+	if !found {
+		return nil, fmt.Errorf("unknown metricset instance '%s'", id)
+	}
+	return ms, nil
+}
+
+// eventsReporter is the ReporterV2 of the metricsets of plugins, it keeps
+// the events of a fetch to send them to Metricbeat.
+type eventsReporter struct {
+	ctx    context.Context
+	events []event
+}
+
+func (r *eventsReporter) Event(e mb.Event) bool {
+	ev := event{
+		Timestamp:       e.Timestamp,
+		RootFields:      e.RootFields,
+		ModuleFields:    e.ModuleFields,
+		MetricSetFields: e.MetricSetFields,
+		Namespace:       e.Namespace,
+		Took:            e.Took,
+		ID:              e.ID,
+	}
+	if e.Error != nil {
+		ev.Error = e.Error.Error()
+	}
+	r.events = append(r.events, ev)
+	return r.ctx.Err() == nil
+}
+
+func (r *eventsReporter) Error(err error) bool {
+	return r.Event(mb.Event{Error: err})
+}
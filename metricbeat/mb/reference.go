@@ -0,0 +1,330 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+// referenceModuleSettings are the settings of ModuleConfig included in the
+// reference configuration of every module. The other settings of
+// ModuleConfig are common to all the modules and are not included.
+var referenceModuleSettings = []string{"metricsets", "enabled", "period", "hosts"}
+
+// referenceHost is the host of the MetricSets created to generate the
+// reference configuration that require hosts. MetricSets are not expected to
+// connect to their hosts when they are created.
+const referenceHost = "localhost"
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// ModuleReference returns the reference configuration of a module, generated
+// from the config structs of the module and of its MetricSets: each setting is
+// listed commented out with its default value and type, preceded by the
+// description in the doc tag of its field, if any. For example:
+//
+//	// Path to the server status page.
+//	StatusPath string `config:"server_status_path" doc:"Path to the server status page."`
+//
+// The structs are the ones unpacked with UnpackConfig when the Module and
+// each of its MetricSets are created, with the values they have before they
+// are unpacked as defaults. MetricSets that can't be created without hosts
+// are created with a localhost host, the ones that can't be created either
+// and don't unpack any struct are reported in a comment.
+func ModuleReference(r *Register, module string) ([]byte, error) {
+	metricSets := r.MetricSets(module)
+	if len(metricSets) == 0 {
+		return nil, fmt.Errorf("unknown module '%s'", module)
+	}
+	sort.Strings(metricSets)
+
+	ref := referenceWriter{seen: map[string]bool{}, visiting: map[reflect.Type]bool{}}
+	defaults := DefaultModuleConfig()
+	moduleConfig := reflect.ValueOf(&defaults).Elem()
+	// All the settings of ModuleConfig are read by every module, so the
+	// structs of the MetricSets that declare them don't add them again.
+	fmt.Fprintf(&ref.buf, "- module: %s\n", module)
+	for _, name := range referenceModuleSettings {
+		field, value := configField(moduleConfig, name)
+		switch name {
+		case "metricsets":
+			value = reflect.ValueOf(metricSets)
+		case "hosts":
+			ref.setting(name, field, value)
+			continue
+		}
+		fmt.Fprintf(&ref.buf, "  %s: %s\n", name, formatReferenceValue(value))
+	}
+	for i := 0; i < moduleConfig.NumField(); i++ {
+		name, _, _ := strings.Cut(moduleConfig.Type().Field(i).Tag.Get("config"), ",")
+		ref.seen[name] = true
+	}
+
+	for _, ms := range metricSets {
+		recorder, err := recordMetricSetSettings(r, module, ms)
+		if err != nil && len(recorder.defaults) == 0 {
+			fmt.Fprintf(&ref.buf, "\n  # The settings of the %s metricset can't be generated: %s\n",
+				ms, strings.ReplaceAll(err.Error(), "\n", " "))
+			ref.started = false
+			continue
+		}
+		for _, v := range recorder.defaults {
+			ref.addStruct("", v)
+		}
+	}
+	return ref.buf.Bytes(), nil
+}
+
+// recordMetricSetSettings creates the Module and a MetricSet without hosts,
+// or with referenceHost if they can't be created without hosts, and returns
+// the recorder of their settings. The structs unpacked before the creation
+// failed are recorded if both attempts fail.
+func recordMetricSetSettings(r *Register, module, metricSet string) (*settingsRecorder, error) {
+	var (
+		best    *settingsRecorder
+		lastErr error
+	)
+	for _, hosts := range [][]string{nil, {referenceHost}} {
+		config, err := conf.NewConfigFrom(map[string]interface{}{
+			"module":     module,
+			"metricsets": []string{metricSet},
+			"hosts":      hosts,
+		})
+		if err != nil {
+			return nil, err
+		}
+		recorder := &settingsRecorder{}
+		lastErr = recordSettings(config, r, recorder, false)
+		if lastErr == nil {
+			return recorder, nil
+		}
+		if best == nil || len(recorder.defaults) > len(best.defaults) {
+			best = recorder
+		}
+	}
+	return best, lastErr
+}
+
+// configField returns the field of a struct value with the given config name.
+func configField(v reflect.Value, name string) (reflect.StructField, reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if n, _, _ := strings.Cut(field.Tag.Get("config"), ","); n == name {
+			return field, v.Field(i)
+		}
+	}
+	return reflect.StructField{}, reflect.Value{}
+}
+
+// referenceWriter writes the settings of a reference configuration, only once
+// each.
+type referenceWriter struct {
+	buf      bytes.Buffer
+	seen     map[string]bool
+	visiting map[reflect.Type]bool // Structs being written, to stop at recursive types.
+	started  bool                  // Set to true when a setting is written after a blank line.
+}
+
+// addStruct writes the settings of the fields of a struct value, with the
+// given prefix. Nested structs are written as dotted settings.
+func (w *referenceWriter) addStruct(prefix string, v reflect.Value) {
+	t := v.Type()
+	if w.visiting[t] {
+		return
+	}
+	w.visiting[t] = true
+	defer delete(w.visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup("config")
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		value := v.Field(i)
+		if strings.Contains(","+opts+",", ",inline,") || (field.Anonymous && name == "") {
+			if s, ok := referenceStruct(value); ok {
+				w.addStruct(prefix, s)
+			}
+			continue
+		}
+		// Fields without config tags can be unpacked, but they are not
+		// documented settings, like the fields of library structs.
+		if !hasTag || name == "" || !isReferenceType(field.Type) {
+			continue
+		}
+		name = joinPath(prefix, name)
+		if s, ok := referenceStruct(value); ok {
+			w.addStruct(name, s)
+			continue
+		}
+		w.setting(name, field, value)
+	}
+}
+
+// setting writes a setting commented out, with its default value and type.
+func (w *referenceWriter) setting(name string, field reflect.StructField, value reflect.Value) {
+	if w.seen[name] {
+		return
+	}
+	w.seen[name] = true
+
+	// Settings with a description are separated from the previous ones.
+	doc := field.Tag.Get("doc")
+	if doc != "" || !w.started {
+		w.buf.WriteString("\n")
+		w.started = true
+	}
+	if doc != "" {
+		fmt.Fprintf(&w.buf, "  # %s\n", doc)
+	}
+	formatted := formatReferenceValue(value)
+	if formatted != "" {
+		formatted = " " + formatted
+	}
+	fmt.Fprintf(&w.buf, "  #%s:%s # %s\n", name, formatted, referenceTypeName(field.Type))
+}
+
+// isReferenceType returns false for the types that can't be configured, like
+// functions and channels.
+func isReferenceType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	return t.Kind() != reflect.Func && t.Kind() != reflect.Chan && t.Kind() != reflect.UnsafePointer
+}
+
+// referenceStruct returns the value of a struct whose fields are settings,
+// dereferencing pointers, or false if the value is not such a struct. Nil
+// pointers return the zero value of the struct.
+func referenceStruct(v reflect.Value) (reflect.Value, bool) {
+	t := v.Type()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		if v.IsValid() && !v.IsNil() {
+			v = v.Elem()
+		} else {
+			v = reflect.Value{}
+		}
+	}
+	if t.Kind() != reflect.Struct || t == configType || hasUnpackMethod(t) {
+		return reflect.Value{}, false
+	}
+	if !v.IsValid() {
+		v = reflect.New(t).Elem()
+	}
+	return v, true
+}
+
+// formatReferenceValue formats a default value as YAML. Values that can't be
+// formatted, like nil values, are formatted as empty, that is null in YAML.
+func formatReferenceValue(v reflect.Value) string {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ""
+	}
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+	if v.CanInterface() {
+		if s, ok := v.Interface().(fmt.Stringer); ok && v.Kind() != reflect.Slice && v.Kind() != reflect.Map {
+			return quoteReferenceString(s.String())
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return quoteReferenceString(v.String())
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v.Interface())
+	case reflect.Slice, reflect.Array:
+		items := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, formatReferenceValue(v.Index(i)))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Map:
+		items := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			items = append(items, fmt.Sprintf("%s: %s", formatReferenceValue(k), formatReferenceValue(v.MapIndex(k))))
+		}
+		sort.Strings(items)
+		return "{" + strings.Join(items, ", ") + "}"
+	}
+	return ""
+}
+
+func quoteReferenceString(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
+
+// referenceTypeName returns the name of the type of a setting, as written in
+// the reference configuration.
+func referenceTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == durationType:
+		return "duration"
+	case t == configType:
+		return "config"
+	case t.Kind() != reflect.Struct && (t.Implements(stringerType) || reflect.PointerTo(t).Implements(stringerType)):
+		// Enums are configured with the names they are formatted with.
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "uint"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.Slice, reflect.Array:
+		return "list of " + referenceTypeName(t.Elem())
+	case reflect.Map:
+		return "map of " + referenceTypeName(t.Elem())
+	case reflect.Interface:
+		return "any"
+	}
+	return "object"
+}
@@ -0,0 +1,100 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package mb
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type referenceMetricSet struct {
+	BaseMetricSet
+}
+
+func (m *referenceMetricSet) Fetch(r ReporterV2) error { return nil }
+
+func TestModuleReference(t *testing.T) {
+	r := NewRegister()
+	require.NoError(t, r.AddMetricSet("reference", "status", func(base BaseMetricSet) (MetricSet, error) {
+		config := struct {
+			Hosts    []string      `config:"hosts"`
+			Username string        `config:"username" doc:"Username of the server."`
+			Interval time.Duration `config:"stats.interval"`
+			Tags     []string      `config:"tags"`
+			Headers  map[string]string
+			Client   *struct {
+				Retries int `config:"retries"`
+			} `config:"client"`
+			Callback func() `config:"callback"`
+		}{
+			Username: "elastic",
+			Interval: time.Minute,
+			Tags:     []string{"a", "b"},
+		}
+		if err := base.Module().UnpackConfig(&config); err != nil {
+			return nil, err
+		}
+		return &referenceMetricSet{BaseMetricSet: base}, nil
+	}))
+	require.NoError(t, r.AddMetricSet("reference", "remote", func(base BaseMetricSet) (MetricSet, error) {
+		if len(base.Module().Config().Hosts) == 0 {
+			return nil, errors.New("hosts are required")
+		}
+		config := struct {
+			Username string `config:"username"`
+			Path     string `config:"path" doc:"Path of the status page."`
+		}{Path: "/status"}
+		if err := base.Module().UnpackConfig(&config); err != nil {
+			return nil, err
+		}
+		return &referenceMetricSet{BaseMetricSet: base}, nil
+	}))
+	require.NoError(t, r.AddMetricSet("reference", "broken", func(base BaseMetricSet) (MetricSet, error) {
+		return nil, errors.New("can't be created")
+	}))
+
+	reference, err := ModuleReference(r, "reference")
+	require.NoError(t, err)
+	assert.Equal(t, `- module: reference
+  metricsets: ["broken", "remote", "status"]
+  enabled: true
+  period: 10s
+
+  # Hosts to fetch the metrics from.
+  #hosts: [] # list of string
+
+  # The settings of the broken metricset can't be generated: 1 error: can't be created
+
+  #username: "" # string
+
+  # Path of the status page.
+  #path: "/status" # string
+  #stats.interval: 1m0s # duration
+  #tags: ["a", "b"] # list of string
+  #client.retries: 0 # int
+`, string(reference))
+
+	_, err = ModuleReference(r, "unknown")
+	assert.Error(t, err)
+}
@@ -24,6 +24,8 @@ import (
 	"sync"
 
 	"github.com/elastic/beats/v7/libbeat/processors"
+	"github.com/elastic/beats/v7/metricbeat/helper/dialer"
+	"github.com/elastic/elastic-agent-libs/keystore"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
 
@@ -62,10 +64,23 @@ type MetricSetRegistration struct {
 	Factory MetricSetFactory
 
 	// Options
-	IsDefault  bool
-	HostParser HostParser
-	Namespace  string
-	Replace    bool
+	IsDefault     bool
+	HostParser    HostParser
+	Namespace     string
+	Replace       bool
+	MultipleHosts bool
+
+	// HostParserName is the name of a HostParser in the Register, used when
+	// HostParser is not set.
+	HostParserName string
+
+	// Capabilities are the capabilities that the environment must have for
+	// the MetricSet to run.
+	Capabilities []Capability
+
+	// parsesHost is set when the Factory parses the host itself, as the
+	// factories of light metricsets do.
+	parsesHost bool
 }
 
 // MetricSetOption sets an option for a MetricSetFactory that is being
@@ -80,6 +95,15 @@ func WithHostParser(p HostParser) MetricSetOption {
 	}
 }
 
+// WithNamedHostParser specifies the name of the HostParser, registered with
+// AddHostParser, that should be used with the MetricSet. The name is resolved
+// when the MetricSet is created, so the HostParser can be registered later.
+func WithNamedHostParser(name string) MetricSetOption {
+	return func(r *MetricSetRegistration) {
+		r.HostParserName = name
+	}
+}
+
 // DefaultMetricSet specifies that the MetricSetFactory will be the default
 // when no MetricSet names are specified in the configuration.
 func DefaultMetricSet() MetricSetOption {
@@ -97,6 +121,17 @@ func WithNamespace(namespace string) MetricSetOption {
 	}
 }
 
+// WithMultipleHosts specifies that a single instance of the MetricSet fetches
+// all the hosts of the module, instead of creating an instance per host. The
+// MetricSet should implement HostFetcher, so the framework fetches each host,
+// concurrently if hosts_parallelism is set. Host parsers are not used with
+// these MetricSets.
+func WithMultipleHosts() MetricSetOption {
+	return func(r *MetricSetRegistration) {
+		r.MultipleHosts = true
+	}
+}
+
 // MustReplace specifies that the MetricSetFactory must be replacing an existing
 // metricset with the same name. An error will happen if there is no metricset
 // defined with the same params.
@@ -116,10 +151,23 @@ type Register struct {
 	modules map[string]ModuleFactory
 	// A map of module name to nested map of MetricSet name to MetricSetRegistration.
 	metricSets map[string]map[string]MetricSetRegistration
+	// A map of name to HostParser.
+	hostParsers map[string]HostParser
 	// Additional source of non-registered modules
 	secondarySource ModulesSource
+	// Keystore used to resolve references in module configurations
+	keystore keystore.Keystore
+	// Wraps the transport of the hosts of new MetricSets, if set
+	transportWrapper TransportWrapper
+	// A map of module name to migrations of its deprecated settings and
+	// removed MetricSets.
+	migrations map[string][]Migration
 }
 
+// TransportWrapper returns the transport used to connect to a host, given its
+// HostData, which contains the transport it would use otherwise, if any.
+type TransportWrapper func(hostData HostData) dialer.Builder
+
 // ModulesSource contains a source of non-registered modules
 type ModulesSource interface {
 	Modules() ([]string, error)
@@ -135,9 +183,11 @@ type ModulesSource interface {
 // NewRegister creates and returns a new Register.
 func NewRegister() *Register {
 	return &Register{
-		log:        logp.NewLogger("registry"),
-		modules:    make(map[string]ModuleFactory, initialSize),
-		metricSets: make(map[string]map[string]MetricSetRegistration, initialSize),
+		log:         logp.NewLogger("registry"),
+		modules:     make(map[string]ModuleFactory, initialSize),
+		metricSets:  make(map[string]map[string]MetricSetRegistration, initialSize),
+		hostParsers: make(map[string]HostParser),
+		migrations:  make(map[string][]Migration),
 	}
 }
 
@@ -190,6 +240,13 @@ func (r *Register) MustAddMetricSet(module, name string, factory MetricSetFactor
 	}
 }
 
+// AddMetricSetWithOptions registers a new MetricSetFactory with the given
+// options. An error is returned if any parameter is empty or nil or if a
+// factory has already been registered under the name.
+func (r *Register) AddMetricSetWithOptions(module, name string, factory MetricSetFactory, options ...MetricSetOption) error {
+	return r.addMetricSet(module, name, factory, options...)
+}
+
 // addMetricSet registers a new MetricSetFactory. An error is returned if any
 // parameter is empty or nil or if a factory has already been registered under
 // the name.
@@ -230,11 +287,95 @@ func (r *Register) addMetricSet(module, name string, factory MetricSetFactory, o
 		return fmt.Errorf("metricset '%s/%s' cannot be registered with a nil factory", module, name)
 	}
 
+	for _, capability := range msInfo.Capabilities {
+		if _, found := capabilityChecks[capability]; !found {
+			return fmt.Errorf("metricset '%s/%s' cannot be registered with unknown capability '%s'", module, name, capability)
+		}
+	}
+
 	r.metricSets[module][name] = msInfo
 	r.log.Infof("MetricSet registered: %s/%s", module, name)
 	return nil
 }
 
+// AddHostParser registers a HostParser under the given name, so it can be
+// referenced by metricset registrations, light modules and configurations. An
+// error is returned if the name is empty, the parser is nil, or if a parser has
+// already been registered under the name.
+func (r *Register) AddHostParser(name string, parser HostParser) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if name == "" {
+		return fmt.Errorf("host parser name is required")
+	}
+
+	name = strings.ToLower(name)
+
+	if _, exists := r.hostParsers[name]; exists {
+		return fmt.Errorf("host parser '%s' is already registered", name)
+	}
+
+	if parser == nil {
+		return fmt.Errorf("host parser '%s' cannot be registered with a nil parser", name)
+	}
+
+	r.hostParsers[name] = parser
+	r.log.Debugf("Host parser registered: %s", name)
+	return nil
+}
+
+// MustAddHostParser registers a HostParser under the given name. It panics if
+// AddHostParser fails.
+func (r *Register) MustAddHostParser(name string, parser HostParser) {
+	if err := r.AddHostParser(name, parser); err != nil {
+		panic(err)
+	}
+}
+
+// HostParser returns the HostParser registered under the given name. An error
+// is returned if there is none.
+func (r *Register) HostParser(name string) (HostParser, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.namedHostParser(name)
+}
+
+// HostParsers returns the sorted names of the registered HostParsers.
+func (r *Register) HostParsers() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	names := make([]string, 0, len(r.hostParsers))
+	for name := range r.hostParsers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// namedHostParser returns the HostParser registered under the given name. The
+// lock must be held by the caller.
+func (r *Register) namedHostParser(name string) (HostParser, error) {
+	parser, exists := r.hostParsers[strings.ToLower(name)]
+	if !exists {
+		return nil, fmt.Errorf("host parser '%s' not found", name)
+	}
+	return parser, nil
+}
+
+// hostParserFor returns the HostParser used with the given MetricSet of the
+// module. It is the one named in the module configuration if there is one,
+// otherwise the given parser.
+func (r *Register) hostParserFor(module Module, metricSet string, parser HostParser) (HostParser, error) {
+	name := module.Config().MetricSetHostParser(metricSet)
+	if name == "" {
+		return parser, nil
+	}
+	return r.HostParser(name)
+}
+
 // moduleFactory returns the registered ModuleFactory associated with the
 // given name. It returns nil if no ModuleFactory is registered.
 func (r *Register) moduleFactory(name string) ModuleFactory {
@@ -257,6 +398,13 @@ func (r *Register) metricSetRegistration(module, name string) (MetricSetRegistra
 	if exists {
 		registration, exists := metricSets[name]
 		if exists {
+			if registration.HostParser == nil && registration.HostParserName != "" {
+				parser, err := r.namedHostParser(registration.HostParserName)
+				if err != nil {
+					return MetricSetRegistration{}, fmt.Errorf("metricset '%s/%s': %w", module, name, err)
+				}
+				registration.HostParser = parser
+			}
 			return registration, nil
 		}
 	}
@@ -407,6 +555,32 @@ func (r *Register) SetSecondarySource(source ModulesSource) {
 	r.secondarySource = source
 }
 
+// SetTransportWrapper sets a function that wraps the transport of the hosts
+// of the MetricSets created from now on, and of their fallbacks, for example to
+// record or replay their connections in tests. Nil removes it.
+func (r *Register) SetTransportWrapper(wrap TransportWrapper) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.transportWrapper = wrap
+}
+
+// wrapTransport sets the transports of the host data and of its fallbacks
+// with the transport wrapper of the Register, if any.
+func (r *Register) wrapTransport(hostData *HostData) {
+	r.lock.RLock()
+	wrap := r.transportWrapper
+	r.lock.RUnlock()
+	if wrap == nil {
+		return
+	}
+
+	hostData.Transport = wrap(*hostData)
+	for i := range hostData.Fallbacks {
+		hostData.Fallbacks[i].Transport = wrap(hostData.Fallbacks[i])
+	}
+}
+
 // String return a string representation of the registered ModuleFactory's and
 // MetricSetFactory's.
 func (r *Register) String() string {
@@ -435,3 +609,98 @@ func (r *Register) String() string {
 	return fmt.Sprintf("Register [ModuleFactory:[%s], MetricSetFactory:[%s]%s]",
 		strings.Join(modules, ", "), strings.Join(metricSets, ", "), secondarySource)
 }
+
+// ModuleDescription describes a module available in a Register.
+type ModuleDescription struct {
+	Name       string                 `json:"name"`
+	Factory    bool                   `json:"factory"` // Set to true if the module has its own ModuleFactory.
+	Light      bool                   `json:"light"`   // Set to true if the module is a light module.
+	MetricSets []MetricSetDescription `json:"metricsets"`
+}
+
+// MetricSetDescription describes a MetricSet available in a Register.
+type MetricSetDescription struct {
+	Name          string `json:"name"`
+	Default       bool   `json:"default"`
+	HostParser    bool   `json:"host_parser"` // Light MetricSets use the host parser of their input in their factory.
+	Namespace     string `json:"namespace,omitempty"`
+	MultipleHosts bool   `json:"multiple_hosts"`
+	Light         bool   `json:"light"`
+
+	Capabilities []Capability `json:"capabilities,omitempty"`
+}
+
+// Describe returns the description of the modules and MetricSets available
+// in the Register, including the light modules of its secondary source,
+// sorted by name. It is meant to be used by tooling that needs to discover
+// the modules supported by a binary.
+func (r *Register) Describe() []ModuleDescription {
+	r.lock.RLock()
+	modules := map[string]*ModuleDescription{}
+	module := func(name string) *ModuleDescription {
+		if m, found := modules[name]; found {
+			return m
+		}
+		m := &ModuleDescription{Name: name, MetricSets: []MetricSetDescription{}}
+		modules[name] = m
+		return m
+	}
+	for name := range r.modules {
+		module(name).Factory = true
+	}
+	for name, metricSets := range r.metricSets {
+		m := module(name)
+		for _, reg := range metricSets {
+			m.MetricSets = append(m.MetricSets, describeMetricSet(reg))
+		}
+	}
+	source := r.secondarySource
+	r.lock.RUnlock()
+
+	// The secondary source is queried without holding the lock, as it uses
+	// the Register to build the registrations of light MetricSets.
+	if source != nil {
+		lightModules, err := source.Modules()
+		if err != nil {
+			r.log.Errorf("Failed to get modules from secondary source: %s", err)
+		}
+		for _, name := range lightModules {
+			m := module(name)
+			m.Light = true
+			metricSets, err := source.MetricSets(r, name)
+			if err != nil {
+				r.log.Errorf("Failed to get metricsets from secondary source: %s", err)
+			}
+			for _, msName := range metricSets {
+				reg, err := source.MetricSetRegistration(r, name, msName)
+				if err != nil {
+					r.log.Errorf("Failed to get registration of metricset '%s/%s' from secondary source: %s", name, msName, err)
+					continue
+				}
+				reg.Name = msName
+				ms := describeMetricSet(reg)
+				ms.Light = true
+				m.MetricSets = append(m.MetricSets, ms)
+			}
+		}
+	}
+
+	descriptions := make([]ModuleDescription, 0, len(modules))
+	for _, m := range modules {
+		sort.Slice(m.MetricSets, func(i, j int) bool { return m.MetricSets[i].Name < m.MetricSets[j].Name })
+		descriptions = append(descriptions, *m)
+	}
+	sort.Slice(descriptions, func(i, j int) bool { return descriptions[i].Name < descriptions[j].Name })
+	return descriptions
+}
+
+func describeMetricSet(reg MetricSetRegistration) MetricSetDescription {
+	return MetricSetDescription{
+		Name:          reg.Name,
+		Default:       reg.IsDefault,
+		HostParser:    reg.HostParser != nil || reg.HostParserName != "",
+		Namespace:     reg.Namespace,
+		MultipleHosts: reg.MultipleHosts,
+		Capabilities:  reg.Capabilities,
+	}
+}
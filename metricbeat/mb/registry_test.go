@@ -105,6 +105,12 @@ func TestAddMetricSetDuplicateName(t *testing.T) {
 	}
 }
 
+func TestAddMetricSetUnknownCapability(t *testing.T) {
+	registry := NewRegister()
+	err := registry.AddMetricSetWithOptions(moduleName, metricSetName, fakeMetricSetFactory, WithCapabilities("gpu"))
+	assert.EqualError(t, err, "metricset 'mymodule/mymetricset' cannot be registered with unknown capability 'gpu'")
+}
+
 func TestAddMetricSet(t *testing.T) {
 	registry := NewRegister()
 	err := registry.AddMetricSet(moduleName, metricSetName, fakeMetricSetFactory)
@@ -114,6 +120,45 @@ func TestAddMetricSet(t *testing.T) {
 	assert.NotNil(t, f, "factory function is nil")
 }
 
+func TestAddHostParser(t *testing.T) {
+	registry := NewRegister()
+	parser := func(Module, string) (HostData, error) { return HostData{Host: "parsed"}, nil }
+
+	assert.EqualError(t, registry.AddHostParser("", parser), "host parser name is required")
+	assert.EqualError(t, registry.AddHostParser("foo", nil), "host parser 'foo' cannot be registered with a nil parser")
+
+	require.NoError(t, registry.AddHostParser("Foo", parser))
+	assert.EqualError(t, registry.AddHostParser("foo", parser), "host parser 'foo' is already registered")
+	require.NoError(t, registry.AddHostParser("bar", parser))
+	assert.Equal(t, []string{"bar", "foo"}, registry.HostParsers())
+
+	p, err := registry.HostParser("FOO")
+	require.NoError(t, err)
+	hostData, err := p(nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, "parsed", hostData.Host)
+
+	_, err = registry.HostParser("baz")
+	assert.EqualError(t, err, "host parser 'baz' not found")
+}
+
+func TestNamedHostParser(t *testing.T) {
+	registry := NewRegister()
+	registry.MustAddMetricSet(moduleName, metricSetName, fakeMetricSetFactory, WithNamedHostParser("foo"))
+
+	_, err := registry.metricSetRegistration(moduleName, metricSetName)
+	assert.EqualError(t, err, "metricset 'mymodule/mymetricset': host parser 'foo' not found")
+
+	// Parsers can be registered after the MetricSets using them.
+	registry.MustAddHostParser("foo", func(Module, string) (HostData, error) { return HostData{Host: "parsed"}, nil })
+	reg, err := registry.metricSetRegistration(moduleName, metricSetName)
+	require.NoError(t, err)
+	require.NotNil(t, reg.HostParser)
+	hostData, err := reg.HostParser(nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, "parsed", hostData.Host)
+}
+
 func TestModuleFactory(t *testing.T) {
 	registry := NewRegister()
 	registry.modules[moduleName] = fakeModuleFactory
@@ -246,3 +291,37 @@ func TestProcessorsForMetricSet_FromSource(t *testing.T) {
 	require.NotNil(t, procs)
 	require.Len(t, procs.List, 1)
 }
+
+func TestDescribe(t *testing.T) {
+	registry := NewRegister()
+	require.NoError(t, registry.AddModule("foo", fakeModuleFactory))
+	registry.MustAddMetricSet("foo", "bar", fakeMetricSetFactory,
+		DefaultMetricSet(),
+		WithHostParser(func(Module, string) (HostData, error) { return HostData{}, nil }),
+	)
+	registry.MustAddMetricSet("foo", "baz", fakeMetricSetFactory, WithNamespace("foo.custom"), WithMultipleHosts(), WithCapabilities(CapabilityRoot))
+	registry.SetSecondarySource(NewLightModulesSource("testdata/lightmodules"))
+
+	descriptions := map[string]ModuleDescription{}
+	for _, d := range registry.Describe() {
+		descriptions[d.Name] = d
+	}
+
+	assert.Equal(t, ModuleDescription{
+		Name:    "foo",
+		Factory: true,
+		MetricSets: []MetricSetDescription{
+			{Name: "bar", Default: true, HostParser: true},
+			{Name: "baz", Namespace: "foo.custom", MultipleHosts: true, Capabilities: []Capability{CapabilityRoot}},
+		},
+	}, descriptions["foo"])
+
+	assert.Equal(t, ModuleDescription{
+		Name:  "service",
+		Light: true,
+		MetricSets: []MetricSetDescription{
+			{Name: "metricset", Default: true, Light: true},
+			{Name: "nondefault", Namespace: "foo.custom", MultipleHosts: true, Light: true, Capabilities: []Capability{CapabilityRoot}},
+		},
+	}, descriptions["service"])
+}
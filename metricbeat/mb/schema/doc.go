@@ -0,0 +1,57 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+/*
+Package schema maps the typed structs that metricsets decode the responses of
+the monitored services into, to the fields of their events.
+
+The fields of the struct are mapped according to their schema tag, which has
+the form `schema:"key,options"`. The key is the dotted path of the field in
+the event. When it is empty, the name in the json tag, or the name of the
+struct field, is used. Fields with the "-" key are not mapped. The options
+are:
+
+  - required: a nil pointer, map or slice is reported as a missing key.
+    Fields that can be missing in the response should be pointers, nil
+    pointers are not mapped.
+  - omitempty: zero values are not mapped.
+  - inline: the fields of a nested struct are mapped into its parent.
+  - unit=from:to: the number is converted between the given units, for
+    example unit=ms:us. The supported units are ns, us, ms, s, m and h for
+    times, b, kb, mb, gb and tb for sizes, and pct and percent for ratios.
+
+Nested structs, and slices of structs, are mapped recursively. Other values are
+added to the event as they are.
+
+For example:
+
+	type status struct {
+		Uptime      int64    `json:"uptime_ms" schema:"uptime.us,unit=ms:us"`
+		Connections *int     `json:"conns"     schema:"connections.active,required"`
+		Version     string   `json:"version"   schema:"-"`
+		Memory      struct {
+			Used int64 `json:"used" schema:"used.bytes,unit=kb:b"`
+		} `json:"memory"`
+	}
+
+	var statusSchema = schema.MustNew[status]()
+
+	func eventMapping(body []byte) (mapstr.M, error) {
+		return statusSchema.Decode(body)
+	}
+*/
+package schema
@@ -0,0 +1,223 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Mapper maps values of the struct type T to event fields, following the
+// schema tags of its fields. A Mapper is safe for concurrent use, it is
+// usually created once per metricset type.
+type Mapper[T any] struct {
+	fields []field
+}
+
+// field describes how a struct field is mapped.
+type field struct {
+	index     int
+	key       string // Dotted path of the field in the event.
+	source    string // Dotted path of the field in the source, for errors.
+	required  bool
+	omitEmpty bool
+	inline    bool
+	unit      *conversion
+	nested    []field // Fields of nested structs, or of the elements of slices of structs.
+}
+
+// New returns a Mapper for the struct type T. It returns an error if T is
+// not a struct or if the schema tags of its fields are invalid.
+func New[T any]() (*Mapper[T], error) {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("schema type must be a struct, got %v", t)
+	}
+
+	fields, err := structFields(t, "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema %v: %w", t, err)
+	}
+	return &Mapper[T]{fields: fields}, nil
+}
+
+// MustNew is like New, but panics if the schema is invalid. It simplifies
+// the initialization of package variables.
+func MustNew[T any]() *Mapper[T] {
+	m, err := New[T]()
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Map converts the value to event fields. The fields that can be mapped are
+// returned even if some fail, the errors of the others are joined in the
+// returned error. Required keys that are missing are reported with
+// schema.KeyNotFoundError of the libbeat schema package.
+func (m *Mapper[T]) Map(v T) (mapstr.M, error) {
+	event := mapstr.M{}
+	errs := mapStruct(reflect.ValueOf(v), m.fields, event)
+	return event, errors.Join(errs...)
+}
+
+// Decode decodes the JSON data into a value of type T, and maps it like Map.
+func (m *Mapper[T]) Decode(data []byte) (mapstr.M, error) {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("failed to decode %T: %w", v, err)
+	}
+	return m.Map(v)
+}
+
+// structFields parses the schema tags of the fields of the struct type.
+func structFields(t reflect.Type, prefix string) ([]field, error) {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		key, options, _ := strings.Cut(sf.Tag.Get("schema"), ",")
+		if key == "-" {
+			continue
+		}
+		source := jsonName(sf)
+		if key == "" {
+			key = source
+		}
+		f := field{
+			index:  i,
+			key:    key,
+			source: prefix + source,
+		}
+
+		for _, option := range strings.Split(options, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+			switch name {
+			case "":
+			case "required":
+				f.required = true
+			case "omitempty":
+				f.omitEmpty = true
+			case "inline":
+				f.inline = true
+			case "unit":
+				conv, err := newConversion(value)
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %w", sf.Name, err)
+				}
+				f.unit = conv
+			default:
+				return nil, fmt.Errorf("field %s: unknown option '%s'", sf.Name, name)
+			}
+		}
+
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.Struct && ft.Elem() != timeType {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && ft != timeType {
+			nested, err := structFields(ft, f.source+".")
+			if err != nil {
+				return nil, err
+			}
+			f.nested = nested
+		}
+
+		switch {
+		case f.inline && (f.nested == nil || sf.Type.Kind() == reflect.Slice):
+			return nil, fmt.Errorf("field %s: only structs can be inlined", sf.Name)
+		case f.unit != nil && !isNumber(ft):
+			return nil, fmt.Errorf("field %s: units can only be converted in numbers", sf.Name)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// jsonName returns the name of the field in its json tag, or its name if it
+// has none.
+func jsonName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return sf.Name
+	}
+	return name
+}
+
+// mapStruct adds the fields of the struct value to the event, and returns the
+// errors of the fields that couldn't be mapped.
+func mapStruct(v reflect.Value, fields []field, event mapstr.M) []error {
+	var errs []error
+	for _, f := range fields {
+		fv := v.Field(f.index)
+		switch fv.Kind() {
+		case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+			if fv.IsNil() {
+				if f.required {
+					errs = append(errs, s.NewKeyNotFoundError(f.source))
+				}
+				continue
+			}
+		}
+		if fv.Kind() == reflect.Pointer {
+			fv = fv.Elem()
+		}
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		switch {
+		case f.nested != nil && fv.Kind() == reflect.Slice:
+			list := make([]mapstr.M, fv.Len())
+			for i := range list {
+				list[i] = mapstr.M{}
+				errs = append(errs, mapStruct(fv.Index(i), f.nested, list[i])...)
+			}
+			_, _ = event.Put(f.key, list)
+		case f.nested != nil:
+			nested := mapstr.M{}
+			errs = append(errs, mapStruct(fv, f.nested, nested)...)
+			if f.inline {
+				event.DeepUpdate(nested)
+			} else {
+				_, _ = event.Put(f.key, nested)
+			}
+		case f.unit != nil:
+			_, _ = event.Put(f.key, f.unit.convert(fv))
+		default:
+			_, _ = event.Put(f.key, fv.Interface())
+		}
+	}
+	return errs
+}
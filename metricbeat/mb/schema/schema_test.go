@@ -0,0 +1,170 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package schema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type testStatus struct {
+	Uptime      int64      `json:"uptime_ms" schema:"uptime.us,unit=ms:us"`
+	Load        float64    `json:"load_percent" schema:"load.pct,unit=percent:pct"`
+	Connections *int       `json:"conns" schema:"connections.active,required"`
+	Idle        *int       `json:"idle" schema:"connections.idle"`
+	Version     string     `json:"version" schema:"-"`
+	Name        string     `json:"name" schema:",omitempty"`
+	Started     time.Time  `json:"started" schema:"started"`
+	Memory      testMemory `json:"memory"`
+	Info        testInfo   `json:"info" schema:",inline"`
+	Queues      []struct {
+		Name   string `json:"name"`
+		Length *int   `json:"length" schema:"length,required"`
+	} `json:"queues"`
+}
+
+type testMemory struct {
+	Used uint64 `json:"used_kb" schema:"used.bytes,unit=kb:b"`
+}
+
+type testInfo struct {
+	Role string `json:"role" schema:"role"`
+}
+
+func TestMapperDecode(t *testing.T) {
+	mapper := MustNew[testStatus]()
+
+	event, err := mapper.Decode([]byte(`{
+		"uptime_ms": 1500,
+		"load_percent": 50,
+		"conns": 3,
+		"version": "1.0",
+		"started": "2024-01-02T03:04:05Z",
+		"memory": {"used_kb": 2},
+		"info": {"role": "primary"},
+		"queues": [{"name": "a", "length": 1}, {"name": "b"}]
+	}`))
+
+	var keyErr *s.KeyNotFoundError
+	require.ErrorAs(t, err, &keyErr)
+	assert.Equal(t, "queues.length", keyErr.Key())
+
+	assert.Equal(t, mapstr.M{
+		"uptime":      mapstr.M{"us": int64(1500000)},
+		"load":        mapstr.M{"pct": 0.5},
+		"connections": mapstr.M{"active": 3},
+		"started":     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		"memory":      mapstr.M{"used": mapstr.M{"bytes": uint64(2048)}},
+		"role":        "primary",
+		"queues": []mapstr.M{
+			{"name": "a", "length": 1},
+			{"name": "b"},
+		},
+	}, event)
+}
+
+func TestMapperMissingRequired(t *testing.T) {
+	mapper := MustNew[testStatus]()
+
+	event, err := mapper.Map(testStatus{Name: "test"})
+	assert.EqualError(t, err, "key `conns` not found")
+	assert.Equal(t, "test", event["name"])
+}
+
+func TestMapperDecodeError(t *testing.T) {
+	mapper := MustNew[testStatus]()
+
+	_, err := mapper.Decode([]byte(`{"conns": "three"}`))
+	assert.ErrorContains(t, err, "failed to decode schema.testStatus")
+}
+
+func TestNewInvalid(t *testing.T) {
+	cases := map[string]func() error{
+		"not a struct": func() error {
+			_, err := New[int]()
+			return err
+		},
+		"unknown option": func() error {
+			_, err := New[struct {
+				A int `schema:"a,optional"`
+			}]()
+			return err
+		},
+		"unknown unit": func() error {
+			_, err := New[struct {
+				A int `schema:"a,unit=ms:days"`
+			}]()
+			return err
+		},
+		"different dimensions": func() error {
+			_, err := New[struct {
+				A int `schema:"a,unit=ms:b"`
+			}]()
+			return err
+		},
+		"unit of a string": func() error {
+			_, err := New[struct {
+				A string `schema:"a,unit=ms:us"`
+			}]()
+			return err
+		},
+		"inline of a number": func() error {
+			_, err := New[struct {
+				A int `schema:"a,inline"`
+			}]()
+			return err
+		},
+	}
+
+	for name, newSchema := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, newSchema())
+		})
+	}
+}
+
+func TestUnitConversion(t *testing.T) {
+	cases := []struct {
+		unit     string
+		value    interface{}
+		expected interface{}
+	}{
+		{"s:ms", int64(2), int64(2000)},
+		{"ms:s", int64(1500), 1.5},
+		{"h:m", uint32(2), uint64(120)},
+		{"gb:mb", 1.5, 1536.0},
+		{"pct:percent", 0.25, 25.0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.unit, func(t *testing.T) {
+			conv, err := newConversion(c.unit)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, conv.convert(reflect.ValueOf(c.value)))
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schema
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// unit is a unit of measure, defined by the size of the base unit of its
+// dimension it corresponds to.
+type unit struct {
+	dimension string
+	factor    float64
+}
+
+// units contains the units supported by the unit option of the schema tags.
+// Sizes use binary multiples. The pct ratio is a fraction of one, as in the
+// pct fields of the events, and percent is a fraction of 100.
+var units = map[string]unit{
+	"ns": {"time", 1},
+	"us": {"time", 1e3},
+	"ms": {"time", 1e6},
+	"s":  {"time", 1e9},
+	"m":  {"time", 60e9},
+	"h":  {"time", 3600e9},
+
+	"b":  {"size", 1},
+	"kb": {"size", 1 << 10},
+	"mb": {"size", 1 << 20},
+	"gb": {"size", 1 << 30},
+	"tb": {"size", 1 << 40},
+
+	"pct":     {"ratio", 1},
+	"percent": {"ratio", 0.01},
+}
+
+// conversion converts numbers from a unit to another one of the same
+// dimension.
+type conversion struct {
+	factor float64 // Multiplier of the values in the source unit.
+	exact  bool    // Set to true if the factor converts integers to integers.
+}
+
+// newConversion parses a unit option value of the form "from:to".
+func newConversion(value string) (*conversion, error) {
+	from, to, found := strings.Cut(value, ":")
+	if !found {
+		return nil, fmt.Errorf("unit '%s' must have the form 'from:to'", value)
+	}
+	fromUnit, ok := units[strings.ToLower(from)]
+	if !ok {
+		return nil, fmt.Errorf("unknown unit '%s'", from)
+	}
+	toUnit, ok := units[strings.ToLower(to)]
+	if !ok {
+		return nil, fmt.Errorf("unknown unit '%s'", to)
+	}
+	if fromUnit.dimension != toUnit.dimension {
+		return nil, fmt.Errorf("can't convert %s to %s", from, to)
+	}
+
+	factor := fromUnit.factor / toUnit.factor
+	return &conversion{
+		factor: factor,
+		exact:  factor >= 1 && factor == math.Trunc(factor),
+	}, nil
+}
+
+// convert converts the number. Integers are kept as integers when the
+// conversion is exact, other numbers are converted to float64.
+func (c *conversion) convert(v reflect.Value) interface{} {
+	switch {
+	case v.CanInt():
+		if c.exact {
+			return v.Int() * int64(c.factor)
+		}
+		return float64(v.Int()) * c.factor
+	case v.CanUint():
+		if c.exact {
+			return v.Uint() * uint64(c.factor)
+		}
+		return float64(v.Uint()) * c.factor
+	default:
+		return v.Float() * c.factor
+	}
+}
+
+// isNumber returns true if the values of the type can be converted between
+// units.
+func isNumber(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
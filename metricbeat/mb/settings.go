@@ -0,0 +1,274 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+// settingsRecorder records the types of the structs unpacked from the
+// configuration of a Module with UnpackConfig, with their values before they
+// are unpacked, and the settings marked as read with MarkSettingsRead.
+type settingsRecorder struct {
+	mu       sync.Mutex
+	types    []reflect.Type
+	defaults []reflect.Value // Copies of the values before unpacking, the zero value if unknown.
+	names    []string
+}
+
+func (r *settingsRecorder) record(to interface{}) {
+	if r == nil {
+		return
+	}
+	t := reflect.TypeOf(to)
+	v := reflect.ValueOf(to)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		if v.IsValid() && !v.IsNil() {
+			v = v.Elem()
+		} else {
+			v = reflect.Value{}
+		}
+	}
+	// Only structs describe the settings, unpacking to maps or other types
+	// reads any setting.
+	if t == nil || t.Kind() != reflect.Struct {
+		return
+	}
+	defaults := reflect.New(t).Elem()
+	if v.IsValid() {
+		defaults.Set(v)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.types = append(r.types, t)
+	r.defaults = append(r.defaults, defaults)
+}
+
+// MarkSettingsRead marks the settings with the given dotted names as read from
+// the configuration of the Module, so they are not reported by
+// UnusedSettings. It must be used by the code that reads settings without
+// unpacking them to a struct, like host parsers that unpack the configuration
+// to a map.
+func MarkSettingsRead(m Module, names ...string) {
+	bm, ok := m.(interface{ settingsRecorder() *settingsRecorder })
+	if !ok {
+		return
+	}
+	if r := bm.settingsRecorder(); r != nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.names = append(r.names, names...)
+	}
+}
+
+// UnusedSettings creates the Module and the MetricSets of the configuration
+// as NewModule does, and returns the settings of the configuration that none
+// of them read, as sorted dotted paths. The settings read are the fields of
+// ModuleConfig, of the structs that the Module and the MetricSets unpack with
+// UnpackConfig when they are created, and of the known structs, that are read
+// from the module configuration elsewhere. Any setting under fields of map or
+// interface types, or of types with their own Unpack method, is considered
+// read. The MetricSets are closed before returning.
+//
+// An error is returned if the Module or the MetricSets can't be created, as
+// NewModule does.
+func UnusedSettings(config *conf.C, r *Register, known ...interface{}) ([]string, error) {
+	if !config.Enabled() {
+		return nil, ErrModuleDisabled
+	}
+
+	recorder := &settingsRecorder{}
+	for _, k := range known {
+		recorder.record(k)
+	}
+	if err := recordSettings(config, r, recorder, true); err != nil {
+		return nil, err
+	}
+
+	tree := settingsNode{}
+	recorder.mu.Lock()
+	for _, t := range recorder.types {
+		tree.addStruct(t)
+	}
+	for _, name := range recorder.names {
+		if name != "" {
+			tree.child(name).any = true
+		}
+	}
+	recorder.mu.Unlock()
+
+	var settings map[string]interface{}
+	if err := config.Unpack(&settings); err != nil {
+		return nil, err
+	}
+	var unused []string
+	tree.unused("", settings, &unused)
+	sort.Strings(unused)
+	return unused, nil
+}
+
+// recordSettings creates the Module and the MetricSets of the configuration
+// as NewModule does, recording the structs they unpack in the recorder, and
+// closes them. The ModuleConfig of the Module is recorded if withModuleConfig
+// is set.
+func recordSettings(config *conf.C, r *Register, recorder *settingsRecorder, withModuleConfig bool) error {
+	resolved, err := r.resolveKeystoreReferences(config)
+	if err != nil {
+		return err
+	}
+	bm, err := newBaseModuleFromConfig(resolved)
+	if err != nil {
+		return err
+	}
+	bm.recorder = recorder
+	if withModuleConfig {
+		recorder.record(&bm.config)
+	}
+
+	module, err := createModule(r, bm)
+	if err != nil {
+		return err
+	}
+	metricSets, err := initMetricSets(r, module, module.Config().Hosts, true)
+	if err != nil {
+		return err
+	}
+	for _, ms := range metricSets {
+		if closer, ok := ms.(Closer); ok {
+			_ = closer.Close()
+		}
+	}
+	if cache := ModuleCacheOf(module); cache != nil {
+		cache.Clear()
+	}
+	return nil
+}
+
+// settingsNode is a tree of the settings read from a configuration, built from
+// the config tags of structs.
+type settingsNode struct {
+	any      bool // Set to true if any nested setting is read.
+	children map[string]*settingsNode
+}
+
+var configType = reflect.TypeOf(conf.C{})
+
+// add adds the settings read by a value of type t to the node.
+func (n *settingsNode) add(t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == configType || hasUnpackMethod(t) {
+		n.any = true
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		n.addStruct(t)
+	case reflect.Slice, reflect.Array:
+		n.add(t.Elem())
+	case reflect.Map, reflect.Interface:
+		n.any = true
+	default:
+		// Scalar settings have no nested settings, values of a wrong type are
+		// reported when they are unpacked.
+		n.any = true
+	}
+}
+
+// addStruct adds the fields of the struct type t to the node.
+func (n *settingsNode) addStruct(t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("config"), ",")
+		if name == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		if strings.Contains(","+opts+",", ",inline,") || (field.Anonymous && name == "") {
+			n.add(field.Type)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		n.child(name).add(field.Type)
+	}
+}
+
+// child returns the node of the setting with the given dotted name.
+func (n *settingsNode) child(name string) *settingsNode {
+	for _, key := range strings.Split(name, ".") {
+		if n.children == nil {
+			n.children = map[string]*settingsNode{}
+		}
+		c, found := n.children[key]
+		if !found {
+			c = &settingsNode{}
+			n.children[key] = c
+		}
+		n = c
+	}
+	return n
+}
+
+// unused appends to the list the paths of the settings of value that are not
+// in the tree of the node.
+func (n *settingsNode) unused(path string, value interface{}, list *[]string) {
+	if n.any {
+		return
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			c, found := n.children[key]
+			if !found {
+				*list = append(*list, joinPath(path, key))
+				continue
+			}
+			c.unused(joinPath(path, key), value, list)
+		}
+	case []interface{}:
+		for i, value := range v {
+			n.unused(joinPath(path, strconv.Itoa(i)), value, list)
+		}
+	}
+}
+
+// hasUnpackMethod returns true if values of type t unpack themselves. The
+// Unpack methods of go-ucfg can take different types, like string or
+// map[string]interface{}.
+func hasUnpackMethod(t reflect.Type) bool {
+	_, found := reflect.PointerTo(t).MethodByName("Unpack")
+	return found
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return fmt.Sprintf("%s.%s", path, key)
+}
@@ -0,0 +1,96 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package mb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+type settingsMetricSet struct {
+	BaseMetricSet
+}
+
+func (m *settingsMetricSet) Fetch(r ReporterV2) error { return nil }
+
+func newSettingsMetricSet(base BaseMetricSet) (MetricSet, error) {
+	config := struct {
+		Username string        `config:"username"`
+		Interval time.Duration `config:"stats.interval"`
+		Options  map[string]interface{}
+		Servers  []struct {
+			Name string `config:"name"`
+		} `config:"servers"`
+		Auth struct {
+			Token string `config:"token"`
+		} `config:",inline"`
+	}{}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+	return &settingsMetricSet{BaseMetricSet: base}, nil
+}
+
+func TestUnusedSettings(t *testing.T) {
+	r := NewRegister()
+	require.NoError(t, r.AddMetricSet("settings", "status", newSettingsMetricSet))
+
+	known := struct {
+		Index string `config:"index"`
+	}{}
+
+	config := conf.MustNewConfigFrom(map[string]interface{}{
+		"module":     "settings",
+		"metricsets": []string{"status"},
+		"hosts":      []string{"localhost"},
+		"period":     "10s",
+		"username":   "elastic",
+		"usrname":    "elastic",
+		"stats": map[string]interface{}{
+			"interval": "1s",
+			"intreval": "1s",
+		},
+		"options": map[string]interface{}{"any": "thing"},
+		"servers": []interface{}{
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{"nmae": "b"},
+		},
+		"token": "secret",
+		"index": "metrics",
+	})
+	unused, err := UnusedSettings(config, r, &known)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"servers.1.nmae", "stats.intreval", "usrname"}, unused)
+
+	t.Run("invalid", func(t *testing.T) {
+		config := conf.MustNewConfigFrom(map[string]interface{}{
+			"module":         "settings",
+			"metricsets":     []string{"status"},
+			"stats.interval": "often",
+		})
+		_, err := UnusedSettings(config, r)
+		assert.Error(t, err)
+	})
+}
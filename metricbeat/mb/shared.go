@@ -0,0 +1,109 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/mitchellh/hashstructure"
+)
+
+var (
+	sharedLock      sync.Mutex
+	sharedResources = map[string]*sharedResource{}
+)
+
+type sharedResource struct {
+	key   string
+	ref   int
+	value interface{}
+	close func() error
+}
+
+// Shared returns a resource, such as a client or a connection pool, shared by
+// the MetricSets of the module, or of other modules with the same name and
+// configuration, that use the same key, so they don't open a connection each
+// to the monitored service. The key usually identifies the host. If there is no such resource
+// yet, it is created with create. create shouldn't block, connections can be
+// established on first use with a LazyConnection.
+//
+// The returned release function must be called once the MetricSet doesn't use
+// the resource anymore, usually from its Close method. The resource is closed
+// with close, if it is not nil, when it is released by all the MetricSets
+// using it.
+func Shared[T any](m Module, key string, create func() (T, error), close func(T) error) (T, func() error, error) {
+	var zero T
+
+	configHash, err := moduleConfigHash(m)
+	if err != nil {
+		return zero, nil, fmt.Errorf("failed to get shared resource '%s': %w", key, err)
+	}
+	key = fmt.Sprintf("%s/%d/%T/%s", m.Name(), configHash, zero, key)
+
+	sharedLock.Lock()
+	defer sharedLock.Unlock()
+
+	r := sharedResources[key]
+	if r == nil {
+		value, err := create()
+		if err != nil {
+			return zero, nil, err
+		}
+
+		r = &sharedResource{key: key, value: value}
+		if close != nil {
+			r.close = func() error { return close(value) }
+		}
+		sharedResources[key] = r
+	}
+	r.ref++
+
+	var once sync.Once
+	release := func() error {
+		var err error
+		once.Do(func() { err = releaseShared(r) })
+		return err
+	}
+	return r.value.(T), release, nil
+}
+
+func releaseShared(r *sharedResource) error {
+	sharedLock.Lock()
+	defer sharedLock.Unlock()
+
+	r.ref--
+	if r.ref > 0 {
+		return nil
+	}
+
+	delete(sharedResources, r.key)
+	if r.close == nil {
+		return nil
+	}
+	return r.close()
+}
+
+// moduleConfigHash returns a hash of the configuration of the module.
+func moduleConfigHash(m Module) (uint64, error) {
+	var config map[string]interface{}
+	if err := m.UnpackConfig(&config); err != nil {
+		return 0, err
+	}
+	return hashstructure.Hash(config, nil)
+}
@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShared(t *testing.T) {
+	r := newTestRegistry(t)
+	newMetricSet := func(host string) MetricSet {
+		return newTestMetricSet(t, r, map[string]interface{}{
+			"module":     moduleName,
+			"metricsets": []string{metricSetName},
+			"hosts":      []string{host},
+		})
+	}
+
+	type client struct{ host string }
+	var created, closed int
+	create := func(host string) func() (*client, error) {
+		return func() (*client, error) {
+			created++
+			return &client{host: host}, nil
+		}
+	}
+	closeClient := func(*client) error {
+		closed++
+		return nil
+	}
+
+	ms1, ms2 := newMetricSet("host1"), newMetricSet("host1")
+	c1, release1, err := Shared(ms1.Module(), ms1.Host(), create(ms1.Host()), closeClient)
+	require.NoError(t, err)
+	c2, release2, err := Shared(ms2.Module(), ms2.Host(), create(ms2.Host()), closeClient)
+	require.NoError(t, err)
+	assert.Same(t, c1, c2, "metricsets with the same configuration should share the client")
+	assert.Equal(t, 1, created)
+
+	ms3 := newMetricSet("host2")
+	c3, release3, err := Shared(ms3.Module(), ms3.Host(), create(ms3.Host()), closeClient)
+	require.NoError(t, err)
+	assert.NotSame(t, c1, c3, "metricsets with different configurations should not share the client")
+	assert.Equal(t, 2, created)
+
+	require.NoError(t, release1())
+	require.NoError(t, release1())
+	assert.Equal(t, 0, closed, "the client should be closed when released by all metricsets")
+	require.NoError(t, release2())
+	assert.Equal(t, 1, closed)
+	require.NoError(t, release3())
+	assert.Equal(t, 2, closed)
+	assert.Empty(t, sharedResources)
+}
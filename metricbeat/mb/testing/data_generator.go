@@ -95,6 +95,36 @@ func WriteEventsReporterV2WithContextCond(f mb.ReportingMetricSetV2WithContext,
 	return writeEvent(events, f, t, path, cond)
 }
 
+// WriteEventsReporterV2BatchCond fetches events and writes the first event that matches
+// the condition to a file.
+func WriteEventsReporterV2BatchCond(f mb.ReportingMetricSetV2Batch, t testing.TB, path string, cond func(mapstr.M) bool) error {
+	if !*flags.DataFlag {
+		t.Skip("skip data generation tests")
+	}
+
+	events, errs := ReportingFetchV2Batch(f)
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	return writeEvent(events, f, t, path, cond)
+}
+
+// WriteEventsReporterV2StreamCond fetches events and writes the first event that matches
+// the condition to a file.
+func WriteEventsReporterV2StreamCond(f mb.ReportingMetricSetV2Stream, t testing.TB, path string, cond func(mapstr.M) bool) error {
+	if !*flags.DataFlag {
+		t.Skip("skip data generation tests")
+	}
+
+	events, errs := ReportingFetchV2Stream(f)
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	return writeEvent(events, f, t, path, cond)
+}
+
 func writeEvent(events []mb.Event, f mb.MetricSet, t testing.TB, path string, cond func(mapstr.M) bool) error {
 	if len(events) == 0 {
 		return fmt.Errorf("no events were generated")
@@ -38,7 +38,13 @@ type Fetcher interface {
 
 // NewFetcher returns a test fetcher from a Metricset configuration
 func NewFetcher(t testing.TB, config interface{}) Fetcher {
-	metricSet := NewMetricSet(t, config)
+	return NewFetcherWithRegistry(t, config, mb.Registry)
+}
+
+// NewFetcherWithRegistry returns a test fetcher from a Metricset
+// configuration, whose factories are obtained from the given registry.
+func NewFetcherWithRegistry(t testing.TB, config interface{}, registry *mb.Register) Fetcher {
+	metricSet := NewMetricSetWithRegistry(t, config, registry)
 	switch metricSet := metricSet.(type) {
 	case mb.ReportingMetricSetV2:
 		return newReporterV2Fetcher(metricSet)
@@ -46,6 +52,10 @@ func NewFetcher(t testing.TB, config interface{}) Fetcher {
 		return newReporterV2FetcherError(metricSet)
 	case mb.ReportingMetricSetV2WithContext:
 		return newReporterV2FetcherWithContext(metricSet)
+	case mb.ReportingMetricSetV2Batch:
+		return newReporterV2FetcherBatch(metricSet)
+	case mb.ReportingMetricSetV2Stream:
+		return newReporterV2FetcherStream(metricSet)
 	default:
 		t.Fatalf("Failed to create a Fetcher for metricset of type %T", metricSet)
 	}
@@ -134,3 +144,57 @@ func (f *reportingMetricSetV2FetcherWithContext) WriteEventsCond(t testing.TB, p
 func (f *reportingMetricSetV2FetcherWithContext) StandardizeEvent(event mb.Event, modifiers ...mb.EventModifier) beat.Event {
 	return StandardizeEvent(f, event, modifiers...)
 }
+
+type reportingMetricSetV2FetcherBatch struct {
+	mb.ReportingMetricSetV2Batch
+}
+
+func newReporterV2FetcherBatch(metricSet mb.ReportingMetricSetV2Batch) *reportingMetricSetV2FetcherBatch {
+	return &reportingMetricSetV2FetcherBatch{metricSet}
+}
+
+func (f *reportingMetricSetV2FetcherBatch) FetchEvents() ([]mb.Event, []error) {
+	return ReportingFetchV2Batch(f)
+}
+
+func (f *reportingMetricSetV2FetcherBatch) WriteEvents(t testing.TB, path string) {
+	f.WriteEventsCond(t, path, nil)
+}
+
+func (f *reportingMetricSetV2FetcherBatch) WriteEventsCond(t testing.TB, path string, cond func(mapstr.M) bool) {
+	err := WriteEventsReporterV2BatchCond(f, t, path, cond)
+	if err != nil {
+		t.Fatal("writing events", err)
+	}
+}
+
+func (f *reportingMetricSetV2FetcherBatch) StandardizeEvent(event mb.Event, modifiers ...mb.EventModifier) beat.Event {
+	return StandardizeEvent(f, event, modifiers...)
+}
+
+type reportingMetricSetV2FetcherStream struct {
+	mb.ReportingMetricSetV2Stream
+}
+
+func newReporterV2FetcherStream(metricSet mb.ReportingMetricSetV2Stream) *reportingMetricSetV2FetcherStream {
+	return &reportingMetricSetV2FetcherStream{metricSet}
+}
+
+func (f *reportingMetricSetV2FetcherStream) FetchEvents() ([]mb.Event, []error) {
+	return ReportingFetchV2Stream(f)
+}
+
+func (f *reportingMetricSetV2FetcherStream) WriteEvents(t testing.TB, path string) {
+	f.WriteEventsCond(t, path, nil)
+}
+
+func (f *reportingMetricSetV2FetcherStream) WriteEventsCond(t testing.TB, path string, cond func(mapstr.M) bool) {
+	err := WriteEventsReporterV2StreamCond(f, t, path, cond)
+	if err != nil {
+		t.Fatal("writing events", err)
+	}
+}
+
+func (f *reportingMetricSetV2FetcherStream) StandardizeEvent(event mb.Event, modifiers ...mb.EventModifier) beat.Event {
+	return StandardizeEvent(f, event, modifiers...)
+}
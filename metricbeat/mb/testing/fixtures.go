@@ -0,0 +1,466 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package testing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/dialer"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/testing/flags"
+	"github.com/elastic/elastic-agent-libs/transport"
+)
+
+// replayRequestIdle is the time after which a replayed request is considered
+// complete if no more data is received, when it is shorter than the recorded
+// one.
+const replayRequestIdle = 50 * time.Millisecond
+
+// Fixture is the recording of the connections made by MetricSets to their
+// hosts, to replay them in tests.
+type Fixture struct {
+	Connections []FixtureConnection `json:"connections"`
+}
+
+// FixtureConnection is a recorded connection.
+type FixtureConnection struct {
+	Network   string            `json:"network"`
+	Address   string            `json:"address"`
+	Exchanges []FixtureExchange `json:"exchanges"`
+}
+
+// FixtureExchange is a request sent over a connection, and the response
+// received for it. The request is empty if the host sent the response first,
+// as in protocols that start with a greeting.
+type FixtureExchange struct {
+	Request  FixtureData `json:"request"`
+	Response FixtureData `json:"response"`
+}
+
+// FixtureData is data sent over a recorded connection. It is saved as a
+// string if it is valid UTF-8, so fixtures of text protocols can be read and
+// edited, and as base64 otherwise.
+type FixtureData []byte
+
+// MarshalJSON implements json.Marshaler.
+func (d FixtureData) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(d) {
+		return json.Marshal(string(d))
+	}
+	return json.Marshal(struct {
+		Base64 []byte `json:"base64"`
+	}{Base64: d})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *FixtureData) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(data, []byte(`"`)) {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*d = FixtureData(s)
+		return nil
+	}
+
+	var encoded struct {
+		Base64 []byte `json:"base64"`
+	}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	*d = encoded.Base64
+	return nil
+}
+
+// LoadFixture reads a fixture from a file.
+func LoadFixture(path string) (Fixture, error) {
+	var f Fixture
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return f, err
+	}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return f, fmt.Errorf("invalid fixture '%s': %w", path, err)
+	}
+	return f, nil
+}
+
+// Save writes the fixture to a file, creating its directory if needed.
+func (f Fixture) Save(path string) error {
+	data, err := json.MarshalIndent(f, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// NewFixtureFetcher returns a Fetcher like NewFetcher, whose connections to its
+// hosts are replayed from the fixture at path, so the test doesn't need the
+// real hosts. When tests run with -record, the connections are made to the
+// real hosts instead, and they are recorded into the fixture when the test
+// ends.
+//
+// Only the connections made through the transport of the HostData of the
+// MetricSet are recorded, like the ones of helper.HTTP. Connections are
+// replayed in the order they were recorded, the one replayed for a new
+// connection is the first one not replayed yet whose first request starts with
+// the same line, whatever its address. Requests are not compared otherwise,
+// and the values of their Authorization and Cookie headers are not recorded.
+// TLS connections are recorded encrypted, and can't be replayed.
+//
+// The transport is set in mb.Registry while the MetricSet is created, so tests
+// that use it can't run in parallel.
+func NewFixtureFetcher(t testing.TB, config interface{}, path string) Fetcher {
+	return newFixtureFetcher(t, config, path, mb.Registry, *flags.RecordFlag)
+}
+
+func newFixtureFetcher(t testing.TB, config interface{}, path string, registry *mb.Register, record bool) Fetcher {
+	t.Helper()
+
+	if record {
+		recorder := NewRecorder()
+		t.Cleanup(func() {
+			if err := recorder.Fixture().Save(path); err != nil {
+				t.Errorf("failed to save fixture: %v", err)
+			}
+		})
+		registry.SetTransportWrapper(recorder.Transport)
+	} else {
+		fixture, err := LoadFixture(path)
+		if err != nil {
+			t.Fatalf("failed to load fixture, use -record to record it: %v", err)
+		}
+		replayer := NewReplayer(fixture)
+		t.Cleanup(func() {
+			for _, err := range replayer.Errors() {
+				t.Errorf("failed to replay fixture '%s': %v", path, err)
+			}
+		})
+		registry.SetTransportWrapper(replayer.Transport)
+	}
+	defer registry.SetTransportWrapper(nil)
+
+	return NewFetcherWithRegistry(t, config, registry)
+}
+
+// Recorder records the connections made by MetricSets to their hosts. Its
+// Transport method can be used as transport wrapper of a mb.Register.
+type Recorder struct {
+	mu          sync.Mutex
+	connections []*FixtureConnection
+}
+
+// NewRecorder returns a new Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Transport returns a transport that records the connections made with the
+// transport of the host data, or with the default one if it has none.
+func (r *Recorder) Transport(hostData mb.HostData) dialer.Builder {
+	parent := hostData.Transport
+	if parent == nil {
+		parent = dialer.NewDefaultDialerBuilder()
+	}
+	return &recordingBuilder{parent: parent, recorder: r}
+}
+
+// Fixture returns the connections recorded so far, except the ones that
+// didn't send or receive any data.
+func (r *Recorder) Fixture() Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f := Fixture{Connections: []FixtureConnection{}}
+	for _, c := range r.connections {
+		if len(c.Exchanges) == 0 {
+			continue
+		}
+		c := FixtureConnection{
+			Network:   c.Network,
+			Address:   c.Address,
+			Exchanges: append([]FixtureExchange(nil), c.Exchanges...),
+		}
+		for i := range c.Exchanges {
+			c.Exchanges[i].Request = redactRequest(c.Exchanges[i].Request)
+		}
+		f.Connections = append(f.Connections, c)
+	}
+	return f
+}
+
+// wrote records data written to a connection.
+func (r *Recorder) wrote(c *FixtureConnection, data []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := len(c.Exchanges)
+	if n == 0 || len(c.Exchanges[n-1].Response) > 0 {
+		c.Exchanges = append(c.Exchanges, FixtureExchange{})
+		n++
+	}
+	c.Exchanges[n-1].Request = append(c.Exchanges[n-1].Request, data...)
+}
+
+// read records data read from a connection.
+func (r *Recorder) read(c *FixtureConnection, data []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := len(c.Exchanges)
+	if n == 0 {
+		c.Exchanges = append(c.Exchanges, FixtureExchange{})
+		n++
+	}
+	c.Exchanges[n-1].Response = append(c.Exchanges[n-1].Response, data...)
+}
+
+type recordingBuilder struct {
+	parent   dialer.Builder
+	recorder *Recorder
+}
+
+func (b *recordingBuilder) String() string {
+	return "recording " + b.parent.String()
+}
+
+func (b *recordingBuilder) Make(timeout time.Duration) (transport.Dialer, error) {
+	d, err := b.parent.Make(timeout)
+	if err != nil {
+		return nil, err
+	}
+	return transport.DialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+		conn, err := d.DialContext(ctx, network, address)
+		if err != nil {
+			return nil, err
+		}
+		c := &FixtureConnection{Network: network, Address: address}
+		b.recorder.mu.Lock()
+		b.recorder.connections = append(b.recorder.connections, c)
+		b.recorder.mu.Unlock()
+		return &recordingConn{Conn: conn, recorder: b.recorder, recording: c}, nil
+	}), nil
+}
+
+// recordingConn records the data written to and read from a connection.
+type recordingConn struct {
+	net.Conn
+	recorder  *Recorder
+	recording *FixtureConnection
+}
+
+func (c *recordingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.recorder.read(c.recording, b[:n])
+	}
+	return n, err
+}
+
+func (c *recordingConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	if n > 0 {
+		c.recorder.wrote(c.recording, b[:n])
+	}
+	return n, err
+}
+
+// secretHeaders matches the values of the HTTP headers with credentials.
+var secretHeaders = regexp.MustCompile(`(?im)^((?:proxy-)?authorization|cookie):[^\r\n]*`)
+
+// redactRequest removes the values of the HTTP headers with credentials from
+// a request.
+func redactRequest(request FixtureData) FixtureData {
+	if !utf8.Valid(request) {
+		return request
+	}
+	return secretHeaders.ReplaceAll(request, []byte("$1: REDACTED"))
+}
+
+// Replayer replays the connections of a Fixture. Its Transport method can be
+// used as transport wrapper of a mb.Register.
+type Replayer struct {
+	mu          sync.Mutex
+	connections []FixtureConnection
+	replayed    []bool
+	errs        []error
+}
+
+// NewReplayer returns a Replayer of the connections of the fixture.
+func NewReplayer(f Fixture) *Replayer {
+	return &Replayer{
+		connections: f.Connections,
+		replayed:    make([]bool, len(f.Connections)),
+	}
+}
+
+// Transport returns a transport whose connections are replayed from the
+// fixture, the host data is ignored.
+func (r *Replayer) Transport(mb.HostData) dialer.Builder {
+	return &replayingBuilder{replayer: r}
+}
+
+// Errors returns the errors of the connections that couldn't be replayed,
+// because there were no recorded connections left for them.
+func (r *Replayer) Errors() []error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]error(nil), r.errs...)
+}
+
+// take returns the first connection not replayed yet for a connection whose
+// first request is given. If it is nil, it returns the first connection that
+// starts with a response, if any.
+func (r *Replayer) take(request []byte) (FixtureConnection, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, c := range r.connections {
+		if r.replayed[i] || len(c.Exchanges) == 0 {
+			continue
+		}
+		first := c.Exchanges[0].Request
+		if (request == nil && len(first) == 0) ||
+			(request != nil && len(first) > 0 && bytes.Equal(firstLine(first), firstLine(request))) {
+			r.replayed[i] = true
+			return c, true
+		}
+	}
+	if request != nil {
+		r.errs = append(r.errs, fmt.Errorf("no recorded connection left for request %q", firstLine(request)))
+	}
+	return FixtureConnection{}, false
+}
+
+// serve plays the host side of a connection, conn is its end of the
+// connection.
+func (r *Replayer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	done := make(chan struct{})
+	defer close(done)
+	chunks := make(chan []byte)
+	go func() {
+		defer close(chunks)
+		for {
+			buf := make([]byte, 32*1024)
+			n, err := conn.Read(buf)
+			if n > 0 {
+				select {
+				case chunks <- buf[:n]:
+				case <-done:
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var received int // Bytes of the current request already received.
+	c, found := r.take(nil)
+	if !found {
+		request, ok := <-chunks
+		if !ok {
+			return
+		}
+		if c, found = r.take(request); !found {
+			return
+		}
+		received = len(request)
+	}
+
+	for _, exchange := range c.Exchanges {
+		if !receive(chunks, len(exchange.Request), received) {
+			return
+		}
+		received = 0
+		if _, err := conn.Write(exchange.Response); err != nil {
+			return
+		}
+	}
+}
+
+// receive waits until a request of the given size is received, when some of
+// it was already received. It returns early if the request stops before,
+// because replayed requests can be shorter than the recorded ones, and false
+// if the connection is closed.
+func receive(chunks <-chan []byte, size, received int) bool {
+	if size == 0 {
+		return true
+	}
+	if received == 0 {
+		chunk, ok := <-chunks
+		if !ok {
+			return false
+		}
+		received = len(chunk)
+	}
+	for received < size {
+		select {
+		case chunk, ok := <-chunks:
+			if !ok {
+				return false
+			}
+			received += len(chunk)
+		case <-time.After(replayRequestIdle):
+			return true
+		}
+	}
+	return true
+}
+
+type replayingBuilder struct {
+	replayer *Replayer
+}
+
+func (b *replayingBuilder) String() string {
+	return "replay"
+}
+
+func (b *replayingBuilder) Make(time.Duration) (transport.Dialer, error) {
+	return transport.DialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go b.replayer.serve(server)
+		return client, nil
+	}), nil
+}
+
+// firstLine returns the first line of data, without its line break.
+func firstLine(data []byte) []byte {
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		data = data[:i]
+	}
+	return bytes.TrimSuffix(data, []byte("\r"))
+}
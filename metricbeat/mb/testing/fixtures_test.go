@@ -0,0 +1,136 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type fixtureMetricSet struct {
+	mb.BaseMetricSet
+	http *helper.HTTP
+}
+
+func (m *fixtureMetricSet) Fetch(r mb.ReporterV2) error {
+	content, err := m.http.FetchContent()
+	if err != nil {
+		return err
+	}
+	r.Event(mb.Event{MetricSetFields: mapstr.M{"body": string(content)}})
+	return nil
+}
+
+func TestFixtureFetcher(t *testing.T) {
+	registry := mb.NewRegister()
+	require.NoError(t, registry.AddMetricSet("fixture", "status", func(base mb.BaseMetricSet) (mb.MetricSet, error) {
+		http, err := helper.NewHTTP(base)
+		if err != nil {
+			return nil, err
+		}
+		return &fixtureMetricSet{BaseMetricSet: base, http: http}, nil
+	}, parse.URLHostParserBuilder{DefaultPath: "/status"}.Build()))
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		fmt.Fprintf(w, "request %d to %s", requests, req.URL.Path)
+	}))
+	config := map[string]interface{}{
+		"module":     "fixture",
+		"metricsets": []string{"status"},
+		"hosts":      []string{server.URL},
+		"headers":    map[string]string{"Authorization": "Bearer secret"},
+	}
+	path := filepath.Join(t.TempDir(), "fixtures", "status.json")
+
+	t.Run("record", func(t *testing.T) {
+		f := newFixtureFetcher(t, config, path, registry, true)
+		for i := 1; i <= 2; i++ {
+			events, errs := f.FetchEvents()
+			require.Empty(t, errs)
+			require.Len(t, events, 1)
+			assert.Equal(t, fmt.Sprintf("request %d to /status", i), events[0].MetricSetFields["body"])
+		}
+	})
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "GET /status HTTP/1.1")
+	assert.Contains(t, string(data), "Authorization: REDACTED")
+	assert.NotContains(t, string(data), "secret")
+
+	t.Run("replay", func(t *testing.T) {
+		f := newFixtureFetcher(t, config, path, registry, false)
+		for i := 1; i <= 2; i++ {
+			events, errs := f.FetchEvents()
+			require.Empty(t, errs)
+			require.Len(t, events, 1)
+			assert.Equal(t, fmt.Sprintf("request %d to /status", i), events[0].MetricSetFields["body"])
+		}
+	})
+
+	t.Run("unknown request", func(t *testing.T) {
+		fixture, err := LoadFixture(path)
+		require.NoError(t, err)
+		replayer := NewReplayer(fixture)
+		registry.SetTransportWrapper(replayer.Transport)
+		defer registry.SetTransportWrapper(nil)
+
+		config := mapstr.M(config).Clone()
+		config["hosts"] = []string{server.URL + "/other"}
+		_, errs := NewFetcherWithRegistry(t, config, registry).FetchEvents()
+		assert.NotEmpty(t, errs)
+		if assert.Len(t, replayer.Errors(), 1) {
+			assert.Contains(t, replayer.Errors()[0].Error(), "GET /other HTTP/1.1")
+		}
+	})
+}
+
+func TestFixtureData(t *testing.T) {
+	for _, data := range []FixtureData{
+		FixtureData("GET / HTTP/1.1\r\n\r\n"),
+		{0x00, 0xff, 0xfe},
+	} {
+		encoded, err := json.Marshal(data)
+		require.NoError(t, err)
+
+		var decoded FixtureData
+		require.NoError(t, json.Unmarshal(encoded, &decoded))
+		assert.Equal(t, data, decoded)
+	}
+
+	encoded, _ := json.Marshal(FixtureData{0xff})
+	assert.JSONEq(t, `{"base64":"/w=="}`, string(encoded))
+}
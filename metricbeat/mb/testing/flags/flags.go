@@ -23,4 +23,9 @@ var (
 	// DataFlag enables file updates (e.g. it dumps events to data.json file).
 	// Use `go test -data` to update files.
 	DataFlag = flag.Bool("data", false, "Write updated files")
+
+	// RecordFlag enables the recording of the fixtures replayed by the tests,
+	// from the connections to real hosts. Use `go test -record` to update
+	// them.
+	RecordFlag = flag.Bool("record", false, "Record the fixtures of the tests from real hosts")
 )
@@ -212,6 +212,32 @@ func NewReportingMetricSetV2WithContext(t testing.TB, config interface{}) mb.Rep
 	return reportingMetricSet
 }
 
+// NewReportingMetricSetV2Batch returns a new ReportingMetricSetV2Batch instance. Then
+// you can use ReportingFetchV2Batch to perform a Fetch operation with the MetricSet.
+func NewReportingMetricSetV2Batch(t testing.TB, config interface{}) mb.ReportingMetricSetV2Batch {
+	metricSet := NewMetricSet(t, config)
+
+	reportingMetricSet, ok := metricSet.(mb.ReportingMetricSetV2Batch)
+	if !ok {
+		t.Fatal("MetricSet does not implement ReportingMetricSetV2Batch")
+	}
+
+	return reportingMetricSet
+}
+
+// NewReportingMetricSetV2Stream returns a new ReportingMetricSetV2Stream instance. Then
+// you can use ReportingFetchV2Stream to perform a Fetch operation with the MetricSet.
+func NewReportingMetricSetV2Stream(t testing.TB, config interface{}) mb.ReportingMetricSetV2Stream {
+	metricSet := NewMetricSet(t, config)
+
+	reportingMetricSet, ok := metricSet.(mb.ReportingMetricSetV2Stream)
+	if !ok {
+		t.Fatal("MetricSet does not implement ReportingMetricSetV2Stream")
+	}
+
+	return reportingMetricSet
+}
+
 // CapturingReporterV2 is a reporter used for testing which stores all events and errors
 type CapturingReporterV2 struct {
 	events []mb.Event
@@ -270,6 +296,27 @@ func ReportingFetchV2WithContext(metricSet mb.ReportingMetricSetV2WithContext) (
 	return r.events, r.errs
 }
 
+// ReportingFetchV2Batch runs the given batch metricset and returns all of the
+// events and errors that occur during that period.
+func ReportingFetchV2Batch(metricSet mb.ReportingMetricSetV2Batch) ([]mb.Event, []error) {
+	events, err := metricSet.Fetch(context.Background())
+	if err != nil {
+		return events, []error{err}
+	}
+	return events, nil
+}
+
+// ReportingFetchV2Stream runs the given streaming metricset and returns all of the
+// events and errors that occur during that period.
+func ReportingFetchV2Stream(metricSet mb.ReportingMetricSetV2Stream) ([]mb.Event, []error) {
+	r := &CapturingReporterV2{}
+	err := metricSet.Fetch(context.Background(), r.Event)
+	if err != nil {
+		r.errs = append(r.errs, err)
+	}
+	return r.events, r.errs
+}
+
 // NewPushMetricSet instantiates a new PushMetricSet using the given
 // configuration. The ModuleFactory and MetricSetFactory are obtained from the
 // global Registry.
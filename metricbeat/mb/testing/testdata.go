@@ -254,6 +254,12 @@ func runTest(t *testing.T, file string, module, metricSetName string, config Dat
 	case mb.ReportingMetricSetV2Error:
 		metricSet := NewReportingMetricSetV2Error(t, moduleConfig)
 		events, errs = ReportingFetchV2Error(metricSet)
+	case mb.ReportingMetricSetV2Batch:
+		metricSet := NewReportingMetricSetV2Batch(t, moduleConfig)
+		events, errs = ReportingFetchV2Batch(metricSet)
+	case mb.ReportingMetricSetV2Stream:
+		metricSet := NewReportingMetricSetV2Stream(t, moduleConfig)
+		events, errs = ReportingFetchV2Stream(metricSet)
 	default:
 		t.Fatalf("unknown type: %T", v)
 	}
@@ -0,0 +1,98 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package v1compat adapts the Fetch and Run methods of MetricSets that
+// implement the deprecated V1 interfaces, mb.ReportingMetricSet and
+// mb.PushMetricSet, to the V2 interfaces, so out-of-tree modules can be
+// migrated without rewriting how their MetricSets report events.
+//
+// A MetricSet implementing mb.ReportingMetricSet is migrated to
+// mb.ReportingMetricSetV2Error by renaming its Fetch method, for example to
+// fetchV1, and adding:
+//
+//	func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+//		return v1compat.Fetch(m.Module().Name(), r, m.fetchV1)
+//	}
+//
+// A MetricSet implementing mb.PushMetricSet is migrated to
+// mb.PushMetricSetV2WithContext by renaming its Run method, for example to
+// runV1, and adding:
+//
+//	func (m *MetricSet) Run(ctx context.Context, r mb.ReporterV2) {
+//		v1compat.Run(ctx, m.Module().Name(), r, m.runV1)
+//	}
+package v1compat
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Fetch calls fetch, the Fetch method of a V1 MetricSet, with a reporter that
+// converts the events to mb.Event and reports them to r. The errors reported
+// without metadata are returned, joined, instead of being reported as events,
+// so a failed fetch is handled as the failure of a mb.ReportingMetricSetV2Error.
+func Fetch(module string, r mb.ReporterV2, fetch func(mb.Reporter)) error { //nolint:staticcheck // Reporter is deprecated, this adapts it
+	reporter := &reporter{module: module, r: r, collectErrors: true}
+	fetch(reporter)
+	return reporter.err()
+}
+
+// Run calls run, the Run method of a V1 push MetricSet, with a reporter that
+// converts the events to mb.Event and reports them to r, and that is done when
+// ctx is done.
+func Run(ctx context.Context, module string, r mb.ReporterV2, run func(mb.PushReporter)) { //nolint:staticcheck // PushReporter is deprecated, this adapts it
+	run(&reporter{module: module, r: r, done: ctx.Done()})
+}
+
+// reporter implements mb.PushReporter on top of a mb.ReporterV2.
+type reporter struct {
+	module string
+	r      mb.ReporterV2
+	done   <-chan struct{}
+
+	collectErrors bool // Set to true if the errors without metadata are kept instead of reported.
+	mu            sync.Mutex
+	errs          []error
+}
+
+func (r *reporter) Done() <-chan struct{}     { return r.done }
+func (r *reporter) Event(event mapstr.M) bool { return r.ErrorWith(nil, event) }
+func (r *reporter) Error(err error) bool      { return r.ErrorWith(err, nil) }
+
+func (r *reporter) ErrorWith(err error, meta mapstr.M) bool {
+	if err == nil && meta == nil {
+		return true
+	}
+	if r.collectErrors && meta == nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.errs = append(r.errs, err)
+		return true
+	}
+	return r.r.Event(mb.TransformMapStrToEvent(r.module, meta, err))
+}
+
+func (r *reporter) err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return errors.Join(r.errs...)
+}
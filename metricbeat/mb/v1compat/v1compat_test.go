@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1compat
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetch(t *testing.T) {
+	r := &mbtest.CapturingReporterV2{}
+	err := Fetch("example", r, func(r mb.Reporter) { //nolint:staticcheck // Reporter is deprecated, this tests its adapter
+		r.Event(mapstr.M{
+			"count":          1,
+			mb.ModuleDataKey: mapstr.M{"version": "1.0"},
+		})
+		r.Event(nil)
+		r.ErrorWith(errors.New("partial data"), mapstr.M{"count": 0})
+		r.Error(errors.New("connection refused"))
+	})
+	assert.EqualError(t, err, "connection refused")
+	assert.Empty(t, r.GetErrors())
+
+	events := r.GetEvents()
+	require.Len(t, events, 2)
+	assert.Equal(t, mapstr.M{"version": "1.0"}, events[0].ModuleFields)
+	assert.Equal(t, mapstr.M{"count": 1}, events[0].MetricSetFields)
+	assert.EqualError(t, events[1].Error, "partial data")
+	assert.Equal(t, mapstr.M{"count": 0}, events[1].MetricSetFields)
+}
+
+func TestFetchWithoutErrors(t *testing.T) {
+	r := &mbtest.CapturingReporterV2{}
+	err := Fetch("example", r, func(r mb.Reporter) { //nolint:staticcheck // Reporter is deprecated, this tests its adapter
+		r.Event(mapstr.M{"count": 1})
+	})
+	assert.NoError(t, err)
+	assert.Len(t, r.GetEvents(), 1)
+}
+
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &mbtest.CapturingReporterV2{}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Run(ctx, "example", r, func(r mb.PushReporter) { //nolint:staticcheck // PushReporter is deprecated, this tests its adapter
+			r.Event(mapstr.M{"count": 1})
+			r.Error(errors.New("connection refused"))
+			<-r.Done()
+		})
+	}()
+
+	cancel()
+	<-done
+
+	events := r.GetEvents()
+	require.Len(t, events, 2)
+	assert.Equal(t, mapstr.M{"count": 1}, events[0].MetricSetFields)
+	assert.EqualError(t, events[1].Error, "connection refused")
+}
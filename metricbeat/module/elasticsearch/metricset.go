@@ -39,6 +39,7 @@ var (
 	HostParser = parse.URLHostParserBuilder{
 		DefaultScheme: defaultScheme,
 		PathConfigKey: pathConfigKey,
+		Fallbacks:     true,
 	}.Build()
 )
 
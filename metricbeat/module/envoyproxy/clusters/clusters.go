@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clusters
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/beats/v7/metricbeat/module/envoyproxy"
+)
+
+const (
+	defaultScheme = "http"
+	clustersPath  = "/clusters?format=json"
+)
+
+// statsFilter restricts the stats returned by Envoy to the ones of the
+// clusters.
+var statsFilter = "&filter=" + url.QueryEscape(`^cluster\.`)
+
+var (
+	hostParser = parse.URLHostParserBuilder{
+		DefaultScheme: defaultScheme,
+	}.Build()
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("envoyproxy", "clusters", New,
+		mb.WithHostParser(hostParser),
+	)
+}
+
+// MetricSet collects the health and the stats of the clusters of Envoy and
+// of their upstream hosts.
+type MetricSet struct {
+	mb.BaseMetricSet
+	http    *helper.HTTP
+	baseURI string
+}
+
+// New creates a new instance of the clusters MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := struct{}{}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+	http, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		http:          http,
+		baseURI:       strings.TrimSuffix(base.HostData().SanitizedURI, "/"),
+	}, nil
+}
+
+// Fetch reports an event for every cluster and for every upstream host of
+// the clusters.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	m.http.SetURI(m.baseURI + clustersPath)
+	content, err := m.http.FetchContent()
+	if err != nil {
+		return fmt.Errorf("error fetching clusters: %w", err)
+	}
+	clusters, err := parseClusters(content)
+	if err != nil {
+		return err
+	}
+
+	m.http.SetURI(m.baseURI + envoyproxy.StatsPath + statsFilter)
+	content, err = m.http.FetchContent()
+	if err != nil {
+		return fmt.Errorf("error fetching stats: %w", err)
+	}
+	stats, err := envoyproxy.ParseStats(content)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range eventsMapping(clusters, stats) {
+		if !reporter.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package clusters
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "json", r.URL.Query().Get("format"))
+
+		var file string
+		switch r.URL.Path {
+		case "/clusters":
+			file = "clusters.json"
+		case "/stats":
+			assert.Equal(t, `^cluster\.`, r.URL.Query().Get("filter"))
+			file = "stats.json"
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		content, err := os.ReadFile(filepath.Join("..", "_meta", "test", file))
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 6)
+
+	cluster := events[0].MetricSetFields
+	assert.Equal(t, mapstr.M{
+		"name":          "backend.v2",
+		"added_via_api": true,
+		"membership": mapstr.M{
+			"total":    int64(3),
+			"healthy":  int64(1),
+			"degraded": int64(1),
+			"excluded": int64(0),
+		},
+		"connections": mapstr.M{
+			"active":          int64(5),
+			"total":           int64(76),
+			"connect_fail":    int64(14),
+			"connect_timeout": int64(9),
+			"overflow":        int64(0),
+		},
+		"requests": mapstr.M{
+			"active":  int64(1),
+			"total":   int64(2852),
+			"timeout": int64(3),
+			"pending": mapstr.M{
+				"active":   int64(0),
+				"overflow": int64(2),
+			},
+			"status": mapstr.M{
+				"2xx": int64(2631),
+				"4xx": int64(0),
+				"5xx": int64(221),
+			},
+			"retry": mapstr.M{
+				"count":          int64(187),
+				"success":        int64(164),
+				"overflow":       int64(1),
+				"limit_exceeded": int64(6),
+			},
+		},
+		"outlier_detection": mapstr.M{
+			"ejections": mapstr.M{
+				"active":   int64(1),
+				"enforced": int64(4),
+			},
+		},
+	}, cluster)
+
+	assert.Equal(t, mapstr.M{
+		"name": "backend.v2",
+		"host": mapstr.M{
+			"address": "10.0.3.17:8080",
+			"weight":  int64(2),
+			"locality": mapstr.M{
+				"region": "eu-west-1",
+				"zone":   "eu-west-1a",
+			},
+			"health": mapstr.M{
+				"status":     "healthy",
+				"eds_status": "healthy",
+			},
+			"connections": mapstr.M{
+				"active":       int64(4),
+				"total":        int64(41),
+				"connect_fail": int64(2),
+			},
+			"requests": mapstr.M{
+				"active":  int64(1),
+				"total":   int64(1900),
+				"success": int64(1893),
+				"error":   int64(7),
+				"timeout": int64(3),
+			},
+			"outlier_detection": mapstr.M{
+				"success_rate": 99.63,
+			},
+		},
+	}, events[1].MetricSetFields)
+
+	ejected := events[2].MetricSetFields
+	assertValue(t, ejected, "host.health.status", "unhealthy")
+	assertValue(t, ejected, "host.health.flags", []string{"failed_outlier_check"})
+	assertValue(t, ejected, "host.requests.timeout", int64(0))
+	assertValue(t, ejected, "host.connections.active", int64(0))
+
+	degraded := events[3].MetricSetFields
+	assertValue(t, degraded, "host.health.status", "degraded")
+	assertValue(t, degraded, "host.health.eds_status", "degraded")
+
+	google := events[4].MetricSetFields
+	assertValue(t, google, "name", "service_google")
+	assertValue(t, google, "added_via_api", false)
+	assertValue(t, google, "requests.retry.count", int64(0))
+	assertValue(t, google, "membership.healthy", int64(1))
+
+	googleHost := events[5].MetricSetFields
+	assertValue(t, googleHost, "host.address", "142.250.185.78:443")
+	assertValue(t, googleHost, "host.hostname", "google.com")
+	assertValue(t, googleHost, "host.requests.success", int64(12))
+	_, err := googleHost.GetValue("host.locality")
+	assert.Error(t, err)
+}
+
+func TestHealthMapping(t *testing.T) {
+	cases := []struct {
+		healthStatus map[string]interface{}
+		expected     mapstr.M
+	}{
+		{
+			healthStatus: map[string]interface{}{},
+			expected:     mapstr.M{"status": "healthy", "eds_status": "unknown"},
+		},
+		{
+			healthStatus: map[string]interface{}{"eds_health_status": "DRAINING"},
+			expected:     mapstr.M{"status": "unhealthy", "eds_status": "draining"},
+		},
+		{
+			healthStatus: map[string]interface{}{"eds_health_status": "HEALTHY", "pending_dynamic_removal": true},
+			expected:     mapstr.M{"status": "healthy", "eds_status": "healthy", "flags": []string{"pending_dynamic_removal"}},
+		},
+		{
+			healthStatus: map[string]interface{}{"eds_health_status": "HEALTHY", "failed_active_degraded_check": true},
+			expected:     mapstr.M{"status": "degraded", "eds_status": "healthy", "flags": []string{"failed_active_degraded_check"}},
+		},
+		{
+			healthStatus: map[string]interface{}{"eds_health_status": "DEGRADED", "failed_active_health_check": true, "failed_active_degraded_check": true},
+			expected:     mapstr.M{"status": "unhealthy", "eds_status": "degraded", "flags": []string{"failed_active_degraded_check", "failed_active_health_check"}},
+		},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, healthMapping(c.healthStatus))
+	}
+}
+
+func TestFetchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("not json"))
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
+	_, errs := mbtest.ReportingFetchV2Error(f)
+	assert.NotEmpty(t, errs)
+}
+
+func assertValue(t *testing.T, fields mapstr.M, key string, expected interface{}) {
+	t.Helper()
+	value, err := fields.GetValue(key)
+	if assert.NoError(t, err, key) {
+		assert.Equal(t, expected, value, key)
+	}
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "envoyproxy",
+		"metricsets": []string{"clusters"},
+		"hosts":      []string{host},
+	}
+}
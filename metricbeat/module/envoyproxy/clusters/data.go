@@ -0,0 +1,255 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clusters
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/envoyproxy"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// clusterStats maps the fields of the cluster events to the names of the
+// stats of the clusters, without the cluster.<name>. prefix.
+var clusterStats = map[string]string{
+	"membership.total":                     "membership_total",
+	"membership.healthy":                   "membership_healthy",
+	"membership.degraded":                  "membership_degraded",
+	"membership.excluded":                  "membership_excluded",
+	"connections.active":                   "upstream_cx_active",
+	"connections.total":                    "upstream_cx_total",
+	"connections.connect_fail":             "upstream_cx_connect_fail",
+	"connections.connect_timeout":          "upstream_cx_connect_timeout",
+	"connections.overflow":                 "upstream_cx_overflow",
+	"requests.active":                      "upstream_rq_active",
+	"requests.total":                       "upstream_rq_total",
+	"requests.timeout":                     "upstream_rq_timeout",
+	"requests.pending.active":              "upstream_rq_pending_active",
+	"requests.pending.overflow":            "upstream_rq_pending_overflow",
+	"requests.status.2xx":                  "upstream_rq_2xx",
+	"requests.status.3xx":                  "upstream_rq_3xx",
+	"requests.status.4xx":                  "upstream_rq_4xx",
+	"requests.status.5xx":                  "upstream_rq_5xx",
+	"requests.retry.count":                 "upstream_rq_retry",
+	"requests.retry.success":               "upstream_rq_retry_success",
+	"requests.retry.overflow":              "upstream_rq_retry_overflow",
+	"requests.retry.limit_exceeded":        "upstream_rq_retry_limit_exceeded",
+	"outlier_detection.ejections.active":   "outlier_detection.ejections_active",
+	"outlier_detection.ejections.enforced": "outlier_detection.ejections_enforced_total",
+	"health_check.attempts":                "health_check.attempt",
+	"health_check.success":                 "health_check.success",
+	"health_check.failure":                 "health_check.failure",
+}
+
+// hostStats maps the names of the stats of the upstream hosts to the fields
+// of the host events.
+var hostStats = map[string]string{
+	"cx_active":       "connections.active",
+	"cx_total":        "connections.total",
+	"cx_connect_fail": "connections.connect_fail",
+	"rq_active":       "requests.active",
+	"rq_total":        "requests.total",
+	"rq_success":      "requests.success",
+	"rq_error":        "requests.error",
+	"rq_timeout":      "requests.timeout",
+}
+
+// Health flags of the upstream hosts making them unhealthy or degraded. Other
+// flags, like pending_dynamic_removal, don't affect the health of the host.
+var (
+	unhealthyFlags = map[string]bool{
+		"failed_active_health_check": true,
+		"failed_outlier_check":       true,
+		"pending_active_hc":          true,
+		"active_hc_timeout":          true,
+	}
+	degradedFlags = map[string]bool{
+		"failed_active_degraded_check": true,
+	}
+)
+
+type clusterStatus struct {
+	Name         string       `json:"name"`
+	AddedViaAPI  bool         `json:"added_via_api"`
+	HostStatuses []hostStatus `json:"host_statuses"`
+}
+
+type hostStatus struct {
+	Address struct {
+		SocketAddress *struct {
+			Address   string `json:"address"`
+			PortValue int    `json:"port_value"`
+		} `json:"socket_address"`
+		Pipe *struct {
+			Path string `json:"path"`
+		} `json:"pipe"`
+	} `json:"address"`
+	Hostname string `json:"hostname"`
+	Weight   int64  `json:"weight"`
+	Stats    []struct {
+		Name string `json:"name"`
+		// Value is encoded as a string, and omitted when it is zero.
+		Value string `json:"value"`
+	} `json:"stats"`
+	HealthStatus map[string]interface{} `json:"health_status"`
+	SuccessRate  *struct {
+		Value float64 `json:"value"`
+	} `json:"success_rate"`
+	Locality struct {
+		Region  string `json:"region"`
+		Zone    string `json:"zone"`
+		SubZone string `json:"sub_zone"`
+	} `json:"locality"`
+}
+
+func parseClusters(content []byte) ([]clusterStatus, error) {
+	var response struct {
+		ClusterStatuses []clusterStatus `json:"cluster_statuses"`
+	}
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, fmt.Errorf("error decoding clusters: %w", err)
+	}
+	sort.Slice(response.ClusterStatuses, func(i, j int) bool {
+		return response.ClusterStatuses[i].Name < response.ClusterStatuses[j].Name
+	})
+	return response.ClusterStatuses, nil
+}
+
+func eventsMapping(clusters []clusterStatus, stats envoyproxy.Stats) []mb.Event {
+	var events []mb.Event
+	for _, cluster := range clusters {
+		fields := mapstr.M{
+			"name":          cluster.Name,
+			"added_via_api": cluster.AddedViaAPI,
+		}
+		// Cluster names can contain dots, so the stats are looked up by
+		// their full name instead of splitting their names.
+		stats.Put(fields, "cluster."+cluster.Name+".", clusterStats)
+		events = append(events, mb.Event{MetricSetFields: fields})
+
+		for _, host := range cluster.HostStatuses {
+			events = append(events, mb.Event{
+				MetricSetFields: mapstr.M{
+					"name": cluster.Name,
+					"host": hostMapping(host),
+				},
+			})
+		}
+	}
+	return events
+}
+
+func hostMapping(host hostStatus) mapstr.M {
+	fields := mapstr.M{
+		"health": healthMapping(host.HealthStatus),
+	}
+	switch {
+	case host.Address.SocketAddress != nil:
+		fields["address"] = net.JoinHostPort(host.Address.SocketAddress.Address, strconv.Itoa(host.Address.SocketAddress.PortValue))
+	case host.Address.Pipe != nil:
+		fields["address"] = host.Address.Pipe.Path
+	}
+	if host.Hostname != "" {
+		fields["hostname"] = host.Hostname
+	}
+	if host.Weight > 0 {
+		fields["weight"] = host.Weight
+	}
+
+	locality := mapstr.M{}
+	for key, value := range map[string]string{
+		"region":   host.Locality.Region,
+		"zone":     host.Locality.Zone,
+		"sub_zone": host.Locality.SubZone,
+	} {
+		if value != "" {
+			locality[key] = value
+		}
+	}
+	if len(locality) > 0 {
+		fields["locality"] = locality
+	}
+
+	for _, stat := range host.Stats {
+		field, found := hostStats[stat.Name]
+		if !found {
+			continue
+		}
+		var value int64
+		if stat.Value != "" {
+			var err error
+			if value, err = strconv.ParseInt(stat.Value, 10, 64); err != nil {
+				continue
+			}
+		}
+		_, _ = fields.Put(field, value)
+	}
+
+	if host.SuccessRate != nil {
+		_, _ = fields.Put("outlier_detection.success_rate", host.SuccessRate.Value)
+	}
+	return fields
+}
+
+// healthMapping summarizes the health status of an upstream host, as
+// reported by EDS and by the active and passive health checks.
+func healthMapping(healthStatus map[string]interface{}) mapstr.M {
+	edsStatus, _ := healthStatus["eds_health_status"].(string)
+	edsStatus = strings.ToLower(edsStatus)
+	if edsStatus == "" {
+		edsStatus = "unknown"
+	}
+
+	status := "healthy"
+	switch edsStatus {
+	case "unhealthy", "draining", "timeout":
+		status = "unhealthy"
+	case "degraded":
+		status = "degraded"
+	}
+
+	flags := []string{}
+	for flag, value := range healthStatus {
+		if set, ok := value.(bool); !ok || !set {
+			continue
+		}
+		flags = append(flags, flag)
+		switch {
+		case unhealthyFlags[flag]:
+			status = "unhealthy"
+		case degradedFlags[flag] && status == "healthy":
+			status = "degraded"
+		}
+	}
+	sort.Strings(flags)
+
+	health := mapstr.M{
+		"status":     status,
+		"eds_status": edsStatus,
+	}
+	if len(flags) > 0 {
+		health["flags"] = flags
+	}
+	return health
+}
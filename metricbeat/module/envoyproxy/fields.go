@@ -32,5 +32,5 @@ func init() {
 // AssetEnvoyproxy returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/envoyproxy.
 func AssetEnvoyproxy() string {
-	return "eJzEml9v47gRwN/zKQZ5ygK32+wWKAo/FDjkFtgFekXR7EPRoiDG5MhiQ5EqObLj+/TFkJJiO7bj3YsUPSwcr8T5zWj+cej38EDbBZBfh20bw+P2CoAtO1rA9efxy+srAENJR9uyDX4Bf7kCgJ2noAmmc3QFEMkRJlrACq8AEjFbv0oL+Pd1Su76J7iumdvr/1wBVJacSYu80nvw2NABh1y8bWWtGLq2/+YIx/5au+slimuK49fH1pNrH3q4jkoq113wjNanAgzFBkUYJEZOO3cfsu3yadclpqga9LjaAz0Ne2rN3XVRs12T6pdP8OzGYXHrmZ4LfkH74fpb1ywpQqhAdzGSZ7ftJcPNBmND5t2gYTqJOpgAjSEzDee3wOhGEsiS4IYs1xRhbTG/MqtBB1/ZFYQId7/cv3uRuAnGVnYm6EEY3AjwRXyRmrCeCa+XdQGdOIb1K3XSMSZzzV4y3PjAvZ9+h392rUGexZpQRF2MpNYWVUNxRTPCAbatE3/EBFm2eRG7/H8hVRq9JuemNeg+GHCNDKvAMAoH9AYMObumSAYIo9u+xB86VqHq1dhYb8JmSh0G+E1tdQ0Yo5U4Cx2Lc2NREQ4wjtaayjpK28TUvFqZqVyXajJquVVsm6N6vpod/BjRIirlJaNHVyBg2VVVTu6RYBMtM3ngAJjVBtOR/FVuledDxye1ihRa8qpCO7F7HqrUw24wQREuzEsCwTlCMmbUaJlUMcC8wMW2yAhWKtS6EOe+8cQLekEJHZrW0cRp9ozZe895gZJlmdHgEzVXd6VuQRYGyf5GQnzUqGA9LLfnUm/hfgOP9kAxhghB5zpswHRRavBgc+ES/44oi59PYLHzsuqrZS8X0KiMN49JRB4gMzUt98UoUuock5E3ONoqf96Cw+2ZeMnwqdOa0kQt1AX4G4oEPUTVOUAGdK6Qn3ZG3zXqgbbT70qykKf+TxQ4k0bDmmK0hpSxUdGjTTyjYXuDGmugSyS+MOCAsZE0h3i6Mdkj94Hfkl566+/XAE1jvRoeSKp059Mo8BFslQMsCx0pS+vQb1+DbA03NhHcnmQ21EbSuQGvCLmLpLo0aQt+mF2fCKAnSCUku0Tmw9n4OxGirxyARcqx6YDlWlpYcR4pBznxpXfn87+ziclPMC0ZF558BjFIumgI8ddz2+iRWUfCvrZ3cVL363vSQTKE5X9Js2hi5eEkvd8mxIdzuf8Qe4YKdsCbdgqWeGR+D99DPsfY58lP9uc+l7nEDIOfJ8C9yc9ZvtI2j09OmuTPjCZHgItBTUTrrV/NhzpI/AHYfso1/1jtZdTRQROHtp3LQQ+FHa0th0N7+F0FRXqKyk07FzpsBvKuGzT6PN10LkhjAH1ZSVJZ+iEIQqpDZFzlDWWqUbZlDTW7/dlxGx0eplxkpCML7i5qcJuUbOycqmxMrDRFaWBbG2fwYpEOWTpwTeDpkUEAbGWz+ZYknl36DQMb6xxktOdZ68nHJ+1bhbI/Z7IpN9vPk8ZPcPvUwJ4ELW9cDZ4yUTAOUwxsQufLyHIQ2BO8PLzoSWvCViX720TmHUgjZfsaEHll7vIiYYvyqNLBe9K5F5oy8nfE5PCvCYIzfQJoY5DmBoKHOmRtGOPpWWepHm8Dvgxcg6dNHsI/0+DsQcKROdArkQ5u0AdZESUekEgHb05DrSmm3RHWq1J9LQ9DlB1fIi/h0wscCsESExl57fd3v0KktT1K83QKx7o2YaUaWqFq7A+04M+X+j2r1IFV76yK2qDraV/vTmzAcXHjYVvwJcXq5+OLV65GZQsCXEfCM55maNmtFKYkhSr4Yev347Y3W4+N1arzDz5svCqVe2ptG0oJV/kkZyDoN79dmQbDxnINPRScgNpt4KZOCSJiyLj3x5qhfZw3tejeNOFHDZpUJC2VzYXw0LWXK3C0f6yZ20+v1mPXhIaimrfV3q1gkoh5aKrFJQpR6ptGh3GVgxl9KWyLxRfmtvz7abG4G5f62rRusbjnSNiUz7/+/E/15fPPv3z+h7r/+q/PcPOnPz6c3lf3cpVeKh9UyuvMY44ytoNNTVG2GgUENDq3RP0gHap8zp1zmfehB0wpaJsbwIL6Ab7VNgFH1A/5xKjz9NiSljv6YyOvxyPcvAJsiaHzxuLKByl7y+75VmE8NnpUJTCsX8153GP9Gp01ICGUz+yriM3wK4S1Da7YoPgA/HlILF++ffv7Hz5Bakn3lsnbjnJSlM+GgB9Vdr5zKh+/YRJFSyCUlzkoOSq93BbvP9OBBtWgz/29BHRZYM6dM/ps9E870S2uuxffRR+Jaw5BNoTbMdwL8IdhAz5ux1LXtiFyKr8OzIeeDE1IDMEPyaI8DFWI8PH29v1d8Gx9R/kOH/z7j7e3wtEGnwh0MAfP5cbZU/9Xzu4c0bqz86JTN0xj4V4aJCIv3Wmk/3WUOIEOeWpUxdCACRt/Im+N2G/u0hzRp8Yy73r1/wMAAP//IFqQow=="
+	return "eJzUnF+P47YRwN/9KQb30guw514uSVHsQ4HD5YALkKRF9oIWKQotLY4tdilSJSnbyqcvhn9k2Zb8Z8/StsDi4LMkzm+GQ3JmSPkNPGFzD6jWuqmM3jYzACecxHt49bH98tUMgKPNjaic0Ooe/jIDgM5TUGpeS5wBGJTILN7Dis0ALDon1Mrewz9fWStf3cGrwrnq1b9mAEuBktt739IbUKzEAw664JqK2jK6ruI3PRz7bXXby2VtHRrbXuhrEaCDvUDHOt/3Sgt/n5BJVwBTHKxjzoJegisQ6so6g6xshdMFb8s7f3O4TRgotHV23mnzUIuuJvTv3oWkyRM2G234wbUT3PT3Mysx8UbMea9YxjnybC1Yxipx0EyQv9BaIlPXyf97ga5A05UPG2aDONgIV+xd4sLmeo2mAYtmLXLshy2xXKCxhah6SQ/7fMjk3SaddkweXU0tSq1WPRfPKE9/H2pjULlWwx16EDkfJCq82zWzvYujMEVJAegOhMplzYVawUK7InJAXmD+RF96166dFNRf6DCnvh9Wg+PKMI58Aj2SqHOWxS0pOAlSEtWHlHByrVSwop310TzHnVnuxBpHUPAz6RGb75LPJx9ZgeQihHhTtmRiChIgObXBC4icKFHXbgqo+BGiyBNwNAMvpd6MRkUIFlzBXJj9hclr4WBhkD2hOVivWhwaRQVCybairMuOageqJDUM/qdG6/7fBlXCfqkRdYH8kZ02EUQPESX6FQc2TFCQCUttgIFBW2llcZizQkXL2HzafktigXVcFCqtu6PxPPTog3DfygeD7JBcm96RSrHABg36GQ/5sFYUONd2/m67HV8fH1UyeLfdtj4CueZ4lu6bCem+uZru2wnpvr2a7rsJ6b67nM6gM80817UafbryssSpRZ9uaOa2znO0dhKcBqK081jTzTdKJ1tx4DWC0/1RgElX6OYGFjVfoTunhhSlcBluc8RxIvwTyiwwZ7XFvShF1ZTvUUgT3cOnvwZZXhzOl0mRmFxlbXJ1swAG/50CptGWxJ9bffOQCskmiEV+XAcZokO11CZHPipfErKTm7yRqabNcMld+rsp5MWZz4tv1kPMOSwrZ0fVvJvRn+iP8SaqHUqUsazlpVQxtxqVSpQlcsEcyiZGNvt0vryn0G20eRpI9hIu+fzF3nEG8tdUcKRGD7KkO9BKUtnMgVB+BsI1ql2xsnfsnfVGzs0pD+gvSV5o7veh8S7gHU36FXMFfSmchV+V2ILV+dOpiZ+e7Kma3gjzU2x9n3NToALhQFh4UnpzIp7foFgVYwQfP2rGYcEkUzllG0FOl3KYSeqcSeGaucHV8fpyI8P94tu+Euh3rUbqx9+0withbL3IxgN6qBdvfr8YKsw/8xBtjwMU9zk6ODSpeKM9xhLx4x08pirrIw3Wx1qlS2fZkdtsAv4gomtV8BFXpY2jIK3x6qHilRbKHW853MFjrfygfuwOc4pa7clpyNthvpRsNa6CXoKf6bXqdJUUTwiPtBohz0J8l3VjlNBf8XqKMf16dqLrdnWAEWPG90fV5BT5nx4TXbjJqszXo71U9fky0pTJjN69bcp0HdY0xc4rbTVeaDyMlaofnZA5FUEuIEZjtHkJXgVe9DWscYdiWtr9WvMw5VF2ngo6mWFuePjYnEnk2VJq5p7H/hDEAInprm60YQsVmhyVu6OlLtdlVXeWuuPNWvgr5QrtkuhXObvX/OEjtAKiYgvZLVokk0hhHaqRTl98rzcqnbPYzXF7BzFa+e0BjPnsdJIz/mGLxESdQ5mMB77zHx/pc1YZXIqtX5TpSxayoXkv5k5xe3EueTa3m2RTpEM+H0SZbO0eRuBondFjHLYIELF95JfhjF6M7VCASfW5bjG2vQ6+onro1qfRpWY8C82+pAZLsaoN8hYJ2Dm7r6ReMJm9uPkDR+e20AvzWR90ZTDrNPi/P0M8+IKO7VSIa8XRrDTVMdqJcykk7f5XRtOyR1uig6TjBQwJ9WAnmtfmGtbEme7Pgm4366lxgzvfuoVNISR2VNxf5oJGJ4aWwVI7zHKp7XirTmcYgJfUBkEVUjhTmwHws12ndOy0LC+YUFnJXF7MLtThDP8xu3c3Lrj6gwMvye9JRFQP0E9prbyZUxVMcVuwJzyMOW7XXTHmpOr/5x8fOhLng1Q7K2XBL0eDI6KdtDgK7vyG3+6EZNwdyNE4sRQ5heZrNOHj6aVG6azz1GhaHJh4p1DcTVcacin8KcodzXx2SEu1sb0J60x8v7owuv+glWNCUXKx1g2Nwm0ThflTGXZ22mETX9wFyUqm2OqGM2uso8XmLRzdmBoXyuGx4Kt3nnZLYgygX2+YKZF/lTS0g6jxhswfrR6HM05UyRheErxG4Y94rwXzXSbyGHtRavPh+4evzhKXmoulmAg6CYPXBHwRHy1d64nwoqwL6MgxhFpl6dFx8PpcM0qG1zQXBj+9wj/rijM3iTUhiLoYyb/+UKJZ4YRwwKpK0lEYZsHL5mexw/XM353lTOUo5bgG3QcLJwZX2kEr3O+Ic5RijZRxITOyOceva5fpZVRjIxTXmzF1SPCbQuQFMGPEOkbzegks2B4OMGZ9+Esh0TbWYTnrQ33OMrOUtS2QZ4smo1zGjGmH3YkkEmW9aY1iMkDAol4u/eRuEDZGOIeKqtCMAmVMOWq4dSjtSloZ1BUqf+oe+ZQqRVg6aRUDNKdhgUA4yAd5SV3MggGmBfa4nDlGddawADgdSpkDHXRGCaoBS3TTatExe/ScM5S+8NcafKTgKr2S44WBFb/7He1eo1KxdtGcmnpJL3wJj07bKDr36zBPuWyyOXGRfxu2d2Jw1qeEqRW53ayP/Tmzly/3na5B3NQkJK89KRcWI4O2llREE2pnK/+5AckaNKfhY5b0Yvj+DH0nVWMOmJSBfNgZVV1mT9iMn5V4Ibv4jxRAPohF1VMjOGZcmAy3wroJDRsNygUHOorLVIsDXBjMnTbNZeRKu5ekp9j6eg0YL4XK0gM2ngMZR4GvQSz9APNCW8oQOsT0VVNquBEW4e0gM8fKIJUdeLZE5mqDWW1HYj40Os2DtkMAkcCGIVnbw0PaXXAafwND9MYDMEjpqw4IV1AIS85DKZmf+OwuaZz1cafa582rJW3Do9cgkqSLihA/nkqjW+bcIItr+6nzxTeAjzFpkgx6QTtlpIlojxjR0eJTc/8h9gQr2AGv7ZRvySOpy68in6Lsk4Qd1n0uc4kJCj87wL3Kz0k+H8lm7ZOjTvK7Oeho8mkBLgblhgkl1Go61CRx5wgXw8Yq13Ssqax2HjXdkVmnq2oqBz0UNutDOyzaf9mCcnYjfoRgwGfdVNmiCIxJOpruEOKyYp3IUxGEgS20cWzlE0pbMMphSyy78dmsT6ujzZSLjNTTYLdRzhqbUWIns6Uw1vndpQy3lTATeDFJBy/d77Yq3O5tKMECybNDvMFhI6QEj4aD+shx41aijPtM8XD38aRxB293AewgaOjxLHkKH7eKwUp6l5VM3gqMPne+eBFJC2RVRnWQcUkNevv6V6eqUHc5S1gxUvLEaZbbVuI7m6HxfJOWPJTd0oEA0AoK/46ndcwM1zrDmvwy4P5nehRufBH+SIMT5q6rnjrQjd0gDrIginzUYq4VH4Zao7GnXkr6IqofwsP0Ogj5p6LhEwWmhWDBLO0LKHj48BMYXItemkS7odMZXK+yElcsK8UzQvDjpr6klUK7LDprhpXOi6tbuqp7O2MD+sUlsFyrMMXmzThIu9UopCDgCoPshKdxXNSrjFlLJx+0Sqnf823PG8VKkWfx/aEsrNxja1uitWyFtFGQCGLyW4dqcDjpEaFiODGoA4U5Y08JrnOE/aEvGNrHeVGL7lUTnmtQmxmk8/mZ1Pqpri5XYNbXIv2y4rtZ3+PPibELZBzN+TOvNw21uysYTcQuBdXkEoHIxqBRMrPyg5mpsDTf339yrgr/vru//9A29UNZyfv7B/+yQPj80/t/ZJ8+vv/+4y/Zww+/fYTXf/rmaTivjnKzfJEpnYWXDqYxR3vEkoqOLIJAzqRcsPyJNgXpc/zFRKr3MQXMWp3TS+r0s5SEOofPhbDgDMufLO2C1Aq3VThbHLeNVN5u4foWoEEHteKCrVQ4LVmvBs1jtlmYaujUyYTbPUKtmRQcaAj5PfulYe3vh62FlsEG8Zzen9PE8unz57/98R3YCvNoGZ92hJ0imisZuC0tlXhiB3vohlEU9aJiZyYlW6UXTfD+QVanNRV0mywO6NDANOTtJiUZ/V0nPgVxML6DPpQMOq2BeNOwixrPY/S6S8dsXdGLSpZC8m1DjzIHJb2zRW8nx7HiH/a/DPb127dv6FihUDX695eVVm++fvt2/yeD9p/zgbPC+D8/uztDVdpT9aKhG8axcJQGFlFRdJre7INcl2STpdEl8PZlqWHsF3dpZ5iyJZ0P4LBo4KNa62b23wEAGF1JhA=="
 }
@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package listeners
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/envoyproxy"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const statsPrefix = "listener."
+
+// listenerStats maps the names of the stats of the listeners, without the
+// listener.<name>. prefix, to the fields of the events.
+var listenerStats = map[string]string{
+	"downstream_cx_active":                    "connections.active",
+	"downstream_cx_total":                     "connections.total",
+	"downstream_cx_destroy":                   "connections.destroy",
+	"downstream_cx_overflow":                  "connections.overflow",
+	"downstream_cx_overload_reject":           "connections.overload_reject",
+	"downstream_global_cx_overflow":           "connections.global_overflow",
+	"downstream_pre_cx_active":                "pre_connections.active",
+	"downstream_pre_cx_timeout":               "pre_connections.timeout",
+	"downstream_listener_filter_error":        "listener_filter.error",
+	"downstream_listener_filter_remote_close": "listener_filter.remote_close",
+	"no_filter_chain_match":                   "no_filter_chain_match",
+	"ssl.handshake":                           "ssl.handshakes",
+	"ssl.connection_error":                    "ssl.connection_errors",
+	"ssl.no_certificate":                      "ssl.no_certificate",
+}
+
+func eventsMapping(stats envoyproxy.Stats) []mb.Event {
+	listeners := map[string]mapstr.M{}
+	for name, value := range stats {
+		listener, field, found := splitStat(name)
+		if !found {
+			continue
+		}
+		fields, found := listeners[listener]
+		if !found {
+			fields = mapstr.M{"name": listener}
+			listeners[listener] = fields
+		}
+		_, _ = fields.Put(field, value)
+	}
+
+	names := make([]string, 0, len(listeners))
+	for name := range listeners {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	events := make([]mb.Event, 0, len(names))
+	for _, name := range names {
+		events = append(events, mb.Event{MetricSetFields: listeners[name]})
+	}
+	return events
+}
+
+// splitStat returns the name of the listener and the field of a stat of a
+// listener. Names of listeners contain dots when they are built from their
+// addresses, so the name of the stat is matched from the end. Stats of the
+// worker threads are ignored, they are already aggregated by listener.
+func splitStat(name string) (listener, field string, found bool) {
+	if !strings.HasPrefix(name, statsPrefix) {
+		return "", "", false
+	}
+	name = strings.TrimPrefix(name, statsPrefix)
+
+	for stat, f := range listenerStats {
+		if !strings.HasSuffix(name, "."+stat) {
+			continue
+		}
+		listener = strings.TrimSuffix(name, "."+stat)
+		if listener == "" || isThread(listener) {
+			return "", "", false
+		}
+		return listener, f, true
+	}
+	return "", "", false
+}
+
+func isThread(listener string) bool {
+	thread := listener[strings.LastIndexByte(listener, '.')+1:]
+	return thread == "main_thread" || strings.HasPrefix(thread, "worker_")
+}
@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package listeners
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/beats/v7/metricbeat/module/envoyproxy"
+)
+
+const defaultScheme = "http"
+
+// defaultPath is the path of the stats of Envoy, restricted to the ones of
+// the listeners.
+var defaultPath = envoyproxy.StatsPath + "&filter=" + url.QueryEscape(`^listener\.`)
+
+var (
+	hostParser = parse.URLHostParserBuilder{
+		DefaultScheme: defaultScheme,
+	}.Build()
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("envoyproxy", "listeners", New,
+		mb.WithHostParser(hostParser),
+	)
+}
+
+// MetricSet collects the connection stats of the listeners of Envoy.
+type MetricSet struct {
+	mb.BaseMetricSet
+	http *helper.HTTP
+}
+
+// New creates a new instance of the listeners MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := struct{}{}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+	http, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+	http.SetURI(strings.TrimSuffix(base.HostData().SanitizedURI, "/") + defaultPath)
+
+	return &MetricSet{
+		base,
+		http,
+	}, nil
+}
+
+// Fetch reports an event for every listener.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	content, err := m.http.FetchContent()
+	if err != nil {
+		return fmt.Errorf("error fetching stats: %w", err)
+	}
+	stats, err := envoyproxy.ParseStats(content)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range eventsMapping(stats) {
+		if !reporter.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
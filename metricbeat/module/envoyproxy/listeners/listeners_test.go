@@ -0,0 +1,123 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package listeners
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetch(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("..", "_meta", "test", "stats.json"))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/stats", r.URL.Path)
+		assert.Equal(t, "json", r.URL.Query().Get("format"))
+		assert.Equal(t, `^listener\.`, r.URL.Query().Get("filter"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, mapstr.M{
+		"name": "0.0.0.0_10000",
+		"connections": mapstr.M{
+			"active":          int64(3),
+			"total":           int64(121),
+			"destroy":         int64(118),
+			"overflow":        int64(0),
+			"overload_reject": int64(0),
+		},
+		"pre_connections": mapstr.M{
+			"active":  int64(0),
+			"timeout": int64(1),
+		},
+		"no_filter_chain_match": int64(2),
+	}, events[0].MetricSetFields)
+
+	assert.Equal(t, mapstr.M{
+		"name": "[__]_8443",
+		"connections": mapstr.M{
+			"active":  int64(7),
+			"total":   int64(419),
+			"destroy": int64(412),
+		},
+		"ssl": mapstr.M{
+			"handshakes":        int64(414),
+			"connection_errors": int64(5),
+		},
+	}, events[1].MetricSetFields)
+
+	assert.Equal(t, mapstr.M{
+		"name": "admin",
+		"connections": mapstr.M{
+			"active":  int64(1),
+			"total":   int64(37),
+			"destroy": int64(36),
+		},
+	}, events[2].MetricSetFields)
+}
+
+func TestSplitStat(t *testing.T) {
+	cases := []struct {
+		name     string
+		listener string
+		field    string
+		found    bool
+	}{
+		{"listener.0.0.0.0_10000.downstream_cx_active", "0.0.0.0_10000", "connections.active", true},
+		{"listener.ingress.ssl.handshake", "ingress", "ssl.handshakes", true},
+		{"listener.0.0.0.0_10000.worker_3.downstream_cx_active", "", "", false},
+		{"listener.admin.main_thread.downstream_cx_total", "", "", false},
+		{"listener.0.0.0.0_10000.http.ingress_http.downstream_rq_2xx", "", "", false},
+		{"listener.downstream_cx_active", "", "", false},
+		{"listener_manager.total_listeners_active", "", "", false},
+	}
+
+	for _, c := range cases {
+		listener, field, found := splitStat(c.name)
+		assert.Equal(t, c.found, found, c.name)
+		assert.Equal(t, c.listener, listener, c.name)
+		assert.Equal(t, c.field, field, c.name)
+	}
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "envoyproxy",
+		"metricsets": []string{"listeners"},
+		"hosts":      []string{host},
+	}
+}
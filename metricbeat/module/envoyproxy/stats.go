@@ -0,0 +1,69 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package envoyproxy
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// StatsPath is the path of the admin endpoint returning the stats of Envoy
+// as JSON.
+const StatsPath = "/stats?format=json"
+
+// Stats are the counters and gauges of Envoy, indexed by their full name.
+type Stats map[string]int64
+
+// ParseStats parses the response of the stats endpoint. Histograms and text
+// readouts are ignored.
+func ParseStats(content []byte) (Stats, error) {
+	var response struct {
+		Stats []struct {
+			Name  string          `json:"name"`
+			Value json.RawMessage `json:"value"`
+		} `json:"stats"`
+	}
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, fmt.Errorf("error decoding stats: %w", err)
+	}
+
+	stats := make(Stats, len(response.Stats))
+	for _, stat := range response.Stats {
+		if stat.Name == "" {
+			continue
+		}
+		var value int64
+		if err := json.Unmarshal(stat.Value, &value); err != nil {
+			continue
+		}
+		stats[stat.Name] = value
+	}
+	return stats, nil
+}
+
+// Put sets the given fields with the values of the stats with the prefix and
+// the mapped names. Stats that are not available are not set.
+func (s Stats) Put(fields mapstr.M, prefix string, names map[string]string) {
+	for field, name := range names {
+		if value, found := s[prefix+name]; found {
+			_, _ = fields.Put(field, value)
+		}
+	}
+}
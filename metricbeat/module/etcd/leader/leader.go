@@ -46,6 +46,7 @@ var (
 	hostParser = parse.URLHostParserBuilder{
 		DefaultScheme: defaultScheme,
 		DefaultPath:   defaultPath,
+		Fallbacks:     true,
 	}.Build()
 )
 
@@ -36,6 +36,7 @@ var (
 	hostParser = parse.URLHostParserBuilder{
 		DefaultScheme: defaultScheme,
 		DefaultPath:   defaultPath,
+		Fallbacks:     true,
 	}.Build()
 )
 
@@ -32,5 +32,5 @@ func init() {
 // AssetHaproxy returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/haproxy.
 func AssetHaproxy() string {
-	return "eJzsXe1z2zaT/56/Ysdfqjwnq0nrNjOZaWca5+klk8TxxM49H25uVIhcmTiDAAuAltW//gYvpCgKFCGLtHPTR1/a6GXx28Vi37BLn8Itrl9DRgop7tfPADTVDF/DybvfLs07J88AUlSJpIWmgr+GX58BAPhP4ZNIS4bPAFQmpJ4ngi/pzWtYEqbMuxIZEoWv4YaY76DWlN+o1/DfJ0qxkymcZFoXJ//zDGBJkaXqtSV+Cpzk2ARlXnpdGEJSlIV/J4CriS1HLWmiZv6D5grNVShfivrN0DJ7ljKv/0SOkjBLR+bEfAXIQpS6BlJIkaBSWEMxr23RVK82yCbQmszWpxViJvhN64M9oM3roswXKEEsQwD3IZjzMh8Iw6WjCNxi6VleZxJJOjz7nm4f8zQNrkwYJW1MBdFZLa7Z7i9zeiOJA6ZliQ+T2fu3PYhlyed/lrhD/1CJBYkrLYqC7pA4fjcqwvC/YtG3J+YrgwMgjMWsXXKLlCwYzkfB0VggBg+jShtTNDyQmnIPglSKosB0zsTN8CA8cTDEe3AsSrWeF4KxMdTTEAdPvAfHklCG6VyiEqw0lIeXilsCGkv02VCibo+FET4OhaY5zhQmAzF5XkqJXHvCQDkoTATvtdM55kKuZzm5ny3WOt5bOu/9GkI/6oH6idzTvMyB5KLk2uyLAwGlIjcWuiUKE50hfPcJ85zczz+9+Q7uCCsREsHvUGpMQQv3zecBHsOq3slhO4KBQHSxw0hFVpR657N9hKOINxfQQhMW/MaeHapePbtRvRpn1kpfGW0Spe7QnzZESXTbcQ6N8Lc7lEZBHD5R6qLUdt3Y7S+wy94ftf0k0fQuxPxexiOYbjhZu4RjYM+GVIgSwTkmGtuB1LCg6lU6cYV3QYi2Lg+xCYyJhIzD8hX9C13ki5t1LB8Re1GqkbchR/N/yi4ESynyh+F0vnFUpN79er0x7teYcIOvYiJWh0pGc6rnfCDnWXkkXkMVBXJYUobKeDor0So/2Y8sEXkhUcWbmZioIZzpNpddFO0V968av/K+1WGrMDCy+X/PE5GbTKcSMaaQEk1swEC1ggKlD3oifVbYbw+J+XOpb8SgmI2/m1vtHxn6F6IR7ELO1j8Qf8slURE+s+OdjI7A5DGPho1bHy18a5pXyq3pYkTpw9TsKTSshh2JMSf3IyOs/ILRoXiMtcq7lGwEl1ole4fh6coijkVzbegeiEUpNhtfPldXHx+Aa1w5PQxTWNePRVTp9+GYxsVzGJaMyHQ+HKCg+5L4Z4lKq6ByPDTsdAqxCTrrRfZjUSK5Ra0COjFc/FutESmVMaFESqWgxQG1nb0o+kKKvSkd5RpvUB6bK9lszvIEaSlNCHmLkiM7XRDziU4KUAWjSXddtYl4KbE7CBoIsVnDIT7SfhyFZ1d/NpLsO1eo1EPj0wcEpbOusHAAKXzxAYtnqRGmx2yORdcVAQ6Frg78PMZoYKOrjtwWXnRRQqn4utb/A90xQUGP7d2B9WhK8xBwj6Y4B4JbSsE18m6XEpOlPjA1vcX1fK9CQYSI2lj2ZFQfcL0lpor3yJSvhtuX+w0CttrX2+NAezsyl1gqnBXJ/rxaJYRhOl8yQbq+WN29FSiTcAJ1AJPVcbfo2hweYpwXJLn9m2qxZz1GWkHYT6rN3eDbeBOSZLZhQdyWe+rNQ1wV2IzULAd+tR5U85wGer3GwuQWi40L/mJ0Mc8xn9v77WNTlAOjhREDhU/NW3uxtHx+C6F/Ho+rvrxIWcgy91rk/Za4rzPN/cijNBDANoU8a6NTemvhg3surzTRChLBmLuutZeEA7RbhqNgTXQZTsRvcb0Ssu0heqR0ZenB5OvlFN5+/tfFFC4+f3wzhU+/vb+4noKQ7v8md5Q8n81moV6QJrwV0pssvM8PLeA4kjBZClkZU/XcIlMo71BufcG9FWxZacJMxYobZRgUaEUUJpv2oOcz+L2Bewo6o8p32lBlbw86sEB9M7rKBMOKxBS40PZtVebVrXm9cvUTL4aIy1TBkeu5YTwoi7DF6GuWquhaIjB58UsV8Ezh5S81Iz/84mDavfzxF1cT+77q7+vbwqr999trhIXJC7sRSyqVBsqVJjzBKbwEp6FGMaZATEwgQPA+Ro2QaIJz868Bz72jateAye9fPl9c//PircNdb9ab384/VO/W2yYkEL52P9wcueh9o/zROuHe2E4mynsQiVI/MqTuvq/6gpAoPU8ywjsimgedy0Zbs7NOoChPcHOH+fXy9FfjBMwem/+e/vr1ErQkXFFDs78jXmj9BJ6+uo+qAFRUjOvftoiwypCDYmKlNJG7qR1VvhfMqjkXlaVebn5jvkO5+1bvyXURwai3GsbuKyA1g0TV604Bqc5QWiFwXO3QqnISy60VjcTTlKqC6CSj/MY5L+9MvO+yUQpILITU1oHtUDXibuNrbkEDYZ9GSWN00lmHjT9ceO/fVi7TDvN87yHRpVuK8huzvcjJgvWCS4S4pQNa5HNLz2ucR+nxeR22Jtd/4lav/uWNc59RESSdLwgjPKH8Zk7YjZBUZ+HxmQfx8FGQFOoVoF5h+O6V6M7Fsa6bz8u8ZMT2jfJQc0hM2RS1pCM3BVbmYYMMtNg2Fx5GTIuDyZ/IXQjUsYir7mOPE6o2+1yBMEBrH/XyxQ9nh9amR5VwsyFoG2pvS9BW19k4d/7v6E2GSm/MygburKO5u42OaI15oUdr3AiJElBpsmBUZbnx7B5C3KEqFT4uVrtkDDaassOb5b7hUQZbVGnqP7kjlNkZMRNyuK2IA/4YHXEf3fUWPxb3Vml03P105dlOdYZxWhzDleG9QDOqQ+XhMQGaJeM6aIaPKlLk43juL96z+RVggQnxN0cKk1JSvTaam6DsvQcB+IeN36/PL13oTlWTHIHchPmu2+XUS8rQ1sbiypLh7qVAk+y76+seupnWG8ImhiWyoG3SPSKeL9bzzUmdm9+OcQ2xR+xGfA3p1EbfIDmIjfqi8ml5qJpWYhmwQ9vpI7STuoU2sV2ocrqTffrgO0i40d1WEV9RnYlSbwJgohS94VHRrxfEuL2ZdZy2LfYIeCil2Jm/GwKa1yu/wAyuxCYNLYRS1PhNq2oKiMQez2bMBxLJ1qBR5pS7Z1TUg1UJo8j1FBa4FNKVpirFzYixM8jt7GQnbYkkdVDbRDt/4j62KUf3zEr9tYQJtTVm1fmDOyKpKBUsyEar26C6bWzFtknRnOXsLpjAls+rKjiPlFo2gTYXt4kmF7YC5U6bPdJBqj01pp56UpjkijoYZEXWVuzxo50mOxrtPF3s9N6CzogG5IkouUZpMHOvw1quKb8BLYKk6mx5k9JHmk13exQkaj/WRNtYmDDWaGHwm1SwUtlLgGbCZsUFXIQPEFFKJNROTBobDAQKIjVNSkbqi7yJKpMMiGqWtCAjd0YAPCwAP7nbc+0HIw0sbV7f3pySDc9qBdt0qLZqFMhIYcxZu1YRYuDxJoJ4LxtPPpDTBVFigvQuylJTc9gTLMaeJG+ss69y1pFBqUJwFd9XE51CPYp9deDr0OW6cfvOE1am2HYvKdEkbBUl4WqJUgFZCPt8jMW66YYm/gFhM2M6Z94W+68+D58rF0hZ/+itZ3/49A9YSaorjkDwRizhx0pgshL8Ow0LBOdQ0vYtmIH4vIP8klBWSgRSFMy6niVl2rCthY/GWgoRcRDHLxvXOx1XN66u6K/PL2N8Rk+2f1zjtUcel/HvZN5Bmv3ZuEn+7K3fibDoIMkwubV1lZOYmTCtQ27yMad+X96P7Yy8Xa+2x4YtL+/vIRFpbFXzhycB+cNhIH98EpA/Hgby7ElAnh0G8qcnAfnTYSCtw3kCmM7RGaAKJoUUWiSCOT8W/YSpDEm6A36AWESi9aoHm7SDavmdj4DpXyd6LYi8C4KYzYb4DYfQk2jcblXChRWRnPLgA/OCm33owGD0bo9XymxcUfii5gETHuMWFhvDx/GQ9g+cHVHytY/mLSXWcxFuqSfM6rYfuXng1OTRhiNE+JGmbS52FOPwasFePvqvdgfho6FTrLro5bgKcTXQE1AGnRvanM940EGzaSP5aKM5zBhMcCoBenrJIoXkJxT8hYNVwwwJ05njdAafucl0eh3j14sPDvOvUPJbLlZdhfv3F++rL1JONSWM/rX78NYa3ufzD//88sV826ffNqTp+PbHs88fPG2LHgpin/pkDCBZo4SzKXABZWH23b6jQKPSJhH3bYmdlK8/f722lB2ll6dnPVcaH8/OP19A6yeNkm4hxYJhPrWpMt6TvAi2r2y/Ts6bjTHLUmF6AhOdFCCVfm5TzgsBUpQaQQvIhNInMKFJXoQrEgAff+6R2c+dP2yJ5GeYXF19fN4nlp+/XF3C1s8ovyOMppsywylsR7BdpF71QH+154fnzR8aC2CbsAlj610yW3sEZy/ObMzdu1kpVUanTgU/PXtx1omlJcZXMDEB/vdXn64ve4X5qiXMV0cI8+r6apvUdoFvWwg2B2lmZN3xoEjHaBH8aEH+dPrKLjAFutz0GcUUnUo3jDICsutNkczePFENWohbcx6XlFOVdZjaftDu6zPz03G8wbUrQZZMd3uEaJgmTxk1lKweyWlgxcSS5Aa53i+9g4uNnYs9wiNJHeNOybwsVhll2J4HKIuYAxF22cOhrcdfNiMv4S6U5vxekGbjunpDqqqgb83zwQKNbze8TU0qkdlwm4Rjk9bYX5O2tXwkyapb4J4Y0fUe+OuJYLB45ISRfYxl551Ju/ehrxBgWDo2pHWv6CLBzsxJn2gixAN+8A6lHRMsOf3T3kQpmiIQN5MScx8R3reBAMbuYdVu0LxTC19H+Ku21LicrVuyyn37XosYzvc/DHwgxlsND85+EYn1cFiOhNu2DPOBznBtPg0SdZ5mbcc9E8Kr6+FAPxDbzM50nl9oPRAkWFt4HFG45fdMGocNj3/Q7U6QN3aG2jF2Cn0OZN+s5x50zddOT4aPWd2D9peuUcrNdFXCidj+rqlViNCA0ZnC3Har1eb+EMYWa5fdfAP75ZixKl+hsvxYljdMweT88uv3b/7l6k5RTUiV4Xt6HltXNZbZFUpsPJO6fxK//rto2/hHPM4jOb+vziVblrr/plUTSWAsH4ZJddwDRAz9mMuDfTnsADAMfVvGmdrL/6l3bNOqlhZ9hdf9N8DG05YRn5vXqDpbznzteZKTe/vv563eGz9YrjM7a7zszCDqPvCKkEk3XsCkijy4iLc2vf03R0nAZOTEt+E4pA+e3QwqjM2JH1lh/l3PHrGe/betY2+KwVanfT82pnBSFiedP6qLnu0fLQm1f7ZUaVGcWECpWPGTiELKRknHUfF3ZW5THZLamUqTIBgxiqUvcLnjFdWOv8erHWW1/sva11qYi7WHFhG70Z1u2IEwfaGq8fyHWDxLwrqLmEfh+Z0wdjgeZ8zGQfTOGcqCSJKj9tf1Fhe8Qb1C5PDCptp/uHfNXv3xH/4fRlB/nL4covR+FBNvPfUtF+Csp3WaMecicMcLw3Yrdah5jAwi5QA7Kr+3dtkG2KH3AwPcPgMHAew8CANDDB2KqjgXPBUuztg6G+6t/hMC0W4EIlzJIUx2upSuO59gMKlEKZMRhhlImgb+LNmGuMb7UPQS0+HuKNdPBLIM7GP32f8FAAD//xOaAGg="
+	return "eJzsnV9z2ziSwN/5KVB+WXlW1iSz3qQqVTNViTNzScWxXbZz83B1pYFIyMKaBBgAtK359FcNAiRFASQkkfbc7a1ctROJ6v51o/Gv8Ucn6J6s36EVzgV/WkcIKapS8g4dfXp/Be8cRQglRMaC5opy9g79EiGEkPkUfeVJkZIIIbniQs1jzpb07h1a4lTCu4KkBEvyDt1heIYoRdmdfIf+60jK9GiKjlZK5Uf/HSG0pCRN5Dst/AQxnJEmFLzUOgdBghe5ecfB1WTLiBI0ljPzQVNDUwtlS1696VLToQr+/oMwInCq5YgMg5cQXvBCVSC54DGRklQoCLVdg5Absglaidn41BKnnN21PuiAhr+LIlsQgfjSBdhFMGdFNhDDVSkRMc3So16tBMGJHEh1bb6R22c8TZyacUpxmynHalW5a7b9zYzeCR0p75ASBdkN3Prs88ceYlGw+feCFORAjzmFS8XznLK7A2Vvl4YVjP7FF30BCY8MDoDTNER3wTQpXqRkPgpHQ0EIT0qlgqZoeJBKcg9BIniek2Se8rvhIYxwBMJ7OBaFXM9znqZjhCcIR0Z4D8cS05Qkc0EkTwuQPLxXShWooaKHSWF5fyiGU3CRK5qRmSTxgdLt66wQgjBlBCPKkCQxZ73tdEYyLtazDD/NFmsV3luWvfc75PpSD+pX/ESzIkM44wVTUC4lBCokvtPoWiiaqBVBf/tKsgw/zb9++Bt6wGlBUMzZAxGKJEjxUv2xw0ansX4L2yMY1+ii9XEtlhdq67MuwUHCmwoUVzh1PtFRQoGlsV1LtJeQhGjihfLETxtRYNXuOIcmfP9ABARIyccLlRdK6w0t/pwQMULx41jRBxLtaHiA0XWRlCpKA2b9RDFnjMSKJKNCVVq8XJELLuc8HaEQ0pTHeByTb+ifBAyG9qjSo+0IKItCjlwMGYECkVoRWgqe7cdZ9o2jkpru18QN9PDQhIMfrREOzsgFW6Q0o2rOokDUwB6JVag8JwwtaUokWnKhPWrnJ9W3nWQxz3JBZHgz40HrqQdttYu8rbFba7jmLu1NAtoujN5C6eFovz6zmGeU3VUuJglKsMJ6wECVRDkRZtDjKaM2srvfHpL5slB3fFBm6O/mOvpHRr/GiiCtCOrD3vyWu670kYt3vJrhGZg8Z9XQ41bnE8MVV93KNptXynTTlWKpdguzl4iwCjuQMcNPIxPafgFiKJzR8sXllCzakS+AzU72duPxzSIOpbkFuTuySJnOxvfPzc35Hlzj+mk/JnesH0pk43t3pnF5dmNZYZHMhwOKXDoE+V4QqaQzOLxaekwuA6IedFZKqiedLJLH90RJR0zsC7I9/rU6qkedJBXwmCiBXslpvkNup5Oib0jROaWjTJE7Ihyf7zRXAh2lTSgpBAwh74lgJD1ZYPhExTmSeUpjf161SbwUhIxNDDpK4gCg7YAZiGc7fmpPergskyRS7js+3TGCYEAx8w0LB/DCtRmwGJMaw/SQwtF0vhHgUHTVwM8wBoONHjpi03kusMhFJ2X6fyl2YFDQ0/a+XNDsA/dsgbMj3FJwpgjzdymuAGqjOT72BVJT9z1ZzzsDKsRFgW6Cvy9kveEma3uHm5y47rIcGNaW6/1h0KYdmQtSSDLLY9VJLmMMy6DLlGPfg3btLScidk+gdjDSVndN17awqw1sm7nA8f2/aRQb00O89deLZj98mzfG8UpvWOD3RUe+2THo33kwCWBaHTLaeqjmGXXs9RqLqVRWI23/RxPwz5Qu5hnJ5np9O3LhueqGhy0Kqx5Wua9G9AVWgGe+Nlft+VLb6fBJm8gd7EPwmADPwrksE01SV8vc2yJ3t8Q9zFdl820oAQHB3o1Z1KaTRDxsOMUdNB3qbpSp8JANLsVJ+0+779K0A3KKYp6m5cJutZwoCgZs6P3VZ+e2zAVRoRszPRsDHTW0x3/fGP1eEPT546ZhsB4ESxPGnhrXSQNMTp57sn7kItkN6QJnLTf3qLeQ4znFaNiLajzn7EOFk8S7sroX0PtS4E4QORdqoKK64kLtpHv5PWHDWf9bkaZr9L3AKV1SWNfjGaZMm7lJNUWPKwJ1ClFZ7p17IAl6pGqFPl7c9DA/Enq3Up5c/76es/n9UvhOPjQ8lFFFB0vk/u7gsGtv5Sb/otzA3AMH63TzeIXZ3ZBbA2+h4ZaUxaReDCyVVMTb3cM0fAuhVFgVA1ZL6KyKVq1Ek29XU/Tx8veLKbq4PP8wRV/ff764naKP1+8/X8xms+MAyIPHXvZ1mZOyPHGKMEsQTjLKqFTw5oPTnbP2wKFn2MZrDVvPdDs3AL9tgoMXTfRuapJMwRoBB1GmSBSMQSaai2rbuc/tTVu0d8ax4v2m45cpvpNwcAZx1jBmilJ6T2AjT0xgpQhGmQxDfeDCvpsITFmAMY1ve01acJ4SzPYz6VYUBNGNsqB6Ib+hOYBT2/OchFohRAeMvfoX8CxnvCLxfeRi3KNefiI4VatSpjysAq60KK8DHa1woPcM4wbcFC2IeiSEoVe6PRFUkr8vcZqevPa4r39cNFgN2xgjrZoeDmBzDJeGcGJz6LQrkiCySNU43rrWsi0ZdOcbeGhSsHvGH9kUMVIogdOp2Xc5RTnWu6u4gB0LCQXrcZqu5+X7Ie2ssx8eyLDNPnnLMNPCnp+eXV4gLtD528svAcQNxeNgf6wfseyy25IA6pgnxIu7f0yf4zUR6J8nb7WCPlfDZ59ub6+sPfCdEI/bgWgmRzDho5HeAQ8dGU1TGr4amYJf3s5SrAiL1+OAn5fCLbcgMudMtsdEirutQp5MvSZHb0ONtgbjO8LUbOzZrtbS3WpuAo0083VzWNUQ3VHfyKBDG7Rbsp3QGuCksdNPI02EfFMfLsr/mjxQfBwwBXrUs1Qn3u5FWO5dMhPwCeyRNykdeazJTJVpPmDyjn2YCX/UucZBQa1QNKmntccz9FuDe4rUikpzyIxKHZweFlQdCnhc8bTKZk0R40p/TxaZje9Ks/2KcUOPF2DDNWeEqTlUeacv3MnyHnecWblaCJq8+tmu9U3R658rQ3762Y5MuUD/+LncDvajPdraV4SmYs3/emfA0eSVLrslFVIhyqSCeeAUvdbvlu3zVI/BJUec9RkKTqIxmQ+bKL0ppepIQJPfri8vbn+9+KgJ68L68P7si323KjYuEGbr8ot1lQsuN8qe7RDoB9CDKOshggOIz4vkP/LoyNI5qfaql/Uyo2md2hm7b1cnv3y8/F0Pr+H/T375doWUwEzSgMyiWgmu1AssctlUrQWwUvBdu0Uss8wy5Y8647QliUpzDFK3SYzblnpZfwfyEJSVT/U4RJJyiWvUDb3Q7kuEKwMxJKdKvVNEqFoRnX9CjDxuybLL8dpa7RpBThIqc6ziFez6RL/Vfarpu/SyGxIEhmq6A9uSCu5u8zWLoEHYF1ECGp0BV67qJSt9j82PBglSYaBKp5YkIgyukOiDizm/pwO2yGdanok4vmy6zMRwc9mk1G7/ZRrnHuKU42S+wClmsKV3jtM7LqhaZcPZcM5xgioNqNLQ60qbynOiuLJ0nYPlpmxX3esMoQAz4e+syIq0TAnXwV4b0hjve9EEXIc08nlY2zzUZHAedqO5MBgBwCBshh/uRiC2B+8NJ7I3TGQScagCVR/1+tVPp7tuyxyBt/Zw7dk2au9puCbjgKdLmq9P9G5FpKqblRp35rnXoE2HlSJZrpy92FiuREQqvEipXGXQsxuEsEoFOyCflVWrDGGjSeqPRVcrF9TSNTX47O61PdD+TR+APQ1HSIQfME2h89RDDu2XWRi4b1f3kODnoAOW7Q7ktsx6o17k4x2kPMudid5wHqdYPZsiO0FXVLWnQmMDgkoPnaUy/UTkAnKVT2/ZWMEJYeP03NemZzMa0ILE2GyaliQuBFVriNyYiM4hRvn3gx6/355dlUN3KpviMMpgmF8e9DoxngLZClpcUaSNKrD5KsV+ur3tkQtXSVaCYQyLRU7bontcPF+s53VNncN35fO6HdzX8I4h0VbIncwws62XtsFgBBug7ytMnuEkdanIckpn5nRr9mkG307BjYOdVjjsKIOLQKsBMJaS3jGShDtinHGa3VhcjdM23R6AR4TgQo6AZuLKKJihG15PQ3MuJYX+XlcXibAgPT0bNB8Ei3SNFBEZZeViXrUJOE4pYQo2Kyy5IGalrNS/wtDOEMitO+MQ/n5AcHFoidoW6v1K+bGecvCi97E45XLjhiHvFx6woLyAXcJ1VLehZpHzy+iHyuxHLE3LqYLCVBCbwXmmqWUTtKlcTzQZ1xkos0EGqrRTak+OqSef5Bb5SMvuDT/itXZ7gPPqUp2NVp9qDxrPgYlYIcJiuKyQCJIgzEwMK7GGTIri0ZYchGwQNqf0gc1muXrkFGp3MOixMFy9aoQZBXKK8rSQehGgdpdpHWB1yikUS8ljqi8LgzYYYZRjoWhcpNhGB5rIIl4hLJspLbTCD+AA5naAubTOkB0HlPCQd/X4B44uzf0HxzwxFUTQji09PKsCrD6c3cpRkBTn0Jy1cxUuA9w935D4thdkvWYElLRv0tQJGwDazsBvIgoSE/oQ1FLDIo6IST72JYoNPRWnAy9yMdptKpEL7qAp1LO0r2aPjR263DZW31mcFonpSurChNvenFKVwEwu4UwTXnB9Nexi3eyGJuZu/Bk0nTPTFptHG+1S81UOpHT/aFrP/uHTD+hRUGUtsnuQzdCkXEJHk0fO/qbQArKp0KEk7VUwQDz2iIe9goUgCOd5qrueJU0VmG12Jm0FxPZ/tEv6GdLGVUmH5Y3tEv3t2dXx7ODZvnsxNtCCa0MeNuPfmnk7ZfbPxmHyp1f9jrimKzdH6bzKUYBDYGbvdceOneievenrp7E7I9Ou2+LRw5bXT0992yCbkD+9CORPu0H+40Ug/7Eb5OmLQJ7uBvnPF4H8526QekL2ApharwaVaJILrnjM07Ifc7XBkYt9RXBCxPBjEUF0rxr5POJr0noVBN1+3K8nWFfI+De4sHcocOclzGVpWeeiRyzgaJcr5xi5+E2iMnJRuxzV6yAreLxUZu0Co8Nx44UXa9zEYj3E3QGp+66l/aHOzIFVUl0JUl679IKzurroIOWxg4/2Sii0Y9UluD9f4B94Bhq9abg1evdsQacd/Uu7g9jRiClzhReDTW8uqwLB+/Idg2Bv189w6MhFPchpxygsXDtPJdSK3XvJhjkWNkOXDGY6NZxTCkLfLr7o/z/5BZkTcp4HP198tg+aI/z0z+3fLbL/u7k8+/Lr9TU8babfekjjefr89PKLka3LqTqSx8xJnlPY4I+KHIJVvyORIhLORtttiV7Jt5ffbrVk/T30+uS0Z0mjPErX+kojpZsLvkhJNtXpA/KEs9y5fWXzdXRWCxBkCTdjHqEJ3CUqpDrWU84LjgQvFIG09YpLdYQmNM5yd0YCofM3PT574/1iyyVv0OTm5vy4zy1vrm+umm55gyh7wClNqiEtOkGbI1ifqLc96G87vnjW/OLGgc1tMRtlhE5fneq7MzzC61dCJex6OeHs5PTVqZel5ca3aPLp9vbqx5uvt1e9znzbcubbA5x5c3uzKaoSoQth0wmAuDEj87Zez3LYcgq7yKt9RrN+KnuMcgSy2zpJpleeqEKK83uoj0vKqFx5mtpKmBe6fHwGGTcv90G9wW2Zguw6AR2OCfMUL+YQQ0kzEeo8Adnk0gcVu73nH3R4yLzKnuHXeMwtCTrIjC8eVzQlzWQ0LBsXeYBzEneXPRxtdfylPvLi3oViVhDcxx/gVQ/mGqJsBn3jPB9aEOjbwbYp3PcIORK1wsy/Mlsf+2vK1i0fjlfGqx53WleavQdmeSJyOdQdZz3+rH0J6yj+NZP23odu2q276fYa0rpHsq2Pa6VbZ076XBPgHnvwjgi4UW5SlPeoUSZpQmBFHM6khKxHuMttIMDQMjSBtrGm5poCILvUlkCXs7FKZrtvs9didvDv4A1keGvDQ7k9AwtSHQ7LCIZUVvmBWpE1bHxyCi07prU+7hljZpeHHfuB0vrsDBEBrgDIIn8xV8AHRW7fd/BGLmj7G0/84OrcU6/bij3HTns7kK6znjs7cyMtbn5jcllulIIaVTsnoPh9p1ZDImB0o0hGVbO538Wwxbqc3fwFyksLKSu5paovRamNQpOzq28/fvi9zGGGNODWXX+1mDT1+5GIutC8W1msMbpBj1z8I1bnkTo/c7WpNsn/c+5NEsex/IGmOuUFIiA/ACPjyagYIF+ncaZ6I8HUTKGmNpfmivrIBer/+fvxoqV7GeOggGlknbVl5ucjJhl+0v8+bu29MQfLFRyvNhfcOeVW+8CtIDhl8QpN7MiD8fDWpnf/zUEegBk5NttwtMn7n92MXPR6Thy5sP8/n/2/Mp/9b5vHrpPBOqbNfmySoKMiP+pPera/BAmVoyk6gntSjzQQZBFqQd7obgTpOCH+qcj0VAcnUNIIJgjmxjZtg94iWbgagDZpZ2b2oFbrP3X7WjlzsTa5t+phLxNcnDkO0zWVxMykK1dVD3p54A7PcXh+g5XwnXl6bjU9iMhcbJpjgTOizHK95kIfNm43/aMsTSirP/5u/gGO+uPk9RCp94OMcN5gWLaeutMMAHSt8Xb3i65ez/FIb5iH+CDQD9sh35m7bAN64n5gwM06sBOgtyIMjOiqFAbUXSt09GzWjfKt/hrStK+B12mkvyvZxUhvl+Jb84lczJIXYutWbX+t6a0xVm7f/ciKPKloL+NbV2qWBnSZG7XZpKLxPdymQaI+mzt4bkAK0mI0jLngcuxfchnv50FkbZDDnU0G7+2IezHcrvOK4Z6spYMHTWg+RTR/eDO1TQTckS/MxfgLyrBYH/dQS/oniQKzSj3I23uUCIM7g+TuvnT8ru2+VBdbNJTtTIPvnv++umsYXFjXWXZwzO4GwLfXTnhXc9bD9SsIcxTpFHGWruvBsr4p7o+6WZkZG/5wXp7mr+NNQ+5J24y+ahZgkP0dvYar1x6Hbjj1KaeCjHMLtN56Ac1jWiKBqo3RIPhyvc8d1qbLp8kI1PW9eWbRviItKxucDgtHHC9lutG+H0IKS5+zH7yQfPEvstVowF/5wfxQf5+VB8El/AaKqNsFExv6HnzIb8xjpqBDgIzsXJDvc4EVmUX/MwDhasfj"
 }
@@ -27,6 +27,7 @@ import (
 	"net"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/gocarina/gocsv"
 	"github.com/mitchellh/mapstructure"
@@ -39,6 +40,22 @@ import (
 // HostParser is used for parsing the configured HAProxy hosts.
 var HostParser = parse.URLHostParserBuilder{DefaultScheme: "tcp"}.Build()
 
+// Config is the configuration of the HAProxy module.
+type Config struct {
+	// MasterSocket is set when the hosts are the master CLI of HAProxy
+	// running in master-worker mode.
+	MasterSocket bool `config:"master_socket"`
+	// Worker is the relative process number of the worker the commands are
+	// sent to through the master CLI.
+	Worker int `config:"worker" validate:"min=1"`
+}
+
+func defaultConfig() Config {
+	return Config{
+		Worker: 1,
+	}
+}
+
 // Stat is an instance of the HAProxy stat information
 type Stat struct {
 	PxName           string `csv:"# pxname"`
@@ -204,6 +221,7 @@ type Info struct {
 type clientProto interface {
 	Stat() (*bytes.Buffer, error)
 	Info() (*bytes.Buffer, error)
+	run(cmd string) (*bytes.Buffer, error)
 }
 
 // Client is struct that wraps the clientProto interface
@@ -218,12 +236,26 @@ func NewHaproxyClient(address string, base mb.BaseMetricSet) (*Client, error) {
 		return nil, fmt.Errorf("invalid url: %w", err)
 	}
 
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	var prefix string
+	if config.MasterSocket {
+		prefix = fmt.Sprintf("@%d ", config.Worker)
+	}
+	timeout := base.Module().Config().Timeout
+
 	switch u.Scheme {
 	case "tcp":
-		return &Client{&unixProto{Network: u.Scheme, Address: u.Host}}, nil
+		return &Client{&unixProto{Network: u.Scheme, Address: u.Host, Prefix: prefix, Timeout: timeout}}, nil
 	case "unix":
-		return &Client{&unixProto{Network: u.Scheme, Address: u.Path}}, nil
+		return &Client{&unixProto{Network: u.Scheme, Address: u.Path, Prefix: prefix, Timeout: timeout}}, nil
 	case "http", "https":
+		if config.MasterSocket {
+			return nil, errors.New("master_socket is not supported with the stats page")
+		}
 		http, err := helper.NewHTTP(base)
 		if err != nil {
 			return nil, err
@@ -293,6 +325,10 @@ func (c *Client) GetInfo() (*Info, error) {
 type unixProto struct {
 	Network string
 	Address string
+	// Prefix is added to the commands, to send them to a worker through the
+	// master CLI.
+	Prefix  string
+	Timeout time.Duration
 }
 
 // Run sends a designated command to the haproxy stats socket
@@ -300,13 +336,19 @@ func (p *unixProto) run(cmd string) (*bytes.Buffer, error) {
 	var conn net.Conn
 	response := bytes.NewBuffer(nil)
 
-	conn, err := net.Dial(p.Network, p.Address)
+	conn, err := net.DialTimeout(p.Network, p.Address, p.Timeout)
 	if err != nil {
 		return response, fmt.Errorf("error connecting to %s: %w", p.Address, err)
 	}
 	defer conn.Close()
 
-	_, err = conn.Write([]byte(cmd + "\n"))
+	if p.Timeout > 0 {
+		if err := conn.SetDeadline(time.Now().Add(p.Timeout)); err != nil {
+			return response, fmt.Errorf("error setting deadline: %w", err)
+		}
+	}
+
+	_, err = conn.Write([]byte(p.Prefix + cmd + "\n"))
 	if err != nil {
 		return response, fmt.Errorf("error writing to connection: %w", err)
 	}
@@ -354,3 +396,7 @@ func (p *httpProto) Stat() (*bytes.Buffer, error) {
 func (p *httpProto) Info() (*bytes.Buffer, error) {
 	return nil, errors.New("not supported")
 }
+
+func (p *httpProto) run(cmd string) (*bytes.Buffer, error) {
+	return nil, errors.New("the runtime API is not available with the stats page")
+}
@@ -0,0 +1,76 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package mtest contains helpers to test the HAProxy module.
+package mtest
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runtimeAPIResponses are the files with the responses of the runtime API,
+// by command.
+var runtimeAPIResponses = map[string]string{
+	"show stat":                   "stat.csv",
+	"show servers state":          "servers_state",
+	"show table":                  "tables",
+	"show table http-webservices": "table_entries",
+}
+
+// RuntimeAPIServer starts a runtime API on a Unix socket, that responds with
+// the test files found in dir, and returns its address.
+// Commands prefixed with a worker, as sent to the master CLI, are accepted.
+func RuntimeAPIServer(t testing.TB, dir string) string {
+	address := filepath.Join(t.TempDir(), "haproxy.sock")
+	listener, err := net.Listen("unix", address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			cmd, _ := bufio.NewReader(conn).ReadString('\n')
+			cmd = strings.TrimSpace(cmd)
+			if strings.HasPrefix(cmd, "@") {
+				_, cmd, _ = strings.Cut(cmd, " ")
+			}
+			response := []byte("Unknown command. Please enter one of the following commands only :\n")
+			if strings.HasPrefix(cmd, "show table ") {
+				response = []byte("No such table: " + strings.TrimPrefix(cmd, "show table ") + "\n")
+			}
+			if file, found := runtimeAPIResponses[cmd]; found {
+				response, err = os.ReadFile(filepath.Join(dir, file))
+				if err != nil {
+					t.Error(err)
+				}
+			}
+			conn.Write(response)
+			conn.Close()
+		}
+	}()
+	return address
+}
@@ -0,0 +1,214 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package haproxy
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ServerState is a server in the response of the 'show servers state'
+// command, indexed by the names of the columns. Empty values are not set.
+type ServerState map[string]interface{}
+
+// Table is a stick table in the response of the 'show table' command.
+type Table struct {
+	Name string
+	Type string
+	Size int64
+	Used int64
+}
+
+// TableEntry is an entry of a stick table in the response of the
+// 'show table <name>' command.
+type TableEntry struct {
+	Key string
+	// Expire is the time left before the entry expires, in milliseconds.
+	Expire     int64
+	ServerID   string
+	ServerName string
+	// Data are the numeric data stored in the entry, without the periods of
+	// the rates.
+	Data map[string]int64
+}
+
+// GetServersState returns the result from the 'show servers state' command
+func (c *Client) GetServersState() ([]ServerState, error) {
+	res, err := c.proto.run("show servers state")
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []string
+	var servers []ServerState
+	scanner := bufio.NewScanner(res)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#"):
+			columns = strings.Fields(strings.TrimPrefix(line, "#"))
+			continue
+		case columns == nil:
+			// Version of the format, before the columns.
+			continue
+		}
+
+		values := strings.Fields(line)
+		server := ServerState{}
+		for i, value := range values {
+			if i >= len(columns) {
+				break
+			}
+			if value != "-" {
+				server[columns[i]] = value
+			}
+		}
+		servers = append(servers, server)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading servers state: %w", err)
+	}
+	return servers, nil
+}
+
+// GetTables returns the stick tables from the 'show table' command
+func (c *Client) GetTables() ([]Table, error) {
+	res, err := c.proto.run("show table")
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []Table
+	scanner := bufio.NewScanner(res)
+	for scanner.Scan() {
+		if table, ok := parseTableHeader(scanner.Text()); ok {
+			tables = append(tables, table)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading tables: %w", err)
+	}
+	return tables, nil
+}
+
+// GetTableEntries returns the entries of a stick table, from the
+// 'show table <name>' command
+func (c *Client) GetTableEntries(name string) ([]TableEntry, error) {
+	res, err := c.proto.run("show table " + name)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(res.String(), "No such table") {
+		return nil, fmt.Errorf("unknown table: %s", name)
+	}
+
+	var entries []TableEntry
+	scanner := bufio.NewScanner(res)
+	for scanner.Scan() {
+		if entry, ok := parseTableEntry(scanner.Text()); ok {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading entries of table %s: %w", name, err)
+	}
+	return entries, nil
+}
+
+// parseTableHeader parses lines like:
+// # table: http-in, type: ip, size:102400, used:3
+func parseTableHeader(line string) (Table, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "# table:") {
+		return Table{}, false
+	}
+
+	var table Table
+	for _, field := range strings.Split(strings.TrimPrefix(line, "#"), ",") {
+		key, value, found := strings.Cut(field, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "table":
+			table.Name = value
+		case "type":
+			table.Type = value
+		case "size":
+			table.Size, _ = strconv.ParseInt(value, 10, 64)
+		case "used":
+			table.Used, _ = strconv.ParseInt(value, 10, 64)
+		}
+	}
+	return table, table.Name != ""
+}
+
+// parseTableEntry parses lines like:
+// 0x55d8c3e3c8a8: key=127.0.0.1 use=0 exp=29832 server_id=1 conn_cnt=3 http_req_rate(10000)=2
+func parseTableEntry(line string) (TableEntry, bool) {
+	_, fields, found := strings.Cut(strings.TrimSpace(line), ": ")
+	if !found || strings.HasPrefix(line, "#") {
+		return TableEntry{}, false
+	}
+
+	entry := TableEntry{Data: map[string]int64{}}
+	for _, field := range strings.Fields(fields) {
+		name, value, found := strings.Cut(field, "=")
+		if !found {
+			continue
+		}
+		switch name {
+		case "key":
+			entry.Key = value
+		case "exp":
+			entry.Expire, _ = strconv.ParseInt(value, 10, 64)
+		case "server_id":
+			entry.ServerID = value
+		case "server_key", "server_name":
+			entry.ServerName = value
+		case "use", "shard":
+		default:
+			if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+				entry.Data[dataName(name)] = v
+			}
+		}
+	}
+	return entry, entry.Key != ""
+}
+
+// dataName returns the name of a stored data without the period of the
+// rates. Indexes of arrays are kept, gpc_rate(1,10000) is named gpc_rate_1.
+func dataName(name string) string {
+	base, args, found := strings.Cut(name, "(")
+	if !found {
+		return name
+	}
+	params := strings.Split(strings.TrimSuffix(args, ")"), ",")
+	if strings.HasSuffix(base, "_rate") {
+		params = params[:len(params)-1]
+	}
+	for _, param := range params {
+		base += "_" + param
+	}
+	return base
+}
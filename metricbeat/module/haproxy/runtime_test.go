@@ -0,0 +1,97 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package haproxy
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/module/haproxy/mtest"
+)
+
+func TestGetServersState(t *testing.T) {
+	client := &Client{&unixProto{Network: "unix", Address: mtest.RuntimeAPIServer(t, filepath.Join("_meta", "test"))}}
+
+	servers, err := client.GetServersState()
+	require.NoError(t, err)
+	require.Len(t, servers, 3)
+
+	assert.Equal(t, "http-webservices", servers[0]["be_name"])
+	assert.Equal(t, "web1", servers[0]["srv_name"])
+	assert.Equal(t, "web1.example.com", servers[0]["srv_fqdn"])
+	assert.Equal(t, "8081", servers[0]["srv_check_port"])
+	assert.NotContains(t, servers[0], "srvrecord")
+	assert.NotContains(t, servers[1], "srv_fqdn")
+}
+
+func TestGetTables(t *testing.T) {
+	client := &Client{&unixProto{Network: "unix", Address: mtest.RuntimeAPIServer(t, filepath.Join("_meta", "test"))}}
+
+	tables, err := client.GetTables()
+	require.NoError(t, err)
+	assert.Equal(t, []Table{
+		{Name: "http-webservices", Type: "ip", Size: 102400, Used: 3},
+		{Name: "peers/sessions", Type: "string", Size: 1048576, Used: 0},
+	}, tables)
+
+	entries, err := client.GetTableEntries("http-webservices")
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, TableEntry{
+		Key:        "10.0.0.101",
+		Expire:     29832,
+		ServerID:   "1",
+		ServerName: "web1",
+		Data: map[string]int64{
+			"conn_cnt":      12,
+			"conn_cur":      1,
+			"http_req_rate": 4,
+			"gpc_0":         2,
+			"gpc_rate_0":    1,
+		},
+	}, entries[0])
+	assert.Empty(t, entries[2].ServerName)
+}
+
+func TestGetTableEntriesUnknownTable(t *testing.T) {
+	client := &Client{&unixProto{Network: "unix", Address: mtest.RuntimeAPIServer(t, filepath.Join("_meta", "test"))}}
+
+	_, err := client.GetTableEntries("unknown")
+	assert.Error(t, err)
+}
+
+func TestMasterSocket(t *testing.T) {
+	client := &Client{&unixProto{Network: "unix", Address: mtest.RuntimeAPIServer(t, filepath.Join("_meta", "test")), Prefix: "@1 ", Timeout: time.Second}}
+
+	tables, err := client.GetTables()
+	require.NoError(t, err)
+	assert.Len(t, tables, 2)
+}
+
+func TestDataName(t *testing.T) {
+	assert.Equal(t, "conn_cnt", dataName("conn_cnt"))
+	assert.Equal(t, "http_req_rate", dataName("http_req_rate(10000)"))
+	assert.Equal(t, "gpc_1", dataName("gpc(1)"))
+	assert.Equal(t, "gpc_rate_1", dataName("gpc_rate(1,10000)"))
+}
@@ -0,0 +1,199 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"strconv"
+	"strings"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstrstr"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/haproxy"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var (
+	schema = s.Schema{
+		"id":   c.Int("srv_id"),
+		"name": c.Str("srv_name"),
+		"backend": s.Object{
+			"id":   c.Int("be_id"),
+			"name": c.Str("be_name"),
+		},
+		"address": c.Str("srv_addr", s.Optional),
+		"port":    c.Int("srv_port", s.Optional),
+		"fqdn":    c.Str("srv_fqdn", s.Optional),
+		"weight": s.Object{
+			"current": c.Int("srv_uweight", s.Optional),
+			"initial": c.Int("srv_iweight", s.Optional),
+		},
+		"last_change": s.Object{
+			"sec": c.Int("srv_time_since_last_change", s.Optional),
+		},
+		"check": s.Object{
+			"health":  c.Int("srv_check_health", s.Optional),
+			"address": c.Str("srv_check_addr", s.Optional),
+			"port":    c.Int("srv_check_port", s.Optional),
+		},
+		"agent": s.Object{
+			"address": c.Str("srv_agent_addr", s.Optional),
+			"port":    c.Int("srv_agent_port", s.Optional),
+		},
+	}
+
+	// Operational states of the servers (SRV_ST_*).
+	operationalStates = map[string]string{
+		"0": "stopped",
+		"1": "starting",
+		"2": "running",
+		"3": "stopping",
+	}
+
+	// Results of the health checks (CHK_RES_*).
+	checkResults = map[string]string{
+		"0": "unknown",
+		"1": "neutral",
+		"2": "failed",
+		"3": "passed",
+		"4": "conditionally_passed",
+	}
+
+	// Administrative state flags of the servers (SRV_ADMF_*).
+	adminFlags = []struct {
+		flag int64
+		name string
+	}{
+		{0x01, "forced_maintenance"},
+		{0x02, "inherited_maintenance"},
+		{0x04, "configured_maintenance"},
+		{0x08, "forced_drain"},
+		{0x10, "inherited_drain"},
+		{0x20, "resolution_maintenance"},
+		{0x40, "hostname_maintenance"},
+	}
+
+	// Statuses of the health checks that received a response from the
+	// server at layer 7.
+	layer7Statuses = map[string]bool{
+		"L7OK":  true,
+		"L7OKC": true,
+		"L7RSP": true,
+		"L7STS": true,
+	}
+)
+
+const (
+	maintenanceFlags = 0x01 | 0x02 | 0x04 | 0x20 | 0x40
+	drainFlags       = 0x08 | 0x10
+)
+
+func eventsMapping(servers []haproxy.ServerState, stats []*haproxy.Stat) []mb.Event {
+	statsByServer := map[string]*haproxy.Stat{}
+	for _, stat := range stats {
+		statsByServer[stat.PxName+"/"+stat.SvName] = stat
+	}
+
+	events := make([]mb.Event, 0, len(servers))
+	for _, server := range servers {
+		// Ports set to 0 are not configured.
+		for _, key := range []string{"srv_port", "srv_check_port", "srv_agent_port"} {
+			if server[key] == "0" {
+				delete(server, key)
+			}
+		}
+
+		fields, _ := schema.Apply(server)
+		if agent, ok := fields["agent"].(mapstr.M); ok && len(agent) == 0 {
+			delete(fields, "agent")
+		}
+		fields["state"] = stateMapping(server)
+		if result, found := checkResults[stringValue(server, "srv_check_result")]; found {
+			_, _ = fields.Put("check.result", result)
+		}
+
+		stat, found := statsByServer[stringValue(server, "be_name")+"/"+stringValue(server, "srv_name")]
+		if found {
+			statMapping(fields, stat)
+		}
+		events = append(events, mb.Event{MetricSetFields: fields})
+	}
+	return events
+}
+
+func stateMapping(server haproxy.ServerState) mapstr.M {
+	state := mapstr.M{}
+	if operational, found := operationalStates[stringValue(server, "srv_op_state")]; found {
+		state["operational"] = operational
+	}
+
+	admin, err := strconv.ParseInt(stringValue(server, "srv_admin_state"), 10, 64)
+	if err != nil {
+		return state
+	}
+	flags := []string{}
+	for _, f := range adminFlags {
+		if admin&f.flag != 0 {
+			flags = append(flags, f.name)
+		}
+	}
+	if len(flags) > 0 {
+		state["admin"] = flags
+	}
+	state["maintenance"] = admin&maintenanceFlags != 0
+	state["drain"] = admin&drainFlags != 0
+	return state
+}
+
+// statMapping adds the results of the last health check of the server, from
+// its stats.
+func statMapping(fields mapstr.M, stat *haproxy.Stat) {
+	if stat.Status != "" {
+		fields["status"] = stat.Status
+	}
+
+	// Checks in progress are prefixed with an asterisk.
+	status := strings.TrimSpace(strings.TrimPrefix(stat.CheckStatus, "*"))
+	if status == "" {
+		return
+	}
+	_, _ = fields.Put("check.status", status)
+	if stat.CheckDescription != "" {
+		_, _ = fields.Put("check.description", stat.CheckDescription)
+	}
+
+	code, codeErr := strconv.ParseInt(stat.CheckCode, 10, 64)
+	if codeErr == nil {
+		_, _ = fields.Put("check.code", code)
+	}
+	duration, durationErr := strconv.ParseInt(stat.CheckDuration, 10, 64)
+	if durationErr == nil {
+		_, _ = fields.Put("check.duration.ms", duration)
+	}
+
+	// The duration of the checks that got a response at layer 7 is the
+	// latency of the response of the server.
+	if layer7Statuses[status] && durationErr == nil {
+		_, _ = fields.Put("check.layer7.latency.ms", duration)
+	}
+}
+
+func stringValue(server haproxy.ServerState, key string) string {
+	value, _ := server[key].(string)
+	return value
+}
@@ -0,0 +1,67 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package server
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/haproxy"
+)
+
+// init registers the haproxy server MetricSet.
+func init() {
+	mb.Registry.MustAddMetricSet("haproxy", "server", New,
+		mb.WithHostParser(haproxy.HostParser),
+	)
+}
+
+// MetricSet for the state and the health checks of the haproxy servers.
+type MetricSet struct {
+	mb.BaseMetricSet
+}
+
+// New creates a new haproxy server MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	return &MetricSet{BaseMetricSet: base}, nil
+}
+
+// Fetch reports an event for every server of the backends, combining the
+// state of the servers with the results of their health checks.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	hapc, err := haproxy.NewHaproxyClient(m.HostData().URI, m.BaseMetricSet)
+	if err != nil {
+		return fmt.Errorf("failed creating haproxy client: %w", err)
+	}
+
+	servers, err := hapc.GetServersState()
+	if err != nil {
+		return fmt.Errorf("failed fetching haproxy servers state: %w", err)
+	}
+	stats, err := hapc.GetStat()
+	if err != nil {
+		return fmt.Errorf("failed fetching haproxy stat: %w", err)
+	}
+
+	for _, event := range eventsMapping(servers, stats) {
+		if !reporter.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
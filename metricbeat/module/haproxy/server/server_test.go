@@ -0,0 +1,135 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package server
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/haproxy/mtest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetch(t *testing.T) {
+	address := mtest.RuntimeAPIServer(t, filepath.Join("..", "_meta", "test"))
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig("unix://"+address))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, mapstr.M{
+		"id":   int64(1),
+		"name": "web1",
+		"backend": mapstr.M{
+			"id":   int64(3),
+			"name": "http-webservices",
+		},
+		"address": "10.0.0.11",
+		"port":    int64(8080),
+		"fqdn":    "web1.example.com",
+		"status":  "UP",
+		"weight": mapstr.M{
+			"current": int64(1),
+			"initial": int64(1),
+		},
+		"last_change": mapstr.M{
+			"sec": int64(3621),
+		},
+		"state": mapstr.M{
+			"operational": "running",
+			"maintenance": false,
+			"drain":       false,
+		},
+		"check": mapstr.M{
+			"status":      "L7OK",
+			"result":      "passed",
+			"description": "Layer7 check passed",
+			"code":        int64(200),
+			"health":      int64(4),
+			"port":        int64(8081),
+			"duration": mapstr.M{
+				"ms": int64(3),
+			},
+			"layer7": mapstr.M{
+				"latency": mapstr.M{
+					"ms": int64(3),
+				},
+			},
+		},
+	}, events[0].MetricSetFields)
+
+	down := events[1].MetricSetFields
+	assertValue(t, down, "status", "DOWN")
+	assertValue(t, down, "state.operational", "stopped")
+	assertValue(t, down, "check.status", "L4CON")
+	assertValue(t, down, "check.result", "failed")
+	assertValue(t, down, "check.duration.ms", int64(0))
+	_, err := down.GetValue("check.layer7")
+	assert.Error(t, err)
+	_, err = down.GetValue("check.code")
+	assert.Error(t, err)
+
+	maintenance := events[2].MetricSetFields
+	assertValue(t, maintenance, "status", "MAINT")
+	assertValue(t, maintenance, "state.admin", []string{"forced_maintenance", "forced_drain"})
+	assertValue(t, maintenance, "state.maintenance", true)
+	assertValue(t, maintenance, "state.drain", true)
+	assertValue(t, maintenance, "check.status", "L7OK")
+	assertValue(t, maintenance, "check.layer7.latency.ms", int64(5))
+}
+
+func TestFetchMasterSocket(t *testing.T) {
+	address := mtest.RuntimeAPIServer(t, filepath.Join("..", "_meta", "test"))
+
+	config := getConfig("unix://" + address)
+	config["master_socket"] = true
+	config["worker"] = 2
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	assert.Len(t, events, 3)
+}
+
+func TestFetchStatsPage(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig("http://localhost:14568/stats"))
+	_, errs := mbtest.ReportingFetchV2Error(f)
+	assert.NotEmpty(t, errs)
+}
+
+func assertValue(t *testing.T, fields mapstr.M, key string, expected interface{}) {
+	t.Helper()
+	value, err := fields.GetValue(key)
+	if assert.NoError(t, err, key) {
+		assert.Equal(t, expected, value, key)
+	}
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "haproxy",
+		"metricsets": []string{"server"},
+		"hosts":      []string{host},
+	}
+}
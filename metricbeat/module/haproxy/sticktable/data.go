@@ -0,0 +1,69 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sticktable
+
+import (
+	"strconv"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/haproxy"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func tableEvent(table haproxy.Table) mb.Event {
+	fields := mapstr.M{
+		"name": table.Name,
+		"type": table.Type,
+		"size": table.Size,
+		"used": table.Used,
+	}
+	if table.Size > 0 {
+		_, _ = fields.Put("usage.pct", float64(table.Used)/float64(table.Size))
+	}
+	return mb.Event{MetricSetFields: fields}
+}
+
+func entryEvent(table haproxy.Table, entry haproxy.TableEntry) mb.Event {
+	fields := mapstr.M{
+		"key": entry.Key,
+		"expire": mapstr.M{
+			"ms": entry.Expire,
+		},
+	}
+	if id, err := strconv.ParseInt(entry.ServerID, 10, 64); err == nil {
+		_, _ = fields.Put("server.id", id)
+	}
+	if entry.ServerName != "" {
+		_, _ = fields.Put("server.name", entry.ServerName)
+	}
+	if len(entry.Data) > 0 {
+		data := mapstr.M{}
+		for name, value := range entry.Data {
+			data[name] = value
+		}
+		fields["data"] = data
+	}
+
+	return mb.Event{
+		MetricSetFields: mapstr.M{
+			"name":  table.Name,
+			"type":  table.Type,
+			"entry": fields,
+		},
+	}
+}
@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sticktable
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/haproxy"
+)
+
+// init registers the haproxy sticktable MetricSet.
+func init() {
+	mb.Registry.MustAddMetricSet("haproxy", "sticktable", New,
+		mb.WithHostParser(haproxy.HostParser),
+	)
+}
+
+type config struct {
+	Sticktable struct {
+		// Entries enables the events of the entries of the stick tables.
+		Entries bool `config:"entries"`
+	} `config:"sticktable"`
+}
+
+// MetricSet for the haproxy stick tables.
+type MetricSet struct {
+	mb.BaseMetricSet
+	entries bool
+}
+
+// New creates a new haproxy sticktable MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	var config config
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		entries:       config.Sticktable.Entries,
+	}, nil
+}
+
+// Fetch reports an event for every stick table, and for every entry of the
+// tables when enabled.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	hapc, err := haproxy.NewHaproxyClient(m.HostData().URI, m.BaseMetricSet)
+	if err != nil {
+		return fmt.Errorf("failed creating haproxy client: %w", err)
+	}
+
+	tables, err := hapc.GetTables()
+	if err != nil {
+		return fmt.Errorf("failed fetching haproxy stick tables: %w", err)
+	}
+
+	for _, table := range tables {
+		if !reporter.Event(tableEvent(table)) {
+			return nil
+		}
+		if !m.entries || table.Used == 0 {
+			continue
+		}
+
+		entries, err := hapc.GetTableEntries(table.Name)
+		if err != nil {
+			reporter.Error(fmt.Errorf("failed fetching entries of haproxy stick table %s: %w", table.Name, err))
+			continue
+		}
+		for _, entry := range entries {
+			if !reporter.Event(entryEvent(table, entry)) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package sticktable
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/haproxy/mtest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetch(t *testing.T) {
+	address := mtest.RuntimeAPIServer(t, filepath.Join("..", "_meta", "test"))
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig("unix://"+address))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, mapstr.M{
+		"name": "http-webservices",
+		"type": "ip",
+		"size": int64(102400),
+		"used": int64(3),
+		"usage": mapstr.M{
+			"pct": 3.0 / 102400,
+		},
+	}, events[0].MetricSetFields)
+	assert.Equal(t, "peers/sessions", events[1].MetricSetFields["name"])
+}
+
+func TestFetchEntries(t *testing.T) {
+	address := mtest.RuntimeAPIServer(t, filepath.Join("..", "_meta", "test"))
+
+	config := getConfig("unix://" + address)
+	config["sticktable.entries"] = true
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 5)
+
+	assert.Equal(t, mapstr.M{
+		"name": "http-webservices",
+		"type": "ip",
+		"entry": mapstr.M{
+			"key": "10.0.0.101",
+			"expire": mapstr.M{
+				"ms": int64(29832),
+			},
+			"server": mapstr.M{
+				"id":   int64(1),
+				"name": "web1",
+			},
+			"data": mapstr.M{
+				"conn_cnt":      int64(12),
+				"conn_cur":      int64(1),
+				"http_req_rate": int64(4),
+				"gpc_0":         int64(2),
+				"gpc_rate_0":    int64(1),
+			},
+		},
+	}, events[1].MetricSetFields)
+
+	entry := events[3].MetricSetFields["entry"].(mapstr.M)
+	assert.Equal(t, "10.0.0.103", entry["key"])
+	assert.NotContains(t, entry, "server")
+
+	// The empty table has no entries.
+	assert.Equal(t, "peers/sessions", events[4].MetricSetFields["name"])
+	assert.NotContains(t, events[4].MetricSetFields, "entry")
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "haproxy",
+		"metricsets": []string{"sticktable"},
+		"hosts":      []string{host},
+	}
+}
@@ -290,6 +290,30 @@ func (b *Broker) FetchPartitionOffsetFromTheLeader(topic string, partitionID int
 	return offset, nil
 }
 
+// FetchMessageTimestamp fetches the timestamp of the message at the given
+// offset of a partition from its leader. The timestamp is zero if the message
+// format of the partition doesn't have timestamps.
+func (b *Broker) FetchMessageTimestamp(topic string, partitionID int32, offset int64) (time.Time, error) {
+	consumer, err := sarama.NewConsumerFromClient(b.client)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer consumer.Close()
+
+	partitionConsumer, err := consumer.ConsumePartition(topic, partitionID, offset)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer partitionConsumer.Close()
+
+	select {
+	case msg := <-partitionConsumer.Messages():
+		return msg.Timestamp, nil
+	case <-time.After(b.cfg.Net.ReadTimeout):
+		return time.Time{}, fmt.Errorf("timeout fetching message at offset %d", offset)
+	}
+}
+
 // ID returns the broker ID or -1 if the broker id is unknown.
 func (b *Broker) ID() int32 {
 	if b.id == noID {
@@ -0,0 +1,123 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package consumergroup_lag
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/kafka"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// init registers the MetricSet with the central registry.
+func init() {
+	mb.Registry.MustAddMetricSet("kafka", "consumergroup_lag", New)
+}
+
+// MetricSet type defines all fields of the MetricSet
+type MetricSet struct {
+	*kafka.MetricSet
+
+	groups  []string
+	topics  []string
+	timeLag bool
+}
+
+var debugf = logp.MakeDebug("kafka")
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	// Message timestamps are available since Kafka 0.10.0.
+	opts := kafka.MetricSetOptions{
+		Version: "0.10.0.0",
+	}
+
+	ms, err := kafka.NewMetricSet(base, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	config := struct {
+		Groups  []string `config:"groups"`
+		Topics  []string `config:"topics"`
+		TimeLag struct {
+			Enabled bool `config:"enabled"`
+		} `config:"time_lag"`
+	}{}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		MetricSet: ms,
+		groups:    config.Groups,
+		topics:    config.Topics,
+		timeLag:   config.TimeLag.Enabled,
+	}, nil
+}
+
+// Fetch consumer group lag metrics from kafka
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	broker, err := m.Connect()
+	if err != nil {
+		return fmt.Errorf("error in connect: %w", err)
+	}
+	defer broker.Close()
+
+	brokerInfo := mapstr.M{
+		"id":      broker.ID(),
+		"address": broker.AdvertisedAddr(),
+	}
+
+	emitEvent := func(event mapstr.M) {
+		moduleFields := mapstr.M{
+			"broker": brokerInfo,
+		}
+
+		// Group events don't have a partition.
+		if topic, ok := event["topic"]; ok {
+			moduleFields["topic"] = mapstr.M{
+				"name": topic,
+			}
+			moduleFields["partition"] = mapstr.M{
+				"id":       event["partition"],
+				"topic_id": fmt.Sprintf("%d-%s", event["partition"], topic),
+			}
+			delete(event, "topic")
+			delete(event, "partition")
+		}
+
+		r.Event(mb.Event{
+			ModuleFields:    moduleFields,
+			MetricSetFields: event,
+		})
+	}
+	query := lagQuery{
+		groups:  m.groups,
+		topics:  m.topics,
+		timeLag: m.timeLag,
+	}
+	err = query.fetch(emitEvent, broker)
+	if err != nil {
+		return fmt.Errorf("error in fetch: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,271 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package consumergroup_lag
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type client interface {
+	ListGroups() ([]string, error)
+	GetTopicsMetadata(topics ...string) ([]*sarama.TopicMetadata, error)
+	FetchGroupOffsets(group string, partitions map[string][]int32) (*sarama.OffsetFetchResponse, error)
+	FetchPartitionOffsetFromTheLeader(topic string, partitionID int32) (int64, error)
+	FetchMessageTimestamp(topic string, partitionID int32, offset int64) (time.Time, error)
+}
+
+// lagQuery computes the lag of the consumer groups managed by a broker.
+type lagQuery struct {
+	groups  []string // Groups to query, all the groups if empty.
+	topics  []string // Topics to query, all the topics if empty.
+	timeLag bool     // Compute the lag in time of the partitions.
+
+	now func() time.Time
+}
+
+// groupLag is the lag of a consumer group, aggregated from the lag of its
+// partitions.
+type groupLag struct {
+	topics     map[string]struct{}
+	partitions int
+	total      int64
+	max        int64
+	maxTime    int64
+	hasTime    bool
+}
+
+// fetch emits an event with the lag of each partition with an offset
+// committed by a group, and an event with the lag of each group. The
+// committed offsets of all the partitions are queried, so groups without
+// active members are also reported.
+func (q *lagQuery) fetch(emit func(mapstr.M), b client) error {
+	groups, err := listGroups(b, q.groups)
+	if err != nil {
+		logp.Err("failed to list known kafka groups: %v", err)
+		return err
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+	debugf("known consumer groups: %v", groups)
+
+	partitions, err := listPartitions(b, q.topics)
+	if err != nil {
+		logp.Err("failed to fetch kafka topics metadata: %v", err)
+		return err
+	}
+	if len(partitions) == 0 {
+		return nil
+	}
+
+	now := time.Now
+	if q.now != nil {
+		now = q.now
+	}
+
+	// Log end offsets are shared by all the groups.
+	logEndOffsets := map[string]map[int32]int64{}
+	logEndOffset := func(topic string, partition int32) (int64, error) {
+		if offset, found := logEndOffsets[topic][partition]; found {
+			return offset, nil
+		}
+		offset, err := b.FetchPartitionOffsetFromTheLeader(topic, partition)
+		if err != nil {
+			return -1, err
+		}
+		if logEndOffsets[topic] == nil {
+			logEndOffsets[topic] = map[int32]int64{}
+		}
+		logEndOffsets[topic][partition] = offset
+		return offset, nil
+	}
+
+	var fetchErr error
+	for _, group := range groups {
+		resp, err := b.FetchGroupOffsets(group, partitions)
+		if err != nil {
+			logp.Err("failed to fetch '%v' group offset: %v", group, err)
+			if fetchErr == nil {
+				fetchErr = err
+			}
+			continue
+		}
+
+		lag := groupLag{topics: map[string]struct{}{}}
+		for _, topic := range sortedKeys(resp.Blocks) {
+			blocks := resp.Blocks[topic]
+			ids := make([]int32, 0, len(blocks))
+			for id := range blocks {
+				ids = append(ids, id)
+			}
+			sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+			for _, partition := range ids {
+				block := blocks[partition]
+				// Partitions without committed offsets have an offset of -1.
+				if block.Err != sarama.ErrNoError || block.Offset < 0 {
+					continue
+				}
+
+				endOffset, err := logEndOffset(topic, partition)
+				if err != nil {
+					logp.Err("failed to fetch offset for (topic, partition): ('%v', %v)", topic, partition)
+					continue
+				}
+				messages := endOffset - block.Offset
+				if messages < 0 {
+					messages = 0
+				}
+				partitionLag := mapstr.M{
+					"messages": messages,
+				}
+
+				if q.timeLag {
+					if ms, ok := q.timeLagMillis(b, topic, partition, block.Offset, messages, now()); ok {
+						partitionLag["time"] = mapstr.M{"ms": ms}
+						if !lag.hasTime || ms > lag.maxTime {
+							lag.maxTime = ms
+						}
+						lag.hasTime = true
+					}
+				}
+
+				emit(mapstr.M{
+					"id":             group,
+					"topic":          topic,
+					"partition":      partition,
+					"offset":         block.Offset,
+					"log_end_offset": endOffset,
+					"lag":            partitionLag,
+				})
+
+				lag.topics[topic] = struct{}{}
+				lag.partitions++
+				lag.total += messages
+				if messages > lag.max {
+					lag.max = messages
+				}
+			}
+		}
+
+		if lag.partitions == 0 {
+			continue
+		}
+		groupFields := mapstr.M{
+			"messages": mapstr.M{
+				"total": lag.total,
+				"max":   lag.max,
+			},
+		}
+		if lag.hasTime {
+			groupFields["time"] = mapstr.M{"max": mapstr.M{"ms": lag.maxTime}}
+		}
+		emit(mapstr.M{
+			"id": group,
+			"group": mapstr.M{
+				"topics":     len(lag.topics),
+				"partitions": lag.partitions,
+				"lag":        groupFields,
+			},
+		})
+	}
+
+	return fetchErr
+}
+
+// timeLagMillis returns the time since the oldest message not consumed by a
+// group was produced, in milliseconds. It returns false if the timestamp of
+// the message is not available.
+func (q *lagQuery) timeLagMillis(b client, topic string, partition int32, offset, messages int64, now time.Time) (int64, bool) {
+	if messages == 0 {
+		return 0, true
+	}
+	timestamp, err := b.FetchMessageTimestamp(topic, partition, offset)
+	if err != nil {
+		debugf("failed to fetch timestamp of offset %v of (topic, partition): ('%v', %v): %v", offset, topic, partition, err)
+		return 0, false
+	}
+	if timestamp.IsZero() {
+		return 0, false
+	}
+	ms := now.Sub(timestamp).Milliseconds()
+	if ms < 0 {
+		ms = 0
+	}
+	return ms, true
+}
+
+// listGroups lists the groups managed by the broker, sorted by name, keeping
+// only the selected ones if any.
+func listGroups(b client, selected []string) ([]string, error) {
+	groups, err := b.ListGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(selected) > 0 {
+		filtered := groups[:0]
+		for _, name := range groups {
+			for _, s := range selected {
+				if name == s {
+					filtered = append(filtered, name)
+					break
+				}
+			}
+		}
+		groups = filtered
+	}
+	sort.Strings(groups)
+	return groups, nil
+}
+
+// listPartitions returns the partition IDs of the given topics, or of all the
+// topics if none is given.
+func listPartitions(b client, topics []string) (map[string][]int32, error) {
+	metadata, err := b.GetTopicsMetadata(topics...)
+	if err != nil {
+		return nil, err
+	}
+
+	partitions := map[string][]int32{}
+	for _, topic := range metadata {
+		if topic.Err != sarama.ErrNoError {
+			debugf("failed to fetch metadata of topic '%v': %v", topic.Name, topic.Err)
+			continue
+		}
+		for _, partition := range topic.Partitions {
+			partitions[topic.Name] = append(partitions[topic.Name], partition.ID)
+		}
+	}
+	return partitions, nil
+}
+
+func sortedKeys(blocks map[string]map[int32]*sarama.OffsetFetchResponseBlock) []string {
+	keys := make([]string, 0, len(blocks))
+	for key := range blocks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,207 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package consumergroup_lag
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type mockClient struct {
+	groups     []string
+	topics     map[string]int                        // topic -> number of partitions
+	offsets    map[string]map[string]map[int32]int64 // group -> topic -> partition -> committed offset
+	endOffsets map[string]map[int32]int64            // topic -> partition -> log end offset
+	timestamps map[string]map[int64]time.Time        // topic -> offset -> message timestamp
+	err        error
+}
+
+func (c *mockClient) ListGroups() ([]string, error) {
+	return append([]string(nil), c.groups...), nil
+}
+
+func (c *mockClient) GetTopicsMetadata(topics ...string) ([]*sarama.TopicMetadata, error) {
+	var metadata []*sarama.TopicMetadata
+	for name, count := range c.topics {
+		if len(topics) > 0 && !contains(topics, name) {
+			continue
+		}
+		topic := &sarama.TopicMetadata{Name: name}
+		for i := 0; i < count; i++ {
+			topic.Partitions = append(topic.Partitions, &sarama.PartitionMetadata{ID: int32(i)})
+		}
+		metadata = append(metadata, topic)
+	}
+	return metadata, nil
+}
+
+func (c *mockClient) FetchGroupOffsets(group string, partitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	resp := &sarama.OffsetFetchResponse{}
+	for topic, ids := range partitions {
+		for _, id := range ids {
+			offset, found := c.offsets[group][topic][id]
+			if !found {
+				offset = -1
+			}
+			resp.AddBlock(topic, id, &sarama.OffsetFetchResponseBlock{Offset: offset})
+		}
+	}
+	return resp, nil
+}
+
+func (c *mockClient) FetchPartitionOffsetFromTheLeader(topic string, partitionID int32) (int64, error) {
+	return c.endOffsets[topic][partitionID], nil
+}
+
+func (c *mockClient) FetchMessageTimestamp(topic string, partitionID int32, offset int64) (time.Time, error) {
+	timestamp, found := c.timestamps[topic][offset]
+	if !found {
+		return time.Time{}, errors.New("message not found")
+	}
+	return timestamp, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFetchLag(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := &mockClient{
+		groups: []string{"group2", "group1", "empty"},
+		topics: map[string]int{"topic1": 2, "topic2": 1},
+		offsets: map[string]map[string]map[int32]int64{
+			"group1": {"topic1": {0: 10, 1: 20}},
+			"group2": {"topic1": {0: 30}, "topic2": {0: 5}},
+		},
+		endOffsets: map[string]map[int32]int64{
+			"topic1": {0: 30, 1: 25},
+			"topic2": {0: 8},
+		},
+		timestamps: map[string]map[int64]time.Time{
+			"topic1": {10: now.Add(-time.Minute), 20: now.Add(-time.Second)},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		query    lagQuery
+		expected []mapstr.M
+	}{
+		{
+			name:  "all groups",
+			query: lagQuery{},
+			expected: []mapstr.M{
+				partitionEvent("group1", "topic1", 0, 10, 30, mapstr.M{"messages": int64(20)}),
+				partitionEvent("group1", "topic1", 1, 20, 25, mapstr.M{"messages": int64(5)}),
+				groupEvent("group1", 1, 2, mapstr.M{"messages": mapstr.M{"total": int64(25), "max": int64(20)}}),
+				partitionEvent("group2", "topic1", 0, 30, 30, mapstr.M{"messages": int64(0)}),
+				partitionEvent("group2", "topic2", 0, 5, 8, mapstr.M{"messages": int64(3)}),
+				groupEvent("group2", 2, 2, mapstr.M{"messages": mapstr.M{"total": int64(3), "max": int64(3)}}),
+			},
+		},
+		{
+			name:  "filtered groups and topics",
+			query: lagQuery{groups: []string{"group2"}, topics: []string{"topic2"}},
+			expected: []mapstr.M{
+				partitionEvent("group2", "topic2", 0, 5, 8, mapstr.M{"messages": int64(3)}),
+				groupEvent("group2", 1, 1, mapstr.M{"messages": mapstr.M{"total": int64(3), "max": int64(3)}}),
+			},
+		},
+		{
+			name:  "time lag",
+			query: lagQuery{timeLag: true, now: func() time.Time { return now }},
+			expected: []mapstr.M{
+				partitionEvent("group1", "topic1", 0, 10, 30, mapstr.M{"messages": int64(20), "time": mapstr.M{"ms": int64(60000)}}),
+				partitionEvent("group1", "topic1", 1, 20, 25, mapstr.M{"messages": int64(5), "time": mapstr.M{"ms": int64(1000)}}),
+				groupEvent("group1", 1, 2, mapstr.M{
+					"messages": mapstr.M{"total": int64(25), "max": int64(20)},
+					"time":     mapstr.M{"max": mapstr.M{"ms": int64(60000)}},
+				}),
+				partitionEvent("group2", "topic1", 0, 30, 30, mapstr.M{"messages": int64(0), "time": mapstr.M{"ms": int64(0)}}),
+				// The timestamp of the message is not available.
+				partitionEvent("group2", "topic2", 0, 5, 8, mapstr.M{"messages": int64(3)}),
+				groupEvent("group2", 2, 2, mapstr.M{
+					"messages": mapstr.M{"total": int64(3), "max": int64(3)},
+					"time":     mapstr.M{"max": mapstr.M{"ms": int64(0)}},
+				}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var events []mapstr.M
+			err := test.query.fetch(func(event mapstr.M) { events = append(events, event) }, client)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, events)
+		})
+	}
+}
+
+func TestFetchLagError(t *testing.T) {
+	client := &mockClient{
+		groups: []string{"group1"},
+		topics: map[string]int{"topic1": 1},
+		err:    errors.New("offset fetch failed"),
+	}
+
+	var events []mapstr.M
+	query := lagQuery{}
+	err := query.fetch(func(event mapstr.M) { events = append(events, event) }, client)
+	assert.Error(t, err)
+	assert.Empty(t, events)
+}
+
+func partitionEvent(group, topic string, partition int32, offset, endOffset int64, lag mapstr.M) mapstr.M {
+	return mapstr.M{
+		"id":             group,
+		"topic":          topic,
+		"partition":      partition,
+		"offset":         offset,
+		"log_end_offset": endOffset,
+		"lag":            lag,
+	}
+}
+
+func groupEvent(group string, topics, partitions int, lag mapstr.M) mapstr.M {
+	return mapstr.M{
+		"id": group,
+		"group": mapstr.M{
+			"topics":     topics,
+			"partitions": partitions,
+			"lag":        lag,
+		},
+	}
+}
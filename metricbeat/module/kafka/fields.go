@@ -32,5 +32,5 @@ func init() {
 // AssetKafka returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/kafka.
 func AssetKafka() string {
-	return "eJzUWk1z2zYTvvtX7OTkHMLcfXhn3iadjpumyaTpTKcXDgQsRdQgwACgbPnXdwCQFL9EEqLcNjrZFHafB/uFxVJv4AGPd/BAsgdyA2C5FXgHrz64/1/dADA0VPPSciXv4H83AAD+OygUqwTeAJhcaZtSJTO+v4OMCOOeahRIDN7B3qnNOApm7rz4G5CkwBOk+9hj6ZZqVZX1kwncvpquqp1WD6jbx1P6zuoMnx+8BninpKkK1PCTE4V7mSldECcAOTkg7BAlaCQMMq0KuK3FciKZ4HLfU2lzBNro81ReJ50Fw71098NZ73GzH6EGELNb6myLs5tJHMKYRmMmwR7w+Kj0kMg6PMIOqC03yFqIkc+sKjlN3N8jv42hZ2C/Oj1e5zkM1FrphCo2RhpYdBHGqwKnKhmjlURb7mSTnv9ikT43aoCzWRS/u3QCa95+PbDfJf9WIXAGKvMRW57QpX8QbLjMI+TgP0MHiGT+vwCaXKUg1LFboNWcmpDgodTV3/z88Y+ObFvgdmjJyrwudkjkXEZ9dAvA5sSCzbkBPKC0wI1DIxYZWLU6WRtQjd8qNDahOZESRfKtwgoTw59xjsnXHMGtaRxRawEvva46DQmUWrGKYpIRLpClJerUIFVytsY4HppYzyMIQq2n0WugRA2TmgKxTChiZ5llaGl+OS8quHOT19IaymmrNF6BXd9uS6RkVexQz5jrCjZaz2HWNNFMSsGpP40TgYShTlEgdf8PVY0YhfXQrPeu2wBfSSqQyDSWRi13DToGjXFUnpV6QCxRJ4wbqqREapdo/KnUBy8DVCh3StfKNgTrmA4+lVwv1pgTlbD+Zbi4lk1JcVzPppF4ETrmKGmMj3wO1b7dxkWofZKJyuTpRMiNs0btwa++JEDrBg9twmWyO1o0TWldguWSqoLLPTipUGXdhr3Ci0moysaxUJXdq2uz0PgXUossjkojdTUqBRpD9mhSPtuO9JxRy2yDv044XAB6BfdfgHotd0dCb3XvCrgGqrnhxvXa7T17ottuv/tO+23f66wqrwWXvKiKkFHEwmPOad6fGxiUzPTbJwNWARlfcdYERgjDWvtiG0cOqF1InNo5L9+wY5ApDQRMiZRnnNZ3sw39LlWabaFXazgRPHGZ5BpJMLZwNfeDxmo+z9w9VvWcHJvc5CkVZHYQ5IOLPPngakNpLLOE1DYsKVVFwUdXh7MbVllm0HUsXsrtt+1mIin4IeF2+A+dWeNqO68vou1F8FTWQjEND/zKC2rqsIRGTzXPKeoPZ5vPBRPJFUPC4Uj1fbt4Eij4bt2AYYDUWr/2P5dWdQZIO3Tp5/r6eQZF/3yJ2iytjFVFNw4sAUYsAWN1d0A8idyITaR3pAUE2fuC1+7+bajNlAhahZONGF+EGM8y1CgpuoP1EVEO5m61MYlkQwPPb2Zy6rp+K+PZsi8FLYe3J4bdoey8ff1FapLOMENW8Pm/MXwvkTX3MxdZLsJ8D1F3NC3JgfRUqsFcusFCFI74vguk7t/DbTCcQWsdvcA24ez1tLG6NHJlhua6lEhP1VnAAt1Rnm7fPpcWtSRi8AamBuhWodE8e0vBnVISX2xnauAlcXogXJCdwFqvaUa6e35A2ZnjR8aoxEecCY+JBF9B1n1+9YqbwjN8ETCk2TGbYC9D6JNXvExo0pnnSsDF/jy9H3IHS6zXwuTxBYz0Sxhpcga34Wry+ryn6lHqC7D4EjRP0zhfbqU5Spou0dopJcYXypXM7iXjlLiLAM8aA7ibJpdUVAxZ84KLyzeOTDtuRle54fb+ty+rdmLSBQ+/yCZsO2Jfpni2MYAr+P/HthcIB7C/ObtjbyJd2wwNL0giRxifa6mpEUb73Xc6wiDNkZHuKtcepv4Gu3QLssoSAaRQlfRVMsi6I1fp4eR9cVBBLM1Tw58xJYfFu+65QYU51+6tAi7I09pL9mrgmZekVGmWGpRs1ezo/PDDYW8dwqQarT5eTMRqjqxWVY+wthLyVeM/RCgMgeqR3L/srNg0aeww/glADGBEeiwBLv10YJXfT8ZtCvpVfiFgSiUNXs4gyG+gwFX6SPhijLWQ928/gRMAy4tY/0a/JemPOMMLE1VZP3ywHVaRPOr52Sqrl6eDeNUbjL8DAAD//1EPWgk="
+	return "eJzUmt+P27gRx9/9VwzuKQES5X0fClzvimKby+3hugWKvgi0OJLZpUiHpHbX+euLoUhZP60f9l4v2bxYFuf74ZAzJIf+CE94uoMnlj+xHYATTuId/PCZPv+wA+BoMyOOTmh1B3/ZAQD476DUvJK4A7AHbVyaaZWL4g5yJi09NSiRWbyDgszmAiW3d775R1CsxLMk/bnTkV41ujqGJyO6XTNtU3ujn9A0j8fsTdqs///VW4CftLJViQb+Tihwr3JtSkadhwN7RtgjKjDIOORGl/AuNDswxaVQRcekOyBk0Z5HeZ+0Xuj3pd0fwTuPY3+k7klc7FKrW4LvRnUY5wat7TWrxZ7w9KIN36TH+DMaJyzyRmLX13b6KLKE+rubl74g+0h2vM0pDTRGmyTTHHczHp2V8aaATCVDtSMzTtBcSQS/Qum3aAYEv6jie5cKvtJ/rccA/1Lia4UgOOjcz9jGPAjlH3iVBRx1DP4xOMAU959q0WQAtyUhhLlbojMis3WA16kufPOPL/9utW0S3B4dWxjX5R6Z6nzTY/hCL4A7MAfuICzgMyoHwoJByRxycLrXfMrFZ1GDXyu0LskOTCmUydcKK0ys+IaXSB4PCPROHIhgBXzrXsPRGT4EOBrNqwyTnAmJPD2iSS1mWvE5DsOc56gbQrAT7Vo4ooFRSzVYLjVzF8lydNlhO1cmBQ2TtxJtAlmrDN6Aruu3OShVlXs0F9y1kaLto+UMF12zmuQoReZX40Qi42hSlJjRZztHVL8P8X0/dFfIVyqTyFS6FiO0uwWORWvJE9+0fkI8okm4sJlWCjM3h/EfrT/7NpBJTat0MHbFZB3i4OtRGFyOUr//Niy0ZdNKnpbTxBZvgmNPKluOEmIojO11LFIXSS4re0hHptyAQeoC/NtbJmjY4KFLhEr2J4c2ptY5WaEyXQpVALXy0r7D3uBmCF25dRS6coW+NYXB/2LmkK9Dia1uhlKitaxAmwq1eDBCm+vkbzMdNojeYPg3qN5quFdKXzu8C+SiVDzhrttrN+fskd128913ut/2G6VF6bUUSpRV6ScXMAcvB5EdunUDi4rb7vbJgtPAhkecqZFqs9Fctmmwzuf42DMaVrS3c759pOOQawMM7BEzkYssnM02r00GM234NXjBwhnwzDLKuhJwbeKK54PoNZ/E6ByrO4O8kqJkr6lkxZx4yV795IoqMGwzp9RsWNJMl6Vwdk4zdljnuUUHoRX1t9nNrETwRcLr5T+3ao1LpVck0Sjc+Dom0/qBf3OBelSOZvopdEFi7ZaSpgw1ubRYmkkFH+Ufy4NTqT6UVH9uXh4VqsduVGxQYOgpxd7G8RfK6VYBaY8UfrSvb4yMEpTd9WVVZ7PKOt2KObIFnDkG1pl2gXhUOTYbCe+VHpCs8Amv6f0nn+8gYzKr6pWNWZ+EuMhzNKgyKm67F6pvd+tuwZlUcWvM9wZptDOjVdflXelOZPrzqaBh+HQmbBdl48ujSPVBahSnHyELeH60VhQKeTyf0cyiGeZrdmFH00D2Wo+F2sVwm5uFA96faqj7n+Fd7TiLzhFeTZsI/r4xMYlx0NbdCKRjalKwxHLfLyJvUhXKoVFMnuesH+Eg0M5CUTq+6N/rReDqxPsLK2LhtAtgP/iT23nyUlwd47fJWI5esd8d+O2Sz/5PWfqhvThTItqffCLyDohV/mHcjGJIXaSoeHo9Thgsha+uOQmFwilfC8WKJJiwm5B+bfaz0UzEawhAaRdnVteDjeFJNidKTMptaMMs+ChKuiig9YMIteR0SxDAt3DHfy/MxtI1/0BDUAopRb2Ztwk8KHmikqw2NIdEDtQtCtsEFdtLembBoptZEsa0N68Iraj3rT8AKwqDhV9v/XrQcYaFF+EOwJo1djwmVi4dfqHvj+7FEb4wAWtjKzkn0c49vxHeZldOIraDN3HaMXkV6iNZaB1Qo+m18TuJWLLXqwC/hFPiGkQKRnaexo3ERWIKz6Rkr8PMsw54GHX0F7tB+17K1qIc5h7bi81+Shk1vD7NxE4P/RO72R3ni/0bM7L+8HZheeyzLPD3j89MSOp7sHv2q3hGde53sjJxKXxB695gcvzqDQfaCDuJ2XKbX8veAOhB8kVAuzGq5r3dGNSG8Tz/3oQOqmtHrb7JfAMn/eIN0y9T3tWlzvfJJES4mn0Dit9ry+MYkzxC0Q1fOoe111oOC9QLye4Vp8twtCDyeDdNex6hMlnx865VqI8E01xfI6UreHf/z98X9cSGO+4/thOuubJvmk0iThYabjH+f2tqC/WB3lfi6Rg9Eq4RKOxazW4uODv6v4VWY1cizXff6ZUIi0tGuq+o3JT6ivglCirn+u0XsFJXipIl1G3pCK/NaUE5tU2wZ3QpY8U3TNlzMac8dfFhp8pHi4SHe7XJov1i4cHMjrr1VUhK90eL7qKmL1NIe/sPDgKHQWdOm0GcEciDqXAldi2Qzxp/IqD6Xjpc8W1BuuFgrQ2T6IfhTwrXCK4IjznBC1Hh/bto3M/OjQn9fAN7hYftUSuL2wnq9lcgCJ2+MOHmxBvJ+08PQA380Wel1upfXcSbNN8I6h9g6Mr5ywzXolrJEQ61i7zedHzhLyL+NwCF86eL"
 }
@@ -0,0 +1,188 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package currentop
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/mongodb"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("mongodb", "currentop", New,
+		mb.WithHostParser(mongodb.ParseURL),
+	)
+}
+
+type config struct {
+	// LongRunningThreshold is the time from which the operations in progress
+	// are reported.
+	LongRunningThreshold time.Duration `config:"currentop.long_running_threshold" validate:"min=0"`
+
+	// Collections are the namespaces, as database.collection, whose
+	// latencies are reported.
+	Collections []string `config:"currentop.collections"`
+}
+
+func defaultConfig() config {
+	return config{
+		LongRunningThreshold: 5 * time.Second,
+	}
+}
+
+// MetricSet type defines all fields of the MetricSet
+// As a minimum it must inherit the mb.BaseMetricSet fields, but can be extended with
+// additional entries. These variables can be used to persist data or configuration between
+// multiple fetch calls.
+type MetricSet struct {
+	*mongodb.Metricset
+	config config
+}
+
+// New creates a new instance of the MetricSet
+// Part of new is also setting up the configuration by processing additional
+// configuration entries if needed.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+	for _, ns := range config.Collections {
+		if db, coll, found := strings.Cut(ns, "."); !found || db == "" || coll == "" {
+			return nil, fmt.Errorf("invalid collection '%s', it must be in the format database.collection", ns)
+		}
+	}
+
+	ms, err := mongodb.NewMetricset(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{Metricset: ms, config: config}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right format
+// It returns the event which is then forward to the output. In case of an error, a
+// descriptive error must be returned.
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	ctx := context.Background()
+	client, err := mongodb.NewClient(m.Metricset.Config, m.HostData().URI, m.Module().Config().Timeout, readpref.PrimaryMode)
+	if err != nil {
+		return fmt.Errorf("could not create mongodb client: %w", err)
+	}
+
+	defer func() {
+		if disconnectErr := client.Disconnect(context.Background()); disconnectErr != nil {
+			m.Logger().Warn("client disconnection did not happen gracefully")
+		}
+	}()
+
+	db := client.Database("admin")
+	var status serverStatus
+	if err := db.RunCommand(ctx, bson.M{"serverStatus": 1}).Decode(&status); err != nil {
+		return fmt.Errorf("failed to retrieve 'serverStatus': %w", err)
+	}
+
+	ops, err := currentOperations(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	if !r.Event(mb.Event{MetricSetFields: summaryEventMapping(status, ops, m.config.LongRunningThreshold)}) {
+		return nil
+	}
+	for _, op := range ops {
+		if op.running() < m.config.LongRunningThreshold {
+			continue
+		}
+		if !r.Event(mb.Event{MetricSetFields: operationEventMapping(op)}) {
+			return nil
+		}
+	}
+
+	for _, ns := range m.config.Collections {
+		stats, err := collectionLatencies(ctx, client, ns)
+		if err != nil {
+			r.Error(err)
+			continue
+		}
+		if !r.Event(mb.Event{MetricSetFields: collectionEventMapping(ns, stats)}) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// currentOperations returns the active operations of the server, excluding
+// the one listing them and the internal operations.
+func currentOperations(ctx context.Context, db *mongo.Database) ([]operation, error) {
+	cursor, err := db.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$currentOp", Value: bson.M{"allUsers": true}}},
+		{{Key: "$match", Value: bson.M{
+			"active":                        true,
+			"op":                            bson.M{"$ne": "none"},
+			"command.pipeline.0.$currentOp": bson.M{"$exists": false},
+		}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the current operations: %w", err)
+	}
+
+	var ops []operation
+	if err := cursor.All(ctx, &ops); err != nil {
+		return nil, fmt.Errorf("could not decode the current operations: %w", err)
+	}
+	return ops, nil
+}
+
+// collectionLatencies returns the latency statistics of a collection.
+func collectionLatencies(ctx context.Context, client *mongo.Client, ns string) (map[string]latencyStats, error) {
+	dbName, collName, _ := strings.Cut(ns, ".")
+	cursor, err := client.Database(dbName).Collection(collName).Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$collStats", Value: bson.M{"latencyStats": bson.M{}}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the latencies of collection '%s': %w", ns, err)
+	}
+
+	var results []struct {
+		LatencyStats map[string]latencyStats `bson:"latencyStats"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("could not decode the latencies of collection '%s': %w", ns, err)
+	}
+	stats := map[string]latencyStats{}
+	// Sharded collections have a result for each shard.
+	for _, result := range results {
+		for kind, s := range result.LatencyStats {
+			total := stats[kind]
+			total.Latency += s.Latency
+			total.Ops += s.Ops
+			stats[kind] = total
+		}
+	}
+	return stats, nil
+}
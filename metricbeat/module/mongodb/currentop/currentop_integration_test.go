@@ -0,0 +1,75 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build integration
+
+package currentop
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/tests/compose"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+)
+
+func TestFetch(t *testing.T) {
+	service := compose.EnsureUp(t, "mongodb")
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(service.Host()))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+
+	assert.Empty(t, errs)
+	// The summary and the latencies of the collection.
+	if !assert.Len(t, events, 2) {
+		t.FailNow()
+	}
+
+	t.Logf("%s/%s event: %+v", f.Module().Name(), f.Name(),
+		events[0].BeatEvent("mongodb", "currentop").Fields.StringToPrint())
+
+	summary := events[0].MetricSetFields
+	active, _ := summary.GetValue("operations.active")
+	assert.GreaterOrEqual(t, active, int64(0))
+	queue, _ := summary.GetValue("global_lock.current_queue.total")
+	assert.GreaterOrEqual(t, queue, int64(0))
+	_, err := summary.GetValue("latencies.reads.count")
+	assert.NoError(t, err)
+
+	namespace, _ := events[1].MetricSetFields.GetValue("collection.namespace")
+	assert.Equal(t, "admin.system.version", namespace)
+}
+
+func TestData(t *testing.T) {
+	service := compose.EnsureUp(t, "mongodb")
+
+	config := getConfig(service.Host())
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	err := mbtest.WriteEventsReporterV2Error(f, t, ".")
+	if err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":                "mongodb",
+		"metricsets":            []string{"currentop"},
+		"hosts":                 []string{host},
+		"currentop.collections": []string{"admin.system.version"},
+	}
+}
@@ -0,0 +1,155 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package currentop
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// serverStatus contains the fields of the serverStatus command used by the
+// metricset.
+type serverStatus struct {
+	GlobalLock struct {
+		CurrentQueue  lockQueue `bson:"currentQueue"`
+		ActiveClients lockQueue `bson:"activeClients"`
+	} `bson:"globalLock"`
+	OpLatencies map[string]latencyStats `bson:"opLatencies"`
+}
+
+type lockQueue struct {
+	Total   int64 `bson:"total"`
+	Readers int64 `bson:"readers"`
+	Writers int64 `bson:"writers"`
+}
+
+// latencyStats are the latencies of a kind of operation, like reads or
+// writes.
+type latencyStats struct {
+	Latency int64 `bson:"latency"`
+	Ops     int64 `bson:"ops"`
+}
+
+// operation is an operation in progress, reported by $currentOp.
+type operation struct {
+	// OpID is a number in mongod, and a string prefixed by the shard in
+	// mongos.
+	OpID             interface{} `bson:"opid"`
+	Type             string      `bson:"op"`
+	Namespace        string      `bson:"ns"`
+	Description      string      `bson:"desc"`
+	Client           string      `bson:"client"`
+	AppName          string      `bson:"appName"`
+	MicrosecsRunning int64       `bson:"microsecs_running"`
+	WaitingForLock   bool        `bson:"waitingForLock"`
+	PlanSummary      string      `bson:"planSummary"`
+}
+
+func (op operation) running() time.Duration {
+	return time.Duration(op.MicrosecsRunning) * time.Microsecond
+}
+
+func summaryEventMapping(status serverStatus, ops []operation, threshold time.Duration) mapstr.M {
+	var longRunning, waitingForLock, maxRunning int64
+	for _, op := range ops {
+		if op.running() >= threshold {
+			longRunning++
+		}
+		if op.WaitingForLock {
+			waitingForLock++
+		}
+		if op.MicrosecsRunning > maxRunning {
+			maxRunning = op.MicrosecsRunning
+		}
+	}
+
+	event := mapstr.M{
+		"operations": mapstr.M{
+			"active":           int64(len(ops)),
+			"long_running":     longRunning,
+			"waiting_for_lock": waitingForLock,
+			"max_running":      mapstr.M{"us": maxRunning},
+		},
+		"global_lock": mapstr.M{
+			"current_queue":  lockQueueMapping(status.GlobalLock.CurrentQueue),
+			"active_clients": lockQueueMapping(status.GlobalLock.ActiveClients),
+		},
+	}
+	// Only mongod instances report the latencies.
+	if len(status.OpLatencies) > 0 {
+		event["latencies"] = latenciesMapping(status.OpLatencies)
+	}
+	return event
+}
+
+func lockQueueMapping(queue lockQueue) mapstr.M {
+	return mapstr.M{
+		"total":   queue.Total,
+		"readers": queue.Readers,
+		"writers": queue.Writers,
+	}
+}
+
+func latenciesMapping(latencies map[string]latencyStats) mapstr.M {
+	event := mapstr.M{}
+	for kind, stats := range latencies {
+		latency := mapstr.M{
+			"latency": stats.Latency,
+			"count":   stats.Ops,
+		}
+		if stats.Ops > 0 {
+			latency["avg"] = mapstr.M{"us": float64(stats.Latency) / float64(stats.Ops)}
+		}
+		event[kind] = latency
+	}
+	return event
+}
+
+func operationEventMapping(op operation) mapstr.M {
+	event := mapstr.M{
+		"type":             op.Type,
+		"running":          mapstr.M{"us": op.MicrosecsRunning},
+		"waiting_for_lock": op.WaitingForLock,
+	}
+	if op.OpID != nil {
+		event["id"] = fmt.Sprint(op.OpID)
+	}
+	for key, value := range map[string]string{
+		"namespace":          op.Namespace,
+		"description":        op.Description,
+		"client.address":     op.Client,
+		"client.application": op.AppName,
+		"plan_summary":       op.PlanSummary,
+	} {
+		if value != "" {
+			_, _ = event.Put(key, value)
+		}
+	}
+	return mapstr.M{"operation": event}
+}
+
+func collectionEventMapping(ns string, latencies map[string]latencyStats) mapstr.M {
+	return mapstr.M{
+		"collection": mapstr.M{
+			"namespace": ns,
+			"latencies": latenciesMapping(latencies),
+		},
+	}
+}
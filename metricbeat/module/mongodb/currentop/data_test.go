@@ -0,0 +1,98 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package currentop
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestSummaryEventMapping(t *testing.T) {
+	var status serverStatus
+	data, err := bson.Marshal(bson.M{
+		"globalLock": bson.M{
+			"currentQueue":  bson.M{"total": int32(3), "readers": int32(1), "writers": int32(2)},
+			"activeClients": bson.M{"total": int32(5), "readers": int32(4), "writers": int32(1)},
+		},
+		"opLatencies": bson.M{
+			"reads":  bson.M{"latency": int64(1000), "ops": int64(4)},
+			"writes": bson.M{"latency": int64(0), "ops": int64(0)},
+		},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, bson.Unmarshal(data, &status))
+
+	ops := []operation{
+		{Type: "query", MicrosecsRunning: 12_000_000, WaitingForLock: true},
+		{Type: "update", MicrosecsRunning: 300},
+	}
+	event := summaryEventMapping(status, ops, 5*time.Second)
+
+	assert.Equal(t, mapstr.M{
+		"operations": mapstr.M{
+			"active":           int64(2),
+			"long_running":     int64(1),
+			"waiting_for_lock": int64(1),
+			"max_running":      mapstr.M{"us": int64(12_000_000)},
+		},
+		"global_lock": mapstr.M{
+			"current_queue":  mapstr.M{"total": int64(3), "readers": int64(1), "writers": int64(2)},
+			"active_clients": mapstr.M{"total": int64(5), "readers": int64(4), "writers": int64(1)},
+		},
+		"latencies": mapstr.M{
+			"reads":  mapstr.M{"latency": int64(1000), "count": int64(4), "avg": mapstr.M{"us": float64(250)}},
+			"writes": mapstr.M{"latency": int64(0), "count": int64(0)},
+		},
+	}, event)
+}
+
+func TestOperationEventMapping(t *testing.T) {
+	var op operation
+	data, err := bson.Marshal(bson.M{
+		"opid":              "shard01:4521",
+		"op":                "query",
+		"ns":                "test.orders",
+		"desc":              "conn42",
+		"client":            "10.0.0.5:51234",
+		"microsecs_running": int64(7_500_000),
+		"waitingForLock":    false,
+		"planSummary":       "COLLSCAN",
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, bson.Unmarshal(data, &op))
+
+	assert.Equal(t, mapstr.M{
+		"operation": mapstr.M{
+			"id":               "shard01:4521",
+			"type":             "query",
+			"namespace":        "test.orders",
+			"description":      "conn42",
+			"client":           mapstr.M{"address": "10.0.0.5:51234"},
+			"running":          mapstr.M{"us": int64(7_500_000)},
+			"waiting_for_lock": false,
+			"plan_summary":     "COLLSCAN",
+		},
+	}, operationEventMapping(op))
+}
@@ -32,5 +32,5 @@ func init() {
 // AssetMongodb returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/mongodb.
 func AssetMongodb() string {
-	return "eJzsXV+TI7dxf79PgVIeJFXt8cpOKg9Xjqoky46V0lmKdC4/pFKz4EyThBYDjAEMefSnT6EBzGCGmD/8u3vM8iGxbsnGrxuNRqPR3XhLnmD/npRSrGWxfEOIYYbDe/LFB/sv33/3xRtCCtC5YpVhUrwn37whhJAPYBTLNckl55AbKMhKyZL4HxENagtKL94QojdSmSyXYsXW78mKcg1vCFHAgWp4T9bUfgeMYWKt35P/+UJr/sX/viFkxYAX+j2O9pYIWkKM0n7MvrIElKwr/y8JoAjWoyod6IX/QzxCPIrlSRtqdPOX1Fgj48VjegExKYilybSxYushsZ+uRMKnjzHG2QiiC/IJ9jupit7fRqDaz/fU0CXVgKRbWMlxW5YuN/4fWzHNQGD/7yXHLpdMUBxcrkgRREFFEU/fDFxGGsoXhpWwqHUSIJdifRy6j5Ym2VFmVwixtMlKKsJl/qQJE6RkuZIacimKjj71UeWyFuaimERdLkFZkVkwCJHAFoTRE2KyX08i6a8vMrACYmIKaDEg8lEWZ7CJrFqBB+lbwdvx5kj/AGNqAi6B8K/NNDTQZsxFDG+nmIFbyhAHPFaIDuX1pdiCO0Kl/1GDYqAvvfat4FQthBWcH2Lemg94LrnqWxEFKPAJ8tpAMSGcNZhSqiENO0s4VD8FpbJDkLxW2i5SuZspqIDtOoKyHGtCAyyqn6ynFMDanWZCdExoUOYaknOUrfAE7Egh87q0ej5Pah7WdYQWsPhR5i3AuirooBE7S1BI2crpSBl5RFeWkRtlnowUlHJ7FRkVwOEUGXlEV5YRopspo1yWJbV4ryAlZy5RTMHHDMPNE1cD7koCO0B1aODbw8fZRyRPglRKbllh7aQgcgtqy2Bn4VBSUWVYXnOq3NGvQbggHzdMNzPcIcs0KaU2JJciByWgIDtmNvhTspW8thYZqTfEzjqF0e06k8vfMs3+CYvl3sBslVlJVVLznnR/NHHkSlNnwsAa1DgRy+91Yc48kSZ/u2IcrouOiQI+3WCIOZTTx9q6zOCTsVbqRApy+RvkJ/9aG6no+sqzIDTSz8rlokyryzRQ1GRUmC0oPRSIOOU0WdLfpJp3vBimwcRJNMLvnQpkKwWQcabTdv4U5kRdnsnaoGYMEIqwJTWkEZmLh03uJmnzPLLH/NrG3MyGGqJgZQ0pMRs8Kyh0oXysx+5G4LaGcOZ6dBHHR+uKGirybtSpceSZcOwxKR6IoU9AKOFSPhFqyMaYSr9/966QuV74AOYil+W7koqa8ncKVqBA5PDO77rvXPDUIq/1u3/xoVT8r8WhnMa2prCNz1agCU/hF6ikMppIgfKzckt5DYdH+I8b8EDRz/HBz8bLoAqQoEWt046InR+g+YZsKa/B7vE0vfsjg26mHVhL2PTiVQf+DaGa7IBz+/8RSfPVFWUciuC6STEWT+kztviD/1/fLDwZvbEH0+4I7ozYfDWMiEJGX8aqzKFMRwZz7M4Zq+F/fLQpw8J0poGvBm1CStfG6Ma0nTiSX5kwOb0I6PEkGhdvvVawpqYfc74bBpc140Vmrdi9cmgd6Kx/YrkzDoVwR4SskvLumR2LAn/mzBXL+568wjpkIr/b+VsxkQJwF7ytwWR5WWScCchkdb9KahnlVJsMlBo5zd0DlzI1xN2wZw9o98xfRRUtwcDd6uhGanPXvqm75rpb7nRWUn3H+om7xHCA/t74TIaU7odJpk0Wwk9368Uhl+m7irvhsWJJ+vfBm5Irxu/WrVFQcQ0ms+6NWrK7XYYxny7Af++cboAqswR6t95OYNSl5WSV1CyRKH037LrLqTtXXjeX98rdbkONLve1YvfC4ZsUDZeB+WYue5MlA8JQJrTLKlKwpqpgYh3yPN1NMhUFqbvpRWTGZZphJRSZrK+R5fwxdRmKmP39+IZuwSEgsjZEM5G7u1m30q3nkYPWlkPVyQvrcyErON7qzeBgSPJ2vMDKIap5uipkZllPi55M6+ssBoifhnYCYuguV80KXCIV8v13/12D2i9+wv9cCPnRISQaDDGSVArTGokHnmK94xOLUxfjicw9ujEfJyfobIN2BsjOEgiVW0yTkjLUtpBinnM2I4V0IC/hjLQLzFlp0x0JzXERWgNTyoKtWO6qlypqDChxIN8pk+MSZIf97MsanDZH2I87bEVcaOwZkIWBx2p6TK3Sq+m60MLAZLlvyjwmfJfbg/TjTheGDF+ynLxe7IqxInLJL0iaOA8VU2A2YI1mDrhtuGIfWYHy9X+ikxrWXqofu6SQcLajzCzKq9RUNeKnpayFCdk9LpGcc+YTyZ1Nszy51B6yoZroyjJXgVpJVVoxrMH8SLX5E8qqkUYKN3E7lJObT7EmX7EFLMjua7JWQA0oO6ggv5uo5XLSuVZBV0o/B9h0LFErlpytGBSXYc9vydea/X5WF050F3jEYs+xo5pY70nX3IRFsfN4idko0BvJC+tfxCKbWM3NaJdayH+RvNAu1wOUxh1YwxYU5UgTl7Ovf7FboTWG+5jnzna+oaLgoEmtrcLjVFMerXykeOwq1zkVGRVFJlUxcsNxWS0O1Xc+zdGaOqKl3a38l/yfciqEbJa58+KlMhHPThZUuLz1KX3OpVhxdphefhM+QXg9iFengzOjNHOfuUzHy20yIRcWjyHY+8DK16lgkyxK9F4bKE/RKgFFiNDf1ETimIQZKHXAQYpaxQWozVp7W3EqCGwpr2lqjzzkqHEQbspT65aczlFStRRU3Pvel9Yrn95LFHDrSFlTbBUsGjGcxYdTg+Mv06oCqjB3m3IenIGQ1q4fsDqOmI3U4BYcVSC+NKQEZ1GwvBzJ2ZPn0YZyYPmNi2qGuEg3LX1LFZO1jltt2F2jL7mA5tRAQdiSRg+pQxxNDRIPhGf7LEe3Y+jcTuYci/skret1GXq51R8+JIojiVlYFyKl8w0U9XDskcyaKDJjsuJRBZi8HBtyJhekkw+6k6rfMONMmvAp57Vm21RY+wyyFmiWvN05k+hlKa4o47VK3n0fQTVyMOpBWpezBUxklZJrBXp6hVxYpS8/Ay9cpxsjwgGqYUN/JDUFtNhfhtRKwRibMzW3FpqtBeVQZK44flSJJ8m1W8vU3jhJSm9qbAmWFXKXukdoSS2l5EDT3+mpcMbsrr2i+ZDoHMV0E6mWGq0qnprGSzoy1mOx4wSPxV0ThINtc96InRpZcbk+1aOhxkBZGZ0ZmS0hlyVkLoBE1ZDGzpzJJTX55gzzODOg35MdCqMjwXBzZU9lnjPr+s/0b8NnrrEeOt50+Z4wbTNZJwPHHi95JwUoCM2V1Brd/5DhNshn9zoyHcK8Pi+Toc1EVBNXp78RPFgvg2skjmKdaLqOunvqz5XT1wiyn7ThI/WyXq1OSKqdgTFEzNwI+hBb0vzovciJlrXKwf+SLGElFcQzYgmBMKGPFXU6mp6QxCl24Qm3HUPIju7xbKxo/hStfPfFs853N9CCcM3QuQsOUmZiFj8t6pKOdLe4BvySfmJlXWJXgBBF9kBdf5aoWDuXGGowoddnYM+t3wcMwTBNhMRLlxVb14ou+UHyRJfjm3IbJivmNpcC23WE/x6frNaTZ4ZRntklcz0/IgzjV+ZQita89VBWQzfEZL4r4HKBsuBrnE9s5Aw3I/Np+GBzMU/OD4GtEpb7+RG8edNyK83vb8Y+9YYWkzvB+PL1vfdu7Rv6YSNb+0B2G5ZvsBGFgn/UoI0LHNKiwPxNyv1lWd+XSOeVtR+qsYlV1z7MVAHymfmbh3L9nJxLO+9MuUi7r+aJLncOeXuZXmTjPYwszxkrU0EBSk8Enq/vD7vrNL9EQBOPZ3hnrRRwSY8vGDj+cP5YKXi7ApNvHu3eugZrQ0BB4z1bHDq6ekIHx13oESaMJL98+8HqGiutN9udIrNRsl5vqnQ+35ytoZD5rc1qy6plHQrHZQmlVPtw4eaTb5zgnNzuwfIdsD7MbhRoSF+Z9vm7mInM9bkm0h2wLYvdVp+9Te4UjlvXeKgojVxRdeNL76OUd+Zsfk667GQRDuleGst9Eyjzh/pEV9wLqAF5kb7BQz/y9NBbCl2ZnSiHNykh+JaMi6YZ4EIDVfnmTUoSp/QEXNb5E5gMPm1ora+XKZpMVotid/kG8ifrfG0A7zawRBXz8mRtsKMKZioRXTNDl3xPOFVru2nmUhWEci6H1Kp1aZxffyMG47gkzphv/E23lHG65AnsI2m8Pm/l6tjH0KVYmnoPw/Tz+M/Oi2ni+03G7sp7uD726LsSYts/w/3SHDhlzcyLf/a0JY/D+fNRq4GQuNowOuIZU+tBXxX/4RoHsqT5k51tUTQXMK7nd+waH8FWp86yH9JKK9fRPUNb2oOtqaOrIh9ZiyU/1pMTDzaXWhTulDQBsr0c9FBDtINhLYFisA2qVSwXazC/tL/7QazkV18fnb/I/gkLbzmON1rpTrGzZUIGNnJdUWcdijgS5+eZCTfciAW2PNVjPSGux06alUbAcW7gID+DjK2Y0u6BCW1omfKrz7YNgXawyzikz6oHqjgDbb6OTLq/Gkis8UEuOL01E3ZEzwOn5nwOdkwUcncl01ywle8yTJZgdgAimggqCsfNCP4pNxVMJi76KNdH3w+43d8bizuJBWvSEzXbDk7iD3ML8OLSIDwR09x6SXg4lHGZLLq3IW5VNAz8CuY/wbjOzqEJ8WRRBWr1xfz9posPM9fx9n+Ie2E3AUi7J1WS+TMVdhrG8+RDVMLrrgO1sb6nlXBUm+FKk+3hEwSm/xurwZJQ17CdmX1/V/bH1WGD7i/YP0sJhIwOVLqG0uhS6bNfuPvVz5L9SAHsT36TtRKU9wgfbzg4vZhj9j1wum9MLR0qM6wUK6nau9iwsSah2ju1Hkq+mn6/4NOpDorViEQlwwxukePDHcaZLjsPMZ+ayx1oM8hhxAsb7iDwMnhZUW3SvCQVbAO0UFL2H2E4XcvSu7pH+GXI3HGeBQLu3wVxug7r5B407jTOh2fxgNcXpJEnzvLg6ktqrN9FL6WwP4eTavRgBqFLWQ/VGblBmiMb861VjlRRL6rFRh48pkIms35nzc9f7K5Fi0JhhukqXoaTqJxpuQ6un/pma/h8GxQCpTQcJT0Lzo9MuwNsGIykBzsElXaDLyolHSNjibEOUZ0TTVNjiP7YHPVn4FGQyy0oJta3mLt2tGbNjk9iBO9a0cdGXAGRP78+tmM/Ji1HXArxJORO3EKCHuSXTm4NVo9gLtCbyRKDsXPRYV+ouvr9LeTo8xvZP+frYgPv5poYRp4SIFVLZkDdQn5+qCmZBURXF1nAM3xmvdECfSxQ0+epVHGTxdiFNGxrJedLmj/d0Iy1ttaPPaXhDcbb7wYzEdZiA5SbzU18od5ugNbWD0/+g6wo13OAXl2WzVAHOhjZ1jnXcSODhtQ5H0T1dxmhDiT66vGvtI69E0k5o/1JrqjZuFAyy2GR/vWE+H7wvRXC2OknfX2Q+Ehg4T47EXOfEVcPIewg70CN/Cy1ZksOrnDDPcyHt/6aSJV8G67tM5ZO1xnUv7nCc4T7CThpEFzmlGeJ09Spgf8fLUEf6df+RcH2/rBpFOixToQXqdagjF4oWNecphvRHC+o9vVmT9ePg/folZJFnbeI3cJKCy/A21ElDlunnw/P0z0XXqkvD63UZ8Oq9UEt3vm4LNFzgdk9zx6BLrUwI30LlI+BmMTadtm7WKTp+2532Pjirm0FyEQusf1dBIDQxONdPi2KcX/RhOnO4Y3S1CxMx6Q8mits2b3WphFr/r6kh9yn3/jWpli4l6TraTKR87qA7mWo3gDnRIPGjY78UQrNCnCNd1wRoEy9DkrIY5Nx9ogXH0XhXtTFwrpPprnhKqipD/poRdoeqFxBnj2B1gJzSNpMuaQWxXJOU82pCJk7w3xh5sdIGca5vH3rUo7x9MV5mhU/eveG+wg96Yol9Jh2naVzLvVkFiF8MoqmXoA6PQ7NqcGeeL7jY45zdewS3gCtslrT9UWfxJ7NBOkkOmFVakj7sfNp0SWSnprFP1p49pPg+0jFpSB/E+zTux+ZqEdzDNeQrWjNb9Yi0I5I3IihHSJWcZGC6ae4WIv8TNfpheh/jc9wWx3v948MFFOW06TeJCOhPTNWjyENpsWXpmMyCM0N24KvMJhqk8vlkvKMy7xfrHqJdFpLtn37PGZz7oLo2ys8CiSLjSdz9mdl66MmtHn5uZJtXn7TsL6ZK0xt8v3q8XqsMWgb8KL9UeZPQwUJWMjONMGKLL4nVh22lGMKkAyLz6mDPwSNyMfvlxm2jBqRz3CJy2wBJasCcdyCLCGnPk+a4vynmR8vW+nM+cB3ZtVozK7QmCh59MztKDOhYTsm6ln9xkS9B7eB1aXv+d5OxUIBLdCVtfrR+Qumbih9kATbF4OncDNBJEUQOjcGHvg+SMUy1pcM1ogOT3+v/+vnxZvLuBlirvEb0Qpn3vm91YL0HhEUweuObu2pZ6ebMKRDF99mI9dw+BpB/Hlxa7e3brHBldsDHR8dPzFqTY4ikepAIi93PR7J2myOnm8VzuZofJKiON3TxU7637bPYnh/zXk21hAAzTfO/v/B0v/mwflkwen5QykL+CYhd8t9FWKh0e9dRNT5DA+Nh/EQ1a48kBIMxVHsMh7oN9Wh71G45hMPZPdAfsHf/t2XaSjQlQLtjtlUQfHQdjq0HpBp/+KLzPFfmu+M1ADgRCwcbwuao6PrrhIWDhXRG7lzp9lUdQ+KZkc18b8twlVL20nfkjkUQGfg9hmA+aPqANddUCAQ/Bf33pru9gtPdpUNTlBDkuxAAdkAL1xvqtBgHK+XZ7JR60Me8rqsOcXVY78T9TJrPdeutxJzMjFqAbTAXe7YaRuUVqB4dLWcWxeDJ79zXhHr6uZQu5WZnYO6xFIlFycT++WSxP5+HrFoWZ0psIjSmdKKKJ0pqojSJeRU64sIqdYXkVCtLyKeWp8rm551OVNEPWpnSqpH7UyB9aidILeG0nDY99UQvhrCV0P4agj/fxjC9lT0agovRezVFM6j9GoKh8m8msKbm8ISDM2sY/hqCS9F7NUSzqP0agmHybxawptbwlT/I/JqBV+t4KsVfLWCd2sF36TIpd8feOakRiaeNZmxbXIWnk4wiq5WLH9okhsfsD0M24ZUCJcdnExYi7ad2rx8vvCO13fMmcXVlXua9nOtmqcRuhMwM8tfVnrBqQGRHxazn6z0PzUtdRrSzVVqkwGIj53vNjL1nAxmuvZfw/VJBKGdkFEsP3YdKaBF4Hf4sbgz5gfnJpflkgkoPPf7/rXymOpYfFdrB3qgOZ1MlyibyXdCamOFPoXT10tPvEn+kiXsAd5KxCMZYyfL2Leoe8lSbiDeSs5hwFPkO2gXBx6UPj0pqtsJtrGEkXpY072vZud4t/6BBnW7x+jtYN1nTfzO0yZ6O+OdpBkMeicHfFiX8LmRG7EWnp4fZKgLPkl3kiFs0n+NmqgUR26wm02W64l9I97cYDfjzb82dCPmDt82ui533oLeiLvGXjOt67aMrS3luQCHg3Y9dGE7KN67uGXvNvv+rKx8K6RZBj9JN2UzX5bBj7ictP2XWGU3tf0Rc3O2gQtO4U23gYjNOTvCBdm87Y4Q8Tlrc7ggo7fdHCJGZ+0TScJDPtvMfcKVgF5qf8DGB66lZlyg71+ywmrlUNHhMZ/Wv2CZSqk/f5L+xMwGFPn3fyNSkX/9/QMpoAL3+I8UviDCULUGQ6jKN8xAbmoFWITQFB0kKUePeHnGc1lWjE88w9nGSzQrQJhFubySbrYhwl++/eBKW2FNXT33Vx+++/ohKnxLVXQnCU/ytWXK1JRfha2WqyQ7chVGb9WzZWt4J5zkqaRVBcUtZsqN5OEnmUzNVLrQ6ztfjxLaCtUatKf81o+zYhz0gwtLNm9rc/YEHNuGLwfsk/3LUP1yt4GAXJG9rFUUMEhf54S/T05CtmNmk/kO7S9jRlxjisYWpKyv++DUraQKDeaZWKfr2TD0li1p/qRd0XDa219KyYEe2UXso6qB7DautbcCrBLrP8VPQ5OuZvvxhbEWvAKj9oPQ/ftyGYg1E5DqJ+awn/SGx7dEG290XWGeK2PzhUnR4x5tax5EQzya8X1zxxQUmWHrgc5LJ2yevxpqmDYs19EW+nc7zkc7zCA+95ls+SNFKC03igpNky2PxhmYwcQgI/Gg0V7CcPdbqzMeIscFsJD10NuVE/ers+tS/xqXUAelcYHviLkpjvq4x5oH3Qo9y5/A6LZFyBzcodkG/vRm2N1u0YE99ZY0LZ5LN/De6STVQNTPqhkO+3GKgaifUy9i0COHPJonD43XsXs4GpZGY7sg2LI82jybb5xq/Er6iZV1OZheQOaIeyrNYCb/9vPB4fFs47t1o/itm/NiwP8atbGK2xF196vB+YriQkyZ/Ytkq3kgAiE67nzZ/Ay+rAprbFdzs5WNQ7qVja9iH4UUN4obQw3vQ02v7z5atA0DAYTr4fWjTuINWE/JoD3VeLqNnm5cq6B1c55tveJT7SZappeyQN0tP76RGi/VOW7YS2Gh1SpnZcIqaNZsshUJ6e5k2YpxyF7U1IT9zCKbsZ2teK03pwM/Wtg43mQXn25S0M3AzWua1KzInCaPo9fBZkebD20v8htC24s8CS0ZiGjfuM5QGYa6Z58fzQ9PaHdChEb6aXb9JiX2vnT9Wb1zcSg42e3vuZIqSn7EaE3ojPPhw7c/b393ZuRjeE2eG/wLHWzDm6s+B2u4900T2dwctNYPzCBYfPreL9dGqIMM4uHnsPv9JThMXVvFrfDJV6X+2jEf3W2Et2c1dttNEtZViD8wsfYvGQf9/bqnTN81vZJcM8yDKKb70KWWvDa+5/ODPc0e9oEmj14dHvFM9ki3gCHIUj8ONVT1fZLJEoyd6dAc2iX6zukOjSNcb378AK6zk5NrkGSQIalA+b0CtiBGXmbFp6yvB7WJlEcdVFttemg1Ca8we7tbkqyR8smymcuy4mDGHFeqTbZigtkFNsjgQN7CrHbWJvlMd0BW9PmZsOxt4P9KBr0d4K0CjvfQUXAfQxYuyxTflyD/NYSHdF5UdNa9qoAqlw1/kN+eMPEHFLsmH8Fg5+4WMwFht4+D2/zpQHhZXuf6uNe2MA54DzwaHF0LD+ZZhu/ifHv02GZPbSkf1nb/s9vcRIYwQve2K+b6kOMkWVwwJ3LsLsKMxFJ3PEroF8A7HqsDR276k0SRDILua8Wp8rB2R7lHEa4khGgEgmajEzRL6/x48g0zOmMic1OZaDl+CeTNq1IubR4HdTZJ5hjoK8huY497NOrjizkeG+CJ5CFH05FhovsrJgrM8CG0cZuFLIDUwjomlGyAbvdkOEzFpW/Jm1uH0FrTVa0wSaVgdC2kZiOBbqCK77Ob2Tv0cgOXvlrLerJBY5ewkr6Vvc43UNR8INZ1or6Pv9F6Rmjqh4P3gvE41O6NvZym5iJ8wthtqWKy1qTaUO0Cs9Hu5oOygwYgSXFYSPOiYsWA70cueNA99AMxp9inVwX98Ucp3yjf6VaqorT9/C2cEJBRa3WC4955zgWIxPXjSLrvugfHJkK0CqqMy3W2rFcrUM8iJ+fcWyRUee/eX4VOmNjw+bWknIMKL6w11skfbYKexW7fdEDJbrlNjs2zSYXmpqac75szZU8m5M9s8CaVEL3XBrB4GQrn+hawZTm4FbWi1m3NqSB0tYLcnCCg2Cd5NhlFooncDbqK5v4gVyf+/BmDoFcSlYKSVlml2JYayLYMds8oKQRTuceIqv1bKd66heZTBV2e1yBZC14vLr7a/E7+jGKxDkhAMQdq15d7GcDHXL1BwgMu4Jv/CwAA//+2umBX"
+	return "eJzsfV2v2ziS9r1+BdHvxUwDbgXv7GIvgtkG0p3BTgPpzkw6i7lYLBxaKtvsI5EakjqO59cvih+SLJP68JF9EvdBgt3p2C4+T7FY/Koqfkce4PialILvRL5JCNFMF/CafPMz/svbH75JCMlBZZJVmgn+mnyfEELIz6AlyxTJRFFApiEnWylK4n5EFMhHkCpNCFF7IfU6E3zLdq/JlhYKEkIkFEAVvCY7it8BrRnfqdfkf75RqvjmfxNCtgyKXL02rX1HOC2hixL/6GOFAqSoK/cvAaD416MqLejUfdBtodsKclKaatV8EmproL1um05BTHCCMpnSqLYeEkL6GiEkjLGLs1GE/2NBPsDxIGTe+2wAKv59SzXdUAVG0S2sYLstpeXa/7FV0wQE+JUl2y43jFP8mIgtyb0qKM9JNguXFpoWqWYlpLUKAiwE381D9xFlkgNlOEIIyiZbIUkhsgdFGCcly6RQkAmeq3QAVSZqrhfFxOtyAxJVhmAMRAKPwHUHRxAQfj2IpD++YiOgK0wCzSMqH6Q4gSb+/YgK99pHxWN7U7R/hjHUAUsg/KXphgbahL7owjtIpuGWOjQNzlWi+dENtNiCm2HS/6xBMlARJUbBjQAzxidrzlFxrolhhfXxhNR1KZpWRR4KfIas1pCPKGcHuhQSrqEcqh68UWETJKulwkEqDhMV5bFdR1HIWBHqYVH1AHkLFmeaEdUxrkDqa2jOSkblcTiQXGR1iXY+TWsO1nWU5rG4VqYNwLrKqYZrKMpIRj3N1JFDdGUd2Vam6UhCKR6voqMcCrhERw7RlXVk0E3UUSbKkmKfXkFL1l0aNfk1pm9umroacFdS2BmqcwffQKmlBK5FlYwt4pqNzQY0TSbBaoQTVZcllexfoIjeAxEVSLNQN2OwkmInQamV+WxXiA0tzKqC/LOGGk77DlfzJyJIQTXwDOcxsTUS7K41TYYXn14DLZZgR4TWsiM98ashe/RwwmTTmetjmmn22N8tDdrLBKSndjMNaAsJ21y7Zc2tgPlVFK4UsH2QRO8pN/2u9xLUXhQdM+9DdqvX9VbIdWD7cjXY3VUzNbYdx1jSz16r545rCYTGiaHCUAQo3QLt9jvZU0U2ANzrfEVY/6TA/hl3eHZMhzR+8SD7u3ENfox1nMbcgeWc1Nr4mtRs/a+g86BVmCbzky0Vkhk2j1O8uFcEqZ4ZcbNjnQrbbM6eHXa7RYzjtn53nRXMrDqubx+2QWIbJJng3B7SKVKB3ApZouKNwv0OPCiwZT+Z2y1saQa7+QRuYVWTCBggUQYefbNwWconvuuvhFoE/l/s2mhFNkfywHh+Mk5S8p4XxzOp9rQed3Ga8gwUkVAJqXEElXOdLZqYSi3x4xX6yZ6yZuYkGHKn4WYl1rOr1fBq/Rx3aLm+HOr2HLaHkyjGM8B7B6nragwkfRxcNmwLQfVlMN88gqQ7GNHqKdoZOjaD5vmMoz9m5yO/lXn0kU61Dwfz5gYyjHeGnpt9s2vgarqO2YgHcAnmW1mHb3CqVTQAb24XYaQzdKsl5QonZJxinfCraThmE10Ql2K/lW10G51qHydAb24jccQjuvbwG7ez2CJL8F1zDNFIn7sQYnlUieHL74lq/Omt11wUWgsC27sOjI/HCs6ArEjBHgD3kPK4crcCK3fiv3JnyCt/kxMUK6R3V3FS+H9VRbMrMfvFiw/Qo6o5e03biIM41E5z1wH7tv2Kh+s2Lm4HjEdmND+jEodsf57SPMczouugfmOF9xHPxlhVBcvo9bSLpuBhdRq7GLdzKkMOdoFDvwZI8IBv4uw1+Rx1I0QBlF+IWdZAWE9/hM07Tq0Kytf2/uF4HTvonfcb/2aajXnAH9+/e/frj29+SZMQ4NZzLDZldc8FaKeBubPWrX1ri3S+c42drgxrciLSwZMWfoa9PW0JSmt+fM4m3idzjlVGHchE2uOr4d5hwASXcs4jthpenkW7Mh48xJgCOroyHl8dz4AdWSUPop/ZB+6UwLVwk26IGVP/4OAyJs9hTsNHHpNgP6tBDeOf2Q9+n/9FOCgP5lIOz2FNvvE5VtQAflY7CiOfqfuTEwjXwE16IGZDXUBP4fIcttQFMMeeToA/q03FGQz0haeRb56cEuJEkEqKR5aDIrjQfwT5yOCAGqakolKzrC4oRrPynWgXruTjnqkmou1ELFOkFMpcKWYg0XMdmN6bn5JHUdSlX743wkLBWZOzTujjbi02v60V+xekm6OO3EIGDBDvoKl+TU5/FGykXQOHpTOuYQey+SwoBPleF+bEDJzgb7esgOuiYzyHzzdoYork4K95Xa7hs8YYggsliM1vkF38a6UF3kteV0VcGfnrcpOWm3H5QRnGko3BYFbdnA1+bBPoJZf0NyGT3ocTkJ3IYPwiGf731gTWWwmwLpjSi5HjdXkBrK6EqGVEBI1ZiJfr8v+SMZZh9zwwx/za5hjqPdVEwhaPbMz85yKpSO1y23A2MofttLmW+GSmnfxTE7RxIrxJXGDc0jMnU5o+gDlMEw+EarLXulKvX73KRaZSl7CZZqJ8VVJe0+KVhC1I4Bm8cmu8Vza0BJHX6tX/c6mj5r/Scz2F+txr1S8ak1A/hQxoQJH494OJV2kOZlBvoSjp8/XPxz04oGZh4ZI9/fcJlWAEImoVDrzGaGWg2Z480gLPMjGJJDj741/X0xYsCtaxfYCP58ZDsQMUBf5//EH71S1lBeQus+c0BOmsXdc7qRef/tn9r+9TJ0btxaHfAgbWK9J81bdolGzWMmgy6ZzGLN0pbTX8h1sbcyxMrRUUoTO5uK0Nye3KtuoIfmXE5bQyYsGGIyL8z+luJ2FHNdwrwU3NinyNXuxeGeICet3fsdwZQx9Bua6EuHuy4Q3/XZDLN/fdeTkWfeDZ3fbflvH8XrntQK+zMl8XjMNaVPdrpEi0oEqvQUoh75ql2N0zvUgM1N3wq6ikJWiQZ9+6E5J7ofRdr01tAN/dslPrkqo7tk8zS8QP6O+NZ/BI6X5IMqXX/vgpv2uW4buKu+FYDSXTf+3cpMDbj3ulJ6EqFOg1rk7lhuW/B56446/VvTPdA5V6A1TfO1GbjLGuhGKBAOS7oWsvp+7ceG1f3iu7w55qVR5rye6FYRKSYSvOJaEfX3AT+qPgmjJu05QIXg7JHPMoXF07HA/2Trk+DS8a0lrDlpWQr0Wtk0lsJ2P2F7Fnl6EGs7sf39NHMEVVcyJq7aLC2noHuPLIQCkbKNatg9VnISqY7/UmMIhpHtvzVNILbZWLNVIPq35U/RMJ+G5oO6AL3caqocKFkULe/vB3TINJ35v/TLnA/CPTNaCJFqSSpowbccDTQYIV4xzym5L7ZNv8NNpBFw/3BUB65Zsh4CtV4907ZcbafIqUr4/SiE1C+CNxCRc7mw82ZqUNdyQ0M4MQHUwpcrb1CXMV1RokV3Ndji0ImCcz9X2hw/E0lG83jQKzR2PPgMw3HIcmQdeSPwM03zCWnvFlbaMo7drl9iBduy2wJIRu8JLl4vGCgUqoIhzPQMz9Dc5Xum6SzCoJGeSBAkNNzpkLDWsv1ecOKZP4scb8yrRUV1U/LTH63Uf3mAArVhTMRW7baR05ufJDmKyqKpwyOnWWdqDfUaX/YnTVZqsEWzYzlKHnQ6zJH1kKKTl8S3YSqPalBP9/OkU7w1f5y9pnhKaddClRFWRsyyBfhp6bkq/V+y0tbMhNXqfAOxR7CzuKAXQSVF00idUHh7ct/4jri67KWsJJiHXTWhIie8FA/qvAiEFjICAVBhkTBZi4VxiZZji7er84FaIzPHY5n0zne8rzAhSpFY5709W0aL9tJc4d5SqjfE15vhYyB3mlju5bsa827sIc0dURJXC2cl9yH2WUc9EMc9PH+LUOZ6sLyok5C06jNI1VmTdLCnYeXn4TnsCdHXRHp4XT4k5C4FHMcW0jHYVcOhbWbADNWy9tynoTLErUUen5xd3QqjhgQJ45oY+mN11D6aZNwjSUyuMgeS27Bfebsfadyc0HDMkdqcjgGTULhJtyapcllzNKQrQkNMUqkhCNJ9iVC+/FhCSKI1sLl6Dclsdwe/Fzrbvfpt0v06oCKk3sNi2Ks1qEK1MNnOi9UBiLTTUGZfM/aFICWoZxtU4cvk4021FGht+wqiZ2uVeZ4OSRSiZq1X1ayNco7SrDozlXXZxJl42fkoJfGmM01siJ2nBvv84kRBbxk0ZNSCQuvZaRl6H9FGoZYQhrIVEq20Nex88ep3XUlM7qtspBZ+VQkxNZdGXmm4OQD0n0e5fIhM9ZUatwIfQniEWg62i66hOELisRLxVqGbz7niHVSwsU159jYlN9AeNrX1I8GcO8sEkv3wNfuE17kaoAqOKOfqY0rC5yXEYUJgdeLslLqbliO04LyNdmWlDJk8S1UwvIJ4pS+1pjifF1Lg58UFS8MNaZCa8xTVpuw2WX4unKfWlYouyYzB168xYygVJozbZNtfuN7qJGVIXYXbqiwYPjstJqrcV6A5koYW0PkMLFvmb05IbqbP8E9zhBbwHdGWWcaNDfXOHDk44ZbmYmrm+n6LLLOra9mai8WdRj2x6neasFzHHE1yWUWf77CDeVjjLBU6HwEeb1uYwebQZONZHt8aSkaGe8RMdIy1dUKrmQ50SOob4ywLqQXaelSQznpt5uLwiqnYDRn5jZFtQ5tqD7UUeeESVqmYH7JdnAFp916/QIkgSu/bt91NpouEMCu9jUCW4rhpADPeIxpZY0e+iMfPvFNJk3gqeM3iWtwGegd+2g0TLjk/i0qPG9m8EE/aXhl/QzK+uSYKP+FNkBtfVZOsnamTAZ9Nq/bezp2fG7MkcwTBE8sMRzRrarJd0UMMz4pmx9Z3XZZoJjrYZO9cKhzvKwGWea0WKNQ+YaA9jOhb4Zgs24C7CLx0NZxW6IJ6jZi8FtF+Rrv9Z4urCBPdyAlN6q8Ho9oHwTpsTE5jj9BG9at9zK8vuTsQu9ofnoTJAO4ncVqocpxHrh8rWha7bja1fksGfZHs88iYR/1qC0vW6ieW7iN2nhLsv6awkX0RJtmipTxOrUP0w0gTEzmDpjTTKHGeqMrWHO9ToK+wtaXGK/M2lP2l02T+dy55zbl7mK9OiGhueEkSkhB6lGDp6vywQHiqsAbb0kKOLwpEkMdyWhEDRP5jqTGS7dOZFPlYTvtqCz/SecW3eAPgQkNPfNiKMN2bK3Zu5CjzCuBfnw5me0NVbiava0i/Reinq3r2p96dSA1YYGu215t9pSReqAbLXA+yMhj/7CzQXfWMVZvd2D5zujHqfbOWgIX5n2+S3mIjP1VBdpw4aQ4unTxr1J7hLGnq0bITc23e6l9yzjndibX5MtW134TbrTxubYHJS5TX3gFfAFzOAqhq+yJxr+qn/ytOoNhVOdXaiHJKQEV5IxbYoBpgqozPZJSBOhgREzPd/Aps4eQK/h857WKjzXD6r5KcFqnbO7bA/ZAy6+sFKcBDApqiYuDyPSsKIKjkBKVM003RRHUlC5w0kzEzLHI00RMytP1K/rb0Swey5pegxyu6N4pKzAk41z7CoO3sWtXB37ELoQpRZxEoKtdTHZUEew9jx2s9L1o0yCO3t0VQnx37QunDuL7LImxsU/e9iSw2H3np1SAz5wtSGaRqlUFFfQV8V/PsaBbGj2gL3Nc7+Md+9TdZfGM2h5OuhBz460FqoZ2sqOlqZ2DhwPM93JWpoMm5UHbjY2SUj5FwwKI2wMZHs56KD60w6GL9DilPXoTSvfpDvQH9rf/cS34o/fzh02eEKaOs8B+TKVYifrJDaR2/dwamVXM35T7PqZcVu6OB3mVKtnoROm0ii4GxsY5RMltmVS6dSMWE3Lai69Kb3hZXu/bJp0UfVAZcFA6W/bwwt/NdDyacRGWRT01iQK2nDAuvpPZnBgPBeHayDfA8nZ1lUZJhvQB3yrrO0IPJkwbAbwRwzJg8eUd/xfSQh6OJ5iBPlHVw/Yq7vjcUexYKbqOpCzbQ058MHUBLxuahCOGEzEEzb/VItumqyJoHUTnlvVIoFfQf8XaFvZ2RchHmGDaaAlqMkzxphvbqr4MH2d1f5P3VrYzQEkvulQCeb2VHg3aPeTq04KL2rXvNyA2Vm8a44uNdm9sofZCBotWBBqC7YzfezPym67miY9eI0i3AX7V6kBhx010JE0OFT69HN7v/pV0u8YAPL9TdSS06IneL7jKOhu8jAb0cNbKOixcbXUZay0fNwuppLMPHiIHphpTHWqjtiplMSCr8aGd0k/z+1Sv0DJ62D21sRef3s+w1jXhf3Q5akKcQCloww7XBj/wrlssbBdkEsSIrQHmkshysWs7Bxnx6r+4CN37MrCXEB0BgR2NSnozo+Te7C4y5jHe/GMK+NfOdf46EuChI0rnb76GKHyN79T7TyYQehG1M183Z/GTSPNlo250iozTdSpKsVKotH+i0X9Tuqfv+KsRU+fW3atjqKyruU6uN733VYUTWMQRkvqOnDe4aGu2LbWR8KNnYMKL4MX1ZLqImMwBdVTTtPkEKIfm63+BDx4VPsIeOF4i75rW2vG7HAnduBd6/SxUZdH5Pavn9q2PwU9R4uy5g9cHPgtNOhA/sEaf4PVIZgK9Ga6NGfMU9G5BwT/dAs9uvhG9q/pttjAu7kl+pbHFEjlBtNnbqE/19SYzjyiq6vM44kiyW80QD9hQ58mmlR+k8F4CikKRoqiwCuXW2ipb+G+7TELbzBeXWmXIqz5Hmih98dnmA2Mt3XNk/8kW1qoKUCvrsumKQ846YPpHXTHtgkDjfrQOXeI6u4yfB7I6JVeaBvgwQ29E0kLRru48U9F9R5XevKRZZCGfz2ivp9MwkMGvu00CMwdEs8E5n6VBs7cR1B1Mxm8vr008jehFMPbf5O4oUw8tLn1V0S4J3jzMI26CofrRO1vqvLqKpT6FQaB92HFOrCbuvTg/x0KdCf9eFmKIQjt/aFXny+i0YIKosMLeKkxn2mHLxoHIc5X1C/NpbuT69ox9+iVFHmdtYjRnkGGlefhHajk56XTnw7PyX0qvFItD61UT4ZVK5CL40KhTwWGc554PF8zPB1dI3kOxCDWtsreYidNb0+rw3Yv7ty0YjYQmTDl7zoA8LDwTJoLi2KFu2hCcT6FNtgL42dSDs3Z5wM9M4F2c3Pa9FKXmhYh5C78xpU2NYl7QbkuvIfxrKhzUKc63UNREAXKTHTkR8EVy00dQDeXEBF6HZSQT03E2Sdz8ZFjRVPpEus+6+aGK6e6LtOoNhspV9BnT6E1xyiQThxf0Iq6eg5LzSj3kTtxXibyYyAN46nc3tiXFHE4Y1p4kIpr3VuPM/fpdnKqFl9j2laWzgqhRqMI4bOWNPQC1MXe4W8F1XgA7Ss+ZmZMzB3Ce6DVulYYnhsL7pl0NbBIoJPJSvVhP9ifiC4Q9NQMfr/oC8p9z4tjx8QFJ//N2edX7xivO8F4fYVU+Hj8lmKa3FxdzOLaDkZskdgWfTlEk8VFcqYe2vtjXNrSXXggul+bZ7jRxtsfnUoMeU4tkmDSny3PjN+2MpjCUmpdl0FoptkjuAyDVqVJSK+7QmxosS5E9rDUCOiE06LY9u3zLs2pA6JFav0VLpjTWgW+N2IFk+zAWUIbl49lLZq4fMazXl9hxIuvV4+zu88iM9+yqn0nsoc0CbZl/By+N24ysoojwTTBR1rgrIceEQl7c3CboAH9uPlybUpGBRuM9+hMBbWDpGPRpt2cbCCjLk4aH6qPkY/391mfR74z2t+TKcU8wDk5rD/kC7ZjFyM/G6i3Ml2u6tLVfG+7IsV0SbOU5XnvExO6IdVZEGxfDU7CzRQRVIGv3Og5FEevFcrPNYOQB7q/5eZ08DVxM5Cj5Dwx64XXrq7/rQakWxFB7lfdnVt76uicBgwpX8W3mcgVNEv2r2Ps9sYt9pGbAy2Pk3WiY4sdaoxUyDONpCPUnm88zqQ2mdHzjcLJjIY7yRPBUamSqcNsBPObJpHEr9fsygYdAdBsb3wA+TPK/35lVgXNoufPpcjh+4DekX3lz0I7v7cnonbNsGpWGKtO7sqKlKApfmKGcaTe1Il8h8KIlityWJEP5rf/cGkaElQlQSFBtacS8lVb6RAzE3X7iUsyN//SfKdt/gwHUlOp1U1KM7PQtXdZqUVF1F4c0PWGs3vw9+SAteQzVzzBXbW0lfRRTDrccPsMwPRWlYdrLyjwnywI+96aOq0XHqwq6xdBjUhywPz9PRSGBm0KjKMXmkqjVuccsrqsC2pGD1Lt1DJrV67NvHXGZKTVHGiO/zC726La8hLPxkVsSvHD2o6LJOaYYlPo0EzlRbvOdiT7J60THWBY2GFJYR+WFPaPpwnrDCu5mKTDYpI+LCZpCT3VahEl1WoRDdVqEfXU6qm66XkXuai0w6LSPiwq7QK9NZLcsuDFEb44whdH+OIIf7eOsN0VvbjCF1f44gpfXOHv1hXioRBmpHfDFV884YsnfPGEL57w9+UJQ/WPXrzgixd88YIvXvB+vWASEhd+f+Dii9BlghoZf9ZgxrbImX86QUu63bJs1QQ3YsmgDNijD4VgqjlyTaO0RK2/fF7mjtdVzJnE6so1TfuxVr65Xgc0McGNoCQEVlQqxUppPDtPZr/Y6N/76AnSiG6uUr3+CF5Qk8NehJ6TMZGu/ddwXRCBLyeE+WdzxxGGn3i+x2v0j+mbTJQbhg8au4b618rpCL5rpQ32o316kS4+ZAQfo+fuIR1/VuhCOF2+dJyACTX5kjXsAN5Kxf3QmyV07ErUfclabiDeSs++wUv0m4QYoF900ReLucU3p5VgG0/YMQ903ccK5vo1xjGv7Bp6Dkw4trGuUTczTxvobZ13UKZ36Ccx4GmUm3lu5EbU/NPzUULNbGTAB+WOEjJF+uFGjGxjN+ssWxP7RtxsYzfj5l4buhG587eNrsvOedAbsWv8NVOqbtPYGpe4BMMkRBP9uq/CBvm1PbtvCSuqfWVevlWSa3fYEoNyQz7zy3L4HZajvv8SG+xzu6nv75CbMg0s2IU3nQY6NKfMCAvSvO2M0OE5aXJYkOhtJ4cO0UnzRFBwO27HyCYhxjYFNAmRvWB+MIUPbEnNboK+e8nKZCv7jA6HuX94M22G2IRC6p/eSX9heg+S/Me/Y8GZf/vTiuRQgX38R3CXEKHx6R9N8AUapiHTtQSThNAkHQQldx7xcsQzUVasGHmG0/OVgBUNuE7LzRVonx4RfnjzszkWLGFHzbkj+ePPP3y76iS+hTK6g4JHeT0yqWtaXIVWyypIR2x96615trTiM+Eop5JWFeS36CnbkoMfJBnqqXPk+OcHl4/iywrVCp+RMpK/c+1sWYHnxPrkbe2CPUBhyoZvwjZgPonlLzdO0T9rfRS1bJCSyNO2/vPRTlhjPbO1q9D+ZfSILWDS+IKQ97V/TNfhYbKDjwVWkxBVc/S2xspyyiYN983WUtwIUQCdWUXso6wBXxY1R0z4mP7pXGxuC6gv0tVMPy4xFsHjEz/HKHT3vtwa+I5xCNUTGyp1N4L9DVHaOV2bmGfT2FxiUudxDz9LOTTEoWkEBqEf8LXetWY7kEtNnvgyB1OaZcpVpUag/8B2PmIzUXzTpsxMcEd0rSXligZLHg0TmEAiSqTbaGcuYaYOzU4GXWqc1UlX4ABIRR3aMY4O8YmUTotPtbp0B98dcmOM+ribuhzPiJ7hK4yqLREyBbcvtmF+ejPsptVT2MNg8e7nuWwD277MNPCXz2sZiGCuYeBvntUuuqDTJAYzo9kebub3TGsmNdqUC4JH5nxg8ziN+calzq+kn1lZl9HwgknqHgszmNElP1s8lpRZ1qWD+HGZ88WA/7VTxsqvDnCxeTpfRfurZZUzqY/zaf0fc1e047oJRN/3K3jslbqR+glXlfpQ6UqVqj57WRvfWHWMZUjU/H11hgHjLDiOd+Nk87ay4cwwDMOAz2wgVigQQRAp1PafzS+QCyaMvLKs1o7WzTObunRkFVTS/SaktFBsDNXXh7o+vy/Rkm9Q1cZ4udereD3WNTdo1zpP6FIJidJFAiV1/H52jIrX+k3yTM8yQd0pPwScEOntZiUg3TyNjxmtihoLsyDM2SQVSSzPQf5XIMVQPNXQ+PUMyBYsZ3V7NPv1wG9WNvUXbcnn0WFQNgQ3z8dzCQ5VxrfDht6WQzt35YbQzl2ZhPaSwjbWuC7IGHLs2SnXfFs2nzOfgXmcUoRWs5OmSMJq4r7cOd5C+leCp1VP+T2RJ/K5IyZa88w4P358/+v02yczH/k5OTt2S8aNGWx9zVW+g5XnvgmZzb006RYdWCrMz9M1KDUrIG25dod7SJg6toqp8MUvB/PNCR+dbbClKENsu8mGTe/zD0jREUGi8Pb77cKYfG7aMhmm7NLEp+9Gt0fLnM/gxUrwQIs3Noc32pO9yZOCaRUH85YjVGWeZPGurFVDIIeG2S5jh6Ye7jc+3IFjdnJ69Zr0OsR1QadeoU6qm6nMiqPDO0INmfKIQXW0pl9HSwIORhxcYbJZq/W/EBPnaTgUnxetqJuuMftkkJ2t07BQwO/CJst0e2TVpTwj1JcU3jHx/5IC+nmHPnbwOihcsp3cksb04Fum8Mw78WcOj5hUVHTeve+VHBwPHjuFWRf/ocWpy6e5Cl8SYRaqQ0qqunU5cOWn72XhkdcfE3/jptDqWPPxsXD2nqV/lsab6nY3lmj2hpNsd1kx+bVtTiJ9GmF62hVL/VHiZLPQwlqJ0ZsqrKZP3WkrYZ5AdtpWe4nc8CcbpWYI9KVVrNVHqQ+gbkzUDvoqJUQ9CHJpIbkEYdI2P4sXE7NougKvqhTl+JdWlQJS7tQtPLqkRF+Fqx6tCpWzA9Ek2Bk/4sfvd26mGett01tNV9ENHyFD2NzpSoljhwIVUuyVPJ1FPk3VaqbkLREQwpvWxwFnsaJq5M9Om8bkFark0J6LzfwdFr8gJX+tBe5n1rF4V7VmKntT7lV1bDO5rpX2Pl+jNbVc3l5TPt4OjWujN6aLle6aszvJodFHI/q9xFZK136O4KWmm3cAyRbzSsqvi7EKq0zsd9VaFmryo/fEoNGdYr5e5e2Ht1JMlI+nTPKL0vHvH79DIEGx5vjAfVLORQlN88c16Z6lDYPZzaqmH1RftPpn8X5ENeyH6MkF90AiB47u+Sj0iov1v78Psm3V4CusBe/EWxtvZ3HYN6uUsOT6nh+nFVniJll7DnvKi2VH/NFkT1KFMGdjFX28rCpsMCtRIS+OYxOrhloibEXGSNa1Ku0KBcUxycN0FKkmCjdkbVX+mlH8BxXeTVWDOsi+6IfmJK0q8PHYAzVFYHroqtT9+VV3r9Cdv7DF97yyzQK82X35bOOV/IFqqfXAy49ZBHUayz0H8LlQL9twJgR8+X8AZQECnw=="
 }
@@ -32,5 +32,5 @@ func init() {
 // AssetMysql returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/mysql.
 func AssetMysql() string {
-	return "eJzkXc1z2ziyv+ev6JrLZKoc7Vz2sKl6W+X1OLuuip2s7ey8d+K0yJaIMQgwAChZ89e/QoNfkkiJ+qCS3dUlsUQBv270NxrQO3ih1XvIVvarfAPghJP0Hn64Xz398+MPbwASsrERuRNavYe/vgEA4M/AklmQAevQFRYyckbEFmItJcWOEpgZnYVHJ28AbKqNi2KtZmL+HmYoLb0BMCQJLb2HOb4BmAmSiX3Pc7wDhRk1uPzLrXL/qNFFXr7TAc6/fuNv/QaxVg6FsuBSqhG6FB0syRDoqf90DWo9xNeCzGpS/tkG1gY3R0kGo8CC+tMuoP5VEzslh633e4hgQtZmGE7QdMVPlGvjV4vpAa3g7zzipDXNJnVtCjHP5Wrtkz7q9lDiX9d+sApUmHWy8VAXljYerTVtfVhBSnQxlV0f78HlX//QS9AzR4pJFkGwjZfjpRGO3llygRlCzUEX7p2evdMmIQNvczQoJUnxB/oZgGYzEQtS8eqnhrxdFMmRKWooWKIFq8FKvQSnA0Gl/DTPCJdCKuap5wF9VfpHG6QrMCYBQuMZtLl04fUvlAVZiKW2ZPwcP4OhWfgvwtwQOjIwx9xrwZJIBTCoEpihbeGwA3i3FCrRy1G4d70gg3OCRFiHKqYaLnPGOkYs9dL/N9YqLowh5eSq5hKzroeGCn9Mxp1LuW7IODETcZDBk5QsodxGFeHfnLvMSFh4uQqiGqOCKUGurRXTFseFgkoV4W2uHSknUEJCc0MEegYbijpEO4VK6DWy4o9+Pkit5sdx4TklUEU2JePRkXJGkPVkeNsdr60n4xiE15FZ4DgWpVq1BrMzqCzG/ksWDMUkFt5ipkISYPtTMJRLTwz16XWtE7KwjszZ1CIMd5pC+LAlEskYIqAdyhZDS+ohI/+OTUUOcYpqThZSzHNSlAyQgpHk9SYYuRbcEmYtswH9EISbIdM6xhdaLbXpYvgAmE9hqb14psLWLI11lmtFyk3g2ZsRYa9gmZLzfs6DVzohENZbCee/jPD58e7++vH/QBt4+PQQVX82A+2RZJ1l4nz2nUf7/qOnNa338UZgg4+kdMGM5bDpdO9+vBwf7dsbUgZ5d60Up0KdItAt4nvQ381YVIMzFBY+ffhw1QhvihaUdrAi10zOgZdaBXWgbW3YEiLvl4SFDFfeyybe62rIhA25W2HYIU3gJqX4hYckY7QBqecw0wZyo3MykAicK22diPcZfFps2oGjdQRuFxY+HKUatBDxpq7uW6whiADgo7AuZGxfvtz98iNbJpSS18yGiasctNOIrhMYng7fjVH59Tb0u163wFAoJySsdAGGOJHxnwoT0unEL1JM1vY6Y9gw1f1W4zRLnepl4AzHLQplmK0S1tt/PcFno52OtdwjRTOpl1HsNgOfo0Xpg89KbrRyRssjrW2Ohd1SfjiLvfWR48yURtYzS2QEVnhb5vkmfSb14eOXp3/A0/P185cntlzeqnEAXcVilYUOQCtV95zkQoNpM739ulOg2W36lbdXkOolZEWchpqDxIVHMPf2yed2PmFO9PLQCCGAilR/kHBa4O048gqMy31UI7zzKlkRpDAjtIUJmYVCpS3FWiVDdMZQvBgB9yO5wpTVnyYI+3ATfb7+8nQLtPD2fN0fVEH5FQgVyyLxq+FSbWn9MbsWzrRfX5QULwSZtnXwsUAjcCrJBt8T68JrL1t/jri0Ikg0BWdkyJLz0MwqcJuNUhHEYa3KtssGkeo3zhfl53fIqIpJXuujOkTpNIQdrNrDJq8qlr4W5G1L4NGVD4g5ALqqDDUbnCY6aoWA+zDreCtZPdpmP/hFsjnFPm8+T+Y3nUU41caNYoU2cj/mxXpK3ZR2GUWo7AYLu/ZcyLiFAnqluNjBd9ioPkUzFLIw9C3p8xAo2Sh4OLJ9+RVs5VjfCH0t8Kd6hC5ZHwhzXeb9LD2SDjukvQ30a0FFV1QC+3g6EDC0CglvhfIZmENFurA/gSQ1d2llVJgYhrODv1vQI1z0odsTdx1AwGMNrcKMIadMQC/I1GW4ITFZtzeBVi1dKysSMjiVK5Bo5lywQAU/T372MYoKalS7qTIrCMX9pp4OaLnE3jsdsqtbARpqank+aFwKKWFOioyPihCk5jy+HUa61GjnpFDzg9Yqw9eRRc37rwxfRVZkveJ12CoNIUuoS5Al1JhkNZYrl7i6hIndrBfjylZJCdpVFvY7vSF+gblBVUg0wg0MH/vzsLMZXx8a/scYX8+y79T4PtXQuo3v0GT4NMMrVML7GT1m0MfIitxSmxd+t5ineeFAWHsgR7+liWyE4D/KRJ6NrAuUyO6qwljo26izmg9P92WVItjPPXmWIUy6+ziOqkP/2to3KfuAhA2TsLWOY8odJ61i74ZfFWqcKwv8lSOeJ3LVyKfu/RWj1B62vF8rUqujLy4Kh1obV3wHyOJ05UbM5qz4g04E244rzrXmjy0bfFrrAzqMLsNCP1WzLT7EzLzQRcIwP83hwC7EtcPBsUReCF2Q/qlwB2Icy8jstDEVPnjLkavTbeUd0hzzDUzNNk/r/QIyM22yjZalbouyq+OyNU6r37LqtTQksdxU9b6v9XBoGQitsSGyXgPS1/y5q/ky1J+5+ZMy/99dRvGRYm0SC/XTVfnaFlmGRvxRFg/jlDLktD4Rc9oqB/Sb4H2m88cMXyc+9jaTJQr340mCcV/Go36kENBXsVtDTV2eR8c1C//YdpRVw/MB3sQSqX5kSXcYt4bs2YNBB8tUlDtegY+8k8bFf+8QuV3AT9aP52uByglJk7/8+TRe+UD3L392qZdGP7OQDbdqYfBiq+LVFXfJibjay+p3hF3C0eBy9Nr14Rquzy3lKMWuZ9SaJ2wHJ9ahOY0lD7XF25KX/slxMT+b/FYtJmeU38BC/0c/tIGpxlNYDT/WpNPyOJxKioSOPP4j2zMqg8Rjwd2fPjEzrDdC3M14oHnR099pR4dGXxVnSNUlcHZnOtrP2C26W8zdOWvvA0fM+cw87hxxrZN10jPpGRLVB2yEnOcqN6/QAm+pL1MKW5nr8hC0gB8zLDCUTOrGu6rGUipJ/c1OADwLO2UjMjSllE3g4cvHj1wm3xwlfEPp6sE7Zck4y7oYtmoTwLl3/w7uHn65/d/o4fr+Fv6HR9wdyk1m5OL0TCYMpQQeD3ROof3Kwl/h560QiDeJ9wY/xxxD4ZF/q6IgcgEPWYgL63RWZfpNE1Nhq5b/9Yho17mT3t7Qw4O4M52XmXSxbT40fCu3bM+V416XO8CnVTOk6HSC525mL9sP+zaxKcaibEEJiCAR3kYIl+rC8emR0KhCrZHK1kI5pOJffmt8QsvNa3SOstx7c13NXeUI7dNywzpGhd4MG48Umr1Nj8Zo08+jo3dGhjjdnMhEmCSGbBcCOLgSvHGagkkLsqdjbuGtDiZYQhOnXr5m2tQ8V/NKFO8+Q4mLOjdyWhrOdc5LoU8KwyhRSttUWd/+BDo4VimsI+UfybXpaVJaOyeiOs+JHI59dw2+2ygYmrWbDuv+z5Lysp01aM0V2CJOw/41d6v4b4SuVgRFXS3iJRmpIUxAG0BVHdrLKNNm5bEkYr1RpotP59p92c+Itk0sS+oZvkbtR6XIRBUqYZzSjh2s0yl3cb402KX+o1C/KkV46if1/xpc97/bCC3J7ozgcIBDJXiQXgZcXi8N5FrKwzTUv95+KOW8OsNSB35Nu6CimKxFI+SqjG6xMmAtlxmE5Xc+CTD5aY/38TJ1Gcdjbf8JtUFe5+nJu1RrPY2MG1KfW6JKIBN9hnuIW0q7u8ngRJnvxrvH9jAhFwHTz7O9h8qOA7MNwQ+/DWCtIPFtAhWdk4q6dGMYhkE49mGBYRIKQ9ZiC9C6cLAe+cDI0x34bsslklq/FHmPmMBQyT0HyDDBqTD1gsxM6uV5kfb7Ev9q0nonMrJXgDNHBrAsSwRb71NSH5pKbSm58oadCauSaG5ERwWFYt9JypkVhNMdfTIaXtWWimsPJuxab0so/TVCD3/aeiuqvmt3O5SpUFJv8m0kj8LAJomwL1Fhz3X4dc9sI020a3vt6OrB3/xgXDs4uGbQ06DBX9UmQzfiTuCa1vMsG/0GKGVV1BjpHMk+Go8ko9pz3U9A7QM5pj+bRDyH4Y6TCVaAUQ7Er6cKPE1F+ZC6D18wcgFgYZ5DkPWcBh4BW3P0dzA6Uyifl4yOrZxnD7Lteli31A8/ztQ9fKewjOSgXJYH9xRilbE9lJ9uJi400XgkNVfSSFxdaqn2lEXPxDjB201Rt1U/+2zcRTPqEs1kYdOobJodRV8zfI34UPDIdsFHupeRtItoqHWGMBvfEIxuBEJm1G1Az+MQgvBeZu0TkrSja/xcHveX24+3z7f1Dm1oeOfieZEPulDKbl9Sdn6Udw9Pt4/PR6PsLQWfF+XT7cfbm+NRFnlPg9l5UX75/Mv1gSve7iAQJ+tWN6ymjbE8pdzadw+bo+HimerQfHlhAdnOby6FS4UC67Qhvs9kbjCzV1CEU/h+1H8WZMMuQzXkBO5cU0HnTg24+XQffb57+Dtow/9/er5+vnt6vrupD4Dsi1K/VvN8U7bV3NKqvGSz/FaVaLY2lqarKuXkspFnxvE8ZiFrOLy+hj3MvtpgdfX3/XP0+fH28/Xjbeudm4+fnm6vmvW5f44eb59un4euT4oqkee6UW7/cZGOS792SsMAidiWinq/9ObT/f3dc2v5BtihC3keLnCWN87opYUUFwRTIlUCqO48Ync+ADa9BpojqeOXkdBXh5lUbEptcGvyPNMGCOOUd/t445oVpgXs7U8wKxQHp1dlk3A4Oi3lKuwZWihPa09pLkI67LWQVMLdXnFMNuzvVzXhrd6pXhZlxkRCXUD+qrWtOVZYsoBsK3BOQGouFP1oQS8V3BfSiXePqOYEj4QJiCyXzN6wp8n3oTCpgfghtwMZytGMIcPXG9e0UDUX5CnaUD5f6nfhj6Dtrb68QeepcYTDyEN2smbC9B7/H2e/vBETnrvcqhAKsLzStOpmGHp49IU2TzGOS4Ah9u6sj9xRgd6WwRQtJaAVoEc0ELvsv3zhQuDrc64vtLYKAwlQ3U3/FybAo+BFEIrp2HedJKybja5bWC5MgUchdGGPpcJ03mZwQSK2lGAmXn2EqO1GY9E+MqLvT6T4RA865NrMEHOupZziaAFJB3LvlzY9rSenPIzGqxMwNY5pSA6NC8q1GOcc4oGUSIzJf1JBOgR+9P2siEcCvBAczh1CzoVqBW11CFOWys23IXJQNuSmXiMujDWUpYZibYpZSifTMRPBxip0dJXsCvH2cKM/vGuHld2NLHsM6Y42G07bwi6xUE7DnVL6l79tZWzbYMLydO51noynGvtASGXOOQaiKp09DFBQt1EAlUMPAFT3mhSzGZko7/oxklHFNimyfPsHdI7jRF8M8Mwxl54bn1j7FFu1Gmo5Doi1KUMz5Kv2SSZVLFCyLzAIPIOuwBkxn5NpV+IcnyDgyoy3MVGLoRHTiC6yaeH4Jlxteh9TerkzfGouzsTkW/NtiSaDIu9hk+eNtx2eL2yhLbnwKxxzvlvJGLK5VuFGWu0HL39YBthHMv9FRgdzmznjuR0q3j3M5qeGMruv9Wd/7+cAPu/u+2xfTNIb4g5oUhzcotjcjbzZsdSrENUxO77pGR3uif/bh8yN68vnx6Iq3voRi0BdpfOMqRTSXooHSQ2P8V8kNQ1H93Dv30RetiXldNmAzYaJngBgDHq6I2lGUdJUH989hSpD/U3I45Hkpz3bykh0Pe2NY5NRznw2SjJh4+/QANR3XkwLu2offVu1dolQSh0uKOL8OsmEEtb58GNB3LufEibN2UCfCUodv1go6/aYYM7PpmjTQYXOVjOL9xUX9kzt7vyuqJN/32y+c6ts7y7DBX0Ppn0Q4AKyx7U9zn/6o9NwXS4m7xgqF2o8X1TVODtYWvj7UflLLv/WJLcO69tiGi7+d3JV/0pNdUI/xYX33kFPedMuhOe7r3zs41x/QXtMrv1gUCU6+6HFEG+zhBNYNlkEHg4mpnKr38Jv6LmIUQYZqHAMi1K7f3/0goaiv3IFF+NZ2adQKk2sC1m2xaATdrZqIqM1i4wqgRSTei8hEYZiry78eCLsywGy483/rox+DGbY+qfytmsi7I56LEmyUph55snVRn5e5ebhiNlW2s2D5mgwI0emPc5gTi1RuOhiUeaDNlkgM3RQQ795DT/QWEU9jWmdwK8pqeobiihpdp+0KU8h8G8Pzansx4JYEpbunuMkXKCQOJV0VY0TsgcLVme0lpiEnXa+tx+rs5zhwHp7fWc+mErLdq2q44H/tXXDSfkbimTDPWbD7AmzKdppCs9Vm9q419JCosO+S1/Q/P8BAAD//70E77g="
+	return "eJzcfU1z2zjy912fAjWXyVQ52rnsYafq2SqPo8y4KraztrP77EkDkS0RGxJgANCK9tP/q/FCQiIhUi+UPTtO1cQhif51o9FoNLqB9+QrbH4hxUZ9yyeEaKZz+IX8cLd5+senHyaEpKASyUrNBP+F/H1CCCHmGVEgX0ASpamuFClAS5Yokog8h0RDSpZSFPbV6YQQlQmp54ngS7b6hSxprmBCiIQcqIJfyIpOCFkyyFP1i6HxnnBaQIMLf/SmxFelqEr3Lx3g8M8f5qs/SCK4powrojOoEeqMarIGCUQs8OkW1LqJbxXIzdT9GgILwa1oDpLOrQjqp11ACQmYXYCmwb9HmMA/f2xRGM7QYmPecH2DvWX4IYKT30yLnrMu7kIOaVnmm60nMe56OME/19iYVxhLNcQRwxLiEUJA66GHlIpqkXc97sGFf34XayKWGrhhmVnFlqjHa8k0vFegzZMN4ysiKv1eLN8LmYIk70oqaZ5Dzv5LcZQQWC5ZwoAnm58a9vZxlE+2npydo4aDNVVECaJysSZaWIac/jTvMJ2RjK0ylAF84+JHZYcLvs0gJUBlzkDudp39+SfNK1AkyYUCiTR+JhKW9q+UrCRQDZKsaEkWoNcA3IKhPCVLqgIcgWpEZbdmPBXrUaR3/QKSroCkTGnKE6jhGskobRDnYg1K46hMKimB63xTS8mILsKDx5+A1JMu5EcMrhuQmi1ZYnXwpEGWQqnmnvFXl64RJHlBvbKqmlBOFkBKoRRbBBJnnPihSN6VQgPXjOYkhZUEIGJJdgbqkNHJeArf54r9Ny6HXPDVcVJ4zoDwqliARHTAtWSgCOPGdidb/WlwDMKrQb7QfNReazBrSbmiCX6kiIQE2AtazIzlQGj4lEgoc2QGYuPac5DkldIgJ13gjxkWtrnTBkQi+HLO0qhQT1ABoWkeCNRxTwpAEauMlSTJKF+BIhktS+CQRuQX4h1JX2+skQvgOpi1zlr0QxDuukz+P6ulX2GzFjI9DuaTaduoZ8aUB0USUZSCA9dT8oxmhKkrss5A4zyH4LlIgTCFVkLjx5R8fry9u378NxGS3D/cz/2vTUM1+UkXj4koCnY++25ae/ve09aoR3/DigE9KVEZwRq3KSK7A2b34/X46Lm9YWXQ7C44N0uhSRf8bhXvQX+LOg1uMmSKPHz8eNUob0YV4UKTDeiGuHG8+AYFrzNoj4aWEuG8xBQp6AZn2RRnXUEKptAIslUlzYQ0JTcZJF8NbZBSSJKLFVkKSUopSpAkZXTFhdIsCQh0igle1LnGCJm9KPLxqKEBLyzZHat9nTUEESHkE1PaLkG/fLn98KPCrqB5bvpMWcJ+DdppRMOfmXvbfptQjv0t4T9i2wKTimuWk42oiASzkMGnTNrldIqdlIBS0ck4lAxaHBhHMk+ZWFvJMK5Bcpob+wZeWWf/fCKfpdAiEXkEqUe5zMV6nuj8XKr0EVclN4JrKXKnPIeqVEkrBWlUcqfYW/Qcl9IZWRQWK4Aohp40SjPHldTHT1+efidPz9fPX55w8ivQpzYOtPfFvIW2QP1QR0mi+dAyFHr43y0nwkybOEerK5KJNSmqJDN9pnL6gghWOJfi2g4XzKlYH+ohWFBzrkaYAFB82nheVnAlcI0+DPWisFpYAFWVtCsLTrlQkAge6kEUvITkZQTcj6Ar6aI/jRP28Wb++frL04zAC9rz7fnAO+VXhPEkr1LsDZ0JBduvqS13Jvz5wnP2FUghcClmnY8XKhld5KDs3JOICkevsf7G4xIcSCrATkYSMPAALxh+MtI2Rqmy6rAVZdsjT4T3NuT5BgXlhYSjfl67KJOBouoREw4VBd8qQNtide4KHWLjAF15Q42kA0cvcAH7MIuEns1m32MnqRISXDefZ+W3WM7pQkitLrD2M7II3ecwtGtQ2MiutbBb79kVN+MEvkNS7ZF7yBtGGOZLyvJKwmvyhxAg3Ql4aFDBOIvzYHTuldDXCn/qjNCl6wNhbus8zjsRTd+n7SHQbxVUXV5Jr0wHAg4DCe8YxxWYphxEpX4iOfCVzrxRMcwYOHvk24I+py8xdD1+1wEMPNbQPGZq15QpEbgp5sNwQ3yyro7ajqULrlgKkmKwM6dyZQIWlJOfpz+TAihOpVQ305RbFWAgehPE0wlVJsQeJUfR3sCGUAlNLA+dxjXLc7ICDhK9IkpyYdbxoRupMym0zhlfHdRXBf2+t69OVzWcvwr6nRVVEVWvw3ppCFuMX4Itxsdky7OE3Uw3lzCxgXH1VP2ihKpNYTdw0WX4SlaS8iqnkumB7mM6vvFF1/B/xviiyN6o8X2qoXUb36GL4dMML+MpGj+ImEH0kTnotZAYJJOiWmVlhUFnVcGfxkQ2SnCiLXlbJvJsbF0gRHbrA2M2b6Ne1Xx8unNRCms/Iyg9Qgk03UyGo+tB9q9g38TlATGMNdB0Y6x1kkCpUcS4o9kLzboanei6jHMfNuPxPIH2LZ+2AjSL9tbTHjXtgdg5+wWemheJDQrbWBuGamKiDPEuNnrE1RzuLJ4I1gNFuznpwnhEnz8GNvik3k6ppvPLiBBJNdviQ8zMV7iIG4ZkDgd2IakdDs4MnwuhM7TIgukDMY5lZPbaGI+PvDOeqxbh4B2SHPMKpqYtUw+GcS7SxaTPmByThGmbjmRfOp/ilnPx4VeitMAwAAG+YhyuzJRorPhWi7f3Hx8e766fbx/u5083v8/urqe39/cPH36d382eH29vnswO9NPvD/8is/vfbu9nxD52nsl0st+g1f1TLZcg56UQ+RSlOe3qsGhnLYUsqO7u5Z5OfHJdh86WBUEMiF6UJV2BmpqtmaEwe6DsjgBD4QRsaLXPBO2+F5TXONy0QcKDQTKpN2dHaVo9WYBLCXB2aNjoycgwx+HsyGKgCM0xoowJGrgdRFNcqiiN2RUv7aUEBhUzoOkVMXtMUqxNGEQZO4Hs0pSW+CVu5mW72YIxts3IPkijT7AJv250tyAO1nEH+xAdPwfuU9Ufp4K5xK00pdVQ4IP1LBcrhhsUSIV4KsNAjQvGbfIkospTsyG8AKKoZmrJ/JqhEWWrTRTtldHyjKa4tKwnVMwd/trPoPEexhO7aV6RFLe8tThcKdaU6fkoJhGjYIrQxjSStUsPoy+U5bh/79M2A8Qo6lajzrdxPYCQzV4/NqqwTxZAlnmlMki7GZZiPUdzNXWZdHNs4vx90VjFJmMPKaGaCdkDbRxIvhM8NC/DBQxHhm1Mi3Nhe95Ne6HJt4pJ9DBq+WGuCClYnrNWxkscIX1ZnQ+lz9A0OE1ACUFCIMmjMBb0+/kw3rmQ6ckY8dVpCjTFv5xfB+uW91BHHkQ1wgDA1olvvRtAWckVTDOGS6fNPGdKT2249uxgKp4Kk6dacVyhoy00xFPMmLUpOuZ3zF7bhzUt8nkKOd1MK3UmkB+wubqSRQvy4e4TwURa6hIlcOZLqwS2UaKGtdoqWCLbuWohG7lYTXPFvT0+Ew9+YwklvJMytB+GmzzOBONTmzypSpTgOmMuSVGC0wSm/NSFL8RdilpimPVcCsb1iGidd2k2bRuC3cCC53R1uRV+7RXXkkRPSAPf3VE5AH9Bv8+RB6o2PLkYJzc1AIIzjnFIG0UJXSPr72B2RLfCEmKQZ1JwUal8M5jhPy2/EXY9qyVIA3S7irA7LrcH9R9BO0EQzgfgJOTU1TlgjwUv4+xHXbU6461yxlgocF9IzaaEmnpsKPCv+4T/CAkmSJP6bZ9RqqqioJL91+XzJRkUFD1vkrIVtDJ0ugUWQxqi/REdHpx95RTdzR9bL+5RsRY33t/BlsyU7lfBATd1xizVRnHwtTQODy3cVAHwOLK0e2d1C9kzgqE6UGMrR7PiMfm4uKFmKniQWBzPt4pi3QRM//bX02SFe89/+6vOSAkSKbO8kVatDKi2PNngHE5KlvgpOwqvUzkaXBq+6z5cn4PB4dQu0motE7M1McUaktNE0rhhLX2JE8dFxbn0168pzqi/VoT4y4+n7v4/maYM393zhsYV+5yJPYvnLhPRaZBMW+T2Lw9mFarQCJmQ4YHmRSz+A3uKprrg7Gs2bNpKtvOVfsHuE+5eqtEXjqD5bGTc2aInZ2Q+jRDtI7hnwvQ/97RRckPL5ZNTRbC2BqtfbQBoWx/sqDTGUxqFgXRa18L6tCc3SOovOwEYKkiglKyg0mnZlNx/+fTJZK7utmJxceFfvOUKpLZOlzFEmJG6wulfk9v7D7P/P7+/vpuR/2dajMrZfDldgk6yqKAPM2FYvmfaC9dofyc/T3ZJYwrMZtI3Mo7ZlDQt/+G3IUFbPJi1ViktCp9800RYK3cgx65HNJ3Eh6Znw2+8MsFHYSZof2ebNXji83i82TZ5R6YEZdcghh9h+TyH3G6VuE992br9dOt1w80+kXSIZetZTCwHZrE42B6xo3Rlu9PsDD/OPn+6vbnu2BOOwQ6hu/Zbz89ueGSbqSsCRak3rg4KSApLWuXaP55GQStRyQSmmVB6HOC/o7PogDtifWBKIeNghliVbiSfhTwUSVWxdByxYN3ygWBsVuA4h2Y8mbbJcExMTMesYg5LlkN1xwlVZ7hjdUX+jUWA9wLTgm9saf5WrUQHYlNWP7UhoRGEeN+ONRmK+xkZgLgApehqJFnf2cbPB1t9y19FN3AGDnQD9eI+OFCiC+YbUogG/RDIb0cjhuK20X0FSRTz8XK+cedoYMU9ktnBSRaQMZfVYe2aCRB0xvN3YUsobCbFfEwGniwUksOynqK6OLlqFhiN3AnbXbvaH1x44CYk+gRMx9nErZlx2Aq9Lzy+YJeZoBPILVZyuKptdADcYqqzXZ21akRMWgSuaUwLFed754JSKIbApyi/6ZLlI42kXxnHNRpu3yzDgJVRQrIA7B6E4E+XHGJet7GXQo3QcZ8djTqdYS8fu1tBsbWrU1es+R/IItZev1r3uEUT9lFjBs06HmFV7QMB7M9iszM+h/L5FrsyPL810pVeGAPYxH2FzYj9+YjtN6wF4wtXqh7oUT1koV+mi+Q2Gx19Esi/s8X+PqGVFnPPXUcblqeFEDlQfhxbzxJP1FqS355vPxh67z097BZmI2ZxhCvN0qnEWAy0S5nOpDBY0iQswqDqZrEJZ5gehF7UFwC4q8DDAJbA8Yia6VgFEfddxcahNCttpvUaPZ6lZkXc2d4wrla0VFNpjnEclSdLosVdwZQyWsxd4Mx0k2exs02jZEO4unBH7WelC3YNGQN7cxv5O1fA7g5qkBm0w4guWrfzbB4iUZ0Jl7G3zb6z275Rbzbi9xvy1xXMnEaxXSJwZUUYx3CZqGIfiguEE/sgXCocYnG4koaH+0+397Mr8ji7efjn7PH2/jcMiXy5f5xd3/x+/eunWRyvFKO5aCJvofUbYUKSp9nNw/2H68d/x7G9gFT7fJaT4Nl9HEdiG2ccUdeJW+f0oXxQ3+Kw+3t4nKjgTAu5z4cKDf00dhLGSPNKGH7Ag05zlmiSgsY4rTFg0EqgjUI3KW2QXg68I7gFfjBY//7l4BqVyPAMM/S0Sqr8LnFb8J3NHtQZUqzV/IXmLKWa8dWoXCKt7uPbscZqQZXZhN3up84ma/6HMgmF0DB1N1fMLzx2aue59naMz1KPKVPv0Nmgy3c+hsv09flr9HjRlVV2OIPGNE/xAGWhLsmgkGzFuEmiFDw03hiI8LXhsRVQa8O8l0Ep8nxBk69vhEGEgys/mnwli92KQgcrg10+owfZdy8m9mD/40yX+0y7cjBWdLJ/heD5cOdLTrr644iV0bU7rvKkwziSnHWmB56uJ9s3b7iz0mMnbkKChwK744sREUmxdHHNdIYn2uNVNz7s2rTkzkEP06LjfNqvxmfUnbRJtcYEDFO752j77Onwaq8I8h3U7Vn6SKXp0wazlxiXURe9QUKK0Q1plwByTtNUgupC0NtTLSDb/WJZs7onElOV429RUUBlkgWuqUsd8Kp4+5k4XOGBO1082EOZLoU+rUxNYULzXDVHQr37yRtgrPMC3PcyK979yP3h8GfB3qUCfUZBwjI8Ib0+rN5xXgejcNRcEVUlmT1s0xyti19g+rQmlHDous/CsWGj5HgYAPc3jBVQCLlBA5Gybn8wlNO5jorrF0RoE935X1jMEr6aM7yuxCaR0qRVG9yxP3MC5zop15KWl+J+41R4gURJzhaSbs+/bYQK8u5c6fE0eNC4tLhwXGLVT54fNkLx591Hp+f+wp06JbY525wDXjdBJcs3Lu+XegPWCNgpC+aUQzr9qSY46ZJngjo16RLj2ScepfLTZp2nJ5xSFQaCLG6SYf0petcFixnuIdNS1q4EOE6lthWnG+90LxTLyEXAxGXWewPWcWDaEMxpSpMYcROfP01l/n6kRogS+LxrbAzDMAhHH5aBGjqoL3qUA5s34RTk2+2LuC7KhfhalWraC3Gv5p4DpCVwKkw8AgivZjkv0lj/tpbTeJDFFaFLvL+CuoINvMHCXEmHkwcug/CaEeoY8+UFJu2CclJx40LhLYcbf2zRXuL+/DcdNsbU1kG8Bse8UXryl9Y/zf23jeAmXfJdMJ6L1WToaNkrxdjo8LQMsClWm88rBZMDOzJCuofaSIQ8kXj58sHS89XlGDtQ0wNF64N1rRf2F00fK4vwv+1Rb6jsxA6xbMgFNRq+oqwcc+lNH49HsuGDgP0MePAuy3HShf0IjXh2SZNH6YQZbunoPW7JeM4j8tnCZW5DvgAwS+cQZJGrC0fA1txTOBidy5gdHZuj04NsR2YYRZwMRBVB1N18p7IcOaD6RowuSjs9mdlUnUnSe8kt2YUIjceSp2KS4SF9E2HRMwmOmULcuRsJY1Ozp+qNQcVTMCe4zN0J/2oykMohFDAehh5vGBQbgw46v53tnl3TLjJClZZAi9HJjG8EsF8g7TagUSKHEHDK29n02fs+hRz0uRYQ8Rn3w+zT7HlW167bS0dM8Dzce42itFZqdJS390+zx+ejUUZDwedF+TT7NLs5HmVVRo7eOS/KL58/XB/Y4x6h+2YyEF4PtG1Y9Sk97UR2tzlqb8n2N3y621VBdX6JW8VYPmYS4HBneCVpoa5IZe9WxVb/gefT4hxRN2mqzOoIusmzJjcPd/PPLjcS/461/7dPz7c39W01fV7qN0/nVcVWS0vwfBN+FWSbuC/we7fkNGEj3E44XsZGyRoJb/dhRNhXO6L2v989zz8/zj5fP86Cf7n59PA0u2r65+55/jh7mj0P7Z+M8jQHeRmrbi+wbD3eow0DNKKtFfV+6c3D3d3tc9B9EWG8wsyDJz25PWyTxlfndTkAPvtrJ/U+Chu+W57N6bMjoXcDhPFEutGgt/QZI85Ak8zs9uGowmj5FrB3P5FlxY1zeuWKtuw9j3m+sXuGirirJRewYnY5jKMQb9DC0/US3NfDpuuYsIu2DhBRIeWccaYv1re1xCqFBxvuXNaBF/OvObmrcs3eP2IlDXnEDXlWlLkRr93TRKFaVjGhQg3RhVJCSeUYOny9c6c0eFqkzDDdFJlfi/f2FzvagxOLBkDH5V4Ud5c9GgQ8ZpdCyksmO+sxesU2UHT71MTQdlsVeC08d8dnuWyGIJVhLwtfYXNRBvwp9/U5+fYoakw8TnFXn2I1xEDsOb2w9Fvg6zLmr7DVCwMZ4PD9DTCAKEwnMI7CJ3iaWiyxbpeDUsLL63OAKJio1LFcSJ6+LhOtQbBk3yFtiqXrdvvYmL89lcLfsbbAlJDv4aTmYrzU6whynC53Z1rUL3dMrznR32JqJqYBjCj6Al3HcL8GJzlN8NT/GtIh8Odvp0cQic2C1+JAdi4UK6hZ0MKFJ9zgxtnB+p8DwJrg8kWx2rDUUKzRC+32O15HLAQ9JdmVVbLPxeuRRty9qylGdhF6emGH8o7EcdmG05YijGvhb63pLpYOwdjugXQMPL7tAyG5NecYiPxy9jBAdriNIiLX9ABAHRcoXVZt06oo562Kn+Mk0abfmBETvcKFNS6xeTMxGjNtjuS1rpk5oD+DPPW+gBNfcIr/FdGSrVYgw0icNnV5ePqpudlyHgh0bnikeq6ySqdizTGUFXuNi3XTR3vklguavrbc1lQW5h6QTjFhlBFthztbgvqTJeydD4mQElSJWfh8hY1RToDKnJmv8SoFlD8r4GBpG8mgtG3EOyJs89ZQYcdSf/aPjYFyjo+REELHlYKDO3twh/tO1zs3fBr+4wMift9gH1MdFw6OzZW7oKfFnR/z4Z2EUY4HaY1pYxLn7H9NaxqJ9kjvT6IvbU05XTdCbmJXwIzFT7cnbVA4nupq7FO4at+8eAmWgutqT++ZnOrkdXrGUT4bJx0X8F6CjR74zW0gi0ptwtK3TVP9v/c63+D6Xl8b6K8IxNr0/gt8e+TWdV/2eILTW7ezd8vMnJZq5LqXiZ5dhgvOPTSLQbiE7iH/dv0TUUHnS+J77w1UE6hBuXCfODtYW8z3c3hhkfzePw/LQbG+qhYKIy5c5xvieKsr9DP6grO3Hadm084uhlwCyYGSk/w1pPaDpDwVxQ+BQNBmMc2oS7KwMhzMjJ9WX4GXnruqJ3HQnUv+ixqKWP7rJWXm8hTcoGlu1rbXam+ac2qC8dS+RDtlEhIcLh23afcKAc3/vhX9GMJwZ4l0xkQQT8ySpBtOC7wcPd/srM/92hw/7Fh2m0ZLKmkBWPYWtDNYUrH7vceR0j1W/xg23b3kcfOa0RJrEZ3X05jWKfkX3grlvuAAabP7hFV+pgqBUOPzuXwskuCxu86uo59UXzB+5dsxfjsmx4kCthYmdqcd20FJKZfGQIMsBaS+RGcqc+laPuPB/F/VCSf+XiBlb3gbZk/23g4/qH8G9c32UO64M77dQdPJ/w0AhoHSbQ=="
 }
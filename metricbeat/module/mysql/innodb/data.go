@@ -0,0 +1,149 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package innodb
+
+import (
+	"regexp"
+	"strconv"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstrstr"
+	"github.com/elastic/beats/v7/metricbeat/module/mysql"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var (
+	// Schema for mapping the metrics of INFORMATION_SCHEMA.INNODB_METRICS,
+	// most of them are disabled by default.
+	schema = s.Schema{
+		"buffer_pool": s.Object{
+			"size": s.Object{
+				"bytes": c.Int("buffer_pool_size", s.Optional),
+			},
+			"pages": s.Object{
+				"total": c.Int("buffer_pool_pages_total", s.Optional),
+				"data":  c.Int("buffer_pool_pages_data", s.Optional),
+				"dirty": c.Int("buffer_pool_pages_dirty", s.Optional),
+				"free":  c.Int("buffer_pool_pages_free", s.Optional),
+				"misc":  c.Int("buffer_pool_pages_misc", s.Optional),
+			},
+			"bytes": s.Object{
+				"data":  c.Int("buffer_pool_bytes_data", s.Optional),
+				"dirty": c.Int("buffer_pool_bytes_dirty", s.Optional),
+			},
+			"read_requests":  c.Int("buffer_pool_read_requests", s.Optional),
+			"reads":          c.Int("buffer_pool_reads", s.Optional),
+			"write_requests": c.Int("buffer_pool_write_requests", s.Optional),
+			"wait_free":      c.Int("buffer_pool_wait_free", s.Optional),
+		},
+		"row_lock": s.Object{
+			"current_waits": c.Int("lock_row_lock_current_waits", s.Optional),
+			"waits":         c.Int("lock_row_lock_waits", s.Optional),
+			"time": s.Object{
+				"ms": c.Int("lock_row_lock_time", s.Optional),
+				"avg": s.Object{
+					"ms": c.Int("lock_row_lock_time_avg", s.Optional),
+				},
+				"max": s.Object{
+					"ms": c.Int("lock_row_lock_time_max", s.Optional),
+				},
+			},
+		},
+		"lock": s.Object{
+			"deadlocks": c.Int("lock_deadlocks", s.Optional),
+			"timeouts":  c.Int("lock_timeouts", s.Optional),
+		},
+		"purge": s.Object{
+			"history_list": s.Object{
+				"length": c.Int("trx_rseg_history_len", s.Optional),
+			},
+			"dml_delay": s.Object{
+				"us": c.Int("purge_dml_delay_usec", s.Optional),
+			},
+		},
+		"log": s.Object{
+			"lsn": s.Object{
+				"current":    c.Int("log_lsn_current", s.Optional),
+				"flushed":    c.Int("log_lsn_last_flush", s.Optional),
+				"checkpoint": c.Int("log_lsn_last_checkpoint", s.Optional),
+			},
+		},
+		"checkpoint": s.Object{
+			"age": s.Object{
+				"bytes": c.Int("log_lsn_checkpoint_age", s.Optional),
+			},
+			"max_age": s.Object{
+				"async": s.Object{
+					"bytes": c.Int("log_max_modified_age_async", s.Optional),
+				},
+				"sync": s.Object{
+					"bytes": c.Int("log_max_modified_age_sync", s.Optional),
+				},
+			},
+		},
+	}
+
+	// engineStatusMetrics are the metrics of INNODB_METRICS that can be read
+	// from the output of SHOW ENGINE INNODB STATUS. The first match is used,
+	// it is the global one when there are several buffer pool instances.
+	engineStatusMetrics = map[string]*regexp.Regexp{
+		"buffer_pool_pages_total": regexp.MustCompile(`(?m)^Buffer pool size[ \t]+(\d+)[ \t]*$`),
+		"buffer_pool_pages_free":  regexp.MustCompile(`(?m)^Free buffers[ \t]+(\d+)[ \t]*$`),
+		"buffer_pool_pages_data":  regexp.MustCompile(`(?m)^Database pages[ \t]+(\d+)[ \t]*$`),
+		"buffer_pool_pages_dirty": regexp.MustCompile(`(?m)^Modified db pages[ \t]+(\d+)[ \t]*$`),
+		"trx_rseg_history_len":    regexp.MustCompile(`(?m)^History list length[ \t]+(\d+)[ \t]*$`),
+		"log_lsn_current":         regexp.MustCompile(`(?m)^Log sequence number[ \t]+(\d+)[ \t]*$`),
+		"log_lsn_last_flush":      regexp.MustCompile(`(?m)^Log flushed up to[ \t]+(\d+)[ \t]*$`),
+		"log_lsn_last_checkpoint": regexp.MustCompile(`(?m)^Last checkpoint at[ \t]+(\d+)[ \t]*$`),
+	}
+)
+
+// parseEngineStatus parses the metrics in the output of SHOW ENGINE INNODB
+// STATUS, with the names they have in INNODB_METRICS.
+func parseEngineStatus(status string) map[string]string {
+	metrics := map[string]string{}
+	for name, re := range engineStatusMetrics {
+		if match := re.FindStringSubmatch(status); match != nil {
+			metrics[name] = match[1]
+		}
+	}
+	return metrics
+}
+
+func eventMapping(metrics map[string]string) mapstr.M {
+	source := map[string]interface{}{}
+	for key, val := range metrics {
+		source[key] = val
+	}
+
+	// The checkpoint age is disabled by default, but it can be calculated
+	// from the current and the last checkpoint LSNs.
+	if _, found := metrics["log_lsn_checkpoint_age"]; !found {
+		current, err1 := strconv.ParseInt(metrics["log_lsn_current"], 10, 64)
+		checkpoint, err2 := strconv.ParseInt(metrics["log_lsn_last_checkpoint"], 10, 64)
+		if err1 == nil && err2 == nil {
+			source["log_lsn_checkpoint_age"] = strconv.FormatInt(current-checkpoint, 10)
+		}
+	}
+
+	// Most of the metrics are disabled by default, their objects are not
+	// reported when they are missing.
+	data, _ := schema.Apply(source)
+	mysql.DropEmptyObjects(data)
+	return data
+}
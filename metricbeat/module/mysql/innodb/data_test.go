@@ -0,0 +1,81 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package innodb
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEngineStatus(t *testing.T) {
+	status, err := os.ReadFile("_meta/test/engine_status.txt")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"buffer_pool_pages_total": "16382",
+		"buffer_pool_pages_free":  "14950",
+		"buffer_pool_pages_data":  "1426",
+		"buffer_pool_pages_dirty": "12",
+		"trx_rseg_history_len":    "42",
+		"log_lsn_current":         "19504663",
+		"log_lsn_last_flush":      "19504663",
+		"log_lsn_last_checkpoint": "19498112",
+	}, parseEngineStatus(string(status)))
+	assert.Empty(t, parseEngineStatus(""))
+}
+
+func TestEventMapping(t *testing.T) {
+	event := eventMapping(map[string]string{
+		"buffer_pool_size":            "134217728",
+		"buffer_pool_pages_total":     "8192",
+		"lock_row_lock_current_waits": "2",
+		"lock_row_lock_time_max":      "51",
+		"trx_rseg_history_len":        "42",
+		"log_lsn_current":             "19504663",
+		"log_lsn_last_checkpoint":     "19498112",
+	})
+
+	for key, expected := range map[string]int64{
+		"buffer_pool.size.bytes":    134217728,
+		"buffer_pool.pages.total":   8192,
+		"row_lock.current_waits":    2,
+		"row_lock.time.max.ms":      51,
+		"purge.history_list.length": 42,
+		"log.lsn.current":           19504663,
+		"log.lsn.checkpoint":        19498112,
+		"checkpoint.age.bytes":      6551,
+	} {
+		value, err := event.GetValue(key)
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, expected, value, key)
+		}
+	}
+	_, err := event.GetValue("row_lock.waits")
+	assert.Error(t, err)
+
+	// The objects of the missing metrics are not reported.
+	for _, key := range []string{"purge.dml_delay", "checkpoint.max_age", "lock"} {
+		_, err := event.GetValue(key)
+		assert.Error(t, err, key)
+	}
+}
@@ -0,0 +1,162 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+/*
+Package innodb fetches metrics of the InnoDB storage engine of MySQL servers.
+
+For more information on the queries it uses, see:
+https://dev.mysql.com/doc/refman/8.0/en/information-schema-innodb-metrics-table.html
+https://dev.mysql.com/doc/refman/8.0/en/innodb-standard-monitor.html
+*/
+package innodb
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/mysql"
+)
+
+// requiredMetrics are the metrics read from SHOW ENGINE INNODB STATUS when
+// they are not enabled in INFORMATION_SCHEMA.INNODB_METRICS.
+var requiredMetrics = []string{
+	"buffer_pool_pages_total",
+	"buffer_pool_pages_free",
+	"buffer_pool_pages_data",
+	"buffer_pool_pages_dirty",
+	"trx_rseg_history_len",
+	"log_lsn_current",
+	"log_lsn_last_flush",
+	"log_lsn_last_checkpoint",
+}
+
+func init() {
+	mb.Registry.MustAddMetricSet("mysql", "innodb", New,
+		mb.WithHostParser(mysql.ParseDSN),
+	)
+}
+
+// MetricSet for fetching the metrics of InnoDB.
+type MetricSet struct {
+	*mysql.Metricset
+	db *sql.DB
+}
+
+// New creates and returns a new MetricSet instance.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := mysql.NewMetricset(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{Metricset: ms, db: nil}, nil
+}
+
+// Fetch fetches the metrics of InnoDB from a mysql host.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	if m.db == nil {
+		var err error
+		m.db, err = mysql.NewDB(m.HostData().URI, m.Metricset.Config.TLSConfig)
+		if err != nil {
+			return fmt.Errorf("mysql-innodb fetch failed: %w", err)
+		}
+	}
+
+	metrics, metricsErr := m.loadMetrics(m.db)
+	if metricsErr != nil {
+		m.Logger().Debugf("Failed to read INNODB_METRICS, reading SHOW ENGINE INNODB STATUS: %v", metricsErr)
+		metrics = map[string]string{}
+	}
+
+	if missingMetrics(metrics) {
+		status, err := m.loadEngineStatus(m.db)
+		if err != nil {
+			if metricsErr != nil {
+				return fmt.Errorf("failed to read InnoDB metrics: %w", metricsErr)
+			}
+			m.Logger().Debugf("Failed to read SHOW ENGINE INNODB STATUS: %v", err)
+		}
+		for name, value := range parseEngineStatus(status) {
+			if _, found := metrics[name]; !found {
+				metrics[name] = value
+			}
+		}
+	}
+
+	reporter.Event(mb.Event{
+		MetricSetFields: eventMapping(metrics),
+	})
+
+	return nil
+}
+
+// loadMetrics loads the enabled metrics of INFORMATION_SCHEMA.INNODB_METRICS.
+func (m *MetricSet) loadMetrics(db *sql.DB) (map[string]string, error) {
+	rows, err := db.Query("SELECT NAME, COUNT FROM INFORMATION_SCHEMA.INNODB_METRICS WHERE STATUS = 'enabled';")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	metrics := map[string]string{}
+
+	for rows.Next() {
+		var name string
+		var value string
+
+		err = rows.Scan(&name, &value)
+		if err != nil {
+			return nil, err
+		}
+
+		metrics[name] = value
+	}
+
+	return metrics, rows.Err()
+}
+
+// loadEngineStatus loads the output of the InnoDB standard monitor.
+func (m *MetricSet) loadEngineStatus(db *sql.DB) (string, error) {
+	var engine, name, status string
+	err := db.QueryRow("SHOW ENGINE INNODB STATUS;").Scan(&engine, &name, &status)
+	if err != nil {
+		return "", err
+	}
+	return status, nil
+}
+
+// missingMetrics returns true if any of the required metrics is not enabled.
+func missingMetrics(metrics map[string]string) bool {
+	for _, name := range requiredMetrics {
+		if _, found := metrics[name]; !found {
+			return true
+		}
+	}
+	return false
+}
+
+// Close closes the database connection and prevents future queries.
+func (m *MetricSet) Close() error {
+	if m.db == nil {
+		return nil
+	}
+	if err := m.db.Close(); err != nil {
+		return fmt.Errorf("failed to close mysql database client: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build integration
+
+package innodb
+
+import (
+	"testing"
+
+	"github.com/elastic/beats/v7/libbeat/tests/compose"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/mysql"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetch(t *testing.T) {
+	service := compose.EnsureUp(t, "mysql")
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(service.Host()))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	if len(errs) > 0 {
+		t.Fatalf("Expected 0 errors, had %d. %v\n", len(errs), errs)
+	}
+	assert.NotEmpty(t, events)
+	event := events[0].MetricSetFields
+	t.Logf("%s/%s event: %+v", f.Module().Name(), f.Name(), event)
+
+	// These metrics are read from SHOW ENGINE INNODB STATUS when they are not
+	// enabled in INNODB_METRICS.
+	pages, err := event.GetValue("buffer_pool.pages.total")
+	assert.NoError(t, err)
+	assert.Greater(t, pages, int64(0))
+
+	lsn, err := event.GetValue("log.lsn.current")
+	assert.NoError(t, err)
+	assert.Greater(t, lsn, int64(0))
+
+	age, err := event.GetValue("checkpoint.age.bytes")
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, age, int64(0))
+}
+
+func TestData(t *testing.T) {
+	service := compose.EnsureUp(t, "mysql")
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(service.Host()))
+
+	err := mbtest.WriteEventsReporterV2Error(f, t, "")
+	if err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "mysql",
+		"metricsets": []string{"innodb"},
+		"hosts":      []string{mysql.GetMySQLEnvDSN(host)},
+	}
+}
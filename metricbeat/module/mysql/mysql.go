@@ -26,6 +26,7 @@ import (
 	"fmt"
 
 	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
 
 	"github.com/go-sql-driver/mysql"
@@ -145,3 +146,16 @@ func NewDB(dsn string, tlsConfig *tls.Config) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// DropEmptyObjects removes the objects of the event that are empty, like the
+// objects of a schema whose optional fields are all missing.
+func DropEmptyObjects(event mapstr.M) {
+	for key, value := range event {
+		if object, ok := value.(mapstr.M); ok {
+			DropEmptyObjects(object)
+			if len(object) == 0 {
+				delete(event, key)
+			}
+		}
+	}
+}
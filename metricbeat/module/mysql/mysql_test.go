@@ -24,6 +24,7 @@ import (
 	"time"
 
 	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -68,3 +69,17 @@ func TestParseDSN(t *testing.T) {
 		}
 	}
 }
+
+func TestDropEmptyObjects(t *testing.T) {
+	event := mapstr.M{
+		"a": mapstr.M{
+			"b": mapstr.M{},
+			"c": mapstr.M{"d": mapstr.M{}},
+			"e": int64(1),
+		},
+		"f": mapstr.M{"g": mapstr.M{}},
+		"h": "",
+	}
+	DropEmptyObjects(event)
+	assert.Equal(t, mapstr.M{"a": mapstr.M{"e": int64(1)}, "h": ""}, event)
+}
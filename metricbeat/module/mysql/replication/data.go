@@ -0,0 +1,151 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replication
+
+import (
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstrstr"
+	"github.com/elastic/beats/v7/metricbeat/module/mysql"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var (
+	// Schema for mapping the columns of SHOW REPLICA STATUS.
+	replicaSchema = s.Schema{
+		"channel": c.Str("Channel_Name", s.Optional),
+		"source": s.Object{
+			"host":      c.Str("Source_Host", s.Optional),
+			"port":      c.Int("Source_Port", s.Optional),
+			"uuid":      c.Str("Source_UUID", s.Optional),
+			"server_id": c.Int("Source_Server_Id", s.Optional),
+		},
+		"io": s.Object{
+			"state": c.Str("Replica_IO_Running", s.Optional),
+			"error": s.Object{
+				"number":  c.Int("Last_IO_Errno", s.Optional),
+				"message": c.Str("Last_IO_Error", s.Optional),
+			},
+		},
+		"sql": s.Object{
+			"state": c.Str("Replica_SQL_Running", s.Optional),
+			"error": s.Object{
+				"number":  c.Int("Last_SQL_Errno", s.Optional),
+				"message": c.Str("Last_SQL_Error", s.Optional),
+			},
+			"delay": s.Object{
+				"sec": c.Int("SQL_Delay", s.Optional),
+			},
+			"remaining_delay": s.Object{
+				"sec": c.Int("SQL_Remaining_Delay", s.Optional),
+			},
+		},
+		"lag": s.Object{
+			"sec": c.Int("Seconds_Behind_Source", s.Optional),
+		},
+		"position": s.Object{
+			"read": s.Object{
+				"file": c.Str("Source_Log_File", s.Optional),
+				"pos":  c.Int("Read_Source_Log_Pos", s.Optional),
+			},
+			"exec": s.Object{
+				"file": c.Str("Relay_Source_Log_File", s.Optional),
+				"pos":  c.Int("Exec_Source_Log_Pos", s.Optional),
+			},
+			"relay": s.Object{
+				"file": c.Str("Relay_Log_File", s.Optional),
+				"pos":  c.Int("Relay_Log_Pos", s.Optional),
+			},
+		},
+		"auto_position": c.Bool("Auto_Position", s.Optional),
+		"gtid": s.Object{
+			"retrieved": c.Str("Retrieved_Gtid_Set", s.Optional),
+			"executed":  c.Str("Executed_Gtid_Set", s.Optional),
+		},
+	}
+
+	// Schema for mapping the columns of the replication_group_members and
+	// replication_group_member_stats tables.
+	groupMemberSchema = s.Schema{
+		"channel": c.Str("CHANNEL_NAME"),
+		"id":      c.Str("MEMBER_ID"),
+		"host":    c.Str("MEMBER_HOST", s.Optional),
+		"port":    c.Int("MEMBER_PORT", s.Optional),
+		"state":   c.Str("MEMBER_STATE", s.Optional),
+		"role":    c.Str("MEMBER_ROLE", s.Optional),
+		"version": c.Str("MEMBER_VERSION", s.Optional),
+		"local":   c.Bool("LOCAL_MEMBER", s.Optional),
+		"transactions": s.Object{
+			"queue":           c.Int("COUNT_TRANSACTIONS_IN_QUEUE", s.Optional),
+			"checked":         c.Int("COUNT_TRANSACTIONS_CHECKED", s.Optional),
+			"conflicts":       c.Int("COUNT_CONFLICTS_DETECTED", s.Optional),
+			"rows_validating": c.Int("COUNT_TRANSACTIONS_ROWS_VALIDATING", s.Optional),
+			"remote": s.Object{
+				"applier_queue": c.Int("COUNT_TRANSACTIONS_REMOTE_IN_APPLIER_QUEUE", s.Optional),
+				"applied":       c.Int("COUNT_TRANSACTIONS_REMOTE_APPLIED", s.Optional),
+			},
+			"local": s.Object{
+				"proposed": c.Int("COUNT_TRANSACTIONS_LOCAL_PROPOSED", s.Optional),
+				"rollback": c.Int("COUNT_TRANSACTIONS_LOCAL_ROLLBACK", s.Optional),
+			},
+		},
+	}
+)
+
+func replicaEventMapping(status map[string]string) mapstr.M {
+	source := map[string]interface{}{}
+	for key, val := range status {
+		source[key] = val
+	}
+	// Some columns are NULL, like the replication lag when the replication
+	// threads are stopped, their objects are not reported.
+	data, _ := replicaSchema.Apply(source)
+	mysql.DropEmptyObjects(data)
+
+	// The GTID sets of MariaDB have a different format, and are not in
+	// these columns.
+	retrieved, err := parseGTIDSet(status["Retrieved_Gtid_Set"])
+	if err != nil {
+		return mapstr.M{"replica": data}
+	}
+	executed, err := parseGTIDSet(status["Executed_Gtid_Set"])
+	if err != nil || (len(retrieved) == 0 && len(executed) == 0) {
+		return mapstr.M{"replica": data}
+	}
+	ranges, transactions := executed.gaps()
+	data.DeepUpdate(mapstr.M{
+		"gtid": mapstr.M{
+			"pending": mapstr.M{
+				"count": retrieved.countMissing(executed),
+			},
+			"gaps": mapstr.M{
+				"ranges": ranges,
+				"count":  transactions,
+			},
+		},
+	})
+	return mapstr.M{"replica": data}
+}
+
+func groupMemberEventMapping(member map[string]string) mapstr.M {
+	source := map[string]interface{}{}
+	for key, val := range member {
+		source[key] = val
+	}
+	data, _ := groupMemberSchema.Apply(source)
+	return mapstr.M{"group_member": data}
+}
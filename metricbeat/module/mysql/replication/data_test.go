@@ -0,0 +1,109 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package replication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestReplicaEventMapping(t *testing.T) {
+	event := replicaEventMapping(map[string]string{
+		"Channel_Name":          "",
+		"Source_Host":           "mysql-source",
+		"Source_Port":           "3306",
+		"Replica_IO_Running":    "Yes",
+		"Replica_SQL_Running":   "Yes",
+		"Last_SQL_Errno":        "0",
+		"Seconds_Behind_Source": "12",
+		"Source_Log_File":       "binlog.000003",
+		"Read_Source_Log_Pos":   "19504663",
+		"Auto_Position":         "1",
+		"Retrieved_Gtid_Set":    sourceUUID + ":1-120",
+		"Executed_Gtid_Set":     sourceUUID + ":1-100:105-110",
+	})
+
+	for key, expected := range map[string]interface{}{
+		"replica.source.host":        "mysql-source",
+		"replica.source.port":        int64(3306),
+		"replica.io.state":           "Yes",
+		"replica.sql.error.number":   int64(0),
+		"replica.lag.sec":            int64(12),
+		"replica.position.read.file": "binlog.000003",
+		"replica.position.read.pos":  int64(19504663),
+		"replica.auto_position":      true,
+		"replica.gtid.pending.count": int64(14),
+		"replica.gtid.gaps.ranges":   int64(1),
+		"replica.gtid.gaps.count":    int64(4),
+		"replica.gtid.retrieved":     sourceUUID + ":1-120",
+	} {
+		value, err := event.GetValue(key)
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, expected, value, key)
+		}
+	}
+
+	// The replication lag is NULL when the replication threads are stopped,
+	// and GTIDs may not be used.
+	event = replicaEventMapping(map[string]string{
+		"Replica_IO_Running":  "No",
+		"Replica_SQL_Running": "No",
+		"Retrieved_Gtid_Set":  "",
+		"Executed_Gtid_Set":   "",
+	})
+	for _, key := range []string{"replica.lag", "replica.sql.remaining_delay", "replica.gtid.gaps"} {
+		_, err := event.GetValue(key)
+		assert.Error(t, err, key)
+	}
+}
+
+func TestGroupMemberEventMapping(t *testing.T) {
+	event := groupMemberEventMapping(map[string]string{
+		"CHANNEL_NAME":                "group_replication_applier",
+		"MEMBER_ID":                   sourceUUID,
+		"MEMBER_HOST":                 "mysql-1",
+		"MEMBER_PORT":                 "3306",
+		"MEMBER_STATE":                "ONLINE",
+		"MEMBER_ROLE":                 "PRIMARY",
+		"LOCAL_MEMBER":                "1",
+		"COUNT_TRANSACTIONS_IN_QUEUE": "0",
+		"COUNT_CONFLICTS_DETECTED":    "2",
+		"COUNT_TRANSACTIONS_REMOTE_IN_APPLIER_QUEUE": "5",
+	})
+
+	member := event["group_member"].(mapstr.M)
+	assert.Equal(t, "ONLINE", member["state"])
+	assert.Equal(t, "PRIMARY", member["role"])
+	assert.Equal(t, true, member["local"])
+	for key, expected := range map[string]int64{
+		"port":                              3306,
+		"transactions.queue":                0,
+		"transactions.conflicts":            2,
+		"transactions.remote.applier_queue": 5,
+	} {
+		value, err := member.GetValue(key)
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, expected, value, key)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replication
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// interval is a range of transaction IDs of a GTID set, both included.
+type interval struct {
+	start, end int64
+}
+
+// gtidSet is a set of GTIDs, with the sorted intervals of transaction IDs of
+// each source. The source is the UUID of the server, followed by the tag of
+// the transactions if they are tagged.
+type gtidSet map[string][]interval
+
+// parseGTIDSet parses a GTID set in the format used by MySQL, like
+// 3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5:7,24DA167-0C0C-11E8-8442-00059A3C7B00:1-3.
+func parseGTIDSet(s string) (gtidSet, error) {
+	set := gtidSet{}
+	s = strings.Join(strings.Fields(s), "")
+	if s == "" {
+		return set, nil
+	}
+	for _, item := range strings.Split(s, ",") {
+		parts := strings.Split(item, ":")
+		source := strings.ToLower(parts[0])
+		if source == "" || len(parts) < 2 {
+			return nil, fmt.Errorf("invalid GTID set item '%s'", item)
+		}
+		for _, part := range parts[1:] {
+			// Tags start with a letter or an underscore.
+			if part != "" && (part[0] < '0' || part[0] > '9') {
+				source = strings.ToLower(parts[0]) + ":" + strings.ToLower(part)
+				continue
+			}
+			startValue, endValue, isRange := strings.Cut(part, "-")
+			if !isRange {
+				endValue = startValue
+			}
+			start, err := strconv.ParseInt(startValue, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid GTID interval '%s': %w", part, err)
+			}
+			end, err := strconv.ParseInt(endValue, 10, 64)
+			if err != nil || end < start {
+				return nil, fmt.Errorf("invalid GTID interval '%s'", part)
+			}
+			set[source] = append(set[source], interval{start: start, end: end})
+		}
+	}
+	for source, intervals := range set {
+		set[source] = mergeIntervals(intervals)
+	}
+	return set, nil
+}
+
+// mergeIntervals sorts the intervals and merges the ones that overlap or are
+// contiguous.
+func mergeIntervals(intervals []interval) []interval {
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].start < intervals[j].start })
+	merged := intervals[:0]
+	for _, i := range intervals {
+		if last := len(merged) - 1; last >= 0 && i.start <= merged[last].end+1 {
+			if i.end > merged[last].end {
+				merged[last].end = i.end
+			}
+			continue
+		}
+		merged = append(merged, i)
+	}
+	return merged
+}
+
+// count returns the number of transactions in the set.
+func (set gtidSet) count() int64 {
+	var count int64
+	for _, intervals := range set {
+		for _, i := range intervals {
+			count += i.end - i.start + 1
+		}
+	}
+	return count
+}
+
+// countMissing returns the number of transactions of the set that are not in
+// the other set.
+func (set gtidSet) countMissing(other gtidSet) int64 {
+	missing := set.count()
+	for source, intervals := range set {
+		others := other[source]
+		for i, j := 0, 0; i < len(intervals) && j < len(others); {
+			start := max(intervals[i].start, others[j].start)
+			end := min(intervals[i].end, others[j].end)
+			if start <= end {
+				missing -= end - start + 1
+			}
+			if intervals[i].end < others[j].end {
+				i++
+			} else {
+				j++
+			}
+		}
+	}
+	return missing
+}
+
+// gaps returns the number of ranges of transactions missing in the set, before
+// the last transaction of each source, and the number of transactions in
+// them.
+func (set gtidSet) gaps() (ranges, transactions int64) {
+	for _, intervals := range set {
+		next := int64(1)
+		for _, i := range intervals {
+			if i.start > next {
+				ranges++
+				transactions += i.start - next
+			}
+			next = i.end + 1
+		}
+	}
+	return ranges, transactions
+}
@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package replication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sourceUUID  = "3e11fa47-71ca-11e1-9e33-c80aa9429562"
+	replicaUUID = "24da167c-0c0c-11e8-8442-00059a3c7b00"
+)
+
+func TestParseGTIDSet(t *testing.T) {
+	set, err := parseGTIDSet("3E11FA47-71CA-11E1-9E33-C80AA9429562:7-9:1-5:6,\n" + replicaUUID + ":1-3:tag_1:2")
+	require.NoError(t, err)
+	assert.Equal(t, gtidSet{
+		sourceUUID:             {{start: 1, end: 9}},
+		replicaUUID:            {{start: 1, end: 3}},
+		replicaUUID + ":tag_1": {{start: 2, end: 2}},
+	}, set)
+	assert.Equal(t, int64(13), set.count())
+
+	set, err = parseGTIDSet("")
+	require.NoError(t, err)
+	assert.Empty(t, set)
+
+	for _, invalid := range []string{"0-1-100", sourceUUID, sourceUUID + ":5-1", ":1-5"} {
+		_, err := parseGTIDSet(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestGTIDSetCountMissing(t *testing.T) {
+	retrieved, err := parseGTIDSet(sourceUUID + ":1-100:200-210")
+	require.NoError(t, err)
+	executed, err := parseGTIDSet(sourceUUID + ":1-95:200-205," + replicaUUID + ":1-10")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(10), retrieved.countMissing(executed))
+	assert.Equal(t, int64(10), executed.countMissing(retrieved))
+	assert.Equal(t, int64(0), executed.countMissing(executed))
+}
+
+func TestGTIDSetGaps(t *testing.T) {
+	set, err := parseGTIDSet(sourceUUID + ":1-10:15-20:22," + replicaUUID + ":3-5")
+	require.NoError(t, err)
+
+	ranges, transactions := set.gaps()
+	assert.Equal(t, int64(3), ranges)
+	assert.Equal(t, int64(7), transactions)
+}
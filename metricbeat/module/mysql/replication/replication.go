@@ -0,0 +1,179 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+/*
+Package replication fetches the replication status of MySQL servers, of their
+replication channels and of the members of their replication group.
+
+For more information on the queries it uses, see:
+https://dev.mysql.com/doc/refman/8.0/en/show-replica-status.html
+https://dev.mysql.com/doc/refman/8.0/en/performance-schema-replication-group-member-stats-table.html
+*/
+package replication
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/mysql"
+)
+
+const (
+	replicaStatusQuery = "SHOW REPLICA STATUS;"
+
+	// replicaStatusQueryOld is used by the versions previous to MySQL 8.0.22
+	// and MariaDB 10.5.1.
+	replicaStatusQueryOld = "SHOW SLAVE STATUS;"
+
+	groupMembersQuery = `SELECT m.*, s.*, m.MEMBER_ID = @@server_uuid AS LOCAL_MEMBER
+FROM performance_schema.replication_group_members m
+JOIN performance_schema.replication_group_member_stats s
+ON s.CHANNEL_NAME = m.CHANNEL_NAME AND s.MEMBER_ID = m.MEMBER_ID;`
+)
+
+// replicaColumnNames replaces the terms of the columns of SHOW SLAVE STATUS
+// with the ones of SHOW REPLICA STATUS.
+var replicaColumnNames = strings.NewReplacer("Master", "Source", "Slave", "Replica")
+
+func init() {
+	mb.Registry.MustAddMetricSet("mysql", "replication", New,
+		mb.WithHostParser(mysql.ParseDSN),
+	)
+}
+
+// MetricSet for fetching the replication status of MySQL servers.
+type MetricSet struct {
+	*mysql.Metricset
+	db *sql.DB
+}
+
+// New creates and returns a new MetricSet instance.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := mysql.NewMetricset(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{Metricset: ms, db: nil}, nil
+}
+
+// Fetch fetches the replication status from a mysql host.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	if m.db == nil {
+		var err error
+		m.db, err = mysql.NewDB(m.HostData().URI, m.Metricset.Config.TLSConfig)
+		if err != nil {
+			return fmt.Errorf("mysql-replication fetch failed: %w", err)
+		}
+	}
+
+	channels, err := m.loadReplicaStatus(m.db)
+	if err != nil {
+		return err
+	}
+	for _, channel := range channels {
+		if !reporter.Event(mb.Event{MetricSetFields: replicaEventMapping(channel)}) {
+			return nil
+		}
+	}
+
+	// The tables of Group Replication are not available in MariaDB and in
+	// MySQL previous to 5.7.6.
+	members, err := query(m.db, groupMembersQuery)
+	if err != nil {
+		m.Logger().Debugf("Failed to read the members of the replication group: %v", err)
+		return nil
+	}
+	for _, member := range members {
+		if !reporter.Event(mb.Event{MetricSetFields: groupMemberEventMapping(member)}) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// loadReplicaStatus loads the status of the replication channels, with the
+// names of the columns of SHOW REPLICA STATUS.
+func (m *MetricSet) loadReplicaStatus(db *sql.DB) ([]map[string]string, error) {
+	channels, err := query(db, replicaStatusQuery)
+	if err == nil {
+		return channels, nil
+	}
+
+	m.Logger().Debugf("Failed to run SHOW REPLICA STATUS, running SHOW SLAVE STATUS: %v", err)
+	channels, err = query(db, replicaStatusQueryOld)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load replica status: %w", err)
+	}
+	for i, channel := range channels {
+		renamed := make(map[string]string, len(channel))
+		for name, value := range channel {
+			renamed[replicaColumnNames.Replace(name)] = value
+		}
+		channels[i] = renamed
+	}
+	return channels, nil
+}
+
+// query runs a query and returns its rows by column name. NULL values are
+// not included.
+func query(db *sql.DB, query string) ([]map[string]string, error) {
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]sql.NullString, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	var results []map[string]string
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		result := map[string]string{}
+		for i, column := range columns {
+			if values[i].Valid {
+				result[column] = values[i].String
+			}
+		}
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+// Close closes the database connection and prevents future queries.
+func (m *MetricSet) Close() error {
+	if m.db == nil {
+		return nil
+	}
+	if err := m.db.Close(); err != nil {
+		return fmt.Errorf("failed to close mysql database client: %w", err)
+	}
+	return nil
+}
@@ -32,5 +32,5 @@ func init() {
 // AssetNats returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/nats.
 func AssetNats() string {
-	return "eJzsmkuP4zYMx+/5FEROLdAN9ljkUGDRXvbQOXSmp6LIKjITC2tJXorKbPbTF/Ij44ccO5lkHkV8jBPyZ5IS/5TzAb7ifglGsJsBsOIMlzC/+/RwP58BJOgkqZyVNUv4bQYAxTfhT5v4DGcAhBkKh0vYihmAQ2Zltm4J/8ydy+a/wDxlzuf/zgA2CrPELQsbH8AIjQev4eJ9HqyQ9Xn1ScR3uL6EH30BaQ0LZRw4FqwcK+mAU8HwiIRAKBLYkNVw9+SiSdCkcEg7pIVKDndqnK+4f7TU/HwAKlwPKVam4PMfQ05Yaey5SQRjy0dOKAVjsoRfFx8XH6f5vy99Bw9gN6CRSUmQhCJ8uwckrTEoW7diWRhx+nudhqIsnmyGwghPUGG4xo/aJVNf3eS0y6QVtGP5GcGt8xQshiBxivFANBLn1wdzLkqhDOMW6QwKr9dIgaPlBJQBTpUbI8vRJMpsV+s9Y5wss2bbubGxpAUvIfajk5Ar76WhMpbjyD7vLIBJrIkn0TM5CTdHUjbppBmUA5/DTw7lz1FKlWS4eiOggeUYatdXfAVPpBDaesMhmcpIq0N6E8Gi+7SRpdpk0uic2Paq62gEJwAegRz0WCPFqn2U59hSeR5v32INaj1fJ6HW89a+9YQeIN9JQg+8bYv9FuvefI9lyyK7VnuTnggNZ3sQktUOQWYKDfcjRtZzXx+dHavC3EXD9HbVAKG2jCvVVUPPVEqlWVAJWAOPqZJp0aTKyD5RYQJsj6oUp35M7qdnCZECKb6r5pbi2+rZyQoWawHZLdpGd+5u2ZXXM3ZylU9wdxMD76d33MTASID+Z2Kgtz29ud52VQlAuFWOkTDpRuLQWlnwBeOzE3TR6Lzi6FqdKo2NrRr14iXPAipVF/xa2oMPizSk+u7Twz3kZCW6+L4mLQ1AXqDQMrtVUmSlkyJ8TR4gbxwMSCiZ+yiVkyLDZLXJrOhuyHUIcySJpnv3hCDK3Lci6I5GsFiqq/h8ARdTVoWXbF+eIWLSU+3Qk59Xy2pz+4h4uimgmwK6KaATeV5KATVBXWYfw77lvEa6zq4VXMDBRePswZqiFUS5Uub4rHZmpQV7tew49MSyj59YbYTfVl1pNI44ARMOE/43j46bb9JixTKA2UT1pKL3x0AnwnaAIVGOSa19cRZmDWhrFFsKBfj3X5/vB2wcexDo6vUfg18bWVsnPhb0qjhVHHRKhdF8tuEot08eX5+9oDgVfSfo9ckDxKngzq/d65MXFKeik7XdPvvy5AFiCHzs+PWZs2L7wPXdzNTVYfrwmfSTLHZIvQ5ymXZb2gabYznpFgfW7YBmyvHg0LCbPq+eODEE2+dxacEyvRIXK40OROkjzPQb600SxBiIFl98RBUyxcXgWfpFJi3nMwaHXL16wNLpEZxU8YoEx5GuOTwHp3WyQBDCGos3AWHh4g6rvwCN4W+EsZ4XWny/bEy1+K6011DaL9VfAuv9VCCx6xZa9Xch69dZN9hTpp8dktjiEM9/AQAA//8pwp3x"
+	return "eJzsnEuP47gRx+/+FIU+JUBPY3ML+hBgkb1MgCyC9OYUBF6aLNvcpkgNSdnr+fRBSaIkW9SrLdleoIFGH2yJ9at/8VF8+Qu84+kVNPNuBeClV/gKTz//+Mvb0wpAoONWpl4a/Qp/WwFA/iT804hM4QrAokLm8BV2bAXg0Hupd+4V/vvknHp6hqe99+nT/1YAW4lKuNe8jC+gWYKVVfrIn1IqxZosLT+J2Ka/X+mlX4Eb7ZnUDpxnXjovuQO/Zx6OaBEsMgFbaxL4uTbRJGhSOLQHtC9SVN8EnHc8HY1tft4BRX+/7LEsCr7+1GXEywQbbxVmBPPNDwWmFjnzKF7hry8/vPwwzv5bbgHIApgtJOit5MAtMnq6BcSN1sjPvopFYcDo30MYKCqNMqlikAclRggBwGWVAYgHp4lK/8++6I7PAG6IE5VIIvk9NqCjxl22qYpzUQqpPe7QfoAiSzZoiePMCEgNfi+bckbJUtRC6t16c/IYJ1NG7y6+2BqbMP8KsZcmIZfWi4LIhzHIWXrRAEaxisye1+HRuClaacRFmEE6yFL4k0P+5yilFArXDwJKLH2ol7biLXgkBUtMpj0FU2puEgqvYL7ZTLuaapMpQefYrlW7ehUcAdgD2WkxIMVq+yBPX1O5jrddYgA1mV8moCbzO/PoAa0g/yABrXjPSwyQdUt2q6GA3nuM9cYztYqJOcPwxjNrUXt1Asa9PCBwJVH7tmK/oXfeIktm0esf6N/y0ppJYjnqF5naM0jvgHFONdA9Q2HcAdOChHZZgjaq6wb9WGUpk9sZe4qK++EMJpRa+dPyEA+o/TMYjfQMKeCeg6vBUzC28jMKn792ARAPyAju4XhcvBKTtUmXYNIW9lbdQGEcMocCNqcyCCRqv09NbY1lO7wTfmn9Cn6L+QNi/QhhCDAkzzW+PEZQrvQmdGhTvRhJWXfswRAcpd832jdqtlEoOgH3bM2cwxsQWkyVpM5SDHbu54jl04sDdtkJHN2kc5MMa7JwIlijBEN5g0BRzIirVtDJ19X6btJ05fdqSeGD+CyVL7E8bH5965b647++gsVvGTrfD4bWGuvuRFYs7W2Z7OtUuNFbuXtJ2O93HpHY7zLJkjAy5ei1W5xpGnPHeHHfsSi4Ecakj/lBb+NaSNvpRTwFngAqpEXuG7lwDRlT8GKQXDq5ZbrD0lBye7YePbNkX38KWsXZetdeZ+RorsMOkTx4sj+EH6uKt+Mfke0POVClyI8QiKEEebQzjxGWa915nHx6LPG9c50pnPdNfc5BOzOhANxaP7tqVHvzzOdpLYsXPDSI3W74iOLVIPk+KIpOlos92IkgtFnUoIAjc50WKyKVOY/2RSETaDvBrhap3JMmM7T+P0IrSmDwJUxiFq/3vbOlAciu/vcmnffYGd+AD1tpnV87/DbVj7Gc1JNoXrHm9gJx+HACqXdLt6IewrxpFSIPwCp2U1UV60Iew7m8popdL6nOkrXLNr8h924hWetuIRgKsCW7myQuEQtUGOuI5wauAEuDxQGkGvUZNuiPiDrfdCrqOG00NSPjBvwJa3TreNYyt0vB3FjRy0m/RY86chBixlGtMgGpUZKfpgEOZf3XD7plYk/FnaM9w1YqpI23jnnUBSrV4DLpvll2PzHctFaVuN1SeGEhKtLSLpT98heQW8i0konsTb1q7nsmENUKm/zeqiR9rjTcaO/czpPsdxT9OOl+B+DNE/7A8egp/6BeApU8oEVRjzHLJU/BRH8WVSGNgC461yXzvXJxdy5gGvXXxXGYJetoE7M03j3kMP6+3ipj7F3rAOPvkFM8txVm/F2bo0KxQ1FsOjOl8sdSiwdpMkcHXtwID+9VYW7j3R1qVwM+wZ4VNUpoCLQ8QLyQ+pFkoWp7+YqlNmfIAk7Yz2yxKuB2zH1Gm3BHJv0txEwzpeqlydIq5UMVci/mzWNeVtCyLVLMKz0p4OBNfHQM0NZkZ40knluNOYyYH97Mi5v13ObjXk+wmBiP69Y2Zl+OM4KqKBakAKPhuJd8n0ewULamQgHeRLnKOrh28juuRlbCSWqVBgokF2cw1s8brNRYH7rjy0pb25Vp3Go63aBMR5jTc01NOg7Sf95O+Lyd8Hk74WO3E1rdUzyWdxzbYvvDs41pFneSJuMoLpUI5i/Pvl+pz4HZWdW54126cn1h6B5dgslLrDYPQsZeGkFYXjOpD+uUWx10mxlSazg6FwXlxqJbqqIps5OcqcJILl+TB2ymac4a50qzi8IL6RxnCgVN1ZnvkDBFy9uzsAki8jQ7U9D1Kpg31XWdEY4O+SQpcyvqFNbZWteImkRFnrhYVJvdR8TS5/3Mz/uZn/czH/R+ZhPUKXNch+m/W42kndRVkIlqhcGFDladaAJLQ0GUi343Y86aRuWFtKMaE6PXiIZqm8Vv68vUaBhxBGZArZaX6oPUkWe7MJuomZXR74dAR8JeAIOQzlu5ySgOFNzEaOmNpVb9n39/fVtFXh9w5Ex3ylK/dz7WU1s/4Fa7Fu/p0ILRJUbTt26Va3jKDO7PnlNMRT8we39ygpgKTkuD9yfPKaaiW2P8KvrEDckJogu8qXGw4lZD/cvoueJZsX+cOXV5u79LlJpCaoe2NYJ0hnUSRFE2mBSLmW6+YH0uqJLOR7lo0nDAZbiKsj/GlTDP9wtx0SqCA1bYoN/G2ZpMC0rGgJ3xRck443t86VxLn2Wm5TLl6bfFyq0HLIz24OylX9v27ubyk2cyGoIFzCJskHoOSw0XD+FI4BD+lmmTebrIOK+m4bxRUT6UV042p7FA7LBbRTeSTbZROJ2HHTA/JNfB8/8BAKni+WU="
 }
@@ -0,0 +1,221 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package jetstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var (
+	moduleSchema = s.Schema{
+		"server": s.Object{
+			"id": c.Str("server_id"),
+		},
+	}
+	statsSchema = s.Schema{
+		"memory":           c.Int("memory"),
+		"storage":          c.Int("storage"),
+		"reserved_memory":  c.Int("reserved_memory"),
+		"reserved_storage": c.Int("reserved_storage"),
+		"accounts":         c.Int("accounts"),
+		"ha_assets":        c.Int("ha_assets"),
+		"streams":          c.Int("streams"),
+		"consumers":        c.Int("consumers"),
+		"messages":         c.Int("messages"),
+		"bytes":            c.Int("bytes"),
+		"api": s.Object{
+			"total":  c.Int("api.total"),
+			"errors": c.Int("api.errors"),
+		},
+		"config": s.Object{
+			"max_memory":  c.Int("config.max_memory"),
+			"max_storage": c.Int("config.max_storage"),
+			"store_dir":   c.Str("config.store_dir"),
+		},
+	}
+	accountSchema = s.Schema{
+		"id":               c.Str("id"),
+		"name":             c.Str("name", s.Required),
+		"memory":           c.Int("memory"),
+		"storage":          c.Int("storage"),
+		"reserved_memory":  c.Int("reserved_memory"),
+		"reserved_storage": c.Int("reserved_storage"),
+		"ha_assets":        c.Int("ha_assets"),
+		"api": s.Object{
+			"total":  c.Int("api.total"),
+			"errors": c.Int("api.errors"),
+		},
+	}
+	streamSchema = s.Schema{
+		"name":    c.Str("name", s.Required),
+		"created": c.Str("created"),
+		"cluster": s.Object{
+			"leader": c.Str("cluster.leader"),
+		},
+		"state": s.Object{
+			"messages":       c.Int("state.messages"),
+			"bytes":          c.Int("state.bytes"),
+			"first_seq":      c.Int("state.first_seq"),
+			"first_ts":       c.Str("state.first_ts"),
+			"last_seq":       c.Int("state.last_seq"),
+			"last_ts":        c.Str("state.last_ts"),
+			"num_subjects":   c.Int("state.num_subjects"),
+			"num_deleted":    c.Int("state.num_deleted"),
+			"consumer_count": c.Int("state.consumer_count"),
+		},
+		"config": s.Object{
+			"retention":    c.Str("config.retention"),
+			"storage":      c.Str("config.storage"),
+			"num_replicas": c.Int("config.num_replicas"),
+			"max_msgs":     c.Int("config.max_msgs"),
+			"max_bytes":    c.Int("config.max_bytes"),
+		},
+	}
+	consumerSchema = s.Schema{
+		"name":    c.Str("name", s.Required),
+		"created": c.Str("created"),
+		"cluster": s.Object{
+			"leader": c.Str("cluster.leader"),
+		},
+		"delivered": s.Object{
+			"consumer_seq": c.Int("delivered.consumer_seq"),
+			"stream_seq":   c.Int("delivered.stream_seq"),
+			"last_active":  c.Str("delivered.last_active"),
+		},
+		"ack_floor": s.Object{
+			"consumer_seq": c.Int("ack_floor.consumer_seq"),
+			"stream_seq":   c.Int("ack_floor.stream_seq"),
+			"last_active":  c.Str("ack_floor.last_active"),
+		},
+		"num_ack_pending": c.Int("num_ack_pending"),
+		"num_redelivered": c.Int("num_redelivered"),
+		"num_waiting":     c.Int("num_waiting"),
+		"num_pending":     c.Int("num_pending"),
+	}
+)
+
+// eventMapping maps JetStream statistics to a Metricbeat event of the given
+// category, with the fields of the account and the stream they belong to
+func eventMapping(content map[string]interface{}, category string, fieldsSchema s.Schema, parents mapstr.M) (mb.Event, error) {
+	fields, err := fieldsSchema.Apply(content, s.FailOnRequired)
+	if err != nil {
+		return mb.Event{}, fmt.Errorf("error applying %s schema: %w", category, err)
+	}
+
+	metricSetFields := mapstr.M{
+		"category": category,
+	}
+	metricSetFields.DeepUpdate(parents)
+	metricSetFields.DeepUpdate(mapstr.M{category: fields})
+	return mb.Event{
+		MetricSetFields: metricSetFields,
+	}, nil
+}
+
+// eventsMapping maps the statistics of the server, and of its accounts,
+// streams and consumers
+func eventsMapping(r mb.ReporterV2, content []byte, config Config) error {
+	var jetStream map[string]interface{}
+	if err := json.Unmarshal(content, &jetStream); err != nil {
+		return fmt.Errorf("failure parsing NATS JetStream API response: %w", err)
+	}
+
+	moduleFields, err := moduleSchema.Apply(jetStream)
+	if err != nil {
+		return fmt.Errorf("failure applying module schema: %w", err)
+	}
+	var timestamp time.Time
+	if now, ok := jetStream["now"].(string); ok {
+		timestamp, err = time.Parse(time.RFC3339Nano, now)
+		if err != nil {
+			return fmt.Errorf("failure parsing server timestamp: %w", err)
+		}
+	}
+
+	// report returns false if the reporter is closed.
+	report := func(content map[string]interface{}, category string, fieldsSchema s.Schema, parents mapstr.M) bool {
+		evt, err := eventMapping(content, category, fieldsSchema, parents)
+		if err != nil {
+			r.Error(fmt.Errorf("error mapping %s event: %w", category, err))
+			return true
+		}
+		evt.ModuleFields = moduleFields.Clone()
+		evt.Timestamp = timestamp
+		return r.Event(evt)
+	}
+
+	if config.Stats.Enabled && !report(jetStream, "stats", statsSchema, nil) {
+		return nil
+	}
+
+	for _, account := range objects(jetStream, "account_details") {
+		accountName, _ := account["name"].(string)
+		if !config.Account.matches(accountName) {
+			continue
+		}
+		if config.Account.Enabled && !report(account, "account", accountSchema, nil) {
+			return nil
+		}
+		accountFields := mapstr.M{"account": mapstr.M{"name": accountName}}
+		if id, ok := account["id"].(string); ok {
+			accountFields.Put("account.id", id)
+		}
+
+		for _, stream := range objects(account, "stream_detail") {
+			streamName, _ := stream["name"].(string)
+			if !config.Stream.matches(streamName) {
+				continue
+			}
+			if config.Stream.Enabled && !report(stream, "stream", streamSchema, accountFields) {
+				return nil
+			}
+			streamFields := accountFields.Clone()
+			streamFields.Put("stream.name", streamName)
+
+			for _, consumer := range objects(stream, "consumer_detail") {
+				consumerName, _ := consumer["name"].(string)
+				if !config.Consumer.Enabled || !config.Consumer.matches(consumerName) {
+					continue
+				}
+				if !report(consumer, "consumer", consumerSchema, streamFields) {
+					return nil
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// objects returns the objects of the list with the given key.
+func objects(content map[string]interface{}, key string) []map[string]interface{} {
+	list, _ := content[key].([]interface{})
+	objects := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		if object, ok := item.(map[string]interface{}); ok {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
@@ -0,0 +1,156 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package jetstream
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	defaultScheme = "http"
+	defaultPath   = "/jsz"
+)
+
+var (
+	hostParser = parse.URLHostParserBuilder{
+		DefaultScheme: defaultScheme,
+		DefaultPath:   defaultPath,
+		PathConfigKey: "jetstream.metrics_path",
+	}.Build()
+)
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. After the
+// MetricSet has been created then Fetch will begin to be called periodically.
+func init() {
+	mb.Registry.MustAddMetricSet("nats", "jetstream", New,
+		mb.WithHostParser(hostParser),
+	)
+}
+
+// categoryConfig enables the events of a category of JetStream statistics,
+// optionally only for the given names.
+type categoryConfig struct {
+	Enabled bool     `config:"enabled"`
+	Names   []string `config:"names"`
+}
+
+// matches returns true if the given name is one of the names of the
+// category, or if there are no names.
+func (c categoryConfig) matches(name string) bool {
+	if len(c.Names) == 0 {
+		return true
+	}
+	for _, n := range c.Names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Config is the configuration of the jetstream metricset.
+type Config struct {
+	Stats    categoryConfig `config:"jetstream.stats"`
+	Account  categoryConfig `config:"jetstream.account"`
+	Stream   categoryConfig `config:"jetstream.stream"`
+	Consumer categoryConfig `config:"jetstream.consumer"`
+}
+
+func defaultConfig() Config {
+	return Config{
+		Stats:    categoryConfig{Enabled: true},
+		Account:  categoryConfig{Enabled: true},
+		Stream:   categoryConfig{Enabled: true},
+		Consumer: categoryConfig{Enabled: true},
+	}
+}
+
+// MetricSet holds any configuration or state information. It must implement
+// the mb.MetricSet interface. And this is best achieved by embedding
+// mb.BaseMetricSet because it implements all of the required mb.MetricSet
+// interface methods except for Fetch.
+type MetricSet struct {
+	mb.BaseMetricSet
+	http   *helper.HTTP
+	config Config
+	Log    *logp.Logger
+}
+
+// New creates a new instance of the MetricSet. New is responsible for unpacking
+// any MetricSet specific configuration options if there are any.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	http, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+
+	// The details of the accounts, streams and consumers are only returned
+	// when requested.
+	uri, err := url.Parse(base.HostData().SanitizedURI)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing URI: %w", err)
+	}
+	query := uri.Query()
+	if config.Account.Enabled || config.Stream.Enabled || config.Consumer.Enabled {
+		query.Set("accounts", "true")
+	}
+	if config.Stream.Enabled || config.Consumer.Enabled {
+		query.Set("streams", "true")
+		query.Set("config", "true")
+	}
+	if config.Consumer.Enabled {
+		query.Set("consumers", "true")
+	}
+	uri.RawQuery = query.Encode()
+	http.SetURI(uri.String())
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		http:          http,
+		config:        config,
+		Log:           logp.NewLogger("nats"),
+	}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	content, err := m.http.FetchContent()
+	if err != nil {
+		return fmt.Errorf("error in fetch: %w", err)
+	}
+	err = eventsMapping(r, content, m.config)
+	if err != nil {
+		return fmt.Errorf("error in mapping: %w", err)
+	}
+	return nil
+}
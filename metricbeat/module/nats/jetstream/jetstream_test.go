@@ -0,0 +1,153 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package jetstream
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestEventMapping(t *testing.T) {
+	content, err := os.ReadFile("./_meta/test/jetstreammetrics.json")
+	require.NoError(t, err)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, content, defaultConfig())
+	require.NoError(t, err)
+	require.Empty(t, reporter.GetErrors())
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 5)
+	for _, event := range events {
+		assert.Equal(t, "2023-06-14T09:24:19.587629464Z", event.Timestamp.Format("2006-01-02T15:04:05.999999999Z07:00"))
+		serverID, _ := event.ModuleFields.GetValue("server.id")
+		assert.Equal(t, "NCWQGNXJPXPYQVZJNMOFNFOPDFDOGMDAOQKLACGAJCJAOGNIPAHFPEJW", serverID)
+	}
+
+	stats := events[0].MetricSetFields
+	assert.Equal(t, "stats", stats["category"])
+	assertValue(t, stats, "stats.storage", int64(6142))
+	assertValue(t, stats, "stats.api.errors", int64(1))
+	assertValue(t, stats, "stats.config.max_storage", int64(28412604416))
+
+	account := events[1].MetricSetFields
+	assert.Equal(t, "account", account["category"])
+	assertValue(t, account, "account.name", "$G")
+	assertValue(t, account, "account.api.total", int64(32))
+
+	stream := events[2].MetricSetFields
+	assert.Equal(t, "stream", stream["category"])
+	assertValue(t, stream, "account.name", "$G")
+	assertValue(t, stream, "stream.name", "ORDERS")
+	assertValue(t, stream, "stream.state.messages", int64(60))
+	assertValue(t, stream, "stream.state.consumer_count", int64(2))
+	assertValue(t, stream, "stream.config.storage", "file")
+
+	consumer := events[3].MetricSetFields
+	assert.Equal(t, "consumer", consumer["category"])
+	assertValue(t, consumer, "account.name", "$G")
+	assertValue(t, consumer, "stream.name", "ORDERS")
+	assertValue(t, consumer, "consumer.name", "processor")
+	assertValue(t, consumer, "consumer.num_ack_pending", int64(5))
+	assertValue(t, consumer, "consumer.num_pending", int64(20))
+	assertValue(t, consumer, "consumer.ack_floor.stream_seq", int64(35))
+	assertValue(t, consumer, "consumer.delivered.last_active", "2023-06-14T09:23:58.116125683Z")
+
+	// Optional fields missing in the response are not reported.
+	_, err = events[4].MetricSetFields.GetValue("consumer.delivered.last_active")
+	assert.Error(t, err)
+}
+
+func TestEventMappingFilters(t *testing.T) {
+	content, err := os.ReadFile("./_meta/test/jetstreammetrics.json")
+	require.NoError(t, err)
+
+	config := defaultConfig()
+	config.Stats.Enabled = false
+	config.Account.Enabled = false
+	config.Stream.Enabled = false
+	config.Consumer.Names = []string{"audit"}
+
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, content, config)
+	require.NoError(t, err)
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 1)
+	assertValue(t, events[0].MetricSetFields, "consumer.name", "audit")
+
+	config = defaultConfig()
+	config.Stream.Names = []string{"OTHER"}
+	reporter = &mbtest.CapturingReporterV2{}
+	err = eventsMapping(reporter, content, config)
+	require.NoError(t, err)
+	// The consumers of the streams not included are not reported either.
+	require.Len(t, reporter.GetEvents(), 2)
+}
+
+func TestFetchEventContent(t *testing.T) {
+	response, err := os.ReadFile("./_meta/test/jetstreammetrics.json")
+	require.NoError(t, err)
+
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json;")
+		w.WriteHeader(200)
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":                     "nats",
+		"metricsets":                 []string{"jetstream"},
+		"hosts":                      []string{server.URL},
+		"jetstream.consumer.enabled": false,
+		"jetstream.stream.names":     []string{"ORDERS"},
+		"jetstream.account.names":    []string{"$G"},
+		"jetstream.account.enabled":  true,
+	}
+	reporter := &mbtest.CapturingReporterV2{}
+
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, config)
+	require.NoError(t, metricSet.Fetch(reporter))
+	assert.Equal(t, "accounts=true&config=true&streams=true", query)
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 3)
+	e := mbtest.StandardizeEvent(metricSet, events[2])
+	t.Logf("%s/%s event: %+v", metricSet.Module().Name(), metricSet.Name(), e.Fields.StringToPrint())
+}
+
+func assertValue(t *testing.T, fields mapstr.M, key string, expected interface{}) {
+	t.Helper()
+	value, err := fields.GetValue(key)
+	if assert.NoError(t, err, key) {
+		assert.Equal(t, expected, value, key)
+	}
+}
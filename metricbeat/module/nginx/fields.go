@@ -32,5 +32,5 @@ func init() {
 // AssetNginx returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/nginx.
 func AssetNginx() string {
-	return "eJzElM9u2zAMxu9+ig8+r3kAH/YIOw3YYRhSVaJtIYrkkXTSvP0gR26cwC1SoMF0skWJ349/xCfs6NQgdj6+VoB6DdSg/pH/6wpwJJb9oD7FBt8rAJhsEOIDMUSNjoI9KXsrsCkEskoOLac9DoZ9yubkxkCyqQDpE+vWptj6rkFrglAFMAUyQg06k8+Qqo+dNPhdi4T6G+pedaj/VEDrKThpJpInRLOnC31eehqyG07jUHZWQsjrebr1DJuiGh8F2tNbHNobxZGYIJbNMMczXdkUF0uSJY3o+HJOy5tpDesDtAnv4uZzjPlI7F63OWPb7GR79rI9F2Gu2GA62iwUryswr9sYl3H2STR/XRnnSHd0OiZ2N7YP4r101ux3s6pqrPrDumZIsfuc4M+eYEdmioo47l+IkdoiARt83rcpRrLZg8BHG0bnY4dfxucWXVrf47U0qHwhsCY14Qo3K5BbAV5H6k10gW5r85VIReFuIsdpGB5KVBTuJmL6O5I8tG4FZVZa5yjd+dB+vwuEyeTGfyzI4qkd+zzZzhPByyw/TbcCip6MI17HPfL0Pv8XbpEvuDKkKIQXY3fQNG2ec/4Ou3k8u3dhdcIVbbSJYeZMb6p/AQAA//9pr1BQ"
+	return "eJzsnFuP27oRx9/1KQb71AI+Bk4uL/tQID0tigBtEJxse4oWhUNLY4tdilRIymvn0x8MJdqyVxdKvgNB/JC1LPL35wzJGYriT/CMm0eQSy7XEYDlVuAjPHyivx8igARNrHluuZKP8KcIAMBdA4N6hRqMZbYwkKHVPDYQKyEwtpjAQqsMVkxzRZdVUgg00wjApErbWazkgi8fYcGEwQhAo0Bm8BGWjH6D1nK5NI/w3wdjxMMEHlJr84f/RQALjiIxj47kJ5Aswx09/bObnIrRqsirbxok0Oeru+srxEpaxqUBm+JWh02ZhRfUCCbWLPd63C3Tqog6SRtNM1EHFX3+urYoE0y2NGpRNvqkpCDQT3/7+Onf8FkUBj58/ghK7y796+lLZRZPClBr4TlaVvv+UEVdSZEbq5FlexfbBPWIos8/q/K887giJsBkAtwayBH1HnMb315rswxfXfSMz7h5UTppuN5DSp9PLENQC+cXviV2dK1A35W8AJBJmXYekim9cXWOQH1GzJngq3ZeoeRyJGyRzVETFE8E7qqi/iYxpltrtm5F/K6yOUdzVkDnd6AxUyvf0esNCfPCgrFcCMi1itEYLpeg8VuBxoZocOVPrbJMXEDHcDco+Yr88nDAZfW3G7EwANa3+1lhfSVk8DXHBKxynGNbWKPJlTQdftw0mgZi/+oL92CNlBOYbyAWzLgr1bQdq6TW5v2Dbl3Tz+t19Opivx0CRR3aw0t84TYFBj+v180aOpHfXBP5zSjkt9dEfjsK+d01kd+NQn5/TeT3o5DbppNTQj9RHSBfo3cwej6D0k7nG9sx5rUALpTOmH2EtpsD4P9MtzqE40fuGPkKkytL8Ri76GSsILqvVcYRs9BnRH2IMwGNudKUDnLpQnz1IgFX5BojZx3eFDv32iFQA30+/sWroJbyAcp+utXRzHXWMsvp5G3PBwYgf0gSjWbrDMQ9AUajCSXZhcYkELgllzox7q9olKBIm73mDgSds/i5yDtR50oJZPI41CddIPAdHnADrKq9Mm8g8QvyZWo7iY/33d9cJSPak0KpC1j+C1VTx4M/FPkEEvUiJ1BItmJcTCBOMX7mcklfpciETTe0vpFoxiWXyz8GamKx5atuUaec1svq6tltfeoJZM7YmpbGpDkz9t95xi1RH4+7YFyYi7Uy1UYjh7WY5dYAW1jU8JLyON3CUyfNmH6m3xnvVmwucNK9RtYplSqeWZ6hKuzUYHxmxU88Q0gKTSsNgeqqttja5LCtjlHfkXSfx85dCXgwcxWsdkK3RTwDqBvz70Hpdl/wE5Z2B1ligK5DixykLcNS8LA0/MIS3oyW8PZWJLwdLeHdrUh4N1rC+1uR8H60hK40/hwixqT0wWl9EHBfTjxAUHOaH6ikN7O/jprmTD9Q03WDMasgVllWSB5TnO/6xkABVTxzMQkU0ZltK8McY3rGVo+q6KvCYIPeQEllCjNzWc3ZY5EPZRZS1llmUi359bjoo1NFkIkGiNk31Z6mnrYP7RPnJa78ZSz4Nvu9Cnxbz6iYBugQzNhZzozBpFdJ96rNQDH11RuC2HMhKIl6dHgNtERBLTLNTDTSEoHgLvPbNrvJaQ3bPyU9XCGhZZGtRVxGh2ZCS5YZF4IbjJVMBgxTCerZZUR+WKFmSwSqjabuJVqn0EcjFc3+HDhWWZVLXkNaNfJ2ZbOvVB2Xp5ftd2UzLgohtizjjOgVxSxOMQqdLXu4f6HC3GaZaRQ2//WsjPetjQ7c1BM38LUixUok0fBxNADJjZsvKRdYoxLK9Uhu3P9oXYquJcyy0sAJN88B1IZ/x9aIu9Mv+yLtAGG/FFrTeEoQe20eAE7raleF/wdb86zIRsHfysPY+WaHPXScC87bLqNnm6edQlPKbTQ0Kxi0M8g9q6rllAeO0z8Q7lugKrbxVz0GCETfD0a3NfYJ6eRus3kQc5/jDNBVOlDVhcdL87KMZQIv4j6uplbAu3Oibjmd9PfhSgMFenFFnjDafx+1aTvfkESP8gQCt/SYa44UZjiaeqJ2d342XGKnovvwvROI3plwxQR3XhC1ST6JS9Yqci8lNEqZRgf334EjDhTWqePG3e9YqV5mxo05r7dJZWGhCnr5RTah3Y979SnpBL9xfzpK24vm1qKcbosbq/PkxvFofilqjKq7tNxQ4V40rnOuzz0BVZXsAU+jg5/fwYDQraMT+8ad6ghlvttsCxur8sSGGdojmjTdodWGyvaS5xt6chS16TzJOFDW4RdZD8juZxjolNFJfeP+NF6Y7zDbssaKPK1ZhnaGJkn3Z7Ohqr1iEzMdn3nJjeJOx5PUt6AQpWDxc32PbM+i8/0MF+GSW2zkRQja3j7T+C1qgm8yTw/1f2rv13sG0LTJyVXlXlZoOZphGoUZ4bLHB2xbKPRpY+v+jU6HCKGqOULVsLvDNyRym6IucTEBqTSwOFaFdOm98RcCBCQo2ObyCqpqAwA1/t+dnHJpQl9veBvOEr2Z6UJehnTP3hWBD+QSvQFdSDpfBgc08RUFeIQBCjy9W8nSs4YBYvS49sUV6caAoFnkNrZJVKfHlNSFKZgQ5TNYWfvRimtbMAGpMrWXTVvJvbVa6U/qEq+FBCG2T83tHjA48HjNNuhdljYXCXuHpbOtA6UctvnBXv0fR0f8ODrix9ERP46OCD46IhYcpQ0Bv7WtSdsZfYiEvs2ynRoCGDs3yDZNPq0bRj2zscW87AVR34zYwfd1V8ywQwFJgFyuZ3RE4YwKmZWlzMpTD30HzdkSGw/kW4Yex0ehTEOE1RVd9djDnSq4LXfaWGvje/StTtBT4RM5Y7ULU756d9556d476VzGokhooe03xmlPSv1qG2+MuTUnBLYHY1lZAyYNwM1IKZOJwOSMSFUNwUSJVnl+VqKqhmAiPwScEalC8TU1c1TeeUKM1/4eBKLRbbM+L8jOKvCS0mpAOSJw2t+62+VdgVZvZTTj0lLqFXGr6vffIqGDWvZn8RZ2dn52dwpmZfg9HdW4tqClLdD4rUBjp9HvAwDZ9yaP"
 }
@@ -0,0 +1,180 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package nginx collects the extended metrics of Nginx, from the NGINX Plus
+// API or from the JSON status of the nginx-module-vts module.
+package nginx
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	// defaultScheme is the default scheme to use when it is not specified in
+	// the host config.
+	defaultScheme = "http"
+
+	// modePlus collects the metrics from the NGINX Plus API.
+	modePlus = "plus"
+	// modeVTS collects the metrics from the JSON status of the
+	// nginx-module-vts module, or of the NJS exporters using the same format.
+	modeVTS = "vts"
+
+	// defaultPlusPath is the default path of the NGINX Plus API.
+	defaultPlusPath = "/api"
+	// defaultVTSPath is the default path of the VTS status in JSON.
+	defaultVTSPath = "/status/format/json"
+)
+
+var (
+	hostParser = parse.URLHostParserBuilder{
+		DefaultScheme: defaultScheme,
+		PathConfigKey: "api_path",
+	}.Build()
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("nginx", "nginx", New,
+		mb.WithHostParser(hostParser),
+	)
+}
+
+type config struct {
+	Mode    string `config:"api_mode"`
+	Version int    `config:"api_version" validate:"min=1"`
+}
+
+func defaultConfig() config {
+	return config{
+		Mode:    modePlus,
+		Version: 8,
+	}
+}
+
+// Validate checks the mode of the API.
+func (c *config) Validate() error {
+	switch c.Mode {
+	case modePlus, modeVTS:
+		return nil
+	}
+	return fmt.Errorf("unknown api_mode '%s', expected '%s' or '%s'", c.Mode, modePlus, modeVTS)
+}
+
+// MetricSet for fetching the extended metrics of Nginx.
+type MetricSet struct {
+	mb.BaseMetricSet
+	http    *helper.HTTP
+	config  config
+	baseURL *url.URL
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	http, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, err := url.Parse(base.HostData().SanitizedURI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL of host %s: %w", base.HostData().SanitizedURI, err)
+	}
+	if baseURL.Path == "" || baseURL.Path == "/" {
+		baseURL.Path = defaultPlusPath
+		if config.Mode == modeVTS {
+			baseURL.Path = defaultVTSPath
+		}
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		http:          http,
+		config:        config,
+		baseURL:       baseURL,
+	}, nil
+}
+
+// Fetch reports the upstreams, caches and limit_req zones of the NGINX Plus
+// API, or the server zones, upstreams and caches of the VTS status.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	if m.config.Mode == modeVTS {
+		content, err := m.fetch(m.baseURL.String())
+		if err != nil {
+			return fmt.Errorf("error fetching VTS status: %w", err)
+		}
+		events, err := vtsMapping(content)
+		if err != nil {
+			return err
+		}
+		report(reporter, events)
+		return nil
+	}
+
+	endpoints := []struct {
+		path    string
+		mapping func([]byte) ([]mapstr.M, error)
+	}{
+		{"http/upstreams", upstreamsMapping},
+		{"http/caches", cachesMapping},
+		{"http/limit_reqs", limitReqsMapping},
+	}
+	for _, endpoint := range endpoints {
+		u := *m.baseURL
+		u.Path = fmt.Sprintf("%s/%d/%s", strings.TrimSuffix(u.Path, "/"), m.config.Version, endpoint.path)
+
+		content, err := m.fetch(u.String())
+		if err != nil {
+			reporter.Error(fmt.Errorf("error fetching %s from the NGINX Plus API: %w", endpoint.path, err))
+			continue
+		}
+		events, err := endpoint.mapping(content)
+		if err != nil {
+			reporter.Error(fmt.Errorf("error parsing %s from the NGINX Plus API: %w", endpoint.path, err))
+			continue
+		}
+		if !report(reporter, events) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *MetricSet) fetch(uri string) ([]byte, error) {
+	m.http.SetURI(uri)
+	return m.http.FetchContent()
+}
+
+func report(reporter mb.ReporterV2, events []mapstr.M) bool {
+	for _, event := range events {
+		if !reporter.Event(mb.Event{MetricSetFields: event}) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,252 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package nginx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetchPlus(t *testing.T) {
+	server := testServer(t, map[string]string{
+		"/api/8/http/upstreams":  "upstreams.json",
+		"/api/8/http/caches":     "caches.json",
+		"/api/8/http/limit_reqs": "limit_reqs.json",
+	})
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL, nil))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 6)
+
+	assert.Equal(t, mapstr.M{
+		"upstream": mapstr.M{
+			"name":      "backend",
+			"zone":      "backend",
+			"keepalive": int64(4),
+			"zombies":   int64(0),
+			"peers": mapstr.M{
+				"total": int64(2),
+				"up":    int64(1),
+			},
+			"requests": int64(1562),
+			"responses": mapstr.M{
+				"1xx":   int64(0),
+				"2xx":   int64(1510),
+				"3xx":   int64(10),
+				"4xx":   int64(27),
+				"5xx":   int64(15),
+				"total": int64(1562),
+			},
+			"sent":     mapstr.M{"bytes": int64(933345)},
+			"received": mapstr.M{"bytes": int64(4665890)},
+		},
+	}, events[0].MetricSetFields)
+
+	peer := events[2].MetricSetFields
+	assertValues(t, peer, map[string]interface{}{
+		"upstream.name":                           "backend",
+		"upstream.peer.id":                        int64(1),
+		"upstream.peer.server":                    "app2.example.com:8080",
+		"upstream.peer.name":                      "10.0.0.2:8080",
+		"upstream.peer.backup":                    true,
+		"upstream.peer.state":                     "unhealthy",
+		"upstream.peer.max_conns":                 int64(100),
+		"upstream.peer.responses.5xx":             int64(10),
+		"upstream.peer.health_checks.unhealthy":   int64(3),
+		"upstream.peer.health_checks.last_passed": false,
+		"upstream.peer.downtime.ms":               int64(65000),
+	})
+	assert.NotContains(t, peer["upstream"].(mapstr.M)["peer"], "response_time")
+	assertValues(t, events[1].MetricSetFields, map[string]interface{}{
+		"upstream.peer.header_time.ms":   int64(12),
+		"upstream.peer.response_time.ms": int64(18),
+	})
+
+	assertValues(t, events[3].MetricSetFields, map[string]interface{}{
+		"cache.name":                   "static",
+		"cache.cold":                   false,
+		"cache.size.bytes":             int64(52428800),
+		"cache.max_size.bytes":         int64(1073741824),
+		"cache.hit.responses":          int64(8200),
+		"cache.miss.written.responses": int64(1100),
+	})
+	assert.NotContains(t, events[3].MetricSetFields["cache"].(mapstr.M)["hit"], "written")
+
+	assert.Equal(t, mapstr.M{
+		"limit_req": mapstr.M{
+			"zone":             "api",
+			"passed":           int64(15000),
+			"delayed":          int64(120),
+			"rejected":         int64(45),
+			"delayed_dry_run":  int64(0),
+			"rejected_dry_run": int64(0),
+		},
+	}, events[4].MetricSetFields)
+	assertValues(t, events[5].MetricSetFields, map[string]interface{}{
+		"limit_req.zone":             "login",
+		"limit_req.rejected_dry_run": int64(3),
+	})
+}
+
+func TestFetchPlusCustomPath(t *testing.T) {
+	server := testServer(t, map[string]string{
+		"/plus/api/9/http/upstreams":  "upstreams.json",
+		"/plus/api/9/http/caches":     "caches.json",
+		"/plus/api/9/http/limit_reqs": "limit_reqs.json",
+	})
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL, map[string]interface{}{
+		"api_path":    "/plus/api",
+		"api_version": 9,
+	}))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	assert.Len(t, events, 6)
+}
+
+func TestFetchPlusEndpointError(t *testing.T) {
+	server := testServer(t, map[string]string{
+		"/api/8/http/upstreams":  "upstreams.json",
+		"/api/8/http/limit_reqs": "limit_reqs.json",
+	})
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL, nil))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "http/caches")
+	assert.Len(t, events, 5)
+}
+
+func TestFetchVTS(t *testing.T) {
+	server := testServer(t, map[string]string{
+		"/status/format/json": "vts.json",
+	})
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL, map[string]interface{}{
+		"api_mode": "vts",
+	}))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 6)
+
+	assertValues(t, events[0].MetricSetFields, map[string]interface{}{
+		"server_zone.name":     "*",
+		"server_zone.requests": int64(980),
+	})
+	assert.Equal(t, mapstr.M{
+		"server_zone": mapstr.M{
+			"name":     "shop.example.com",
+			"requests": int64(960),
+			"responses": mapstr.M{
+				"1xx":   int64(0),
+				"2xx":   int64(900),
+				"3xx":   int64(20),
+				"4xx":   int64(30),
+				"5xx":   int64(10),
+				"total": int64(960),
+			},
+			"sent":         mapstr.M{"bytes": int64(5230000)},
+			"received":     mapstr.M{"bytes": int64(412000)},
+			"request_time": mapstr.M{"ms": int64(14)},
+		},
+	}, events[1].MetricSetFields)
+
+	assertValues(t, events[2].MetricSetFields, map[string]interface{}{
+		"upstream.name":           "default-shop-80",
+		"upstream.peers.total":    int64(2),
+		"upstream.peers.up":       int64(1),
+		"upstream.requests":       int64(920),
+		"upstream.responses.4xx":  int64(30),
+		"upstream.sent.bytes":     int64(390000),
+		"upstream.received.bytes": int64(4800000),
+	})
+	assertValues(t, events[4].MetricSetFields, map[string]interface{}{
+		"upstream.name":                  "default-shop-80",
+		"upstream.peer.server":           "10.244.2.7:8080",
+		"upstream.peer.state":            "down",
+		"upstream.peer.max_fails":        int64(3),
+		"upstream.peer.fail_timeout.sec": int64(10),
+		"upstream.peer.response_time.ms": int64(14),
+	})
+	assertValues(t, events[5].MetricSetFields, map[string]interface{}{
+		"cache.name":           "static",
+		"cache.size.bytes":     int64(20971520),
+		"cache.max_size.bytes": int64(1073741824),
+		"cache.hit.responses":  int64(40),
+		"cache.sent.bytes":     int64(2400000),
+	})
+}
+
+func TestInvalidMode(t *testing.T) {
+	c, err := conf.NewConfigFrom(getConfig("http://127.0.0.1", map[string]interface{}{
+		"api_mode": "stub",
+	}))
+	require.NoError(t, err)
+
+	_, _, err = mb.NewModule(c, mb.Registry)
+	assert.ErrorContains(t, err, "unknown api_mode")
+}
+
+func testServer(t *testing.T, files map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, found := files[r.URL.Path]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		http.ServeFile(w, r, filepath.Join("_meta", "test", file))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func assertValues(t *testing.T, fields mapstr.M, expected map[string]interface{}) {
+	t.Helper()
+	for key, value := range expected {
+		actual, err := fields.GetValue(key)
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, value, actual, key)
+		}
+	}
+}
+
+func getConfig(host string, extra map[string]interface{}) map[string]interface{} {
+	config := map[string]interface{}{
+		"module":     "nginx",
+		"metricsets": []string{"nginx"},
+		"hosts":      []string{host},
+	}
+	for key, value := range extra {
+		config[key] = value
+	}
+	return config
+}
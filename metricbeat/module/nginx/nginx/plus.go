@@ -0,0 +1,300 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package nginx
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type plusUpstream struct {
+	Peers     []plusPeer `json:"peers"`
+	Keepalive int64      `json:"keepalive"`
+	Zombies   int64      `json:"zombies"`
+	Zone      string     `json:"zone"`
+}
+
+type plusPeer struct {
+	ID           int64      `json:"id"`
+	Server       string     `json:"server"`
+	Name         string     `json:"name"`
+	Backup       bool       `json:"backup"`
+	Weight       int64      `json:"weight"`
+	State        string     `json:"state"`
+	Active       int64      `json:"active"`
+	MaxConns     *int64     `json:"max_conns"`
+	Requests     int64      `json:"requests"`
+	Responses    responses  `json:"responses"`
+	Sent         int64      `json:"sent"`
+	Received     int64      `json:"received"`
+	Fails        int64      `json:"fails"`
+	Unavail      int64      `json:"unavail"`
+	HealthChecks plusHealth `json:"health_checks"`
+	Downtime     int64      `json:"downtime"`
+	HeaderTime   *int64     `json:"header_time"`
+	ResponseTime *int64     `json:"response_time"`
+}
+
+type plusHealth struct {
+	Checks     int64 `json:"checks"`
+	Fails      int64 `json:"fails"`
+	Unhealthy  int64 `json:"unhealthy"`
+	LastPassed *bool `json:"last_passed"`
+}
+
+type responses struct {
+	Informational int64 `json:"1xx"`
+	Success       int64 `json:"2xx"`
+	Redirection   int64 `json:"3xx"`
+	ClientError   int64 `json:"4xx"`
+	ServerError   int64 `json:"5xx"`
+	Total         int64 `json:"total"`
+}
+
+type plusCache struct {
+	Size        int64            `json:"size"`
+	MaxSize     *int64           `json:"max_size"`
+	Cold        bool             `json:"cold"`
+	Hit         plusCacheCounter `json:"hit"`
+	Stale       plusCacheCounter `json:"stale"`
+	Updating    plusCacheCounter `json:"updating"`
+	Revalidated plusCacheCounter `json:"revalidated"`
+	Miss        plusCacheCounter `json:"miss"`
+	Expired     plusCacheCounter `json:"expired"`
+	Bypass      plusCacheCounter `json:"bypass"`
+}
+
+type plusCacheCounter struct {
+	Responses        int64  `json:"responses"`
+	Bytes            int64  `json:"bytes"`
+	ResponsesWritten *int64 `json:"responses_written"`
+	BytesWritten     *int64 `json:"bytes_written"`
+}
+
+type plusLimitReq struct {
+	Passed         int64 `json:"passed"`
+	Delayed        int64 `json:"delayed"`
+	Rejected       int64 `json:"rejected"`
+	DelayedDryRun  int64 `json:"delayed_dry_run"`
+	RejectedDryRun int64 `json:"rejected_dry_run"`
+}
+
+// upstreamsMapping reports an event for every upstream, followed by an event
+// for every one of its peers.
+func upstreamsMapping(content []byte) ([]mapstr.M, error) {
+	var upstreams map[string]plusUpstream
+	if err := json.Unmarshal(content, &upstreams); err != nil {
+		return nil, err
+	}
+
+	var events []mapstr.M
+	for _, name := range sortedKeys(upstreams) {
+		upstream := upstreams[name]
+
+		var total upstreamTotal
+		for _, peer := range upstream.Peers {
+			total.add(peer.State == "up", peer.Requests, peer.Responses, peer.Sent, peer.Received)
+		}
+		fields := total.fields()
+		fields["name"] = name
+		fields["keepalive"] = upstream.Keepalive
+		fields["zombies"] = upstream.Zombies
+		if upstream.Zone != "" {
+			fields["zone"] = upstream.Zone
+		}
+		events = append(events, mapstr.M{"upstream": fields})
+
+		for _, peer := range upstream.Peers {
+			events = append(events, mapstr.M{
+				"upstream": mapstr.M{
+					"name": name,
+					"peer": plusPeerFields(peer),
+				},
+			})
+		}
+	}
+	return events, nil
+}
+
+func plusPeerFields(peer plusPeer) mapstr.M {
+	fields := mapstr.M{
+		"id":        peer.ID,
+		"server":    peer.Server,
+		"backup":    peer.Backup,
+		"weight":    peer.Weight,
+		"state":     peer.State,
+		"active":    peer.Active,
+		"requests":  peer.Requests,
+		"responses": peer.Responses.fields(),
+		"sent":      mapstr.M{"bytes": peer.Sent},
+		"received":  mapstr.M{"bytes": peer.Received},
+		"fails":     peer.Fails,
+		"unavail":   peer.Unavail,
+		"health_checks": mapstr.M{
+			"checks":    peer.HealthChecks.Checks,
+			"fails":     peer.HealthChecks.Fails,
+			"unhealthy": peer.HealthChecks.Unhealthy,
+		},
+		"downtime": mapstr.M{"ms": peer.Downtime},
+	}
+	if peer.Name != "" {
+		fields["name"] = peer.Name
+	}
+	if peer.MaxConns != nil {
+		fields["max_conns"] = *peer.MaxConns
+	}
+	if peer.HealthChecks.LastPassed != nil {
+		_, _ = fields.Put("health_checks.last_passed", *peer.HealthChecks.LastPassed)
+	}
+	if peer.HeaderTime != nil {
+		_, _ = fields.Put("header_time.ms", *peer.HeaderTime)
+	}
+	if peer.ResponseTime != nil {
+		_, _ = fields.Put("response_time.ms", *peer.ResponseTime)
+	}
+	return fields
+}
+
+// cachesMapping reports an event for every cache.
+func cachesMapping(content []byte) ([]mapstr.M, error) {
+	var caches map[string]plusCache
+	if err := json.Unmarshal(content, &caches); err != nil {
+		return nil, err
+	}
+
+	var events []mapstr.M
+	for _, name := range sortedKeys(caches) {
+		cache := caches[name]
+		fields := mapstr.M{
+			"name":        name,
+			"cold":        cache.Cold,
+			"size":        mapstr.M{"bytes": cache.Size},
+			"hit":         cache.Hit.fields(),
+			"stale":       cache.Stale.fields(),
+			"updating":    cache.Updating.fields(),
+			"revalidated": cache.Revalidated.fields(),
+			"miss":        cache.Miss.fields(),
+			"expired":     cache.Expired.fields(),
+			"bypass":      cache.Bypass.fields(),
+		}
+		if cache.MaxSize != nil {
+			_, _ = fields.Put("max_size.bytes", *cache.MaxSize)
+		}
+		events = append(events, mapstr.M{"cache": fields})
+	}
+	return events, nil
+}
+
+func (c plusCacheCounter) fields() mapstr.M {
+	fields := mapstr.M{
+		"responses": c.Responses,
+		"bytes":     c.Bytes,
+	}
+	if c.ResponsesWritten != nil {
+		_, _ = fields.Put("written.responses", *c.ResponsesWritten)
+	}
+	if c.BytesWritten != nil {
+		_, _ = fields.Put("written.bytes", *c.BytesWritten)
+	}
+	return fields
+}
+
+// limitReqsMapping reports an event for every limit_req zone.
+func limitReqsMapping(content []byte) ([]mapstr.M, error) {
+	var zones map[string]plusLimitReq
+	if err := json.Unmarshal(content, &zones); err != nil {
+		return nil, err
+	}
+
+	var events []mapstr.M
+	for _, name := range sortedKeys(zones) {
+		zone := zones[name]
+		events = append(events, mapstr.M{
+			"limit_req": mapstr.M{
+				"zone":             name,
+				"passed":           zone.Passed,
+				"delayed":          zone.Delayed,
+				"rejected":         zone.Rejected,
+				"delayed_dry_run":  zone.DelayedDryRun,
+				"rejected_dry_run": zone.RejectedDryRun,
+			},
+		})
+	}
+	return events, nil
+}
+
+func (r responses) fields() mapstr.M {
+	return mapstr.M{
+		"1xx":   r.Informational,
+		"2xx":   r.Success,
+		"3xx":   r.Redirection,
+		"4xx":   r.ClientError,
+		"5xx":   r.ServerError,
+		"total": r.Total,
+	}
+}
+
+// upstreamTotal sums the metrics of the peers of an upstream.
+type upstreamTotal struct {
+	peers     int64
+	up        int64
+	requests  int64
+	responses responses
+	sent      int64
+	received  int64
+}
+
+func (t *upstreamTotal) add(up bool, requests int64, r responses, sent, received int64) {
+	t.peers++
+	if up {
+		t.up++
+	}
+	t.requests += requests
+	t.responses.Informational += r.Informational
+	t.responses.Success += r.Success
+	t.responses.Redirection += r.Redirection
+	t.responses.ClientError += r.ClientError
+	t.responses.ServerError += r.ServerError
+	t.responses.Total += r.Total
+	t.sent += sent
+	t.received += received
+}
+
+func (t *upstreamTotal) fields() mapstr.M {
+	return mapstr.M{
+		"peers": mapstr.M{
+			"total": t.peers,
+			"up":    t.up,
+		},
+		"requests":  t.requests,
+		"responses": t.responses.fields(),
+		"sent":      mapstr.M{"bytes": t.sent},
+		"received":  mapstr.M{"bytes": t.received},
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
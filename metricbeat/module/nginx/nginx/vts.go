@@ -0,0 +1,175 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package nginx
+
+import (
+	"encoding/json"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type vtsStatus struct {
+	ServerZones   map[string]vtsServerZone       `json:"serverZones"`
+	UpstreamZones map[string][]vtsUpstreamServer `json:"upstreamZones"`
+	CacheZones    map[string]vtsCacheZone        `json:"cacheZones"`
+}
+
+type vtsServerZone struct {
+	RequestCounter int64        `json:"requestCounter"`
+	InBytes        int64        `json:"inBytes"`
+	OutBytes       int64        `json:"outBytes"`
+	Responses      vtsResponses `json:"responses"`
+	RequestMsec    int64        `json:"requestMsec"`
+}
+
+type vtsUpstreamServer struct {
+	Server         string       `json:"server"`
+	RequestCounter int64        `json:"requestCounter"`
+	InBytes        int64        `json:"inBytes"`
+	OutBytes       int64        `json:"outBytes"`
+	Responses      vtsResponses `json:"responses"`
+	RequestMsec    int64        `json:"requestMsec"`
+	ResponseMsec   int64        `json:"responseMsec"`
+	Weight         int64        `json:"weight"`
+	MaxFails       int64        `json:"maxFails"`
+	FailTimeout    int64        `json:"failTimeout"`
+	Backup         bool         `json:"backup"`
+	Down           bool         `json:"down"`
+}
+
+type vtsCacheZone struct {
+	MaxSize   int64        `json:"maxSize"`
+	UsedSize  int64        `json:"usedSize"`
+	InBytes   int64        `json:"inBytes"`
+	OutBytes  int64        `json:"outBytes"`
+	Responses vtsResponses `json:"responses"`
+}
+
+type vtsResponses struct {
+	Informational int64 `json:"1xx"`
+	Success       int64 `json:"2xx"`
+	Redirection   int64 `json:"3xx"`
+	ClientError   int64 `json:"4xx"`
+	ServerError   int64 `json:"5xx"`
+	Miss          int64 `json:"miss"`
+	Bypass        int64 `json:"bypass"`
+	Expired       int64 `json:"expired"`
+	Stale         int64 `json:"stale"`
+	Updating      int64 `json:"updating"`
+	Revalidated   int64 `json:"revalidated"`
+	Hit           int64 `json:"hit"`
+	Scarce        int64 `json:"scarce"`
+}
+
+// responses returns the counters of the status codes of the responses, the
+// VTS status doesn't report their total.
+func (r vtsResponses) responses() responses {
+	return responses{
+		Informational: r.Informational,
+		Success:       r.Success,
+		Redirection:   r.Redirection,
+		ClientError:   r.ClientError,
+		ServerError:   r.ServerError,
+		Total:         r.Informational + r.Success + r.Redirection + r.ClientError + r.ServerError,
+	}
+}
+
+// vtsMapping reports the server zones, the upstreams with their peers, and
+// the caches of the VTS status, with the same fields as the NGINX Plus API
+// where they are equivalent.
+func vtsMapping(content []byte) ([]mapstr.M, error) {
+	var status vtsStatus
+	if err := json.Unmarshal(content, &status); err != nil {
+		return nil, err
+	}
+
+	var events []mapstr.M
+	for _, name := range sortedKeys(status.ServerZones) {
+		zone := status.ServerZones[name]
+		events = append(events, mapstr.M{
+			"server_zone": mapstr.M{
+				"name":         name,
+				"requests":     zone.RequestCounter,
+				"responses":    zone.Responses.responses().fields(),
+				"sent":         mapstr.M{"bytes": zone.OutBytes},
+				"received":     mapstr.M{"bytes": zone.InBytes},
+				"request_time": mapstr.M{"ms": zone.RequestMsec},
+			},
+		})
+	}
+
+	for _, name := range sortedKeys(status.UpstreamZones) {
+		servers := status.UpstreamZones[name]
+
+		var total upstreamTotal
+		for _, server := range servers {
+			total.add(!server.Down, server.RequestCounter, server.Responses.responses(), server.OutBytes, server.InBytes)
+		}
+		fields := total.fields()
+		fields["name"] = name
+		events = append(events, mapstr.M{"upstream": fields})
+
+		for _, server := range servers {
+			state := "up"
+			if server.Down {
+				state = "down"
+			}
+			events = append(events, mapstr.M{
+				"upstream": mapstr.M{
+					"name": name,
+					"peer": mapstr.M{
+						"server":        server.Server,
+						"backup":        server.Backup,
+						"weight":        server.Weight,
+						"state":         state,
+						"max_fails":     server.MaxFails,
+						"fail_timeout":  mapstr.M{"sec": server.FailTimeout},
+						"requests":      server.RequestCounter,
+						"responses":     server.Responses.responses().fields(),
+						"sent":          mapstr.M{"bytes": server.OutBytes},
+						"received":      mapstr.M{"bytes": server.InBytes},
+						"request_time":  mapstr.M{"ms": server.RequestMsec},
+						"response_time": mapstr.M{"ms": server.ResponseMsec},
+					},
+				},
+			})
+		}
+	}
+
+	for _, name := range sortedKeys(status.CacheZones) {
+		cache := status.CacheZones[name]
+		events = append(events, mapstr.M{
+			"cache": mapstr.M{
+				"name":        name,
+				"size":        mapstr.M{"bytes": cache.UsedSize},
+				"max_size":    mapstr.M{"bytes": cache.MaxSize},
+				"sent":        mapstr.M{"bytes": cache.OutBytes},
+				"received":    mapstr.M{"bytes": cache.InBytes},
+				"hit":         mapstr.M{"responses": cache.Responses.Hit},
+				"stale":       mapstr.M{"responses": cache.Responses.Stale},
+				"updating":    mapstr.M{"responses": cache.Responses.Updating},
+				"revalidated": mapstr.M{"responses": cache.Responses.Revalidated},
+				"miss":        mapstr.M{"responses": cache.Responses.Miss},
+				"expired":     mapstr.M{"responses": cache.Responses.Expired},
+				"bypass":      mapstr.M{"responses": cache.Responses.Bypass},
+				"scarce":      mapstr.M{"responses": cache.Responses.Scarce},
+			},
+		})
+	}
+	return events, nil
+}
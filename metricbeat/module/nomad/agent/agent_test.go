@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/nomad"
+)
+
+func TestFetch(t *testing.T) {
+	content, err := os.ReadFile("./_meta/test/metrics.json")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/metrics", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, nomad.GetConfig([]string{"agent"}, server.Listener.Addr().String()))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	// Metrics are grouped by their labels, unknown metrics are ignored.
+	require.Len(t, events, 2)
+
+	client := events[0].MetricSetFields
+	expected := map[string]interface{}{
+		"labels.node_id":               "6e3b1d3e-1f47-5e5c-3c9d-4b1b0c5e1b0a",
+		"labels.datacenter":            "dc1",
+		"client.allocations.running":   float64(2),
+		"client.allocations.pending":   float64(1),
+		"client.allocated.memory.mb":   float64(512),
+		"client.unallocated.memory.mb": float64(15360),
+	}
+	for k, v := range expected {
+		value, err := client.GetValue(k)
+		if assert.NoError(t, err, k) {
+			assert.Equal(t, v, value, k)
+		}
+	}
+
+	agent := events[1].MetricSetFields
+	expected = map[string]interface{}{
+		"labels.host":                              "nomad-dev",
+		"runtime.alloc.bytes":                      float64(27563040),
+		"runtime.sys.bytes":                        float64(58504456),
+		"runtime.heap_objects":                     float64(101462),
+		"runtime.goroutines":                       float64(173),
+		"runtime.garbage_collector.pause.total.ns": float64(2617426),
+		"runtime.garbage_collector.runs":           float64(19),
+		"server.broker.ready":                      float64(0),
+		"server.blocked_evals.blocked":             float64(3),
+	}
+	for k, v := range expected {
+		value, err := agent.GetValue(k)
+		if assert.NoError(t, err, k) {
+			assert.Equal(t, v, value, k)
+		}
+	}
+	_, err = agent.GetValue("raft")
+	assert.Error(t, err)
+}
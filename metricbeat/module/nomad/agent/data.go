@@ -0,0 +1,177 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const metricPrefix = "nomad."
+
+type inputConverter interface {
+	Rename() string
+}
+
+type valueHelper struct {
+	renamedTo string
+	unit      string
+}
+
+func (v *valueHelper) Rename() string {
+	if v.unit == "" {
+		return v.renamedTo
+	}
+
+	return fmt.Sprintf("%s.%s", v.renamedTo, v.unit)
+}
+
+var (
+	allowedValues = map[string]inputConverter{
+		"nomad.runtime.alloc_bytes":       &valueHelper{renamedTo: "runtime.alloc", unit: "bytes"},
+		"nomad.runtime.sys_bytes":         &valueHelper{renamedTo: "runtime.sys", unit: "bytes"},
+		"nomad.runtime.heap_objects":      &valueHelper{renamedTo: "runtime.heap_objects"},
+		"nomad.runtime.malloc_count":      &valueHelper{renamedTo: "runtime.malloc_count"},
+		"nomad.runtime.num_goroutines":    &valueHelper{renamedTo: "runtime.goroutines"},
+		"nomad.runtime.total_gc_pause_ns": &valueHelper{renamedTo: "runtime.garbage_collector.pause.total", unit: "ns"},
+		"nomad.runtime.total_gc_runs":     &valueHelper{renamedTo: "runtime.garbage_collector.runs"},
+
+		"nomad.client.uptime":                     &valueHelper{renamedTo: "client.uptime", unit: "sec"},
+		"nomad.client.allocations.running":        &valueHelper{renamedTo: "client.allocations.running"},
+		"nomad.client.allocations.pending":        &valueHelper{renamedTo: "client.allocations.pending"},
+		"nomad.client.allocations.blocked":        &valueHelper{renamedTo: "client.allocations.blocked"},
+		"nomad.client.allocations.migrating":      &valueHelper{renamedTo: "client.allocations.migrating"},
+		"nomad.client.allocations.terminal":       &valueHelper{renamedTo: "client.allocations.terminal"},
+		"nomad.client.allocated.cpu":              &valueHelper{renamedTo: "client.allocated.cpu", unit: "mhz"},
+		"nomad.client.allocated.memory":           &valueHelper{renamedTo: "client.allocated.memory", unit: "mb"},
+		"nomad.client.allocated.disk":             &valueHelper{renamedTo: "client.allocated.disk", unit: "mb"},
+		"nomad.client.unallocated.cpu":            &valueHelper{renamedTo: "client.unallocated.cpu", unit: "mhz"},
+		"nomad.client.unallocated.memory":         &valueHelper{renamedTo: "client.unallocated.memory", unit: "mb"},
+		"nomad.client.unallocated.disk":           &valueHelper{renamedTo: "client.unallocated.disk", unit: "mb"},
+		"nomad.client.host.memory.total":          &valueHelper{renamedTo: "client.host.memory.total", unit: "bytes"},
+		"nomad.client.host.memory.available":      &valueHelper{renamedTo: "client.host.memory.available", unit: "bytes"},
+		"nomad.client.host.memory.used":           &valueHelper{renamedTo: "client.host.memory.used", unit: "bytes"},
+		"nomad.nomad.broker.total_ready":          &valueHelper{renamedTo: "server.broker.ready"},
+		"nomad.nomad.broker.total_unacked":        &valueHelper{renamedTo: "server.broker.unacked"},
+		"nomad.nomad.broker.total_blocked":        &valueHelper{renamedTo: "server.broker.blocked"},
+		"nomad.nomad.blocked_evals.total_blocked": &valueHelper{renamedTo: "server.blocked_evals.blocked"},
+		"nomad.nomad.plan.queue_depth":            &valueHelper{renamedTo: "server.plan.queue_depth"},
+		"nomad.nomad.heartbeat.active":            &valueHelper{renamedTo: "server.heartbeats.active"},
+	}
+)
+
+func eventMapping(content []byte) ([]mapstr.M, error) {
+	var agent agent
+
+	if err := json.Unmarshal(content, &agent); err != nil {
+		return nil, err
+	}
+
+	labels := map[string]mapstr.M{}
+
+	for _, gauge := range agent.Gauges {
+		metricApply(labels, gauge.nomadMetric, gauge.Value)
+	}
+
+	for _, point := range agent.Points {
+		metricApply(labels, point.nomadMetric, point.Value)
+	}
+
+	for _, counter := range agent.Counters {
+		metricApply(labels, counter.nomadMetric, counter.Mean)
+	}
+
+	for _, sample := range agent.Samples {
+		metricApply(labels, sample.nomadMetric, sample.Mean)
+	}
+
+	// Events are reported in order, to make them predictable.
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	data := make([]mapstr.M, 0, len(keys))
+	for _, k := range keys {
+		data = append(data, labels[k])
+	}
+
+	return data, nil
+}
+
+func metricApply(labels map[string]mapstr.M, m nomadMetric, value float64) {
+	prettyName := prettyName(m.Name)
+	if prettyName == nil {
+		//omitting unwanted metric
+		return
+	}
+
+	labelsCombination := uniqueKeyForLabelMap(m.Labels)
+
+	if _, ok := labels[labelsCombination]; !ok {
+		temp := mapstr.M{}
+		if len(m.Labels) != 0 {
+			l := mapstr.M{}
+			for k, v := range m.Labels {
+				l[k] = v
+			}
+			temp["labels"] = l
+		}
+		labels[labelsCombination] = temp
+	}
+	labels[labelsCombination].Put(prettyName.Rename(), value)
+}
+
+// prettyName is used to translate a name in Nomad metrics to a metric name that follows ES naming conventions
+// https://www.elastic.co/guide/en/beats/devguide/current/event-conventions.html
+// Gauges are prefixed with the hostname of the agent unless disable_hostname
+// is set in its telemetry settings, so the segments after the prefix of the
+// metrics are removed until the name is found.
+func prettyName(s string) inputConverter {
+	name, found := strings.CutPrefix(s, metricPrefix)
+	if !found {
+		return nil
+	}
+
+	for {
+		if v, ok := allowedValues[metricPrefix+name]; ok {
+			return v
+		}
+
+		_, rest, found := strings.Cut(name, ".")
+		if !found {
+			return nil
+		}
+		name = rest
+	}
+}
+
+// Create a simple unique value for a map of labels without using a hash function
+func uniqueKeyForLabelMap(m map[string]string) string {
+	mm := mapstr.M{}
+	for k, v := range m {
+		mm[k] = v
+	}
+
+	return mm.String()
+}
@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package allocations
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/nomad"
+)
+
+func TestFetch(t *testing.T) {
+	content, err := os.ReadFile("./_meta/test/allocations.json")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/allocations", r.URL.Path)
+		assert.Equal(t, "*", r.URL.Query().Get("namespace"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, nomad.GetConfig([]string{"allocations"}, server.Listener.Addr().String()))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 2)
+
+	expected := []map[string]interface{}{
+		{
+			"id":             "0c2b0d4e-9b0a-6f1e-5c77-f2b4d4bf0c11",
+			"name":           "example.cache[0]",
+			"namespace":      "default",
+			"task_group":     "cache",
+			"job.id":         "example",
+			"job.type":       "service",
+			"job.version":    uint64(2),
+			"node.name":      "nomad-dev",
+			"status.desired": "run",
+			"status.client":  "running",
+			"tasks.count":    2,
+			"tasks.running":  uint64(2),
+			"tasks.failed":   uint64(0),
+			"tasks.restarts": uint64(1),
+			"create_time":    time.Date(2024, 3, 12, 10, 20, 15, 376553000, time.UTC),
+		},
+		{
+			"id":             "e1c4cfa5-e83a-47b1-9a61-7a5f0a6d2b7e",
+			"namespace":      "analytics",
+			"job.type":       "batch",
+			"status.desired": "stop",
+			"status.client":  "failed",
+			"tasks.count":    1,
+			"tasks.running":  uint64(0),
+			"tasks.failed":   uint64(1),
+			"tasks.restarts": uint64(3),
+		},
+	}
+	for i, fields := range expected {
+		for k, v := range fields {
+			value, err := events[i].MetricSetFields.GetValue(k)
+			if assert.NoError(t, err, k) {
+				assert.Equal(t, v, value, k)
+			}
+		}
+	}
+}
+
+func TestFetchNamespace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "analytics", r.URL.Query().Get("namespace"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	config := nomad.GetConfig([]string{"allocations"}, server.Listener.Addr().String())
+	config["namespace"] = "analytics"
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	assert.Empty(t, events)
+}
@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocations
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type allocation struct {
+	ID            string               `json:"ID"`
+	Name          string               `json:"Name"`
+	Namespace     string               `json:"Namespace"`
+	NodeID        string               `json:"NodeID"`
+	NodeName      string               `json:"NodeName"`
+	JobID         string               `json:"JobID"`
+	JobType       string               `json:"JobType"`
+	JobVersion    uint64               `json:"JobVersion"`
+	TaskGroup     string               `json:"TaskGroup"`
+	DesiredStatus string               `json:"DesiredStatus"`
+	ClientStatus  string               `json:"ClientStatus"`
+	TaskStates    map[string]taskState `json:"TaskStates"`
+	CreateTime    int64                `json:"CreateTime"`
+	ModifyTime    int64                `json:"ModifyTime"`
+}
+
+type taskState struct {
+	State    string `json:"State"`
+	Failed   bool   `json:"Failed"`
+	Restarts uint64 `json:"Restarts"`
+}
+
+func eventsMapping(content []byte) ([]mb.Event, error) {
+	var allocations []allocation
+	if err := json.Unmarshal(content, &allocations); err != nil {
+		return nil, err
+	}
+
+	events := make([]mb.Event, 0, len(allocations))
+	for _, alloc := range allocations {
+		events = append(events, eventMapping(alloc))
+	}
+	return events, nil
+}
+
+func eventMapping(alloc allocation) mb.Event {
+	var running, failed, restarts uint64
+	for _, task := range alloc.TaskStates {
+		if task.State == "running" {
+			running++
+		}
+		if task.Failed {
+			failed++
+		}
+		restarts += task.Restarts
+	}
+
+	return mb.Event{
+		MetricSetFields: mapstr.M{
+			"id":         alloc.ID,
+			"name":       alloc.Name,
+			"namespace":  alloc.Namespace,
+			"task_group": alloc.TaskGroup,
+			"job": mapstr.M{
+				"id":      alloc.JobID,
+				"type":    alloc.JobType,
+				"version": alloc.JobVersion,
+			},
+			"node": mapstr.M{
+				"id":   alloc.NodeID,
+				"name": alloc.NodeName,
+			},
+			"status": mapstr.M{
+				"desired": alloc.DesiredStatus,
+				"client":  alloc.ClientStatus,
+			},
+			"tasks": mapstr.M{
+				"count":    len(alloc.TaskStates),
+				"running":  running,
+				"failed":   failed,
+				"restarts": restarts,
+			},
+			"create_time": time.Unix(0, alloc.CreateTime).UTC(),
+			"modify_time": time.Unix(0, alloc.ModifyTime).UTC(),
+		},
+	}
+}
@@ -0,0 +1,36 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Code generated by beats/dev-tools/cmd/asset/asset.go - DO NOT EDIT.
+
+package nomad
+
+import (
+	"github.com/elastic/beats/v7/libbeat/asset"
+)
+
+func init() {
+	if err := asset.SetFields("metricbeat", "nomad", asset.ModuleFieldsPri, AssetNomad); err != nil {
+		panic(err)
+	}
+}
+
+// AssetNomad returns asset data.
+// This is the base64 encoded zlib format compressed contents of module/nomad.
+func AssetNomad() string {
+	return "eJzMmt1u47oRx+/9FINcFokewBcFzjlpu6fYjzSbbS+KwqbEsc2YIlVylNT79AUpMpJtfdnSyS42WCS2NP/fjIbDIak72ONhCUrnjC8ASJDEJdx8dn/fLAA42syIgoRWS/jzAgDAfwe55qXEBYBBicziElIktgCwSCTU1i7h3zfWyptbuNkRFTf/WQBsBEpul97MHSiWYy3t/tGhwCVsjS6L8EmLvvtZ+7vWkGlFTCgLtEPIkYzILOiN/7PiZFtUZG+BKe4/ZVLqjDl/rP/sWadvd2SytIQmCTJN2iaxN/n2aRs1wHlcAHo9cj9PKNE5cXBALATaq0WkNqwmmmQpSpv86ejLSKjTZ8zo5Kvqw1V1xR4Pr9rwxdEVfcTu30evGWMYHsItSLFHH9WdtnQLnBHLUBEaH3alOdZhFycu1g6ZUpHI8US0PeJnrI/VzaeJcRbRrqg2QXzeJOmB0J5dE4GkVtuWLzfa5IyW0HXzEfOv7qKYpcghPTSSuTA6Q2uTTkp7sO/GqDeQY67NAXTqBiFy2Bide9wvX7sZd8iKVZV2F2MeYXypbDSCpZVXdwrd+rm/fpXp8mgUX67/AVkB+hSiW3irjS5JKLSTZD+XeYrGJbMplRJq2zDcI85Myra4yrSUmJE2ScFKiwlpYjJRE5GY0hYzrbh1JdmWeZW5lnRxRzu8e9VG8sgAgUFoBR7CghUqw3pwgiVm+mN55o4pJzrxt2M6bcDHBvB/mJXOz5NxF1Gq8nVdhfpUV6ZY79uq4XB5KgtX6RKL2aQYfPNmYqF0lTv+XmHdglAQnnTSCRPGggtZi2B3aM5wfmlM1iccPrsY4TlFd7CajGHstF4zELEzzMcwDht+J73iBSo+l/hDZWu8eCp1tsfTSf468eYDCnbduGdQGHwRurSgFfbj5GJrGM0VjSbQm2WfOK4Bccl8CRuhyYVicha0p2Cs/0lF6XAR8mkj6BGtLk2GLbNke6EZO36yokzy3fdZIvPbw7caz5eYTx++J73yVeeR5OksAJ+8tVOGX/sRuLD7uQDuhd2PkI/SpXq7dq70OCqvQDtGwAyC0lRz/Sy5cgT1A/OlheO9c2YcQpR3E3pShWJa3oQAdPYJ12aK77g6VzCjYjS0kjnz5clphgSJXvhA9ZKyFyYkSyW+M+0vUfdi4tIif2fYbxb5IGfks2he0CzG5uRQH12ZSxbjkjBCpEbv0SQGGT8sLgzREdFfXpgsQyPirQFpSDEu4ZG7sepSDd8ujOJDcKViHQ3cdXgc/1ti6VZsJVUFP9sr/SqRb5HDAWmQqLulvI4otpJXhKi6c+Wiav8wLrevJEO581uGsGMWUOlyuwMT59RuykIylfigrzgWtJsE+CCZsvDKhGuiQ5axopCi3j2SyHhf1HbIDKXIyCYsI/GCk4A+RGvgFpPmpLewQIbFhYKDOx2oEap9FTnbZmtzpRAA4wL8aBe4q2pETHGaXqO3Un+/j8K1q0mrhvv/WpXPLMdLdGzBskli3sBYRWJ2v2or76Mln5jdVxPEWM1nnY6eZYbmi7OnP8TekwXPOh1yoSntVKaKPx2Kt+R41mnYtV+7ISkyXN/COmWU7dagDaztwRLm626iFzRWaDWpePyzstGAGopJVHdHCj/lcw1rquaRx5jn2zLoL4ZoDv4LMCKC22Er7WxB5WiFQT7Vqa+e6tyHaP94YrExqU2pQh6TLnqyuHU/dz5Ig4U2VFPGDc0KMuwMupEXdijdr5nOC4mEFf+GCYn8xINI7wrqfE9s+iGJK88tYUgWl2/MjheNG7J0qXgV2knaf/UmLpc26A897CTxx2AkCo+iiASZQUa46jxv5Yxw0SP+5I4OjoXgldlgl7eL5pqLzWF+UcksuZcUxEY0N66irDv2XwyNkCu6yr/r9Me3k886TVqNz9VH9grM20B2ShXMoKLVHPGqTIXDATRCc5G5Slsww3IkNOI7ct+KCGWJqQzbkep3HOy1UPe1icEItDSAo3WmN36RojBCG0GHxciadYTxEG4e9LWnExnh7fFk7P3VyndBbROu95kj65pi3an2iVrlbaq1RKb6SP61Q9qhiRwgrDdXIG/Xiuk4o1402SHYzPk5VZt2h9aC9qpkqleCw2Onni3GN0udB4212Nux9C3YMndvQegXNCDIAtV0yYVdmN8pOn0YvXE5w/2HN9F0O+mU8y3E1BbsazAyTnLOpm+UYOyoJyn+FoyMk5yvtxwlJ7Wd1rl/dKc6o6RK5faL1SS15ngSKppse8cjqmY7IblBdd34/T3M5OHMoHfav3S8hjllUjziax2x43inwTNCzs2Mk7TukfEuv6KILdNc0AzLAjf9uPWALdNcECFPFv8fAEcI0qc="
+}
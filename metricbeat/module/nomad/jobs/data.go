@@ -0,0 +1,124 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package jobs
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type job struct {
+	ID               string     `json:"ID"`
+	ParentID         string     `json:"ParentID"`
+	Name             string     `json:"Name"`
+	Namespace        string     `json:"Namespace"`
+	Datacenters      []string   `json:"Datacenters"`
+	Type             string     `json:"Type"`
+	Priority         int        `json:"Priority"`
+	Periodic         bool       `json:"Periodic"`
+	ParameterizedJob bool       `json:"ParameterizedJob"`
+	Stop             bool       `json:"Stop"`
+	Status           string     `json:"Status"`
+	JobSummary       jobSummary `json:"JobSummary"`
+	SubmitTime       int64      `json:"SubmitTime"`
+}
+
+type jobSummary struct {
+	Summary  map[string]taskGroupSummary `json:"Summary"`
+	Children childrenSummary             `json:"Children"`
+}
+
+type taskGroupSummary struct {
+	Queued   int64 `json:"Queued"`
+	Starting int64 `json:"Starting"`
+	Running  int64 `json:"Running"`
+	Complete int64 `json:"Complete"`
+	Failed   int64 `json:"Failed"`
+	Lost     int64 `json:"Lost"`
+	Unknown  int64 `json:"Unknown"`
+}
+
+type childrenSummary struct {
+	Pending int64 `json:"Pending"`
+	Running int64 `json:"Running"`
+	Dead    int64 `json:"Dead"`
+}
+
+func eventsMapping(content []byte) ([]mb.Event, error) {
+	var jobs []job
+	if err := json.Unmarshal(content, &jobs); err != nil {
+		return nil, err
+	}
+
+	events := make([]mb.Event, 0, len(jobs))
+	for _, j := range jobs {
+		events = append(events, eventMapping(j))
+	}
+	return events, nil
+}
+
+func eventMapping(j job) mb.Event {
+	// The allocations of the job are summed over all its task groups.
+	var allocs taskGroupSummary
+	for _, tg := range j.JobSummary.Summary {
+		allocs.Queued += tg.Queued
+		allocs.Starting += tg.Starting
+		allocs.Running += tg.Running
+		allocs.Complete += tg.Complete
+		allocs.Failed += tg.Failed
+		allocs.Lost += tg.Lost
+		allocs.Unknown += tg.Unknown
+	}
+
+	fields := mapstr.M{
+		"id":            j.ID,
+		"name":          j.Name,
+		"namespace":     j.Namespace,
+		"datacenters":   j.Datacenters,
+		"type":          j.Type,
+		"priority":      j.Priority,
+		"status":        j.Status,
+		"stop":          j.Stop,
+		"periodic":      j.Periodic,
+		"parameterized": j.ParameterizedJob,
+		"task_groups":   len(j.JobSummary.Summary),
+		"allocations": mapstr.M{
+			"queued":   allocs.Queued,
+			"starting": allocs.Starting,
+			"running":  allocs.Running,
+			"complete": allocs.Complete,
+			"failed":   allocs.Failed,
+			"lost":     allocs.Lost,
+			"unknown":  allocs.Unknown,
+		},
+		"children": mapstr.M{
+			"pending": j.JobSummary.Children.Pending,
+			"running": j.JobSummary.Children.Running,
+			"dead":    j.JobSummary.Children.Dead,
+		},
+		"submit_time": time.Unix(0, j.SubmitTime).UTC(),
+	}
+	if j.ParentID != "" {
+		fields["parent_id"] = j.ParentID
+	}
+
+	return mb.Event{MetricSetFields: fields}
+}
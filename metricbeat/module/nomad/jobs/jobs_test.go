@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package jobs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/nomad"
+)
+
+func TestFetch(t *testing.T) {
+	content, err := os.ReadFile("./_meta/test/jobs.json")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/jobs", r.URL.Path)
+		assert.Equal(t, "*", r.URL.Query().Get("namespace"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, nomad.GetConfig([]string{"jobs"}, server.Listener.Addr().String()))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 2)
+
+	expected := []map[string]interface{}{
+		{
+			"id":                   "example",
+			"namespace":            "default",
+			"type":                 "service",
+			"priority":             50,
+			"status":               "running",
+			"datacenters":          []string{"dc1"},
+			"task_groups":          2,
+			"allocations.queued":   int64(2),
+			"allocations.starting": int64(1),
+			"allocations.running":  int64(4),
+			"allocations.complete": int64(1),
+			"allocations.failed":   int64(1),
+			"allocations.lost":     int64(1),
+			"submit_time":          time.Date(2024, 3, 12, 10, 20, 15, 376553000, time.UTC),
+		},
+		{
+			"id":                 "batch-report/periodic-1710238900",
+			"namespace":          "analytics",
+			"type":               "batch",
+			"status":             "dead",
+			"parent_id":          "batch-report",
+			"allocations.failed": int64(1),
+			"children.running":   int64(1),
+			"children.dead":      int64(2),
+		},
+	}
+	for i, fields := range expected {
+		for k, v := range fields {
+			value, err := events[i].MetricSetFields.GetValue(k)
+			if assert.NoError(t, err, k) {
+				assert.Equal(t, v, value, k)
+			}
+		}
+	}
+
+	_, err = events[0].MetricSetFields.GetValue("parent_id")
+	assert.Error(t, err)
+}
@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package nomad
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// Config contains the settings shared by the metricsets of the module.
+type Config struct {
+	// Namespace filters the allocations and jobs, "*" for all the namespaces.
+	Namespace string `config:"namespace"`
+}
+
+// DefaultConfig returns the default settings of the module.
+func DefaultConfig() Config {
+	return Config{
+		Namespace: "*",
+	}
+}
+
+// NewHTTP creates the HTTP helper of a metricset, with the namespace of the
+// module configuration in the query of its requests.
+func NewHTTP(base mb.BaseMetricSet) (*helper.HTTP, error) {
+	config := DefaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	http, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+	if config.Namespace == "" {
+		return http, nil
+	}
+
+	u, err := url.Parse(http.GetURI())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing URI: %w", err)
+	}
+	q := u.Query()
+	q.Set("namespace", config.Namespace)
+	u.RawQuery = q.Encode()
+	http.SetURI(u.String())
+	return http, nil
+}
@@ -32,5 +32,5 @@ func init() {
 // AssetPostgresql returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/postgresql.
 func AssetPostgresql() string {
-	return "eJzUWk+P47oNv8+nIN7l7RbZoL3OoUCxr0AX6O7bh92ix4CR6ViILHklOZn00xeU5D+xncwkY8+2Oc0kFvkTSZE/Uv4Aezo9QmWc31lyP9QDgJde0SP88jV++e2Pf/7yAJCRE1ZWXhr9CH99AAD4TN5K4UAYpUh4yiC3poRuHTiyB7Ju/QDgCmP9Rhidy90j5KgcPQBYUoSOHmGHDwC5JJW5xyD8A2gsaQCNP/5U8fPW1FX6ZgIaf3o4yoh0nX7r6+nrQuHlQfpT+8OUtisa+fO7JsiMqEvSHiqyyQZQWSPIuRUb4ij1DqTOjS2RZbAZkO3nDfiCQNTWkvZnchtsYHLwBfqewFoUgA6cR0+AOmvWw4+a7GkNH1v/bE9nMsPvjKXabXj1plGy7j127qLmMzRh34wZetyio7WR2dkDjTmV0bvBD1csGqz66be4cWqlgy+kgy2KPekMJIeh1nGb3qyvA+N/J5Ht6XQ0doj6GXBfsKQZ0FWzWetrDA1ojNYhmdZcO7LrJXzFgkGZ3Y4ykDpE94uwTPjnBh/coRWrSkkRDuPmdcp7kuI5Hfj+BWCEkqT9GrPMknO3Qfn0FdK6BlCUdieGwjh/uz3+YZwPcloMrfIod8X5ylJlbMxKgGCJKwXBb1++gTJmX1e8OD6+4S1dxcmSZgrf7x+/AosDXZdbstGJPUNKB7XjpJkbC8KUZa0bfx+lL4JtR0KTrVdgLHz4C8gcEP6l5RM4I/aUhNIFX6TFG97RjXs5VZ0PUlFo3M710cmtomApB2gJsPbmgKKuS1BYa1GQXfW/PBq7J7sa6VFmJwUqdmkb/J2AqV+TJKjQolKk2i8YHpdbPUxHAEcrPT+SHNHaVBQk9pWROvzqPFpfVys4orIkSB742yMTDp2RDQXyiCoKW4+U/P3Jk3bSaAclnsDSTjpPNuFz0ceYZZK3gapN8cGI1/0XkE1GaYb+Vs/KkuBYkI5nOZEBOEYewMdqBXJN61Xz0GQiGInl5yJhmd6Kt6gdswSj32A7v7ZB29Pb3+M0yEBrFoPXniR1iqSMosJz2xvLhzxSMulAmyGUxOio5yCFzo9lTe8xSN6IAvVumsm8dpMROuMIsKKmC2COKL0c5dmIY2uMItQ3QrE1sf1GJKq1fFIJRgOCMmJ/xUy36f6YQs4ciFNTMsSQR3XZ84CqjumzY8MjoQB/Sv5+hO8F9fdETyTqsBdMhH1ytczUeG1jBS5FCJqO3SEvSxzW9r4okLp/qEaSJdu198AKtrW/FMn86Vxzw4YGKOAdbgMleM94pOvOj5OlVGiZu6R1kyDOANOToMqD0W0JDOK4MQv742/6ygVyCZ6UixrIWjNRLngnOTpfoS8gr3UjSqmrjuYlH87WTIvOpMOtomxoj5Y78SGxKPZN6ybJ8e/NurjP58he8NKNJ5Se/PBQ/OqgZObHVbfrPj/10mDKl2FR6CBHcrk7doMs2xmuEamBT6bxBffXLNytQPpu8ZigdKk18DnOa1HstZy2ocOwDX/WMP9G6SGsiwxYxtC7lsSeA3AP8SsirWMXRTCcIo6FFMUQzghES1t2kSO9ZhryzaOXzkvhALem9q3ySPESpWvrvWvHHP2pRaTbQ7c2M4sG5jjX3D656JikWztRUFarUUa4t6/4EtsJk0Mrua8vxmWBB4ItkYaKbG5seSk8+0gt/ajJ+QWQtpJnQuplSW4d/LUuh11ShJsrgzceue/GowIsTa1jTmIik0C6iNFVoXWLSZ9TJ2dp7i9bcCOpKSY59I4FWYJcqtQm8QY88yTDiXa/YsGlVEo6EkZnF9qAsSHcSYv/Zzsw/sIaLf8Txwo3GGNb5zlZt+4ZZfboTTpad2W15V30/XAd2wRxnR/V9jSdFF+AbZPXSs0OMMTmhUTtvKkqygAhAGBzOoEathTYE8hx/BSY9Q6MgRL1qd3G1T2mIrV8XEhLgutxGERdZUoDaJucj8BCHmihBBN60xAWkN6BOWoIygPXhHfa2BKVGrI4uODHAnWmgpeNo8AQus6v0ZoZ5pJR1/Qs5v1VG6FSRuASZalxYKvhcvPnNpYczToHYPrYECkXm4pEcY6cHgPVDErHlKq5HXgNpfpdE1hzDHdLjbzuVqn55sNRZn1s57dA7c3PJKOaQHk7lfp5dz9h+PLnQHhdgZYy9oaprbg0n/vJt0EroLLyp1sAh5OwMfkmiVygdibBvW5lfIPQ3qc9Nyt0a2HKclQcZsiUPR1tr9uz+hlRjRguposzvNYoxTb4uYgZBR9YvDTf2ioj9twB4PxZltlcUgCsYIT2KqRiCW+HutjHFTJuHiobKgZ5ashtrBEgUBScHqdG3ejDvRPzEwyDLdDEVBftCd6FnRqtWKBQdUYOCtkNjrqXC0aCzzWzWF5gKrIYhhju5DyVv7pApNN/8en3Vy3Kuw+evtQyZKbeqnsqWuwKWHRTQiK2ZOTtqUsHwxhYTY3iXkD/e1u62g6+ck8s+632ZM2RT6KvrV6iFTdHPoZRelPCw7TvRacygMvJi2IpbEn4ndCkdmQXGWEwtkb6neDqKluExwZsSfid0DJStBi0JPx2aMLoXEmxQE/f4BCoBXFtzGpiPtJqjBe0loQ5kD01eEcSn6EuVFbGoj2tw7Bj/kLWyE/DFGHp2RiAv416fRgJQksgTK3DHaSlHVpu88K7IMciDhrOl3DpG0lt4Lyj9W7NxdPGuzbuG10h9e79KlyjnysIV5hmt2EFmym7ATjylwfendG3Jz+b0YdDsVAJegO8oQXHLrgcO+ySO1zwjBcDG0kuWMLOGWEWiuD8jLGRDBn52Cq8LF/873Tqo5a9vTN8bc9+9lJovIriv8LLatO9/MQbomdSpT6Y9DpN81JokNu9EiqqGmqHu/haqA9HIXCuG94J7S5NX3e38pYvGXZDrGAVi5G0T9xGv/k7q/23RWytYXjze3YnO5+9OiytP6/p9fR04+XDH/FydbjuXKxApRaopu3AOpq2ve2w9VXjMseZtcro62z9Qugli/OxDCd09huhDla/iWm6vlrr7mr9OYCl1DPC+yy1LOtyVoD4NCdAfJodIOFFE94ed58J9ZzonM8yOsyH76upahVLlPOoM7QZZHSQXdXqzR/6SF94jRihl1Qae1rH4emMk6fh8UnT2TBCiBObOBNKV3jPmvgc54xDuxcADXOu+4Bm0no5W4/5AqxJ4Z1wE7l/O7iDm9yXwlVGoFowWoP8VwdrRLlgrI5h3hOqEeaykTpGemegRrDLxukY7J1hym3mkv5n+a92fwC5rEFHOKft+d8AAAD//5VSM/M="
+	return "eJzcXM2P4zayv/dfUcglMw8e471rHx6QTRZIgCQz2ZlFjgYtlSyiKVJDUnYrf/2iSEqiPv3Rck9vMH1IbKvqVx8sVhWL+gBPWD9CqYw9aDRfxQOA5VbgI3z3yX/4+Y9fv3sASNEkmpeWK/kI//8AAPAbWs0TA4kSAhOLKWRaFdA9Bwb1EbXZPgCYXGm7S5TM+OERMiYMPgBoFMgMPsKBPQBkHEVqHh3xDyBZgQNo9IWtS/q9VlUZPpmARn8RjsIj3YbvYj4xL5ZYfuS2br+Y4rbAkf4+SoRUJVWB0kKJOugASq0SNGZDijhxeQAuM6ULRgolNTDSn1Vgc4Sk0hql7dFtsIHKwObMRgSrJAdmwFhmEZhMm+fha4W63sKPrX32sWjgvycs5WFHT+8aJo2iAIYmAphWYazGlFm2Zwa3iqe9HzTqFEoeBl8saJT+Pv7ykxccW+pgc25gz5InlClwckMpvRtatV0GRv874OGRPWF9Ujq9DtzvrMAV0JWraeuTdw1olNYhmeZcGdTbe9iKCINQhwOmwKVVl2KZsM8VNriBKytLwRO3GHcvYx5R8ut0YPsLwCSCo7RblqYajbkOyi+fIDzXAPLUbsSQK2Ov18fPyliQkVI65p7uhuKVxlJp+mxfAwONtFMg/PT7ZxBKPVUlCeB/viORFnESpZXc98uPn4DIgayKPWpvxEiR3EBlKGhmSkOiiqKSjb1P3ObOviOiQdcbUBo+/B/wDBj8W/JnMCp5wkAUZ2wRHt5RiLpSlrrsbBA2hUBtS/u04XuBTlMGmEZglVVHllRVAYJVMslRb+IPT0o/od6M+Ah14AkToLFz/o7A1LeBEpRMMyFQtB8QPNpu5TAcAZw0t/RMMEQQZANJjslTqbh03xrLtK3KDZyY0JggP9KnJ0o4ZIrabZAnJjyxvsLp3z+fLUrDlTRQsBo0HrixqAM+423M0pSTzploVpFX4rL9HLIBQ3rMbUzXWpYXCKccpfO3JhmAk88DaFltgG9xu2l+NBkIRmTpdz5hmRbFaiYNZQlKvoI437dOG/GNZZwG6dKau8FrV5KogTRxRJ9H9XWvNC1ySqqQFrdUQygho8PIQIIZO6Y1LaOjvEtyJg94FyEdAyeTg+U5zSj8xLjlozjrceyVEsjklVB0haS/eJ8iNXaaDyxBSWAgVPK0oKbreP8YXE4dkUJTUMQwj+qi55GJyofPLhseEQX4n2DvR/iSYywTPmNSkfqAhYR98mmeivGzjRZoK2Ig8dQt8qJgMp0nBVzGi3lEmZNeox9sYF/ZOU+mv840Vwg0QAHv2N6lBO8JD29KGvoPXnDBNOUu4blJED3A+JxgaUHJdgt05KgwM45xjj3mCasMjncd+sckoNZqYrsgfWbM2JLZHLJKNqSEWDQ0PfKh98w06ZQbtheYDvXR5k60SDRLnprSjaOh75vnvJyR306uEmel61bJF3y2w+LiewMFZX6063bV5y9RGAzx0j3kKsgRXaqOzSDKdoprSEqglalsTvU16cRsgNvu4RHZKLS6fI7imie7FNN2eByW4WcV8yfjFtxzTrk+ivX8YBTEzgG4JfEj3w7JnwdDIeKU8yQHOxlDtg9DAPuDz5Fe0g35bJnlxlKXiO1VZVvmPsULKV273wcP4bbXtfDp9tCsTc+igRmc40Wdiy6TNFuT5JhWAtOV6orffTmhMmgpR5kr+TyzkLMjwh5RUuuI+kNz7hkj1fi1QmPvgLSlvBJSyws0W2evbTGsbMnLHyETil255L4oywSwQlW0bWdAXBqQxmM0Ja2BEPQpdFKUVlkEbkQ1+CS53ilHjZBx4fd557WWkjYFKTdPG4qyBReCG0yUTM2lijC1TP6b9UD4c60k/wvTK5Wxr7KMOsORUlb33sCjNVdaaZIiYnkG20Tiuj6qfT0dFC/Atssq0bTF1wNIy8fMBGpjVVliCgwcAFKnSZiEPbrsCfjYf3KWtrJapaBgsm7FWJQxbFKrCzi0QMo1JrQfu0ZU4HoRtF1GS2B1gN4CLRSnQquahAW4NaBOEhxzl2vCO0lnCULUkxX92I45kymtYpsrgy5d6Sq/hmuqKJf0vEZkSXf4flFHTAiVsHtsS4EDtBymjUVpp9lpNGjXLJGZ7RIp4wvlkOKcKDy6VNMx3T4METWnAy9JqT5KBK1OlCC09LpTpeaTDyeextj6p0Dtyc9kRtXQeFEq9e3Oflzz5X9dwmtypjEFjUZVOpnrz33j06ANYFHa+hrAbiXsVLYLJM1Kqo6WWCAclWExZi9QI940yqi2NttEFQW3q8OMebS1bqT1XqLqMcyGix5erYQg5X5bxISCDkjYXH9rT60vqgBYujpSyuYCAyAGI7SLkPJ7WNvtizEuF3Ezt7NRt5+ldZPc+j0CEpbkuAGjRmRdYkznTpSfMNeiBYmU6jJdwzsnqZKCCCaiStFAzrvGUTdcMCLc50xkCY8qUTMqtMHUxmLxvXEFRfg//+v3ixqlrMBZeq5kSFW1F3idct2O5qsCIt1sIR5bUPK+7sLB0Ac2U624C9L/SKTFcvCFMhHt15JJqxOtRFtpeY9SnPpiDfVmC+c4Rr8ALkNLrYf7YAvEb4TGpUF9lxYGYWuo3wiuKtO75LFEHALxG6GlKPBu0ALx66HRmJfgyR1q+gZHwmSC1EJLK6TWQ8vRH9BqTOj8JuwGEwfyy/gtFqXSTNdbioPrS9HSD82URONZH4AfRrU+jAhRVyahPhidQWo8ME1lniGep9w3GvqP0NY3otrAeYfbw5Y2T+02LkV1o8m5PLzfuGP0PgMiLtRhRwx2U3oDMGjnG94tsO2+tqspfdgUI31G/YiBOsyECeZ9h0xygwlGBPskyCSNCe6h5xRZ6rbflTTcuXVLGVK0vlQYufEkpLdTqT8MoUVDKw9DWFdU7f+KZl8iHMGcfuRiE85IKM00lsl0Xxtnfz4IpPEgjRHKmsnTkraej39O5CZOUqKf7BxFOHI8me1UC2CP9tImQJBi0qZD5V1g1M+eXDdIRFNYWhWRDmPEc+iWhy4Xl8IFIKcGMBH+/OFXCANIoaHefBN0tJ2FeGY28Vy74kLIczOMl6Kcm9u8Azxi5cblmt55ABfyAGqszOMMA4PTw5UrgR0PYZ5V3/K85Uq4RnOZAdbmklnMSYp9bZ4Vb2JMc43lNhzZnPCNeWihDJycF1vcgS4FNxyronXUawAuhK/lSaaV3OJzN+E0Fag2IPgTKVIjK0IemDCb5FW5AJeONkvNle7fp1jL5J8C6YEnkzb3CAkda0gaMm6OKFXV7qlnQN9T0X0wFgfoN82wEiMkGyiVRWk5ExugD0DphxFNd41D6aqYF0sYuTXjQZaVhPqVzjvIa9xpD20ZxKt163M6J3CUjuNroYtyf99JvDBOENBMVCZ/LaCOGaY3AaUMidWvhdRzuyLmCnZwHjlZ6Z0NDzQXw+wjzD18gQz/oEdppZEc1KIlNFDjFX7LDmGQ5c3I0Hh2EOM6p2GHuUbs8kzKhWBdPYaClW5rRnui4w3n4bSjhInBxqkEjVa7/gKN748ni/0/qSzPmjS1vaURJKVORVvmc3tmKmWoCQfs7ThnEwluNax7/u9k2EYhVxvWR6q3Y9k2cpJprzFpEORvZNNWFVcZlZ6qd3SOdK/UPaRooVlUihoKOiM8YJC4ufZ8kemot/JKVXLU0nFsz1dFMci1Wja9vhehUNlCuXOuW/NtNLedxVOK6sDlfRB9rGxZ2cCigdZcpVuGNTEvvhKo+EIhYdhAmdfGQVK6QTcPrO0Cj6eDzobfS1Q2MSl0leJafK/jaLeBbE8LJugv3bm6EGB39wqdtmi8qmU5j4pGWo74epD2SHvR+NLsGNPutXq8hsbFlYw68MuGpIpS43Lf6UW+9lGkOK7VvlZcd7VaoqSpinAseRFk9w4PXWC6u2M53MNclVSWtUc5I8zxuqFcYpJiC7vLVCg1YPPCarSMS0zfRrrYoGm38EVDDYy0o5zxTYhBWW9niijtXTLqvJwnJqhzaytzHz/84ci4YHsuol4jWYMOas1oMRHWpok3SY6OOPWRToaR7pmn9F+VbD6lwVWhzIK4hmW4czLzv95I58El8/4OQFtw7zFTGluVhAulKV0h1nOqOfgz64ECWsGbq5sP55LSa97NQyf9tZukdmdK0yPVEy/q6VHl8tgEqubdPI5u92aepKygciUDFb/WTSRQydIfyu4RHb2ap7u7Onkoe/Fc9mu+66W7S+CcRDMq/XB8Ua9D92qvDgoo3KyFriQoOQ3I/Wo9fXVYWnsu8bX4bK9j8AfhhdFzfbIZp4VY6vF1r6WIeYbzz8zkjXD+Noq7FhYu3eKz3YT34aR0ppHxcMfIUMk6dQGZXpCglaG3raA2m9Z4fiQierPYnJTUbjIr2W3qdpTj0l2t09WiC9FA3Upg/EiT7M/qDUdD60W/ouDj4tC4KzjfPboSVtzACeO4upKyu8d9DmDB5YrwfuOSF1WxKkD2vCZA9rw6QGSzKrze735DJtdEZ2ya4nE9fJ9UWYnQtqH+FtMppHjk3d4cDbvHSM/0G/vQCyyUrrf+atGK1xyGyydcBXLz6v56gL+AEFLNsyru41zxhsgFQInbjUBTri3HV8QaGN4IN2S8rwe3TbGvg0u1tLijtzr6L3ZWR+WevjqGeYurOip39tQx0hsd1RG6s5+Owd7optRovKf9if6LzU9E7qzQEc5pff5nALulEg4="
 }
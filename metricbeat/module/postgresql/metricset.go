@@ -21,6 +21,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -52,15 +53,16 @@ func (ms *MetricSet) DB(ctx context.Context) (*sql.Conn, error) {
 	return ms.db.Conn(ctx)
 }
 
-// QueryStats makes the database call for a given metric
-func (ms *MetricSet) QueryStats(ctx context.Context, query string) ([]map[string]interface{}, error) {
+// QueryStats makes the database call for a given metric, with the arguments
+// of the placeholders of the query.
+func (ms *MetricSet) QueryStats(ctx context.Context, query string, args ...interface{}) ([]map[string]interface{}, error) {
 	db, err := ms.DB(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to obtain a connection with the database: %w", err)
 	}
 	defer db.Close()
 
-	rows, err := db.QueryContext(ctx, query)
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query database: %w", err)
 	}
@@ -94,6 +96,24 @@ func (ms *MetricSet) QueryStats(ctx context.Context, query string) ([]map[string
 	return results, nil
 }
 
+// ServerVersion returns the version of the server, as a number like 130002
+// for 13.2.
+func (ms *MetricSet) ServerVersion(ctx context.Context) (int, error) {
+	results, err := ms.QueryStats(ctx, "SHOW server_version_num")
+	if err != nil {
+		return 0, fmt.Errorf("QueryStats: %w", err)
+	}
+	if len(results) == 0 {
+		return 0, fmt.Errorf("server version not found")
+	}
+	value, _ := results[0]["server_version_num"].(string)
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse server version '%s': %w", value, err)
+	}
+	return version, nil
+}
+
 // Close closes the metricset and its connections
 func (ms *MetricSet) Close() error {
 	if ms.db == nil {
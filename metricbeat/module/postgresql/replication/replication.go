@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/postgresql"
+)
+
+// PostgreSQL 10 renames the functions and columns about the WAL from *xlog*
+// and *_location to *wal* and *_lsn.
+// Based on: https://www.postgresql.org/docs/10/release-10.html#id-1.11.6.38.4
+const (
+	// standbysQuery queries the standbys replicating from the server, with
+	// their lag in bytes and in milliseconds, and the slot they use.
+	standbysQuery = `SELECT r.*, s.slot_name,
+	pg_wal_lsn_diff({current}, r.sent_lsn) AS sent_lag_bytes,
+	pg_wal_lsn_diff({current}, r.write_lsn) AS write_lag_bytes,
+	pg_wal_lsn_diff({current}, r.flush_lsn) AS flush_lag_bytes,
+	pg_wal_lsn_diff({current}, r.replay_lsn) AS replay_lag_bytes,
+	EXTRACT(EPOCH FROM r.write_lag) * 1000 AS write_lag_ms,
+	EXTRACT(EPOCH FROM r.flush_lag) * 1000 AS flush_lag_ms,
+	EXTRACT(EPOCH FROM r.replay_lag) * 1000 AS replay_lag_ms
+FROM pg_stat_replication r
+LEFT JOIN pg_replication_slots s ON s.active_pid = r.pid`
+
+	// slotsQuery queries the replication slots of the server, with the WAL
+	// they retain.
+	slotsQuery = `SELECT *,
+	pg_wal_lsn_diff({current}, restart_lsn) AS retained_bytes,
+	pg_wal_lsn_diff({current}, confirmed_flush_lsn) AS confirmed_flush_lag_bytes
+FROM pg_replication_slots`
+
+	// currentLSN is the last WAL location of the server, or the last location
+	// received from the primary on standbys that have cascading standbys.
+	currentLSN = "CASE WHEN pg_is_in_recovery() THEN pg_last_wal_receive_lsn() ELSE pg_current_wal_lsn() END"
+
+	standbysQueryOld = `SELECT r.*, s.slot_name,
+	r.sent_location AS sent_lsn, r.write_location AS write_lsn,
+	r.flush_location AS flush_lsn, r.replay_location AS replay_lsn,
+	pg_xlog_location_diff({current}, r.sent_location) AS sent_lag_bytes,
+	pg_xlog_location_diff({current}, r.write_location) AS write_lag_bytes,
+	pg_xlog_location_diff({current}, r.flush_location) AS flush_lag_bytes,
+	pg_xlog_location_diff({current}, r.replay_location) AS replay_lag_bytes
+FROM pg_stat_replication r
+LEFT JOIN pg_replication_slots s ON s.active_pid = r.pid`
+
+	slotsQueryOld = `SELECT *,
+	pg_xlog_location_diff({current}, restart_lsn) AS retained_bytes,
+	pg_xlog_location_diff({current}, confirmed_flush_lsn) AS confirmed_flush_lag_bytes
+FROM pg_replication_slots`
+
+	currentLSNOld = "CASE WHEN pg_is_in_recovery() THEN pg_last_xlog_receive_location() ELSE pg_current_xlog_location() END"
+)
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. After the
+// MetricSet has been created then Fetch will begin to be called periodically.
+func init() {
+	mb.Registry.MustAddMetricSet("postgresql", "replication", New,
+		mb.WithHostParser(postgresql.ParseURL),
+	)
+}
+
+// MetricSet holds any configuration or state information. It must implement
+// the mb.MetricSet interface. And this is best achieved by embedding
+// mb.BaseMetricSet because it implements all of the required mb.MetricSet
+// interface methods except for Fetch.
+type MetricSet struct {
+	*postgresql.MetricSet
+}
+
+// New creates a new instance of the MetricSet. New is responsible for unpacking
+// any MetricSet specific configuration options if there are any.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := postgresql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{MetricSet: ms}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	ctx := context.Background()
+	version, err := m.ServerVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	standbys, err := m.QueryStats(ctx, queryForVersion(version, standbysQuery, standbysQueryOld))
+	if err != nil {
+		return fmt.Errorf("QueryStats: %w", err)
+	}
+	slots, err := m.QueryStats(ctx, queryForVersion(version, slotsQuery, slotsQueryOld))
+	if err != nil {
+		return fmt.Errorf("QueryStats: %w", err)
+	}
+
+	eventsMapping(reporter, standbys, slots)
+	return nil
+}
+
+// queryForVersion returns the query to use with the given version of the
+// server, with its current WAL location.
+func queryForVersion(version int, query, oldQuery string) string {
+	if version < 100000 {
+		return strings.ReplaceAll(oldQuery, "{current}", currentLSNOld)
+	}
+	return strings.ReplaceAll(query, "{current}", currentLSN)
+}
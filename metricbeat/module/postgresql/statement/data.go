@@ -18,6 +18,11 @@
 package statement
 
 import (
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+
 	s "github.com/elastic/beats/v7/libbeat/common/schema"
 	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstrstr"
 )
@@ -77,3 +82,25 @@ var schemaOldTime = s.Schema{
 		},
 	},
 }
+
+var (
+	// placeholderList matches lists of placeholders, like the values of an
+	// IN clause, that are normalized by pg_stat_statements with a placeholder
+	// for each value.
+	placeholderList = regexp.MustCompile(`\$\d+(\s*,\s*\$\d+)+`)
+	placeholder     = regexp.MustCompile(`\$\d+`)
+)
+
+// fingerprint returns a hash of a query normalized by pg_stat_statements,
+// that identifies the same query across users, databases and servers, unlike
+// its ID. The spacing, the case, the numbering of the placeholders and the
+// number of values in lists of placeholders are not taken into account.
+func fingerprint(query string) string {
+	normalized := strings.ToLower(strings.Join(strings.Fields(query), " "))
+	normalized = placeholderList.ReplaceAllString(normalized, "?")
+	normalized = placeholder.ReplaceAllString(normalized, "?")
+
+	h := fnv.New64a()
+	h.Write([]byte(normalized))
+	return strconv.FormatUint(h.Sum64(), 16)
+}
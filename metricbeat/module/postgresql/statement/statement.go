@@ -25,6 +25,8 @@ import (
 	"github.com/elastic/beats/v7/metricbeat/module/postgresql"
 )
 
+const statementsQuery = "SELECT * FROM pg_stat_statements"
+
 // init registers the MetricSet with the central registry as soon as the program
 // starts. The New function will be called later to instantiate an instance of
 // the MetricSet for each host defined in the module's configuration. After the
@@ -41,16 +43,32 @@ func init() {
 // interface methods except for Fetch.
 type MetricSet struct {
 	*postgresql.MetricSet
+	topN int
+
+	// version of the server, resolved on the first fetch with top_n, and
+	// again after a failed query, as the server may have been upgraded.
+	version int
+}
+
+type config struct {
+	// TopN limits the statements reported to the ones with the highest
+	// total execution time. All the statements are reported if it is 0.
+	TopN int `config:"statement.top_n" validate:"min=0"`
 }
 
 // New creates a new instance of the MetricSet. New is responsible for unpacking
 // any MetricSet specific configuration options if there are any.
 func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := config{}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
 	ms, err := postgresql.NewMetricSet(base)
 	if err != nil {
 		return nil, err
 	}
-	return &MetricSet{MetricSet: ms}, nil
+	return &MetricSet{MetricSet: ms, topN: config.TopN}, nil
 }
 
 // Fetch methods implements the data gathering and data conversion to the right
@@ -58,8 +76,20 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 // of an error set the Error field of mb.Event or simply call report.Error().
 func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
 	ctx := context.Background()
-	results, err := m.QueryStats(ctx, "SELECT * FROM pg_stat_statements")
+	query, args := statementsQuery, []interface{}(nil)
+	if m.topN > 0 {
+		if m.version == 0 {
+			version, err := m.ServerVersion(ctx)
+			if err != nil {
+				return err
+			}
+			m.version = version
+		}
+		query, args = topStatementsQuery(m.version), []interface{}{m.topN}
+	}
+	results, err := m.QueryStats(ctx, query, args...)
 	if err != nil {
+		m.version = 0
 		return fmt.Errorf("QueryStats: %w", err)
 	}
 
@@ -71,6 +101,9 @@ func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
 			execTimes, _ := schemaOldTime.Apply(result)
 			data.DeepUpdate(execTimes)
 		}
+		if query, ok := result["query"].(string); ok {
+			_, _ = data.Put("query.fingerprint", fingerprint(query))
+		}
 		reporter.Event(mb.Event{
 			MetricSetFields: data,
 		})
@@ -78,3 +111,14 @@ func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
 
 	return nil
 }
+
+// topStatementsQuery returns the query of the statements with the highest
+// total execution time, limited by its argument. The column of the total
+// execution time is named total_time before PostgreSQL 13.
+func topStatementsQuery(version int) string {
+	column := "total_exec_time"
+	if version < 130000 {
+		column = "total_time"
+	}
+	return "SELECT * FROM pg_stat_statements ORDER BY " + column + " DESC LIMIT $1"
+}
@@ -55,6 +55,7 @@ func TestFetch(t *testing.T) {
 	query := event["query"].(mapstr.M)
 	assert.Contains(t, query, "id")
 	assert.Contains(t, query, "text")
+	assert.Contains(t, query, "fingerprint")
 	assert.Contains(t, query, "calls")
 	assert.Contains(t, query, "rows")
 
@@ -98,6 +99,19 @@ func TestData(t *testing.T) {
 	}
 }
 
+func TestFetchTopN(t *testing.T) {
+	service := compose.EnsureUp(t, "postgresql")
+
+	config := getConfig(service.Host())
+	config["statement.top_n"] = 1
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	if len(errs) > 0 {
+		t.Fatalf("Expected 0 error, had %d. %v\n", len(errs), errs)
+	}
+	assert.Len(t, events, 1)
+}
+
 func getConfig(host string) map[string]interface{} {
 	return map[string]interface{}{
 		"module":     "postgresql",
@@ -0,0 +1,39 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package statement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprint(t *testing.T) {
+	query := "SELECT * FROM users WHERE id IN ($1, $2, $3) AND name = $4"
+	assert.Equal(t, fingerprint(query), fingerprint("select *\n  from users where id in ($1) and name = $2"))
+	assert.NotEqual(t, fingerprint(query), fingerprint("SELECT * FROM accounts WHERE id IN ($1, $2, $3) AND name = $4"))
+}
+
+func TestTopStatementsQuery(t *testing.T) {
+	assert.Equal(t, "SELECT * FROM pg_stat_statements ORDER BY total_exec_time DESC LIMIT $1", topStatementsQuery(130002))
+
+	// Older versions of pg_stat_statements name the column total_time.
+	assert.Equal(t, "SELECT * FROM pg_stat_statements ORDER BY total_time DESC LIMIT $1", topStatementsQuery(120010))
+}
@@ -32,5 +32,5 @@ func init() {
 // AssetRabbitmq returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/rabbitmq.
 func AssetRabbitmq() string {
-	return "eJzsW0tvHLnxv+tTFHSRDcgN7VWHP7B/7Wbjgwxv7E0OQTCoIWu6GbHJNos9o0mQ7x4U2fPu1mikHnuTeA4G3M2u+tWD9SL1Dh5oeQsBp1MT6y8XANFES7dw+af06P7XywsATayCaaLx7hb+7wIAYPUaaq9bSxcAgSwh0y2UeAHAFKNxJd/CXy+Z7eU1XFYxNpd/uwCYGbKabxOdd+Cwph0E8ovLRigF3zbdkx4Mu5S2qc0rz3H9dEXugZYLH/TW816i+fdnE2KLFoRSogoLEytw3r378dPd+/egKgyoIgUGYoUNaUAG4+CuOMCjvHOkhM0BqG0Zj0DqpbKr9tVvXzHbYOTfnRfD+jkCSH6fK8rq8TOIFW2BfInCnjLiBihag7z3psFYbdyo6Pu4NmXALEcM7b4Ojsg5ij9si9cyhROlk0+KHvO9SrDfmEKC1A/Ted3vLc8wgnw7Ot4PXtMTeDliHNG97zbunCj3M1UVOkd2Xx2Zr/WufMGeauspBdlVK+Lg3d4OexLMpMbHEfHU+Gjqtu7Dhdb6Benn4psFrGlEdPcdsoZCbZjN1BKw+UeKSJi5wRvjYLqMxG8henBU+mgwdntYWUMucrFHeeZDjfE2f9criSAeMZIum54o2q/DwdD4Is6fKMwpJKI5lE8jGkca5gYh0JwCE/z04dM1+AAmMrz/CKh1IGYws+0VMENjxRUCLJBBG8apJd0vREMUinEl+UhnkcP5COSeEsWHfilOd+fOGELxCbWNyDDp7Al2qB4oTpRvXSyY3FhsP6wDSebAIMSfG0R2UAVSZOa07yvjIVsxeBG6hpw2BxjGA9fRfy42r+J5zZkYnGbNbUxnM2aH61Rb5uwwaYKfG026r555RcBK1Rc3pMzMkN6unndLnBUaepS0W9Jr+ogeGr+3LuJLS+2rK+z/8gZCt0EyUq+AU+8toTsN4V8qipXslZAS3sYO3Ia5mZPs6ZSbAnHEsF8xrXBhG/1Ek6WBOvxV2Ladw1qYEmROOrGtMRqF1i5hUZED51PAoCCd00DmNi5ScGjPA3W11cDwmtM1mIIKUOhEyyKBCaSiXULTTq3hirQUqdMlYBd8/gMayEXlQQVCscS24BvovTLUxIwlcdGJPjGuSGlgpOgPd0JMosqK05aSL427FE2jW8PtbJPcHx8kq6JSKxLBt9G4st8afZJoimgsF2GoJZ1Zj/uiHpMI/ugXULeq2nWwju074yADrpAhv9PSGgGT8k5LPJHvaolCkgpdBMa6sSJr8tA52mdK6Nv4FY3l23iZWroDa73WUiLHtzCVb+OYthqY2JxcHux9f3ppoA0/FLNAVBy2zi/3jp8MP4BQBW5QEaw6+tPb9g0+a2oTR0X50RsXASMsKtPZXdgBWgx1zlmlBz+bnQ57povo40CmOh3pH4yl9RofGHCOxkpFMTC20YVk0ZG4/8akYbYHoZ9xqQrX1qNGmk1T8Msd+IZy8hvmH0hZNDXpUX3ll7tsbdiQP9kpjC9Ei5MKnbZU+IbcBGOkuokFzsuiHgtscpfMRTTmAOclRLNXSjwH1piGPESVmQwqKxDq8ylGqB9VTIIwamTEiJn1Kia+xI0SrDFts4G12WHDzMV652CfvOI4ACZ6GNcvEn8he9QhEu/RRU+snyH40qlzCL506rjgwnt8wYX1ccEXwUQ6g+SJ7lHRM/fRo0Bm/powkIGNbpQM7IhVaqq/TklWU+3DcoyiTCCn4cKYiO8zPKE7UOau2Ttig0WqaOPjmWqk+8QEYkDHmIaT3OmywjnBlMhJ1yLKSt0/SrfypTWBdLY7S5edMD4lRMD6dyWDNjoNAjpZnicKlwVHH2gyejrdyLLujvcFSMl2FnzdOXlaBgnPMbjjb/tn4BWmkZyo9JmAXzF1PgS4ukjQy6kJXo3ab90fHKD/HCy6MnEi5qEtnoCM2Hl9GOQvsablAc13q3wYK8htcCgfiEFTJJUGuU5Dm86NYbrsQPZjSoPgwjhNj8XffRsc2rM6ciDlg+Z9v83z6AQDOhjH8Z4xPqxg7oaDLZTH0X1bLfbjC60r0qKREP04pyDhZnhDwgJNNK4UiMK9FxZ79UDxK41mpCKRPQrIHeOBqNGhGjFuHIBK5ckxHONekUkhW74fOBFpDkruVzis8MoUD2es+3548pB1n8D3A9jvB7D/mwew9Khsy2Z+VqyGN3zgTTo1qpDBLxyFSWP02++XXweCQiK5ExUK+CBtsRj5KrTOGVdeXcO0jVDjUhzi8p+8dMq48hruuUyHev+6BDPbtYesqYJ3ho0rC/hVnq9aBAwE1qt0muwdiOEihWQOzs2ZrFBtCOSiXYL2C7f2R67y/2IFmOC3LPivZNHVwI4JZVunO6A1Pk6aYHwwcXm2wn/FACzNyXJK6hvdRA/cNsMX8pR33NYU+Ez12Yb+EfZtNNZw8uWiUf1AWKElPek7PZVXxpWTGarowy38cHMzMH5pKKjDm2rH6pWQ+/yV70oihzdTigtpPW+Km9Ro/FDcvM0eteOcqdaKHkxdkzYYSZyMrJHgu25no99SBnyuDINCJy5oiZOfOqEvnr9el13b1EZce7oER3Hhw4MsKIkz3gBNoBlFVeXj4SPH2KnyHNUXPrV1LtRRL3M75lA9OL+wpEvSGw28yQrT1MRqIITuwTzHOfvOKfsWojNegUiqOfe4JOs/+i7JivPlq0G9F9YHMJ5d4erg0kbGfT7l73rjua3Qp/mU7aREW1KEHTAnIf96thnawGe8JESBDUdy4x74fpYosp1K12w2QnXocyR4k8oCtAtcsmykm5xtAzoWU+ZVPBi8ah+Wp832h88Mjgr3/+k2QHK+dADQZY2UKESi3fkEILNXJhVJqdpZC30NxinbplvhHFE9XENF2KRQvrqFCBxDq2IbhuaOacgtW3ncOmPfgJKWedshD4fZ6QJNUoHhVUNknCIwEVIfNLTvkgh5bP9tZNgacZ0kxLqer/yc7NE5w3p4MKWIz5s/HFD+dqOGDOX7rOFJ8b53orudaHaa4VZU+dZqifdXaXPJCymsryKF2jiJmQOt4Mh/01fleeU+7J9NGlAIuGhUhqaXDmujroqLfwcAAP//9NY4nA=="
+	return "eJzsnN1v47gRwN/zVwzykl0gK+y95qHANffRfchi73bv+lAUDk2OLTYUqSVHdtyi/3sxpCR/Sf5IpOTaLrA4+Gx55jcfHJJDOu/gAVc34MV0qqn4egFAmgzewOWv8a27Xy4vABQG6XVJ2tkb+NMFAEDzMRROVQYvADwaFAFvYC4uAAISaTsPN/C3yxDM5TVc5kTl5d8vAGYajQo3Uc47sKLALQJ+m1YlS/KuKut3Ohi2JW1KW+QuUPtuI+4BV0vn1cb7nULTv9+1p0oYYEmREZaacrDOvvv+8+2HDyBz4YUk9AEwSFGiAhFAW7jN9niksxYl+29Dx76NR5A6pWy7HaDbMZsw/N+tD/r9cwSI/33JMRoJbgaU4wbkUxx2KIhrUGG0CDuflILydRplXV8u9NwLJrsB8hWeZ+cg+bBpXhXQn2kdfyXrCN+zDPstoI8GdWNap/BMzDYI/N3BeT86hQd4AwkaML1v1+kcJXcrlbmwFs2uO5Je4+z8PKVxTFXFFD2PqkY4OLszwg7CTArxOCBPIR51URVdXMIYt0R1Kt/MiwIHpLuryUr0hQ5BTw1C0P9E9p1I2uCNtjBdEYa3QA4szh1pQfUYlkajpbBNCzBzvhB0k77XaQnHd7hU+7IqO6potw97S+OTNH9Gv0AfpzoWD25KQltUsNACPC7QB4QfPn6+BudBU4APn0Ao5TEE0LPNJ2AmtOFU8LAUAZQOYmpQdRtRIvpsWEs+4Sh2WEeA9pApztNA6VwHo3SeDrhtQIWf8LA6IR+QJtJVlrKAdii1H9tCkjQEYOGnFpEtKo8S9QLVaGSNgifRlWiVtvPR4Gr5p7I5SeOGMyo4L5qbTKMFs+Y6N5ZpdpiU3i20QtW1nnlGwYqrr1Ci1DONagNmZ4nT0OAjT+9zfM4+okPGH20X8bXCCr9tIA5uIFTleUbaUZSyYeqcQWHPI/xrjpTzGPZxwlvHIVR+oRfIYzrOTR4DCU+hm0tU5CYKDdIIbJvJYQxMEZImFdUWgrQUxqxgmaMF62LBQA9V6Ju5tSX0VphxUJuhBjq0mq5BZ5iBFJa9zBZoj5LMCspqanTIUfEidboCURef/4IN5DJ3ID0KjsSm4Wv0ThsKDEHMMWS16RNtszgNdBp2fvWHWxbGVaXRtOHkS20v2dPCtrh1bDgwJB54VhVSNiK8q7inlZ1siUIS2oTM921JZ8YJOtMi+ItbQlHJfDvBarXvtIUEnIvAFdPOUUHJkwxKZxWXS/5ewVWIp0JLEERRGrY1ZuhCmBMtdBW9YLBcRZccyP1oPTdSbMdrhMpVNGSsejo2Zy8Pdr5//tJA6fCQzTxitr91fnp2/KDDA7BUCKWQCM2O/vxt+5rP6ELToJSfnLYEgmCZ6zrurA6EEb5Ic9bcgZvNzseeqYwcCTMQ6U/aYPuM8wHEQmjDK4qsTz3PogNp/y2ggtkOQrfiucxsVQxaadabgp9vwZWYJr+e9cxcZh6lEbpANWiu/HybekuwFn92UmiXsRcnubDKYOZKtBNBhEVJmVjMs2Io2JguSQt7zIJYzIF0gWdjDRnIfaqkpNdZHoUazzEs/ahj+KFB8+gHQdymE6qtiU9JIxYw6CBbY61HWL9yjt4Y6lnuCQAB8WHYvIjms9ijCcEPDW86Sz3F8JWVYxi+svK44ax7eMNZ9XHDl14TjmB5lHvU9PjU8FUgin1WGYgShg9KFHssKgUWL7MkK7BwfjXEooyReVk0KPFdwmO5bSyzbvUWgxYZLzEzehxpjXQXlQB5YYOIzclQ+zIXC4QpouVdCzsr7v4F71a+VtqjSnEPvMuOjIeM8KL4Q9mgtIp9sNqW00wJ8yyQ8zgZfDpd29LujncNYJ0w866okzw+BpHnGO7ww/4EXlZKaDk7TgR+Rte5/yJBp6bSOznofutu7wD9R2+EnUdNGELfEOePh9x5fezVz7WmCj2er59yPgzOIZ3HAAoJJbcPhVVQxXNjmK5qyG6m2AjOtFX4mP3DVdxcHTWRPUrnVdjN24gBEQNqjOO8I9aHBnO7HGxQHqd7XS928/nKZpFxIKLvF+i53PQPSFgKzR1fDjRr78QKjs96X6g1w1s57uLzyVBSHA5SDVg39qBY9lGOYa/IxLtfLLJbWVXuLbmfbm/UlSRmF7uadvPw7CbrroBvB7DfDmD/Pw9g8VGaKujFqKw6rPXAm3jGx+djbmnRT0qt3naz7RyFnJW//wOXX7koRJFbVSGDj9xP5FP2K19Zq+386hqmFUEhVnwif/mvsLJS2/k13IV5PNT79yXoDRF8HM7P5N5ZHfikDn7hODVbBOERjJPxNNlZ4AQhvqXsFIa0weQnZOU9Wj44V25p23wMefo/ykFE/Cow/xU/dNXtN+HnVRHvgBbicVJ67bym1UATyf7Cv1EABhd8gZYn9bVvyEGoyv4LedLZUBXow0jrs7X8I+or0kaHmMtZKbtBghQG1aTr9JQ/0nY+mQlJzt/Ad+/f97RfSvRy/6baEYN+4qmKL1LVucsTObyZIi15q/w+ex83Gt9l79+mjNpKzrj7IAe6KFDxTWFOMjSai2+7nSW34Qz4kuvAlzp4BBi+UUq5sPBd9p4zv30uJm7scaHi7Y1FWjr/wILmGNiVXP1LjzMkmafj4e44NBhp5TloLnyuCk5T3j+sopcqK+SDdUuDiq8TtB54kxymsKT87UmYY5yzb52ybxCNeAUiumak8dcoqf3PV4GwST7ur7n6VlA4iXF0h7eXLna4x3P+djaOHYUuz8fZjpdoKyTYgjmL/OVis614bdx4QSrRBx2Ib68NGaAvXOw2p9JWzdqomj5VgjdxWSDMUqwCD6T3PKukPjDf603rw9BbvLgRf15vv//M4Khxf2Y9HK76fKKeNeJEwfHY7k+ACMFJnpxU2sm0Rl+DttJUfOucF0Dy4RpyFGUs5c0tRAjkK0mV7+s7xn4915AwagB5Wg7r2HU1s5kkuUCHeooO2vIVIGLzPPWNO/5iamW9kg0bLa6zjGgM+Fo5XxVH+wxt82CKJC5OMuOXKLnO/pgYgTyKImSv0n74OHTrYbyfZtUj7D7F5p6Xa/fJd/fdKAaFQj8cTGxM1TBJ9hZaN0SBnKxhtMmSX4UTOJw12o7VWU7Cz6BphuxobumYkbpJvJh1698d6ycA/CpmtLHxFbVD0uuvGwN/G6V7vB9rLxxL5hN4t5jb1E7Q12D0A8J9SvT7a7ifufhzU59GnnV8MHWf7vtr4sYCr8vqvkTWa0qdI7kux7HnrpXfbc/CUWNC6V3hiHec9/28hH5zHjiaoydi3qYWSnI/69imPeA/IXNtccK/Dd3+gwRDof2eJDdAMfcatc2bnVm8hpSuKDRNjJiPwPejJa+xrTLGzZuX9XBr+1SckAmFl2orpH5i4/pRuyrBoVG7JVcEmsSjvc7HjnjjRI/wvw+so/WJCPy7WfKr9h23cym/E7ReNr0S6s6i7QReUZZG7x20vRRvrb05R90aKIfh6/HxOnmxHhHRjMOkwYoy5O61WBv1hyEN2jnlI8N1lJ20GToC23oyLlUvTi0wR6g+R2m8IyUnnYECyWtZLzvSqji7OK1cNYD1r2P6FqtHfXmCH9crtLWyxqHd0J14zUs1AuJds5lslTRNgGchS2dnmq/pjYnsZgdBU7t9iXyC0+CcBI/eO/8HQE9/46IfuW32v0ACt7q2QU+Di69G9eduB+sZuG42CxgXc9n+344ZBLo+p0uKwIh2QXeUuqENuVuguThWWJ/Qr9mT3FVGx+nN7F4NSSjf7oYcvBvy7ebA9s2BlDT9Vwekq4zi/vxVbIbyB7w5vuI9qbbc4756kUYfHxSvyj3sH3W8UMJwpGVCUysrCi2vsov/DADgmLLU"
 }
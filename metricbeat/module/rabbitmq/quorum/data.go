@@ -0,0 +1,169 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quorum
+
+import (
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var (
+	queueSchema = s.Schema{
+		"name":   c.Str("name"),
+		"type":   c.Str("type"),
+		"leader": c.Str("leader", s.Optional),
+		"messages": s.Object{
+			"count": c.Int("messages", s.Optional),
+		},
+	}
+
+	// Members of quorum queues, as reported by the status endpoint, and
+	// by rabbitmq-queues quorum_status.
+	memberSchema = s.Schema{
+		"state":           c.Str("Raft State", s.Optional),
+		"membership":      c.Str("Membership", s.Optional),
+		"term":            c.Int("Term", s.Optional),
+		"machine_version": c.Int("Machine Version", s.Optional),
+		"log": s.Object{
+			"last_index":     c.Int("Last Log Index", s.Optional),
+			"last_written":   c.Int("Last Written", s.Optional),
+			"last_applied":   c.Int("Last Applied", s.Optional),
+			"commit_index":   c.Int("Commit Index", s.Optional),
+			"snapshot_index": c.Int("Snapshot Index", s.Optional),
+		},
+	}
+)
+
+type streamQueue struct {
+	Name  string `json:"name"`
+	Vhost string `json:"vhost"`
+}
+
+type streamPublisher struct {
+	Queue     streamQueue `json:"queue"`
+	Published int64       `json:"published"`
+	Confirmed int64       `json:"confirmed"`
+	Errored   int64       `json:"errored"`
+}
+
+type streamConsumer struct {
+	Queue     streamQueue `json:"queue"`
+	Consumed  int64       `json:"consumed"`
+	OffsetLag int64       `json:"offset_lag"`
+}
+
+// streamStats contains the publishers and consumers of the streams, reported
+// by the stream management plugin.
+type streamStats struct {
+	enabled    bool
+	publishers []streamPublisher
+	consumers  []streamConsumer
+}
+
+func queueEvent(queue map[string]interface{}) mb.Event {
+	fields, _ := queueSchema.Apply(queue)
+
+	if members, ok := queue["members"].([]interface{}); ok {
+		_, _ = fields.Put("members.count", len(members))
+	}
+	if online, ok := queue["online"].([]interface{}); ok {
+		_, _ = fields.Put("online.count", len(online))
+	}
+
+	moduleFields := mapstr.M{}
+	if v, ok := queue["vhost"]; ok {
+		_, _ = moduleFields.Put("vhost", v)
+	}
+	if v, err := fields.GetValue("leader"); err == nil {
+		_, _ = moduleFields.Put("node.name", v)
+	}
+
+	return mb.Event{
+		MetricSetFields: fields,
+		ModuleFields:    moduleFields,
+	}
+}
+
+func memberEvent(queue map[string]interface{}, member map[string]interface{}) mb.Event {
+	event := queueEvent(queue)
+
+	raft, _ := memberSchema.Apply(member)
+	lastIndex, lastErr := raft.GetValue("log.last_index")
+	if commitIndex, err := raft.GetValue("log.commit_index"); err == nil && lastErr == nil {
+		_, _ = raft.Put("commit_lag", lastIndex.(int64)-commitIndex.(int64))
+	}
+	if snapshotIndex, err := raft.GetValue("log.snapshot_index"); err == nil && lastErr == nil {
+		_, _ = raft.Put("log.length", lastIndex.(int64)-snapshotIndex.(int64))
+	}
+	event.MetricSetFields["raft"] = raft
+
+	if v, ok := member["Node Name"]; ok {
+		_, _ = event.ModuleFields.Put("node.name", v)
+	}
+	return event
+}
+
+func streamEvent(queue map[string]interface{}, stats *streamStats) mb.Event {
+	event := queueEvent(queue)
+	if !stats.enabled {
+		return event
+	}
+
+	name, _ := queue["name"].(string)
+	vhost, _ := queue["vhost"].(string)
+
+	var publishers, published, confirmed, errored int64
+	for _, p := range stats.publishers {
+		if p.Queue.Name != name || p.Queue.Vhost != vhost {
+			continue
+		}
+		publishers++
+		published += p.Published
+		confirmed += p.Confirmed
+		errored += p.Errored
+	}
+
+	var consumers, consumed, maxOffsetLag int64
+	for _, c := range stats.consumers {
+		if c.Queue.Name != name || c.Queue.Vhost != vhost {
+			continue
+		}
+		consumers++
+		consumed += c.Consumed
+		maxOffsetLag = max(maxOffsetLag, c.OffsetLag)
+	}
+
+	event.MetricSetFields["stream"] = mapstr.M{
+		"publishers": mapstr.M{
+			"count":     publishers,
+			"published": published,
+			"confirmed": confirmed,
+			"errored":   errored,
+		},
+		"consumers": mapstr.M{
+			"count":    consumers,
+			"consumed": consumed,
+			"offset_lag": mapstr.M{
+				"max": maxOffsetLag,
+			},
+		},
+	}
+	return event
+}
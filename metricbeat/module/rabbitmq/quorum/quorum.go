@@ -0,0 +1,203 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quorum
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/rabbitmq"
+)
+
+// queueColumns are the columns of the queues requested to the management API,
+// the statistics of the queues are not needed.
+const queueColumns = "name,vhost,type,leader,members,online,messages"
+
+func init() {
+	mb.Registry.MustAddMetricSet("rabbitmq", "quorum", New,
+		mb.WithHostParser(rabbitmq.HostParser),
+	)
+}
+
+// MetricSet for fetching the metrics of the replicated queues of RabbitMQ,
+// quorum queues and streams.
+type MetricSet struct {
+	*rabbitmq.MetricSet
+
+	status     *rabbitmq.MetricSet
+	publishers *rabbitmq.MetricSet
+	consumers  *rabbitmq.MetricSet
+	statusURI  string
+
+	// statusFound is set once the status of a quorum queue is fetched, and
+	// statusUnsupported if the status endpoint is not found before, as in
+	// versions of RabbitMQ before 3.13, so it is not requested again. Once
+	// found, not found responses are for queues deleted in the meantime.
+	statusFound       bool
+	statusUnsupported bool
+}
+
+// New creates new instance of MetricSet
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := rabbitmq.NewMetricSet(base, rabbitmq.QueuesPath+"?columns="+queueColumns)
+	if err != nil {
+		return nil, err
+	}
+	status, err := rabbitmq.NewMetricSet(base, rabbitmq.QuorumQueuesPath)
+	if err != nil {
+		return nil, err
+	}
+	publishers, err := rabbitmq.NewMetricSet(base, rabbitmq.StreamPublishersPath)
+	if err != nil {
+		return nil, err
+	}
+	consumers, err := rabbitmq.NewMetricSet(base, rabbitmq.StreamConsumersPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		MetricSet:  ms,
+		status:     status,
+		publishers: publishers,
+		consumers:  consumers,
+		statusURI:  status.GetURI(),
+	}, nil
+}
+
+// Fetch fetches the quorum queues and streams, and the status of the members
+// of the quorum queues.
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	content, err := m.HTTP.FetchContent()
+	if err != nil {
+		return fmt.Errorf("error in fetch: %w", err)
+	}
+
+	var queues []map[string]interface{}
+	if err := json.Unmarshal(content, &queues); err != nil {
+		return fmt.Errorf("error in mapping: %w", err)
+	}
+
+	var streams *streamStats
+	for _, queue := range queues {
+		switch queue["type"] {
+		case "quorum":
+			for _, event := range m.quorumEvents(queue) {
+				if !r.Event(event) {
+					return nil
+				}
+			}
+		case "stream":
+			if streams == nil {
+				streams, err = m.fetchStreamStats()
+				if err != nil {
+					return fmt.Errorf("error fetching stream stats: %w", err)
+				}
+			}
+			if !r.Event(streamEvent(queue, streams)) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// quorumEvents returns an event for each member of a quorum queue, or a single
+// event with the fields of the queue if the status of the members can't be
+// fetched.
+func (m *MetricSet) quorumEvents(queue map[string]interface{}) []mb.Event {
+	if m.statusUnsupported {
+		return []mb.Event{queueEvent(queue)}
+	}
+
+	name, _ := queue["name"].(string)
+	vhost, _ := queue["vhost"].(string)
+	m.status.SetURI(m.statusURI + "/" + url.PathEscape(vhost) + "/" + url.PathEscape(name) + "/status")
+
+	var members []map[string]interface{}
+	found, err := fetchJSON(m.status.HTTP, &members)
+	switch {
+	case err == nil && found:
+		m.statusFound = true
+	case err == nil && !m.statusFound:
+		m.Logger().Debug("status of quorum queues not available, only the fields of the queues are reported")
+		m.statusUnsupported = true
+	}
+	if err != nil || !found || len(members) == 0 {
+		event := queueEvent(queue)
+		if err != nil {
+			event.Error = fmt.Errorf("error fetching status of quorum queue '%s': %w", name, err)
+		}
+		return []mb.Event{event}
+	}
+
+	events := make([]mb.Event, 0, len(members))
+	for _, member := range members {
+		events = append(events, memberEvent(queue, member))
+	}
+	return events
+}
+
+// fetchStreamStats fetches the publishers and consumers of the streams. The
+// stats are empty if the stream management plugin is not enabled.
+func (m *MetricSet) fetchStreamStats() (*streamStats, error) {
+	var stats streamStats
+	found, err := fetchJSON(m.publishers.HTTP, &stats.publishers)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return &stats, nil
+	}
+	if _, err := fetchJSON(m.consumers.HTTP, &stats.consumers); err != nil {
+		return nil, err
+	}
+	stats.enabled = true
+	return &stats, nil
+}
+
+// fetchJSON decodes the response of a request in v. It returns false if the
+// endpoint is not found, because the version of RabbitMQ or its plugins don't
+// provide it.
+func fetchJSON(h *helper.HTTP, v interface{}) (bool, error) {
+	resp, err := h.FetchResponse()
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, body)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
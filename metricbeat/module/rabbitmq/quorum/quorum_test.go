@@ -0,0 +1,194 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quorum
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// server mocks the management API, with the responses of the given escaped
+// paths, and counts the requests to each path.
+func server(t *testing.T, files map[string]string) (*httptest.Server, map[string]int) {
+	responses := map[string][]byte{}
+	for path, file := range files {
+		body, err := os.ReadFile(filepath.Join("_meta", "testdata", file))
+		require.NoError(t, err)
+		responses[path] = body
+	}
+
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.EscapedPath()]++
+		w.Header().Set("Content-Type", "application/json")
+		body, found := responses[r.URL.EscapedPath()]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Object Not Found","reason":"Not Found"}`))
+			return
+		}
+		w.Write(body)
+	}))
+	return server, requests
+}
+
+var allResponses = map[string]string{
+	"/api/queues":                          "queues.json",
+	"/api/queues/quorum/%2F/orders/status": "quorum_status.json",
+	"/api/stream/publishers":               "stream_publishers.json",
+	"/api/stream/consumers":                "stream_consumers.json",
+}
+
+func TestFetchEventContents(t *testing.T) {
+	server, _ := server(t, allResponses)
+	defer server.Close()
+
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(metricSet)
+	require.Empty(t, errs)
+	// One event for each member of the quorum queue, and one for the stream.
+	require.Len(t, events, 4)
+
+	expected := []struct {
+		node   string
+		fields map[string]interface{}
+	}{
+		{
+			node: "rabbit@rabbitmq-0",
+			fields: map[string]interface{}{
+				"name":                "orders",
+				"type":                "quorum",
+				"leader":              "rabbit@rabbitmq-0",
+				"members.count":       3,
+				"online.count":        2,
+				"messages.count":      int64(1530),
+				"raft.state":          "leader",
+				"raft.membership":     "voter",
+				"raft.term":           int64(4),
+				"raft.log.last_index": int64(48215),
+				"raft.log.length":     int64(1215),
+				"raft.commit_lag":     int64(5),
+			},
+		},
+		{
+			node: "rabbit@rabbitmq-1",
+			fields: map[string]interface{}{
+				"raft.state":            "follower",
+				"raft.log.last_applied": int64(48190),
+				"raft.commit_lag":       int64(2),
+			},
+		},
+		{
+			node: "rabbit@rabbitmq-2",
+			fields: map[string]interface{}{
+				"raft.state": "noproc",
+			},
+		},
+		{
+			node: "rabbit@rabbitmq-1",
+			fields: map[string]interface{}{
+				"name":                            "audit",
+				"type":                            "stream",
+				"online.count":                    3,
+				"messages.count":                  int64(250000),
+				"stream.publishers.count":         int64(2),
+				"stream.publishers.published":     int64(250000),
+				"stream.publishers.confirmed":     int64(249990),
+				"stream.publishers.errored":       int64(3),
+				"stream.consumers.count":          int64(2),
+				"stream.consumers.consumed":       int64(449000),
+				"stream.consumers.offset_lag.max": int64(50000),
+			},
+		},
+	}
+	for i, e := range expected {
+		node, _ := events[i].ModuleFields.GetValue("node.name")
+		assert.Equal(t, e.node, node)
+		for k, v := range e.fields {
+			value, err := events[i].MetricSetFields.GetValue(k)
+			if assert.NoError(t, err, k) {
+				assert.Equal(t, v, value, k)
+			}
+		}
+	}
+
+	_, err := events[2].MetricSetFields.GetValue("raft.commit_lag")
+	assert.Error(t, err)
+	vhost, _ := events[3].ModuleFields.GetValue("vhost")
+	assert.Equal(t, "prod", vhost)
+}
+
+func TestFetchWithoutStatus(t *testing.T) {
+	server, requests := server(t, map[string]string{
+		"/api/queues": "queues.json",
+	})
+	defer server.Close()
+
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
+	for i := 0; i < 2; i++ {
+		events, errs := mbtest.ReportingFetchV2Error(metricSet)
+		require.Empty(t, errs)
+		require.Len(t, events, 2)
+
+		assert.Equal(t, mapstr.M{
+			"name":     "orders",
+			"type":     "quorum",
+			"leader":   "rabbit@rabbitmq-0",
+			"members":  mapstr.M{"count": 3},
+			"online":   mapstr.M{"count": 2},
+			"messages": mapstr.M{"count": int64(1530)},
+		}, events[0].MetricSetFields)
+
+		_, err := events[1].MetricSetFields.GetValue("stream")
+		assert.Error(t, err)
+	}
+
+	// The status is not requested again once it is not found.
+	assert.Equal(t, 1, requests["/api/queues/quorum/%2F/orders/status"])
+}
+
+func TestData(t *testing.T) {
+	server, _ := server(t, allResponses)
+	defer server.Close()
+
+	ms := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
+	err := mbtest.WriteEventsReporterV2ErrorCond(ms, t, "", func(e mapstr.M) bool {
+		state, _ := e.GetValue("rabbitmq.quorum.raft.state")
+		return state == "leader"
+	})
+	if err != nil {
+		t.Fatal("error creating data.json file:", err)
+	}
+}
+
+func getConfig(url string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "rabbitmq",
+		"metricsets": []string{"quorum"},
+		"hosts":      []string{url},
+	}
+}
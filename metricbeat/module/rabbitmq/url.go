@@ -29,6 +29,10 @@ const (
 	OverviewPath    = "/api/overview"
 	QueuesPath      = "/api/queues"
 	ShovelsPath     = "/api/shovels"
+
+	QuorumQueuesPath     = "/api/queues/quorum"
+	StreamConsumersPath  = "/api/stream/consumers"
+	StreamPublishersPath = "/api/stream/publishers"
 )
 
 const (
@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/redis"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const clusterInfo = "cluster_state:ok\r\n" +
+	"cluster_slots_assigned:16384\r\n" +
+	"cluster_slots_ok:16384\r\n" +
+	"cluster_slots_pfail:0\r\n" +
+	"cluster_slots_fail:0\r\n" +
+	"cluster_known_nodes:4\r\n" +
+	"cluster_size:2\r\n" +
+	"cluster_current_epoch:4\r\n" +
+	"cluster_my_epoch:1\r\n" +
+	"cluster_stats_messages_sent:1483972\r\n" +
+	"cluster_stats_messages_received:1483968\r\n"
+
+const clusterNodes = "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 127.0.0.1:30001@31001,redis-1 myself,master - 0 0 1 connected 0-5460 16383 [5461->-67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1]\n" +
+	"67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1 127.0.0.1:30002@31002,redis-2 master - 0 1426238316232 2 connected 5461-10922 [5461-<-e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca]\n" +
+	"292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 127.0.0.1:30003@31003 master,fail - 1426238316232 1426238316232 3 disconnected 10923-16382\n" +
+	"07c37dfeb235213a872192d90877d0cd55635b91 127.0.0.1:30004@31004,redis-4 slave e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 0 1426238317239 4 connected\n"
+
+func TestEventsMapping(t *testing.T) {
+	nodes := parseClusterNodes(clusterNodes)
+	require.Len(t, nodes, 4)
+
+	// Reply of CLUSTER SHARDS, as returned by redigo.
+	shards := []interface{}{
+		[]interface{}{
+			[]byte("slots"), []interface{}{int64(0), int64(5460)},
+			[]byte("nodes"), []interface{}{
+				[]interface{}{[]byte("id"), []byte("e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca"), []byte("port"), int64(30001), []byte("health"), []byte("online")},
+				[]interface{}{[]byte("id"), []byte("07c37dfeb235213a872192d90877d0cd55635b91"), []byte("port"), int64(30004), []byte("health"), []byte("loading")},
+			},
+		},
+	}
+	setNodesHealth(nodes, parseShardsHealth(shards))
+
+	reporter := &mbtest.CapturingReporterV2{}
+	eventsMapping(reporter, redis.ParseRedisInfo(clusterInfo), nodes)
+	events := reporter.GetEvents()
+	require.Len(t, events, 5)
+
+	cluster := events[0].MetricSetFields
+	assert.Equal(t, "ok", cluster["state"])
+	assertValue(t, cluster, "slots.assigned", int64(16384))
+	assertValue(t, cluster, "slots.coverage.pct", float64(1))
+	assertValue(t, cluster, "known_nodes", int64(4))
+	assertValue(t, cluster, "messages.received", int64(1483968))
+	assertValue(t, cluster, "nodes.masters", int64(3))
+	assertValue(t, cluster, "nodes.replicas", int64(1))
+	assertValue(t, cluster, "nodes.fail", int64(1))
+	assertValue(t, cluster, "nodes.disconnected", int64(1))
+	assertValue(t, cluster, "migration.migrating", int64(1))
+	assertValue(t, cluster, "migration.importing", int64(1))
+
+	myself := events[1].MetricSetFields
+	assertValue(t, myself, "node.id", "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca")
+	assertValue(t, myself, "node.address", "127.0.0.1:30001")
+	assertValue(t, myself, "node.hostname", "redis-1")
+	assertValue(t, myself, "node.myself", true)
+	assertValue(t, myself, "node.role", "master")
+	assertValue(t, myself, "node.slots.count", int64(5462))
+	assertValue(t, myself, "node.slots.ranges", []string{"0-5460", "16383"})
+	assertValue(t, myself, "node.migration.migrating", int64(1))
+	assertValue(t, myself, "node.health", "online")
+
+	failed := events[3].MetricSetFields
+	assertValue(t, failed, "node.flags", []string{"master", "fail"})
+	assertValue(t, failed, "node.link_state", "disconnected")
+	assertValue(t, failed, "node.ping_sent", int64(1426238316232))
+	_, err := failed.GetValue("node.health")
+	assert.Error(t, err)
+
+	replica := events[4].MetricSetFields
+	assertValue(t, replica, "node.role", "replica")
+	assertValue(t, replica, "node.master_id", "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca")
+	assertValue(t, replica, "node.slots.count", int64(0))
+	assertValue(t, replica, "node.health", "loading")
+	_, err = replica.GetValue("node.slots.ranges")
+	assert.Error(t, err)
+}
+
+func assertValue(t *testing.T, fields mapstr.M, key string, expected interface{}) {
+	t.Helper()
+	value, err := fields.GetValue(key)
+	if assert.NoError(t, err, key) {
+		assert.Equal(t, expected, value, key)
+	}
+}
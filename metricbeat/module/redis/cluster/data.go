@@ -0,0 +1,280 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cluster
+
+import (
+	"strconv"
+	"strings"
+
+	rd "github.com/gomodule/redigo/redis"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstrstr"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Number of hash slots of a Redis Cluster.
+const totalSlots = 16384
+
+var schema = s.Schema{
+	"state": c.Str("cluster_state"),
+	"slots": s.Object{
+		"assigned": c.Int("cluster_slots_assigned"),
+		"ok":       c.Int("cluster_slots_ok"),
+		"pfail":    c.Int("cluster_slots_pfail"),
+		"fail":     c.Int("cluster_slots_fail"),
+	},
+	"known_nodes":   c.Int("cluster_known_nodes"),
+	"size":          c.Int("cluster_size"),
+	"current_epoch": c.Int("cluster_current_epoch"),
+	"my_epoch":      c.Int("cluster_my_epoch"),
+	"messages": s.Object{
+		"sent":     c.Int("cluster_stats_messages_sent"),
+		"received": c.Int("cluster_stats_messages_received"),
+	},
+}
+
+// clusterNode is a node of the cluster, as seen by the monitored node.
+type clusterNode struct {
+	id           string
+	address      string
+	hostname     string
+	flags        []string
+	masterID     string
+	pingSent     int64
+	pongReceived int64
+	configEpoch  int64
+	linkState    string
+	slots        int64
+	slotRanges   []string
+	migrating    int64
+	importing    int64
+	health       string
+}
+
+func (n *clusterNode) hasFlag(flag string) bool {
+	for _, f := range n.flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// role returns the role of the node, master or replica.
+func (n *clusterNode) role() string {
+	switch {
+	case n.hasFlag("master"):
+		return "master"
+	case n.hasFlag("slave"):
+		return "replica"
+	default:
+		return ""
+	}
+}
+
+// parseClusterNodes parses the output of the CLUSTER NODES command, with a
+// line per node:
+// <id> <ip:port@cport[,hostname]> <flags> <master> <ping-sent> <pong-recv> <config-epoch> <link-state> <slot> ...
+// Slots are single slots, ranges of slots, or slots being migrated, like
+// [slot->-node], or imported, like [slot-<-node].
+func parseClusterNodes(out string) []*clusterNode {
+	var nodes []*clusterNode
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.Fields(line)
+		if len(parts) < 8 {
+			continue
+		}
+
+		node := &clusterNode{
+			id:        parts[0],
+			flags:     strings.Split(parts[2], ","),
+			linkState: parts[7],
+		}
+		node.address, node.hostname, _ = strings.Cut(parts[1], ",")
+		node.address, _, _ = strings.Cut(node.address, "@")
+		if parts[3] != "-" {
+			node.masterID = parts[3]
+		}
+		node.pingSent, _ = strconv.ParseInt(parts[4], 10, 64)
+		node.pongReceived, _ = strconv.ParseInt(parts[5], 10, 64)
+		node.configEpoch, _ = strconv.ParseInt(parts[6], 10, 64)
+
+		for _, slot := range parts[8:] {
+			switch {
+			case strings.Contains(slot, "->-"):
+				node.migrating++
+			case strings.Contains(slot, "-<-"):
+				node.importing++
+			default:
+				node.slotRanges = append(node.slotRanges, slot)
+				start, end, found := strings.Cut(slot, "-")
+				if !found {
+					node.slots++
+					continue
+				}
+				first, err1 := strconv.ParseInt(start, 10, 64)
+				last, err2 := strconv.ParseInt(end, 10, 64)
+				if err1 == nil && err2 == nil && last >= first {
+					node.slots += last - first + 1
+				}
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// parseShardsHealth returns the health of the nodes, by ID, from the reply of
+// the CLUSTER SHARDS command, a list of shards with their slots and nodes.
+func parseShardsHealth(shards []interface{}) map[string]string {
+	health := map[string]string{}
+	for _, shard := range shards {
+		shardFields, err := rd.Values(shard, nil)
+		if err != nil {
+			continue
+		}
+		for i := 0; i+1 < len(shardFields); i += 2 {
+			if key, _ := rd.String(shardFields[i], nil); key != "nodes" {
+				continue
+			}
+			nodes, err := rd.Values(shardFields[i+1], nil)
+			if err != nil {
+				continue
+			}
+			for _, node := range nodes {
+				// Nodes have integer fields, like their port, so they
+				// can't be read with rd.StringMap.
+				nodeFields, err := rd.Values(node, nil)
+				if err != nil {
+					continue
+				}
+				var id, nodeHealth string
+				for j := 0; j+1 < len(nodeFields); j += 2 {
+					switch key, _ := rd.String(nodeFields[j], nil); key {
+					case "id":
+						id, _ = rd.String(nodeFields[j+1], nil)
+					case "health":
+						nodeHealth, _ = rd.String(nodeFields[j+1], nil)
+					}
+				}
+				if id != "" {
+					health[id] = nodeHealth
+				}
+			}
+		}
+	}
+	return health
+}
+
+func setNodesHealth(nodes []*clusterNode, health map[string]string) {
+	for _, node := range nodes {
+		node.health = health[node.id]
+	}
+}
+
+// eventsMapping reports an event with the state of the cluster, and an event
+// per node.
+func eventsMapping(r mb.ReporterV2, info map[string]string, nodes []*clusterNode) {
+	source := map[string]interface{}{}
+	for key, val := range info {
+		source[key] = val
+	}
+	cluster, _ := schema.Apply(source)
+	if slots, ok := cluster["slots"].(mapstr.M); ok {
+		if assigned, ok := slots["assigned"].(int64); ok {
+			slots["coverage"] = mapstr.M{"pct": float64(assigned) / totalSlots}
+		}
+	}
+
+	var masters, replicas, failed, pfail, disconnected, migrating, importing int64
+	for _, node := range nodes {
+		switch node.role() {
+		case "master":
+			masters++
+		case "replica":
+			replicas++
+		}
+		if node.hasFlag("fail") {
+			failed++
+		}
+		if node.hasFlag("fail?") {
+			pfail++
+		}
+		if node.linkState != "connected" {
+			disconnected++
+		}
+		migrating += node.migrating
+		importing += node.importing
+	}
+	cluster.DeepUpdate(mapstr.M{
+		"nodes": mapstr.M{
+			"masters":      masters,
+			"replicas":     replicas,
+			"fail":         failed,
+			"pfail":        pfail,
+			"disconnected": disconnected,
+		},
+		"migration": mapstr.M{
+			"migrating": migrating,
+			"importing": importing,
+		},
+	})
+	if !r.Event(mb.Event{MetricSetFields: cluster}) {
+		return
+	}
+
+	for _, node := range nodes {
+		fields := mapstr.M{
+			"id":            node.id,
+			"address":       node.address,
+			"flags":         node.flags,
+			"myself":        node.hasFlag("myself"),
+			"config_epoch":  node.configEpoch,
+			"ping_sent":     node.pingSent,
+			"pong_received": node.pongReceived,
+			"link_state":    node.linkState,
+			"slots": mapstr.M{
+				"count": node.slots,
+			},
+			"migration": mapstr.M{
+				"migrating": node.migrating,
+				"importing": node.importing,
+			},
+		}
+		if node.hostname != "" {
+			fields["hostname"] = node.hostname
+		}
+		if role := node.role(); role != "" {
+			fields["role"] = role
+		}
+		if node.masterID != "" {
+			fields["master_id"] = node.masterID
+		}
+		if len(node.slotRanges) > 0 {
+			fields.Put("slots.ranges", node.slotRanges)
+		}
+		if node.health != "" {
+			fields["health"] = node.health
+		}
+		if !r.Event(mb.Event{MetricSetFields: mapstr.M{"node": fields}}) {
+			return
+		}
+	}
+}
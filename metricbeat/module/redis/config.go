@@ -25,9 +25,9 @@ import (
 )
 
 type Config struct {
-	IdleTimeout time.Duration     `config:"idle_timeout"`
-	Network     string            `config:"network"`
-	MaxConn     int               `config:"maxconn" validate:"min=1"`
+	IdleTimeout time.Duration     `config:"idle_timeout" doc:"Timeout after which idle connections are closed. Zero keeps them open."`
+	Network     string            `config:"network" doc:"Network type to be used for redis connection."`
+	MaxConn     int               `config:"maxconn" validate:"min=1" doc:"Max number of concurrent connections."`
 	TLS         *tlscommon.Config `config:"ssl"`
 
 	UseTLSConfig *tls.Config
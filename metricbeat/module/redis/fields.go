@@ -32,5 +32,5 @@ func init() {
 // AssetRedis returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/redis.
 func AssetRedis() string {
-	return "eJzsXN+P2zbyf89fMdjvQ7dFVvlegbuHRVEgP3tB02Sxm+DQJy1FjWzWFKmSlDfuX38gKcmyLEry2nK2wPkhwdoS5zPD+UkOeQUr3FyDwpTpZwCGGY7XcHFr/754BpCipooVhklxDT8/AwBwv0GORjGqgUrOkRpMIVMy9z9GzwAUciQar2FBngFkDHmqr937VyBIjlua9mM2hX1UybKovukhbD/37q17oFIYwoQGs0RgIpMqJ/ZZICIFbYhh2lh4u6Dspw2lDccO0nzZh2gAlUNmB5gOTKEplcAUko179P79x3ef7Ot5TkQatYbelWT96bLRZoVyhsLond9CHI1wtZ1wP6hjQUedZ/rA7ACSQjgl2XuihsWlWPT8OILMfj6WeYIKZFYjrIgxKTRc4lfKy5SJxc7XTis0J2vU33d52aLOyddYlqYoTZyUWYZqBvQfpFigNuDpAGfaAMmlxVsq5djZ42oYMROzAn7FFg6wIwOezChiuJTC2zv8M/r/AZEnXNLVWdREQ4HCKYa1TU/YqQnhHC5ffbj5dPMcXt1u//tw8+Xu3y3oAcsrtdmT+9GW5wZte5NDDRAFSfiAXBMpORLxONG+FymjxKD1ecQ4b9YBrmsAY+IrytOK7vXNF++xDpRXqTGN9Kb72haRpoRjGmdcEvM4qd1ttMHcIaRS6DLfxgKPXaNaowrbSo0xpkvGU4V9s3cGsAmhKzs/IoVCSYpa44CHcqBLPeCajgf7RaM6Vq4W4jkEG8Q6LNZe68kxl2pzWgPyYz4u6jtBrgkv8VB/7v3cNSQbg302OEGwn6UhHETj9d1QQDiX1lU5Me+khQH4Sod9wHzgP3ZgO7/qJ2TLAbGqjKJWGFmgIsaGMO2N9ZJEq4iAQs1Sl7mhAc3+woHw61gukKy+Ac83SFa1urWNYcos8ZJ8A8RfNKY14moSPpQEUCyYwBHEKTFEY5/TmN0wlujUAJiotEtmDnsX0lCW+c3M+jcvbs5yZgbz4KiQnNGuN9xCXOHmQaq+jGgCirdr5hJb8ETASDur8LBEUSuEQ2gzH4WELoOZTxt1psgiR2F8hmeNWQbhHxFybu3AkKB5sN7DamPsMcdKa1emtr6bCDY0o3OrwyunwIfyEmSKUMPWGKdouYuYjlUpBOsFf4K8+R0nC2A+ebZ+m2UVAPAAGvFaNfK/TGDBxwcZTrCO0vzK/hoqE9S6eTbuy8RhMEeBgTSjh0ZvhQNjeggTdBGmicd+XjYROmBCsKdxTwG117wJkOuE4gmAvq1zmwmwp3lXGPWwB8B7t2PEYZKH+VQ4s5BrLlw6FhqtUQ6tzyPbZurHxWoxPRVhNrB3x+qt5wpUmmmDgnYdxKlWRQ6t6Lgk6TnDoU1LLU2bohJIy7yAjHG08VCKq4Xsx/J/8Fm+kZDLNcJ9Bfne5mj1H1G1GnXvMgSSpiDNElXNnpcNJG6qfO11qQ1RBgzL8TkYV1q6CXzu3qkt4zlEUfT9eERUaXJwFJxSQSm5Zinq3W2HRJYGbt+8GlAnmBhlOdEm1mSNEV0SsUAda9Y/Gkwxq4km01q59VTBUfXKQbRxejERt53AmeG+LSRdXiXEFoeWnDYkLyx6h1WXlKLWWcndnFhQYX1p85AsHANMxIWSC4W96xIwwRIPYKVrkaTBPGKBe7D9DBhiymHY4dz0ANh3jk5d1jqxN7irVRLZL5k+1HYOI4302OCRlgOEJ/P2phplhDtb3GukUqTD4bpitNq5eeK81grXy28GRAxUeLCzG1lsYiniB8VMrZrsr6eQhPeuzVi4V1JcObh1peN21dJSWXFs9eDVm5CWh6szmZ05Fr389M7HomNCUXhDC+b1gRY9l4uFy1Cqsnyn8BypnbzSfWM3bpmooLRtaKJPr5nQdIlp+U1mgYgADw+Mc0gQGmwg61xh330wDVTmBUfTs1zbx/HfJSAE5ndaTKiZ/ZsFhQDPu3FhiF/fyxA9kVBwZ8NAxWObtU5jx0h031Hcb5h/9U3ONB6eBv6uWhlZT8z/Uo7JKUdbIE/b2A4xMstC7SufACsWft2I5dZKgiLeYcDWq08EfYMapABODGrXdahMWYBUtRuZNjmZ3ggaVZ1eZ1uncFSb/rI/ZKKddW07Ot6/+AR/lti7g9oFnyInm8dvbEwNq55KBZ3KUpiQAWzjZsEZ7QvpRyxPhoYcW5lUkoe3o4/a8bqVvPENTGhDbD55SYmwaeZFTrRBdfHcauaF6yi9CPUIQl9DbOy7UIPQT9LwWBODDrEgPKuqXC6i4O7UseC6+XxLl2riPZueIZgBF3eeFqPeXoo+fgItuV1mMqa0ie1oscyyR/SITOwB8ZoLnsYJcC+ZNnygV+7xaO9C3Sr2y4mog/C9GKJZRe07Dr/TTWBuAw5Q7uDzhdu8GlGpQlnY9PZhyehyB+j7NxqIQiCUYtG31d6BzJlYhXP2E3jmTp7OxAouy+JFKh/E96PgbArOZFwVxDFZhHttRjzIQF17kJuuoHR3N5iNxIRWqxtmWXEwqjA2dxheZjrBnl3T++3wVi6FabDUXZ3g6yQXdyYh5ph55/cU2i4tGEgwkwobjlprRtMYeuqK5pTMKCJ0hsplplWNR+Du94+vD1lLdtM8ryvd95y1/TviTYY2grFQTCpmwu2Bx6Gsh9/LG4kGApSIlKXWaDKpICOMy/WAQXvETMcKSSoFD4OeY/u9EqvrY0yvdsj3VgU+1p2qILhzo3XP5sGEgmCNSvfbigdDOCN9bqIgZum5YBSj8Cg5W3izuAajegu5NmvjtrNgJtZL8o8jQ+U0QilTA7p/KkpJyXgas/Cpo1MRymV6bPE3TkSGI9KwLkkdZSXnZ9AhougyTthAj+XJJF5ywwqOX5lYxKRg82stpfGYSZ+KVnXeZkhzh2e8GiAqekc49ayrUpzDyAwt4kKqowL7OJWyCLTonJDG8q95x+eqjCmX9OBTNIeRoVJkbBFn7OiVr5GI3tO1fWQD4jGHyN0JaIUU2fq4g8L92Vvn2Fj7EHNNNHzuZBfiH0cfeX8ERE900hKku2lAx/XxwnMg9SSbE41TcAo0kTts/rhDJlNBonmQalUda68Xm8IzbVH5M/tngVVdD7CPKwjQlxyGCJSljmSh4wJV3L+Nf8ql5u4MQ4GqqjgnYvUXGKySYs7T17ZKq4T7na9tQNmKbIvWCvvXVy/6JBaQsb8q4qzA/QbtOPJwcbkRNJSenqIS3hq/JQIKLUHtF7ICWx870AqiDCM8kkcF00kA63VNqGhWYEHhnyXqnqS6FyiqOW7d2EWaomATcAYBr3CjI/xaMDXLVRtdt7/CDThqftkG1z2X03TBrdns18VUNBxBSEsEIyEnX9vnN6eJsiAUo+VQ2XUKuK3OcS7lqiwq0ep6FyQnTEDqD6aSgZObDeScaT3z1mNGGMf0QMDhwqxMdJm4wwcC+RzIf+Ey2dHdokxe6DKBmqb3XPVdSGXSjBhW6Ap1QYxB1fPcjKgrmhNAh4sZ14ARZ1Kt4nKe9GG/hdH1fGQu8Wl3L+aMKtltYQyvUbg6G2Pqzl/HWtIVzmKju865omORC7fA+9v7X25ffn4LRakKqadsv7vAGHsHrWOjCF1hGlvTmR29s8+KokPvUGwa8HBJCrcCn3AEKbg7+26zEPdFdaXWOIe7B61n953utgKb5bU62QpUmVTVJRPVuWu3sdw5e11lshNZOYNPJYlUNm71MeX6pLaXyASOkx/G0go38ewz5PVuSQw8oKqB800L+sCG8z7eM0xDB7FesaJ4lOS3Vi8fuFxErvmqd72lB/YI5Nd2LOeVuHzYOtORg6d1FXf6hZ9q5NZFiAcuAl38EFHCububsvs5rSd0ZPwEV1d4NOjxK9LSzeelkKZZdBm4WOfih8gGz7lQ+xOpr2++uBOH3bukNDYzOorQrRJY1sNQjyxeyRoVWeDupVe2eN2T7iDYWujxmdShplfpxfZCw39FP14p+uPw5Ps8+FxYq6x7HGmrJHjWBXPI1acr3LRuPt0/8uVqutYrh19pav/tdUX9K9wjIvsVN27EkQvV9nZVjiD5RbA/SwTmsyqzZNrVxZf/sam5jRhWZvBTXZz9fP2TxfDz2IWTFtFp5WJfd3eLLeWDu1zs/vPvN297bqbtxcNRLMzyRNHrgxusLgucuFrlO8fc3eNp5cmZNvp5Rd19o41iYqGfAyUqZYJwZjb+BzR6TKo+9Y6M6S6LPZaTu6rlxsh67D4bdDN/rCG6QYbuIXYWWQnUPTzvZcSnNKJfK8Tg7tBgGcPQBURNarhexKebxpdV7DKGj9DtKdYeS7STclaLJ80qzhRNfiyU/wYAAP//elnqRA=="
+	return "eJzknd2P2ziSwN/9VxT6HrZn0dHMLXB3QGMxh2Qy2QkmOwk6CQ77JNNS2eaaIjUk1R3PX38okpJlWV/+kLsXu2tgOrbF+lWxSFbxy69gg9t70JhyMwOw3Aq8h5sH+vfNDCBFk2ieW67kPfw4AwBwn0GGVvPEQKKEwMRiCkutMv9hNAPQKJAZvIcVmwEsOYrU3LvnX4FkGe5k0v/tNqevalXk4Z0WwfSau6fmkChpGZcG7BqBy6XSGSNIYDIFY5nlxhLePhTAPkodJxGFsair99ugesDoNQ9lNPAIB0EtgQXb/eS/5ljVErg1IFWKFSJAzX4LtKz2fhO/roITtPdJqcQGt09Kp43PelSh1+eSm2wcVLuDudrMgS+BCeG1E8oaYBrBoH7E9A7mS8bFHJRdo37iBndqtVNTAREzhq8kNhE9vlBydRz7b0W2QE3wgS8UD1YBc9YeRaU2E/F4W8Fi61jIjZl1RpTKApfAgIxY6OA9o2BzeuRKvEvBVitMgRnIlTF8IbaOmMvVKNaroAagZuPqwUrUI2q2wihPbCueSZjANF4KxZpf8H3QPeSoE5T2OCU++YfYqmpw3m/3W9/xbryR6knGzgCt+pxjbleql0COTJ0BvXUHXCaiSMny5XuGfHrNZGrWbDPozfwPvDhsxpwBPQy1PsJjFqiXtaAkOg8YIEsKrVHaGHOVrC+E+JMvE1yZjfoewMm2lyVRcslX+yAjXCxDY9gKTWQOvf4C9RZKByod7FqrYrXeaxOLwowl1Jggf5xgkCklQCnhRFIyt4m8r5ax0QUpa21gFIjGXPCEXZ4kFHwEyiQDRtU1HTPYuocmGmzPHVzd81HKTaKkdGH5RIhPa2UQBJcbiqjKngK4gbrwAdqMr7SL2qPwl1xdHJd6dQMLpO7eS6HGqXx0GuxVPT5AybNc6ckpvZQyoRrPSd9pBWtmMMeE/j5W7hyWunOSOhlvdrhDickIQnq9fztimKqDsDTVaMw0NK994XWkO4qPeX5PFToCb62MJdBp+H4Jpe8DUiYnpSpkgukIRK3ERHgPSlR+5tHmfhycg9IwDyPGfAQjdZ8T1fE7KnqfUvANwjzbGhTL+Q76DuZGsEech3z4f70a9OcYHXx5nUoslBLI5GlKfNEFAt/pQL129bdLRDWGLmhv+qK1B+jWwNkhnr7th8jeza/U44oRjDR+xW2zJheC3JtBaQ6WdzCvBkrvHPWhc4yTrJEJu54G/RdXdpurKym4rPy6ZBeKUcY3j+CjFFtgj4wLthAIhssEw8TX/0Q/jFArcXlIa2rTO9qO1OzINKdOlnO5ilvSnEtgfeEZej+hhDRHSeZ0EuGJlelP3Xu4hIwLwQ0mSqYmGNpZ7Q5+CA1cu9YtlRylnpKruCNHmkBFFVSrciY3WTtKwRHKlBM6xSS11T3pVPKPRtRMrtB0Mp7XAZEEN8/spZwJvAuFw19ydQXbNoL3PS8ZF8ofF85Pp0MV2tcack+YXyLT+sZsKKrvgZpTAePXTDTaQsudc8zf//buIz2eZUymrYsUq7FLFIngKK25VJ7ihxVfqFPBHJubVAPuwTcu6QSBMAjjShq4xW/lLGn9becNLnI030Wd1Bn7FqvC5oWNF8VyubdsdSn6D4p6DAteDghuLLCMOu0wDdqiVT8xl5MCv+ErB+zEgBczSAy3SvqlSPiv6Iceky+ESjZXcRNTjf7UNr1g5ya05Hb75sOnj5/u4M3D7j8fPn39/EsNfdbGHyL3WRv7GS3PFVrvTY5tgCgpTEynSXXey5QnzJYLbDTUNcBNCTBkvryYteGdbLqfPn31PdaR9ioMppHZmk6D9SxRjbTa562xmDnCRElTZLuxwFvPBQ+1JLCLMU7WXKQa5fPALliyofqRKeRaJWhMfXRthS4M6glhvxrU59qVEK9h2E7WfrPO2sAzzJTeztpAT25AvszTRn1X149MFHhsf16u8i62Fs1phv2iLBMgq17fFUX7KRR1Vc7MeztWOvC1Mc8AvxusXCG+X3W4NQ1cvoqydBiVI82gyxUY37PcsmgTMdBoeErjsUELhv+BPcOvUzlHtnkGnT8h25TuVm8MY2pJFPXQ+FrEXw2mJXGohA8FA5QrXp8JaCVOmWUG7TNQf6HZRv4HbegK3hWmZ5pInQpk7NuzNeu/e3MLnvHaZH8bYa4ET7adiGdl/D8/8oQowQuh/LgwCE9rlKVDOEKaFtLIkvWoaf+lZqsMJeWISkbUmOsJafk/j3/GkPNABcMC7ROidO0n9syxNsalqbX3RsJ21ejU7vCGHj1al06lWGL5I8YpUlVE3MS6kLJv7uKsuJnWOYD74JlSD1olcgCQ4p55yY08WlVOtwp+fFB6Gs8P7a+SEh1BFLdF4v0xSl+Y0SKjNcMZ9MMxvjjSPPR6XY3QHU1oD3y/Wp+R2oGE3qsXuQwoXgD0QxnbjMAe17sO97BH4L3ba8TdItsR++xzTSOXWmDaU1qpgjbmOratqn7YrMT0UoxZYe+XNWvjzlEbbizKBGdjO8zjZkWiWUPRgYwurD/Oumx46eGQ8hqSSZkQg7TIclhy4VbblHy1Uu0s/wFf1FsFmXrE3ZIpxWjlP6IwGzV3EQJL07AyED72toGFqyqfe90ay7QFyzO8A+tSS1eBd+6ZsmXcQRRF31VEnWbU6aLThF2j4AgDftLqkdPWtr1lh4UqLDy8fdPjTmNHWcGMjQ17xChZu5Wu2PD20kY1qxEqNWZuvdSwRlmtcZJfjOSmCpwY92daG361YJQckjhjWZYTvWM1RUJzOctCuDohqKqkXh0WK/puxGWca7Xq2GA1piUeoUqzRbKKeaAFHmCT7i78K/qxu2PTI7BpQ0hRpbUkescdZkmUHE1NdRgZTE51m3LwSIsewaN1extKGdCOkvuwoj9G0bBy88J1LR2uVV/aYbcLAHuVTlS+jZWMnzS3pWsenoY4WukLRAetczOE+0rJVw63zHTc6mZa6PL8B2kBD2/eNuxSSZp1GYOp5ZXHotcf3/mx6JyhKIzgvXU2SR9I9EKtVmT4Mi3fSzx7sTW6WnzubpyUCCj1NjSyTy+VMDSvVTxLLTDZocMTFwIWCBUbqDJWOOw+aG1VZbnA+nGEPo3/VQaEjvodNyaUyv6LDQodOu+PC336+r0M0QsZCj7TFH3Qsa5aY2NHr2aLVanbc8dfbZVTPd+rw8vgb7pV2Fr2+uO7qpReLf7NQw4fctQN8rIb2zGNjIxX9pUvQBXCDzR+rqTTxHsKUL76QugralASBO0rsjQjo22R0wb+0I1URfTqtjRbmURhp9ep+h09T+GkVvvL/qkWFAbXd3S8//4j/F5ggSPgUxRsi+nE8G+9FC8T3IburgZQ4oVjIC1Denf2MEDz0FlkV05w3SNTXBrLKJ68TZikMPPGn4u5uSPPvHE7Sm+69gjWccPeSExj94yZHVmzI7hr02alMGgI68QjVxVqFTUWHS8H14zna75UCm9Z9OzC7OjieiGHOq0RSvgtRgeDJzlKmz6NQaVLmSXXxsZUWqyWyxP2iIwhr5/oIhkX4F5zYwXKCWg/t1mYdqvQmyOpO/F9A44mNbXfyfknUw3MdeAOyQ0+n7hN6xHBFYqc1kqe1jxZ71n2/Vt/AxFLEszb9vI3kKtzf4WZpmduxOl08u+2yL9P1ZP8btb47gEcJURcxSEhjtlKHWvVEXntUd30/gmsKsLm0qKmjtDNbth10GBIQRrHB6aZhuZnRtBXe7/rh0Rpj+1WJtSvhzzJjTujiAUufednTq2OMzr0XV24QoBgYIFLpbHSqDZnNE6hl+5ozsmsZtIsUbvINOR4DD7/47efxiR2pd6umqftSg97zrL9O+FVhDbAmGuuNLfbiSjL4g/iRmaAQcJkylM6tLxU2l3oRRdiDRDTZjRkqZJiO01T7lh+D2Z1+xjTV3viZ620bqybteGdkBB8dqU1z+aNSQgeaYWhta14WzHBD67coVfO7NprwROMukupTjjeg9UFDti8+riTd8VtbNbsPzuBxw2V1ce9glKu7XZySYuCi/T8uwmqjzsFZYeXslxeiDIn+pIy0bIQ4go+xHSyjhfcmsmNkRXC8lzgNzqrz3I+ucBVksRDTfpSssJ5mz7P7a/xUECU8/QKta4LeY1GZpM8poPVnYJGDJnVx51Sirxji84FZaz/mLZ8oYs4ofOk04oJN3jQdGmnoHMqv5TTtmv7jBk+OsR00ubDMGdGyxDRJe7P6JnT2R0bqwmtrh2MRiL+8+wj7ycgeqE7xB5Wd9OAiUNvdRVSL7LsIEdxSrSRO2zeuYf3IpBon5TegJNUTTZFs8aX96j8mf2rYIXrAQ65OgF9ymGZRFWYSOUmzlHH7cv4o0kHU8vDGqatPiHjHMnqqiDeLHIz4blbytKCcf/kcxvQlJHtaMkJfn3zfZvFOmxc2KuDu6WwEeSdKrg5io7w9Fy3cDauXIOE0GHUrUyMn8jqWPrYQ8uZtpyJSG0mByznNSHIDLCg8fcCjR0JilpPTpqi5CM4O4E3uDURfsu5xnQK2Ea3v8EtOGku1wF8RNljTQ9HBywxnbSvCjIoRTGQFkiz3hn7Vj+/WZXQS5uzBKN1X9p1CdzaznGh1IYWw5dOfLkKkjEuIfUHU5neDiNnnC4VmBSaJrcwPRK4kzwvFqZYuMMHEsUU5H8TarHnu3mx+N4UCyhl+p4r3HBjikVVohmizpm1qOVVqYPMEdCd9H4DRrxUehMXbYPh+fiHWxhJJE3ob3azz+Q0GU+0CrPXVUmd5OEuszhx569jQzcLWTN55xzkELkkHeDv7//28PrLz5AXOlf1BtdJ7gbG2HWZaGKrGd2JFFPTmZyehECQ6OgdxbaCh1uWuxl4unWSZoOp16QohLZjhxH9u2MPWk/ed7rbCijKq+1ky1HTwoo7uxV43IR38+x1iGRHqnKFPpUt/LWxbUq5fVK7S2Q6jpMfp9IGt/HkNeT9bs0sPKEuwcW2ho7pEbxXqIYGsdnwPD/J8qUWRqgn2gDSdptmJ/YA8k9UVrgg8WnXme4AWknKLO7yEz+h5NpFiEdOAt38OUqYEObm4Bs9RjqpJ3Riypua3RBS0eM3TApSFG7pl4TKSZeei3Vu/hzR4DkVtT+RSlde0exp8y4pgyW5GSR0swSkejfqmckr8z/+s3/pFSWvB9bthS2NHl/JHUp54OTVLjT87+gvr3Tyl/7K93HwtVhD1D1MWhJucDsbauM9FPMNbms3nx4e+aK+8rwrTVtu7O+b4R4w2a+4dZrvmFqF8vRyIr9K/jvdCO+jKrvmhsqB2/+j0JxGDLIZ/LVMzn68/ysB/lirrFZEMsLlIMkuVCL9ooJZh5+cmn/5x6efW26mbeURKFcHt6V3evcAzgdXWJkWOHPtxl8USEGacfake1PNXZDu3jGWNrGYO0iYTrlkgtut/wBt/dLXVi1c0IuRteJCmnwOW26sKsueNWWWNX9uQ3S5fd89xO5CgGBQ9+VpLyO+ZCP6NRCDu/WFL3nnjySU0tnjKr5cNb4OY5e1YkBuS7J2qtBGyMnlXuUNcIQk8kSU/x8ALcExWA=="
 }
@@ -71,6 +71,11 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 	conn := m.Connection()
 	defer func() {
+		// The pool is shared with other metricsets, leave the connection
+		// in the originally configured keyspace.
+		if err := redis.Select(conn, m.OriginalDBNumber()); err != nil {
+			m.Logger().Debug(fmt.Errorf("failed to select original keyspace: %w", err))
+		}
 		if err := conn.Close(); err != nil {
 			m.Logger().Debug(fmt.Errorf("failed to release connection: %w", err))
 		}
@@ -32,7 +32,8 @@ import (
 // MetricSet for fetching Redis server information and statistics.
 type MetricSet struct {
 	mb.BaseMetricSet
-	pool *Pool
+	pool    *Pool
+	release func() error
 }
 
 // NewMetricSet creates the base for Redis metricsets.
@@ -58,16 +59,28 @@ func NewMetricSet(base mb.BaseMetricSet) (*MetricSet, error) {
 		config.UseTLSConfig = tlsConfig.ToConfig()
 	}
 
+	// All the metricsets of the module share the pool of each host.
+	pool, release, err := mb.Shared(base.Module(), base.HostData().URI,
+		func() (*Pool, error) {
+			return CreatePool(
+				base.Host(),
+				username,
+				password,
+				dbNumber,
+				&config,
+				base.Module().Config().Timeout,
+			), nil
+		},
+		(*Pool).Close,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &MetricSet{
 		BaseMetricSet: base,
-		pool: CreatePool(
-			base.Host(),
-			username,
-			password,
-			dbNumber,
-			&config,
-			base.Module().Config().Timeout,
-		),
+		pool:          pool,
+		release:       release,
 	}, nil
 }
 
@@ -76,9 +89,10 @@ func (m *MetricSet) Connection() rd.Conn {
 	return m.pool.Get()
 }
 
-// Close redis connections
+// Close releases the pool, its redis connections are closed when it is not
+// used by other metricsets.
 func (m *MetricSet) Close() error {
-	return m.pool.Close()
+	return m.release()
 }
 
 // OriginalDBNumber returns the originally configured database number, this can be used by
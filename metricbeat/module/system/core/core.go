@@ -26,6 +26,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common/diagnostics"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/beats/v7/metricbeat/module/system"
 	metrics "github.com/elastic/elastic-agent-system-metrics/metric/cpu"
 	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
 )
@@ -34,6 +35,14 @@ func init() {
 	mb.Registry.MustAddMetricSet("system", "core", New,
 		mb.WithHostParser(parse.EmptyHostParser),
 	)
+	mb.Registry.MustAddMigration(mb.Migration{
+		Module:      "system",
+		MetricSet:   "core",
+		Setting:     "cpu_ticks",
+		Replacement: "core.metrics",
+		Convert:     system.MigrateCPUTicks(defaultConfig.Metrics),
+		Since:       "6.1.0",
+	})
 }
 
 // MetricSet for fetching system core metrics.
@@ -27,6 +27,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common/diagnostics"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/beats/v7/metricbeat/module/system"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 	metrics "github.com/elastic/elastic-agent-system-metrics/metric/cpu"
 	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
@@ -37,6 +38,14 @@ func init() {
 		mb.WithHostParser(parse.EmptyHostParser),
 		mb.DefaultMetricSet(),
 	)
+	mb.Registry.MustAddMigration(mb.Migration{
+		Module:      "system",
+		MetricSet:   "cpu",
+		Setting:     "cpu_ticks",
+		Replacement: "cpu.metrics",
+		Convert:     system.MigrateCPUTicks(defaultConfig.Metrics),
+		Since:       "6.1.0",
+	})
 }
 
 // MetricSet for fetching system CPU metrics.
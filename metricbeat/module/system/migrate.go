@@ -0,0 +1,45 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package system
+
+// MigrateCPUTicks returns a migration converter for the deprecated cpu_ticks
+// setting. When cpu_ticks is enabled it adds ticks to the configured metrics
+// list, or to the given defaults if no list is configured.
+func MigrateCPUTicks(defaults []string) func(value, current interface{}) (interface{}, bool) {
+	return func(value, current interface{}) (interface{}, bool) {
+		if enabled, _ := value.(bool); !enabled {
+			return nil, false
+		}
+		var metrics []interface{}
+		if current == nil {
+			for _, metric := range defaults {
+				metrics = append(metrics, metric)
+			}
+		} else if list, ok := current.([]interface{}); ok {
+			metrics = list
+		} else {
+			return nil, false
+		}
+		for _, metric := range metrics {
+			if metric == "ticks" {
+				return nil, false
+			}
+		}
+		return append(metrics, "ticks"), true
+	}
+}
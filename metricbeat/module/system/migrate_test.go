@@ -0,0 +1,47 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package system
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateCPUTicks(t *testing.T) {
+	migrate := MigrateCPUTicks([]string{"percentages", "normalized_percentages"})
+
+	metrics, ok := migrate(true, nil)
+	assert.True(t, ok)
+	assert.Equal(t, []interface{}{"percentages", "normalized_percentages", "ticks"}, metrics)
+
+	metrics, ok = migrate(true, []interface{}{"percentages"})
+	assert.True(t, ok)
+	assert.Equal(t, []interface{}{"percentages", "ticks"}, metrics)
+
+	_, ok = migrate(true, []interface{}{"ticks"})
+	assert.False(t, ok)
+
+	_, ok = migrate(false, nil)
+	assert.False(t, ok)
+
+	_, ok = migrate(true, "percentages")
+	assert.False(t, ok)
+}
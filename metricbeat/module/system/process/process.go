@@ -39,6 +39,13 @@ func init() {
 		mb.WithHostParser(parse.EmptyHostParser),
 		mb.DefaultMetricSet(),
 	)
+	mb.Registry.MustAddMigration(mb.Migration{
+		Module:      "system",
+		MetricSet:   "process",
+		Setting:     "cpu_ticks",
+		Replacement: "process.include_cpu_ticks",
+		Since:       "6.1.0",
+	})
 }
 
 // MetricSet that fetches process metrics.
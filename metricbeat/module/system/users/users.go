@@ -20,6 +20,7 @@
 package users
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strconv"
@@ -46,7 +47,7 @@ func init() {
 type MetricSet struct {
 	mb.BaseMetricSet
 	counter int
-	conn    *dbus.Conn
+	conn    *mb.LazyConnection[*dbus.Conn]
 }
 
 // New creates a new instance of the MetricSet. New is responsible for unpacking
@@ -54,10 +55,10 @@ type MetricSet struct {
 func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 	cfgwarn.Beta("The system users metricset is beta.")
 
-	conn, err := initDbusConnection()
-	if err != nil {
-		return nil, fmt.Errorf("error connecting to dbus: %w", err)
-	}
+	conn := mb.NewLazyConnection(
+		func(context.Context) (*dbus.Conn, error) { return initDbusConnection() },
+		(*dbus.Conn).Close,
+	)
 
 	return &MetricSet{
 		BaseMetricSet: base,
@@ -70,18 +71,29 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 // format. It publishes the event which is then forwarded to the output. In case
 // of an error set the Error field of mb.Event or simply call report.Error().
 func (m *MetricSet) Fetch(report mb.ReporterV2) error {
-	sessions, err := listSessions(m.conn)
+	conn, err := m.conn.Get(context.Background())
 	if err != nil {
+		return fmt.Errorf("error connecting to dbus: %w", err)
+	}
+
+	sessions, err := listSessions(conn)
+	if err != nil {
+		_ = m.conn.Reset()
 		return fmt.Errorf("error listing sessions: %w", err)
 	}
 
-	err = eventMapping(m.conn, sessions, report)
+	err = eventMapping(conn, sessions, report)
 	if err != nil {
 		return fmt.Errorf("error formatting event: %w", err)
 	}
 	return nil
 }
 
+// Close closes the connection to dbus.
+func (m *MetricSet) Close() error {
+	return m.conn.Close()
+}
+
 // eventMapping iterates through the lists of users and sessions, combining the two
 func eventMapping(conn *dbus.Conn, sessions []loginSession, report mb.ReporterV2) error {
 
@@ -0,0 +1,67 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificates
+
+import (
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/traefik"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("traefik", "certificates", New,
+		mb.WithHostParser(traefik.HostParser),
+	)
+}
+
+// MetricSet collects the expiration of the TLS certificates of Traefik v2
+// and v3.
+type MetricSet struct {
+	mb.BaseMetricSet
+	prometheus prometheus.Prometheus
+}
+
+// New creates a new instance of the certificates MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	prometheus, err := prometheus.NewPrometheusClient(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		prometheus:    prometheus,
+	}, nil
+}
+
+// Fetch reports an event for every TLS certificate.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	metrics, err := traefik.FetchMetrics(m.prometheus)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range eventsMapping(metrics, time.Now()) {
+		if !reporter.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
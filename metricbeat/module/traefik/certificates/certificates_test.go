@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package certificates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/traefik"
+	"github.com/elastic/beats/v7/metricbeat/module/traefik/mtest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetch(t *testing.T) {
+	server := mtest.NewServer(t)
+
+	f := mbtest.NewReportingMetricSetV2Error(t, mtest.GetConfig("certificates", server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "TRAEFIK DEFAULT CERT", events[0].MetricSetFields["common_name"])
+	assert.Equal(t, "whoami.example.com", events[1].MetricSetFields["common_name"])
+}
+
+func TestEventsMapping(t *testing.T) {
+	server := mtest.NewServer(t)
+
+	f := mbtest.NewReportingMetricSetV2Error(t, mtest.GetConfig("certificates", server.URL))
+	metrics, err := traefik.FetchMetrics(f.(*MetricSet).prometheus)
+	require.NoError(t, err)
+
+	now := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	events := eventsMapping(metrics, now)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, mapstr.M{
+		"common_name": "whoami.example.com",
+		"sans":        []string{"whoami.example.com", "www.whoami.example.com"},
+		"serial":      "1157324590317381720939129016541219462712312466",
+		"not_after":   time.Date(2024, 7, 17, 0, 0, 0, 0, time.UTC),
+		"expires_in": mapstr.M{
+			"sec": int64(16 * 24 * 60 * 60),
+		},
+		"expired": false,
+	}, events[1].MetricSetFields)
+
+	events = eventsMapping(metrics, now.AddDate(0, 1, 0))
+	assert.Equal(t, true, events[1].MetricSetFields["expired"])
+	assert.Equal(t, int64(-15*24*60*60), events[1].MetricSetFields["expires_in"].(mapstr.M)["sec"])
+}
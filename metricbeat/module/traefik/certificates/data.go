@@ -0,0 +1,60 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificates
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/traefik"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const notAfterMetric = "traefik_tls_certs_not_after"
+
+func eventsMapping(metrics traefik.Metrics, now time.Time) []mb.Event {
+	certificates := metrics[notAfterMetric]
+	events := make([]mb.Event, 0, len(certificates))
+	for _, metric := range certificates {
+		notAfter := time.Unix(int64(metric.GetGauge().GetValue()), 0).UTC()
+		fields := mapstr.M{
+			"common_name": traefik.Label(metric, "cn"),
+			"serial":      traefik.Label(metric, "serial"),
+			"not_after":   notAfter,
+			"expires_in": mapstr.M{
+				"sec": int64(notAfter.Sub(now).Seconds()),
+			},
+			"expired": !now.Before(notAfter),
+		}
+		if sans := traefik.Label(metric, "sans"); sans != "" {
+			fields["sans"] = strings.Split(sans, ",")
+		}
+		events = append(events, mb.Event{MetricSetFields: fields})
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		a, b := events[i].MetricSetFields, events[j].MetricSetFields
+		if a["common_name"] != b["common_name"] {
+			return a["common_name"].(string) < b["common_name"].(string)
+		}
+		return a["serial"].(string) < b["serial"].(string)
+	})
+	return events
+}
@@ -32,5 +32,5 @@ func init() {
 // AssetTraefik returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/traefik.
 func AssetTraefik() string {
-	return "eJy00bFu2zAQBuBdT/FDS4GidncNBTx2aFEU7RQEBkWdZMYUSfBORvz2AWXJkRXFMRLkRp599+m/FfZ0LCBRUW32GSBGLBXI/51e8gyoiHU0QYx3BX5kADB0EelAkQkh+scjvsN6VaFUVjlNES1JNJozIJIlxVSgURnAJGJcwwXucmabf0O+Ewn5fQbUhmzFRb9lBadamupSyTGkQdF3YXhZAL4HmWq6fkrYkbKyOz8vKa5IUv067YEvRRlHFero29H4hYcN2Pz5CXJV8MbJ5P+XAY41107FXRDT0ppJX7RHufWumTWu4FP97wfC1+dgjWNJIcI4MGnvKl6kROLgHdMiZB7hDZK/w7zZ7a6lMuVo311k+2YwN5BS/e7akmIKaPzguezZoA7Ntr9P9/I3H5dsDhRVQ2cH+ssZh9bo6JdONbWxKOl4q31FvP76Ks+XD6SXcjw1tp8QJwLFgYfEy54CAAD//+cgQmw="
+	return "eJzMmEtv20YQgO/6FQNfChSKCrQ3HwoEbYEUSAzDdtBDUUgr7pDcerXLzgyp6N8Xy4dC0iuJdugkMAFbu/LMNw/NQ2/gEQ/XIKQwNY8LADFi8RquHpqTqwWARk7IFGK8u4ZfFwAA7S0QVkiMUJD/dICfwHqlYauscgkS7FDIJLwAILSoGK8hUwsARhHjMr6Gv6+Y7dUSrnKR4uqfBUBq0Gq+rrW8Aad22KcLP3IogiDyZdGeRABfAgkwVN9HSJDEpCZRgt1b4ywAPWu3KKp3foIzPA/v7wc6wKdH/upnUE5D9cuq9y9j0AGs3+28W4cXg/uO9xEPe096dHeGLjy/1ULriAQ4yRG43P6LiXQve/yrKBcrx/MB3bfalRUkp8RUWNPxdB4ko+yMRLU8cOVuizSZwnlZq1SQRvIa12gl+DyK35Ug1AJhn5skH1OAYXBeoFLW6DgSfioMIa+NWzEmIw0Nl/Uuex7XPSbeaQaLqcAWU0/4BK1VvASHWRPRfY4OjECuuMU6yzyOWAO79d6ics/j/StHyZGeMEZJOooclZX8YoU4o/pDUzTBb0UZhxpS8ruuFvzArQZ4e/snoNOFN05ipSdTE4tFWYjZ4Yxx/lgL7Bcw41hCsQXjgJssiKIQcuEdx4vW2IUTSO5aeaMaf84rfZzElwPfXnTMBKTw3BzrQ2cwn2RQVbYO7lyV/AokbyskleGRA4KqEKWdScjHQtVnY1FS8jrxGnn1Y0RBg+frMh25bi7Wr+BOKJBaPAh4izE6+VKQeHEpyV7Qyd89PNx28r+oi8/bvm96fbuhW4JxiS21cRkY4TDDVUYjraI03e18RLetxCFVXHsTy/l039fyxv7YoFNbi3qzhI023PwNnmCzV+SMyzZxOirtjJG6Ky1O8glSZZIZFd83AnuKIfW0V6S5OcP/SmRhEB8nQid0WNctacZY/RGkQiN1il92RmuLe0U4I8SHz0KnMBRkPBk5LCbW6wvab1txQ9XL43BkOOxUcRSx/DozUZsigrQzLuxFzRLjncMkuJHjPEjkKY70svSo5YWmFTyTeJearCQVCKZEqkvqlXhRdqZ4PXgZ7AHHTw4jVahhe5jOdbbLnumwl7rrBRNupsEvx632gjX0dJ1pbOBEWdTr1HolzyO9O8fX4NWDDLBxbXUrCCvjS4YUJckvIOs2m1aqyp6OYS/1bzd66XGunrXmlAnL8dC2WoztadvF6808nYLo0LOsfwfw8LZ2NJIcDQ2/juFvPx21dnxv41GL9S3mo6NHXjoghcjMB/dwKJ4Gy5pHhE3IpS6VAuQeTZZLA7wzoVMEyHqkS5WxvkI6gVwy6vX2MB/1XbsSlBwyqsd+ofx8lb7UznmoQfwz2L6f3nTKgMn9Scggv5qba+lf4PG2aM46pHz24rAkDwvyeZ6y+KowILkSUIRQFufARuLjfW4CVdiIkKIoS/DOHsLY3Y2eWGFvQ+k8NJIZa2l9/pLG4b1UdiaYEZ6Pd++HaKuTDNFmMhPGu+bby/YDOQBawqYsmm6i/d5tTgOWxUm4+FYzEa6/3TROAsNQFqvF/wMAhDZAQw=="
 }
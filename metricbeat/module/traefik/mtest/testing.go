@@ -17,6 +17,14 @@
 
 package mtest
 
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
 // GetConfig for Traefik
 func GetConfig(metricset string, host string) map[string]interface{} {
 	return map[string]interface{}{
@@ -25,3 +33,36 @@ func GetConfig(metricset string, host string) map[string]interface{} {
 		"hosts":      []string{host},
 	}
 }
+
+// testFiles are the files served by the test server, by path.
+var testFiles = map[string]struct {
+	name        string
+	contentType string
+}{
+	"/metrics":           {"metrics", "text/plain; version=0.0.4"},
+	"/api/http/routers":  {"routers.json", "application/json"},
+	"/api/http/services": {"services.json", "application/json"},
+}
+
+// NewServer starts a server with the Prometheus metrics and the API of
+// Traefik v3, from the test files of the module. It must be used from the
+// directory of a metricset.
+func NewServer(t testing.TB) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, found := testFiles[r.URL.Path]
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		content, err := os.ReadFile(filepath.Join("..", "_meta", "test", file.name))
+		if err != nil {
+			t.Error(err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", file.contentType)
+		w.Write(content)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
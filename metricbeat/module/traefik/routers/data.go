@@ -0,0 +1,170 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package routers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/traefik"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	requestsMetric = "traefik_router_requests_total"
+	durationMetric = "traefik_router_request_duration_seconds"
+)
+
+// router is an HTTP router, as returned by the API.
+type router struct {
+	Name        string          `json:"name"`
+	Provider    string          `json:"provider"`
+	Rule        string          `json:"rule"`
+	Service     string          `json:"service"`
+	Status      string          `json:"status"`
+	EntryPoints []string        `json:"entryPoints"`
+	Middlewares []string        `json:"middlewares"`
+	Priority    int64           `json:"priority"`
+	TLS         json.RawMessage `json:"tls"`
+	Errors      []string        `json:"error"`
+}
+
+// counters are the counters of requests of a router.
+type counters struct {
+	requests      float64
+	statusCodes   map[string]float64
+	durationSum   float64
+	durationCount uint64
+}
+
+// snapshot are the counters of requests of all the routers at a given time.
+type snapshot struct {
+	time     time.Time
+	counters map[string]*counters
+}
+
+func eventsMapping(routers []router, metrics traefik.Metrics, previous *snapshot, now time.Time) ([]mb.Event, *snapshot) {
+	current := &snapshot{
+		time:     now,
+		counters: collectCounters(metrics),
+	}
+
+	events := make([]mb.Event, 0, len(routers))
+	for _, r := range routers {
+		fields := mapstr.M{
+			"name":     r.Name,
+			"provider": r.Provider,
+			"rule":     r.Rule,
+			"service":  r.Service,
+			"status":   r.Status,
+			"tls":      len(r.TLS) > 0 && string(r.TLS) != "null",
+		}
+		if len(r.EntryPoints) > 0 {
+			fields["entry_points"] = r.EntryPoints
+		}
+		if len(r.Middlewares) > 0 {
+			fields["middlewares"] = r.Middlewares
+		}
+		if r.Priority != 0 {
+			fields["priority"] = r.Priority
+		}
+		if len(r.Errors) > 0 {
+			fields["errors"] = r.Errors
+		}
+
+		if _, found := current.counters[r.Name]; found {
+			fields["requests"] = requestsMapping(r.Name, current, previous)
+		}
+		events = append(events, mb.Event{MetricSetFields: fields})
+	}
+	return events, current
+}
+
+// collectCounters sums the counters of the requests of every router. The
+// metrics of the routers are only available when `addRoutersLabels` is
+// enabled in the configuration of the Prometheus metrics of Traefik.
+func collectCounters(metrics traefik.Metrics) map[string]*counters {
+	routers := map[string]*counters{}
+	get := func(name string) *counters {
+		c, found := routers[name]
+		if !found {
+			c = &counters{statusCodes: map[string]float64{}}
+			routers[name] = c
+		}
+		return c
+	}
+
+	for _, metric := range metrics[requestsMetric] {
+		name := traefik.Label(metric, "router")
+		if name == "" {
+			continue
+		}
+		value := metric.GetCounter().GetValue()
+		c := get(name)
+		c.requests += value
+		if code := traefik.Label(metric, "code"); code != "" {
+			c.statusCodes[code] += value
+		}
+	}
+
+	for _, metric := range metrics[durationMetric] {
+		name := traefik.Label(metric, "router")
+		if name == "" {
+			continue
+		}
+		histogram := metric.GetHistogram()
+		c := get(name)
+		c.durationSum += histogram.GetSampleSum()
+		c.durationCount += histogram.GetSampleCount()
+	}
+	return routers
+}
+
+// requestsMapping maps the counters of requests of a router. The rate and
+// the average duration of the requests are calculated since the previous
+// fetch, they are not reported on the first fetch or after a restart of
+// Traefik.
+func requestsMapping(name string, current *snapshot, previous *snapshot) mapstr.M {
+	c := current.counters[name]
+	requests := mapstr.M{
+		"total": int64(c.requests),
+	}
+	if len(c.statusCodes) > 0 {
+		statusCodes := mapstr.M{}
+		for code, count := range c.statusCodes {
+			statusCodes[code] = int64(count)
+		}
+		requests["status_codes"] = statusCodes
+	}
+
+	if previous == nil {
+		return requests
+	}
+	prev, found := previous.counters[name]
+	elapsed := current.time.Sub(previous.time).Seconds()
+	if !found || c.requests < prev.requests || elapsed <= 0 {
+		return requests
+	}
+	requests["rate"] = (c.requests - prev.requests) / elapsed
+	if c.durationCount > prev.durationCount && c.durationSum >= prev.durationSum {
+		avg := (c.durationSum - prev.durationSum) / float64(c.durationCount-prev.durationCount)
+		_, _ = requests.Put("duration.avg.us", int64(avg*1000*1000))
+	}
+	return requests
+}
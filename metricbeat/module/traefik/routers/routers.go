@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package routers
+
+import (
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/traefik"
+)
+
+const routersPath = "/api/http/routers"
+
+func init() {
+	mb.Registry.MustAddMetricSet("traefik", "routers", New,
+		mb.WithHostParser(traefik.HostParser),
+	)
+}
+
+// MetricSet collects the configuration and the requests of the HTTP routers
+// of Traefik v2 and v3.
+type MetricSet struct {
+	mb.BaseMetricSet
+	prometheus prometheus.Prometheus
+	api        *traefik.APIClient
+
+	// previous are the counters of the previous fetch, used to calculate the
+	// rates of requests.
+	previous *snapshot
+}
+
+// New creates a new instance of the routers MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	prometheus, err := prometheus.NewPrometheusClient(base)
+	if err != nil {
+		return nil, err
+	}
+	api, err := traefik.NewAPIClient(base, routersPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		prometheus:    prometheus,
+		api:           api,
+	}, nil
+}
+
+// Fetch reports an event for every HTTP router.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	routers, err := traefik.FetchList[router](m.api)
+	if err != nil {
+		return err
+	}
+	metrics, err := traefik.FetchMetrics(m.prometheus)
+	if err != nil {
+		return err
+	}
+
+	events, current := eventsMapping(routers, metrics, m.previous, time.Now())
+	m.previous = current
+	for _, event := range events {
+		if !reporter.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package routers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/traefik/mtest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetch(t *testing.T) {
+	server := mtest.NewServer(t)
+
+	f := mbtest.NewReportingMetricSetV2Error(t, mtest.GetConfig("routers", server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 4)
+
+	assert.Equal(t, mapstr.M{
+		"name":         "whoami@file",
+		"provider":     "file",
+		"rule":         "Host(`whoami.example.com`)",
+		"service":      "whoami",
+		"status":       "enabled",
+		"tls":          true,
+		"entry_points": []string{"web", "websecure"},
+		"middlewares":  []string{"compress@file"},
+		"requests": mapstr.M{
+			"total": int64(1500),
+			"status_codes": mapstr.M{
+				"200": int64(1496),
+				"502": int64(4),
+			},
+		},
+	}, events[3].MetricSetFields)
+
+	broken := events[2].MetricSetFields
+	assert.Equal(t, "disabled", broken["status"])
+	assert.Equal(t, true, broken["tls"])
+	assert.Equal(t, []string{`the service "missing@file" does not exist`}, broken["errors"])
+	assert.NotContains(t, broken, "requests")
+
+	api := events[0].MetricSetFields
+	assert.Equal(t, false, api["tls"])
+	assert.Equal(t, int64(9223372036854775806), api["priority"])
+	assert.NotContains(t, api, "requests")
+
+	dashboard := events[1].MetricSetFields
+	assertValue(t, dashboard, "requests.total", int64(24))
+}
+
+func TestFetchPages(t *testing.T) {
+	pages := map[string]string{
+		"1": `[{"name": "a@file", "status": "enabled"}, {"name": "b@file", "status": "enabled"}]`,
+		"2": `[{"name": "c@file", "status": "enabled"}]`,
+	}
+	nextPages := map[string]string{"1": "2", "2": "1"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/metrics" {
+			w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+			return
+		}
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+		page := r.URL.Query().Get("page")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Next-Page", nextPages[page])
+		w.Write([]byte(pages[page]))
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, mtest.GetConfig("routers", server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 3)
+	assert.Equal(t, "c@file", events[2].MetricSetFields["name"])
+}
+
+func TestRequestsRate(t *testing.T) {
+	now := time.Now()
+	previous := &snapshot{
+		time: now.Add(-10 * time.Second),
+		counters: map[string]*counters{
+			"whoami@file": {requests: 1000, durationSum: 10, durationCount: 1000},
+		},
+	}
+	current := &snapshot{
+		time: now,
+		counters: map[string]*counters{
+			"whoami@file": {requests: 1500, durationSum: 17, durationCount: 1500},
+			"new@file":    {requests: 10, durationSum: 1, durationCount: 10},
+		},
+	}
+
+	requests := requestsMapping("whoami@file", current, previous)
+	assert.Equal(t, 50.0, requests["rate"])
+	assertValue(t, requests, "duration.avg.us", int64(14000))
+
+	requests = requestsMapping("new@file", current, previous)
+	assert.NotContains(t, requests, "rate")
+
+	requests = requestsMapping("whoami@file", current, nil)
+	assert.NotContains(t, requests, "rate")
+
+	// Counters are reset when Traefik restarts.
+	previous.counters["whoami@file"].requests = 2000
+	requests = requestsMapping("whoami@file", current, previous)
+	assert.NotContains(t, requests, "rate")
+}
+
+func assertValue(t *testing.T, fields mapstr.M, key string, expected interface{}) {
+	t.Helper()
+	value, err := fields.GetValue(key)
+	if assert.NoError(t, err, key) {
+		assert.Equal(t, expected, value, key)
+	}
+}
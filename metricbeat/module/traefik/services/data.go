@@ -0,0 +1,191 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"strings"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/traefik"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	requestsMetric = "traefik_service_requests_total"
+	retriesMetric  = "traefik_service_retries_total"
+	serverUpMetric = "traefik_service_server_up"
+)
+
+// service is an HTTP service, as returned by the API.
+type service struct {
+	Name         string   `json:"name"`
+	Provider     string   `json:"provider"`
+	Type         string   `json:"type"`
+	Status       string   `json:"status"`
+	UsedBy       []string `json:"usedBy"`
+	LoadBalancer *struct {
+		Servers []struct {
+			URL string `json:"url"`
+		} `json:"servers"`
+	} `json:"loadBalancer"`
+	ServerStatus map[string]string `json:"serverStatus"`
+}
+
+// counters are the counters of requests of a service.
+type counters struct {
+	requests    float64
+	statusCodes map[string]float64
+	retries     float64
+	hasRetries  bool
+}
+
+func eventsMapping(services []service, metrics traefik.Metrics) []mb.Event {
+	serviceCounters := collectCounters(metrics)
+	serversUp := collectServersUp(metrics)
+
+	var events []mb.Event
+	for _, s := range services {
+		fields := mapstr.M{
+			"name":     s.Name,
+			"provider": s.Provider,
+			"status":   s.Status,
+		}
+		if s.Type != "" {
+			fields["type"] = s.Type
+		}
+		if len(s.UsedBy) > 0 {
+			fields["used_by"] = s.UsedBy
+		}
+		if c, found := serviceCounters[s.Name]; found {
+			fields["requests"] = requestsMapping(c)
+			if c.hasRetries {
+				fields["retries"] = int64(c.retries)
+			}
+		}
+
+		var servers []mapstr.M
+		if s.LoadBalancer != nil {
+			up := 0
+			for _, server := range s.LoadBalancer.Servers {
+				status := serverStatus(s, server.URL, serversUp)
+				if status == "up" {
+					up++
+				}
+				servers = append(servers, mapstr.M{
+					"url":    server.URL,
+					"status": status,
+					"up":     status == "up",
+				})
+			}
+			fields["servers"] = mapstr.M{
+				"total": len(s.LoadBalancer.Servers),
+				"up":    up,
+			}
+		}
+		events = append(events, mb.Event{MetricSetFields: fields})
+
+		for _, server := range servers {
+			events = append(events, mb.Event{
+				MetricSetFields: mapstr.M{
+					"name":     s.Name,
+					"provider": s.Provider,
+					"server":   server,
+				},
+			})
+		}
+	}
+	return events
+}
+
+// serverStatus returns the status of a server of a service. The status
+// reported by the API is used when available, or else the one of the
+// Prometheus metrics. Servers are up when no health check is configured.
+func serverStatus(s service, url string, serversUp map[string]map[string]bool) string {
+	if status, found := s.ServerStatus[url]; found {
+		return strings.ToLower(status)
+	}
+	if up, found := serversUp[s.Name][url]; found && !up {
+		return "down"
+	}
+	return "up"
+}
+
+func collectCounters(metrics traefik.Metrics) map[string]*counters {
+	services := map[string]*counters{}
+	get := func(name string) *counters {
+		c, found := services[name]
+		if !found {
+			c = &counters{statusCodes: map[string]float64{}}
+			services[name] = c
+		}
+		return c
+	}
+
+	for _, metric := range metrics[requestsMetric] {
+		name := traefik.Label(metric, "service")
+		if name == "" {
+			continue
+		}
+		value := metric.GetCounter().GetValue()
+		c := get(name)
+		c.requests += value
+		if code := traefik.Label(metric, "code"); code != "" {
+			c.statusCodes[code] += value
+		}
+	}
+
+	for _, metric := range metrics[retriesMetric] {
+		name := traefik.Label(metric, "service")
+		if name == "" {
+			continue
+		}
+		c := get(name)
+		c.retries += metric.GetCounter().GetValue()
+		c.hasRetries = true
+	}
+	return services
+}
+
+func collectServersUp(metrics traefik.Metrics) map[string]map[string]bool {
+	servers := map[string]map[string]bool{}
+	for _, metric := range metrics[serverUpMetric] {
+		name, url := traefik.Label(metric, "service"), traefik.Label(metric, "url")
+		if name == "" || url == "" {
+			continue
+		}
+		if servers[name] == nil {
+			servers[name] = map[string]bool{}
+		}
+		servers[name][url] = metric.GetGauge().GetValue() == 1
+	}
+	return servers
+}
+
+func requestsMapping(c *counters) mapstr.M {
+	requests := mapstr.M{
+		"total": int64(c.requests),
+	}
+	if len(c.statusCodes) > 0 {
+		statusCodes := mapstr.M{}
+		for code, count := range c.statusCodes {
+			statusCodes[code] = int64(count)
+		}
+		requests["status_codes"] = statusCodes
+	}
+	return requests
+}
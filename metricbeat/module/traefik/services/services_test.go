@@ -0,0 +1,119 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/metricbeat/module/traefik"
+	"github.com/elastic/beats/v7/metricbeat/module/traefik/mtest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetch(t *testing.T) {
+	server := mtest.NewServer(t)
+
+	f := mbtest.NewReportingMetricSetV2Error(t, mtest.GetConfig("services", server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 5)
+
+	assert.Equal(t, mapstr.M{
+		"name":     "api@internal",
+		"provider": "internal",
+		"status":   "enabled",
+		"used_by":  []string{"api@internal"},
+	}, events[0].MetricSetFields)
+
+	assert.Equal(t, mapstr.M{
+		"name":     "canary@file",
+		"provider": "file",
+		"type":     "weighted",
+		"status":   "enabled",
+		"used_by":  []string{"canary@file"},
+	}, events[1].MetricSetFields)
+
+	assert.Equal(t, mapstr.M{
+		"name":     "whoami@file",
+		"provider": "file",
+		"type":     "loadbalancer",
+		"status":   "enabled",
+		"used_by":  []string{"whoami@file"},
+		"requests": mapstr.M{
+			"total": int64(1500),
+			"status_codes": mapstr.M{
+				"200": int64(1496),
+				"502": int64(4),
+			},
+		},
+		"retries": int64(6),
+		"servers": mapstr.M{
+			"total": 2,
+			"up":    1,
+		},
+	}, events[2].MetricSetFields)
+
+	assert.Equal(t, mapstr.M{
+		"name":     "whoami@file",
+		"provider": "file",
+		"server": mapstr.M{
+			"url":    "http://10.0.0.11:80",
+			"status": "up",
+			"up":     true,
+		},
+	}, events[3].MetricSetFields)
+
+	assert.Equal(t, mapstr.M{
+		"name":     "whoami@file",
+		"provider": "file",
+		"server": mapstr.M{
+			"url":    "http://10.0.0.12:80",
+			"status": "down",
+			"up":     false,
+		},
+	}, events[4].MetricSetFields)
+}
+
+func TestServerStatus(t *testing.T) {
+	serversUp := map[string]map[string]bool{
+		"whoami@docker": {
+			"http://172.18.0.3:80": true,
+			"http://172.18.0.4:80": false,
+		},
+	}
+	s := service{Name: "whoami@docker"}
+
+	assert.Equal(t, "up", serverStatus(s, "http://172.18.0.3:80", serversUp))
+	assert.Equal(t, "down", serverStatus(s, "http://172.18.0.4:80", serversUp))
+	assert.Equal(t, "up", serverStatus(s, "http://172.18.0.5:80", serversUp))
+
+	s.ServerStatus = map[string]string{"http://172.18.0.3:80": "DOWN"}
+	assert.Equal(t, "down", serverStatus(s, "http://172.18.0.3:80", serversUp))
+}
+
+func TestEventsMappingWithoutMetrics(t *testing.T) {
+	events := eventsMapping([]service{{Name: "whoami@docker", Provider: "docker"}}, traefik.Metrics{})
+	require.Len(t, events, 1)
+	assert.NotContains(t, events[0].MetricSetFields, "requests")
+}
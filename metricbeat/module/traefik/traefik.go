@@ -0,0 +1,143 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package traefik
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+)
+
+const (
+	defaultScheme      = "http"
+	defaultMetricsPath = "/metrics"
+)
+
+// HostParser parses the hosts of the metricsets reading the Prometheus
+// metrics of Traefik v2 and v3. The path of the metrics can be set with
+// `metrics_path`.
+var HostParser = parse.URLHostParserBuilder{
+	DefaultScheme: defaultScheme,
+	DefaultPath:   defaultMetricsPath,
+	PathConfigKey: "metrics_path",
+}.Build()
+
+// apiPageSize is the number of items requested in each page of the lists of
+// the API.
+const apiPageSize = 100
+
+// APIClient fetches the lists of an endpoint of the API of Traefik, that is
+// served on the same host as the metrics.
+type APIClient struct {
+	http *helper.HTTP
+	uri  string
+}
+
+// NewAPIClient creates a client for the given path of the API of Traefik.
+func NewAPIClient(base mb.BaseMetricSet, path string) (*APIClient, error) {
+	http, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(base.HostData().SanitizedURI)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing URL of host: %w", err)
+	}
+	u.Path = path
+	u.RawQuery = ""
+	return &APIClient{http: http, uri: u.String()}, nil
+}
+
+// FetchList fetches all the pages of the list returned by the endpoint of
+// the API client.
+func FetchList[T any](c *APIClient) ([]T, error) {
+	var items []T
+	for page := 1; ; {
+		c.http.SetURI(fmt.Sprintf("%s?per_page=%d&page=%d", c.uri, apiPageSize, page))
+		next, err := fetchPage(c.http, &items)
+		if err != nil {
+			return nil, err
+		}
+		// The API returns the first page as next page after the last one.
+		if next <= page {
+			return items, nil
+		}
+		page = next
+	}
+}
+
+func fetchPage[T any](http *helper.HTTP, items *[]T) (int, error) {
+	resp, err := http.FetchResponse()
+	if err != nil {
+		return 0, fmt.Errorf("error fetching %s: %w", http.GetURI(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return 0, fmt.Errorf("HTTP error %d in %s: %s", resp.StatusCode, http.GetURI(), resp.Status)
+	}
+
+	var page []T
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return 0, fmt.Errorf("error decoding response of %s: %w", http.GetURI(), err)
+	}
+	*items = append(*items, page...)
+
+	next, err := strconv.Atoi(resp.Header.Get("X-Next-Page"))
+	if err != nil {
+		// Without pagination header, this is the only page.
+		return 0, nil
+	}
+	return next, nil
+}
+
+// Metrics are the Prometheus metrics of Traefik, indexed by the name of
+// their family.
+type Metrics map[string][]*prometheus.OpenMetric
+
+// FetchMetrics fetches the Prometheus metrics of Traefik.
+func FetchMetrics(p prometheus.Prometheus) (Metrics, error) {
+	families, err := p.GetFamilies()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching metrics: %w", err)
+	}
+
+	metrics := make(Metrics, len(families))
+	for _, family := range families {
+		metrics[family.GetName()] = append(metrics[family.GetName()], family.GetMetric()...)
+	}
+	return metrics, nil
+}
+
+// Label returns the value of the label of a metric, or an empty string if
+// the metric doesn't have it.
+func Label(metric *prometheus.OpenMetric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.Name == name {
+			return label.Value
+		}
+	}
+	return ""
+}
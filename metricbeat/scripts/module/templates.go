@@ -0,0 +1,30 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package module contains the templates of the files of new modules and
+// metricsets. They are used by the generate metricset command and by
+// create_metricset.py. The {module} and {metricset} placeholders are replaced by
+// the names of the module and metricset.
+package module
+
+import "embed"
+
+// Templates contains the templates of the module files, and of the metricset
+// files under metricset/.
+//
+//go:embed config.yml doc.go.tmpl docs.asciidoc fields.yml metricset
+var Templates embed.FS
@@ -0,0 +1,46 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tracing
+
+import (
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+// Config is the configuration of the tracing of metricset fetches.
+type Config struct {
+	Enabled bool `config:"enabled"`
+
+	// Endpoint is the base URL of the OTLP/HTTP receiver, spans are sent to
+	// its /v1/traces path.
+	Endpoint string            `config:"endpoint" validate:"required"`
+	Headers  map[string]string `config:"headers"`
+
+	// SamplingRate is the ratio of the fetches that are traced.
+	SamplingRate float64 `config:"sampling_rate" validate:"min=0, max=1"`
+
+	Transport httpcommon.HTTPTransportSettings `config:",inline"`
+}
+
+// DefaultConfig returns the default configuration, tracing is disabled.
+func DefaultConfig() Config {
+	return Config{
+		Endpoint:     "http://localhost:4318",
+		SamplingRate: 1,
+		Transport:    httpcommon.DefaultHTTPTransportSettings(),
+	}
+}
@@ -0,0 +1,235 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// The types below are the subset of the JSON encoding of the OTLP traces
+// protocol used by the exporter. See
+// https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/trace/v1/trace.proto
+
+const (
+	statusCodeOk    = 1
+	statusCodeError = 2
+)
+
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope  `json:"scope"`
+	Spans []span `json:"spans"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type span struct {
+	TraceID           string     `json:"traceId"` // IDs are encoded in hex.
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Events            []event    `json:"events,omitempty"`
+	Status            status     `json:"status"`
+}
+
+type event struct {
+	TimeUnixNano string     `json:"timeUnixNano"`
+	Name         string     `json:"name"`
+	Attributes   []keyValue `json:"attributes,omitempty"`
+}
+
+type status struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"` // 64-bit integers are encoded as strings.
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// exporter exports spans to an OTLP/HTTP endpoint.
+type exporter struct {
+	client *http.Client
+	url    string
+}
+
+// ExportSpans sends the spans to the endpoint.
+func (e *exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(makeRequest(spans))
+	if err != nil {
+		return fmt.Errorf("failed to encode spans: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %v from %v", resp.StatusCode, e.url)
+	}
+	return nil
+}
+
+// Shutdown releases the connections of the exporter.
+func (e *exporter) Shutdown(context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// makeRequest groups the spans by resource and instrumentation scope.
+func makeRequest(spans []sdktrace.ReadOnlySpan) exportRequest {
+	var request exportRequest
+	resources := map[attribute.Distinct]int{}
+	scopes := map[attribute.Distinct]map[instrumentation.Scope]int{}
+
+	for _, s := range spans {
+		key := s.Resource().Equivalent()
+		ri, found := resources[key]
+		if !found {
+			ri = len(request.ResourceSpans)
+			resources[key] = ri
+			scopes[key] = map[instrumentation.Scope]int{}
+			request.ResourceSpans = append(request.ResourceSpans, resourceSpans{
+				Resource: resource{Attributes: makeAttributes(s.Resource().Attributes())},
+			})
+		}
+		rs := &request.ResourceSpans[ri]
+
+		si, found := scopes[key][s.InstrumentationScope()]
+		if !found {
+			si = len(rs.ScopeSpans)
+			scopes[key][s.InstrumentationScope()] = si
+			rs.ScopeSpans = append(rs.ScopeSpans, scopeSpans{Scope: scope{
+				Name:    s.InstrumentationScope().Name,
+				Version: s.InstrumentationScope().Version,
+			}})
+		}
+		rs.ScopeSpans[si].Spans = append(rs.ScopeSpans[si].Spans, makeSpan(s))
+	}
+	return request
+}
+
+func makeSpan(s sdktrace.ReadOnlySpan) span {
+	out := span{
+		TraceID:           s.SpanContext().TraceID().String(),
+		SpanID:            s.SpanContext().SpanID().String(),
+		Name:              s.Name(),
+		Kind:              int(s.SpanKind()),
+		StartTimeUnixNano: formatTime(s.StartTime()),
+		EndTimeUnixNano:   formatTime(s.EndTime()),
+		Attributes:        makeAttributes(s.Attributes()),
+	}
+	if s.Parent().IsValid() {
+		out.ParentSpanID = s.Parent().SpanID().String()
+	}
+	for _, e := range s.Events() {
+		out.Events = append(out.Events, event{
+			TimeUnixNano: formatTime(e.Time),
+			Name:         e.Name,
+			Attributes:   makeAttributes(e.Attributes),
+		})
+	}
+	switch s.Status().Code {
+	case codes.Ok:
+		out.Status = status{Code: statusCodeOk}
+	case codes.Error:
+		out.Status = status{Code: statusCodeError, Message: s.Status().Description}
+	}
+	return out
+}
+
+func makeAttributes(attrs []attribute.KeyValue) []keyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	out := make([]keyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		out = append(out, keyValue{Key: string(attr.Key), Value: makeValue(attr.Value)})
+	}
+	return out
+}
+
+func makeValue(v attribute.Value) anyValue {
+	switch v.Type() {
+	case attribute.BOOL:
+		b := v.AsBool()
+		return anyValue{BoolValue: &b}
+	case attribute.INT64:
+		i := strconv.FormatInt(v.AsInt64(), 10)
+		return anyValue{IntValue: &i}
+	case attribute.FLOAT64:
+		f := v.AsFloat64()
+		return anyValue{DoubleValue: &f}
+	default:
+		// Slices are encoded as strings.
+		s := v.Emit()
+		return anyValue{StringValue: &s}
+	}
+}
+
+func formatTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
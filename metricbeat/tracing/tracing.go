@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package tracing sets up the OpenTelemetry tracing of metricset fetches, and
+// exports the spans to an OpenTelemetry collector or APM server with the
+// OTLP/HTTP protocol.
+package tracing
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+// NewTracerProvider returns a tracer provider that exports the spans of the
+// beat in batches to the configured endpoint. It must be shut down to export
+// the pending spans and release its resources.
+func NewTracerProvider(info beat.Info, config Config) (*sdktrace.TracerProvider, error) {
+	logger := logp.NewLogger("tracing")
+	client, err := config.Transport.Client(
+		httpcommon.WithLogger(logger),
+		httpcommon.WithHeaderRoundTripper(config.Headers),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP client: %w", err)
+	}
+
+	exporter := &exporter{
+		client: client,
+		url:    strings.TrimSuffix(config.Endpoint, "/") + "/v1/traces",
+	}
+	logger.Infof("Exporting the traces of metricset fetches to %v", exporter.url)
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SamplingRate))),
+		sdktrace.WithResource(makeResource(info)),
+	), nil
+}
+
+// makeResource returns the resource identifying the beat that reports the
+// spans.
+func makeResource(info beat.Info) *sdkresource.Resource {
+	attrs := []attribute.KeyValue{
+		attribute.String("service.name", info.Beat),
+		attribute.String("service.version", info.Version),
+		attribute.String("service.instance.id", info.ID.String()),
+	}
+	if info.Hostname != "" {
+		attrs = append(attrs, attribute.String("host.name", info.Hostname))
+	}
+	return sdkresource.NewSchemaless(attrs...)
+}
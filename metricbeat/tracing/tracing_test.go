@@ -0,0 +1,114 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+)
+
+func TestTracerProvider(t *testing.T) {
+	requests := make(chan exportRequest, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+
+		var request exportRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		requests <- request
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.Endpoint = server.URL
+	config.Headers = map[string]string{"Authorization": "secret"}
+
+	info := beat.Info{Beat: "metricbeat", Version: "8.15.0", ID: uuid.Must(uuid.NewV4()), Hostname: "host"}
+	provider, err := NewTracerProvider(info, config)
+	require.NoError(t, err)
+
+	tracer := provider.Tracer("metricbeat")
+	ctx, parent := tracer.Start(context.Background(), "fetch redis/info",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attribute.String("metricbeat.module", "redis"), attribute.Int("attempt", 2)),
+	)
+	_, child := tracer.Start(ctx, "query")
+	child.End()
+	parent.SetStatus(codes.Error, "connection refused")
+	parent.End()
+
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	request := <-requests
+	require.Len(t, request.ResourceSpans, 1)
+	rs := request.ResourceSpans[0]
+	assert.Contains(t, rs.Resource.Attributes, keyValue{Key: "service.name", Value: stringValue("metricbeat")})
+	assert.Contains(t, rs.Resource.Attributes, keyValue{Key: "host.name", Value: stringValue("host")})
+
+	require.Len(t, rs.ScopeSpans, 1)
+	assert.Equal(t, "metricbeat", rs.ScopeSpans[0].Scope.Name)
+	spans := rs.ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	query, fetch := spans[0], spans[1]
+	assert.Equal(t, "query", query.Name)
+	assert.Equal(t, fetch.TraceID, query.TraceID)
+	assert.Equal(t, fetch.SpanID, query.ParentSpanID)
+	assert.Equal(t, status{}, query.Status)
+
+	assert.Equal(t, "fetch redis/info", fetch.Name)
+	assert.Equal(t, parent.SpanContext().TraceID().String(), fetch.TraceID)
+	assert.Empty(t, fetch.ParentSpanID)
+	assert.Equal(t, int(trace.SpanKindInternal), fetch.Kind)
+	assert.Equal(t, status{Code: statusCodeError, Message: "connection refused"}, fetch.Status)
+	attempt := "2"
+	assert.Equal(t, []keyValue{
+		{Key: "metricbeat.module", Value: stringValue("redis")},
+		{Key: "attempt", Value: anyValue{IntValue: &attempt}},
+	}, fetch.Attributes)
+}
+
+func TestTracerProviderSampling(t *testing.T) {
+	config := DefaultConfig()
+	config.SamplingRate = 0
+
+	provider, err := NewTracerProvider(beat.Info{Beat: "metricbeat"}, config)
+	require.NoError(t, err)
+	defer provider.Shutdown(context.Background())
+
+	_, span := provider.Tracer("metricbeat").Start(context.Background(), "fetch")
+	defer span.End()
+	assert.False(t, span.IsRecording())
+}
+
+func stringValue(s string) anyValue {
+	return anyValue{StringValue: &s}
+}
@@ -15,6 +15,9 @@ import (
 	"github.com/elastic/beats/v7/libbeat/publisher/processing"
 	"github.com/elastic/beats/v7/metricbeat/beater"
 	mbcmd "github.com/elastic/beats/v7/metricbeat/cmd"
+	"github.com/elastic/beats/v7/metricbeat/cmd/bench"
+	"github.com/elastic/beats/v7/metricbeat/cmd/fetch"
+	"github.com/elastic/beats/v7/metricbeat/cmd/generate"
 	"github.com/elastic/beats/v7/metricbeat/cmd/test"
 	"github.com/elastic/beats/v7/x-pack/libbeat/management"
 	"github.com/elastic/elastic-agent-libs/mapstr"
@@ -50,10 +53,20 @@ func init() {
 	}
 	settings := mbcmd.MetricbeatSettings()
 	settings.ElasticLicensed = true
-	settings.Processing = processing.MakeDefaultSupport(true, globalProcs, withECSVersion, processing.WithHost, processing.WithAgentMeta())
+	settings.Processing = mbcmd.WithDryRun(processing.MakeDefaultSupport(true, globalProcs, withECSVersion, processing.WithHost, processing.WithAgentMeta()))
 	RootCmd = cmd.GenRootCmdWithSettings(beater.DefaultCreator(), settings)
-	RootCmd.AddCommand(cmd.GenModulesCmd(Name, "", mbcmd.BuildModulesManager))
+	modulesCmd := cmd.GenModulesCmd(Name, "", mbcmd.BuildModulesManager)
+	modulesCmd.AddCommand(mbcmd.GenDescribeModulesCmd(Name, ""))
+	RootCmd.AddCommand(modulesCmd)
 	RootCmd.TestCmd.AddCommand(test.GenTestModulesCmd(Name, "", beater.DefaultTestModulesCreator()))
+	RootCmd.AddCommand(generate.GenGenerateCmd())
+	RootCmd.AddCommand(fetch.GenFetchCmd(Name, ""))
+	RootCmd.AddCommand(bench.GenBenchCmd(Name, ""))
+	RootCmd.ExportCmd.AddCommand(mbcmd.GenExportModuleReferenceCmd(Name, ""))
+	RootCmd.AddCommand(mbcmd.GenMigrateCmd(settings))
+	mbcmd.AddTestConfigFlags(RootCmd)
+	mbcmd.AddExportConfigFlags(RootCmd, settings)
+	mbcmd.AddSetupFlags(RootCmd)
 	RootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
 		management.ConfigTransform.SetTransform(metricbeatCfg)
 	}
@@ -21,17 +21,25 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/azure/billing"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/azure/monitor"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/azure/storage"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/caddy"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/caddy/admin"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/caddy/upstreams"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/cloudfoundry"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/cloudfoundry/container"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/cloudfoundry/counter"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/cloudfoundry/value"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/cockroachdb"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/cockroachdb/node"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/cockroachdb/ranges"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/containerd"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/containerd/blkio"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/containerd/cpu"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/containerd/memory"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/coredns"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/coredns/stats"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/dns_server"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/dns_server/bind"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/dns_server/powerdns"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/enterprisesearch"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/enterprisesearch/health"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/enterprisesearch/stats"
@@ -55,9 +63,16 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/performance"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/sysmetric"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/tablespace"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/postfix"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/postfix/queue"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/postfix/smtp"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/prometheus"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/prometheus/collector"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/prometheus/remote_write"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/pulsar"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/pulsar/broker"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/pulsar/namespace"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/pulsar/topic"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/redisenterprise"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/sql"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/sql/query"
@@ -73,4 +88,8 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/syncgateway/replication"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/syncgateway/resources"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/tomcat"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/vault"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/vault/health"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/vault/metrics"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/vault/seal_status"
 )
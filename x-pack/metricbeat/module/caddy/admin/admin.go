@@ -0,0 +1,74 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package admin
+
+import (
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/caddy"
+)
+
+const configPath = "/config/"
+
+func init() {
+	mb.Registry.MustAddMetricSet("caddy", "admin", New,
+		mb.WithHostParser(caddy.HostParser),
+		mb.DefaultMetricSet(),
+	)
+}
+
+// MetricSet collects the state of the configuration of Caddy, and the
+// requests of its HTTP routes.
+type MetricSet struct {
+	mb.BaseMetricSet
+	prometheus prometheus.Prometheus
+	api        *caddy.APIClient
+
+	// previous are the counters of the previous fetch, used to calculate the
+	// rates of requests.
+	previous *snapshot
+}
+
+// New creates a new instance of the admin MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	prometheus, err := prometheus.NewPrometheusClient(base)
+	if err != nil {
+		return nil, err
+	}
+	api, err := caddy.NewAPIClient(base, configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		prometheus:    prometheus,
+		api:           api,
+	}, nil
+}
+
+// Fetch reports an event with the state of the configuration of Caddy,
+// followed by an event for every HTTP route.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	var config config
+	if err := m.api.FetchJSON(&config); err != nil {
+		return err
+	}
+	metrics, err := caddy.FetchMetrics(m.prometheus)
+	if err != nil {
+		return err
+	}
+
+	events, current := eventsMapping(config, metrics, m.previous, time.Now())
+	m.previous = current
+	for _, event := range events {
+		if !reporter.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package admin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/caddy/mtest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetch(t *testing.T) {
+	server := mtest.NewServer(t)
+
+	f := mbtest.NewReportingMetricSetV2Error(t, mtest.GetConfig("admin", server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, mapstr.M{
+		"config": mapstr.M{
+			"apps": []string{"http", "tls"},
+			"http": mapstr.M{
+				"servers": 2,
+				"routes":  3,
+			},
+			"last_reload": mapstr.M{
+				"successful": true,
+				"time":       time.Unix(1712345600, 0).UTC(),
+			},
+		},
+		"api": mapstr.M{
+			"requests": mapstr.M{
+				"total":  int64(54),
+				"errors": int64(1),
+			},
+		},
+	}, events[0].MetricSetFields)
+
+	assert.Equal(t, mapstr.M{
+		"route": mapstr.M{
+			"server":  "srv0",
+			"handler": "reverse_proxy",
+			"listen":  []string{":8080"},
+		},
+		"requests": mapstr.M{
+			"total":     int64(105),
+			"in_flight": int64(2),
+			"errors":    int64(5),
+			"status_codes": mapstr.M{
+				"200": int64(100),
+				"502": int64(5),
+			},
+		},
+	}, events[1].MetricSetFields)
+
+	assertValue(t, events[2].MetricSetFields, "route.server", "srv1")
+	assertValue(t, events[2].MetricSetFields, "route.handler", "static_response")
+	assertValue(t, events[2].MetricSetFields, "requests.total", int64(40))
+}
+
+func TestRequestsRate(t *testing.T) {
+	proxy := route{server: "srv0", handler: "reverse_proxy"}
+	static := route{server: "srv1", handler: "static_response"}
+
+	now := time.Now()
+	previous := &snapshot{
+		time: now.Add(-10 * time.Second),
+		counters: map[route]*counters{
+			proxy: {requests: 1000, durationSum: 10, durationCount: 1000},
+		},
+	}
+	current := &snapshot{
+		time: now,
+		counters: map[route]*counters{
+			proxy:  {requests: 1500, durationSum: 17, durationCount: 1500},
+			static: {requests: 10, durationSum: 1, durationCount: 10},
+		},
+	}
+
+	requests := requestsMapping(proxy, current, previous)
+	assert.Equal(t, 50.0, requests["rate"])
+	assertValue(t, requests, "duration.avg.us", int64(14000))
+
+	requests = requestsMapping(static, current, previous)
+	assert.NotContains(t, requests, "rate")
+
+	requests = requestsMapping(proxy, current, nil)
+	assert.NotContains(t, requests, "rate")
+
+	// Counters are reset when Caddy restarts.
+	previous.counters[proxy].requests = 2000
+	requests = requestsMapping(proxy, current, previous)
+	assert.NotContains(t, requests, "rate")
+}
+
+func assertValue(t *testing.T, fields mapstr.M, key string, expected interface{}) {
+	t.Helper()
+	value, err := fields.GetValue(key)
+	if assert.NoError(t, err, key) {
+		assert.Equal(t, expected, value, key)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package admin
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/caddy"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	adminRequestsMetric = "caddy_admin_http_requests_total"
+	adminErrorsMetric   = "caddy_admin_http_request_errors_total"
+	reloadSuccessMetric = "caddy_config_last_reload_successful"
+	reloadTimeMetric    = "caddy_config_last_reload_success_timestamp_seconds"
+
+	requestsMetric = "caddy_http_requests_total"
+	inFlightMetric = "caddy_http_requests_in_flight"
+	errorsMetric   = "caddy_http_request_errors_total"
+	durationMetric = "caddy_http_request_duration_seconds"
+)
+
+// config is the configuration of Caddy, as returned by the admin API.
+type config struct {
+	Apps map[string]json.RawMessage `json:"apps"`
+}
+
+// httpApp is the configuration of the HTTP app of Caddy.
+type httpApp struct {
+	Servers map[string]struct {
+		Listen []string          `json:"listen"`
+		Routes []json.RawMessage `json:"routes"`
+	} `json:"servers"`
+}
+
+// route identifies the routes of Caddy in its metrics, by the name of their
+// server and of their handler.
+type route struct {
+	server  string
+	handler string
+}
+
+// counters are the counters of requests of a route.
+type counters struct {
+	requests      float64
+	inFlight      float64
+	errors        float64
+	statusCodes   map[string]float64
+	durationSum   float64
+	durationCount uint64
+}
+
+// snapshot are the counters of requests of all the routes at a given time.
+type snapshot struct {
+	time     time.Time
+	counters map[route]*counters
+}
+
+func eventsMapping(config config, metrics caddy.Metrics, previous *snapshot, now time.Time) ([]mb.Event, *snapshot) {
+	var app httpApp
+	if raw, found := config.Apps["http"]; found {
+		_ = json.Unmarshal(raw, &app)
+	}
+
+	events := []mb.Event{{MetricSetFields: instanceMapping(config, app, metrics)}}
+
+	current := &snapshot{
+		time:     now,
+		counters: collectCounters(metrics),
+	}
+	routes := make([]route, 0, len(current.counters))
+	for r := range current.counters {
+		routes = append(routes, r)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].server != routes[j].server {
+			return routes[i].server < routes[j].server
+		}
+		return routes[i].handler < routes[j].handler
+	})
+
+	for _, r := range routes {
+		fields := mapstr.M{
+			"route": mapstr.M{
+				"server":  r.server,
+				"handler": r.handler,
+			},
+			"requests": requestsMapping(r, current, previous),
+		}
+		if server, found := app.Servers[r.server]; found && len(server.Listen) > 0 {
+			_, _ = fields.Put("route.listen", server.Listen)
+		}
+		events = append(events, mb.Event{MetricSetFields: fields})
+	}
+	return events, current
+}
+
+// instanceMapping maps the state of the configuration of Caddy, and the
+// requests to its admin API.
+func instanceMapping(config config, app httpApp, metrics caddy.Metrics) mapstr.M {
+	apps := make([]string, 0, len(config.Apps))
+	for name := range config.Apps {
+		apps = append(apps, name)
+	}
+	sort.Strings(apps)
+
+	routes := 0
+	for _, server := range app.Servers {
+		routes += len(server.Routes)
+	}
+
+	fields := mapstr.M{
+		"config": mapstr.M{
+			"apps": apps,
+			"http": mapstr.M{
+				"servers": len(app.Servers),
+				"routes":  routes,
+			},
+		},
+		"api": mapstr.M{
+			"requests": mapstr.M{
+				"total":  int64(sum(metrics[adminRequestsMetric])),
+				"errors": int64(sum(metrics[adminErrorsMetric])),
+			},
+		},
+	}
+
+	if reload := metrics[reloadSuccessMetric]; len(reload) > 0 {
+		_, _ = fields.Put("config.last_reload.successful", caddy.Value(reload[0]) == 1)
+	}
+	if reload := metrics[reloadTimeMetric]; len(reload) > 0 {
+		if seconds := caddy.Value(reload[0]); seconds > 0 {
+			_, _ = fields.Put("config.last_reload.time", time.Unix(int64(seconds), 0).UTC())
+		}
+	}
+	return fields
+}
+
+func sum(metrics []*prometheus.OpenMetric) float64 {
+	var total float64
+	for _, metric := range metrics {
+		total += caddy.Value(metric)
+	}
+	return total
+}
+
+// collectCounters sums the counters of the requests of every route. The
+// metrics of the HTTP servers are only available when they are enabled in
+// the configuration of Caddy.
+func collectCounters(metrics caddy.Metrics) map[route]*counters {
+	routes := map[route]*counters{}
+	get := func(metric *prometheus.OpenMetric) *counters {
+		r := route{server: caddy.Label(metric, "server"), handler: caddy.Label(metric, "handler")}
+		c, found := routes[r]
+		if !found {
+			c = &counters{statusCodes: map[string]float64{}}
+			routes[r] = c
+		}
+		return c
+	}
+
+	for _, metric := range metrics[requestsMetric] {
+		get(metric).requests += caddy.Value(metric)
+	}
+	for _, metric := range metrics[inFlightMetric] {
+		get(metric).inFlight += caddy.Value(metric)
+	}
+	for _, metric := range metrics[errorsMetric] {
+		get(metric).errors += caddy.Value(metric)
+	}
+	for _, metric := range metrics[durationMetric] {
+		histogram := metric.GetHistogram()
+		c := get(metric)
+		c.durationSum += histogram.GetSampleSum()
+		c.durationCount += histogram.GetSampleCount()
+		if code := caddy.Label(metric, "code"); code != "" {
+			c.statusCodes[code] += float64(histogram.GetSampleCount())
+		}
+	}
+	return routes
+}
+
+// requestsMapping maps the counters of requests of a route. The rate and the
+// average duration of the requests are calculated since the previous fetch,
+// they are not reported on the first fetch or after a restart of Caddy.
+func requestsMapping(r route, current *snapshot, previous *snapshot) mapstr.M {
+	c := current.counters[r]
+	requests := mapstr.M{
+		"total":     int64(c.requests),
+		"in_flight": int64(c.inFlight),
+		"errors":    int64(c.errors),
+	}
+	if len(c.statusCodes) > 0 {
+		statusCodes := mapstr.M{}
+		for code, count := range c.statusCodes {
+			statusCodes[code] = int64(count)
+		}
+		requests["status_codes"] = statusCodes
+	}
+
+	if previous == nil {
+		return requests
+	}
+	prev, found := previous.counters[r]
+	elapsed := current.time.Sub(previous.time).Seconds()
+	if !found || c.requests < prev.requests || elapsed <= 0 {
+		return requests
+	}
+	requests["rate"] = (c.requests - prev.requests) / elapsed
+	if c.durationCount > prev.durationCount && c.durationSum >= prev.durationSum {
+		avg := (c.durationSum - prev.durationSum) / float64(c.durationCount-prev.durationCount)
+		_, _ = requests.Put("duration.avg.us", int64(avg*1000*1000))
+	}
+	return requests
+}
@@ -0,0 +1,103 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package caddy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+)
+
+const (
+	defaultScheme      = "http"
+	defaultMetricsPath = "/metrics"
+)
+
+// HostParser parses the hosts of the metricsets, that are the addresses of
+// the admin API of Caddy. The path of the Prometheus metrics can be set with
+// `metrics_path`.
+var HostParser = parse.URLHostParserBuilder{
+	DefaultScheme: defaultScheme,
+	DefaultPath:   defaultMetricsPath,
+	PathConfigKey: "metrics_path",
+}.Build()
+
+// APIClient fetches an endpoint of the admin API of Caddy, that is served on
+// the same host as the metrics.
+type APIClient struct {
+	http *helper.HTTP
+}
+
+// NewAPIClient creates a client for the given path of the admin API of Caddy.
+func NewAPIClient(base mb.BaseMetricSet, path string) (*APIClient, error) {
+	http, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(base.HostData().SanitizedURI)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing URL of host: %w", err)
+	}
+	u.Path = path
+	u.RawQuery = ""
+	http.SetURI(u.String())
+	return &APIClient{http: http}, nil
+}
+
+// FetchJSON fetches the endpoint of the API client, and decodes its response
+// into v.
+func (c *APIClient) FetchJSON(v interface{}) error {
+	content, err := c.http.FetchContent()
+	if err != nil {
+		return fmt.Errorf("error fetching %s: %w", c.http.GetURI(), err)
+	}
+	if err := json.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("error decoding response of %s: %w", c.http.GetURI(), err)
+	}
+	return nil
+}
+
+// Metrics are the Prometheus metrics of Caddy, indexed by the name of their
+// family.
+type Metrics map[string][]*prometheus.OpenMetric
+
+// FetchMetrics fetches the Prometheus metrics of Caddy.
+func FetchMetrics(p prometheus.Prometheus) (Metrics, error) {
+	families, err := p.GetFamilies()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching metrics: %w", err)
+	}
+
+	metrics := make(Metrics, len(families))
+	for _, family := range families {
+		metrics[family.GetName()] = append(metrics[family.GetName()], family.GetMetric()...)
+	}
+	return metrics, nil
+}
+
+// Label returns the value of the label of a metric, or an empty string if
+// the metric doesn't have it.
+func Label(metric *prometheus.OpenMetric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.Name == name {
+			return label.Value
+		}
+	}
+	return ""
+}
+
+// Value returns the value of a counter or a gauge.
+func Value(metric *prometheus.OpenMetric) float64 {
+	if counter := metric.GetCounter(); counter != nil {
+		return counter.GetValue()
+	}
+	return metric.GetGauge().GetValue()
+}
@@ -0,0 +1,23 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Code generated by beats/dev-tools/cmd/asset/asset.go - DO NOT EDIT.
+
+package caddy
+
+import (
+	"github.com/elastic/beats/v7/libbeat/asset"
+)
+
+func init() {
+	if err := asset.SetFields("metricbeat", "caddy", asset.ModuleFieldsPri, AssetCaddy); err != nil {
+		panic(err)
+	}
+}
+
+// AssetCaddy returns asset data.
+// This is the base64 encoded zlib format compressed contents of module/caddy.
+func AssetCaddy() string {
+	return "eJy8mEGP6jYQx+/5FCOOFS8fgEOlVS/t5emp3Z6qimfsCXFx7NQzYcu3r+xgCMIhIXqslgsx8fz+k5n/OPsFDnjagBRKnQoA1mxwA6tfwvdVAaCQpNcta2c38HMBABDXoHGqM1gAeDQoCDewQxYFACGztnvawF8rIrNaw6pmbld/FwCVRqNoE7f5AlY0eA0d/vjU4gb23nXt+Uomfvh8j3d9B+ksC20JuEZokL2WBNIZg5JRQeVdE5eEarSFt2+/gat6AeV5ryHSECvecbmaQ3uAFz5/sGAM4UJ86Wyl950XIZEXhjUIq8Djvx0SU7ismeDX9/dv4F3HSAkS4D7PAHkBN7mNYW+WxpRMqJmpaAg8BneT5balu8WEeMDTh/Mqsz4BGj5vbWu0jPmmCyyqNRh9QAgVCc4Dm0GORynDr0tCf0Q/Tmuc3S9D/do1O/Qhh/HRnwMNoOci9kXzUsI+xIANtAVhTKyJIf4MZiOItx6NE6qkTkokqjozir9zzqCwyxS8+w5B980Y4kIfN1/NH4LgCvSkEtYNjmpQgnGhAN1cei8KuAI+0jICn8BFq8vkPyU7FqaYWTkTyIOKSfbG7taKn0FD752nz2MDrgWDR+pMmCPaQk8wwRy7I0u5wG6vc2ANWqFlXWlUsDvFKdG7RBwg4WstrDLon/Xffpe75R/iwF/FtWIHzpAuxVyN5HOIeFb2esZzoBu+87jwGCwNt613/53C3Ki0wW2fvBkSjCZG+xoFb0p5JEJ6mOczAsGkI6SWKHKkC6r499RiQ5xnyzTnTA8dYGbyMk7QV0FsswzuKKG228rofc2fQyk779GyOS3lzRrqi2BHvDScHMb6boYCYsEdbaVTSOVPo0rc7h+UuafSL2x/pF5qnSW8LfXwYHpUCKgzhPn8GaEHrYwTvIz00okteiCUzqps9QBpKzEqaD0etesIKmRZz2BX6dghjvuye0V9vR3Riz1eIl2SndQ9JWkdirDR0rs+IYMBnyR1LbFH0VAx5YYP6P9Mm1xx40SBMFE0UvYFaskbn+jHQZFLen7OTOT8PF8Sd8rGlTOLkXq/FJL1EYuZNTDB8owRzgSthDb0AjyJluPmqO5OmQltDR4bDPdcsVtBpI8INQrDNcga5WHq1Nn/Nv0PZc5L04SQ4ctSggVNKdAanDUn8Ng6Hxz9o0Z7V9RhMqU7QNYoD1QW/w8A8ZzQmA=="
+}
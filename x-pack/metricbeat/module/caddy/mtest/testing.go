@@ -0,0 +1,55 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package mtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// GetConfig for Caddy
+func GetConfig(metricset string, host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "caddy",
+		"metricsets": []string{metricset},
+		"hosts":      []string{host},
+	}
+}
+
+// testFiles are the files served by the test server, by path.
+var testFiles = map[string]struct {
+	name        string
+	contentType string
+}{
+	"/metrics":                 {"metrics", "text/plain; version=0.0.4"},
+	"/config/":                 {"config.json", "application/json"},
+	"/reverse_proxy/upstreams": {"upstreams.json", "application/json"},
+}
+
+// NewServer starts a server with the Prometheus metrics and the admin API of
+// Caddy, from the test files of the module. It must be used from the
+// directory of a metricset.
+func NewServer(t testing.TB) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, found := testFiles[r.URL.Path]
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		content, err := os.ReadFile(filepath.Join("..", "_meta", "test", file.name))
+		if err != nil {
+			t.Error(err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", file.contentType)
+		w.Write(content)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
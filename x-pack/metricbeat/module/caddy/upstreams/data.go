@@ -0,0 +1,50 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package upstreams
+
+import (
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/caddy"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const healthyMetric = "caddy_reverse_proxy_upstreams_healthy"
+
+// upstream is an upstream of the reverse proxies, as returned by the admin
+// API.
+type upstream struct {
+	Address     string `json:"address"`
+	NumRequests int64  `json:"num_requests"`
+	Fails       int64  `json:"fails"`
+	// Healthy is only returned by older versions of Caddy.
+	Healthy *bool `json:"healthy"`
+}
+
+func eventsMapping(upstreams []upstream, metrics caddy.Metrics) []mb.Event {
+	// The health of the upstreams is only reported in the metrics when they
+	// have health checks.
+	healthy := map[string]bool{}
+	for _, metric := range metrics[healthyMetric] {
+		healthy[caddy.Label(metric, "upstream")] = caddy.Value(metric) == 1
+	}
+
+	events := make([]mb.Event, 0, len(upstreams))
+	for _, u := range upstreams {
+		fields := mapstr.M{
+			"address": u.Address,
+			"requests": mapstr.M{
+				"active": u.NumRequests,
+			},
+			"fails": u.Fails,
+		}
+		if h, found := healthy[u.Address]; found {
+			fields["healthy"] = h
+		} else if u.Healthy != nil {
+			fields["healthy"] = *u.Healthy
+		}
+		events = append(events, mb.Event{MetricSetFields: fields})
+	}
+	return events
+}
@@ -0,0 +1,65 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package upstreams
+
+import (
+	"github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/caddy"
+)
+
+const upstreamsPath = "/reverse_proxy/upstreams"
+
+func init() {
+	mb.Registry.MustAddMetricSet("caddy", "upstreams", New,
+		mb.WithHostParser(caddy.HostParser),
+		mb.DefaultMetricSet(),
+	)
+}
+
+// MetricSet collects the state of the upstreams of the reverse proxies of
+// Caddy.
+type MetricSet struct {
+	mb.BaseMetricSet
+	prometheus prometheus.Prometheus
+	api        *caddy.APIClient
+}
+
+// New creates a new instance of the upstreams MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	prometheus, err := prometheus.NewPrometheusClient(base)
+	if err != nil {
+		return nil, err
+	}
+	api, err := caddy.NewAPIClient(base, upstreamsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		prometheus:    prometheus,
+		api:           api,
+	}, nil
+}
+
+// Fetch reports an event for every upstream.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	var upstreams []upstream
+	if err := m.api.FetchJSON(&upstreams); err != nil {
+		return err
+	}
+	metrics, err := caddy.FetchMetrics(m.prometheus)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range eventsMapping(upstreams, metrics) {
+		if !reporter.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
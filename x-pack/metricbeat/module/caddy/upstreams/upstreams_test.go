@@ -0,0 +1,70 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package upstreams
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/caddy/mtest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetch(t *testing.T) {
+	server := mtest.NewServer(t)
+
+	f := mbtest.NewReportingMetricSetV2Error(t, mtest.GetConfig("upstreams", server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, mapstr.M{
+		"address": "127.0.0.1:8081",
+		"requests": mapstr.M{
+			"active": int64(2),
+		},
+		"fails":   int64(0),
+		"healthy": true,
+	}, events[0].MetricSetFields)
+
+	assert.Equal(t, mapstr.M{
+		"address": "127.0.0.1:8082",
+		"requests": mapstr.M{
+			"active": int64(0),
+		},
+		"fails":   int64(3),
+		"healthy": false,
+	}, events[1].MetricSetFields)
+}
+
+func TestFetchWithoutHealthChecks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metrics":
+			w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		case "/reverse_proxy/upstreams":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[{"address": "app:8080", "num_requests": 1, "fails": 0}, {"address": "old:8080", "healthy": false, "num_requests": 0, "fails": 1}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, mtest.GetConfig("upstreams", server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 2)
+
+	assert.NotContains(t, events[0].MetricSetFields, "healthy")
+	assert.Equal(t, false, events[1].MetricSetFields["healthy"])
+}
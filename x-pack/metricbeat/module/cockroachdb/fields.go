@@ -19,5 +19,5 @@ func init() {
 // AssetCockroachdb returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/cockroachdb.
 func AssetCockroachdb() string {
-	return "eJxcjztuxDAMBXud4kGNmzgHUJEiyQHSBykUiWsT1g8iVfj2C+8HMJblgAMOZ2y0O4Qatl59WOO/AZQ1kcP09aTfn5MBOiXyQg6LN0AkCZ2bci0OHwYATvvINY5EkNB9I2TSzkEwhMuCn14z6UpDQCW2ykXfDSCkymURh18rkuwb7Kra7J8BLkwpirudmVF8ptfoY3RvR16voz3I2bu75y+uAQAA//8vgE98"
+	return "eJzMms1u4zgSx+9+ikJf5pLWA+SwQG8n2B2gZ5DtZPayWAglsmwTpkiFVXKP5+kXpD6seGRbzljOIjkYsln/XxW/iiV+hg3t7kF5tQke1VoXCwAxYukefvraPX34+08LgECWkOkeVrgA0MQqmEqMd/fwtwUAwOD3UHpdWwJWASuCkiQYxVCzcSt4Cr4kWVPNQE5X3jjJFgBMIsat+B7+84nZfrqDT2uR6tN/FwBLQ1bzfZL5DA5LOoSOf7KrIl7wddU+GbYbtnVeU/9wrCHAwOGCBAfPR1zv/r7WAYV077BfAr6JSxQGdBqMMLD4QJwNLBzyDpmNfvO4o7berQ6+OAEY/39+AL8EWVOiyUbFUOtAzBnqLQUxTKPaG9r98EFfJv+lMQ29aQ3FrscB8emzlzWF9IQ7WmVrFgqngeOQuT5rS/DPl5enfsxODyK/2tmQnv/17QKiojZWZ4Kr6/H8mwIb72I0hgM91M7FyW7cJKSVz7eNoVnI/uGhZtJxcKUQDFFPRsqUxILl+JDSKHQZ0gMKdb2UFA7CNs5SV2JKypjUOIavC3shyFPwKo6ixvQdGAdMyjvN4wgllT7sssCcFTshnroULX0oUe5hrNEZxO/ERpOTuDEAmz/6wFUN+zioquqsZgpZpeTAZNNprNCSzpfWoxyBrSgocnIZ7lPTCFeJMyLA16ffIIYX6sEid5aedyxUfjB/A/FOD8QL2sz58NFenMK/gwiI1vyx/8rVZUGhbXnEwZUPvhbjps+BM8i/9qJ70+PSS535itzVdaNRWBpLfRMfjiBYsyUXOz9+yOPWd/0wRNPtzm/ccOe/A2RgItf0l+ET20oPuiYMUhAKZ1yr2PNXB27tLmubgHptGGhPhlyisXWgq0NGu6QvBFTWq03ul0smyUpCl7lrhe8XQtfYh8Y+/DCyPsz90s7k0PmTu9MbTBataXs90GdBpzFo0LQ1KG1GETmvRM+vNlPeOVLROF+951FJnFExUxzIHGd5rSnsMuVrJ1dniRDRviEG+p1ULaSPkzBZUjIjyvPjt8evL8CCQiU5mQJlHFOYE+rnX58fv18IVVcxGZ0R6renhy8vj5dBabI0K9TD47fHS6Ha9XVGqgGOrFHalfc4kfzusoJWxs3IJAEdx4XAu1h3wHAyRpFI+bI0ciukRu0sFBY+3IwpiZ0jCt7aAtXmVlBRLyasUXIcLBaVDiyO17cmgPwyrGOlclW39/058xqvXp2sYJ074E8gfFvQSojZUQCFFSoju/aEMnYoPdlt5060E3lfonrqqHja6qAu9QG3aCwWlj7Mjy8dwV/2JR4vP8yN50P47rQ4KMx0daypDgViCtv/J6c6oni+BnZY8doLH3clnhE+jn5Q7okgoFFwYh8EdCviZopfij4Bbb82N0KXUdWun7a3YEtHqiX9oJDOmhCoskYhg6zRgSPS7YB4rX2oywn4mkLeWhHSH++DrPsn0SoIhhXJWUf89mP8KOMWeg032ta3GOYd6MSB3pFZQk2BZ2X7jkuBVmgaEtPa29m50otDaJWmxyp3XvKbQQ6DFxcEAQwxsZOL8V9rQzxSrL0ub6ui97pHyZIDxwp+16UaFP+SKgR6rYlPbq3xd5xRCD7MytbW/KZyBSrQolPGrbK2SJNXFPKmejWideL900TWL1tKacq+8t4Kx/J/+0oK2sSlLdKnjfauKenHXGZIPcm1H8HIR3i2oR1DFBdyQ/fE/wW/vNqwLrJAqHMsK2uW7RYy68DShjcQNTk5Evtsdx5S+bJqz7Gz0g10zkMVsYibK1RrytZG5l0skhgkMYhil+GVhpluB9jIZYs/oaXccnGuvPCe6zOxhtJfbaiCLyyVXf40epcmW5wuP3TMqVxx0xs0xzP996qOZpS+FiDn69X6IK08ltXv+U6m8leFbNP3y1PeDtZvb8da+tCDvQPV+TzgMqVxmsKcpLHrBzncUZw0DecG2aI1baYxDjIIyj7NzZsccza4tWcaxgjSO2NJHTpMcseR+7Q2p9caLedi1Ma41by4/dCL2XiPkC4NtvqAjQeMJaXrGaci7le5eJ/bOGRnA4/zBttA+xWI95AEx8GUCao2kheBcEMhH0uEr80mwVTV/uzQMUDLsAf9/Oai6/8GAKTocTo="
 }
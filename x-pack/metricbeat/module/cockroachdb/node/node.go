@@ -0,0 +1,102 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package node
+
+import (
+	"github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+)
+
+const (
+	defaultScheme = "http"
+	defaultPath   = "/_status/vars"
+)
+
+var (
+	hostParser = parse.URLHostParserBuilder{
+		DefaultScheme: defaultScheme,
+		DefaultPath:   defaultPath,
+		PathConfigKey: "metrics_path",
+	}.Build()
+)
+
+// mapping contains a curated set of the metrics of the Prometheus endpoint of
+// the nodes. Metrics of the stores of the node are reported in an event for
+// each store.
+var mapping = &prometheus.MetricsMapping{
+	Metrics: map[string]prometheus.MetricMap{
+		"node_id":         prometheus.Metric("id"),
+		"build_timestamp": prometheus.Metric("build.timestamp", prometheus.OpUnixTimestampValue()),
+
+		// Process
+		"sys_uptime":                          prometheus.Metric("uptime.sec"),
+		"sys_rss":                             prometheus.Metric("memory.rss.bytes"),
+		"sys_cpu_user_percent":                prometheus.Metric("cpu.user.pct"),
+		"sys_cpu_sys_percent":                 prometheus.Metric("cpu.system.pct"),
+		"sys_cpu_combined_percent_normalized": prometheus.Metric("cpu.total.norm.pct"),
+		"sys_goroutines":                      prometheus.Metric("goroutines"),
+		"sys_fd_open":                         prometheus.Metric("fd.open"),
+
+		// Cluster
+		"liveness_livenodes":          prometheus.Metric("liveness.live_nodes"),
+		"liveness_heartbeatsuccesses": prometheus.Metric("liveness.heartbeats.success"),
+		"liveness_heartbeatfailures":  prometheus.Metric("liveness.heartbeats.failure"),
+		"clock_offset_meannanos":      prometheus.Metric("clock_offset.mean.ns"),
+		"clock_offset_stddevnanos":    prometheus.Metric("clock_offset.stddev.ns"),
+
+		// SQL
+		"sql_conns":              prometheus.Metric("sql.connections"),
+		"sql_query_count":        prometheus.Metric("sql.query.count"),
+		"sql_select_count":       prometheus.Metric("sql.select.count"),
+		"sql_insert_count":       prometheus.Metric("sql.insert.count"),
+		"sql_update_count":       prometheus.Metric("sql.update.count"),
+		"sql_delete_count":       prometheus.Metric("sql.delete.count"),
+		"sql_failure_count":      prometheus.Metric("sql.failure.count"),
+		"sql_txn_begin_count":    prometheus.Metric("sql.txn.begin.count"),
+		"sql_txn_commit_count":   prometheus.Metric("sql.txn.commit.count"),
+		"sql_txn_abort_count":    prometheus.Metric("sql.txn.abort.count"),
+		"sql_txn_rollback_count": prometheus.Metric("sql.txn.rollback.count"),
+
+		// Stores
+		"capacity":                          prometheus.Metric("store.capacity.total.bytes"),
+		"capacity_available":                prometheus.Metric("store.capacity.available.bytes"),
+		"capacity_used":                     prometheus.Metric("store.capacity.used.bytes"),
+		"capacity_reserved":                 prometheus.Metric("store.capacity.reserved.bytes"),
+		"livebytes":                         prometheus.Metric("store.live.bytes"),
+		"ranges":                            prometheus.Metric("store.ranges.total"),
+		"ranges_unavailable":                prometheus.Metric("store.ranges.unavailable"),
+		"ranges_underreplicated":            prometheus.Metric("store.ranges.under_replicated"),
+		"ranges_overreplicated":             prometheus.Metric("store.ranges.over_replicated"),
+		"replicas":                          prometheus.Metric("store.replicas.total"),
+		"replicas_leaders":                  prometheus.Metric("store.replicas.leaders"),
+		"replicas_leaseholders":             prometheus.Metric("store.replicas.leaseholders"),
+		"replicas_leaders_not_leaseholders": prometheus.Metric("store.replicas.leaders_not_leaseholders"),
+		"replicas_quiescent":                prometheus.Metric("store.replicas.quiescent"),
+		"leases_success":                    prometheus.Metric("store.leases.success"),
+		"leases_error":                      prometheus.Metric("store.leases.error"),
+		"rebalancing_queriespersecond":      prometheus.Metric("store.rebalancing.queries_per_second"),
+		"rebalancing_writespersecond":       prometheus.Metric("store.rebalancing.writes_per_second"),
+		"rocksdb_read_amplification":        prometheus.Metric("store.rocksdb.read_amplification"),
+		"rocksdb_compactions":               prometheus.Metric("store.rocksdb.compactions"),
+		"rocksdb_block_cache_hits":          prometheus.Metric("store.rocksdb.block_cache.hits"),
+		"rocksdb_block_cache_misses":        prometheus.Metric("store.rocksdb.block_cache.misses"),
+	},
+
+	Labels: map[string]prometheus.LabelMap{
+		"store":          prometheus.KeyLabel("store.id"),
+		"advertise_addr": prometheus.Label("address.advertise"),
+		"http_addr":      prometheus.Label("address.http"),
+		"sql_addr":       prometheus.Label("address.sql"),
+		"tag":            prometheus.Label("build.tag"),
+		"go_version":     prometheus.Label("build.go_version"),
+	},
+}
+
+func init() {
+	mb.Registry.MustAddMetricSet("cockroachdb", "node",
+		prometheus.MetricSetBuilder(mapping),
+		mb.WithHostParser(hostParser))
+}
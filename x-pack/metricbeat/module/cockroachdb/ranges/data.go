@@ -0,0 +1,79 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package ranges
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// problemRanges is the response of the problem ranges endpoint. Range IDs are
+// only counted, so they are kept raw, as they are encoded as strings.
+type problemRanges struct {
+	ProblemsByNodeID map[string]nodeProblems `json:"problems_by_node_id"`
+}
+
+type nodeProblems struct {
+	ErrorMessage             string            `json:"error_message"`
+	Unavailable              []json.RawMessage `json:"unavailable_range_ids"`
+	RaftLeaderNotLeaseHolder []json.RawMessage `json:"raft_leader_not_lease_holder_range_ids"`
+	NoRaftLeader             []json.RawMessage `json:"no_raft_leader_range_ids"`
+	NoLease                  []json.RawMessage `json:"no_lease_range_ids"`
+	UnderReplicated          []json.RawMessage `json:"underreplicated_range_ids"`
+	OverReplicated           []json.RawMessage `json:"overreplicated_range_ids"`
+	QuiescentEqualsTicking   []json.RawMessage `json:"quiescent_equals_ticking_range_ids"`
+	RaftLogTooLarge          []json.RawMessage `json:"raft_log_too_large_range_ids"`
+	CircuitBreakerError      []json.RawMessage `json:"circuit_breaker_error_range_ids"`
+}
+
+func eventsMapping(r mb.ReporterV2, content []byte) error {
+	var response problemRanges
+	if err := json.Unmarshal(content, &response); err != nil {
+		return fmt.Errorf("error parsing problem ranges: %w", err)
+	}
+
+	// Nodes are reported in order, to make the events predictable.
+	nodeIDs := make([]int64, 0, len(response.ProblemsByNodeID))
+	problemsByNodeID := make(map[int64]nodeProblems, len(response.ProblemsByNodeID))
+	for id, problems := range response.ProblemsByNodeID {
+		nodeID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing node id '%s': %w", id, err)
+		}
+		nodeIDs = append(nodeIDs, nodeID)
+		problemsByNodeID[nodeID] = problems
+	}
+	sort.Slice(nodeIDs, func(i, j int) bool { return nodeIDs[i] < nodeIDs[j] })
+
+	for _, nodeID := range nodeIDs {
+		problems := problemsByNodeID[nodeID]
+		event := mb.Event{
+			MetricSetFields: mapstr.M{
+				"node":                         mapstr.M{"id": nodeID},
+				"unavailable":                  len(problems.Unavailable),
+				"under_replicated":             len(problems.UnderReplicated),
+				"over_replicated":              len(problems.OverReplicated),
+				"no_raft_leader":               len(problems.NoRaftLeader),
+				"no_lease":                     len(problems.NoLease),
+				"raft_leader_not_lease_holder": len(problems.RaftLeaderNotLeaseHolder),
+				"quiescent_equals_ticking":     len(problems.QuiescentEqualsTicking),
+				"raft_log_too_large":           len(problems.RaftLogTooLarge),
+				"circuit_breaker_error":        len(problems.CircuitBreakerError),
+			},
+		}
+		if problems.ErrorMessage != "" {
+			// The counts of a node that couldn't be reached are not meaningful.
+			event.Error = errors.New(problems.ErrorMessage)
+		}
+		r.Event(event)
+	}
+	return nil
+}
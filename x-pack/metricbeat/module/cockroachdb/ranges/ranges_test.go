@@ -0,0 +1,93 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package ranges
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+)
+
+func TestFetch(t *testing.T) {
+	response, err := os.ReadFile(filepath.Join("_meta", "test", "problemranges.json"))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, defaultPath, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	assert.Empty(t, errs)
+	require.Len(t, events, 3)
+
+	expected := []map[string]interface{}{
+		{
+			"node.id":                      int64(1),
+			"unavailable":                  2,
+			"under_replicated":             1,
+			"over_replicated":              1,
+			"no_raft_leader":               1,
+			"raft_leader_not_lease_holder": 0,
+			"raft_log_too_large":           1,
+			"circuit_breaker_error":        2,
+		},
+		{
+			"node.id":                      int64(2),
+			"unavailable":                  0,
+			"under_replicated":             3,
+			"raft_leader_not_lease_holder": 1,
+			"circuit_breaker_error":        0,
+		},
+		{
+			"node.id":     int64(3),
+			"unavailable": 0,
+		},
+	}
+	for i, fields := range expected {
+		for key, value := range fields {
+			actual, err := events[i].MetricSetFields.GetValue(key)
+			if assert.NoError(t, err, key) {
+				assert.Equal(t, value, actual, key)
+			}
+		}
+	}
+	assert.NoError(t, events[0].Error)
+	assert.NoError(t, events[1].Error)
+	assert.EqualError(t, events[2].Error, "rpc error: code = Unavailable desc = node unavailable; first retry")
+}
+
+func TestFetchInvalidResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"problems_by_node_id": {"n1": {}}}`))
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	assert.Empty(t, events)
+	assert.NotEmpty(t, errs)
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "cockroachdb",
+		"metricsets": []string{"ranges"},
+		"hosts":      []string{host},
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package bind
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/dns_server"
+)
+
+const (
+	defaultScheme = "http"
+	defaultPath   = "/json/v1"
+)
+
+var (
+	hostParser = parse.URLHostParserBuilder{
+		DefaultScheme: defaultScheme,
+		DefaultPath:   defaultPath,
+	}.Build()
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("dns_server", "bind", New,
+		mb.WithHostParser(hostParser),
+	)
+}
+
+// MetricSet collects the statistics of BIND from its statistics channel.
+type MetricSet struct {
+	mb.BaseMetricSet
+	http *helper.HTTP
+
+	// previous are the counters of queries of the previous fetch, used to
+	// calculate the rates of queries.
+	previous *dns_server.QueryCounters
+}
+
+// New creates a new instance of the bind MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	http, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		http:          http,
+	}, nil
+}
+
+// Fetch reports an event with the statistics of the server, followed by an
+// event for every zone.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	content, err := m.http.FetchContent()
+	if err != nil {
+		return fmt.Errorf("error in http fetch: %w", err)
+	}
+
+	stats, err := parseStatistics(content)
+	if err != nil {
+		return fmt.Errorf("error parsing statistics of BIND: %w", err)
+	}
+
+	events, current := eventsMapping(stats, m.previous, time.Now())
+	m.previous = current
+	for _, event := range events {
+		if !reporter.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
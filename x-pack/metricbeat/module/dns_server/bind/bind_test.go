@@ -0,0 +1,144 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package bind
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetch(t *testing.T) {
+	files := map[string]struct {
+		name        string
+		contentType string
+	}{
+		"/json/v1": {"stats.json", "application/json"},
+		"/xml/v3":  {"stats.xml", "text/xml"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, found := files[r.URL.Path]
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", file.contentType)
+		http.ServeFile(w, r, filepath.Join("_meta", "test", file.name))
+	}))
+	defer server.Close()
+
+	for name, host := range map[string]string{
+		"json": server.URL,
+		"xml":  server.URL + "/xml/v3",
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := mbtest.NewReportingMetricSetV2Error(t, getConfig(host))
+			events, errs := mbtest.ReportingFetchV2Error(f)
+			require.Empty(t, errs)
+			require.Len(t, events, 3)
+
+			assert.Equal(t, mapstr.M{
+				"version":     "9.18.24",
+				"boot_time":   time.Date(2024, 4, 5, 10, 0, 0, 123000000, time.UTC),
+				"config_time": time.Date(2024, 4, 5, 10, 0, 0, 456000000, time.UTC),
+				"queries": mapstr.M{
+					"total": int64(1520),
+					"types": mapstr.M{
+						"A":    int64(900),
+						"NS":   int64(20),
+						"SOA":  int64(40),
+						"MX":   int64(60),
+						"AAAA": int64(480),
+						"AXFR": int64(2),
+						"IXFR": int64(18),
+					},
+				},
+				"responses": mapstr.M{
+					"rcodes": mapstr.M{
+						"NOERROR":  int64(1380),
+						"FORMERR":  int64(0),
+						"SERVFAIL": int64(12),
+						"NXDOMAIN": int64(120),
+						"REFUSED":  int64(8),
+					},
+				},
+				"cache": mapstr.M{
+					"hits":      int64(750),
+					"misses":    int64(250),
+					"hit_ratio": 0.75,
+				},
+				"zone_transfers": mapstr.M{
+					"outgoing": mapstr.M{
+						"completed": int64(19),
+						"rejected":  int64(1),
+					},
+					"incoming": mapstr.M{
+						"succeeded": int64(3),
+						"failed":    int64(1),
+					},
+				},
+			}, events[0].MetricSetFields)
+
+			assert.Equal(t, mapstr.M{
+				"zone": mapstr.M{
+					"name":   "example.com",
+					"view":   "_default",
+					"class":  "IN",
+					"type":   "primary",
+					"serial": int64(2024040501),
+					"loaded": time.Date(2024, 4, 5, 10, 0, 0, 200000000, time.UTC),
+				},
+			}, events[1].MetricSetFields)
+
+			assert.Equal(t, mapstr.M{
+				"zone": mapstr.M{
+					"name":    "example.org",
+					"view":    "_default",
+					"class":   "IN",
+					"type":    "secondary",
+					"serial":  int64(2024040317),
+					"loaded":  time.Date(2024, 4, 5, 11, 30, 0, 0, time.UTC),
+					"expires": time.Date(2024, 4, 12, 11, 30, 0, 0, time.UTC),
+					"refresh": time.Date(2024, 4, 5, 12, 30, 0, 0, time.UTC),
+				},
+			}, events[2].MetricSetFields)
+		})
+	}
+}
+
+func TestQueryRates(t *testing.T) {
+	content := []byte(`{"opcodes": {"QUERY": 100}, "qtypes": {"A": 60, "AAAA": 40}}`)
+	stats, err := parseStatistics(content)
+	require.NoError(t, err)
+
+	now := time.Now()
+	_, previous := eventsMapping(stats, nil, now.Add(-10*time.Second))
+
+	stats.opcodes["QUERY"] = 150
+	stats.qtypes = map[string]int64{"A": 90, "AAAA": 60}
+	events, _ := eventsMapping(stats, previous, now)
+
+	queries := events[0].MetricSetFields["queries"].(mapstr.M)
+	assert.Equal(t, 5.0, queries["rate"])
+	assert.Equal(t, mapstr.M{"A": 3.0, "AAAA": 2.0}, queries["rates"])
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "dns_server",
+		"metricsets": []string{"bind"},
+		"hosts":      []string{host},
+	}
+}
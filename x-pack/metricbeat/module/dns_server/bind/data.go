@@ -0,0 +1,122 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package bind
+
+import (
+	"sort"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/dns_server"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	// bindView is the view of the zones of BIND itself, like version.bind.
+	bindView = "_bind"
+	// builtinZone is the type of the empty zones created automatically.
+	builtinZone = "builtin"
+)
+
+func eventsMapping(stats *statistics, previous *dns_server.QueryCounters, now time.Time) ([]mb.Event, *dns_server.QueryCounters) {
+	current := &dns_server.QueryCounters{
+		Time:   now,
+		Total:  stats.opcodes["QUERY"],
+		ByType: stats.qtypes,
+	}
+
+	fields := mapstr.M{
+		"version": stats.version,
+		"queries": dns_server.QueriesFields(current, previous),
+		"zone_transfers": mapstr.M{
+			"outgoing": mapstr.M{
+				"completed": stats.nsstats["XfrReqDone"],
+				"rejected":  stats.nsstats["XfrRej"],
+			},
+			"incoming": mapstr.M{
+				"succeeded": stats.zonestats["XfrSuccess"],
+				"failed":    stats.zonestats["XfrFail"],
+			},
+		},
+	}
+	if len(stats.rcodes) > 0 {
+		rcodes := mapstr.M{}
+		for rcode, count := range stats.rcodes {
+			rcodes[rcode] = count
+		}
+		_, _ = fields.Put("responses.rcodes", rcodes)
+	}
+	putTime(fields, "boot_time", stats.bootTime)
+	putTime(fields, "config_time", stats.configTime)
+
+	// The cache is only used by the views with recursion.
+	var hits, misses int64
+	var cached bool
+	for _, v := range stats.views {
+		if len(v.cachestats) == 0 {
+			continue
+		}
+		cached = true
+		hits += v.cachestats["QueryHits"]
+		misses += v.cachestats["QueryMisses"]
+	}
+	if cached {
+		fields["cache"] = dns_server.CacheFields(hits, misses)
+	}
+
+	events := []mb.Event{{MetricSetFields: fields}}
+	for _, v := range stats.views {
+		if v.name == bindView {
+			continue
+		}
+		for _, z := range v.zones {
+			if z.kind == builtinZone {
+				continue
+			}
+			events = append(events, mb.Event{MetricSetFields: mapstr.M{"zone": zoneFields(v.name, z)}})
+		}
+	}
+	return events, current
+}
+
+// zoneFields maps a zone. The expiration and the refresh times are only
+// reported for secondary zones, they are updated by the zone transfers.
+func zoneFields(viewName string, z zone) mapstr.M {
+	fields := mapstr.M{
+		"name": z.name,
+		"view": viewName,
+		"type": z.kind,
+	}
+	if z.class != "" {
+		fields["class"] = z.class
+	}
+	if serial, ok := parseInt(z.serial); ok && serial >= 0 {
+		fields["serial"] = serial
+	}
+	putTime(fields, "loaded", z.loaded)
+	putTime(fields, "expires", z.expires)
+	putTime(fields, "refresh", z.refresh)
+	return fields
+}
+
+func putTime(fields mapstr.M, key string, value string) {
+	if value == "" {
+		return
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return
+	}
+	_, _ = fields.Put(key, t.UTC())
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
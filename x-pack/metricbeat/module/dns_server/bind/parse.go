@@ -0,0 +1,194 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package bind
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strconv"
+)
+
+// statistics are the statistics of BIND, from the JSON or the XML format of
+// its statistics channel.
+type statistics struct {
+	version    string
+	bootTime   string
+	configTime string
+	opcodes    map[string]int64
+	rcodes     map[string]int64
+	qtypes     map[string]int64
+	nsstats    map[string]int64
+	zonestats  map[string]int64
+	views      []view
+}
+
+type view struct {
+	name       string
+	cachestats map[string]int64
+	zones      []zone
+}
+
+type zone struct {
+	name    string
+	class   string
+	kind    string
+	serial  string
+	loaded  string
+	expires string
+	refresh string
+}
+
+// parseStatistics parses the statistics of BIND. The format is detected
+// from the content, XML is used when BIND is built without JSON support.
+func parseStatistics(content []byte) (*statistics, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte("<")) {
+		return parseXML(content)
+	}
+	return parseJSON(content)
+}
+
+type jsonStatistics struct {
+	Version    string           `json:"version"`
+	BootTime   string           `json:"boot-time"`
+	ConfigTime string           `json:"config-time"`
+	Opcodes    map[string]int64 `json:"opcodes"`
+	Rcodes     map[string]int64 `json:"rcodes"`
+	QTypes     map[string]int64 `json:"qtypes"`
+	NSStats    map[string]int64 `json:"nsstats"`
+	ZoneStats  map[string]int64 `json:"zonestats"`
+	Views      map[string]struct {
+		Zones []struct {
+			Name    string      `json:"name"`
+			Class   string      `json:"class"`
+			Serial  json.Number `json:"serial"`
+			Type    string      `json:"type"`
+			Loaded  string      `json:"loaded"`
+			Expires string      `json:"expires"`
+			Refresh string      `json:"refresh"`
+		} `json:"zones"`
+		Resolver struct {
+			CacheStats map[string]int64 `json:"cachestats"`
+		} `json:"resolver"`
+	} `json:"views"`
+}
+
+func parseJSON(content []byte) (*statistics, error) {
+	var s jsonStatistics
+	if err := json.Unmarshal(content, &s); err != nil {
+		return nil, err
+	}
+
+	stats := &statistics{
+		version:    s.Version,
+		bootTime:   s.BootTime,
+		configTime: s.ConfigTime,
+		opcodes:    s.Opcodes,
+		rcodes:     s.Rcodes,
+		qtypes:     s.QTypes,
+		nsstats:    s.NSStats,
+		zonestats:  s.ZoneStats,
+	}
+	for _, name := range sortedKeys(s.Views) {
+		v := s.Views[name]
+		parsed := view{name: name, cachestats: v.Resolver.CacheStats}
+		for _, z := range v.Zones {
+			parsed.zones = append(parsed.zones, zone{
+				name:    z.Name,
+				class:   z.Class,
+				kind:    z.Type,
+				serial:  z.Serial.String(),
+				loaded:  z.Loaded,
+				expires: z.Expires,
+				refresh: z.Refresh,
+			})
+		}
+		stats.views = append(stats.views, parsed)
+	}
+	return stats, nil
+}
+
+type xmlStatistics struct {
+	Server struct {
+		BootTime   string        `xml:"boot-time"`
+		ConfigTime string        `xml:"config-time"`
+		Version    string        `xml:"version"`
+		Counters   []xmlCounters `xml:"counters"`
+	} `xml:"server"`
+	Views []struct {
+		Name  string `xml:"name,attr"`
+		Zones []struct {
+			Name    string `xml:"name,attr"`
+			Class   string `xml:"rdataclass,attr"`
+			Type    string `xml:"type"`
+			Serial  string `xml:"serial"`
+			Loaded  string `xml:"loaded"`
+			Expires string `xml:"expires"`
+			Refresh string `xml:"refresh"`
+		} `xml:"zones>zone"`
+		Counters []xmlCounters `xml:"counters"`
+	} `xml:"views>view"`
+}
+
+type xmlCounters struct {
+	Type     string `xml:"type,attr"`
+	Counters []struct {
+		Name  string `xml:"name,attr"`
+		Value int64  `xml:",chardata"`
+	} `xml:"counter"`
+}
+
+// counters returns the counters of the given type.
+func counters(list []xmlCounters, kind string) map[string]int64 {
+	values := map[string]int64{}
+	for _, c := range list {
+		if c.Type != kind {
+			continue
+		}
+		for _, counter := range c.Counters {
+			values[counter.Name] = counter.Value
+		}
+	}
+	return values
+}
+
+func parseXML(content []byte) (*statistics, error) {
+	var s xmlStatistics
+	if err := xml.Unmarshal(content, &s); err != nil {
+		return nil, err
+	}
+
+	stats := &statistics{
+		version:    s.Server.Version,
+		bootTime:   s.Server.BootTime,
+		configTime: s.Server.ConfigTime,
+		opcodes:    counters(s.Server.Counters, "opcode"),
+		rcodes:     counters(s.Server.Counters, "rcode"),
+		qtypes:     counters(s.Server.Counters, "qtype"),
+		nsstats:    counters(s.Server.Counters, "nsstat"),
+		zonestats:  counters(s.Server.Counters, "zonestat"),
+	}
+	for _, v := range s.Views {
+		parsed := view{name: v.Name, cachestats: counters(v.Counters, "cachestats")}
+		for _, z := range v.Zones {
+			parsed.zones = append(parsed.zones, zone{
+				name:    z.Name,
+				class:   z.Class,
+				kind:    z.Type,
+				serial:  z.Serial,
+				loaded:  z.Loaded,
+				expires: z.Expires,
+				refresh: z.Refresh,
+			})
+		}
+		stats.views = append(stats.views, parsed)
+	}
+	return stats, nil
+}
+
+func parseInt(s string) (int64, bool) {
+	value, err := strconv.ParseInt(s, 10, 64)
+	return value, err == nil
+}
@@ -0,0 +1,70 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package dns_server
+
+import (
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// QueryCounters are the counters of the queries received by a DNS server at
+// a given time, used to calculate the rates of queries between fetches.
+type QueryCounters struct {
+	Time   time.Time
+	Total  int64
+	ByType map[string]int64
+}
+
+// QueriesFields maps the counters of queries. The rates of queries are
+// calculated since the previous counters, they are not reported on the first
+// fetch or after a restart of the server.
+func QueriesFields(current, previous *QueryCounters) mapstr.M {
+	fields := mapstr.M{
+		"total": current.Total,
+	}
+	if len(current.ByType) > 0 {
+		types := mapstr.M{}
+		for qtype, count := range current.ByType {
+			types[qtype] = count
+		}
+		fields["types"] = types
+	}
+
+	if previous == nil {
+		return fields
+	}
+	elapsed := current.Time.Sub(previous.Time).Seconds()
+	if elapsed <= 0 || current.Total < previous.Total {
+		return fields
+	}
+	fields["rate"] = float64(current.Total-previous.Total) / elapsed
+
+	rates := mapstr.M{}
+	for qtype, count := range current.ByType {
+		prev := previous.ByType[qtype]
+		if count < prev {
+			continue
+		}
+		rates[qtype] = float64(count-prev) / elapsed
+	}
+	if len(rates) > 0 {
+		fields["rates"] = rates
+	}
+	return fields
+}
+
+// CacheFields maps the hits and misses of the cache of a DNS server, and its
+// hit ratio.
+func CacheFields(hits, misses int64) mapstr.M {
+	fields := mapstr.M{
+		"hits":   hits,
+		"misses": misses,
+	}
+	if hits+misses > 0 {
+		fields["hit_ratio"] = float64(hits) / float64(hits+misses)
+	}
+	return fields
+}
@@ -0,0 +1,23 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Code generated by beats/dev-tools/cmd/asset/asset.go - DO NOT EDIT.
+
+package dns_server
+
+import (
+	"github.com/elastic/beats/v7/libbeat/asset"
+)
+
+func init() {
+	if err := asset.SetFields("metricbeat", "dns_server", asset.ModuleFieldsPri, AssetDnsServer); err != nil {
+		panic(err)
+	}
+}
+
+// AssetDnsServer returns asset data.
+// This is the base64 encoded zlib format compressed contents of module/dns_server.
+func AssetDnsServer() string {
+	return "eJzsmVGP2kYQx9/5FKN7rMh9AB4q0VwqndqQK0RR1aoiy+4Yb7F3yc4YQj59tTZrDNix4YBeq5P8cl7f7O8/Mx52xm9ggZsBKENTQrdC1wNgzQkO4O5hNIHi5l0PQCFJp5esrRnAjz0AgN0DkFqVJdgDcJigIBzADFn0AAiZtZnTAP68I0ru+nAXMy/v/uoBRBoTRYPc1hswIsUDEr/AmyUOYO5sttzeqSHx1+fdv34GaQ0LbQg4RkiRnZYE0iYJSkYFkbNpvkQsWBP7VRtVBNH91m6Vsco500aVN+sov0Pqr8nexgJ+ehw9BGcKo0AzwTdrkPqH2H6lgi1jYQwmgRfgOAYA9Vqqerxmbc3eWpC1wM3auqrcFnH++lQYBBvl2nZ8tdvPrOUp6xRrAZRgPG33jzpFWMdoiigXfl2L3HOOUbXgSGsiPb8eUGE/c4K3LjqgTAQxJFaoVtIvGTqNVEt5mI4dMH8rzIFDiXqFCmabCtuOpTmhqnBsWSRHqwEvsWZes9hC6K9Rls7Qeb996cjbjLhZIt3/0AhpZ3+j5JrlYmF6Kyn+L//EJufqQ6IXCEOwDobD4bCDUHecsjuVUWIFnycgZMwSHRBKa1STAtJGYn5j6XClbUYQIcu4I/zzo3QTlXtxOlVzqRdpaQ0h3TtpVU2Cfkd2W2K2qN0lZAkBhIaPZYZ18IjbjBx9eDcefxj7vBz9/vDh/fBx1CJVChnjpcrXW28s1FOHZJMVuj5oAyJJ8jCsNK5pv+KeWtVizYf1dgd9yUogDK3RVY8rubMaHFpFTDUR3gaSY8EgbZYoMJZhhs/AjjVP89/ERnKSIkE1bXqTI+tSwQP/mko0fJ7GsScIKXJeLIIgf4KbshOGInR0qSz/wxqE0urzktlmPLfazO+lTZcJMqpG318ia77to7MFy3FeUz38Npt89kDJ0+DiWhEOfe27pQZw+CVDYioSo0lNAOsgRhtpUx8RyqREVLeNSJneS6dT4Tb7WkqkU3REQicvSUTB06Ag0PtE7dXhnvu+Pust9UyNDqxvz7r6UKQlm9fc4JYqjP8JvQ7MJ43rE2FkIoiuQ/PWmz4Rx297HZqP/jhZgdkeuEKG23AuFW7TgZPQ6au0ZpPc8IleKzrdRpqabrur0/a6bk/Tsb2u0uHXpXZIV8UTu+jl0Q2b9kFHoBk05acrh5FDisMxxI+CDkpcBz1bI9fSsw29wa8lr0+HI4XtCgLw0q7RKUO9tlp8wrztydv0875hxrF12i+vECY5g29fxigzR7bLNG749Pjc8ZsSmFozrSkf3ysdLSGplowiPfog9uRa55v1XOdOQS3gNeeDIRYtCK+jrv/LqKttsvA66PrXBl3jMjQVnW1Reh1z3XbM9STkArmwGQr8fmEP5b4o8Nap/YePi/4LGX35DQLkCxp2FVucCvYCx1ld/RskJILRyM19Rr2Ovm0hGq7QiTkGw4GlGK/R4WlFG0i1dLaoQ5WjYS2sT3rf3F8IdRf+ybvxp5+Hj7+WmMeV4nWccO44YbH/Jf2CML9oo6owoXjnJbIP7wWxj6+DSSJW+J/rmo1lHWlU0xtilZsC220ChqauewOKSvONsUXkQ+2LX/5p3RNoazqwFu31cdtxoRR92u996xrlDpBe01TGKBeNlJcYUmwLzta3Rw19OVnJSVCBNWdOKJQhQtmoZWZtgsKcKcdlCLqSGpqA9NyggrXmGB5Gk8m7t/e9fwYAERFjFg=="
+}
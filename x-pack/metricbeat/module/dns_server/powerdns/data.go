@@ -0,0 +1,159 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package powerdns
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/dns_server"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const recursor = "recursor"
+
+// server is the description of the server, as returned by the API.
+type server struct {
+	DaemonType string `json:"daemon_type"`
+	Version    string `json:"version"`
+}
+
+// statistic is an item of the statistics of the server. The value of the
+// statistics is a number as a string, or a list of items for the
+// statistics by name.
+type statistic struct {
+	Name  string          `json:"name"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// zone is a zone of the server, as returned by the API.
+type zone struct {
+	Name           string   `json:"name"`
+	Kind           string   `json:"kind"`
+	Serial         int64    `json:"serial"`
+	NotifiedSerial int64    `json:"notified_serial"`
+	EditedSerial   int64    `json:"edited_serial"`
+	Masters        []string `json:"masters"`
+	LastCheck      int64    `json:"last_check"`
+	DNSSEC         bool     `json:"dnssec"`
+}
+
+// names of the statistics of the authoritative server and of the recursor.
+type names struct {
+	queries     []string
+	cacheHits   string
+	cacheMisses string
+	latency     string
+	servfail    string
+}
+
+var (
+	authoritativeNames = names{
+		queries:     []string{"udp-queries", "tcp-queries"},
+		cacheHits:   "packetcache-hit",
+		cacheMisses: "packetcache-miss",
+		latency:     "latency",
+		servfail:    "servfail-packets",
+	}
+	recursorNames = names{
+		queries:     []string{"questions"},
+		cacheHits:   "cache-hits",
+		cacheMisses: "cache-misses",
+		latency:     "qa-latency",
+		servfail:    "servfail-answers",
+	}
+)
+
+func eventsMapping(info server, stats []statistic, zones []zone, previous *dns_server.QueryCounters, now time.Time) ([]mb.Event, *dns_server.QueryCounters) {
+	values := map[string]int64{}
+	maps := map[string]map[string]int64{}
+	for _, s := range stats {
+		switch s.Type {
+		case "StatisticItem":
+			var value string
+			if err := json.Unmarshal(s.Value, &value); err != nil {
+				continue
+			}
+			if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+				values[s.Name] = v
+			}
+		case "MapStatisticItem":
+			var items []struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			}
+			if err := json.Unmarshal(s.Value, &items); err != nil {
+				continue
+			}
+			m := map[string]int64{}
+			for _, item := range items {
+				if v, err := strconv.ParseInt(item.Value, 10, 64); err == nil {
+					m[item.Name] = v
+				}
+			}
+			maps[s.Name] = m
+		}
+	}
+
+	n := authoritativeNames
+	if info.DaemonType == recursor {
+		n = recursorNames
+	}
+
+	current := &dns_server.QueryCounters{
+		Time:   now,
+		ByType: maps["response-by-qtype"],
+	}
+	for _, name := range n.queries {
+		current.Total += values[name]
+	}
+
+	fields := mapstr.M{
+		"daemon_type": info.DaemonType,
+		"version":     info.Version,
+		"queries":     dns_server.QueriesFields(current, previous),
+		"cache":       dns_server.CacheFields(values[n.cacheHits], values[n.cacheMisses]),
+		"servfail":    values[n.servfail],
+	}
+	if latency, found := values[n.latency]; found {
+		_, _ = fields.Put("latency.us", latency)
+	}
+	if rcodes := maps["response-by-rcode"]; len(rcodes) > 0 {
+		m := mapstr.M{}
+		for rcode, count := range rcodes {
+			m[rcode] = count
+		}
+		_, _ = fields.Put("responses.rcodes", m)
+	}
+
+	events := []mb.Event{{MetricSetFields: fields}}
+	for _, z := range zones {
+		events = append(events, mb.Event{MetricSetFields: mapstr.M{"zone": zoneFields(z)}})
+	}
+	return events, current
+}
+
+// zoneFields maps a zone. The last check of the serial of the zone on its
+// primary servers is only reported for secondary zones.
+func zoneFields(z zone) mapstr.M {
+	fields := mapstr.M{
+		"name":            z.Name,
+		"kind":            z.Kind,
+		"serial":          z.Serial,
+		"notified_serial": z.NotifiedSerial,
+		"edited_serial":   z.EditedSerial,
+		"dnssec":          z.DNSSEC,
+	}
+	if len(z.Masters) > 0 {
+		fields["primaries"] = z.Masters
+	}
+	if z.LastCheck > 0 {
+		fields["last_check"] = time.Unix(z.LastCheck, 0).UTC()
+	}
+	return fields
+}
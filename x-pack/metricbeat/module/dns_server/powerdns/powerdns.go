@@ -0,0 +1,120 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package powerdns
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/dns_server"
+)
+
+const (
+	defaultScheme = "http"
+	defaultPath   = "/api/v1/servers"
+)
+
+var (
+	hostParser = parse.URLHostParserBuilder{
+		DefaultScheme: defaultScheme,
+		DefaultPath:   defaultPath,
+	}.Build()
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("dns_server", "powerdns", New,
+		mb.WithHostParser(hostParser),
+	)
+}
+
+type config struct {
+	PowerDNS struct {
+		APIKey   string `config:"api_key"`
+		ServerID string `config:"server_id"`
+	} `config:"powerdns"`
+}
+
+func defaultConfig() config {
+	var c config
+	c.PowerDNS.ServerID = "localhost"
+	return c
+}
+
+// MetricSet collects the statistics and the zones of a PowerDNS
+// Authoritative Server or Recursor from its API.
+type MetricSet struct {
+	mb.BaseMetricSet
+	http      *helper.HTTP
+	serverURL string
+
+	// previous are the counters of queries of the previous fetch, used to
+	// calculate the rates of queries.
+	previous *dns_server.QueryCounters
+}
+
+// New creates a new instance of the powerdns MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	http, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+	if config.PowerDNS.APIKey != "" {
+		http.SetHeader("X-API-Key", config.PowerDNS.APIKey)
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		http:          http,
+		serverURL:     strings.TrimSuffix(base.HostData().SanitizedURI, "/") + "/" + config.PowerDNS.ServerID,
+	}, nil
+}
+
+// Fetch reports an event with the statistics of the server, followed by an
+// event for every zone.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	var info server
+	if err := m.fetch("", &info); err != nil {
+		return err
+	}
+	var stats []statistic
+	if err := m.fetch("/statistics", &stats); err != nil {
+		return err
+	}
+	var zones []zone
+	if err := m.fetch("/zones", &zones); err != nil {
+		return err
+	}
+
+	events, current := eventsMapping(info, stats, zones, m.previous, time.Now())
+	m.previous = current
+	for _, event := range events {
+		if !reporter.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *MetricSet) fetch(path string, v interface{}) error {
+	m.http.SetURI(m.serverURL + path)
+	content, err := m.http.FetchContent()
+	if err != nil {
+		return fmt.Errorf("error fetching %s: %w", m.http.GetURI(), err)
+	}
+	if err := json.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("error decoding response of %s: %w", m.http.GetURI(), err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package powerdns
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetch(t *testing.T) {
+	files := map[string]string{
+		"/api/v1/servers/localhost":            "server.json",
+		"/api/v1/servers/localhost/statistics": "statistics.json",
+		"/api/v1/servers/localhost/zones":      "zones.json",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		file, found := files[r.URL.Path]
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		http.ServeFile(w, r, filepath.Join("_meta", "test", file))
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL, "secret"))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, mapstr.M{
+		"daemon_type": "authoritative",
+		"version":     "4.8.4",
+		"queries": mapstr.M{
+			"total": int64(1000),
+			"types": mapstr.M{
+				"A":    int64(700),
+				"AAAA": int64(250),
+				"SOA":  int64(50),
+			},
+		},
+		"responses": mapstr.M{
+			"rcodes": mapstr.M{
+				"NOERROR":  int64(940),
+				"NXDOMAIN": int64(57),
+				"SERVFAIL": int64(3),
+			},
+		},
+		"cache": mapstr.M{
+			"hits":      int64(600),
+			"misses":    int64(200),
+			"hit_ratio": 0.75,
+		},
+		"latency":  mapstr.M{"us": int64(112)},
+		"servfail": int64(3),
+	}, events[0].MetricSetFields)
+
+	assert.Equal(t, mapstr.M{
+		"zone": mapstr.M{
+			"name":            "example.com.",
+			"kind":            "Master",
+			"serial":          int64(2024040502),
+			"notified_serial": int64(2024040502),
+			"edited_serial":   int64(2024040502),
+			"dnssec":          true,
+		},
+	}, events[1].MetricSetFields)
+
+	assert.Equal(t, mapstr.M{
+		"zone": mapstr.M{
+			"name":            "example.org.",
+			"kind":            "Slave",
+			"serial":          int64(2024040317),
+			"notified_serial": int64(0),
+			"edited_serial":   int64(2024040317),
+			"dnssec":          false,
+			"primaries":       []string{"192.0.2.53"},
+			"last_check":      time.Date(2024, 4, 5, 11, 30, 0, 0, time.UTC),
+		},
+	}, events[2].MetricSetFields)
+}
+
+func TestFetchUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL, "wrong"))
+	_, errs := mbtest.ReportingFetchV2Error(f)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "/api/v1/servers/localhost")
+}
+
+func TestRecursor(t *testing.T) {
+	var stats []statistic
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"name": "questions", "type": "StatisticItem", "value": "400"},
+		{"name": "cache-hits", "type": "StatisticItem", "value": "100"},
+		{"name": "cache-misses", "type": "StatisticItem", "value": "300"},
+		{"name": "qa-latency", "type": "StatisticItem", "value": "2500"},
+		{"name": "servfail-answers", "type": "StatisticItem", "value": "7"},
+		{"name": "udp-queries", "type": "StatisticItem", "value": "12345"}
+	]`), &stats))
+
+	now := time.Now()
+	info := server{DaemonType: "recursor", Version: "5.0.3"}
+	_, previous := eventsMapping(info, stats, nil, nil, now.Add(-10*time.Second))
+
+	stats[0].Value = json.RawMessage(`"500"`)
+	events, _ := eventsMapping(info, stats, nil, previous, now)
+	require.Len(t, events, 1)
+
+	fields := events[0].MetricSetFields
+	assert.Equal(t, mapstr.M{"total": int64(500), "rate": 10.0}, fields["queries"])
+	assert.Equal(t, mapstr.M{"hits": int64(100), "misses": int64(300), "hit_ratio": 0.25}, fields["cache"])
+	assert.Equal(t, mapstr.M{"us": int64(2500)}, fields["latency"])
+	assert.Equal(t, int64(7), fields["servfail"])
+}
+
+func getConfig(host, apiKey string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":           "dns_server",
+		"metricsets":       []string{"powerdns"},
+		"hosts":            []string{host},
+		"powerdns.api_key": apiKey,
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Code generated by beats/dev-tools/cmd/asset/asset.go - DO NOT EDIT.
+
+package postfix
+
+import (
+	"github.com/elastic/beats/v7/libbeat/asset"
+)
+
+func init() {
+	if err := asset.SetFields("metricbeat", "postfix", asset.ModuleFieldsPri, AssetPostfix); err != nil {
+		panic(err)
+	}
+}
+
+// AssetPostfix returns asset data.
+// This is the base64 encoded zlib format compressed contents of module/postfix.
+func AssetPostfix() string {
+	return "eJzElsGO2zYQhu96ikHOjh7AhwJBek0QtLknNPlLZpYitcORverTF6QlW7uWbTVro1hhAYkm+f3DmX/4kZ7Qr6kNUSr7UhCJFYc1ffh2+PKhIDKImm0rNvg1/VEQEQ2j1ATTORREDAcVsaYNRBVElYUzcZ1//JG8ajDdJP1J32JNNYeuHb7M7JOen8O8n6SDF2V9JNmCGghbHUkH56AFhioOTR567tAhkvImv7pQU6hG5nJYeEo4pcyTj1/nOK+wpucLYlQ1IllP6rDcZPvVHHDchv0zRfDOaoyAROdhJZqHnwpI/18NjBqe0O8DmzdjV5Sk56tqMv4xrity9gmktNgdVmRQgRmGAtM2OHOin4VrhuCUOnReZjFd8PV/ZOyaDThRjsun2B+JFyNtla9xJ6bPebExcv46IUXrNfJ7y9jZ0EWqIHp7A52hbWvhJd6J+uuR87T0qOF3YhvtPyg3vWAxYBW4UbKmuUk34L8HUS5v+R5kVaNs1EsZoZcy3+D6dMqD4AyijFyvsFbpLUIHb2JZvKWKjbTvMaU/4ewObHF+nMkmdfAeOuVyJAl5/O8v379lSwJfsy8rMRvs7Qz+PTczR/DZ03gbggXHcSUUmz5LH/bsSdVjAQzyp2oukU/pJaXk2eiVVFrA/7pOT6wiaFqZ5M5FqggvD4WKndaIserc5PwWgI3N5KFwJyKSrRKqlHUwlKIXWLF1/SpfHfbWOdqAOF00YBbgb0Ln9f9D34Ib5eHF9QtA8dLaR4f5WFQM6dinCGdjsUwR3oBpA626mE2jpz34tR9SFZgkhBy1BZrSMXaMH6zEhovKolYO5kflgporgbH7tGA9XyQLYvBXIhi95aw+D0mX5U7vTkPuLBBq4FRfql0906IWC10g49MOrGqQ2AbJsffAoV0pZrtT7sw9h/u25XPR882tmJOXOp25l9d/XtrXyuJNItww9knDvHgE96iiaWMO1QFeu3zluxDHKSXjF7Q8lpDx3CHKGd4kzxm/oAWmLP4dAPV90dM="
+}
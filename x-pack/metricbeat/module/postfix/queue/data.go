@@ -0,0 +1,62 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package queue
+
+import (
+	"sort"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func eventsMapping(queues map[string][]message, previous map[string]int64, now time.Time) ([]mb.Event, map[string]int64) {
+	names := make([]string, 0, len(queues))
+	for name := range queues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	current := make(map[string]int64, len(queues))
+	events := make([]mb.Event, 0, len(queues))
+	for _, name := range names {
+		messages := queues[name]
+		count := int64(len(messages))
+		current[name] = count
+
+		var size, recipients int64
+		var oldest time.Time
+		for _, m := range messages {
+			size += m.size
+			recipients += m.recipients
+			if oldest.IsZero() || m.arrival.Before(oldest) {
+				oldest = m.arrival
+			}
+		}
+
+		fields := mapstr.M{
+			"name": name,
+			"messages": mapstr.M{
+				"count": count,
+			},
+			"recipients": recipients,
+			"size": mapstr.M{
+				"bytes": size,
+			},
+		}
+		if prev, found := previous[name]; found {
+			_, _ = fields.Put("messages.change", count-prev)
+		}
+		if !oldest.IsZero() {
+			age := now.Sub(oldest)
+			if age < 0 {
+				age = 0
+			}
+			_, _ = fields.Put("age.max.sec", int64(age.Seconds()))
+		}
+		events = append(events, mb.Event{MetricSetFields: fields})
+	}
+	return events, current
+}
@@ -0,0 +1,87 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package queue
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+)
+
+var hostParser = parse.URLHostParserBuilder{DefaultScheme: "unix"}.Build()
+
+func init() {
+	mb.Registry.MustAddMetricSet("postfix", "queue", New,
+		mb.WithHostParser(hostParser),
+		mb.DefaultMetricSet(),
+	)
+}
+
+// MetricSet collects the messages in the queues of Postfix from its showq
+// service.
+type MetricSet struct {
+	mb.BaseMetricSet
+
+	// previous are the numbers of messages by queue of the previous fetch,
+	// used to calculate the change of the size of the queues.
+	previous map[string]int64
+}
+
+// New creates a new instance of the queue MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	return &MetricSet{BaseMetricSet: base}, nil
+}
+
+// Fetch reports an event for every queue of Postfix.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	content, err := m.showq()
+	if err != nil {
+		return fmt.Errorf("error fetching the queues from %s: %w", m.HostData().SanitizedURI, err)
+	}
+
+	now := time.Now()
+	queues, err := parseShowq(content, now)
+	if err != nil {
+		return fmt.Errorf("error parsing the queues: %w", err)
+	}
+
+	events, current := eventsMapping(queues, m.previous, now)
+	m.previous = current
+	for _, event := range events {
+		if !reporter.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// showq reads the content of the queues from the showq service, that writes
+// them and closes the connection.
+func (m *MetricSet) showq() ([]byte, error) {
+	u, err := url.Parse(m.HostData().URI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+
+	// The host is the path of the socket for unix sockets.
+	timeout := m.Module().Config().Timeout
+	conn, err := net.DialTimeout(u.Scheme, m.HostData().Host, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if timeout > 0 {
+		if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, err
+		}
+	}
+	return io.ReadAll(conn)
+}
@@ -0,0 +1,157 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package queue
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestParseShowq(t *testing.T) {
+	now := time.Date(2024, 4, 5, 11, 0, 0, 0, time.UTC)
+
+	for _, file := range []string{"showq", "mailq"} {
+		t.Run(file, func(t *testing.T) {
+			content, err := os.ReadFile(filepath.Join("_meta", "test", file))
+			require.NoError(t, err)
+
+			queues, err := parseShowq(content, now)
+			require.NoError(t, err)
+
+			events, current := eventsMapping(queues, nil, now)
+			fields := map[string]mapstr.M{}
+			for _, event := range events {
+				fields[event.MetricSetFields["name"].(string)] = event.MetricSetFields
+			}
+
+			assert.Equal(t, mapstr.M{
+				"name":       "active",
+				"messages":   mapstr.M{"count": int64(1)},
+				"recipients": int64(2),
+				"size":       mapstr.M{"bytes": int64(1024)},
+				"age":        mapstr.M{"max": mapstr.M{"sec": int64(3600)}},
+			}, fields["active"])
+			assert.Equal(t, mapstr.M{
+				"name":       "deferred",
+				"messages":   mapstr.M{"count": int64(2)},
+				"recipients": int64(2),
+				"size":       mapstr.M{"bytes": int64(6144)},
+				"age":        mapstr.M{"max": mapstr.M{"sec": int64(90000)}},
+			}, fields["deferred"])
+			assert.Equal(t, mapstr.M{
+				"name":       "hold",
+				"messages":   mapstr.M{"count": int64(1)},
+				"recipients": int64(1),
+				"size":       mapstr.M{"bytes": int64(512)},
+				"age":        mapstr.M{"max": mapstr.M{"sec": int64(1800)}},
+			}, fields["hold"])
+
+			if file == "showq" {
+				require.Len(t, events, 5)
+				assert.Equal(t, mapstr.M{
+					"name":       "maildrop",
+					"messages":   mapstr.M{"count": int64(0)},
+					"recipients": int64(0),
+					"size":       mapstr.M{"bytes": int64(0)},
+				}, fields["maildrop"])
+			} else {
+				require.Len(t, events, 3)
+			}
+
+			assert.Equal(t, int64(2), current["deferred"])
+		})
+	}
+}
+
+func TestParseShowqEmpty(t *testing.T) {
+	for _, content := range []string{"", "Mail queue is empty\n"} {
+		queues, err := parseShowq([]byte(content), time.Now())
+		require.NoError(t, err)
+		require.NotEmpty(t, queues)
+		for name, messages := range queues {
+			assert.Empty(t, messages, name)
+		}
+	}
+}
+
+func TestParseTextArrivalYear(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)
+	m, err := parseTextMessage("3E5A81234      1024 Sun Dec 31 23:30:00  sender@example.com", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 12, 31, 23, 30, 0, 0, time.UTC), m.arrival)
+}
+
+func TestFetch(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("_meta", "test", "showq"))
+	require.NoError(t, err)
+
+	for _, network := range []string{"unix", "tcp"} {
+		t.Run(network, func(t *testing.T) {
+			address := "127.0.0.1:0"
+			if network == "unix" {
+				address = filepath.Join(t.TempDir(), "showq")
+			}
+			listener := newServer(t, network, address, content)
+
+			f := mbtest.NewReportingMetricSetV2Error(t, getConfig(network+"://"+listener.Addr().String()))
+			events, errs := mbtest.ReportingFetchV2Error(f)
+			require.Empty(t, errs)
+			require.Len(t, events, 5)
+			assert.Equal(t, "active", events[0].MetricSetFields["name"])
+			assert.Equal(t, mapstr.M{"count": int64(1)}, events[0].MetricSetFields["messages"])
+
+			events, errs = mbtest.ReportingFetchV2Error(f)
+			require.Empty(t, errs)
+			require.Len(t, events, 5)
+			assert.Equal(t, mapstr.M{"count": int64(1), "change": int64(0)}, events[0].MetricSetFields["messages"])
+		})
+	}
+}
+
+func TestFetchError(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig("unix://"+filepath.Join(t.TempDir(), "showq")))
+	_, errs := mbtest.ReportingFetchV2Error(f)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "error fetching the queues")
+}
+
+// newServer starts a server that writes the content to every connection and
+// closes it, like showq.
+func newServer(t *testing.T, network, address string, content []byte) net.Listener {
+	listener, err := net.Listen(network, address)
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = conn.Write(content)
+			conn.Close()
+		}
+	}()
+	return listener
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "postfix",
+		"metricsets": []string{"queue"},
+		"hosts":      []string{host},
+	}
+}
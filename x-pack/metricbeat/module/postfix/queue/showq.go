@@ -0,0 +1,173 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package queue
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// attributeQueues are the queues reported by showq with the attribute
+	// protocol of Postfix 3.1 and newer.
+	attributeQueues = []string{"maildrop", "incoming", "active", "deferred", "hold"}
+
+	// textQueues are the queues reported by showq in the format of mailq,
+	// by older versions of Postfix.
+	textQueues = []string{"active", "deferred", "hold"}
+)
+
+// message is a message in a queue of Postfix.
+type message struct {
+	queue      string
+	size       int64
+	arrival    time.Time
+	recipients int64
+}
+
+// parseShowq parses the messages of the queues written by showq, in the
+// attribute protocol or in the format of mailq. All the queues of the format
+// are returned, even when they are empty.
+func parseShowq(content []byte, now time.Time) (map[string][]message, error) {
+	if bytes.HasPrefix(content, []byte("-Queue ID-")) || bytes.HasPrefix(content, []byte("Mail queue is empty")) {
+		return parseText(content, now)
+	}
+	return parseAttributes(content)
+}
+
+// parseAttributes parses messages written as attributes, with a null byte
+// after each name and value, and an empty name after each message.
+func parseAttributes(content []byte) (map[string][]message, error) {
+	queues := newQueues(attributeQueues)
+	add := func(m message) {
+		if m.queue != "" {
+			queues[m.queue] = append(queues[m.queue], m)
+		}
+	}
+
+	var m message
+	fields := bytes.Split(content, []byte{0})
+	for i := 0; i < len(fields); i++ {
+		name := string(fields[i])
+		if name == "" {
+			add(m)
+			m = message{}
+			continue
+		}
+
+		i++
+		if i == len(fields) {
+			return nil, fmt.Errorf("attribute %q without value", name)
+		}
+		value := string(fields[i])
+
+		switch name {
+		case "queue_name":
+			m.queue = value
+		case "size":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid size of message: %w", err)
+			}
+			m.size = size
+		case "time":
+			arrival, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid arrival time of message: %w", err)
+			}
+			m.arrival = time.Unix(arrival, 0)
+		case "recipient":
+			m.recipients++
+		}
+	}
+	add(m)
+	return queues, nil
+}
+
+// parseText parses messages in the format of mailq. The first line of a
+// message contains its ID, its size, its arrival time and its sender, it is
+// followed by the reasons of the delays between parentheses and by the
+// recipients. The ID of the messages in the active queue ends with `*`, and
+// with `!` in the hold queue.
+func parseText(content []byte, now time.Time) (map[string][]message, error) {
+	queues := newQueues(textQueues)
+
+	var m *message
+	add := func() {
+		if m != nil {
+			queues[m.queue] = append(queues[m.queue], *m)
+		}
+		m = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.TrimSpace(line) == "":
+			add()
+		case strings.HasPrefix(line, "-") || strings.HasPrefix(line, "Mail queue is empty"):
+			// Header and summary.
+		case m == nil:
+			parsed, err := parseTextMessage(line, now)
+			if err != nil {
+				return nil, err
+			}
+			m = parsed
+		case strings.HasPrefix(strings.TrimSpace(line), "("):
+			// Reason of the delay of the message.
+		default:
+			m.recipients++
+		}
+	}
+	add()
+	return queues, scanner.Err()
+}
+
+func parseTextMessage(line string, now time.Time) (*message, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 6 {
+		return nil, fmt.Errorf("invalid message %q", line)
+	}
+
+	m := &message{queue: "deferred"}
+	switch fields[0][len(fields[0])-1] {
+	case '*':
+		m.queue = "active"
+	case '!':
+		m.queue = "hold"
+	}
+
+	size, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid size of message %q: %w", line, err)
+	}
+	m.size = size
+
+	// The arrival time doesn't contain the year, it is the last time with
+	// this date before now.
+	arrival, err := time.ParseInLocation("Mon Jan 2 15:04:05", strings.Join(fields[2:6], " "), now.Location())
+	if err != nil {
+		return nil, fmt.Errorf("invalid arrival time of message %q: %w", line, err)
+	}
+	arrival = arrival.AddDate(now.Year()-arrival.Year(), 0, 0)
+	if arrival.After(now) {
+		arrival = arrival.AddDate(-1, 0, 0)
+	}
+	m.arrival = arrival
+	return m, nil
+}
+
+func newQueues(names []string) map[string][]message {
+	queues := make(map[string][]message, len(names))
+	for _, name := range names {
+		queues[name] = nil
+	}
+	return queues
+}
@@ -0,0 +1,63 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package smtp
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// logReader reads the lines added to a log file since the previous read. It
+// starts at the end of the file, and starts again at the beginning of the
+// file when it is rotated or truncated.
+type logReader struct {
+	path   string
+	info   os.FileInfo
+	offset int64
+}
+
+// read calls fn with every complete line added to the file since the
+// previous read. It returns false on the first read, that only finds the end
+// of the file. An incomplete last line is read on the next read.
+func (r *logReader) read(fn func(line string)) (bool, error) {
+	f, err := os.Open(r.path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	if r.info == nil {
+		r.info, r.offset = info, info.Size()
+		return false, nil
+	}
+	if !os.SameFile(r.info, info) || info.Size() < r.offset {
+		r.offset = 0
+	}
+	r.info = info
+
+	if _, err := f.Seek(r.offset, io.SeekStart); err != nil {
+		return true, err
+	}
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadString('\n')
+		if errors.Is(err, io.EOF) {
+			return true, nil
+		}
+		if err != nil {
+			return true, err
+		}
+		r.offset += int64(len(line))
+		fn(strings.TrimRight(line, "\r\n"))
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package smtp
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var (
+	// lineRegexp matches the lines logged by the services of Postfix, like
+	// `postfix/smtp[1234]: ...` or `postfix/submission/smtpd[1234]: ...`,
+	// with the name of the service and the message.
+	lineRegexp = regexp.MustCompile(`\spostfix[^\s\[]*/(\w+)\[\d+\]: (.*)$`)
+
+	statusRegexp = regexp.MustCompile(`, status=(\w+)`)
+	delayRegexp  = regexp.MustCompile(`, delay=([\d.]+)`)
+)
+
+// counters are the deliveries of the messages and the connections to the
+// SMTP server found in the log.
+type counters struct {
+	sent     int64
+	deferred int64
+	bounced  int64
+	expired  int64
+
+	// delay is the sum of the delays of the deliveries, in seconds.
+	delay float64
+
+	connections int64
+	rejects     int64
+}
+
+// add counts a line of the log. The deliveries are logged by the delivery
+// agents, like smtp, lmtp or local, with their status, and the messages that
+// expired in the queue are logged by qmgr.
+func (c *counters) add(line string) {
+	match := lineRegexp.FindStringSubmatch(line)
+	if match == nil {
+		return
+	}
+	service, msg := match[1], match[2]
+
+	if service == "smtpd" {
+		switch {
+		case strings.HasPrefix(msg, "connect from "):
+			c.connections++
+		case strings.Contains(msg, "reject: "):
+			c.rejects++
+		}
+		return
+	}
+
+	status := statusRegexp.FindStringSubmatch(msg)
+	if status == nil {
+		return
+	}
+	switch status[1] {
+	case "sent":
+		c.sent++
+	case "deferred":
+		c.deferred++
+	case "bounced":
+		c.bounced++
+	case "expired":
+		c.expired++
+		return
+	default:
+		return
+	}
+
+	if delay := delayRegexp.FindStringSubmatch(msg); delay != nil {
+		if v, err := strconv.ParseFloat(delay[1], 64); err == nil {
+			c.delay += v
+		}
+	}
+}
+
+func (c *counters) fields() mapstr.M {
+	total := c.sent + c.deferred + c.bounced
+	deliveries := mapstr.M{
+		"total":    total,
+		"sent":     c.sent,
+		"deferred": c.deferred,
+		"bounced":  c.bounced,
+		"expired":  c.expired,
+	}
+	if total > 0 {
+		deliveries["failure_ratio"] = float64(c.deferred+c.bounced) / float64(total)
+		_, _ = deliveries.Put("delay.avg.sec", c.delay/float64(total))
+	}
+
+	return mapstr.M{
+		"deliveries": deliveries,
+		"smtpd": mapstr.M{
+			"connections": c.connections,
+			"rejects":     c.rejects,
+		},
+	}
+}
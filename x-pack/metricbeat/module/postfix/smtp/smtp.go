@@ -0,0 +1,64 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package smtp
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("postfix", "smtp", New)
+}
+
+type config struct {
+	SMTP struct {
+		LogPath string `config:"log_path" validate:"required"`
+	} `config:"smtp"`
+}
+
+func defaultConfig() config {
+	var c config
+	c.SMTP.LogPath = "/var/log/mail.log"
+	return c
+}
+
+// MetricSet collects the deliveries of the messages and the connections to
+// the SMTP server of Postfix from its log.
+type MetricSet struct {
+	mb.BaseMetricSet
+	log *logReader
+}
+
+// New creates a new instance of the smtp MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		log:           &logReader{path: config.SMTP.LogPath},
+	}, nil
+}
+
+// Fetch reports an event with the deliveries and the connections logged
+// since the previous fetch. Nothing is reported on the first fetch, that
+// only finds the end of the log.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	var c counters
+	started, err := m.log.read(c.add)
+	if err != nil {
+		return fmt.Errorf("error reading the log of Postfix: %w", err)
+	}
+	if !started {
+		return nil
+	}
+
+	reporter.Event(mb.Event{MetricSetFields: c.fields()})
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package smtp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetch(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("_meta", "test", "mail.log"))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "mail.log")
+	require.NoError(t, os.WriteFile(path, []byte("Apr  5 09:59:59 mail postfix/smtpd[2000]: connect from old.example.com[192.0.2.9]\n"), 0o600))
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(path))
+
+	// The first fetch only finds the end of the log.
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Empty(t, events)
+
+	appendFile(t, path, content)
+	events, errs = mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+	assert.Equal(t, mapstr.M{
+		"deliveries": mapstr.M{
+			"total":         int64(6),
+			"sent":          int64(4),
+			"deferred":      int64(1),
+			"bounced":       int64(1),
+			"expired":       int64(1),
+			"failure_ratio": 2.0 / 6,
+			"delay":         mapstr.M{"avg": mapstr.M{"sec": 35.02 / 6}},
+		},
+		"smtpd": mapstr.M{
+			"connections": int64(2),
+			"rejects":     int64(1),
+		},
+	}, events[0].MetricSetFields)
+
+	events, errs = mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+	assert.Equal(t, mapstr.M{
+		"total":    int64(0),
+		"sent":     int64(0),
+		"deferred": int64(0),
+		"bounced":  int64(0),
+		"expired":  int64(0),
+	}, events[0].MetricSetFields["deliveries"])
+}
+
+func TestFetchRotated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.log")
+	sent := "Apr  5 10:00:02 mail postfix/smtp[2003]: 3E5A81234: to=<user@example.org>, relay=mx.example.org[192.0.2.1]:25, delay=1, delays=0.1/0/0.4/0.5, dsn=2.0.0, status=sent (250 2.0.0 OK)\n"
+	require.NoError(t, os.WriteFile(path, []byte(sent+sent), 0o600))
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(path))
+	_, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+
+	// The new log is read from its beginning.
+	require.NoError(t, os.Rename(path, path+".1"))
+	require.NoError(t, os.WriteFile(path, []byte(sent), 0o600))
+
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(1), events[0].MetricSetFields["deliveries"].(mapstr.M)["sent"])
+
+	// An incomplete line is read when it is complete.
+	appendFile(t, path, []byte(sent[:20]))
+	events, errs = mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	assert.Equal(t, int64(0), events[0].MetricSetFields["deliveries"].(mapstr.M)["sent"])
+
+	appendFile(t, path, []byte(sent[20:]))
+	events, errs = mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	assert.Equal(t, int64(1), events[0].MetricSetFields["deliveries"].(mapstr.M)["sent"])
+}
+
+func TestFetchError(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(filepath.Join(t.TempDir(), "mail.log")))
+	_, errs := mbtest.ReportingFetchV2Error(f)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "error reading the log of Postfix")
+}
+
+func appendFile(t *testing.T, path string, content []byte) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = f.Write(content)
+	require.NoError(t, err)
+}
+
+func getConfig(path string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":        "postfix",
+		"metricsets":    []string{"smtp"},
+		"smtp.log_path": path,
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package broker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+)
+
+func TestFetch(t *testing.T) {
+	response, err := os.ReadFile(filepath.Join("_meta", "test", "load_report.json"))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, defaultPath, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+
+	expected := map[string]interface{}{
+		"url":                  "http://pulsar-broker-0:8080",
+		"service_url":          "pulsar://pulsar-broker-0:6650",
+		"version":              "3.2.2",
+		"topics":               int64(3),
+		"bundles":              int64(3),
+		"producers":            int64(4),
+		"consumers":            int64(4),
+		"msg_rate.in":          26.5,
+		"throughput.out.bytes": 19345.625,
+		"cpu.usage":            38.5,
+		"cpu.limit":            400.0,
+		"cpu.pct":              0.09625,
+		"memory.pct":           0.4030761718750,
+		"direct_memory.pct":    0.125,
+		"bandwidth.in.usage":   10506.25,
+		"bandwidth.out.limit":  1250000.0,
+		"last_update":          time.UnixMilli(1710238815376).UTC(),
+	}
+	for key, value := range expected {
+		actual, err := events[0].MetricSetFields.GetValue(key)
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, value, actual, key)
+		}
+	}
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "pulsar",
+		"metricsets": []string{"broker"},
+		"hosts":      []string{host},
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package broker
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var schema = s.Schema{
+	"url":         c.Str("webServiceUrl"),
+	"service_url": c.Str("pulsarServiceUrl", s.Optional),
+	"version":     c.Str("brokerVersionString", s.Optional),
+	"topics":      c.Int("numTopics"),
+	"bundles":     c.Int("numBundles"),
+	"producers":   c.Int("numProducers"),
+	"consumers":   c.Int("numConsumers"),
+	"msg_rate": s.Object{
+		"in":  c.Float("msgRateIn"),
+		"out": c.Float("msgRateOut"),
+	},
+	"throughput": s.Object{
+		"in":  s.Object{"bytes": c.Float("msgThroughputIn")},
+		"out": s.Object{"bytes": c.Float("msgThroughputOut")},
+	},
+}
+
+// resources are the resources of the host of the broker in the load report,
+// with their usage and limit.
+var resources = map[string]string{
+	"cpu":           "cpu",
+	"memory":        "memory",
+	"direct_memory": "directMemory",
+	"bandwidth.in":  "bandwidthIn",
+	"bandwidth.out": "bandwidthOut",
+}
+
+func eventMapping(r mb.ReporterV2, content []byte) error {
+	var data map[string]interface{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return fmt.Errorf("error parsing load report: %w", err)
+	}
+
+	fields, err := schema.Apply(data)
+	if err != nil {
+		return fmt.Errorf("error applying load report schema: %w", err)
+	}
+
+	for field, key := range resources {
+		resource, ok := data[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		usage, _ := resource["usage"].(float64)
+		limit, _ := resource["limit"].(float64)
+		value := mapstr.M{
+			"usage": usage,
+			"limit": limit,
+		}
+		if limit > 0 {
+			value["pct"] = usage / limit
+		}
+		_, _ = fields.Put(field, value)
+	}
+
+	if lastUpdate, ok := data["lastUpdate"].(float64); ok {
+		fields["last_update"] = time.UnixMilli(int64(lastUpdate)).UTC()
+	}
+
+	r.Event(mb.Event{MetricSetFields: fields})
+	return nil
+}
@@ -0,0 +1,23 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Code generated by beats/dev-tools/cmd/asset/asset.go - DO NOT EDIT.
+
+package pulsar
+
+import (
+	"github.com/elastic/beats/v7/libbeat/asset"
+)
+
+func init() {
+	if err := asset.SetFields("metricbeat", "pulsar", asset.ModuleFieldsPri, AssetPulsar); err != nil {
+		panic(err)
+	}
+}
+
+// AssetPulsar returns asset data.
+// This is the base64 encoded zlib format compressed contents of module/pulsar.
+func AssetPulsar() string {
+	return "eJzsmk1v20YQhu/6FQOdHfmuQwE7bdEUsWs0cXooCnlJjsmFlrvE7tCK8uuL5ZcoasUPmbLoooAQIEtq5tl3XpLDkT/AGrdLSFJhmJ4BECeBS5g/ZAvzGUCAxtc8Ia7kEn6aAQDcJMyPEPJzIFZBKnAGoFEgM7gED4nNAAwScRmaJfw9N0bMr2AeESXzf2YAzxxFYJZZuA8gWYw1CLtI2wSXEGqVJsWKA8R+nvKvPYGvJDEuDVCEECNp7hvwlRDoEwbwrFWcHWJBzCXcPHwC9dzYiqfVGrVZFMHrkHXQ/LRq2QULcKgHQOtG7OezYgFoTJQmS8f2wUouF1udL9Vib70EXON2o3TQONaCYz+Pf362KFa5DXpgUL9wH8ulQ7AdRnHq6lw4hTYDiF5QG67keDTf8oB9cpNKuG8aEXIhhJLhsLz3aeyhztJmYbOyYADetpPDS2UgcHyQIu4AkkSrIPVRj89SRQZfSZlf/6Q6gXwlTRqfA6iKPAwoNuFKM8IFd3vW+ExgsHoWitEwtDs0hoVoIEk9wU3U5IEENRj0lQw60FRK52ILUPAX1E03dbJRpFUaRklKCy4X3pbQDEV8VjpmtATXlzvwb7f0Cl1r7CqlC8GfKryfpI2I7idjD5CPD4+QWovu31uvgEuroY+SioMMPj487vO4H4910iz0wdEe2vZA3+E3BVwc5RE85nRmHvbCuGCewH1LHodK/JORSgsWpToN+lfNfKo9Xls2ARQxAm4y0Rcz125ijJXejuXP37/dQYQscdjz7nZqbvzNgk7JjnfsO4/T2KHg+3HjIXsPEwZco0+rcb34cxa0MPi7MOQ+8RSdGRzX9P1YtGUTPbzqMRlseEDRWD69R9oovd4FLpEiZWgfL/PtmgvlcTJH+45uI3O5OLeXP0lfxVyGtW0datpgOreLHUzVg7MVbGoO5ocbOW7c+l5s83zuyv+RUqiGVN5Cnbv0DqgepbdkU6u9OtxJ901LMEOrNAkYNSufb8RxoAPtK48xuzOJ2nBuw0yWCvJURx9fJZb91yTMx1nXffSEqeEXYmRK1fI5k/0f22WtTWVOnCnaUE5BT5qb3bO4erGrIK+AGXgilEzSdbX6tHDy5KeNR/Q1i3fAdIlZXpNhYiO1Ai/X4HKDtALDpF711V5se19Y+CqVNDrlK6E85q+FChcx+z4SWjVS4zJjKTKUaPX0eWMoWFj8kKIMdUwA33A4WVwh0xpOkjoQsVH0IVO/6Uwsh7NPYWI5QjUMKc1CbNmK4wp8xRa+8B/V07DIvU/sxqxuE4Ul+5L2dfj+vaISsUXZds5LyNmEb3KWjNn6WXszlms05U4sA7wCwdcIT4n9adMQSlpeXzebsuvs1Iu0Zllmd+IKb7zc92XIhkYDOtX8h9HxkG6zeCVPlX1HZ1+QuHTD7KraiJvXx1NKIJPDgP6KkCLU+wC7RNPoVCfcqLrRLtun9mH6v01tbVPfW5c6pNGbWJP6n+tROzcUFwU+5upXXHtuiTswjln4FRwD5HLDZVUeINCZzNkGN0C2t7SfG5m9oH0tWtk7quE/8M0vo5scAEytwS8dOEj8qb3guSmr52uxxb6gfS+wwe937ZiXELPI3YMyQRlwGa5YEKxQkuaj6flLHg02jNs/d7b28xA2mhOhrK41Z9X/HQAk/Ao6"
+}
@@ -0,0 +1,100 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package namespace
+
+import (
+	"sort"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/pulsar"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// namespaceStats are the stats of the topics of a namespace, summed.
+type namespaceStats struct {
+	name             string
+	tenant           string
+	topics           int64
+	producers        int64
+	subscriptions    int64
+	consumers        int64
+	msgRateIn        float64
+	msgRateOut       float64
+	msgThroughputIn  float64
+	msgThroughputOut float64
+	storageSize      int64
+	backlogSize      int64
+	backlog          int64
+	maxBacklog       int64
+}
+
+func eventsMapping(topics []pulsar.TopicStats) []mb.Event {
+	namespaces := map[string]*namespaceStats{}
+	for _, topic := range topics {
+		ns, found := namespaces[topic.Namespace]
+		if !found {
+			ns = &namespaceStats{name: topic.Namespace, tenant: topic.Tenant}
+			namespaces[topic.Namespace] = ns
+		}
+
+		backlog, maxBacklog := topic.Backlog()
+		ns.topics++
+		ns.producers += topic.ProducerCount
+		ns.subscriptions += int64(len(topic.Subscriptions))
+		ns.consumers += topic.Consumers()
+		ns.msgRateIn += topic.MsgRateIn
+		ns.msgRateOut += topic.MsgRateOut
+		ns.msgThroughputIn += topic.MsgThroughputIn
+		ns.msgThroughputOut += topic.MsgThroughputOut
+		ns.storageSize += topic.StorageSize
+		ns.backlogSize += topic.BacklogSize
+		ns.backlog += backlog
+		ns.maxBacklog = max(ns.maxBacklog, maxBacklog)
+	}
+
+	names := make([]string, 0, len(namespaces))
+	for name := range namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	events := make([]mb.Event, 0, len(names))
+	for _, name := range names {
+		events = append(events, eventMapping(namespaces[name]))
+	}
+	return events
+}
+
+func eventMapping(ns *namespaceStats) mb.Event {
+	fields := mapstr.M{
+		"name":      ns.name,
+		"tenant":    ns.tenant,
+		"topics":    ns.topics,
+		"producers": ns.producers,
+		"consumers": ns.consumers,
+		"subscriptions": mapstr.M{
+			"count": ns.subscriptions,
+			"backlog": mapstr.M{
+				"max": ns.maxBacklog,
+			},
+		},
+		"msg_rate": mapstr.M{
+			"in":  ns.msgRateIn,
+			"out": ns.msgRateOut,
+		},
+		"throughput": mapstr.M{
+			"in":  mapstr.M{"bytes": ns.msgThroughputIn},
+			"out": mapstr.M{"bytes": ns.msgThroughputOut},
+		},
+		"storage": mapstr.M{
+			"bytes": ns.storageSize,
+		},
+		"backlog": mapstr.M{
+			"messages": ns.backlog,
+			"bytes":    ns.backlogSize,
+		},
+	}
+	return mb.Event{MetricSetFields: fields}
+}
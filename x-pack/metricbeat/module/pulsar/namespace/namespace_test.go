@@ -0,0 +1,80 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package namespace
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/pulsar"
+)
+
+func TestFetch(t *testing.T) {
+	response, err := os.ReadFile(filepath.Join("..", "_meta", "test", "topics.json"))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, pulsar.TopicsPath, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 2)
+
+	expected := []map[string]interface{}{
+		{
+			"name":                      "public/default",
+			"tenant":                    "public",
+			"topics":                    int64(2),
+			"producers":                 int64(3),
+			"consumers":                 int64(3),
+			"subscriptions.count":       int64(2),
+			"subscriptions.backlog.max": int64(3880),
+			"msg_rate.in":               21.5,
+			"msg_rate.out":              35.25,
+			"throughput.in.bytes":       10570.25,
+			"storage.bytes":             int64(6144000),
+			"backlog.messages":          int64(4000),
+			"backlog.bytes":             int64(2048000),
+		},
+		{
+			"name":                      "tenant-a/analytics",
+			"tenant":                    "tenant-a",
+			"topics":                    int64(1),
+			"consumers":                 int64(1),
+			"subscriptions.backlog.max": int64(0),
+			"storage.bytes":             int64(256000),
+		},
+	}
+	for i, fields := range expected {
+		for key, value := range fields {
+			actual, err := events[i].MetricSetFields.GetValue(key)
+			if assert.NoError(t, err, key) {
+				assert.Equal(t, value, actual, key)
+			}
+		}
+	}
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "pulsar",
+		"metricsets": []string{"namespace"},
+		"hosts":      []string{host},
+	}
+}
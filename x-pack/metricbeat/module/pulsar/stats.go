@@ -0,0 +1,102 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package pulsar
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// TopicsPath is the path of the endpoint of the admin API with the stats of
+// the topics served by a broker.
+const TopicsPath = "/admin/v2/broker-stats/topics"
+
+// TopicStats are the stats of a topic served by a broker.
+type TopicStats struct {
+	// Name, Tenant, Namespace, Bundle and Persistent are set from the keys
+	// of the response the topic is in.
+	Name       string `json:"-"`
+	Tenant     string `json:"-"`
+	Namespace  string `json:"-"`
+	Bundle     string `json:"-"`
+	Persistent bool   `json:"-"`
+
+	ProducerCount          int64                        `json:"producerCount"`
+	AverageMsgSize         float64                      `json:"averageMsgSize"`
+	MsgRateIn              float64                      `json:"msgRateIn"`
+	MsgRateOut             float64                      `json:"msgRateOut"`
+	MsgThroughputIn        float64                      `json:"msgThroughputIn"`
+	MsgThroughputOut       float64                      `json:"msgThroughputOut"`
+	MsgInCount             int64                        `json:"msgInCount"`
+	MsgOutCount            int64                        `json:"msgOutCount"`
+	BytesInCount           int64                        `json:"bytesInCount"`
+	BytesOutCount          int64                        `json:"bytesOutCount"`
+	StorageSize            int64                        `json:"storageSize"`
+	BacklogSize            int64                        `json:"backlogSize"`
+	PendingAddEntriesCount int64                        `json:"pendingAddEntriesCount"`
+	Subscriptions          map[string]SubscriptionStats `json:"subscriptions"`
+}
+
+// SubscriptionStats are the stats of a subscription of a topic.
+type SubscriptionStats struct {
+	MsgBacklog       int64             `json:"msgBacklog"`
+	MsgRateOut       float64           `json:"msgRateOut"`
+	MsgThroughputOut float64           `json:"msgThroughputOut"`
+	MsgRateRedeliver float64           `json:"msgRateRedeliver"`
+	Consumers        []json.RawMessage `json:"consumers"`
+}
+
+// Consumers returns the number of consumers of the subscriptions of the topic.
+func (t TopicStats) Consumers() int64 {
+	var consumers int64
+	for _, sub := range t.Subscriptions {
+		consumers += int64(len(sub.Consumers))
+	}
+	return consumers
+}
+
+// Backlog returns the messages in the backlogs of the subscriptions of the
+// topic, and the backlog of the subscription that lags the most.
+func (t TopicStats) Backlog() (total int64, maxBacklog int64) {
+	for _, sub := range t.Subscriptions {
+		total += sub.MsgBacklog
+		if sub.MsgBacklog > maxBacklog {
+			maxBacklog = sub.MsgBacklog
+		}
+	}
+	return total, maxBacklog
+}
+
+// ParseTopicStats parses the stats of the topics of a broker, reported by
+// namespace, bundle and domain of the topics. Topics are sorted by name.
+func ParseTopicStats(content []byte) ([]TopicStats, error) {
+	var namespaces map[string]map[string]map[string]map[string]TopicStats
+	if err := json.Unmarshal(content, &namespaces); err != nil {
+		return nil, err
+	}
+
+	var topics []TopicStats
+	for namespace, bundles := range namespaces {
+		// Namespaces are named after their tenant, as tenant/namespace.
+		tenant, _, _ := strings.Cut(namespace, "/")
+		for bundle, domains := range bundles {
+			for domain, stats := range domains {
+				for name, topic := range stats {
+					topic.Name = name
+					topic.Tenant = tenant
+					topic.Namespace = namespace
+					topic.Bundle = bundle
+					topic.Persistent = domain == "persistent"
+					topics = append(topics, topic)
+				}
+			}
+		}
+	}
+	sort.Slice(topics, func(i, j int) bool {
+		return topics[i].Name < topics[j].Name
+	})
+	return topics, nil
+}
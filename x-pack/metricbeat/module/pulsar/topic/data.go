@@ -0,0 +1,60 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package topic
+
+import (
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/pulsar"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func eventMapping(topic pulsar.TopicStats) mb.Event {
+	backlog, maxBacklog := topic.Backlog()
+
+	return mb.Event{
+		MetricSetFields: mapstr.M{
+			"name":       topic.Name,
+			"tenant":     topic.Tenant,
+			"namespace":  topic.Namespace,
+			"bundle":     topic.Bundle,
+			"persistent": topic.Persistent,
+			"producers":  topic.ProducerCount,
+			"consumers":  topic.Consumers(),
+			"subscriptions": mapstr.M{
+				"count": len(topic.Subscriptions),
+				"backlog": mapstr.M{
+					"max": maxBacklog,
+				},
+			},
+			"msg_rate": mapstr.M{
+				"in":  topic.MsgRateIn,
+				"out": topic.MsgRateOut,
+			},
+			"throughput": mapstr.M{
+				"in":  mapstr.M{"bytes": topic.MsgThroughputIn},
+				"out": mapstr.M{"bytes": topic.MsgThroughputOut},
+			},
+			"messages": mapstr.M{
+				"in":  topic.MsgInCount,
+				"out": topic.MsgOutCount,
+			},
+			"bytes": mapstr.M{
+				"in":  topic.BytesInCount,
+				"out": topic.BytesOutCount,
+			},
+			"average_msg_size": mapstr.M{
+				"bytes": topic.AverageMsgSize,
+			},
+			"storage": mapstr.M{
+				"bytes": topic.StorageSize,
+			},
+			"backlog": mapstr.M{
+				"messages": backlog,
+				"bytes":    topic.BacklogSize,
+			},
+			"pending_add_entries": topic.PendingAddEntriesCount,
+		},
+	}
+}
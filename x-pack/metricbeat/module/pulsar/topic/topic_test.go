@@ -0,0 +1,90 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package topic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/pulsar"
+)
+
+func TestFetch(t *testing.T) {
+	response, err := os.ReadFile(filepath.Join("..", "_meta", "test", "topics.json"))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, pulsar.TopicsPath, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 3)
+
+	expected := []map[string]interface{}{
+		{
+			"name":                "non-persistent://public/default/heartbeats",
+			"tenant":              "public",
+			"namespace":           "public/default",
+			"bundle":              "0x40000000_0x80000000",
+			"persistent":          false,
+			"producers":           int64(1),
+			"subscriptions.count": 0,
+			"backlog.messages":    int64(0),
+		},
+		{
+			"name":                      "persistent://public/default/orders",
+			"persistent":                true,
+			"producers":                 int64(2),
+			"consumers":                 int64(3),
+			"subscriptions.count":       2,
+			"subscriptions.backlog.max": int64(3880),
+			"msg_rate.in":               20.5,
+			"msg_rate.out":              35.25,
+			"throughput.in.bytes":       10506.25,
+			"messages.in":               int64(10000),
+			"bytes.out":                 int64(8200000),
+			"average_msg_size.bytes":    512.5,
+			"storage.bytes":             int64(6144000),
+			"backlog.messages":          int64(4000),
+			"backlog.bytes":             int64(2048000),
+			"pending_add_entries":       int64(1),
+		},
+		{
+			"name":      "persistent://tenant-a/analytics/clicks",
+			"tenant":    "tenant-a",
+			"namespace": "tenant-a/analytics",
+		},
+	}
+	for i, fields := range expected {
+		for key, value := range fields {
+			actual, err := events[i].MetricSetFields.GetValue(key)
+			if assert.NoError(t, err, key) {
+				assert.Equal(t, value, actual, key)
+			}
+		}
+	}
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "pulsar",
+		"metricsets": []string{"topic"},
+		"hosts":      []string{host},
+	}
+}
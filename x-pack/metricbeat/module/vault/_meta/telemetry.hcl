@@ -0,0 +1,4 @@
+telemetry {
+  prometheus_retention_time = "30s"
+  disable_hostname          = true
+}
@@ -0,0 +1,177 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package vault
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+const (
+	tokenHeader     = "X-Vault-Token"
+	namespaceHeader = "X-Vault-Namespace"
+
+	defaultAppRoleMountPath = "approle"
+
+	// tokenRenewRatio is the part of the lease of the tokens obtained with
+	// AppRole after which a new one is obtained, so they don't expire while in use.
+	tokenRenewRatio = 0.8
+
+	// noLeaseTokenTTL is how long tokens without lease are kept.
+	noLeaseTokenTTL = time.Hour
+)
+
+// Config contains the authentication settings of the module.
+type Config struct {
+	Token     string         `config:"token"`
+	AppRole   *AppRoleConfig `config:"approle"`
+	Namespace string         `config:"namespace"`
+}
+
+// AppRoleConfig contains the settings to log in with the AppRole auth method.
+type AppRoleConfig struct {
+	RoleID    string `config:"role_id" validate:"required"`
+	SecretID  string `config:"secret_id"`
+	MountPath string `config:"mount_path"`
+}
+
+// Validate checks that only one authentication method is configured.
+func (c *Config) Validate() error {
+	if c.Token != "" && c.AppRole != nil {
+		return errors.New("only one of token and approle can be configured")
+	}
+	return nil
+}
+
+// Auth authenticates the requests of a metricset to Vault, with the token of
+// the configuration, or with a token obtained by logging in with AppRole.
+// Tokens obtained with AppRole are shared by the metricsets of the module
+// that monitor the same host, through the cache of the module.
+type Auth struct {
+	config   Config
+	cache    *mb.ModuleCache
+	cacheKey string
+	login    *helper.HTTP
+}
+
+// loginResponse is the response of the login endpoints of Vault.
+type loginResponse struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int64  `json:"lease_duration"`
+	} `json:"auth"`
+}
+
+// NewAuth creates the Auth of a metricset from the configuration of its module.
+func NewAuth(base mb.BaseMetricSet) (*Auth, error) {
+	var config Config
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	a := &Auth{config: config}
+	if config.AppRole == nil {
+		return a, nil
+	}
+
+	mountPath := strings.Trim(config.AppRole.MountPath, "/")
+	if mountPath == "" {
+		mountPath = defaultAppRoleMountPath
+	}
+	loginURI, err := url.Parse(base.HostData().SanitizedURI)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing host URI: %w", err)
+	}
+	loginURI.Path = "/v1/auth/" + mountPath + "/login"
+	loginURI.RawQuery = ""
+
+	body, err := json.Marshal(map[string]string{
+		"role_id":   config.AppRole.RoleID,
+		"secret_id": config.AppRole.SecretID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	login, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+	login.SetMethod("POST")
+	login.SetURI(loginURI.String())
+	login.SetBody(body)
+	login.SetHeader("Content-Type", "application/json")
+	if config.Namespace != "" {
+		login.SetHeader(namespaceHeader, config.Namespace)
+	}
+
+	a.cache = mb.ModuleCacheOf(base.Module())
+	a.cacheKey = "vault.approle.token." + loginURI.String()
+	a.login = login
+	return a, nil
+}
+
+// SetHeaders sets the token and the namespace in the headers of the requests
+// made with http. With AppRole, it logs in if there is no valid token.
+func (a *Auth) SetHeaders(http *helper.HTTP) error {
+	if a.config.Namespace != "" {
+		http.SetHeader(namespaceHeader, a.config.Namespace)
+	}
+
+	token := a.config.Token
+	if a.login != nil {
+		var err error
+		if token, err = a.appRoleToken(); err != nil {
+			return err
+		}
+	}
+	if token != "" {
+		http.SetHeader(tokenHeader, token)
+	}
+	return nil
+}
+
+// Invalidate discards the token obtained with AppRole, so a new one is
+// obtained on the next call to SetHeaders. It returns false if the token
+// can't be renewed, because it is not obtained with AppRole.
+func (a *Auth) Invalidate() bool {
+	if a.login == nil {
+		return false
+	}
+	a.cache.Delete(a.cacheKey)
+	return true
+}
+
+func (a *Auth) appRoleToken() (string, error) {
+	if token, found := a.cache.Get(a.cacheKey); found {
+		return token.(string), nil
+	}
+
+	content, err := a.login.FetchContent()
+	if err != nil {
+		return "", fmt.Errorf("error logging in with AppRole: %w", err)
+	}
+	var response loginResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return "", fmt.Errorf("error parsing AppRole login response: %w", err)
+	}
+	if response.Auth.ClientToken == "" {
+		return "", errors.New("AppRole login response doesn't contain a token")
+	}
+
+	ttl := noLeaseTokenTTL
+	if response.Auth.LeaseDuration > 0 {
+		ttl = time.Duration(float64(response.Auth.LeaseDuration) * tokenRenewRatio * float64(time.Second))
+	}
+	a.cache.Put(a.cacheKey, response.Auth.ClientToken, ttl)
+	return response.Auth.ClientToken, nil
+}
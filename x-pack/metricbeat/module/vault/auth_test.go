@@ -0,0 +1,53 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cases := map[string]struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		"no auth": {
+			config: map[string]interface{}{},
+			valid:  true,
+		},
+		"token": {
+			config: map[string]interface{}{"token": "hvs.root"},
+			valid:  true,
+		},
+		"approle": {
+			config: map[string]interface{}{"approle.role_id": "metricbeat", "approle.secret_id": "s3cr3t"},
+			valid:  true,
+		},
+		"approle without role id": {
+			config: map[string]interface{}{"approle.secret_id": "s3cr3t"},
+		},
+		"token and approle": {
+			config: map[string]interface{}{"token": "hvs.root", "approle.role_id": "metricbeat"},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var config Config
+			err := conf.MustNewConfigFrom(c.config).Unpack(&config)
+			if c.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Code generated by beats/dev-tools/cmd/asset/asset.go - DO NOT EDIT.
+
+package vault
+
+import (
+	"github.com/elastic/beats/v7/libbeat/asset"
+)
+
+func init() {
+	if err := asset.SetFields("metricbeat", "vault", asset.ModuleFieldsPri, AssetVault); err != nil {
+		panic(err)
+	}
+}
+
+// AssetVault returns asset data.
+// This is the base64 encoded zlib format compressed contents of module/vault.
+func AssetVault() string {
+	return "eJzsm01v2zgTx+/+FIOcU6GXHuLDc2mCpwWyRbFNu4fFwqbFiUWYIrXkyIH20y+oF1u2aVvOUtlsSkSHWC+cH0ecPwcU5x2ssJrCmpWSJgAkSOIUrn6431cTAI42NaIgodUU/jcBAPjEbCY+alNAfRfkmpcSJwAGJTKLU1ggsQmARSKhlnYKv19ZK6+u4SojKq7+mAA8CpTcTusG34FiOW4h3B9VBU5haXRZtGc8JO6Y10/NIdWKmFAWKEPIkYxILaRaSkwJOTwane+TJ20jfZY+T4ZMUrY57YMCOOw2wElgd3yqWwb9CKx1otIcOx4fU5/LEqPS7lzq2FZYPWnD966dIHHHN2KEDsa5zoFcg1b1iTlLSaxxfg1zS0zxReX+LdA8apMzleKsd9oik8jnoA3MSyWUIMGk+Av5PPF2o3fHHlLTl4XWEpm6rC+/ZUgZmk1XQNi+IT9JQz4uRGPjiP3GieMCCNXZ8UN43uq4QKxv8jTbGo0VWoUb8j+aBt0Yb+LPlEoJtQShNox+FFSEpjDC4ojeMaWyLdjdxp6fJ5WlJTSJ+xXOP19YvlGE1kJfIE6jCB4O5PPtxRgGCylS5rqW9EZYkmse0ENfe2O3Z9HNhvueO4/JTWC6W2GZMw0GU71GUz2P0aJZo5mRODK2OCO8DOxB5Hj+FaZSp6uZXeFTkvsnOqnV8jLLH12b4NqEJ0FZ3flmemvnPKEgF1IKi6lW3PrJMM30jJemeW/B4G7bFjvXSGYJMmSGFsgISF+A26G2SdAY+ctHh4scCCU6M9Um43pmSpNqg0mpXmIi7qwkx0GaYTEuxt77HBSONdxrnqcN/lmiJX9U7I+7ATi/tu1BxhSXyGFRbdB2CfzDa9d5paLJzqXTITuAzx1fynzRTEu6wCaKbXKUwpb5oWpsMbguFxKfB/KgiUnge0qyhTqjcH3K4sP7sSg/vKfMDagUFQm5mQvCYN+Mhn0zKvbNaNg3YbE7ZKmXQs1Ch/u9axVMDPoY9DHoX1/QL5gxAk2yRAoV8f+/e+hxdGStoWugjBGgSk1VUJMycUasu82SNmzpbk9XqHjUhqgNURv+ZW0oymDa8PV71IaoDVEb3ow2SGGDicP9529RHaI6RHV4M+rAUSJhKH24vbu/e7jr0USFiAoRFeI/qRD19hqb+GLqaDydwd3GUdu41zDpFaqxDLeNew2bUrlvrgmTUqfJoiK0Q+3XH2poCr6HzrD9grk2FdRGGZ1aeN3ntJV9aUq9cNu9up1dvXGllmArS5gPx19qo0sSajj/4Le8bdqPwEouKJF62S2mzx6ZkKUZAaVrGJ6McPvytgvt3edlxwIc1yIdgmsLrSy+KG9j8hLgdh4PlVbcM0KVCrSHYubOsH+aN7SPHVw/vRlkAPj+pp49zmuQYoUwN+yRmh18qVa2lPPkKOrhKvBpzw6E9K8In3HrKdf2mX1KfnbAXgC/O4C33UhOUh1N0wZMyxeABUjZBqVtYanHSOEGpXFhu3EzejduXqQbgVO8nS4cLB0HEZSv36OgREGJgvLzCYpnvTmIohxZe46SEiUlSsrblhTvInUQUTm6YB1lJcpKlJW3JSsduqtEmB2UcwYr0/iGTLbVos+tNXUsE59Ln1WW9FAVG8e5vnfLTjZjuTDNwhN7sqvcxprRMzWjlBm0mZZ8MlDLz9jfavcKK7AZM2hBIXJX5qPbqpkN3RGf1E+NCOQ3Wxi9NGjHNAyF0WvhfNEWiKalMaio9curr1pdlELymadc77l1fLe9kvG68Q2UHyAXy0YWw0cMq+Nka6Etdu5GhZ+nq4ucuWfDM3VvA0r3uWJThLnC6ghPm+XNRtTb3TyyO318zPzclcV/DwBauT7H"
+}
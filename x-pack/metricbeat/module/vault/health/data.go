@@ -0,0 +1,78 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+var schema = s.Schema{
+	"initialized":         c.Bool("initialized"),
+	"sealed":              c.Bool("sealed"),
+	"standby":             c.Bool("standby"),
+	"performance_standby": c.Bool("performance_standby", s.Optional),
+	"version":             c.Str("version"),
+	"enterprise":          c.Bool("enterprise", s.Optional),
+	"cluster": s.Object{
+		"name": c.Str("cluster_name", s.Optional),
+		"id":   c.Str("cluster_id", s.Optional),
+	},
+	"replication": s.Object{
+		"performance": s.Object{
+			"mode": c.Str("replication_performance_mode", s.Optional),
+		},
+		"dr": s.Object{
+			"mode": c.Str("replication_dr_mode", s.Optional),
+		},
+	},
+	"clock_skew": s.Object{
+		"ms": c.Int("clock_skew_ms", s.Optional),
+	},
+	"echo_duration": s.Object{
+		"ms": c.Int("echo_duration_ms", s.Optional),
+	},
+}
+
+func eventMapping(r mb.ReporterV2, content []byte) error {
+	var data map[string]interface{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return fmt.Errorf("error parsing health response: %w", err)
+	}
+
+	fields, err := schema.Apply(data)
+	if err != nil {
+		return fmt.Errorf("error applying health schema: %w", err)
+	}
+	fields["status"] = status(fields)
+	if serverTime, ok := data["server_time_utc"].(float64); ok {
+		fields["server_time"] = time.Unix(int64(serverTime), 0).UTC()
+	}
+
+	r.Event(mb.Event{MetricSetFields: fields})
+	return nil
+}
+
+// status summarizes the state of the node like the status codes of the health
+// endpoint do by default.
+func status(fields map[string]interface{}) string {
+	switch {
+	case fields["initialized"] == false:
+		return "uninitialized"
+	case fields["sealed"] == true:
+		return "sealed"
+	case fields["performance_standby"] == true:
+		return "performance_standby"
+	case fields["standby"] == true:
+		return "standby"
+	default:
+		return "active"
+	}
+}
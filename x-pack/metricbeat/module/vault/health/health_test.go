@@ -0,0 +1,85 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+)
+
+func TestFetch(t *testing.T) {
+	cases := map[string]map[string]interface{}{
+		"health.json": {
+			"status":              "active",
+			"initialized":         true,
+			"sealed":              false,
+			"standby":             false,
+			"performance_standby": false,
+			"version":             "1.15.6",
+			"enterprise":          false,
+			"cluster.name":        "vault-cluster-4d2a1f3e",
+			"replication.dr.mode": "disabled",
+			"clock_skew.ms":       int64(0),
+			"server_time":         time.Unix(1712345678, 0).UTC(),
+		},
+		"health-sealed.json": {
+			"status":                       "sealed",
+			"sealed":                       true,
+			"replication.performance.mode": "unknown",
+		},
+	}
+
+	for file, expected := range cases {
+		t.Run(file, func(t *testing.T) {
+			response, err := os.ReadFile(filepath.Join("_meta", "test", file))
+			require.NoError(t, err)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, defaultPath, r.URL.Path)
+				assert.Equal(t, "200", r.URL.Query().Get("sealedcode"))
+				w.Header().Set("Content-Type", "application/json")
+				w.Write(response)
+			}))
+			defer server.Close()
+
+			f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
+			events, errs := mbtest.ReportingFetchV2Error(f)
+			require.Empty(t, errs)
+			require.Len(t, events, 1)
+
+			for key, value := range expected {
+				actual, err := events[0].MetricSetFields.GetValue(key)
+				if assert.NoError(t, err, key) {
+					assert.Equal(t, value, actual, key)
+				}
+			}
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	assert.Equal(t, "uninitialized", status(map[string]interface{}{"initialized": false, "sealed": true}))
+	assert.Equal(t, "standby", status(map[string]interface{}{"initialized": true, "sealed": false, "standby": true}))
+	assert.Equal(t, "performance_standby", status(map[string]interface{}{"initialized": true, "standby": true, "performance_standby": true}))
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "vault",
+		"metricsets": []string{"health"},
+		"hosts":      []string{host},
+	}
+}
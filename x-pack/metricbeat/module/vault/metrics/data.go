@@ -0,0 +1,190 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// nodeMetrics maps the metrics of the node to the fields of its event.
+var nodeMetrics = map[string]string{
+	"vault_core_unsealed":              "core.unsealed",
+	"vault_core_active":                "core.active",
+	"vault_core_performance_standby":   "core.performance_standby",
+	"vault_core_handle_request":        "requests",
+	"vault_core_handle_login_request":  "login_requests",
+	"vault_expire_num_leases":          "leases.count",
+	"vault_token_count":                "tokens.count",
+	"vault_runtime_alloc_bytes":        "runtime.alloc.bytes",
+	"vault_runtime_sys_bytes":          "runtime.sys.bytes",
+	"vault_runtime_num_goroutines":     "runtime.goroutines",
+	"vault_audit_log_request_failure":  "audit.log_request_failures",
+	"vault_audit_log_response_failure": "audit.log_response_failures",
+	"vault_barrier_get":                "barrier.get",
+	"vault_barrier_put":                "barrier.put",
+	"vault_barrier_list":               "barrier.list",
+	"vault_barrier_delete":             "barrier.delete",
+}
+
+// booleanMetrics are the gauges of the node reported as booleans.
+var booleanMetrics = map[string]bool{
+	"vault_core_unsealed":            true,
+	"vault_core_active":              true,
+	"vault_core_performance_standby": true,
+}
+
+// storageBackends maps the prefixes of the metrics of the storage backends to
+// their names. The names match the storage type reported by the seal status.
+var storageBackends = map[string]string{
+	"vault_azure_":        "azure",
+	"vault_cassandra_":    "cassandra",
+	"vault_cockroachdb_":  "cockroachdb",
+	"vault_consul_":       "consul",
+	"vault_couchdb_":      "couchdb",
+	"vault_dynamodb_":     "dynamodb",
+	"vault_etcd_":         "etcd",
+	"vault_file_":         "file",
+	"vault_gcs_":          "gcs",
+	"vault_mssql_":        "mssql",
+	"vault_mysql_":        "mysql",
+	"vault_postgres_":     "postgresql",
+	"vault_raft_storage_": "raft",
+	"vault_s3_":           "s3",
+	"vault_spanner_":      "spanner",
+	"vault_swift_":        "swift",
+	"vault_zookeeper_":    "zookeeper",
+}
+
+// storageOperations are the operations of the storage backends.
+var storageOperations = []string{"get", "put", "list", "delete"}
+
+// quantiles maps the quantiles of the summaries to their fields.
+var quantiles = map[float64]string{
+	0.5:  "p50",
+	0.9:  "p90",
+	0.99: "p99",
+}
+
+func eventsMapping(r mb.ReporterV2, families []*prometheus.MetricFamily) {
+	node := mapstr.M{}
+	storage := map[string]mapstr.M{}
+
+	for _, family := range families {
+		name := family.GetName()
+		if field, found := nodeMetrics[name]; found {
+			if value, ok := familyValue(family, booleanMetrics[name]); ok {
+				node.Put(field, value)
+			}
+			continue
+		}
+
+		backend, operation, found := storageMetric(name)
+		if !found {
+			continue
+		}
+		if value, ok := familyValue(family, false); ok {
+			if storage[backend] == nil {
+				storage[backend] = mapstr.M{"backend": backend}
+			}
+			storage[backend][operation] = value
+		}
+	}
+
+	if len(node) > 0 {
+		r.Event(mb.Event{MetricSetFields: node})
+	}
+
+	// Backends are reported in order, to make the events predictable.
+	backends := make([]string, 0, len(storage))
+	for backend := range storage {
+		backends = append(backends, backend)
+	}
+	sort.Strings(backends)
+	for _, backend := range backends {
+		r.Event(mb.Event{MetricSetFields: mapstr.M{"storage": storage[backend]}})
+	}
+}
+
+// storageMetric returns the backend and the operation of the latency metric of
+// a storage backend.
+func storageMetric(name string) (backend, operation string, found bool) {
+	for prefix, backend := range storageBackends {
+		operation, found := strings.CutPrefix(name, prefix)
+		if !found {
+			continue
+		}
+		for _, op := range storageOperations {
+			if operation == op {
+				return backend, operation, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// familyValue returns the value of a metric family. The values of gauges and
+// counters with several series, like the count of tokens of each auth method,
+// are added up. Only the first series of summaries is used.
+func familyValue(family *prometheus.MetricFamily, boolean bool) (interface{}, bool) {
+	var sum float64
+	var found bool
+	for _, metric := range family.Metric {
+		if summary := metric.GetSummary(); summary != nil {
+			return summaryValue(summary), true
+		}
+
+		var value float64
+		switch {
+		case metric.GetGauge() != nil:
+			value = metric.GetGauge().GetValue()
+		case metric.GetCounter() != nil:
+			value = metric.GetCounter().GetValue()
+		case metric.GetUnknown() != nil:
+			value = metric.GetUnknown().GetValue()
+		default:
+			continue
+		}
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		sum += value
+		found = true
+	}
+	if !found {
+		return nil, false
+	}
+	if boolean {
+		return sum == 1, true
+	}
+	if sum == math.Trunc(sum) {
+		return int64(sum), true
+	}
+	return sum, true
+}
+
+// summaryValue returns the count, sum and quantiles of a summary of durations,
+// that Vault measures in milliseconds.
+func summaryValue(summary *prometheus.Summary) mapstr.M {
+	value := mapstr.M{
+		"count": int64(summary.GetSampleCount()),
+	}
+	if sum := summary.GetSampleSum(); !math.IsNaN(sum) && !math.IsInf(sum, 0) {
+		value["sum"] = mapstr.M{"ms": sum}
+	}
+	for _, quantile := range summary.GetQuantile() {
+		field, found := quantiles[quantile.GetQuantile()]
+		if !found || math.IsNaN(quantile.GetValue()) || math.IsInf(quantile.GetValue(), 0) {
+			continue
+		}
+		value[field] = mapstr.M{"ms": quantile.GetValue()}
+	}
+	return value
+}
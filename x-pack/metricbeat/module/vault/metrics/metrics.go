@@ -0,0 +1,95 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/vault"
+)
+
+const (
+	defaultScheme      = "http"
+	defaultPath        = "/v1/sys/metrics"
+	defaultQueryParams = "format=prometheus"
+
+	// contentType is the format of the metrics returned by Vault with
+	// format=prometheus.
+	contentType = "text/plain"
+)
+
+var (
+	hostParser = parse.URLHostParserBuilder{
+		DefaultScheme: defaultScheme,
+		DefaultPath:   defaultPath,
+		QueryParams:   defaultQueryParams,
+	}.Build()
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("vault", "metrics", New,
+		mb.WithHostParser(hostParser),
+	)
+}
+
+// MetricSet collects a curated set of the telemetry metrics of a Vault node.
+type MetricSet struct {
+	mb.BaseMetricSet
+	http *helper.HTTP
+	auth *vault.Auth
+}
+
+// New creates a new instance of the metrics metricset.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	auth, err := vault.NewAuth(base)
+	if err != nil {
+		return nil, err
+	}
+
+	http, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		http:          http,
+		auth:          auth,
+	}, nil
+}
+
+// Fetch reports an event with the metrics of the node, and an event with the
+// latencies of each storage backend.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	content, err := m.fetch()
+	// Tokens obtained with AppRole can be revoked before they expire, a new
+	// one is obtained once if the request is rejected.
+	if mb.ErrorCategoryOf(err) == mb.ErrorCategoryAuth && m.auth.Invalidate() {
+		content, err = m.fetch()
+	}
+	if err != nil {
+		return fmt.Errorf("error in http fetch: %w", err)
+	}
+
+	families, err := prometheus.ParseMetricFamilies(content, contentType, time.Now(), m.Logger())
+	if err != nil {
+		return fmt.Errorf("error parsing metrics: %w", err)
+	}
+
+	eventsMapping(reporter, families)
+	return nil
+}
+
+func (m *MetricSet) fetch() ([]byte, error) {
+	if err := m.auth.SetHeaders(m.http); err != nil {
+		return nil, err
+	}
+	return m.http.FetchContent()
+}
@@ -0,0 +1,164 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+)
+
+// newServer returns a server that replies with the metrics of the fixture to
+// the requests with a valid token.
+func newServer(t *testing.T, validToken func(token string) bool) (*httptest.Server, *int32) {
+	response, err := os.ReadFile(filepath.Join("_meta", "test", "metrics.txt"))
+	require.NoError(t, err)
+
+	var logins int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/approle/login":
+			var body map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "metricbeat", body["role_id"])
+			assert.Equal(t, "s3cr3t", body["secret_id"])
+			n := atomic.AddInt32(&logins, 1)
+			fmt.Fprintf(w, `{"auth": {"client_token": "hvs.approle%d", "lease_duration": 3600, "renewable": true}}`, n)
+		case defaultPath:
+			assert.Equal(t, "prometheus", r.URL.Query().Get("format"))
+			if !validToken(r.Header.Get("X-Vault-Token")) {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"errors":["permission denied"]}`))
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+			w.Write(response)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server, &logins
+}
+
+func TestFetch(t *testing.T) {
+	server, _ := newServer(t, func(token string) bool { return token == "hvs.root" })
+	defer server.Close()
+
+	config := getConfig(server.URL)
+	config["token"] = "hvs.root"
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 2)
+
+	node := events[0].MetricSetFields
+	expected := map[string]interface{}{
+		"core.unsealed":               true,
+		"core.active":                 true,
+		"core.performance_standby":    false,
+		"requests.count":              int64(251),
+		"requests.sum.ms":             96.4,
+		"requests.p99.ms":             2.0183,
+		"login_requests.p50.ms":       1.384,
+		"leases.count":                int64(14),
+		"tokens.count":                int64(4),
+		"runtime.alloc.bytes":         int64(30914544),
+		"runtime.goroutines":          int64(187),
+		"audit.log_response_failures": int64(2),
+		"barrier.get.p90.ms":          0.0458,
+		"barrier.put.count":           int64(17),
+	}
+	for key, value := range expected {
+		actual, err := node.GetValue(key)
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, value, actual, key)
+		}
+	}
+	// Quantiles without observations are not reported.
+	_, err := node.GetValue("barrier.put.p50")
+	assert.Error(t, err)
+
+	storage := events[1].MetricSetFields
+	expected = map[string]interface{}{
+		"storage.backend":     "raft",
+		"storage.get.count":   int64(298),
+		"storage.put.p99.ms":  6.337,
+		"storage.list.sum.ms": 1.31,
+	}
+	for key, value := range expected {
+		actual, err := storage.GetValue(key)
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, value, actual, key)
+		}
+	}
+	_, err = storage.GetValue("storage.stats_applied_index")
+	assert.Error(t, err)
+}
+
+func TestFetchAppRole(t *testing.T) {
+	var revoked atomic.Bool
+	server, logins := newServer(t, func(token string) bool {
+		if revoked.Load() {
+			return token == "hvs.approle2"
+		}
+		return token == "hvs.approle1"
+	})
+	defer server.Close()
+
+	config := getConfig(server.URL)
+	config["approle"] = map[string]interface{}{
+		"role_id":   "metricbeat",
+		"secret_id": "s3cr3t",
+	}
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	assert.NotEmpty(t, events)
+
+	// The token is kept between fetches.
+	_, errs = mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	assert.EqualValues(t, 1, atomic.LoadInt32(logins))
+
+	// A new token is obtained when the current one is rejected.
+	revoked.Store(true)
+	events, errs = mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	assert.NotEmpty(t, events)
+	assert.EqualValues(t, 2, atomic.LoadInt32(logins))
+}
+
+func TestFetchForbidden(t *testing.T) {
+	server, _ := newServer(t, func(token string) bool { return false })
+	defer server.Close()
+
+	config := getConfig(server.URL)
+	config["token"] = "hvs.invalid"
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	assert.Empty(t, events)
+	assert.NotEmpty(t, errs)
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "vault",
+		"metricsets": []string{"metrics"},
+		"hosts":      []string{host},
+	}
+}
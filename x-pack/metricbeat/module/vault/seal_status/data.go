@@ -0,0 +1,52 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package seal_status
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+var schema = s.Schema{
+	"type":          c.Str("type"),
+	"initialized":   c.Bool("initialized"),
+	"sealed":        c.Bool("sealed"),
+	"threshold":     c.Int("t"),
+	"shares":        c.Int("n"),
+	"progress":      c.Int("progress"),
+	"version":       c.Str("version"),
+	"migration":     c.Bool("migration", s.Optional),
+	"recovery_seal": c.Bool("recovery_seal", s.Optional),
+	"storage_type":  c.Str("storage_type", s.Optional),
+	"cluster": s.Object{
+		"name": c.Str("cluster_name", s.Optional),
+		"id":   c.Str("cluster_id", s.Optional),
+	},
+}
+
+func eventMapping(r mb.ReporterV2, content []byte) error {
+	var data map[string]interface{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return fmt.Errorf("error parsing seal status response: %w", err)
+	}
+
+	fields, err := schema.Apply(data)
+	if err != nil {
+		return fmt.Errorf("error applying seal status schema: %w", err)
+	}
+	if buildDate, ok := data["build_date"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, buildDate); err == nil {
+			fields["build_date"] = t
+		}
+	}
+
+	r.Event(mb.Event{MetricSetFields: fields})
+	return nil
+}
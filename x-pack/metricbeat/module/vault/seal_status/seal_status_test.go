@@ -0,0 +1,64 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package seal_status
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+)
+
+func TestFetch(t *testing.T) {
+	response, err := os.ReadFile(filepath.Join("_meta", "test", "seal_status.json"))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, defaultPath, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(server.URL))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+
+	expected := map[string]interface{}{
+		"type":         "shamir",
+		"sealed":       true,
+		"threshold":    int64(3),
+		"shares":       int64(5),
+		"progress":     int64(1),
+		"storage_type": "raft",
+		"build_date":   time.Date(2024, 2, 28, 17, 7, 34, 0, time.UTC),
+	}
+	for key, value := range expected {
+		actual, err := events[0].MetricSetFields.GetValue(key)
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, value, actual, key)
+		}
+	}
+	_, err = events[0].MetricSetFields.GetValue("nonce")
+	assert.Error(t, err)
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "vault",
+		"metricsets": []string{"seal_status"},
+		"hosts":      []string{host},
+	}
+}